@@ -15,6 +15,7 @@ import (
 	"github.com/depot/cli/pkg/cleanup"
 	"github.com/depot/cli/pkg/cmd/root"
 	"github.com/depot/cli/pkg/config"
+	"github.com/depot/cli/pkg/debuglog"
 	"github.com/depot/cli/pkg/helpers"
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli-plugins/manager"
@@ -84,6 +85,7 @@ func runMain() int {
 	}
 
 	defer cleanup.CleanupTmpfiles()
+	defer debuglog.Close()
 
 	buildVersion := build.Version
 	buildDate := build.Date
@@ -63,6 +63,31 @@ func CheckForUpdate(stateFilePath, currentVersion string) (*api.ReleaseResponse,
 	return nil, nil
 }
 
+// LatestRelease returns the latest release, using the release of the last
+// update check if it was less than an hour ago.
+func LatestRelease(stateFilePath string) (*api.ReleaseResponse, error) {
+	state, _ := readStateFile(stateFilePath)
+	if state != nil && state.LatestRelease != nil && time.Since(state.CheckedForUpdateAt) < time.Hour*1 {
+		return state.LatestRelease, nil
+	}
+
+	release, err := api.LatestRelease()
+	if err != nil {
+		return nil, err
+	}
+
+	state = &StateEntry{CheckedForUpdateAt: time.Now(), LatestRelease: release}
+	if err := writeStateFile(stateFilePath, state); err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+// IsNewer returns true if the release is newer than the current version.
+func IsNewer(release *api.ReleaseResponse, currentVersion string) bool {
+	return release != nil && versionGreaterThan(release.Version, currentVersion)
+}
+
 func readStateFile(stateFilePath string) (*StateEntry, error) {
 	content, err := os.ReadFile(stateFilePath)
 	if err != nil {
@@ -0,0 +1,223 @@
+package api
+
+import (
+	"context"
+	crand "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/depot/cli/pkg/config"
+)
+
+const (
+	defaultAPITimeout  = 30 * time.Second
+	defaultAPIAttempts = 3
+	apiMinBackoff      = 250 * time.Millisecond
+	apiMaxBackoff      = 5 * time.Second
+
+	// After breakerThreshold calls to a method in a row fail with retryable
+	// errors, calls fail immediately for breakerCooldown before one call is
+	// allowed to test the method again.
+	breakerThreshold = 5
+	breakerCooldown  = 30 * time.Second
+)
+
+// RequestPolicy is how a unary API method is timed out and retried.
+type RequestPolicy struct {
+	// Timeout is the timeout of each attempt; zero disables it.
+	Timeout time.Duration
+	// Attempts is the number of attempts, including the first.
+	Attempts   int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// policyFor returns the policy of an API method, such as "CreateBuild".  The
+// defaults are overridden with DEPOT_API_TIMEOUT and DEPOT_API_ATTEMPTS, or
+// api_timeout and api_attempts in the config file, and per method with
+// DEPOT_API_TIMEOUT_CREATEBUILD or api_timeout_createbuild.
+func policyFor(method string) RequestPolicy {
+	policy := RequestPolicy{
+		Timeout:    defaultAPITimeout,
+		Attempts:   defaultAPIAttempts,
+		MinBackoff: apiMinBackoff,
+		MaxBackoff: apiMaxBackoff,
+	}
+	if d := config.GetAPITimeout(method); d > 0 {
+		policy.Timeout = d
+	}
+	if n, ok := config.GetAPIAttempts(method); ok {
+		policy.Attempts = max(n, 1)
+	}
+	return policy
+}
+
+// backoff is a random delay up to MinBackoff doubled for every attempt.
+func (p RequestPolicy) backoff(attempt int) time.Duration {
+	delay := p.MinBackoff << (attempt - 1)
+	if delay <= 0 || delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(delay)) + 1)
+}
+
+// WithRetries times out and retries unary requests according to their
+// RequestPolicy.  Every attempt of a request has the same Idempotency-Key
+// header so that the API applies a retried request once.  Requests that
+// create resources are only retried when they were not sent.
+func WithRetries() connect.ClientOption {
+	return connect.WithInterceptors(&retryInterceptor{policy: policyFor})
+}
+
+type retryInterceptor struct {
+	policy func(method string) RequestPolicy
+}
+
+func (i *retryInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		_, method := splitProcedure(req.Spec().Procedure)
+		policy := i.policy(method)
+		b := breakerFor(req.Spec().Procedure)
+
+		if req.Header().Get("Idempotency-Key") == "" {
+			req.Header().Set("Idempotency-Key", newIdempotencyKey())
+		}
+
+		for attempt := 1; ; attempt++ {
+			if err := b.allow(); err != nil {
+				return nil, err
+			}
+
+			res, err := callWithTimeout(context.WithValue(ctx, attemptKey{}, attempt), policy.Timeout, next, req)
+			retry := err != nil && ctx.Err() == nil && retryable(method, err)
+			b.record(retry)
+			if !retry || attempt >= policy.Attempts {
+				return res, err
+			}
+
+			select {
+			case <-time.After(policy.backoff(attempt)):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+	}
+}
+
+func (i *retryInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *retryInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+// attemptKey holds the attempt of a request for its trace.
+type attemptKey struct{}
+
+func callWithTimeout(ctx context.Context, timeout time.Duration, next connect.UnaryFunc, req connect.AnyRequest) (connect.AnyResponse, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return next(ctx, req)
+}
+
+func newIdempotencyKey() string {
+	key := make([]byte, 16)
+	_, _ = crand.Read(key)
+	return hex.EncodeToString(key)
+}
+
+// nonIdempotent are the methods that create a resource each time they are
+// applied, such as a build.
+var nonIdempotent = map[string]bool{
+	"CreateBuild": true,
+	"CreateToken": true,
+	"StartLogin":  true,
+	"StartPush":   true,
+}
+
+// retryable reports whether a request may succeed if it is sent again.  A
+// request of a nonIdempotent method is only retried when it failed to
+// connect, as one that timed out may have been applied.
+func retryable(method string, err error) bool {
+	if nonIdempotent[method] {
+		return connect.CodeOf(err) == connect.CodeUnavailable
+	}
+	switch connect.CodeOf(err) {
+	case connect.CodeUnavailable, connect.CodeResourceExhausted, connect.CodeAborted, connect.CodeDeadlineExceeded:
+		return true
+	}
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// splitProcedure splits "/depot.cli.v1.BuildService/CreateBuild" into its
+// service and method.
+func splitProcedure(procedure string) (string, string) {
+	procedure = strings.TrimPrefix(procedure, "/")
+	service, method, _ := strings.Cut(procedure, "/")
+	return service, method
+}
+
+// breaker stops calls to a method that keeps failing.
+type breaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	now       func() time.Time
+}
+
+var (
+	breakersMu sync.Mutex
+	breakers   = map[string]*breaker{}
+)
+
+// breakerFor returns the breaker of a procedure, so that a failing method
+// does not stop the calls to the other methods of its service.
+func breakerFor(procedure string) *breaker {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+	b, ok := breakers[procedure]
+	if !ok {
+		b = &breaker{now: time.Now}
+		breakers[procedure] = b
+	}
+	return b
+}
+
+func (b *breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < breakerThreshold {
+		return nil
+	}
+	if b.now().Before(b.openUntil) {
+		return connect.NewError(connect.CodeUnavailable, errors.New("the Depot API is unavailable after repeated failures; try again shortly"))
+	}
+	// Half-open: let this call through, and reopen if it fails.
+	b.openUntil = b.now().Add(breakerCooldown)
+	return nil
+}
+
+func (b *breaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !failed {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures == breakerThreshold {
+		b.openUntil = b.now().Add(breakerCooldown)
+	}
+}
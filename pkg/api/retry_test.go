@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+)
+
+func TestRetryInterceptor(t *testing.T) {
+	interceptor := &retryInterceptor{policy: func(string) RequestPolicy {
+		return RequestPolicy{Timeout: time.Second, Attempts: 3}
+	}}
+
+	var keys []string
+	calls := 0
+	unary := interceptor.WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		calls++
+		keys = append(keys, req.Header().Get("Idempotency-Key"))
+		if calls < 3 {
+			return nil, connect.NewError(connect.CodeUnavailable, errors.New("unavailable"))
+		}
+		return connect.NewResponse(&cliv1.CreateBuildResponse{}), nil
+	})
+
+	if _, err := unary(context.Background(), connect.NewRequest(&cliv1.CreateBuildRequest{})); err != nil {
+		t.Fatalf("expected the third attempt to succeed, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+	if keys[0] == "" || keys[0] != keys[1] || keys[1] != keys[2] {
+		t.Errorf("expected every attempt to have the same idempotency key, got %v", keys)
+	}
+
+	calls = 0
+	unary = interceptor.WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		calls++
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid"))
+	})
+	if _, err := unary(context.Background(), connect.NewRequest(&cliv1.CreateBuildRequest{})); err == nil || calls != 1 {
+		t.Errorf("expected an invalid request not to be retried, got %d attempts", calls)
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	b := &breaker{now: func() time.Time { return now }}
+
+	for i := 0; i < breakerThreshold; i++ {
+		if err := b.allow(); err != nil {
+			t.Fatalf("expected call %d to be allowed, got %v", i+1, err)
+		}
+		b.record(true)
+	}
+	if err := b.allow(); connect.CodeOf(err) != connect.CodeUnavailable {
+		t.Fatalf("expected the breaker to open, got %v", err)
+	}
+
+	now = now.Add(breakerCooldown)
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected one call after the cooldown, got %v", err)
+	}
+	if err := b.allow(); err == nil {
+		t.Errorf("expected only one call while half-open")
+	}
+	b.record(false)
+	if err := b.allow(); err != nil {
+		t.Errorf("expected a success to close the breaker, got %v", err)
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		code   connect.Code
+		want   bool
+	}{
+		{name: "unavailable", method: "GetBuild", code: connect.CodeUnavailable, want: true},
+		{name: "deadline exceeded", method: "GetBuild", code: connect.CodeDeadlineExceeded, want: true},
+		{name: "invalid argument", method: "GetBuild", code: connect.CodeInvalidArgument, want: false},
+		{name: "create unavailable", method: "CreateBuild", code: connect.CodeUnavailable, want: true},
+		{name: "create deadline exceeded", method: "CreateBuild", code: connect.CodeDeadlineExceeded, want: false},
+		{name: "create aborted", method: "CreateBuild", code: connect.CodeAborted, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryable(tt.method, connect.NewError(tt.code, errors.New("failed"))); got != tt.want {
+				t.Errorf("retryable(%q, %v) = %v, want %v", tt.method, tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakerFor(t *testing.T) {
+	health := breakerFor("/depot.cli.v1.BuildService/ReportBuildHealth")
+	if health == breakerFor("/depot.cli.v1.BuildService/CreateBuild") {
+		t.Errorf("expected the methods of a service to have their own breakers")
+	}
+	if health != breakerFor("/depot.cli.v1.BuildService/ReportBuildHealth") {
+		t.Errorf("expected a method to keep its breaker")
+	}
+}
@@ -15,7 +15,7 @@ func NewBuildClient() cliv1connect.BuildServiceClient {
 	if baseURL == "" {
 		baseURL = "https://api.depot.dev"
 	}
-	return cliv1connect.NewBuildServiceClient(http.DefaultClient, baseURL, WithUserAgent())
+	return cliv1connect.NewBuildServiceClient(http.DefaultClient, baseURL, WithUserAgent(), WithRetries(), WithTrace("api"))
 }
 
 func NewLoginClient() cliv1beta1connect.LoginServiceClient {
@@ -23,7 +23,7 @@ func NewLoginClient() cliv1beta1connect.LoginServiceClient {
 	if baseURL == "" {
 		baseURL = "https://api.depot.dev"
 	}
-	return cliv1beta1connect.NewLoginServiceClient(http.DefaultClient, baseURL, WithUserAgent())
+	return cliv1beta1connect.NewLoginServiceClient(http.DefaultClient, baseURL, WithUserAgent(), WithRetries(), WithTrace("api"))
 }
 
 func NewProjectsClient() cliv1beta1connect.ProjectsServiceClient {
@@ -31,7 +31,7 @@ func NewProjectsClient() cliv1beta1connect.ProjectsServiceClient {
 	if baseURL == "" {
 		baseURL = "https://api.depot.dev"
 	}
-	return cliv1beta1connect.NewProjectsServiceClient(http.DefaultClient, baseURL, WithUserAgent())
+	return cliv1beta1connect.NewProjectsServiceClient(http.DefaultClient, baseURL, WithUserAgent(), WithRetries(), WithTrace("api"))
 }
 
 func NewSDKProjectsClient() corev1connect.ProjectServiceClient {
@@ -39,7 +39,7 @@ func NewSDKProjectsClient() corev1connect.ProjectServiceClient {
 	if baseURL == "" {
 		baseURL = "https://api.depot.dev"
 	}
-	return corev1connect.NewProjectServiceClient(http.DefaultClient, baseURL, WithUserAgent())
+	return corev1connect.NewProjectServiceClient(http.DefaultClient, baseURL, WithUserAgent(), WithRetries(), WithTrace("api"))
 }
 
 func NewPushClient() cliv1connect.PushServiceClient {
@@ -47,7 +47,31 @@ func NewPushClient() cliv1connect.PushServiceClient {
 	if baseURL == "" {
 		baseURL = "https://api.depot.dev"
 	}
-	return cliv1connect.NewPushServiceClient(http.DefaultClient, baseURL, WithUserAgent())
+	return cliv1connect.NewPushServiceClient(http.DefaultClient, baseURL, WithUserAgent(), WithRetries(), WithTrace("api"))
+}
+
+func NewUsageClient() cliv1connect.UsageServiceClient {
+	baseURL := os.Getenv("DEPOT_API_URL")
+	if baseURL == "" {
+		baseURL = "https://api.depot.dev"
+	}
+	return cliv1connect.NewUsageServiceClient(http.DefaultClient, baseURL, WithUserAgent(), WithRetries(), WithTrace("api"))
+}
+
+func NewTokenClient() cliv1connect.TokenServiceClient {
+	baseURL := os.Getenv("DEPOT_API_URL")
+	if baseURL == "" {
+		baseURL = "https://api.depot.dev"
+	}
+	return cliv1connect.NewTokenServiceClient(http.DefaultClient, baseURL, WithUserAgent(), WithRetries(), WithTrace("api"))
+}
+
+func NewProfileClient() cliv1connect.ProfileServiceClient {
+	baseURL := os.Getenv("DEPOT_API_URL")
+	if baseURL == "" {
+		baseURL = "https://api.depot.dev"
+	}
+	return cliv1connect.NewProfileServiceClient(http.DefaultClient, baseURL, WithUserAgent(), WithRetries(), WithTrace("api"))
 }
 
 func WithAuthentication[T any](req *connect.Request[T], token string) *connect.Request[T] {
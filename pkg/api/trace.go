@@ -0,0 +1,309 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/depot/cli/pkg/config"
+	"github.com/depot/cli/pkg/debuglog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// DEPOT_GRPC_DEBUG=file appends a JSON line to the trace file when every
+// Connect call to the API and gRPC call to a machine or through the proxy
+// starts and ends.  A call that starts without ending is where a build hangs.
+// DEPOT_GRPC_DEBUG_FILE overrides the file, by default grpc-trace.jsonl in
+// the depot state directory.
+
+var (
+	traceOnce sync.Once
+	tracer    *traceWriter
+)
+
+type traceWriter struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	nextID atomic.Uint64
+}
+
+// traceOutput opens the trace file the first time a client is created.  It
+// is nil unless tracing is enabled.
+func traceOutput() *traceWriter {
+	traceOnce.Do(func() {
+		switch mode := os.Getenv("DEPOT_GRPC_DEBUG"); mode {
+		case "":
+			return
+		case "file":
+		default:
+			debuglog.Log("unknown DEPOT_GRPC_DEBUG %q; only file is supported", mode)
+			return
+		}
+
+		path := os.Getenv("DEPOT_GRPC_DEBUG_FILE")
+		if path == "" {
+			var err error
+			path, err = config.GRPCTraceFile()
+			if err != nil {
+				debuglog.Log("unable to trace gRPC calls: %v", err)
+				return
+			}
+		}
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			debuglog.Log("unable to trace gRPC calls: %v", err)
+			return
+		}
+		fmt.Fprintf(os.Stderr, "Tracing gRPC calls to %s\n", path)
+		tracer = &traceWriter{enc: json.NewEncoder(f)}
+	})
+	return tracer
+}
+
+func (w *traceWriter) write(record any) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.enc.Encode(record); err != nil {
+		debuglog.Log("unable to trace gRPC call: %v", err)
+	}
+}
+
+// traceStart is the line written when a call starts.  The line written when
+// it ends has the same ID.
+type traceStart struct {
+	Time   time.Time `json:"time"`
+	Event  string    `json:"event"`
+	ID     uint64    `json:"id"`
+	Client string    `json:"client"`
+	Method string    `json:"method"`
+	Stream bool      `json:"stream,omitempty"`
+	// Attempt is the attempt of an API call that is retried, starting at 1.
+	Attempt int `json:"attempt,omitempty"`
+}
+
+type traceEnd struct {
+	traceStart
+	DurationMS       float64 `json:"duration_ms"`
+	Status           string  `json:"status"`
+	Error            string  `json:"error,omitempty"`
+	SentBytes        int64   `json:"sent_bytes"`
+	ReceivedBytes    int64   `json:"received_bytes"`
+	SentMessages     int64   `json:"sent_messages"`
+	ReceivedMessages int64   `json:"received_messages"`
+}
+
+type tracedCall struct {
+	w     *traceWriter
+	start traceStart
+	once  sync.Once
+
+	sentBytes, receivedBytes       atomic.Int64
+	sentMessages, receivedMessages atomic.Int64
+}
+
+func (w *traceWriter) begin(ctx context.Context, client, method string, stream bool) *tracedCall {
+	attempt, _ := ctx.Value(attemptKey{}).(int)
+	c := &tracedCall{w: w, start: traceStart{
+		Time:    time.Now(),
+		Event:   "start",
+		ID:      w.nextID.Add(1),
+		Client:  client,
+		Method:  method,
+		Stream:  stream,
+		Attempt: attempt,
+	}}
+	w.write(c.start)
+	return c
+}
+
+func (c *tracedCall) sent(msg any) {
+	c.sentMessages.Add(1)
+	c.sentBytes.Add(int64(messageSize(msg)))
+}
+
+func (c *tracedCall) received(msg any) {
+	c.receivedMessages.Add(1)
+	c.receivedBytes.Add(int64(messageSize(msg)))
+}
+
+// end writes the end of the call once; the status is "ok" or the lower case
+// gRPC code, such as "unavailable".
+func (c *tracedCall) end(code string, err error) {
+	c.once.Do(func() {
+		record := traceEnd{
+			traceStart:       c.start,
+			DurationMS:       float64(time.Since(c.start.Time).Microseconds()) / 1000,
+			Status:           code,
+			SentBytes:        c.sentBytes.Load(),
+			ReceivedBytes:    c.receivedBytes.Load(),
+			SentMessages:     c.sentMessages.Load(),
+			ReceivedMessages: c.receivedMessages.Load(),
+		}
+		record.Event = "end"
+		if err != nil && !errors.Is(err, io.EOF) {
+			record.Error = err.Error()
+		}
+		c.w.write(record)
+	})
+}
+
+func messageSize(msg any) int {
+	switch m := msg.(type) {
+	case proto.Message:
+		return proto.Size(m)
+	case interface{ Size() int }:
+		// The gogo messages of BuildKit.
+		return m.Size()
+	}
+	return 0
+}
+
+// WithTrace traces the calls of an API client when DEPOT_GRPC_DEBUG=file.
+// It is the innermost interceptor so that every retry is traced.
+func WithTrace(client string) connect.ClientOption {
+	w := traceOutput()
+	if w == nil {
+		return connect.WithInterceptors()
+	}
+	return connect.WithInterceptors(&traceInterceptor{w: w, client: client})
+}
+
+type traceInterceptor struct {
+	w      *traceWriter
+	client string
+}
+
+func (i *traceInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		c := i.w.begin(ctx, i.client, req.Spec().Procedure, false)
+		c.sent(req.Any())
+		res, err := next(ctx, req)
+		if err == nil {
+			c.received(res.Any())
+		}
+		c.end(connectStatus(err), err)
+		return res, err
+	}
+}
+
+func (i *traceInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		c := i.w.begin(ctx, i.client, spec.Procedure, true)
+		return &tracedStreamingConn{StreamingClientConn: next(ctx, spec), call: c}
+	}
+}
+
+func (i *traceInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+type tracedStreamingConn struct {
+	connect.StreamingClientConn
+	call *tracedCall
+}
+
+func (s *tracedStreamingConn) Send(msg any) error {
+	err := s.StreamingClientConn.Send(msg)
+	if err == nil {
+		s.call.sent(msg)
+	}
+	return err
+}
+
+func (s *tracedStreamingConn) Receive(msg any) error {
+	err := s.StreamingClientConn.Receive(msg)
+	if err != nil {
+		s.call.end(connectStatus(err), err)
+		return err
+	}
+	s.call.received(msg)
+	return nil
+}
+
+func (s *tracedStreamingConn) CloseResponse() error {
+	err := s.StreamingClientConn.CloseResponse()
+	s.call.end(connectStatus(err), err)
+	return err
+}
+
+func connectStatus(err error) string {
+	if err == nil || errors.Is(err, io.EOF) {
+		return "ok"
+	}
+	return connect.CodeOf(err).String()
+}
+
+// GRPCTraceOptions traces the calls of a gRPC client, such as the BuildKit
+// client of a machine, when DEPOT_GRPC_DEBUG=file.
+func GRPCTraceOptions(client string) []grpc.DialOption {
+	w := traceOutput()
+	if w == nil {
+		return nil
+	}
+
+	unary := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		c := w.begin(ctx, client, method, false)
+		c.sent(req)
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			c.received(reply)
+		}
+		c.end(grpcStatus(err), err)
+		return err
+	}
+	stream := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		c := w.begin(ctx, client, method, true)
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			c.end(grpcStatus(err), err)
+			return nil, err
+		}
+		return &tracedClientStream{ClientStream: cs, call: c, serverStreams: desc.ServerStreams}, nil
+	}
+	return []grpc.DialOption{grpc.WithChainUnaryInterceptor(unary), grpc.WithChainStreamInterceptor(stream)}
+}
+
+type tracedClientStream struct {
+	grpc.ClientStream
+	call          *tracedCall
+	serverStreams bool
+}
+
+func (s *tracedClientStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.call.sent(m)
+	}
+	return err
+}
+
+func (s *tracedClientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.call.end(grpcStatus(err), err)
+		return err
+	}
+	s.call.received(m)
+	// A client stream ends with its only response.
+	if !s.serverStreams {
+		s.call.end("ok", nil)
+	}
+	return nil
+}
+
+func grpcStatus(err error) string {
+	if err == nil || errors.Is(err, io.EOF) {
+		return "ok"
+	}
+	// The codes of Connect are the codes of gRPC.
+	return connect.Code(status.Code(err)).String()
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+)
+
+func TestTraceInterceptor(t *testing.T) {
+	var buf bytes.Buffer
+	w := &traceWriter{enc: json.NewEncoder(&buf)}
+	retries := &retryInterceptor{policy: func(string) RequestPolicy {
+		return RequestPolicy{Timeout: time.Second, Attempts: 2}
+	}}
+	trace := &traceInterceptor{w: w, client: "api"}
+
+	calls := 0
+	unary := retries.WrapUnary(trace.WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		calls++
+		if calls == 1 {
+			return nil, connect.NewError(connect.CodeUnavailable, errors.New("unavailable"))
+		}
+		return connect.NewResponse(&cliv1.CreateBuildResponse{BuildId: "build"}), nil
+	}))
+	if _, err := unary(context.Background(), connect.NewRequest(&cliv1.CreateBuildRequest{Options: []*cliv1.BuildOptions{{Command: cliv1.Command_COMMAND_BUILD}}})); err != nil {
+		t.Fatal(err)
+	}
+
+	var records []traceEnd
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var record traceEnd
+		if err := dec.Decode(&record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 4 {
+		t.Fatalf("expected a start and end of both attempts, got %d lines", len(records))
+	}
+
+	failed, succeeded := records[1], records[3]
+	if failed.Event != "end" || failed.Attempt != 1 || failed.Status != "unavailable" || failed.Error == "" {
+		t.Errorf("unexpected trace of the first attempt: %+v", failed)
+	}
+	if succeeded.Attempt != 2 || succeeded.Status != "ok" || succeeded.ID != records[2].ID {
+		t.Errorf("unexpected trace of the second attempt: %+v", succeeded)
+	}
+	if succeeded.SentBytes == 0 || succeeded.ReceivedBytes == 0 {
+		t.Errorf("expected the sizes of the request and response, got %+v", succeeded)
+	}
+}
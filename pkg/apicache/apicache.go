@@ -0,0 +1,141 @@
+// Caches the list responses of the API that completions and interactive
+// listings request repeatedly.
+package apicache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/depot/cli/pkg/config"
+	"github.com/depot/cli/pkg/debuglog"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultTTL is how long a cached response is used before it is fetched
+// again.  DEPOT_API_CACHE_TTL overrides it, such as "5m", or "0" to disable
+// the cache.
+const DefaultTTL = 30 * time.Second
+
+// disabled is set by --no-cache to skip cached responses.
+var disabled bool
+
+// Disable makes Get fetch every response, as Refresh does, for --no-cache.
+func Disable() {
+	disabled = true
+}
+
+// Get returns the cached response of the named list for the token and
+// request, or fetches and caches it if there is none or it is older than the
+// TTL.
+func Get[T proto.Message](ctx context.Context, name, token string, req proto.Message, fetch func(context.Context) (T, error)) (T, error) {
+	ttl := cacheTTL()
+	if disabled {
+		ttl = 0
+	}
+	return get(ctx, cacheFile(name, token, req), ttl, fetch)
+}
+
+// Refresh fetches the response of the named list and caches it, for callers
+// that need the latest response, such as the latest build.
+func Refresh[T proto.Message](ctx context.Context, name, token string, req proto.Message, fetch func(context.Context) (T, error)) (T, error) {
+	return get(ctx, cacheFile(name, token, req), 0, fetch)
+}
+
+// Clear removes the cached responses of the named list, for example after
+// creating a project.
+func Clear(name string) {
+	if err := os.RemoveAll(config.APICacheDir(name)); err != nil {
+		debuglog.Log("unable to clear the %s API cache: %v", name, err)
+	}
+}
+
+func get[T proto.Message](ctx context.Context, path string, ttl time.Duration, fetch func(context.Context) (T, error)) (T, error) {
+	if ttl > 0 && path != "" {
+		if cached, ok := readCache[T](path, ttl); ok {
+			return cached, nil
+		}
+	}
+
+	res, err := fetch(ctx)
+	if err != nil {
+		return res, err
+	}
+	if path != "" {
+		if err := writeCache(path, res); err != nil {
+			debuglog.Log("unable to cache API response: %v", err)
+		}
+	}
+	return res, nil
+}
+
+func cacheTTL() time.Duration {
+	value := os.Getenv("DEPOT_API_CACHE_TTL")
+	if value == "" {
+		return DefaultTTL
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		debuglog.Log("invalid DEPOT_API_CACHE_TTL %q: %v", value, err)
+		return DefaultTTL
+	}
+	return ttl
+}
+
+// cacheFile keeps the responses of different tokens and requests apart
+// without writing the token to disk.  It is empty if the request cannot be
+// hashed.
+func cacheFile(name, token string, req proto.Message) string {
+	dt, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(append([]byte(token+"\x00"), dt...))
+	return filepath.Join(config.APICacheDir(name), hex.EncodeToString(sum[:8])+".json")
+}
+
+func readCache[T proto.Message](path string, ttl time.Duration) (T, bool) {
+	var zero T
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) >= ttl {
+		return zero, false
+	}
+	dt, err := os.ReadFile(path)
+	if err != nil {
+		return zero, false
+	}
+	res := zero.ProtoReflect().New().Interface().(T)
+	if err := protojson.Unmarshal(dt, res); err != nil {
+		return zero, false
+	}
+	return res, true
+}
+
+// writeCache replaces the cached response at once, as completions of several
+// shells may read it at the same time.
+func writeCache(path string, res proto.Message) error {
+	dt, err := protojson.Marshal(res)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if _, err := f.Write(dt); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,62 @@
+package apicache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cliv1beta1 "github.com/depot/cli/pkg/proto/depot/cli/v1beta1"
+)
+
+func TestGet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "projects", "key.json")
+	calls := 0
+	fetch := func(context.Context) (*cliv1beta1.ListProjectsResponse, error) {
+		calls++
+		return &cliv1beta1.ListProjectsResponse{Projects: []*cliv1beta1.ListProjectsResponse_Project{{Id: "abc123", Name: "app"}}}, nil
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		res, err := get(ctx, path, time.Minute, fetch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Projects) != 1 || res.Projects[0].Id != "abc123" {
+			t.Fatalf("unexpected projects %v", res.Projects)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected the second call to be cached, fetched %d times", calls)
+	}
+
+	if _, err := get(ctx, path, 0, fetch); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("expected a TTL of 0 to fetch, fetched %d times", calls)
+	}
+
+	old := time.Now().Add(-2 * time.Minute)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := get(ctx, path, time.Minute, fetch); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 {
+		t.Errorf("expected an expired response to be fetched, fetched %d times", calls)
+	}
+}
+
+func TestCacheFile(t *testing.T) {
+	a := cacheFile("builds", "token", &cliv1beta1.ListProjectsRequest{})
+	if a == "" || a != cacheFile("builds", "token", &cliv1beta1.ListProjectsRequest{}) {
+		t.Errorf("expected the same key for the same request, got %q", a)
+	}
+	if a == cacheFile("builds", "other", &cliv1beta1.ListProjectsRequest{}) {
+		t.Error("expected tokens to have separate keys")
+	}
+}
@@ -0,0 +1,234 @@
+package artifact
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	depotbuild "github.com/depot/cli/pkg/buildx/build"
+	"github.com/depot/cli/pkg/buildx/imagetools"
+	"github.com/depot/cli/pkg/load"
+	"github.com/depot/cli/pkg/sbom"
+	"github.com/distribution/reference"
+	buildx "github.com/docker/buildx/build"
+	"github.com/docker/buildx/util/progress"
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	// OutputType is the export type that pushes only the attestations of a
+	// build as OCI artifacts referring to an existing image.
+	OutputType = "attestation-manifest"
+
+	mediaTypeEmptyJSON  = "application/vnd.oci.empty.v1+json"
+	mediaTypeInToto     = "application/vnd.in-toto+json"
+	annotationPredicate = "in-toto.io/predicate-type"
+)
+
+// Output is an attestation-manifest output of a single build target.
+type Output struct {
+	// Name is the existing image the attestations are attached to.
+	Name string
+}
+
+// ExtractOutputs replaces every attestation-manifest export with an image
+// export that is neither named nor pushed, so that the image and its
+// attestation manifests are only stored on the builder, and returns the
+// outputs by target.  SBOM generation is enabled for targets with
+// attestation-manifest outputs; provenance is attested by default.
+func ExtractOutputs(buildOpts map[string]buildx.Options) (map[string][]Output, error) {
+	outputs := map[string][]Output{}
+	for target, opt := range buildOpts {
+		for i, export := range opt.Exports {
+			if export.Type != OutputType {
+				continue
+			}
+
+			name := export.Attrs["name"]
+			if name == "" && len(opt.Tags) > 0 {
+				name = opt.Tags[0]
+			}
+			if name == "" {
+				return nil, errors.Errorf("%s output requires an image name (use --tag or type=%s,name=<image>)", OutputType, OutputType)
+			}
+			if _, err := reference.ParseNormalizedNamed(name); err != nil {
+				return nil, errors.Wrapf(err, "invalid %s image name %q", OutputType, name)
+			}
+
+			outputs[target] = append(outputs[target], Output{Name: name})
+			// The lease keeps the image on the builder until Push reads it.
+			opt.Exports[i] = client.ExportEntry{Type: client.ExporterImage, Attrs: map[string]string{"depot.export.lease": "true"}}
+		}
+
+		if len(outputs[target]) == 0 {
+			continue
+		}
+		if len(opt.Exports) > 1 {
+			return nil, errors.Errorf("%s output cannot be combined with other outputs", OutputType)
+		}
+
+		if opt.Attests == nil {
+			opt.Attests = map[string]*string{}
+		}
+		if v, ok := opt.Attests["attest:sbom"]; !ok || v == nil {
+			enabled := "type=sbom"
+			opt.Attests["attest:sbom"] = &enabled
+		}
+		buildOpts[target] = opt
+	}
+
+	return outputs, nil
+}
+
+// Push uploads the provenance and SBOM attestations of each build response
+// as OCI artifacts whose subject is the platform manifest of the output
+// image.  Registries supporting the referrers API will list them alongside
+// the image.
+func Push(ctx context.Context, imageOpt imagetools.Opt, resp []depotbuild.DepotBuildResponse, outputs map[string][]Output, w progress.Writer) error {
+	resolver := imagetools.New(imageOpt)
+
+	// The export leases of the other targets are kept for their loads.
+	var leased []depotbuild.DepotBuildResponse
+	defer func() { load.DeleteExportLeases(ctx, leased) }()
+
+	for _, buildRes := range resp {
+		targetOutputs, ok := outputs[buildRes.Name]
+		if !ok {
+			continue
+		}
+		leased = append(leased, buildRes)
+
+		var attestations []sbom.Attestation
+		for _, nodeRes := range buildRes.NodeResponses {
+			atts, err := nodeAttestations(ctx, nodeRes)
+			if err != nil {
+				return err
+			}
+			attestations = append(attestations, atts...)
+		}
+		if len(attestations) == 0 {
+			return errors.Errorf("target %s did not produce any attestations", buildRes.Name)
+		}
+
+		for _, output := range targetOutputs {
+			output := output
+			err := progress.Wrap(fmt.Sprintf("[attestation] pushing attestations for %s", output.Name), w.Write, func(progress.SubLogger) error {
+				return pushAttestations(ctx, resolver, output.Name, attestations)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func pushAttestations(ctx context.Context, resolver *imagetools.Resolver, name string, attestations []sbom.Attestation) error {
+	ref, err := reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return err
+	}
+
+	dt, desc, err := resolver.Get(ctx, ref.String())
+	if err != nil {
+		return errors.Wrapf(err, "unable to resolve %s", name)
+	}
+
+	for _, att := range attestations {
+		subject, err := platformManifest(dt, desc, att.Platform)
+		if err != nil {
+			return errors.Wrapf(err, "unable to find %s manifest for %s", att.Platform, name)
+		}
+
+		if err := pushArtifact(ctx, resolver, ref, subject, att.Statement); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// platformManifest returns the manifest descriptor of the image for the platform.
+// Single-platform images are returned as is.
+func platformManifest(dt []byte, desc ocispecs.Descriptor, platform string) (ocispecs.Descriptor, error) {
+	if !images.IsIndexType(desc.MediaType) {
+		return desc, nil
+	}
+
+	p, err := platforms.Parse(platform)
+	if err != nil {
+		return ocispecs.Descriptor{}, err
+	}
+	matcher := platforms.OnlyStrict(p)
+
+	var index ocispecs.Index
+	if err := json.Unmarshal(dt, &index); err != nil {
+		return ocispecs.Descriptor{}, err
+	}
+	for _, m := range index.Manifests {
+		if m.Platform != nil && matcher.Match(*m.Platform) {
+			return ocispecs.Descriptor{MediaType: m.MediaType, Digest: m.Digest, Size: m.Size}, nil
+		}
+	}
+
+	return ocispecs.Descriptor{}, errors.New("no matching manifest")
+}
+
+func pushArtifact(ctx context.Context, resolver *imagetools.Resolver, ref reference.Named, subject ocispecs.Descriptor, statement []byte) error {
+	var predicate struct {
+		PredicateType string `json:"predicateType"`
+	}
+	if err := json.Unmarshal(statement, &predicate); err != nil {
+		return errors.Wrap(err, "invalid in-toto statement")
+	}
+
+	config := []byte("{}")
+	configDesc := ocispecs.Descriptor{
+		MediaType: mediaTypeEmptyJSON,
+		Digest:    digest.FromBytes(config),
+		Size:      int64(len(config)),
+	}
+	layerDesc := ocispecs.Descriptor{
+		MediaType:   mediaTypeInToto,
+		Digest:      digest.FromBytes(statement),
+		Size:        int64(len(statement)),
+		Annotations: map[string]string{annotationPredicate: predicate.PredicateType},
+	}
+
+	manifest, err := json.Marshal(ocispecs.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispecs.MediaTypeImageManifest,
+		Config:    configDesc,
+		Layers:    []ocispecs.Descriptor{layerDesc},
+		Subject:   &subject,
+	})
+	if err != nil {
+		return err
+	}
+	manifestDesc := ocispecs.Descriptor{
+		MediaType: ocispecs.MediaTypeImageManifest,
+		Digest:    digest.FromBytes(manifest),
+		Size:      int64(len(manifest)),
+	}
+
+	if err := resolver.Push(ctx, ref, configDesc, config); err != nil {
+		return err
+	}
+	if err := resolver.Push(ctx, ref, layerDesc, statement); err != nil {
+		return err
+	}
+
+	// Push the manifest by digest so that the image tag is left untouched.
+	digested, err := reference.WithDigest(reference.TrimNamed(ref), manifestDesc.Digest)
+	if err != nil {
+		return err
+	}
+	return resolver.Push(ctx, digested, manifestDesc, manifest)
+}
@@ -0,0 +1,133 @@
+package artifact
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/depot/cli/pkg/sbom"
+	buildx "github.com/docker/buildx/build"
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestExtractOutputs(t *testing.T) {
+	buildOpts := map[string]buildx.Options{
+		"app": {
+			Tags:    []string{"repo/app:1.0"},
+			Exports: []client.ExportEntry{{Type: OutputType, Attrs: map[string]string{}}},
+		},
+		"other": {
+			Exports: []client.ExportEntry{{Type: client.ExporterImage, Attrs: map[string]string{"push": "true"}}},
+		},
+	}
+	outputs, err := ExtractOutputs(buildOpts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string][]Output{"app": {{Name: "repo/app:1.0"}}}; !reflect.DeepEqual(outputs, want) {
+		t.Errorf("expected outputs %v, got %v", want, outputs)
+	}
+
+	export := buildOpts["app"].Exports[0]
+	if export.Type != client.ExporterImage || export.Attrs["push"] != "" || export.Attrs["name"] != "" {
+		t.Errorf("expected an unnamed image export that is not pushed, got %+v", export)
+	}
+	if sbom := buildOpts["app"].Attests["attest:sbom"]; sbom == nil {
+		t.Error("expected SBOM generation to be enabled")
+	}
+	if _, ok := buildOpts["app"].Attests["attest:provenance"]; ok {
+		t.Error("expected the default provenance to be kept")
+	}
+
+	_, err = ExtractOutputs(map[string]buildx.Options{
+		"app": {Exports: []client.ExportEntry{{Type: OutputType, Attrs: map[string]string{}}}},
+	})
+	if err == nil {
+		t.Error("expected an error without an image name")
+	}
+}
+
+func statement(predicateType string) []byte {
+	dt, _ := json.Marshal(sbom.Statement{
+		Type:          "https://in-toto.io/Statement/v0.1",
+		PredicateType: predicateType,
+		Predicate:     json.RawMessage(`{}`),
+	})
+	return dt
+}
+
+func TestIndexAttestations(t *testing.T) {
+	const (
+		provenance = "https://slsa.dev/provenance/v0.2"
+		spdx       = "https://spdx.dev/Document"
+	)
+	blobs := map[digest.Digest][]byte{}
+	add := func(v interface{}) ocispecs.Descriptor {
+		dt, ok := v.([]byte)
+		if !ok {
+			dt, _ = json.Marshal(v)
+		}
+		dgst := digest.FromBytes(dt)
+		blobs[dgst] = dt
+		return ocispecs.Descriptor{Digest: dgst, Size: int64(len(dt))}
+	}
+
+	index := ocispecs.Index{}
+	var want []sbom.Attestation
+	for _, arch := range []string{"amd64", "arm64"} {
+		image := add(ocispecs.Manifest{MediaType: ocispecs.MediaTypeImageManifest, Annotations: map[string]string{"arch": arch}})
+		image.MediaType = ocispecs.MediaTypeImageManifest
+		image.Platform = &ocispecs.Platform{OS: "linux", Architecture: arch}
+
+		var layers []ocispecs.Descriptor
+		for _, predicate := range []string{provenance, spdx} {
+			dt := statement(predicate)
+			layer := add(dt)
+			layer.Annotations = map[string]string{annotationPredicate: predicate}
+			layers = append(layers, layer)
+			want = append(want, sbom.Attestation{Platform: "linux/" + arch, Statement: dt})
+		}
+		attestation := add(ocispecs.Manifest{MediaType: ocispecs.MediaTypeImageManifest, Layers: layers})
+		attestation.Platform = &ocispecs.Platform{OS: "unknown", Architecture: "unknown"}
+		attestation.Annotations = map[string]string{
+			annotationReferenceType:   referenceTypeAttestation,
+			annotationReferenceDigest: image.Digest.String(),
+		}
+		index.Manifests = append(index.Manifests, image, attestation)
+	}
+	indexDesc := add(index)
+
+	read := func(dgst digest.Digest) ([]byte, error) {
+		dt, ok := blobs[dgst]
+		if !ok {
+			return nil, fmt.Errorf("%s not found", dgst)
+		}
+		return dt, nil
+	}
+	got, err := indexAttestations(read, indexDesc.Digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %d attestations, got %d", len(want), len(got))
+	}
+
+	// A depot SBOM is only added for a platform without one.
+	sboms := []sbom.Attestation{
+		{Platform: "linux/amd64", Statement: statement(spdx)},
+		{Platform: "linux/riscv64", Statement: statement(spdx)},
+	}
+	merged := mergeAttestations(got, sboms)
+	if !reflect.DeepEqual(merged, append(want, sboms[1])) {
+		t.Errorf("expected only the SBOM of linux/riscv64 to be added, got %d attestations", len(merged))
+	}
+
+	manifest := add(ocispecs.Manifest{MediaType: ocispecs.MediaTypeImageManifest})
+	got, err = indexAttestations(read, manifest.Digest)
+	if err != nil || len(got) != 0 {
+		t.Errorf("expected no attestations of a single manifest, got %v, %v", got, err)
+	}
+}
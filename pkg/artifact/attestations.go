@@ -0,0 +1,117 @@
+package artifact
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/containerd/containerd/platforms"
+	depotbuild "github.com/depot/cli/pkg/buildx/build"
+	"github.com/depot/cli/pkg/sbom"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	annotationReferenceType   = "vnd.docker.reference.type"
+	annotationReferenceDigest = "vnd.docker.reference.digest"
+	referenceTypeAttestation  = "attestation-manifest"
+)
+
+// nodeAttestations returns the attestations of the image that a node stored
+// on its builder, such as the provenance, and the SBOMs that Depot generated
+// for platforms that the image has no SBOM of.
+func nodeAttestations(ctx context.Context, nodeRes depotbuild.DepotNodeResponse) ([]sbom.Attestation, error) {
+	var attestations []sbom.Attestation
+	if dgst := nodeRes.SolveResponse.ExporterResponse[exptypes.ExporterImageDigestKey]; dgst != "" {
+		read := func(dgst digest.Digest) ([]byte, error) {
+			return sbom.ReadContent(ctx, nodeRes.Node.Driver, dgst)
+		}
+		var err error
+		attestations, err = indexAttestations(read, digest.Digest(dgst))
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read the attestations of the image")
+		}
+	}
+
+	sboms, err := sbom.Attestations(ctx, nodeRes)
+	if err != nil {
+		return nil, err
+	}
+	return mergeAttestations(attestations, sboms), nil
+}
+
+// indexAttestations reads the in-toto statements of the attestation
+// manifests of the image index, by the platform of the manifest they refer
+// to.  A single manifest has no attestations.
+func indexAttestations(read func(digest.Digest) ([]byte, error), dgst digest.Digest) ([]sbom.Attestation, error) {
+	dt, err := read(dgst)
+	if err != nil {
+		return nil, err
+	}
+	var index ocispecs.Index
+	if err := json.Unmarshal(dt, &index); err != nil {
+		return nil, err
+	}
+
+	manifestPlatforms := map[digest.Digest]string{}
+	for _, m := range index.Manifests {
+		if m.Platform != nil && m.Annotations[annotationReferenceType] != referenceTypeAttestation {
+			manifestPlatforms[m.Digest] = platforms.Format(*m.Platform)
+		}
+	}
+
+	var attestations []sbom.Attestation
+	for _, m := range index.Manifests {
+		if m.Annotations[annotationReferenceType] != referenceTypeAttestation {
+			continue
+		}
+		platform, ok := manifestPlatforms[digest.Digest(m.Annotations[annotationReferenceDigest])]
+		if !ok {
+			continue
+		}
+
+		dt, err := read(m.Digest)
+		if err != nil {
+			return nil, err
+		}
+		var manifest ocispecs.Manifest
+		if err := json.Unmarshal(dt, &manifest); err != nil {
+			return nil, err
+		}
+		for _, layer := range manifest.Layers {
+			if layer.Annotations[annotationPredicate] == "" {
+				continue
+			}
+			statement, err := read(layer.Digest)
+			if err != nil {
+				return nil, err
+			}
+			attestations = append(attestations, sbom.Attestation{Platform: platform, Statement: statement})
+		}
+	}
+	return attestations, nil
+}
+
+// mergeAttestations adds the extra attestations whose platform and predicate
+// type are not already attested.
+func mergeAttestations(attestations, extra []sbom.Attestation) []sbom.Attestation {
+	type key struct{ platform, predicateType string }
+	seen := map[key]bool{}
+	for _, att := range attestations {
+		seen[key{att.Platform, predicateType(att.Statement)}] = true
+	}
+	for _, att := range extra {
+		if !seen[key{att.Platform, predicateType(att.Statement)}] {
+			attestations = append(attestations, att)
+		}
+	}
+	return attestations
+}
+
+func predicateType(statement []byte) string {
+	var s sbom.Statement
+	_ = json.Unmarshal(statement, &s)
+	return s.PredicateType
+}
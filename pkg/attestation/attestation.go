@@ -0,0 +1,129 @@
+package attestation
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// PayloadType is the DSSE payload type of in-toto statements.
+const PayloadType = "application/vnd.in-toto+json"
+
+// Envelope is a DSSE envelope of an in-toto statement, one per line of an
+// .intoto.jsonl bundle.
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+type Signature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// NewEnvelope wraps a statement in an envelope without signatures.
+func NewEnvelope(statement []byte) Envelope {
+	return Envelope{
+		PayloadType: PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(statement),
+		Signatures:  []Signature{},
+	}
+}
+
+// PAE is the DSSE pre-authentication encoding of a payload that is signed.
+func PAE(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// Sign adds the signature of signer to the envelope.
+func (e *Envelope) Sign(signer crypto.Signer) error {
+	payload, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return err
+	}
+
+	message := PAE(e.PayloadType, payload)
+	var sig []byte
+	if _, ok := signer.Public().(ed25519.PublicKey); ok {
+		sig, err = signer.Sign(rand.Reader, message, crypto.Hash(0))
+	} else {
+		digest := sha256.Sum256(message)
+		sig, err = signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	if err != nil {
+		return err
+	}
+
+	e.Signatures = append(e.Signatures, Signature{Sig: base64.StdEncoding.EncodeToString(sig)})
+	return nil
+}
+
+// LoadSigner reads an unencrypted PEM private key.  ECDSA, Ed25519 and RSA
+// keys are supported.
+func LoadSigner(path string) (crypto.Signer, error) {
+	dt, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(dt)
+	if block == nil {
+		return nil, errors.Errorf("no PEM private key in %s", path)
+	}
+
+	var key any
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, errors.Errorf("unsupported private key %q in %s, only unencrypted keys are supported", block.Type, path)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid private key %s", path)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.Errorf("unsupported private key in %s", path)
+	}
+	return signer, nil
+}
+
+// PublicKeyPEM returns the PEM encoded public key of signer.
+func PublicKeyPEM(signer crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// WriteBundle writes the envelopes as JSON Lines.
+func WriteBundle(path string, envelopes []Envelope) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	for _, envelope := range envelopes {
+		if err := enc.Encode(envelope); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
@@ -0,0 +1,74 @@
+package attestation
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSignAndUploadRekor(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statement := []byte(`{"_type":"https://in-toto.io/Statement/v0.1"}`)
+	envelope := NewEnvelope(statement)
+	if err := envelope.Sign(key); err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(envelope.Signatures[0].Sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256(PAE(PayloadType, statement))
+	if !ecdsa.VerifyASN1(&key.PublicKey, digest[:], sig) {
+		t.Fatal("signature does not verify")
+	}
+
+	publicKey, err := PublicKeyPEM(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/log/entries" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		var entry struct {
+			Kind string `json:"kind"`
+			Spec struct {
+				ProposedContent struct {
+					Envelope  string   `json:"envelope"`
+					Verifiers []string `json:"verifiers"`
+				} `json:"proposedContent"`
+			} `json:"spec"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+			t.Fatal(err)
+		}
+		verifier, _ := base64.StdEncoding.DecodeString(entry.Spec.ProposedContent.Verifiers[0])
+		if entry.Kind != "dsse" || string(verifier) != string(publicKey) {
+			t.Errorf("unexpected entry %+v", entry)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"abc123":{"logIndex":42,"integratedTime":1700000000}}`))
+	}))
+	defer srv.Close()
+
+	entry, err := UploadRekor(context.Background(), srv.URL, envelope, publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.UUID != "abc123" || entry.LogIndex != 42 {
+		t.Errorf("unexpected log entry %+v", entry)
+	}
+}
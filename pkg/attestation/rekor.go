@@ -0,0 +1,87 @@
+package attestation
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/depot/cli/pkg/api"
+)
+
+// DefaultRekorURL is the public Sigstore transparency log.
+const DefaultRekorURL = "https://rekor.sigstore.dev"
+
+// LogEntry is the entry of an envelope in the Rekor transparency log.
+type LogEntry struct {
+	PredicateType  string `json:"predicateType,omitempty"`
+	Platform       string `json:"platform,omitempty"`
+	UUID           string `json:"uuid"`
+	LogIndex       int64  `json:"logIndex"`
+	IntegratedTime int64  `json:"integratedTime"`
+	URL            string `json:"url"`
+}
+
+// UploadRekor adds a signed envelope to the Rekor log at rekorURL as a dsse
+// entry that is verified with publicKey.
+func UploadRekor(ctx context.Context, rekorURL string, envelope Envelope, publicKey []byte) (*LogEntry, error) {
+	dt, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, err
+	}
+	entry := map[string]any{
+		"apiVersion": "0.0.1",
+		"kind":       "dsse",
+		"spec": map[string]any{
+			"proposedContent": map[string]any{
+				"envelope":  string(dt),
+				"verifiers": []string{base64.StdEncoding.EncodeToString(publicKey)},
+			},
+		},
+	}
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	rekorURL = strings.TrimSuffix(rekorURL, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rekorURL+"/api/v1/log/entries", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", api.Agent())
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+		return nil, fmt.Errorf("unexpected status code from rekor: %s: %s", res.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var entries map[string]struct {
+		LogIndex       int64 `json:"logIndex"`
+		IntegratedTime int64 `json:"integratedTime"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("invalid rekor response: %w", err)
+	}
+	for uuid, e := range entries {
+		return &LogEntry{
+			UUID:           uuid,
+			LogIndex:       e.LogIndex,
+			IntegratedTime: e.IntegratedTime,
+			URL:            rekorURL + "/api/v1/log/entries/" + uuid,
+		}, nil
+	}
+	return nil, fmt.Errorf("rekor did not return a log entry")
+}
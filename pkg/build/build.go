@@ -10,6 +10,7 @@ import (
 
 	"connectrpc.com/connect"
 	depotapi "github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/progresshelper"
 	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
 	"github.com/docker/buildx/driver"
 	"github.com/docker/buildx/util/progress"
@@ -33,6 +34,9 @@ type Build struct {
 type Credential struct {
 	Host  string
 	Token string
+	// IdentityToken is an OAuth refresh token used instead of the Token, such
+	// as the "identitytoken" of a Docker config.
+	IdentityToken string
 }
 
 func (b *Build) AdditionalTags() []string {
@@ -78,6 +82,27 @@ func (b *Build) AuthProvider(dockerAuth driver.Auth) driver.Auth {
 	return NewAuthProvider(b.AdditionalCredentials(), dockerAuth)
 }
 
+// ForbiddenEntitlements returns the requested entitlements that the project's
+// policy forbids.
+func (b *Build) ForbiddenEntitlements() []string {
+	if b.Response == nil || b.Response.Msg == nil {
+		return nil
+	}
+	return b.Response.Msg.ForbiddenEntitlements
+}
+
+// CacheImport exchanges the build's token for access to the cache of another
+// project of the organization.
+func (b *Build) CacheImport(ctx context.Context, projectID string) (*cliv1.GetCacheImportResponse, error) {
+	client := depotapi.NewBuildClient()
+	req := &cliv1.GetCacheImportRequest{BuildId: b.ID, ProjectId: projectID}
+	res, err := client.GetCacheImport(ctx, depotapi.WithAuthentication(connect.NewRequest(req), b.Token))
+	if err != nil {
+		return nil, err
+	}
+	return res.Msg, nil
+}
+
 // BuildProject returns the project ID to be used for the build.
 // This is important as the API may use a different project ID than the one
 // initially requested (e.g. onboarding)
@@ -93,6 +118,9 @@ func (b *Build) BuildProject() string {
 }
 
 func NewBuild(ctx context.Context, req *cliv1.CreateBuildRequest, token string) (Build, error) {
+	// Release the machines of builds whose CLI process crashed.
+	ReapOrphanedBuilds(ctx)
+
 	client := depotapi.NewBuildClient()
 	res, err := client.CreateBuild(ctx, depotapi.WithAuthentication(connect.NewRequest(req), token))
 	if err != nil {
@@ -103,6 +131,7 @@ func NewBuild(ctx context.Context, req *cliv1.CreateBuildRequest, token string)
 	if err != nil {
 		return Build{}, err
 	}
+	recordRunningBuild(build.ID, build.Token)
 
 	return build, nil
 }
@@ -130,6 +159,8 @@ func FromExistingBuild(ctx context.Context, buildID, token string, buildRes *con
 		if err != nil {
 			log.Printf("error releasing builder: %v", err)
 		}
+		removeRunningBuild(buildID)
+		progresshelper.ForgetSteps(buildID)
 	}
 
 	if buildRes == nil {
@@ -0,0 +1,174 @@
+package build
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"connectrpc.com/connect"
+	depotapi "github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/config"
+	"github.com/depot/cli/pkg/debuglog"
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+)
+
+// RunningBuild is recorded on disk while a CLI process runs a build.  If the
+// process dies without finishing the build, the record is used to cancel the
+// build so that its machines are released.  The record holds the build token,
+// so it is only readable by the user and is removed when the build finishes.
+type RunningBuild struct {
+	BuildID string `json:"build_id"`
+	Token   string `json:"token"`
+	PID     int    `json:"pid"`
+	// Host identifies the host and PID namespace of the process, see hostID.
+	Host      string    `json:"host"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// hostID identifies the host, its boot, and the PID namespace of this
+// process.  The state directory may be shared with other hosts or containers,
+// such as a shared home or a CI cache, where the PIDs of the records mean
+// nothing.
+func hostID() string {
+	id, _ := os.Hostname()
+	if bootID, err := os.ReadFile("/proc/sys/kernel/random/boot_id"); err == nil {
+		id += "/" + strings.TrimSpace(string(bootID))
+	}
+	if ns, err := os.Readlink("/proc/self/ns/pid"); err == nil {
+		id += "/" + ns
+	}
+	return id
+}
+
+func runningBuildFile(buildID string) (string, error) {
+	dir, err := config.RunningBuildsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, buildID+".json"), nil
+}
+
+// recordRunningBuild is best effort; failing to record only means the build
+// cannot be reaped.
+func recordRunningBuild(buildID, token string) {
+	path, err := runningBuildFile(buildID)
+	if err != nil {
+		debuglog.Log("unable to record running build: %v", err)
+		return
+	}
+
+	dt, err := json.Marshal(RunningBuild{BuildID: buildID, Token: token, PID: os.Getpid(), Host: hostID(), StartedAt: time.Now()})
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(path, dt, 0600); err != nil {
+		debuglog.Log("unable to record running build: %v", err)
+		return
+	}
+	// WriteFile keeps the mode of an existing file.
+	_ = os.Chmod(path, 0600)
+}
+
+func removeRunningBuild(buildID string) {
+	path, err := runningBuildFile(buildID)
+	if err != nil {
+		return
+	}
+	_ = os.Remove(path)
+}
+
+// RunningBuilds returns the builds recorded by this and other CLI processes.
+func RunningBuilds() ([]RunningBuild, error) {
+	dir, err := config.RunningBuildsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var builds []RunningBuild
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		dt, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		var build RunningBuild
+		if err := json.Unmarshal(dt, &build); err != nil || build.BuildID == "" {
+			continue
+		}
+		builds = append(builds, build)
+	}
+	return builds, nil
+}
+
+// Orphaned reports whether the process that started the build has exited.
+// Builds of other hosts and PID namespaces, and of versions that did not
+// record the host, are never orphaned, as their process cannot be checked.
+func (b RunningBuild) Orphaned() bool {
+	if b.Host == "" || b.Host != hostID() || b.PID == os.Getpid() {
+		return false
+	}
+
+	p, err := os.FindProcess(b.PID)
+	if err != nil {
+		return true
+	}
+	// On Windows FindProcess fails for processes that do not exist.
+	if runtime.GOOS == "windows" {
+		return false
+	}
+
+	err = p.Signal(syscall.Signal(0))
+	return err != nil && !errors.Is(err, syscall.EPERM)
+}
+
+// Reap cancels the build so that the machines it holds are released and
+// removes its record.
+func (b RunningBuild) Reap(ctx context.Context) error {
+	req := cliv1.FinishBuildRequest{
+		BuildId: b.BuildID,
+		Result:  &cliv1.FinishBuildRequest_Canceled{Canceled: &cliv1.FinishBuildRequest_BuildCanceled{}},
+	}
+	_, err := depotapi.NewBuildClient().FinishBuild(ctx, depotapi.WithAuthentication(connect.NewRequest(&req), b.Token))
+	if err != nil && connect.CodeOf(err) != connect.CodeNotFound && connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		return err
+	}
+
+	removeRunningBuild(b.BuildID)
+	return nil
+}
+
+// ReapOrphanedBuilds cancels the builds of CLI processes that have exited.
+// Errors are logged as this runs before every build.
+func ReapOrphanedBuilds(ctx context.Context) {
+	builds, err := RunningBuilds()
+	if err != nil {
+		return
+	}
+
+	for _, build := range builds {
+		if !build.Orphaned() {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		err := build.Reap(ctx)
+		cancel()
+		if err != nil {
+			debuglog.Log("unable to reap build %s: %v", build.BuildID, err)
+		} else {
+			debuglog.Log("reaped orphaned build %s", build.BuildID)
+		}
+	}
+}
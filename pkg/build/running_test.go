@@ -0,0 +1,34 @@
+package build
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func TestRunningBuildOrphaned(t *testing.T) {
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+	exited := cmd.Process.Pid
+
+	tests := []struct {
+		name  string
+		build RunningBuild
+		want  bool
+	}{
+		{name: "exited", build: RunningBuild{PID: exited, Host: hostID()}, want: true},
+		{name: "running", build: RunningBuild{PID: os.Getppid(), Host: hostID()}, want: false},
+		{name: "this process", build: RunningBuild{PID: os.Getpid(), Host: hostID()}, want: false},
+		{name: "other host", build: RunningBuild{PID: exited, Host: "ci-runner-2/boot/pid:[1]"}, want: false},
+		{name: "no host", build: RunningBuild{PID: exited}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.build.Orphaned(); got != tt.want {
+				t.Errorf("Orphaned() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 
 	composecli "github.com/compose-spec/compose-go/v2/cli"
 	"github.com/depot/cli/pkg/buildx/bake/hclparser"
+	depotbuildflags "github.com/depot/cli/pkg/buildx/buildflags"
 	"github.com/docker/buildx/build"
 	"github.com/docker/buildx/util/buildflags"
 	"github.com/docker/buildx/util/platformutil"
@@ -941,10 +942,15 @@ func (t *Target) GetName(ectx *hcl.EvalContext, block *hcl.Block, loadDeps func(
 
 type DepotBakeOptions struct {
 	ProjectTargetOptions map[string]map[string]build.Options
+	// Files are the bake and compose files the targets were read from.
+	Files []File
 }
 
+// NewDepotBakeOptions converts the targets to build options by project.  The
+// "cmd" credential processes of secrets are only allowed with allowSecretCmd,
+// as bake files, even remote ones, would otherwise run commands.
 // input is only used for remote bake.
-func NewDepotBakeOptions(defaultProjectID string, targets map[string]*Target, input *Input) (*DepotBakeOptions, error) {
+func NewDepotBakeOptions(defaultProjectID string, targets map[string]*Target, input *Input, allowSecretCmd bool) (*DepotBakeOptions, error) {
 	opts := &DepotBakeOptions{
 		ProjectTargetOptions: map[string]map[string]build.Options{},
 	}
@@ -957,7 +963,7 @@ func NewDepotBakeOptions(defaultProjectID string, targets map[string]*Target, in
 		if projectID == "" {
 			return nil, errors.Errorf("Project ID is missing for target %s, please specify with --project, DEPOT_PROJECT_ID, or run `depot init`", targetName)
 		}
-		buildOpt, err := toBuildOpt(target, input)
+		buildOpt, err := toBuildOpt(target, input, allowSecretCmd)
 		if err != nil {
 			return nil, err
 		}
@@ -976,6 +982,13 @@ func (o *DepotBakeOptions) ProjectOpts(id string) map[string]build.Options {
 	return o.ProjectTargetOptions[id]
 }
 
+// ValidateTarget checks that the target converts to build options, such as
+// its platforms, outputs, cache, secrets, and attestations.
+func ValidateTarget(target *Target, allowSecretCmd bool) error {
+	_, err := toBuildOpt(target, nil, allowSecretCmd)
+	return err
+}
+
 // ProjectIDs returns the x-depot project IDs.
 func (o *DepotBakeOptions) ProjectIDs() []string {
 	projectIDs := make([]string, 0, len(o.ProjectTargetOptions))
@@ -1081,7 +1094,7 @@ func checkPath(p string) error {
 	return nil
 }
 
-func toBuildOpt(t *Target, inp *Input) (*build.Options, error) {
+func toBuildOpt(t *Target, inp *Input, allowSecretCmd bool) (*build.Options, error) {
 	if v := t.Context; v != nil && *v == "-" {
 		return nil, errors.Errorf("context from stdin not allowed in bake")
 	}
@@ -1186,7 +1199,7 @@ func toBuildOpt(t *Target, inp *Input) (*build.Options, error) {
 	dockerConfig := config.LoadDefaultConfigFile(os.Stderr)
 	bo.Session = append(bo.Session, authprovider.NewDockerAuthProvider(dockerConfig))
 
-	secrets, err := buildflags.ParseSecretSpecs(t.Secrets)
+	secrets, err := depotbuildflags.ParseSecretSpecs(t.Secrets, allowSecretCmd)
 	if err != nil {
 		return nil, err
 	}
@@ -1196,7 +1209,7 @@ func toBuildOpt(t *Target, inp *Input) (*build.Options, error) {
 	if len(sshSpecs) == 0 && buildflags.IsGitSSH(contextPath) {
 		sshSpecs = []string{"default"}
 	}
-	ssh, err := buildflags.ParseSSHSpecs(sshSpecs)
+	ssh, err := depotbuildflags.ParseSSHSpecs(sshSpecs)
 	if err != nil {
 		return nil, err
 	}
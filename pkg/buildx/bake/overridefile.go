@@ -0,0 +1,262 @@
+package bake
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// OverrideFile is a JSON or YAML file of target overrides passed with --set-file.
+//
+//	target:
+//	  "*":
+//	    args:
+//	      VERSION: "1.2.3"
+//	  api:
+//	    tags: [example/api:latest, example/api:1.2.3]
+//	patch:
+//	  - op: add
+//	    path: /api/cache-from/-
+//	    value: type=registry,ref=example/api:cache
+type OverrideFile struct {
+	// Target maps target patterns to the keys they override, as with --set.
+	Target map[string]map[string]interface{} `yaml:"target"`
+	// Patch are JSON patch operations applied to the resolved targets.
+	Patch []PatchOp `yaml:"patch"`
+}
+
+// PatchOp is an add, replace, or remove operation of a JSON patch.  The
+// path is a JSON pointer starting with the target name, e.g. /api/attest/0.
+type PatchOp struct {
+	Op    string      `yaml:"op"`
+	Path  string      `yaml:"path"`
+	Value interface{} `yaml:"value"`
+}
+
+// ReadOverrideFile reads and validates an override file.
+func ReadOverrideFile(path string) (*OverrideFile, error) {
+	dt, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f OverrideFile
+	dec := yaml.NewDecoder(bytes.NewReader(dt))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil {
+		return nil, errors.Wrapf(err, "invalid override file %s", path)
+	}
+	for _, op := range f.Patch {
+		switch op.Op {
+		case "add", "replace", "remove":
+		default:
+			return nil, errors.Errorf("invalid override file %s: unsupported patch op %q", path, op.Op)
+		}
+	}
+	return &f, nil
+}
+
+// Overrides returns the target overrides of the file in the --set format.
+func (f *OverrideFile) Overrides() ([]string, error) {
+	var overrides []string
+	for _, pattern := range sortedKeys(f.Target) {
+		keys := f.Target[pattern]
+		for _, key := range sortedKeys(keys) {
+			prefix := pattern + "." + key
+			switch v := keys[key].(type) {
+			case []interface{}:
+				for _, elem := range v {
+					s, err := overrideValue(prefix, elem)
+					if err != nil {
+						return nil, err
+					}
+					overrides = append(overrides, prefix+"="+s)
+				}
+			case map[string]interface{}:
+				for _, name := range sortedKeys(v) {
+					if v[name] == nil {
+						// Like --set target.args.NAME, reads the value from the environment.
+						overrides = append(overrides, prefix+"."+name)
+						continue
+					}
+					s, err := overrideValue(prefix+"."+name, v[name])
+					if err != nil {
+						return nil, err
+					}
+					overrides = append(overrides, prefix+"."+name+"="+s)
+				}
+			default:
+				s, err := overrideValue(prefix, v)
+				if err != nil {
+					return nil, err
+				}
+				overrides = append(overrides, prefix+"="+s)
+			}
+		}
+	}
+	return overrides, nil
+}
+
+func overrideValue(key string, v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case bool, int, int64, float64:
+		return fmt.Sprint(v), nil
+	default:
+		return "", errors.Errorf("invalid value for override %s: expected a string, number, or boolean", key)
+	}
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ApplyPatches applies JSON patch operations to the targets as they are
+// printed by --print.  Missing objects and arrays on the path of an add are
+// created, so that e.g. /api/args/VERSION works for targets without args.
+func ApplyPatches(targets map[string]*Target, patches []PatchOp) error {
+	if len(patches) == 0 {
+		return nil
+	}
+
+	dt, err := json.Marshal(targets)
+	if err != nil {
+		return err
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(dt, &doc); err != nil {
+		return err
+	}
+
+	for _, op := range patches {
+		tokens, err := parsePointer(op.Path)
+		if err != nil {
+			return err
+		}
+		if len(tokens) < 2 {
+			return errors.Errorf("invalid patch path %q: expected /target/key", op.Path)
+		}
+		if _, ok := targets[tokens[0]]; !ok {
+			return errors.Errorf("invalid patch path %q: unknown target %s", op.Path, tokens[0])
+		}
+		if _, err := patchValue(doc, tokens, op); err != nil {
+			return errors.Wrapf(err, "unable to %s %s", op.Op, op.Path)
+		}
+	}
+
+	for name, t := range targets {
+		dt, err := json.Marshal(doc[name])
+		if err != nil {
+			return err
+		}
+		var patched Target
+		if err := json.Unmarshal(dt, &patched); err != nil {
+			return errors.Wrapf(err, "invalid patched target %s", name)
+		}
+		patched.Name = t.Name
+		patched.NetworkMode = t.NetworkMode
+		patched.linked = t.linked
+		*t = patched
+	}
+	return nil
+}
+
+// parsePointer splits a JSON pointer into its unescaped reference tokens.
+func parsePointer(path string) ([]string, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, errors.Errorf("invalid patch path %q: must start with /", path)
+	}
+	tokens := strings.Split(path[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+// patchValue applies the operation at the path of tokens below node and
+// returns the changed node, which differs from node when an array grows.
+func patchValue(node interface{}, tokens []string, op PatchOp) (interface{}, error) {
+	token, last := tokens[0], len(tokens) == 1
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[token]
+		if last {
+			switch {
+			case op.Op == "add":
+				n[token] = op.Value
+			case !ok:
+				return nil, errors.Errorf("%s not found", token)
+			case op.Op == "replace":
+				n[token] = op.Value
+			default:
+				delete(n, token)
+			}
+			return n, nil
+		}
+		if !ok || child == nil {
+			if op.Op != "add" {
+				return nil, errors.Errorf("%s not found", token)
+			}
+			child = map[string]interface{}{}
+			if next := tokens[1]; next == "-" || isIndex(next) {
+				child = []interface{}{}
+			}
+		}
+		child, err := patchValue(child, tokens[1:], op)
+		if err != nil {
+			return nil, err
+		}
+		n[token] = child
+		return n, nil
+
+	case []interface{}:
+		if token == "-" && last && op.Op == "add" {
+			return append(n, op.Value), nil
+		}
+		i, err := strconv.Atoi(token)
+		if err != nil || i < 0 || i > len(n) || (i == len(n) && !(last && op.Op == "add")) {
+			return nil, errors.Errorf("index %s out of range", token)
+		}
+		if !last {
+			child, err := patchValue(n[i], tokens[1:], op)
+			if err != nil {
+				return nil, err
+			}
+			n[i] = child
+			return n, nil
+		}
+		switch op.Op {
+		case "add":
+			n = append(n, nil)
+			copy(n[i+1:], n[i:])
+			n[i] = op.Value
+		case "replace":
+			n[i] = op.Value
+		default:
+			n = append(n[:i], n[i+1:]...)
+		}
+		return n, nil
+
+	default:
+		return nil, errors.Errorf("%s is not in an object or array", token)
+	}
+}
+
+func isIndex(token string) bool {
+	_, err := strconv.Atoi(token)
+	return err == nil
+}
@@ -0,0 +1,88 @@
+package bake
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestOverrideFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.yaml")
+	err := os.WriteFile(path, []byte(`
+target:
+  "*":
+    no-cache: true
+    args:
+      VERSION: 1.2.3
+      TOKEN:
+  api:
+    tags: [example/api:latest, example/api:1.2.3]
+patch:
+  - op: replace
+    path: /api/attest/0
+    value: type=sbom,generator=example/scanner
+  - op: add
+    path: /api/cache-from/-
+    value: type=registry,ref=example/api:cache
+  - op: add
+    path: /api/labels/org.opencontainers.image~1source
+    value: https://example.com
+  - op: remove
+    path: /api/platforms/1
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := ReadOverrideFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	overrides, err := f.Overrides()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"*.args.TOKEN",
+		"*.args.VERSION=1.2.3",
+		"*.no-cache=true",
+		"api.tags=example/api:latest",
+		"api.tags=example/api:1.2.3",
+	}
+	if !reflect.DeepEqual(overrides, want) {
+		t.Errorf("Overrides() = %q, want %q", overrides, want)
+	}
+
+	targets := map[string]*Target{
+		"api": {
+			Name:      "api",
+			Attest:    []string{"type=sbom"},
+			Platforms: []string{"linux/amd64", "linux/arm64"},
+		},
+	}
+	if err := ApplyPatches(targets, f.Patch); err != nil {
+		t.Fatal(err)
+	}
+	api := targets["api"]
+	if api.Name != "api" {
+		t.Errorf("Name = %q, want api", api.Name)
+	}
+	if !reflect.DeepEqual(api.Attest, []string{"type=sbom,generator=example/scanner"}) {
+		t.Errorf("Attest = %q", api.Attest)
+	}
+	if !reflect.DeepEqual(api.CacheFrom, []string{"type=registry,ref=example/api:cache"}) {
+		t.Errorf("CacheFrom = %q", api.CacheFrom)
+	}
+	if v := api.Labels["org.opencontainers.image/source"]; v == nil || *v != "https://example.com" {
+		t.Errorf("Labels = %v", api.Labels)
+	}
+	if !reflect.DeepEqual(api.Platforms, []string{"linux/amd64"}) {
+		t.Errorf("Platforms = %q", api.Platforms)
+	}
+
+	err = ApplyPatches(targets, []PatchOp{{Op: "replace", Path: "/web/tags/0", Value: "x"}})
+	if err == nil {
+		t.Error("expected an error patching an unknown target")
+	}
+}
@@ -0,0 +1,120 @@
+package bake
+
+import (
+	"encoding/json"
+)
+
+// OverrideSource is the source of attributes set by --set and --set-file.
+const OverrideSource = "--set"
+
+// flattenedAttributes are the attributes whose keys are attributed one by one,
+// e.g. "args.VERSION".
+var flattenedAttributes = map[string]struct{}{
+	"args":     {},
+	"contexts": {},
+	"labels":   {},
+}
+
+// PrecedenceOrder returns the files in the order they are merged by
+// ParseFiles: compose files first, then HCL and JSON files, each in the
+// order given.  Attributes of later files override those of earlier ones.
+func PrecedenceOrder(files []File) []File {
+	var compose, other []File
+	for _, f := range files {
+		if isCompose, _ := validateComposeFile(f.Data, f.Name); isCompose {
+			compose = append(compose, f)
+		} else {
+			other = append(other, f)
+		}
+	}
+	return append(compose, other...)
+}
+
+// ReadSources returns the file that set each attribute of the targets, keyed
+// by target and attribute.  Each file is attributed with the attributes it
+// added or changed when merged after the files that precede it.  Attributes
+// of the targets that differ from the merged files were set by overrides.
+func ReadSources(files []File, targets map[string]*Target, defaults map[string]string) map[string]map[string]string {
+	sources := map[string]map[string]string{}
+	for name := range targets {
+		sources[name] = map[string]string{}
+	}
+
+	ordered := PrecedenceOrder(files)
+	previous := map[string]map[string]string{}
+	for i := range ordered {
+		// Files that use variables of later files can only be parsed with
+		// them; their attributes are attributed to the later file.
+		c, err := ParseFiles(ordered[:i+1], defaults)
+		if err != nil {
+			continue
+		}
+		for name := range targets {
+			attrs := resolvedAttributes(c, name)
+			for attr, value := range attrs {
+				if prev, ok := previous[name][attr]; !ok || prev != value {
+					sources[name][attr] = ordered[i].Name
+				}
+			}
+			previous[name] = attrs
+		}
+	}
+
+	for name, t := range targets {
+		for attr, value := range targetAttributes(t) {
+			prev, ok := previous[name][attr]
+			if ok && prev == value {
+				continue
+			}
+			if !ok && isDefaultAttribute(attr, value) {
+				continue
+			}
+			sources[name][attr] = OverrideSource
+		}
+	}
+	return sources
+}
+
+// isDefaultAttribute returns true for the defaults of ResolveTarget.
+func isDefaultAttribute(attr, value string) bool {
+	return (attr == "context" && value == `"."`) || (attr == "dockerfile" && value == `"Dockerfile"`)
+}
+
+// resolvedAttributes returns the attributes of a target with those it
+// inherits, but without the defaults of ResolveTarget.
+func resolvedAttributes(c *Config, name string) map[string]string {
+	t, err := c.target(name, map[string]*Target{}, nil)
+	if err != nil || t == nil {
+		return map[string]string{}
+	}
+	return targetAttributes(t)
+}
+
+// targetAttributes returns the JSON value of each attribute of a target.
+func targetAttributes(t *Target) map[string]string {
+	attrs := map[string]string{}
+	dt, err := json.Marshal(t)
+	if err != nil {
+		return attrs
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(dt, &fields); err != nil {
+		return attrs
+	}
+	for key, value := range fields {
+		if key == "inherits" {
+			continue
+		}
+		if _, ok := flattenedAttributes[key]; ok {
+			var values map[string]json.RawMessage
+			if err := json.Unmarshal(value, &values); err == nil {
+				for k, v := range values {
+					attrs[key+"."+k] = string(v)
+				}
+				continue
+			}
+		}
+		attrs[key] = string(value)
+	}
+	return attrs
+}
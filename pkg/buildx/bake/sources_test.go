@@ -0,0 +1,60 @@
+package bake
+
+import (
+	"context"
+	"testing"
+)
+
+func TestReadSources(t *testing.T) {
+	compose := File{Name: "docker-compose.yml", Data: []byte(`
+services:
+  api:
+    build:
+      context: ./api
+      dockerfile: Dockerfile.api
+      args:
+        VERSION: "1"
+        MODE: compose
+`)}
+	hcl := File{Name: "docker-bake.hcl", Data: []byte(`
+target "base" {
+  platforms = ["linux/amd64", "linux/arm64"]
+}
+target "api" {
+  inherits = ["base"]
+  args = {
+    MODE = "hcl"
+  }
+  tags = ["example/api:latest"]
+}
+`)}
+
+	// The HCL file is given first but still overrides the compose file.
+	files := []File{hcl, compose}
+	targets, _, err := ReadTargets(context.Background(), files, []string{"api"}, []string{"api.target=release"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := *targets["api"].Args["MODE"]; got != "hcl" {
+		t.Errorf("expected the HCL file to override the compose file, got MODE=%s", got)
+	}
+
+	sources := ReadSources(files, targets, nil)["api"]
+	want := map[string]string{
+		"context":      "docker-compose.yml",
+		"dockerfile":   "docker-compose.yml",
+		"args.VERSION": "docker-compose.yml",
+		"args.MODE":    "docker-bake.hcl",
+		"platforms":    "docker-bake.hcl",
+		"tags":         "docker-bake.hcl",
+		"target":       OverrideSource,
+	}
+	for attr, source := range want {
+		if sources[attr] != source {
+			t.Errorf("source of %s = %q, want %q", attr, sources[attr], source)
+		}
+	}
+	if len(sources) != len(want) {
+		t.Errorf("expected %d sources, got %v", len(want), sources)
+	}
+}
@@ -95,6 +95,20 @@ type Options struct {
 	Target        string
 	Ulimits       *opts.UlimitOpt
 
+	// Frontend is the buildkitd frontend, the Dockerfile frontend if nil.
+	Frontend *Frontend
+	// RunLimits caps the resources of the RUN steps, unlimited if nil.
+	RunLimits *RunLimits
+	// IncludeGitDir sends the .git directory of the context even if the
+	// Dockerfile does not appear to use it.
+	IncludeGitDir bool
+	// CacheMountPolicy is the sharing mode of the cache mounts of RUN steps
+	// that do not set one, the mode of the Dockerfile if empty.
+	CacheMountPolicy string
+	// ContextIgnores applies ignore files to the local named contexts on the
+	// client if set.
+	ContextIgnores *ContextIgnores
+
 	// Linked marks this target as exclusively linked (not requested by the user).
 	Linked    bool
 	PrintFunc *PrintFunc
@@ -369,7 +383,7 @@ func toSolveOpt(ctx context.Context, node builder.Node, multiDriver bool, opt Op
 	cacheFrom = append(cacheFrom, opt.CacheFrom...)
 
 	so := client.SolveOpt{
-		Frontend:            "dockerfile.v0",
+		Frontend:            DockerfileFrontend,
 		FrontendAttrs:       map[string]string{},
 		LocalDirs:           map[string]string{},
 		CacheExports:        cacheTo,
@@ -380,6 +394,12 @@ func toSolveOpt(ctx context.Context, node builder.Node, multiDriver bool, opt Op
 	if opt.CgroupParent != "" {
 		so.FrontendAttrs["cgroup-parent"] = opt.CgroupParent
 	}
+	for k, v := range opt.RunLimits.frontendAttrs() {
+		so.FrontendAttrs[k] = v
+	}
+	if opt.CacheMountPolicy != "" {
+		so.FrontendAttrs["depot.cache-mount-sharing"] = opt.CacheMountPolicy
+	}
 
 	if v, ok := opt.BuildArgs["BUILDKIT_MULTI_PLATFORM"]; ok {
 		if v, _ := strconv.ParseBool(v); v {
@@ -516,6 +536,9 @@ func toSolveOpt(ctx context.Context, node builder.Node, multiDriver bool, opt Op
 		}
 		so.SharedKey = sharedKey + ":" + tryNodeIdentifier(configDir)
 	}
+	if err := serveLocalDirs(opt, dockerfile, &so, pw); err != nil {
+		return nil, nil, nil, err
+	}
 
 	if opt.Pull {
 		so.FrontendAttrs["image-resolve-mode"] = "pull"
@@ -595,6 +618,15 @@ func toSolveOpt(ctx context.Context, node builder.Node, multiDriver bool, opt Op
 		so.FrontendAttrs["ulimit"] = ulimits
 	}
 
+	if opt.Frontend != nil {
+		if opt.Frontend.Name != "" {
+			so.Frontend = opt.Frontend.Name
+		}
+		for k, v := range opt.Frontend.Opts {
+			so.FrontendAttrs[k] = v
+		}
+	}
+
 	return &so, dockerfile, releaseF, nil
 }
 
@@ -756,7 +788,7 @@ type DepotNodeResponse struct {
 	SolveResponse *client.SolveResponse
 }
 
-func BuildWithResultHandler(ctx context.Context, nodes []builder.Node, opt map[string]Options, docker *dockerutil.Client, configDir string, w progress.Writer, dockerfileCallback DockerfileCallback, resultHandleFunc func(driverIndex int, rCtx *ResultContext), allowNoOutput bool, build *depotbuild.Build) (resp []DepotBuildResponse, err error) {
+func BuildWithResultHandler(ctx context.Context, nodes []builder.Node, opt map[string]Options, docker *dockerutil.Client, configDir string, w progress.Writer, dockerfileCallback DockerfileCallback, resultHandleFunc func(driverIndex int, rCtx *ResultContext), allowNoOutput bool, build *depotbuild.Build, failureMode FailureMode) (resp []DepotBuildResponse, err error) {
 	if len(nodes) == 0 {
 		return nil, errors.Errorf("driver required for build")
 	}
@@ -801,6 +833,10 @@ func BuildWithResultHandler(ctx context.Context, nodes []builder.Node, opt map[s
 	}
 	debuglog.Log("Drivers resolved")
 
+	if err := checkBuilderCapabilities(ctx, nodes, m, opt); err != nil {
+		return nil, err
+	}
+
 	defers := make([]func(), 0, 2)
 	defer func() {
 		if err != nil {
@@ -836,7 +872,7 @@ func BuildWithResultHandler(ctx context.Context, nodes []builder.Node, opt map[s
 				return nil, err
 			}
 
-			if dockerfileCallback != nil {
+			if dockerfileCallback != nil && opt.Frontend.UsesDockerfile() {
 				debuglog.Log("Calling dockerfile callback")
 				if err := dockerfileCallback.Handle(ctx, k, np.driverIndex, dockerfile, w); err != nil {
 					return nil, err
@@ -903,6 +939,8 @@ func BuildWithResultHandler(ctx context.Context, nodes []builder.Node, opt map[s
 	resp = []DepotBuildResponse{}
 	var respMu sync.Mutex
 	results := waitmap.New()
+	// failed are the errors of the failed targets of a KeepGoing build.
+	failed := map[string]error{}
 
 	multiTarget := len(opt) > 1
 
@@ -958,7 +996,13 @@ func BuildWithResultHandler(ctx context.Context, nodes []builder.Node, opt map[s
 				pw := progress.WithPrefix(w, k, multiTarget)
 
 				c := clients[dp.driverIndex]
-				eg2.Go(func() error {
+				eg2.Go(func() (err error) {
+					defer func() {
+						// Fail the targets waiting on this one as a context.
+						if err != nil {
+							results.Set(resultKey(dp.driverIndex, k), &dependencyError{target: k})
+						}
+					}()
 					debuglog.Log("Preparing to call client Build()")
 					pw = progress.ResetTime(pw)
 
@@ -1112,6 +1156,12 @@ func BuildWithResultHandler(ctx context.Context, nodes []builder.Node, opt map[s
 				}()
 				pw := progress.WithPrefix(w, "default", false)
 				if err := eg2.Wait(); err != nil {
+					if failureMode == KeepGoing {
+						respMu.Lock()
+						failed[k] = err
+						respMu.Unlock()
+						return nil
+					}
 					return err
 				}
 
@@ -1242,14 +1292,8 @@ func BuildWithResultHandler(ctx context.Context, nodes []builder.Node, opt map[s
 
 							itpush := imagetools.New(imageopt)
 
-							for _, n := range names {
-								nn, err := reference.ParseNormalizedNamed(n)
-								if err != nil {
-									return err
-								}
-								if err := itpush.Push(ctx, nn, desc, dt); err != nil {
-									return err
-								}
+							if err := pushManifestList(ctx, itpush, names, ref, descs, desc, dt); err != nil {
+								return err
 							}
 							{
 								// DEPOT: Return all results rather than just the first one.
@@ -1306,6 +1350,9 @@ func BuildWithResultHandler(ctx context.Context, nodes []builder.Node, opt map[s
 	if err := eg.Wait(); err != nil {
 		return nil, err
 	}
+	if len(failed) > 0 {
+		return resp, &TargetsError{Errors: failed}
+	}
 
 	return resp, nil
 }
@@ -1636,10 +1683,7 @@ func LoadInputs(ctx context.Context, d driver.Driver, inp Inputs, pw progress.Wr
 		if !st.IsDir() {
 			return nil, errors.Wrapf(syscall.ENOTDIR, "failed to get build context path %v", v)
 		}
-		localName := k
-		if k == "context" || k == "dockerfile" {
-			localName = "_" + k // underscore to avoid collisions
-		}
+		localName := namedContextLocalName(k)
 		target.LocalDirs[localName] = v.Path
 		target.FrontendAttrs["context:"+k] = "local:" + localName
 	}
@@ -1687,6 +1731,9 @@ func waitContextDeps(ctx context.Context, index int, results *waitmap.Map, so *c
 		if !ok {
 			continue
 		}
+		if err, ok := r.(*dependencyError); ok {
+			return err
+		}
 		rr, ok := r.(*gateway.Result)
 		if !ok {
 			return errors.Errorf("invalid result type %T", rr)
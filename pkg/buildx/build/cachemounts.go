@@ -0,0 +1,27 @@
+package build
+
+import "github.com/pkg/errors"
+
+// Cache mount policies are the sharing mode of the RUN --mount=type=cache
+// mounts that do not set one with sharing=.
+const (
+	// CacheMountShared lets concurrent builds use a cache mount at once, the
+	// default of the Dockerfile frontend.
+	CacheMountShared = "shared"
+	// CacheMountPrivate gives each concurrent build its own copy of the cache
+	// mount.
+	CacheMountPrivate = "private"
+	// CacheMountLocked makes concurrent builds wait for the cache mount, for
+	// tools whose caches are corrupted by concurrent writers.
+	CacheMountLocked = "locked"
+)
+
+// ValidateCacheMountPolicy checks that the policy is a cache mount sharing
+// mode.  The empty policy keeps the sharing modes of the Dockerfile.
+func ValidateCacheMountPolicy(policy string) error {
+	switch policy {
+	case "", CacheMountShared, CacheMountPrivate, CacheMountLocked:
+		return nil
+	}
+	return errors.Errorf("unknown cache mount policy %q, expected shared, private, or locked", policy)
+}
@@ -0,0 +1,16 @@
+package build
+
+import "testing"
+
+func TestValidateCacheMountPolicy(t *testing.T) {
+	for _, policy := range []string{"", CacheMountShared, CacheMountPrivate, CacheMountLocked} {
+		if err := ValidateCacheMountPolicy(policy); err != nil {
+			t.Errorf("expected %q to be valid: %v", policy, err)
+		}
+	}
+	for _, policy := range []string{"exclusive", "Shared"} {
+		if err := ValidateCacheMountPolicy(policy); err == nil {
+			t.Errorf("expected %q to be invalid", policy)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package build
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/buildx/builder"
+	"github.com/hashicorp/go-version"
+	"github.com/moby/buildkit/client"
+	"github.com/pkg/errors"
+)
+
+// builderRequirement is a build feature only supported by newer buildkitd versions.
+type builderRequirement struct {
+	feature    string
+	minVersion string
+}
+
+// builderRequirements returns the features of the build options that need a
+// minimum buildkitd version.
+func builderRequirements(opt Options) []builderRequirement {
+	var reqs []builderRequirement
+
+	for _, v := range opt.Attests {
+		if v != nil {
+			reqs = append(reqs, builderRequirement{feature: "attestations (--sbom, --provenance, --attest)", minVersion: "0.11.0"})
+			break
+		}
+	}
+
+	for _, cacheType := range append(cacheTypes(opt.CacheFrom), cacheTypes(opt.CacheTo)...) {
+		switch cacheType {
+		case "gha":
+			reqs = append(reqs, builderRequirement{feature: "the gha cache backend", minVersion: "0.10.0"})
+		case "s3", "azblob":
+			reqs = append(reqs, builderRequirement{feature: "the " + cacheType + " cache backend", minVersion: "0.11.0"})
+		}
+	}
+
+	if len(opt.Inputs.NamedContexts) > 0 {
+		reqs = append(reqs, builderRequirement{feature: "named build contexts (--build-context)", minVersion: "0.10.0"})
+	}
+
+	return reqs
+}
+
+// checkBuilderVersion returns an error for the first requirement the builder
+// version does not meet.  Unknown and development versions are not checked
+// and left for buildkitd to reject.
+func checkBuilderVersion(builderVersion string, reqs []builderRequirement) error {
+	current, err := version.NewVersion(builderVersion)
+	if err != nil {
+		return nil
+	}
+	// Depot builds of buildkit are tagged as pre-releases, e.g. v0.11.6-depot.39.
+	current = releaseVersion(current)
+
+	for _, req := range reqs {
+		if current.LessThan(version.Must(version.NewVersion(req.minVersion))) {
+			return errors.Errorf("%s requires builder >= v%s, but the builder is running buildkit %s", req.feature, req.minVersion, builderVersion)
+		}
+	}
+	return nil
+}
+
+// checkBuilderCapabilities rejects features the builders of each target do not support.
+func checkBuilderCapabilities(ctx context.Context, nodes []builder.Node, m map[string][]driverPair, opts map[string]Options) error {
+	for name, opt := range opts {
+		reqs := builderRequirements(opt)
+		if len(reqs) == 0 {
+			continue
+		}
+		for _, dp := range m[name] {
+			builderVersion, err := nodes[dp.driverIndex].Driver.Version(ctx)
+			if err != nil || builderVersion == "" {
+				continue
+			}
+			if err := checkBuilderVersion(builderVersion, reqs); err != nil {
+				if name != "default" {
+					return errors.Wrapf(err, "target %s", name)
+				}
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func cacheTypes(entries []client.CacheOptionsEntry) []string {
+	types := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		types = append(types, entry.Type)
+	}
+	return types
+}
+
+func releaseVersion(v *version.Version) *version.Version {
+	segments := v.Segments()
+	parts := make([]string, len(segments))
+	for i, s := range segments {
+		parts[i] = fmt.Sprint(s)
+	}
+	return version.Must(version.NewVersion(strings.Join(parts, ".")))
+}
@@ -0,0 +1,23 @@
+package build
+
+import "testing"
+
+func TestCheckBuilderVersion(t *testing.T) {
+	reqs := []builderRequirement{{feature: "attestations", minVersion: "0.11.0"}}
+	tests := []struct {
+		version string
+		wantErr bool
+	}{
+		{version: "v0.10.6", wantErr: true},
+		{version: "v0.11.0", wantErr: false},
+		{version: "v0.11.6-depot.39", wantErr: false},
+		{version: "v0.11.0-rc1", wantErr: false},
+		{version: "dev", wantErr: false},
+	}
+	for _, tt := range tests {
+		err := checkBuilderVersion(tt.version, reqs)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("checkBuilderVersion(%q) error = %v, wantErr %v", tt.version, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package build
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/docker/buildx/util/progress"
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/frontend/dockerfile/dockerignore"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/filesync"
+	"github.com/pkg/errors"
+)
+
+// ContextIgnores applies ignore files to the local named contexts of a build
+// on the client, so that the ignored files are never sent to the builder,
+// whatever the frontend does with them.
+type ContextIgnores struct {
+	// Dockerignore applies the .dockerignore of each local named context.
+	Dockerignore bool
+	// Files are the ignore files of named contexts by name, applied instead
+	// of their .dockerignore.
+	Files map[string]string
+}
+
+// namedContextLocalName is the name of the local directory of a named context.
+func namedContextLocalName(name string) string {
+	if name == "context" || name == "dockerfile" {
+		return "_" + name // underscore to avoid collisions
+	}
+	return name
+}
+
+// serveLocalDirs serves the local directories of the build from a file sync
+// provider of our own, as the one of the buildkit client cannot skip paths,
+// when the .git directory of the context is skipped or the named contexts
+// have ignore files to apply.
+func serveLocalDirs(opt Options, dockerfile *DockerfileInputs, so *client.SolveOpt, pw progress.Writer) error {
+	skipGit := excludeGitDir(opt, dockerfile, so)
+	excludes, err := namedContextExcludes(opt, so)
+	if err != nil {
+		return err
+	}
+	if !skipGit && len(excludes) == 0 {
+		return nil
+	}
+
+	dirs := make(filesync.StaticDirSource, len(so.LocalDirs))
+	for name, dir := range so.LocalDirs {
+		fi, err := os.Stat(dir)
+		if err != nil {
+			return errors.Wrapf(err, "could not find %s", dir)
+		}
+		if !fi.IsDir() {
+			return errors.Errorf("%s not a directory", dir)
+		}
+		mapFn := resetUIDAndGID
+		if name == "context" && skipGit {
+			mapFn = skipGitDir
+		}
+		// The excludes of the directory replace those the frontend requests.
+		dirs[name] = filesync.SyncedDir{Dir: dir, Excludes: excludes[name], Map: mapFn}
+	}
+
+	// The sessions of the options are shared by the nodes of the build.
+	so.Session = append(append([]session.Attachable{}, so.Session...), filesync.NewFSSyncProvider(dirs))
+	so.LocalDirs = nil
+
+	if skipGit {
+		progress.Write(pw, "[internal] excluding .git from the build context (use --include-git-dir to send it)", func() error { return nil })
+	}
+	if len(excludes) > 0 {
+		names := make([]string, 0, len(excludes))
+		for name := range opt.Inputs.NamedContexts {
+			if _, ok := excludes[namedContextLocalName(name)]; ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		progress.Write(pw, fmt.Sprintf("[internal] excluding ignored files from build contexts %s", strings.Join(names, ", ")), func() error { return nil })
+	}
+	return nil
+}
+
+// namedContextExcludes reads the ignore file of each local named context: the
+// file given for it, or else its .dockerignore if ContextIgnores.Dockerignore
+// is set.  The excludes are keyed by the local directory of the context.
+func namedContextExcludes(opt Options, so *client.SolveOpt) (map[string][]string, error) {
+	ignores := opt.ContextIgnores
+	if ignores == nil {
+		return nil, nil
+	}
+
+	excludes := map[string][]string{}
+	for name, nc := range opt.Inputs.NamedContexts {
+		localName := namedContextLocalName(name)
+		// Only local directories are synced from the client.
+		if nc.State != nil || so.LocalDirs[localName] != nc.Path {
+			continue
+		}
+
+		path, ok := ignores.Files[name]
+		if !ok {
+			if !ignores.Dockerignore {
+				continue
+			}
+			path = filepath.Join(nc.Path, ".dockerignore")
+		}
+		patterns, err := readIgnoreFile(path)
+		switch {
+		case os.IsNotExist(err) && !ok:
+			continue
+		case err != nil:
+			return nil, errors.Wrapf(err, "unable to read the ignore file of build context %s", name)
+		}
+		if len(patterns) > 0 {
+			excludes[localName] = patterns
+		}
+	}
+	return excludes, nil
+}
+
+func readIgnoreFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return dockerignore.ReadAll(f)
+}
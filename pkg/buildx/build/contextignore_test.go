@@ -0,0 +1,71 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/client/llb"
+)
+
+func TestNamedContextExcludes(t *testing.T) {
+	dir := t.TempDir()
+	write := func(path, content string) string {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	write("lib/.dockerignore", "node_modules\n# comment\n*.log\n")
+	write("tools/.dockerignore", "dist\n")
+	override := write("tools.ignore", "build\n")
+	if err := os.MkdirAll(filepath.Join(dir, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	image := llb.Image("alpine")
+	opt := Options{Inputs: Inputs{NamedContexts: map[string]NamedContext{
+		"lib":     {Path: filepath.Join(dir, "lib")},
+		"tools":   {Path: filepath.Join(dir, "tools")},
+		"docs":    {Path: filepath.Join(dir, "docs")},
+		"context": {Path: filepath.Join(dir, "lib")},
+		"base":    {State: &image},
+	}}}
+	so := &client.SolveOpt{LocalDirs: map[string]string{
+		"context":  dir,
+		"lib":      filepath.Join(dir, "lib"),
+		"tools":    filepath.Join(dir, "tools"),
+		"docs":     filepath.Join(dir, "docs"),
+		"_context": filepath.Join(dir, "lib"),
+	}}
+
+	excludes, err := namedContextExcludes(opt, so)
+	if err != nil || excludes != nil {
+		t.Fatalf("expected no excludes without ContextIgnores, got %v, %v", excludes, err)
+	}
+
+	opt.ContextIgnores = &ContextIgnores{Dockerignore: true, Files: map[string]string{"tools": override}}
+	excludes, err = namedContextExcludes(opt, so)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		"lib":      {"node_modules", "*.log"},
+		"_context": {"node_modules", "*.log"},
+		"tools":    {"build"},
+	}
+	if !reflect.DeepEqual(excludes, want) {
+		t.Errorf("excludes = %v, want %v", excludes, want)
+	}
+
+	opt.ContextIgnores = &ContextIgnores{Files: map[string]string{"docs": filepath.Join(dir, "missing")}}
+	if _, err := namedContextExcludes(opt, so); err == nil {
+		t.Error("expected a missing ignore file to fail")
+	}
+}
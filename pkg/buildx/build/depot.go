@@ -10,8 +10,20 @@ import (
 	"github.com/docker/buildx/util/progress"
 )
 
-func DepotBuild(ctx context.Context, nodes []builder.Node, opt map[string]dockerbuild.Options, docker *dockerutil.Client, configDir string, w progress.Writer, dockerfileCallback DockerfileCallback, build *depotbuild.Build) ([]DepotBuildResponse, error) {
-	return DepotBuildWithResultHandler(ctx, nodes, opt, docker, configDir, w, dockerfileCallback, nil, false, build)
+// DepotBuild builds the targets of a bake, handling a failed target by the
+// failureMode.  The RUN steps of every target are capped by limits and share
+// cache mounts by cacheMountPolicy, the .git directory of the contexts is only
+// sent if used or includeGitDir is set, and ignores apply to named contexts.
+func DepotBuild(ctx context.Context, nodes []builder.Node, opt map[string]dockerbuild.Options, docker *dockerutil.Client, configDir string, w progress.Writer, dockerfileCallback DockerfileCallback, build *depotbuild.Build, failureMode FailureMode, limits *RunLimits, includeGitDir bool, cacheMountPolicy string, ignores *ContextIgnores) ([]DepotBuildResponse, error) {
+	depotopts := BuildxOpts(opt)
+	for k, opt := range depotopts {
+		opt.RunLimits = limits
+		opt.IncludeGitDir = includeGitDir
+		opt.CacheMountPolicy = cacheMountPolicy
+		opt.ContextIgnores = ignores
+		depotopts[k] = opt
+	}
+	return BuildWithResultHandler(ctx, nodes, depotopts, docker, configDir, w, dockerfileCallback, nil, false, build, failureMode)
 }
 
 // DepotBuildWithResultHandler is a wrapper around BuildWithResultHandler
@@ -19,8 +31,22 @@ func DepotBuild(ctx context.Context, nodes []builder.Node, opt map[string]docker
 //
 // BuildWithResultHandler was copied from github.com/docker/buildx/build/build.go
 // and modified to return multiple responses.
-func DepotBuildWithResultHandler(ctx context.Context, nodes []builder.Node, opts map[string]dockerbuild.Options, docker *dockerutil.Client, configDir string, w progress.Writer, dockerfileCallback DockerfileCallback, resultHandleFunc func(driverIndex int, rCtx *dockerbuild.ResultContext), allowNoOutput bool, build *depotbuild.Build) ([]DepotBuildResponse, error) {
+//
+// The buildx options have no frontend, so every target is built with frontend,
+// or the Dockerfile frontend if it is nil, and its RUN steps capped by limits
+// and sharing cache mounts by cacheMountPolicy.  The .git directory of the
+// context is only sent if used or includeGitDir is set, and ignores apply to
+// the named contexts.
+func DepotBuildWithResultHandler(ctx context.Context, nodes []builder.Node, opts map[string]dockerbuild.Options, docker *dockerutil.Client, configDir string, w progress.Writer, dockerfileCallback DockerfileCallback, resultHandleFunc func(driverIndex int, rCtx *dockerbuild.ResultContext), allowNoOutput bool, build *depotbuild.Build, frontend *Frontend, limits *RunLimits, includeGitDir bool, cacheMountPolicy string, ignores *ContextIgnores) ([]DepotBuildResponse, error) {
 	depotopts := BuildxOpts(opts)
+	for k, opt := range depotopts {
+		opt.Frontend = frontend
+		opt.RunLimits = limits
+		opt.IncludeGitDir = includeGitDir
+		opt.CacheMountPolicy = cacheMountPolicy
+		opt.ContextIgnores = ignores
+		depotopts[k] = opt
+	}
 
 	var depotHandleFunc func(driverIndex int, rCtx *ResultContext)
 	if resultHandleFunc != nil {
@@ -36,7 +62,7 @@ func DepotBuildWithResultHandler(ctx context.Context, nodes []builder.Node, opts
 		}
 
 	}
-	return BuildWithResultHandler(ctx, nodes, depotopts, docker, configDir, w, dockerfileCallback, depotHandleFunc, allowNoOutput, build)
+	return BuildWithResultHandler(ctx, nodes, depotopts, docker, configDir, w, dockerfileCallback, depotHandleFunc, allowNoOutput, build, FailFast)
 }
 
 func BuildxOpts(opts map[string]dockerbuild.Options) map[string]Options {
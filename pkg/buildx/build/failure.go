@@ -0,0 +1,66 @@
+package build
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FailureMode controls what a multi-target build does when a target fails.
+type FailureMode int
+
+const (
+	// FailFast cancels the other targets on the first failure.
+	FailFast FailureMode = iota
+	// KeepGoing builds every target that does not depend on a failed target
+	// and returns a *TargetsError for the failed targets.
+	KeepGoing
+)
+
+// TargetsError is the aggregate error of the failed targets of a KeepGoing
+// build.
+type TargetsError struct {
+	Errors map[string]error
+}
+
+// Targets returns the names of the failed targets in order.
+func (e *TargetsError) Targets() []string {
+	targets := make([]string, 0, len(e.Errors))
+	for target := range e.Errors {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	return targets
+}
+
+func (e *TargetsError) Error() string {
+	targets := e.Targets()
+	if len(targets) == 1 {
+		return fmt.Sprintf("target %s failed: %v", targets[0], e.Errors[targets[0]])
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d targets failed:", len(targets))
+	for _, target := range targets {
+		fmt.Fprintf(&b, "\n  %s: %v", target, e.Errors[target])
+	}
+	return b.String()
+}
+
+func (e *TargetsError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, target := range e.Targets() {
+		errs = append(errs, e.Errors[target])
+	}
+	return errs
+}
+
+// dependencyError is the result of a failed target for the targets that use
+// it as a context.
+type dependencyError struct {
+	target string
+}
+
+func (e *dependencyError) Error() string {
+	return fmt.Sprintf("dependency %s failed", e.target)
+}
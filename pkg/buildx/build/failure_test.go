@@ -0,0 +1,37 @@
+package build
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/docker/buildx/util/waitmap"
+	"github.com/moby/buildkit/client"
+)
+
+func TestTargetsError(t *testing.T) {
+	errAPI := errors.New("exit code 1")
+	err := &TargetsError{Errors: map[string]error{
+		"web": &dependencyError{target: "api"},
+		"api": errAPI,
+	}}
+	if !errors.Is(err, errAPI) {
+		t.Errorf("expected the target errors to be wrapped")
+	}
+	expected := "2 targets failed:\n  api: exit code 1\n  web: dependency api failed"
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestWaitContextDepsFailedDependency(t *testing.T) {
+	results := waitmap.New()
+	results.Set(resultKey(0, "api"), &dependencyError{target: "api"})
+
+	so := &client.SolveOpt{FrontendAttrs: map[string]string{"context:api": "target:api"}}
+	err := waitContextDeps(context.Background(), 0, results, so)
+	var depErr *dependencyError
+	if !errors.As(err, &depErr) || depErr.target != "api" {
+		t.Errorf("expected the failed dependency, got %v", err)
+	}
+}
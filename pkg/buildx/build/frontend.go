@@ -0,0 +1,39 @@
+package build
+
+import (
+	"github.com/distribution/reference"
+)
+
+const (
+	// DockerfileFrontend is the builtin Dockerfile frontend of buildkitd.
+	DockerfileFrontend = "dockerfile.v0"
+	// GatewayFrontend runs the frontend image in the "source" option.
+	GatewayFrontend = "gateway.v0"
+)
+
+// dockerfileFrontendImages are the frontend images that build Dockerfiles.
+var dockerfileFrontendImages = map[string]bool{
+	"docker/dockerfile":          true,
+	"docker/dockerfile-upstream": true,
+}
+
+// Frontend is the buildkitd frontend of a build and its options, which are
+// passed to the frontend as is.  A nil Frontend is the Dockerfile frontend.
+type Frontend struct {
+	Name string
+	Opts map[string]string
+}
+
+// UsesDockerfile reports whether the frontend reads a Dockerfile, so that
+// the Dockerfile can be linted and checked before the build.  Gateway
+// frontends only do if their image is the Dockerfile frontend.
+func (f *Frontend) UsesDockerfile() bool {
+	if f == nil || f.Name == "" || f.Name == DockerfileFrontend {
+		return true
+	}
+	named, err := reference.ParseNormalizedNamed(f.Opts["source"])
+	if err != nil {
+		return false
+	}
+	return dockerfileFrontendImages[reference.FamiliarName(named)]
+}
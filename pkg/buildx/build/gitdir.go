@@ -0,0 +1,82 @@
+package build
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/tonistiigi/fsutil"
+	fstypes "github.com/tonistiigi/fsutil/types"
+)
+
+var (
+	// gitPathPattern matches a .git path, such as "COPY .git .git" or
+	// "--mount=type=bind,source=.git", but not ".gitignore".
+	gitPathPattern = regexp.MustCompile(`(^|[\s/=,"'])\.git(/|[\s,"']|$)`)
+	// gitCommandPattern matches a git command in a RUN step.
+	gitCommandPattern = regexp.MustCompile(`(^|[\s;&|(])git\s`)
+)
+
+// excludeGitDir reports whether to skip the .git directory of the local
+// context, often its largest part, because the Dockerfile does not appear to
+// use it.
+func excludeGitDir(opt Options, dockerfile *DockerfileInputs, so *client.SolveOpt) bool {
+	contextDir, ok := so.LocalDirs["context"]
+	if !ok || opt.IncludeGitDir || !opt.Frontend.UsesDockerfile() {
+		return false
+	}
+	if dockerfile == nil || dockerfile.Err != nil || usesGitDir(dockerfile.Content) {
+		return false
+	}
+	_, err := os.Lstat(filepath.Join(contextDir, ".git"))
+	return err == nil
+}
+
+// usesGitDir reports whether a Dockerfile may read the .git directory of its
+// context: a step names a .git path or a RUN step runs git.  Dockerfiles that
+// cannot be parsed are assumed to use it.
+func usesGitDir(dockerfile []byte) bool {
+	res, err := parser.Parse(bytes.NewReader(dockerfile))
+	if err != nil {
+		return true
+	}
+	for _, node := range res.AST.Children {
+		if gitPathPattern.MatchString(node.Original) {
+			return true
+		}
+		if !strings.EqualFold(node.Value, "run") {
+			continue
+		}
+		if gitCommandPattern.MatchString(node.Original) {
+			return true
+		}
+		for _, heredoc := range node.Heredocs {
+			if gitCommandPattern.MatchString(heredoc.Content) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func resetUIDAndGID(_ string, st *fstypes.Stat) fsutil.MapResult {
+	st.Uid = 0
+	st.Gid = 0
+	return fsutil.MapResultKeep
+}
+
+// skipGitDir is resetUIDAndGID that also skips .git directories and the .git
+// files of submodules and worktrees.
+func skipGitDir(p string, st *fstypes.Stat) fsutil.MapResult {
+	if filepath.Base(p) == ".git" {
+		if os.FileMode(st.Mode).IsDir() {
+			return fsutil.MapResultSkipDir
+		}
+		return fsutil.MapResultExclude
+	}
+	return resetUIDAndGID(p, st)
+}
@@ -0,0 +1,48 @@
+package build
+
+import (
+	"os"
+	"testing"
+
+	"github.com/tonistiigi/fsutil"
+	fstypes "github.com/tonistiigi/fsutil/types"
+)
+
+func TestUsesGitDir(t *testing.T) {
+	tests := map[string]bool{
+		"FROM alpine\nCOPY . /src\nRUN make\n":                                            false,
+		"FROM alpine\nCOPY .gitignore /src/\nRUN apk add git\n":                           false,
+		"FROM alpine\nCOPY .git /src/.git\n":                                              true,
+		"FROM alpine\nCOPY . /src\nRUN git describe --tags > /version\n":                  true,
+		"FROM alpine\nRUN --mount=type=bind,source=.git,target=/src/.git make\n":          true,
+		"FROM alpine\nRUN cd /src && git rev-parse HEAD\n":                                true,
+		"# syntax=docker/dockerfile:1\nFROM alpine\nRUN <<EOF\nset -e\ngit log -1\nEOF\n": true,
+	}
+	for dockerfile, want := range tests {
+		if got := usesGitDir([]byte(dockerfile)); got != want {
+			t.Errorf("usesGitDir(%q) = %v, want %v", dockerfile, got, want)
+		}
+	}
+}
+
+func TestSkipGitDir(t *testing.T) {
+	tests := []struct {
+		path string
+		mode os.FileMode
+		want fsutil.MapResult
+	}{
+		{path: ".git", mode: os.ModeDir, want: fsutil.MapResultSkipDir},
+		{path: "vendor/lib/.git", mode: 0, want: fsutil.MapResultExclude},
+		{path: ".gitignore", mode: 0, want: fsutil.MapResultKeep},
+		{path: "src/main.go", mode: 0, want: fsutil.MapResultKeep},
+	}
+	for _, tt := range tests {
+		st := &fstypes.Stat{Path: tt.path, Mode: uint32(tt.mode), Uid: 1000, Gid: 1000}
+		if got := skipGitDir(tt.path, st); got != tt.want {
+			t.Errorf("skipGitDir(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+		if tt.want == fsutil.MapResultKeep && (st.Uid != 0 || st.Gid != 0) {
+			t.Errorf("skipGitDir(%q) kept uid %d and gid %d, want 0", tt.path, st.Uid, st.Gid)
+		}
+	}
+}
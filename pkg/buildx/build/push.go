@@ -0,0 +1,134 @@
+package build
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/containerd/containerd/errdefs"
+	remoteserrors "github.com/containerd/containerd/remotes/errors"
+	"github.com/depot/cli/pkg/buildx/imagetools"
+	"github.com/depot/cli/pkg/debuglog"
+	"github.com/distribution/reference"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// pushAttempts is how many times the manifest list of a multi-platform
+	// build is pushed to a registry that fails with server errors.
+	pushAttempts = 4
+	// pushBackoff is the wait before the second attempt, doubled for each
+	// attempt after.
+	pushBackoff = time.Second
+)
+
+var registryServerErrorRe = regexp.MustCompile(`unexpected status(?: from [A-Z]+ request to \S+)?: 5\d\d`)
+
+// IsRegistryServerError returns true if a push failed because the registry
+// responded with a 5xx status.  Errors of the builders only carry the message.
+func IsRegistryServerError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var status remoteserrors.ErrUnexpectedStatus
+	if errors.As(err, &status) {
+		return status.StatusCode >= 500
+	}
+	return registryServerErrorRe.MatchString(err.Error())
+}
+
+// incompletePushError is returned when a pushed tag does not resolve to the
+// pushed manifest list.
+type incompletePushError struct {
+	name string
+	err  error
+}
+
+func (e *incompletePushError) Error() string {
+	return fmt.Sprintf("pushed image %s is incomplete: %v", e.name, e.err)
+}
+
+func (e *incompletePushError) Unwrap() error {
+	return e.err
+}
+
+// pushManifestList pushes the merged manifest list of a multi-platform build
+// to every name concurrently, as the names may be in different registries.
+// A push that failed partway can leave a repository without some platform
+// manifests, so those are copied from the repository of src before the
+// manifest list is pushed, skipping the blobs that the repository already
+// has.  The tag is then resolved to check that it points to the manifest
+// list.  Registry server errors and incomplete pushes are retried.
+func pushManifestList(ctx context.Context, r *imagetools.Resolver, names []string, src reference.Named, manifests []specs.Descriptor, desc specs.Descriptor, dt []byte) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, n := range names {
+		n := n
+		eg.Go(func() error {
+			nn, err := reference.ParseNormalizedNamed(n)
+			if err != nil {
+				return err
+			}
+
+			backoff := pushBackoff
+			for attempt := 1; ; attempt++ {
+				err = pushManifestListTo(ctx, r, nn, src, manifests, desc, dt)
+				var incomplete *incompletePushError
+				if err == nil || attempt == pushAttempts || !(IsRegistryServerError(err) || errors.As(err, &incomplete)) {
+					return err
+				}
+				debuglog.Log("retrying push of %s after attempt %d failed: %v", n, attempt, err)
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(backoff):
+				}
+				backoff *= 2
+			}
+		})
+	}
+	return eg.Wait()
+}
+
+func pushManifestListTo(ctx context.Context, r *imagetools.Resolver, nn reference.Named, src reference.Named, manifests []specs.Descriptor, desc specs.Descriptor, dt []byte) error {
+	for _, manifest := range manifests {
+		if manifest.Size < 0 {
+			// Descriptors of very old buildkits cannot be copied.
+			continue
+		}
+		dest, err := reference.WithDigest(reference.TrimNamed(nn), manifest.Digest)
+		if err != nil {
+			return err
+		}
+		_, _, err = r.Resolve(ctx, dest.String())
+		if err == nil {
+			continue
+		}
+		if !errdefs.IsNotFound(err) {
+			return err
+		}
+		debuglog.Log("copying missing manifest %s to %s", manifest.Digest, reference.FamiliarName(nn))
+		if err := r.Copy(ctx, &imagetools.Source{Ref: src, Desc: manifest}, dest); err != nil {
+			return errors.Wrapf(err, "failed to copy manifest %s", manifest.Digest)
+		}
+	}
+
+	if err := r.Push(ctx, nn, desc, dt); err != nil {
+		return err
+	}
+
+	tagged := reference.TagNameOnly(nn)
+	_, got, err := r.Resolve(ctx, tagged.String())
+	if err != nil {
+		if IsRegistryServerError(err) {
+			return err
+		}
+		return &incompletePushError{name: reference.FamiliarString(tagged), err: err}
+	}
+	if got.Digest != desc.Digest {
+		return &incompletePushError{name: reference.FamiliarString(tagged), err: errors.Errorf("tag points to %s instead of %s", got.Digest, desc.Digest)}
+	}
+	return nil
+}
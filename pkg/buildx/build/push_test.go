@@ -0,0 +1,28 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	remoteserrors "github.com/containerd/containerd/remotes/errors"
+)
+
+func TestIsRegistryServerError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: remoteserrors.ErrUnexpectedStatus{Status: "503 Service Unavailable", StatusCode: 503}, want: true},
+		{err: fmt.Errorf("push: %w", remoteserrors.ErrUnexpectedStatus{Status: "401 Unauthorized", StatusCode: 401}), want: false},
+		{err: errors.New("failed to push example/app:latest: unexpected status: 502 Bad Gateway"), want: true},
+		{err: errors.New("failed to push: unexpected status from PUT request to https://registry.example.com/v2/app/manifests/latest: 500 Internal Server Error"), want: true},
+		{err: errors.New("failed to push: unexpected status: 404 Not Found"), want: false},
+		{err: nil, want: false},
+	}
+	for _, tt := range tests {
+		if got := IsRegistryServerError(tt.err); got != tt.want {
+			t.Errorf("IsRegistryServerError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
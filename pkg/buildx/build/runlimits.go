@@ -0,0 +1,55 @@
+package build
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// minRunMemory is the smallest memory limit of a container that docker
+	// accepts.
+	minRunMemory = 6 * 1024 * 1024
+	minCPUShares = 2
+	maxCPUShares = 262144
+)
+
+// RunLimits caps the resources of each RUN step container on the builder,
+// so that one step cannot exhaust the builder shared by the other targets.
+// Zero values are unlimited.
+type RunLimits struct {
+	// Memory is the memory limit in bytes.
+	Memory int64
+	// CPUShares is the relative CPU weight, 1024 being the default weight.
+	CPUShares int64
+}
+
+// Validate checks the limits against the ranges of the container runtime.
+func (l *RunLimits) Validate() error {
+	if l == nil {
+		return nil
+	}
+	if l.Memory != 0 && l.Memory < minRunMemory {
+		return errors.Errorf("run memory limit %d is below the minimum of 6MiB", l.Memory)
+	}
+	if l.CPUShares != 0 && (l.CPUShares < minCPUShares || l.CPUShares > maxCPUShares) {
+		return errors.Errorf("run CPU shares %d must be between %d and %d", l.CPUShares, minCPUShares, maxCPUShares)
+	}
+	return nil
+}
+
+// frontendAttrs are the worker options of the limits, which the builder
+// applies to the containers of RUN steps.
+func (l *RunLimits) frontendAttrs() map[string]string {
+	attrs := map[string]string{}
+	if l == nil {
+		return attrs
+	}
+	if l.Memory > 0 {
+		attrs["depot.run-memory"] = strconv.FormatInt(l.Memory, 10)
+	}
+	if l.CPUShares > 0 {
+		attrs["depot.run-cpu-shares"] = strconv.FormatInt(l.CPUShares, 10)
+	}
+	return attrs
+}
@@ -0,0 +1,31 @@
+package build
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRunLimits(t *testing.T) {
+	var unlimited *RunLimits
+	if err := unlimited.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if attrs := unlimited.frontendAttrs(); len(attrs) != 0 {
+		t.Errorf("expected no attrs, got %v", attrs)
+	}
+
+	limits := &RunLimits{Memory: 4 << 30, CPUShares: 512}
+	if err := limits.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"depot.run-memory": "4294967296", "depot.run-cpu-shares": "512"}
+	if attrs := limits.frontendAttrs(); !reflect.DeepEqual(attrs, want) {
+		t.Errorf("expected %v, got %v", want, attrs)
+	}
+
+	for _, invalid := range []*RunLimits{{Memory: 1024}, {CPUShares: 1}, {CPUShares: 300000}} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", invalid)
+		}
+	}
+}
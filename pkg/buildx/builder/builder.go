@@ -11,6 +11,7 @@ import (
 
 	depotbuild "github.com/depot/cli/pkg/build"
 	"github.com/depot/cli/pkg/buildx/imagetools"
+	"github.com/depot/cli/pkg/progresshelper"
 	"github.com/docker/buildx/driver"
 	"github.com/docker/buildx/store"
 	"github.com/docker/buildx/store/storeutil"
@@ -34,6 +35,9 @@ type Builder struct {
 	buildID       string
 	buildPlatform string
 	credentials   []depotbuild.Credential
+	region        string
+	near          []string
+	localBuildkit string
 }
 
 type builderOpts struct {
@@ -84,6 +88,22 @@ func WithDepotOptions(buildPlatform string, build depotbuild.Build) Option {
 	}
 }
 
+// WithPlacement sets the placement hints of the build machines.
+func WithPlacement(region string, near []string) Option {
+	return func(b *Builder) {
+		b.region = region
+		b.near = near
+	}
+}
+
+// WithLocalBuildkit builds on the buildkitd at endpoint, such as
+// "docker-container://name" or "tcp://host:port", rather than on Depot machines.
+func WithLocalBuildkit(endpoint string) Option {
+	return func(b *Builder) {
+		b.localBuildkit = endpoint
+	}
+}
+
 // New initializes a new builder client
 func New(dockerCli command.Cli, opts ...Option) (_ *Builder, err error) {
 	b := &Builder{
@@ -111,6 +131,19 @@ func New(dockerCli command.Cli, opts ...Option) (_ *Builder, err error) {
 
 	currentContext := dockerCli.CurrentContext()
 
+	if b.localBuildkit != "" {
+		// A single node builds every platform the local buildkitd supports.
+		b.NodeGroup = &store.NodeGroup{
+			Name:   currentContext,
+			Driver: "remote",
+			Nodes: []store.Node{
+				{Name: "depot_local", Endpoint: b.localBuildkit},
+			},
+			DockerContext: true,
+		}
+		return b, nil
+	}
+
 	amdNode := store.Node{
 		Name: "buildx_buildkit_depot_amd64",
 		Platforms: []v1.Platform{
@@ -119,7 +152,7 @@ func New(dockerCli command.Cli, opts ...Option) (_ *Builder, err error) {
 			{OS: "linux", Architecture: "amd64", Variant: "v3"},
 			{OS: "linux", Architecture: "386"},
 		},
-		DriverOpts: map[string]string{"token": b.token, "platform": "amd64", "buildID": b.buildID, "credentials": string(credentialsJSON)},
+		DriverOpts: map[string]string{"token": b.token, "platform": "amd64", "buildID": b.buildID, "credentials": string(credentialsJSON), "region": b.region, "near": strings.Join(b.near, ",")},
 	}
 
 	armNode := store.Node{
@@ -130,7 +163,7 @@ func New(dockerCli command.Cli, opts ...Option) (_ *Builder, err error) {
 			{OS: "linux", Architecture: "arm", Variant: "v7"},
 			{OS: "linux", Architecture: "arm", Variant: "v6"},
 		},
-		DriverOpts: map[string]string{"token": b.token, "platform": "arm64", "buildID": b.buildID, "credentials": string(credentialsJSON)},
+		DriverOpts: map[string]string{"token": b.token, "platform": "arm64", "buildID": b.buildID, "credentials": string(credentialsJSON), "region": b.region, "near": strings.Join(b.near, ",")},
 	}
 
 	b.NodeGroup = &store.NodeGroup{
@@ -184,7 +217,7 @@ func (b *Builder) Boot(ctx context.Context) (bool, error) {
 		return false, nil
 	}
 
-	printer, err := progress.NewPrinter(context.TODO(), os.Stderr, os.Stderr, progress.PrinterModeAuto)
+	printer, err := progress.NewPrinter(context.TODO(), os.Stderr, os.Stderr, progresshelper.ResolveMode(progress.PrinterModeAuto))
 	if err != nil {
 		return false, err
 	}
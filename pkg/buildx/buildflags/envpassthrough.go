@@ -0,0 +1,67 @@
+package buildflags
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var envNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ParseEnvPassthrough parses the --env-passthrough variables into secret
+// specs that read each variable into the secret of the same name.  Passing
+// variables as secrets rather than build args keeps their values out of the
+// image history and the build cache keys.
+func ParseEnvPassthrough(names []string) ([]string, error) {
+	specs := make([]string, 0, len(names))
+	seen := map[string]bool{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if !envNameRegexp.MatchString(name) {
+			return nil, errors.Errorf("invalid environment variable name %q in --env-passthrough", name)
+		}
+		if _, ok := os.LookupEnv(name); !ok {
+			return nil, errors.Errorf("environment variable %s of --env-passthrough is not set", name)
+		}
+		seen[name] = true
+		specs = append(specs, "id="+name+",env="+name)
+	}
+	return specs, nil
+}
+
+// CheckEnvPassthrough rejects variables that are passed both as secrets and
+// as build args or other secrets, as a build arg would leak the value anyway.
+func CheckEnvPassthrough(names, secretSpecs []string, buildArgs []string) error {
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		for _, arg := range buildArgs {
+			if key, _, _ := strings.Cut(arg, "="); key == name {
+				return errors.Errorf("%s is passed with both --env-passthrough and as a build arg, which stores its value in the image history; remove the build arg", name)
+			}
+		}
+		for _, spec := range secretSpecs {
+			if secretID(spec) == name {
+				return errors.Errorf("%s is passed with both --env-passthrough and --secret", name)
+			}
+		}
+	}
+	return nil
+}
+
+// secretID returns the id of a secret spec without validating its sources.
+func secretID(spec string) string {
+	for _, field := range strings.Split(spec, ",") {
+		if key, value, ok := strings.Cut(field, "="); ok && strings.EqualFold(key, "id") {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+package buildflags
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseEnvPassthrough(t *testing.T) {
+	t.Setenv("NPM_TOKEN", "from-env")
+	t.Setenv("GITHUB_TOKEN", "")
+
+	specs, err := ParseEnvPassthrough([]string{"NPM_TOKEN", " GITHUB_TOKEN", "NPM_TOKEN"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"id=NPM_TOKEN,env=NPM_TOKEN", "id=GITHUB_TOKEN,env=GITHUB_TOKEN"}
+	if !reflect.DeepEqual(specs, expected) {
+		t.Errorf("expected %v, got %v", expected, specs)
+	}
+
+	for _, invalid := range []string{"UNSET_PASSTHROUGH_TOKEN", "NPM-TOKEN", "1TOKEN"} {
+		if _, err := ParseEnvPassthrough([]string{invalid}); err == nil {
+			t.Errorf("expected %q to be rejected", invalid)
+		}
+	}
+
+	if err := CheckEnvPassthrough([]string{"NPM_TOKEN"}, []string{"id=other,env=OTHER"}, []string{"VERSION=1"}); err != nil {
+		t.Errorf("expected no conflict, got %v", err)
+	}
+	if err := CheckEnvPassthrough([]string{" NPM_TOKEN"}, nil, []string{"NPM_TOKEN=1"}); err == nil {
+		t.Error("expected a padded name passed as a build arg to be rejected")
+	}
+	if err := CheckEnvPassthrough([]string{"NPM_TOKEN"}, nil, []string{"NPM_TOKEN"}); err == nil {
+		t.Errorf("expected a build arg of the same name to be rejected")
+	}
+	if err := CheckEnvPassthrough([]string{"NPM_TOKEN"}, []string{"id=NPM_TOKEN,src=.npmrc"}, nil); err == nil {
+		t.Errorf("expected a secret of the same name to be rejected")
+	}
+}
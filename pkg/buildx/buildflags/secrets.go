@@ -0,0 +1,218 @@
+package buildflags
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/secrets"
+	"github.com/moby/buildkit/session/secrets/secretsprovider"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// SecretProvider provides the secrets of a build and records the requests for them.
+type SecretProvider struct {
+	secrets.SecretsServer
+	usageRecorder
+}
+
+// ParseSecretSpecs parses the --secret specs.  In addition to the buildx
+// fields, a secret may take its value from another secret or environment
+// variable with "source=id:NAME", and may list several sources that are tried
+// in order: the environment variable, the file, then the "cmd" credential
+// process, e.g. "id=npm,env=NPM_TOKEN,src=.npmrc,cmd=op read op://ci/npm".
+// As "cmd" runs a command, it is only allowed with allowCmd, such as for the
+// --secret flags of the command line but not the secrets of bake files.
+func ParseSecretSpecs(specs []string, allowCmd bool) (session.Attachable, error) {
+	store := &secretStore{sources: map[string]secretSource{}, values: map[string][]byte{}}
+	ids := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		source, err := parseSecret(spec)
+		if err != nil {
+			return nil, err
+		}
+		if source.Cmd != "" && !allowCmd {
+			return nil, errors.Errorf("secret %s has a cmd credential process, which bake files may only use with --allow-secret-cmd", source.ID)
+		}
+		store.sources[source.ID] = *source
+		ids = append(ids, source.ID)
+	}
+	sort.Strings(ids)
+
+	server, ok := secretsprovider.NewSecretProvider(store).(secrets.SecretsServer)
+	if !ok {
+		return nil, errors.New("unexpected secret provider")
+	}
+	return &SecretProvider{SecretsServer: server, usageRecorder: newUsageRecorder(ids)}, nil
+}
+
+func (p *SecretProvider) Register(server *grpc.Server) {
+	secrets.RegisterSecretsServer(server, p)
+}
+
+func (p *SecretProvider) GetSecret(ctx context.Context, req *secrets.GetSecretRequest) (*secrets.GetSecretResponse, error) {
+	resp, err := p.SecretsServer.GetSecret(ctx, req)
+	p.record(req.ID, err == nil)
+	return resp, err
+}
+
+// secretSource is where the value of a secret comes from.
+type secretSource struct {
+	ID string
+	// Ref is the ID of the secret or environment variable the secret is remapped from.
+	Ref  string
+	Env  string
+	File string
+	Cmd  string
+}
+
+func parseSecret(spec string) (*secretSource, error) {
+	fields, err := csv.NewReader(strings.NewReader(spec)).Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse csv secret")
+	}
+
+	var (
+		source secretSource
+		typ    string
+	)
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return nil, errors.Errorf("invalid field '%s' must be a key=value pair", field)
+		}
+
+		switch strings.ToLower(key) {
+		case "type":
+			if value != "file" && value != "env" {
+				return nil, errors.Errorf("unsupported secret type %q", value)
+			}
+			typ = value
+		case "id":
+			source.ID = value
+		case "source", "src":
+			if ref, ok := strings.CutPrefix(value, "id:"); ok {
+				source.Ref = ref
+			} else {
+				source.File = value
+			}
+		case "env":
+			source.Env = value
+		case "cmd":
+			source.Cmd = value
+		default:
+			return nil, errors.Errorf("unexpected key '%s' in '%s'", key, field)
+		}
+	}
+	if typ == "env" && source.Env == "" {
+		source.Env, source.File = source.File, ""
+	}
+
+	if source.ID == "" {
+		return nil, errors.Errorf("secret missing ID")
+	}
+	if source.Ref == source.ID {
+		return nil, errors.Errorf("secret %s cannot be remapped from itself", source.ID)
+	}
+	if source.Ref == "" && source.Env == "" && source.File == "" && source.Cmd == "" {
+		// Like buildx, a secret without a source is the variable or file of the same name.
+		source.Ref = source.ID
+		return &source, nil
+	}
+	// Files are checked early unless there is a fallback.
+	if source.File != "" && source.Env == "" && source.Cmd == "" && source.Ref == "" {
+		if _, err := os.Stat(source.File); err != nil {
+			return nil, errors.Wrapf(err, "failed to stat %s", source.File)
+		}
+	}
+	return &source, nil
+}
+
+type secretStore struct {
+	sources map[string]secretSource
+
+	mu sync.Mutex
+	// values caches the output of credential processes.
+	values map[string][]byte
+}
+
+func (s *secretStore) GetSecret(ctx context.Context, id string) ([]byte, error) {
+	return s.resolve(ctx, id, map[string]bool{})
+}
+
+func (s *secretStore) resolve(ctx context.Context, id string, seen map[string]bool) ([]byte, error) {
+	source, ok := s.sources[id]
+	if !ok || seen[id] {
+		return nil, errors.WithStack(secrets.ErrNotFound)
+	}
+	seen[id] = true
+
+	if source.Ref != "" {
+		if _, declared := s.sources[source.Ref]; declared && source.Ref != id {
+			return s.resolve(ctx, source.Ref, seen)
+		}
+		if v, ok := os.LookupEnv(source.Ref); ok {
+			return []byte(v), nil
+		}
+		if source.Ref == id {
+			return readSecretFile(id)
+		}
+		return nil, errors.Wrapf(secrets.ErrNotFound, "secret %s is remapped from %s", id, source.Ref)
+	}
+
+	if source.Env != "" {
+		// Without a fallback, an unset variable is an empty secret like in buildx.
+		if v, ok := os.LookupEnv(source.Env); ok || (source.File == "" && source.Cmd == "") {
+			return []byte(v), nil
+		}
+	}
+	if source.File != "" {
+		if _, err := os.Stat(source.File); err == nil || source.Cmd == "" {
+			return readSecretFile(source.File)
+		}
+	}
+	if source.Cmd != "" {
+		return s.credentialProcess(ctx, id, source.Cmd)
+	}
+	return nil, errors.WithStack(secrets.ErrNotFound)
+}
+
+func readSecretFile(path string) ([]byte, error) {
+	dt, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, errors.Wrapf(secrets.ErrNotFound, "%s does not exist", path)
+	}
+	return dt, err
+}
+
+// credentialProcess runs the command of a secret once and returns its output
+// without the trailing newline.
+func (s *secretStore) credentialProcess(ctx context.Context, id, command string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if dt, ok := s.values[id]; ok {
+		return dt, nil
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	cmd.Stderr = &stderr
+	dt, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "credential process of secret %s failed: %s", id, strings.TrimSpace(stderr.String()))
+	}
+	dt = bytes.TrimSuffix(dt, []byte("\n"))
+	s.values[id] = dt
+	return dt, nil
+}
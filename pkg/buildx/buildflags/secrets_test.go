@@ -0,0 +1,67 @@
+package buildflags
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moby/buildkit/session/secrets"
+)
+
+func TestSecretSources(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "npmrc")
+	if err := os.WriteFile(file, []byte("from-file"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("NPM_TOKEN_PROD", "from-env")
+
+	store := &secretStore{sources: map[string]secretSource{}, values: map[string][]byte{}}
+	for _, spec := range []string{
+		"id=npm_token,source=id:NPM_TOKEN_PROD",
+		"id=alias,source=id:npm_token",
+		"id=env_first,env=NPM_TOKEN_PROD,src=" + file,
+		"id=file_fallback,env=UNSET_TOKEN,src=" + file,
+		"id=cmd_fallback,env=UNSET_TOKEN,src=" + filepath.Join(dir, "missing") + ",cmd=echo from-cmd",
+		"id=missing,source=id:UNSET_TOKEN",
+	} {
+		source, err := parseSecret(spec)
+		if err != nil {
+			t.Fatalf("parseSecret(%q): %v", spec, err)
+		}
+		store.sources[source.ID] = *source
+	}
+
+	tests := map[string]string{
+		"npm_token":     "from-env",
+		"alias":         "from-env",
+		"env_first":     "from-env",
+		"file_fallback": "from-file",
+		"cmd_fallback":  "from-cmd",
+	}
+	for id, want := range tests {
+		got, err := store.GetSecret(context.Background(), id)
+		if err != nil || string(got) != want {
+			t.Errorf("GetSecret(%s) = %q, %v, want %q", id, got, err, want)
+		}
+	}
+
+	if _, err := store.GetSecret(context.Background(), "missing"); !errors.Is(err, secrets.ErrNotFound) {
+		t.Errorf("GetSecret(missing) = %v, want not found", err)
+	}
+	if _, err := parseSecret("id=loop,source=id:loop"); err == nil {
+		t.Errorf("parseSecret() expected error for a secret remapped from itself")
+	}
+}
+
+func TestParseSecretSpecsCmd(t *testing.T) {
+	specs := []string{"id=npm,cmd=echo token"}
+	if _, err := ParseSecretSpecs(specs, false); err == nil {
+		t.Error("expected a cmd secret to fail when commands are not allowed")
+	}
+	if _, err := ParseSecretSpecs(specs, true); err != nil {
+		t.Errorf("ParseSecretSpecs() with commands allowed: %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+// Package buildflags parses the secret and SSH flags of a build into session
+// providers that record which secrets and SSH agents the builder requests.
+package buildflags
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/sshforward"
+	"github.com/moby/buildkit/session/sshforward/sshprovider"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Usage is the declared and requested IDs of secrets or SSH agents.
+type Usage struct {
+	Declared []string
+	// Requested maps the requested IDs to whether they were provided.
+	Requested map[string]bool
+}
+
+type usageRecorder struct {
+	declared []string
+
+	mu        sync.Mutex
+	requested map[string]bool
+}
+
+func newUsageRecorder(declared []string) usageRecorder {
+	return usageRecorder{declared: declared, requested: map[string]bool{}}
+}
+
+func (r *usageRecorder) record(id string, provided bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requested[id] = r.requested[id] || provided
+}
+
+// Usage returns the IDs declared for the build and those the builder requested.
+func (r *usageRecorder) Usage() Usage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	requested := make(map[string]bool, len(r.requested))
+	for id, provided := range r.requested {
+		requested[id] = provided
+	}
+	return Usage{Declared: r.declared, Requested: requested}
+}
+
+// SSHProvider forwards the SSH agents of a build and records the requests for them.
+type SSHProvider struct {
+	sshforward.SSHServer
+	usageRecorder
+
+	// restricted are the agents of the IDs with an allow policy.
+	restricted map[string]*restrictedSource
+}
+
+// ParseSSHSpecs parses the --ssh specs.  In addition to the buildx format,
+// "allow=" restricts the agent to sign only with the keys of the given
+// SHA256 fingerprints or only for the given hosts, e.g. "default,allow=github.com".
+func ParseSSHSpecs(specs []string) (session.Attachable, error) {
+	configs := make([]sshprovider.AgentConfig, 0, len(specs))
+	restricted := map[string]*restrictedSource{}
+	ids := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		cfg, policy, err := parseSSH(spec)
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, cfg)
+
+		id := cfg.ID
+		if id == "" {
+			id = sshforward.DefaultID
+		}
+		ids = append(ids, id)
+
+		if policy != nil {
+			src, err := newRestrictedSource(cfg, policy)
+			if err != nil {
+				return nil, err
+			}
+			restricted[id] = src
+		}
+	}
+	sort.Strings(ids)
+
+	attachable, err := sshprovider.NewSSHAgentProvider(configs)
+	if err != nil {
+		return nil, err
+	}
+	server, ok := attachable.(sshforward.SSHServer)
+	if !ok {
+		return nil, errors.New("unexpected ssh agent provider")
+	}
+	return &SSHProvider{SSHServer: server, usageRecorder: newUsageRecorder(ids), restricted: restricted}, nil
+}
+
+func (p *SSHProvider) Register(server *grpc.Server) {
+	sshforward.RegisterSSHServer(server, p)
+}
+
+func (p *SSHProvider) CheckAgent(ctx context.Context, req *sshforward.CheckAgentRequest) (*sshforward.CheckAgentResponse, error) {
+	resp, err := p.SSHServer.CheckAgent(ctx, req)
+	id := req.ID
+	if id == "" {
+		id = sshforward.DefaultID
+	}
+	p.record(id, err == nil)
+	return resp, err
+}
+
+func (p *SSHProvider) ForwardAgent(stream sshforward.SSH_ForwardAgentServer) error {
+	id := sshforward.DefaultID
+	opts, _ := metadata.FromIncomingContext(stream.Context())
+	if v, ok := opts[sshforward.KeySSHID]; ok && len(v) > 0 && v[0] != "" {
+		id = v[0]
+	}
+
+	var err error
+	if src, ok := p.restricted[id]; ok {
+		err = src.forward(stream)
+	} else {
+		err = p.SSHServer.ForwardAgent(stream)
+	}
+	p.record(id, err == nil)
+	return err
+}
@@ -0,0 +1,359 @@
+package buildflags
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/depot/cli/pkg/debuglog"
+	"github.com/moby/buildkit/session/sshforward"
+	"github.com/moby/buildkit/session/sshforward/sshprovider"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"golang.org/x/sync/errgroup"
+)
+
+// sessionBindExtension is sent by OpenSSH 8.9 and later to bind an agent
+// connection to the host key of the server being authenticated to.
+const sessionBindExtension = "session-bind@openssh.com"
+
+// msgUserAuthRequest is SSH_MSG_USERAUTH_REQUEST.
+const msgUserAuthRequest = 50
+
+// sshPolicy restricts the keys and hosts a forwarded SSH agent can sign for.
+type sshPolicy struct {
+	// keys are the SHA256 fingerprints of the allowed keys.
+	keys  []string
+	hosts []string
+	// hostKeys checks a host key against the known_hosts entries of a host.
+	hostKeys ssh.HostKeyCallback
+}
+
+// parseSSH parses an --ssh spec of the form "id[=path[,path...]][,allow=host|SHA256:fingerprint...]".
+func parseSSH(spec string) (sshprovider.AgentConfig, *sshPolicy, error) {
+	parts := strings.Split(spec, ",")
+	id, path, _ := strings.Cut(parts[0], "=")
+	cfg := sshprovider.AgentConfig{ID: id}
+	if path != "" {
+		cfg.Paths = []string{path}
+	}
+
+	var policy sshPolicy
+	for _, part := range parts[1:] {
+		allow, ok := strings.CutPrefix(part, "allow=")
+		if !ok {
+			cfg.Paths = append(cfg.Paths, part)
+			continue
+		}
+		if allow == "" {
+			return cfg, nil, errors.Errorf("invalid empty allow in ssh spec %q", spec)
+		}
+		if strings.HasPrefix(allow, "SHA256:") {
+			policy.keys = append(policy.keys, allow)
+		} else {
+			policy.hosts = append(policy.hosts, allow)
+		}
+	}
+	if len(policy.keys) == 0 && len(policy.hosts) == 0 {
+		return cfg, nil, nil
+	}
+
+	if len(policy.hosts) > 0 {
+		hostKeys, err := knownHosts()
+		if err != nil {
+			return cfg, nil, errors.Wrapf(err, "allowed hosts of ssh %s require known_hosts", id)
+		}
+		policy.hostKeys = hostKeys
+	}
+	return cfg, &policy, nil
+}
+
+// knownHosts reads the user and system known_hosts files.
+func knownHosts() (ssh.HostKeyCallback, error) {
+	var files []string
+	if home, err := os.UserHomeDir(); err == nil {
+		files = append(files, filepath.Join(home, ".ssh", "known_hosts"))
+	}
+	files = append(files, "/etc/ssh/ssh_known_hosts")
+
+	var existing []string
+	for _, file := range files {
+		if _, err := os.Stat(file); err == nil {
+			existing = append(existing, file)
+		}
+	}
+	if len(existing) == 0 {
+		return nil, errors.New("no known_hosts file found")
+	}
+	return knownhosts.New(existing...)
+}
+
+func (p *sshPolicy) allowsKey(key ssh.PublicKey) bool {
+	if len(p.keys) == 0 {
+		return true
+	}
+	fingerprint := ssh.FingerprintSHA256(key)
+	for _, allowed := range p.keys {
+		if allowed == fingerprint {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsHostKey reports whether the host key is a known key of an allowed host.
+func (p *sshPolicy) allowsHostKey(key ssh.PublicKey) bool {
+	remote := &net.TCPAddr{IP: net.IPv4zero, Port: 22}
+	for _, host := range p.hosts {
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			host = net.JoinHostPort(host, "22")
+		}
+		if p.hostKeys(host, remote, key) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// restrictedSource is the agent socket or keys of an SSH ID with a policy.
+type restrictedSource struct {
+	policy *sshPolicy
+	socket string
+	keys   agent.Agent
+}
+
+func newRestrictedSource(cfg sshprovider.AgentConfig, policy *sshPolicy) (*restrictedSource, error) {
+	paths := cfg.Paths
+	if len(paths) == 0 {
+		paths = []string{os.Getenv("SSH_AUTH_SOCK")}
+	}
+	if paths[0] == "" {
+		return nil, errors.New("invalid empty ssh agent socket")
+	}
+
+	src := &restrictedSource{policy: policy}
+	for _, path := range paths {
+		fi, err := os.Stat(path)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if fi.Mode()&os.ModeSocket != 0 {
+			if len(paths) > 1 {
+				return nil, errors.Errorf("invalid combination of keys and sockets")
+			}
+			src.socket = path
+			return src, nil
+		}
+
+		dt, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", path)
+		}
+		key, err := ssh.ParseRawPrivateKey(dt)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s", path)
+		}
+		if src.keys == nil {
+			src.keys = agent.NewKeyring()
+		}
+		if err := src.keys.Add(agent.AddedKey{PrivateKey: key}); err != nil {
+			return nil, errors.Wrapf(err, "failed to add %s to agent", path)
+		}
+	}
+	return src, nil
+}
+
+// forward serves the agent protocol over the stream with the policy applied.
+func (s *restrictedSource) forward(stream sshforward.SSH_ForwardAgentServer) error {
+	a := s.keys
+	if s.socket != "" {
+		conn, err := net.DialTimeout("unix", s.socket, 2*time.Second)
+		if err != nil {
+			return errors.Wrapf(err, "failed to connect to %s", s.socket)
+		}
+		defer conn.Close()
+		a = agent.NewClient(conn)
+	}
+
+	s1, s2 := net.Pipe()
+	eg, ctx := errgroup.WithContext(context.TODO())
+	eg.Go(func() error {
+		return agent.ServeAgent(&policyAgent{Agent: a, policy: s.policy}, s1)
+	})
+	eg.Go(func() error {
+		defer s1.Close()
+		return sshforward.Copy(ctx, s2, stream, nil)
+	})
+	return eg.Wait()
+}
+
+// policyAgent is a read-only agent that lists and signs with the allowed keys
+// only, and, when hosts are restricted, only signs the user authentication of
+// the session the connection was last bound to, which must be to an allowed
+// host.
+type policyAgent struct {
+	agent.Agent
+	policy *sshPolicy
+
+	mu sync.Mutex
+	// binds are the session binds of the connection, the first by the client
+	// and, when it forwards the agent, the later ones by the hops it forwards
+	// the agent to.
+	binds []sessionBinding
+	// refused is set once a session was bound to a host that is not allowed.
+	refused bool
+}
+
+type sessionBinding struct {
+	sessionID  []byte
+	forwarding bool
+}
+
+func (a *policyAgent) List() ([]*agent.Key, error) {
+	keys, err := a.Agent.List()
+	if err != nil {
+		return nil, err
+	}
+	allowed := keys[:0]
+	for _, key := range keys {
+		if a.policy.allowsKey(key) {
+			allowed = append(allowed, key)
+		}
+	}
+	return allowed, nil
+}
+
+func (a *policyAgent) Sign(key ssh.PublicKey, data []byte) (*ssh.Signature, error) {
+	return a.SignWithFlags(key, data, 0)
+}
+
+func (a *policyAgent) SignWithFlags(key ssh.PublicKey, data []byte, flags agent.SignatureFlags) (*ssh.Signature, error) {
+	if !a.policy.allowsKey(key) {
+		debuglog.Log("ssh agent refused to sign with %s", ssh.FingerprintSHA256(key))
+		return nil, errors.New("key is not allowed")
+	}
+	if len(a.policy.hosts) > 0 {
+		if err := a.checkSession(data); err != nil {
+			debuglog.Log("ssh agent refused to sign for a host not in %v: %v", a.policy.hosts, err)
+			return nil, errors.Wrap(err, "host is not allowed")
+		}
+	}
+
+	if extended, ok := a.Agent.(agent.ExtendedAgent); ok {
+		return extended.SignWithFlags(key, data, flags)
+	}
+	return a.Agent.Sign(key, data)
+}
+
+func (a *policyAgent) Extension(extensionType string, contents []byte) ([]byte, error) {
+	if extensionType != sessionBindExtension {
+		return nil, agent.ErrExtensionUnsupported
+	}
+
+	var bind struct {
+		HostKey    []byte
+		SessionID  []byte
+		Signature  []byte
+		Forwarding bool
+	}
+	if err := ssh.Unmarshal(contents, &bind); err != nil {
+		return nil, err
+	}
+	hostKey, err := ssh.ParsePublicKey(bind.HostKey)
+	if err != nil {
+		return nil, err
+	}
+	var signature ssh.Signature
+	if err := ssh.Unmarshal(bind.Signature, &signature); err != nil {
+		return nil, err
+	}
+	if err := hostKey.Verify(bind.SessionID, &signature); err != nil {
+		return nil, err
+	}
+
+	allowed := len(a.policy.hosts) == 0 || a.policy.allowsHostKey(hostKey)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	// Like OpenSSH, only a connection bound for forwarding is bound again,
+	// by the hop the agent is forwarded to.
+	if n := len(a.binds); n > 0 && !a.binds[n-1].forwarding {
+		return nil, errors.New("session bind on a connection that is not forwarded")
+	}
+	a.binds = append(a.binds, sessionBinding{sessionID: bind.SessionID, forwarding: bind.Forwarding})
+	a.refused = a.refused || !allowed
+
+	if extended, ok := a.Agent.(agent.ExtendedAgent); ok {
+		if _, err := extended.Extension(extensionType, contents); err != nil && err != agent.ErrExtensionUnsupported {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// checkSession checks that the data is a user authentication request of the
+// session the connection was last bound to, and that every host the agent
+// went through is allowed.  A connection bound for forwarding only signs
+// after the hop it is forwarded to binds it to its own session.
+func (a *policyAgent) checkSession(data []byte) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	n := len(a.binds)
+	switch {
+	case n == 0:
+		return errors.New("connection is not bound to a session")
+	case a.refused:
+		return errors.New("connection is bound to a host that is not allowed")
+	case a.binds[n-1].forwarding:
+		return errors.New("connection is bound for forwarding")
+	}
+
+	sessionID, err := userauthSessionID(data)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(sessionID, a.binds[n-1].sessionID) {
+		return errors.New("user authentication request is not for the bound session")
+	}
+	return nil
+}
+
+// userauthSessionID returns the session identifier of the data signed for a
+// publickey user authentication request (RFC 4252, section 7).
+func userauthSessionID(data []byte) ([]byte, error) {
+	var req struct {
+		SessionID []byte
+		Type      byte
+		Rest      []byte `ssh:"rest"`
+	}
+	if err := ssh.Unmarshal(data, &req); err != nil || req.Type != msgUserAuthRequest {
+		return nil, errors.New("data is not a user authentication request")
+	}
+	return req.SessionID, nil
+}
+
+func (a *policyAgent) Add(agent.AddedKey) error {
+	return errors.New("adding new keys not allowed by buildkit")
+}
+
+func (a *policyAgent) Remove(ssh.PublicKey) error {
+	return errors.New("removing keys not allowed by buildkit")
+}
+
+func (a *policyAgent) RemoveAll() error {
+	return errors.New("removing keys not allowed by buildkit")
+}
+
+func (a *policyAgent) Lock([]byte) error {
+	return errors.New("locking agent not allowed by buildkit")
+}
+
+func (a *policyAgent) Signers() ([]ssh.Signer, error) {
+	return nil, errors.New("signers are not available from a restricted agent")
+}
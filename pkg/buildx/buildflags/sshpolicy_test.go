@@ -0,0 +1,176 @@
+package buildflags
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func newTestSigner(t *testing.T) (ssh.Signer, ed25519.PrivateKey) {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer, key
+}
+
+// sessionBind returns the session-bind extension contents for the host key.
+func sessionBind(t *testing.T, host ssh.Signer, sessionID string, forwarding bool) []byte {
+	t.Helper()
+	signature, err := host.Sign(rand.Reader, []byte(sessionID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ssh.Marshal(struct {
+		HostKey    []byte
+		SessionID  []byte
+		Signature  []byte
+		Forwarding bool
+	}{host.PublicKey().Marshal(), []byte(sessionID), ssh.Marshal(signature), forwarding})
+}
+
+// userauth returns the data signed for a publickey user authentication.
+func userauth(sessionID string, key ssh.PublicKey) []byte {
+	return ssh.Marshal(struct {
+		SessionID []byte
+		Type      byte
+		User      string
+		Service   string
+		Method    string
+		Signed    bool
+		Algo      string
+		PubKey    []byte
+	}{[]byte(sessionID), msgUserAuthRequest, "git", "ssh-connection", "publickey", true, key.Type(), key.Marshal()})
+}
+
+func TestPolicyAgent(t *testing.T) {
+	allowedHost, _ := newTestSigner(t)
+	otherHost, _ := newTestSigner(t)
+	knownHostsFile := filepath.Join(t.TempDir(), "known_hosts")
+	line := knownhosts.Line([]string{"github.com"}, allowedHost.PublicKey()) + "\n"
+	if err := os.WriteFile(knownHostsFile, []byte(line), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	hostKeys, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	allowedKey, allowedPriv := newTestSigner(t)
+	_, otherPriv := newTestSigner(t)
+	keyring := agent.NewKeyring()
+	for _, key := range []ed25519.PrivateKey{allowedPriv, otherPriv} {
+		if err := keyring.Add(agent.AddedKey{PrivateKey: key}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	policy := &sshPolicy{
+		keys:     []string{ssh.FingerprintSHA256(allowedKey.PublicKey())},
+		hosts:    []string{"github.com"},
+		hostKeys: hostKeys,
+	}
+	a := &policyAgent{Agent: keyring, policy: policy}
+
+	keys, err := a.List()
+	if err != nil || len(keys) != 1 || ssh.FingerprintSHA256(keys[0]) != ssh.FingerprintSHA256(allowedKey.PublicKey()) {
+		t.Fatalf("List() = %v, %v, want only the allowed key", keys, err)
+	}
+
+	if _, err := a.Sign(allowedKey.PublicKey(), userauth("s1", allowedKey.PublicKey())); err == nil {
+		t.Errorf("Sign() before a session bind should fail")
+	}
+	if _, err := a.Extension(sessionBindExtension, sessionBind(t, otherHost, "s1", false)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Sign(allowedKey.PublicKey(), userauth("s1", allowedKey.PublicKey())); err == nil {
+		t.Errorf("Sign() for a host that is not allowed should fail")
+	}
+
+	a = &policyAgent{Agent: keyring, policy: policy}
+	if _, err := a.Extension(sessionBindExtension, sessionBind(t, allowedHost, "s1", false)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Sign(allowedKey.PublicKey(), userauth("s1", allowedKey.PublicKey())); err != nil {
+		t.Errorf("Sign() for an allowed host: %v", err)
+	}
+	if _, err := a.Sign(allowedKey.PublicKey(), userauth("s2", allowedKey.PublicKey())); err == nil {
+		t.Errorf("Sign() for another session should fail")
+	}
+	if _, err := a.Sign(allowedKey.PublicKey(), []byte("data")); err == nil {
+		t.Errorf("Sign() of data that is not a user authentication should fail")
+	}
+	otherKey, _ := ssh.NewPublicKey(otherPriv.Public())
+	if _, err := a.Sign(otherKey, userauth("s1", otherKey)); err == nil {
+		t.Errorf("Sign() with a key that is not allowed should fail")
+	}
+	if _, err := a.Extension(sessionBindExtension, sessionBind(t, allowedHost, "s2", false)); err == nil {
+		t.Errorf("a second bind of a connection that is not forwarded should fail")
+	}
+}
+
+func TestPolicyAgentForwarding(t *testing.T) {
+	allowedHost, _ := newTestSigner(t)
+	otherHost, _ := newTestSigner(t)
+	hostKeys := func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		if string(key.Marshal()) != string(allowedHost.PublicKey().Marshal()) {
+			return errors.New("unknown host")
+		}
+		return nil
+	}
+	key, priv := newTestSigner(t)
+	keyring := agent.NewKeyring()
+	if err := keyring.Add(agent.AddedKey{PrivateKey: priv}); err != nil {
+		t.Fatal(err)
+	}
+	policy := &sshPolicy{hosts: []string{"github.com"}, hostKeys: hostKeys}
+
+	a := &policyAgent{Agent: keyring, policy: policy}
+	if _, err := a.Extension(sessionBindExtension, sessionBind(t, allowedHost, "s1", true)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Sign(key.PublicKey(), userauth("s1", key.PublicKey())); err == nil {
+		t.Errorf("Sign() on a connection bound for forwarding should fail")
+	}
+	if _, err := a.Extension(sessionBindExtension, sessionBind(t, allowedHost, "s2", false)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Sign(key.PublicKey(), userauth("s2", key.PublicKey())); err != nil {
+		t.Errorf("Sign() after the forwarded hop bound its session: %v", err)
+	}
+
+	a = &policyAgent{Agent: keyring, policy: policy}
+	if _, err := a.Extension(sessionBindExtension, sessionBind(t, otherHost, "s1", true)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Extension(sessionBindExtension, sessionBind(t, allowedHost, "s2", false)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Sign(key.PublicKey(), userauth("s2", key.PublicKey())); err == nil {
+		t.Errorf("Sign() forwarded through a host that is not allowed should fail")
+	}
+}
+
+func TestParseSSH(t *testing.T) {
+	cfg, policy, err := parseSSH("default=/a,/b")
+	if err != nil || cfg.ID != "default" || len(cfg.Paths) != 2 || policy != nil {
+		t.Errorf("parseSSH() = %+v, %+v, %v", cfg, policy, err)
+	}
+	cfg, policy, err = parseSSH("deploy=/key,allow=SHA256:abc")
+	if err != nil || cfg.ID != "deploy" || len(cfg.Paths) != 1 || policy == nil || len(policy.keys) != 1 {
+		t.Errorf("parseSSH() = %+v, %+v, %v", cfg, policy, err)
+	}
+}
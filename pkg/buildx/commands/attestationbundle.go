@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/depot/cli/pkg/attestation"
+	depotbuildxbuild "github.com/depot/cli/pkg/buildx/build"
+	"github.com/depot/cli/pkg/buildx/imagetools"
+	"github.com/depot/cli/pkg/sbom"
+	"github.com/distribution/reference"
+	"github.com/docker/buildx/build"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	"github.com/pkg/errors"
+)
+
+// validateAttestationBundle loads the key that signs the attestation bundle.
+func validateAttestationBundle(o *DepotOptions) (err error) {
+	if o.attestationBundle == "" {
+		if o.attestationKey != "" || o.rekorUpload {
+			return errors.New("--attestation-key and --rekor-upload require --attestation-bundle")
+		}
+		return nil
+	}
+	if o.rekorUpload && o.attestationKey == "" {
+		return errors.New("--rekor-upload requires --attestation-key")
+	}
+	if o.attestationKey != "" {
+		o.attestationSigner, err = attestation.LoadSigner(o.attestationKey)
+	}
+	return err
+}
+
+// writeAttestationBundle writes the provenance and SBOM statements of the
+// builds to the attestation bundle, signed if a key was given, and uploads
+// them to Rekor.  The statements of pushed images are read from their
+// registry; the SBOMs of other builds are read from the builders.
+func writeAttestationBundle(ctx context.Context, resolver *imagetools.Resolver, opts map[string]build.Options, resp []depotbuildxbuild.DepotBuildResponse, o DepotOptions) ([]attestation.LogEntry, error) {
+	statements, err := buildStatements(ctx, resolver, opts, resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read attestations")
+	}
+	if len(statements) == 0 {
+		return nil, errors.New("the build has no attestations to bundle: push the image or build with --sbom")
+	}
+
+	envelopes := make([]attestation.Envelope, 0, len(statements))
+	for _, statement := range statements {
+		envelope := attestation.NewEnvelope(statement.Payload)
+		if o.attestationSigner != nil {
+			if err := envelope.Sign(o.attestationSigner); err != nil {
+				return nil, errors.Wrap(err, "failed to sign attestation")
+			}
+		}
+		envelopes = append(envelopes, envelope)
+	}
+	if err := attestation.WriteBundle(o.attestationBundle, envelopes); err != nil {
+		return nil, err
+	}
+
+	if !o.rekorUpload {
+		return nil, nil
+	}
+	publicKey, err := attestation.PublicKeyPEM(o.attestationSigner)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]attestation.LogEntry, 0, len(envelopes))
+	for i, envelope := range envelopes {
+		entry, err := attestation.UploadRekor(ctx, o.rekorURL, envelope, publicKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to upload attestation to rekor")
+		}
+		entry.PredicateType = statements[i].PredicateType
+		entry.Platform = statements[i].Platform
+		entries = append(entries, *entry)
+	}
+	return entries, nil
+}
+
+// buildStatements returns the in-toto statements of every node response.
+func buildStatements(ctx context.Context, resolver *imagetools.Resolver, opts map[string]build.Options, resp []depotbuildxbuild.DepotBuildResponse) ([]imagetools.Statement, error) {
+	var statements []imagetools.Statement
+	seen := map[string]struct{}{}
+	for _, buildRes := range resp {
+		pushed := isPushed(opts[buildRes.Name])
+		for _, nodeRes := range buildRes.NodeResponses {
+			exporterResponse := nodeRes.SolveResponse.ExporterResponse
+			if ref, ok := pushedImageRef(exporterResponse); ok && pushed {
+				if _, ok := seen[ref]; ok {
+					continue
+				}
+				seen[ref] = struct{}{}
+				s, err := resolver.Statements(ctx, ref)
+				if err != nil {
+					return nil, err
+				}
+				statements = append(statements, s...)
+				continue
+			}
+
+			attestations, err := sbom.Attestations(ctx, nodeRes)
+			if err != nil {
+				return nil, err
+			}
+			for _, a := range attestations {
+				var statement sbom.Statement
+				_ = json.Unmarshal(a.Statement, &statement)
+				statements = append(statements, imagetools.Statement{Platform: a.Platform, PredicateType: statement.PredicateType, Payload: a.Statement})
+			}
+		}
+	}
+	return statements, nil
+}
+
+func isPushed(opt build.Options) bool {
+	for _, export := range opt.Exports {
+		if export.Attrs["push"] == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// pushedImageRef returns the first image name of an exporter response
+// pinned to the exported digest.
+func pushedImageRef(exporterResponse map[string]string) (string, bool) {
+	dgst := exporterResponse[exptypes.ExporterImageDigestKey]
+	name, _, _ := strings.Cut(exporterResponse["image.name"], ",")
+	if dgst == "" || name == "" {
+		return "", false
+	}
+	named, err := reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return "", false
+	}
+	return reference.TrimNamed(named).String() + "@" + dgst, true
+}
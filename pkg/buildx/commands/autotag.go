@@ -0,0 +1,158 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/distribution/reference"
+	"github.com/pkg/errors"
+)
+
+// The helpers of --auto-tag.
+const (
+	autoTagGitDescribe = "gitdescribe"
+	autoTagCalVer      = "calver"
+	autoTagSHA         = "sha"
+)
+
+// autoTagVars is the repository state that auto tags are computed from.
+type autoTagVars struct {
+	// Describe is "git describe --tags --always --dirty" of the context, or
+	// ShortSHA outside a git checkout.
+	Describe string
+	// ShortSHA is the abbreviated commit being built.
+	ShortSHA string
+	// Now is the start of the build, or SOURCE_DATE_EPOCH when set.
+	Now time.Time
+}
+
+func validateAutoTags(kinds, tags []string) error {
+	for _, kind := range kinds {
+		switch kind {
+		case autoTagGitDescribe, autoTagCalVer, autoTagSHA:
+		default:
+			return errors.Errorf("unknown --auto-tag %q (expected %q, %q, or %q)", kind, autoTagGitDescribe, autoTagCalVer, autoTagSHA)
+		}
+	}
+	if len(kinds) > 0 && len(tags) == 0 {
+		return errors.New("--auto-tag requires --tag to name the image repository")
+	}
+	return nil
+}
+
+// readAutoTagVars reads the repository state of the context.
+func readAutoTagVars(ctx context.Context, contextPath string) autoTagVars {
+	vars := autoTagVars{Now: time.Now().UTC()}
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		if sec, err := strconv.ParseInt(epoch, 10, 64); err == nil {
+			vars.Now = time.Unix(sec, 0).UTC()
+		}
+	}
+
+	if sha := gitSHA(ctx, contextPath); len(sha) >= 7 {
+		vars.ShortSHA = sha[:7]
+	}
+	vars.Describe = gitDescribe(ctx, contextPath)
+	if vars.Describe == "" {
+		// Outside a git checkout, such as a CI workspace without the .git
+		// directory, describe the commit of the CI job like `--always` does
+		// for commits without tags.
+		vars.Describe = vars.ShortSHA
+	}
+	return vars
+}
+
+// gitDescribe returns "git describe --tags --always --dirty" of the context,
+// or "" when git is not installed or the context is not a git checkout.
+func gitDescribe(ctx context.Context, contextPath string) string {
+	wd, err := filepath.Abs(contextPath)
+	if err != nil {
+		return ""
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return ""
+	}
+	out, err := exec.CommandContext(ctx, "git", "-C", wd, "describe", "--tags", "--always", "--dirty").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// autoTags returns the auto tags of every repository named by the tags, e.g.
+// "example/app:sha-1a2b3c4" for "example/app:latest".
+func autoTags(kinds, tags []string, vars autoTagVars) ([]string, error) {
+	if len(kinds) == 0 {
+		return nil, nil
+	}
+
+	values := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		value, err := autoTagValue(kind, vars)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	var (
+		computed []string
+		seen     = map[string]bool{}
+	)
+	for _, tag := range tags {
+		named, err := reference.ParseNormalizedNamed(tag)
+		if err != nil {
+			// Invalid tags are reported with the other tags.
+			continue
+		}
+		repository := reference.FamiliarName(named)
+		if seen[repository] {
+			continue
+		}
+		seen[repository] = true
+		for _, value := range values {
+			computed = append(computed, repository+":"+value)
+		}
+	}
+	return computed, nil
+}
+
+func autoTagValue(kind string, vars autoTagVars) (string, error) {
+	switch kind {
+	case autoTagSHA:
+		if vars.ShortSHA == "" {
+			return "", errors.New("--auto-tag sha requires a git repository or a CI commit")
+		}
+		return "sha-" + vars.ShortSHA, nil
+	case autoTagGitDescribe:
+		if vars.Describe == "" {
+			return "", errors.New("--auto-tag gitdescribe requires a git repository or a CI commit")
+		}
+		return sanitizeTag(vars.Describe), nil
+	case autoTagCalVer:
+		calver := vars.Now.Format("2006.01.02")
+		if vars.ShortSHA != "" {
+			calver += "-" + vars.ShortSHA
+		}
+		return calver, nil
+	}
+	return "", errors.Errorf("unknown --auto-tag %q", kind)
+}
+
+var invalidTagChars = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+// sanitizeTag makes a tag of s, e.g. "v1.2.0-3-g1a2b3c4" from "v1.2.0+3-g1a2b3c4".
+func sanitizeTag(s string) string {
+	tag := invalidTagChars.ReplaceAllString(s, "-")
+	tag = strings.TrimLeft(tag, ".-")
+	if len(tag) > 128 {
+		tag = tag[:128]
+	}
+	return tag
+}
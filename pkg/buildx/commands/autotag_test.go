@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAutoTags(t *testing.T) {
+	vars := autoTagVars{
+		Describe: "v1.2.0-3-g1a2b3c4-dirty",
+		ShortSHA: "1a2b3c4",
+		Now:      time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	tags, err := autoTags([]string{"sha", "gitdescribe", "calver"}, []string{"example/app:latest", "example/app:edge", "ghcr.io/example/app"}, vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"example/app:sha-1a2b3c4",
+		"example/app:v1.2.0-3-g1a2b3c4-dirty",
+		"example/app:2024.06.01-1a2b3c4",
+		"ghcr.io/example/app:sha-1a2b3c4",
+		"ghcr.io/example/app:v1.2.0-3-g1a2b3c4-dirty",
+		"ghcr.io/example/app:2024.06.01-1a2b3c4",
+	}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Errorf("expected %v, got %v", expected, tags)
+	}
+
+	if _, err := autoTags([]string{"sha"}, []string{"example/app"}, autoTagVars{}); err == nil {
+		t.Errorf("expected sha to require a commit")
+	}
+	if err := validateAutoTags([]string{"semver"}, []string{"example/app"}); err == nil {
+		t.Errorf("expected an unknown helper to be rejected")
+	}
+	if err := validateAutoTags([]string{"sha"}, nil); err == nil {
+		t.Errorf("expected --auto-tag to require --tag")
+	}
+}
+
+func TestSanitizeTag(t *testing.T) {
+	for in, expected := range map[string]string{
+		"v1.2.0+build.3": "v1.2.0-build.3",
+		"release/2024":   "release-2024",
+		".hidden":        "hidden",
+	} {
+		if got := sanitizeTag(in); got != expected {
+			t.Errorf("sanitizeTag(%q) = %q, expected %q", in, got, expected)
+		}
+	}
+}
+
+func TestReadAutoTagVarsOutsideGit(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_SHA", "1a2b3c4d5e6f")
+
+	vars := readAutoTagVars(context.Background(), t.TempDir())
+	if vars.ShortSHA != "1a2b3c4" || vars.Describe != "1a2b3c4" {
+		t.Errorf("readAutoTagVars() = %+v, expected the CI commit", vars)
+	}
+}
@@ -3,20 +3,29 @@
 package commands
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/containerd/containerd/platforms"
+	"github.com/depot/cli/pkg/artifact"
+	"github.com/depot/cli/pkg/attestation"
 	"github.com/depot/cli/pkg/buildx/bake"
 	"github.com/depot/cli/pkg/buildx/build"
 	"github.com/depot/cli/pkg/buildx/builder"
+	depotbuildflags "github.com/depot/cli/pkg/buildx/buildflags"
+	"github.com/depot/cli/pkg/buildx/imagetools"
 	"github.com/depot/cli/pkg/compose"
+	depotconfig "github.com/depot/cli/pkg/config"
 	"github.com/depot/cli/pkg/dockerclient"
 	"github.com/depot/cli/pkg/helpers"
 	"github.com/depot/cli/pkg/load"
+	"github.com/depot/cli/pkg/notify"
 	"github.com/depot/cli/pkg/progresshelper"
 	"github.com/depot/cli/pkg/registry"
 	"github.com/depot/cli/pkg/sbom"
@@ -27,25 +36,56 @@ import (
 	"github.com/docker/buildx/util/progress"
 	"github.com/docker/buildx/util/tracing"
 	"github.com/docker/cli/cli/command"
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
 	"github.com/moby/buildkit/util/appcontext"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 	"golang.org/x/sync/errgroup"
 )
 
 type BakeOptions struct {
-	files     []string
+	files []string
+	// stdinFile is the bake file of "--file -", read from stdin once as the
+	// bake files are read more than once, such as with --interactive.
+	stdinFile []byte
 	overrides []string
-	printOnly bool
+	// overrideFiles are files of overrides applied before overrides.
+	overrideFiles []string
+	// skipUnchanged skips targets with the fingerprint of a previous build.
+	skipUnchanged bool
+	printOnly     bool
+	// interactive picks the targets in a terminal UI when none are given.
+	interactive bool
+	// groupOutput prints the plain progress of each target contiguously.
+	groupOutput bool
+	// statusTee, when set, receives a copy of every progress status.
+	statusTee chan<- *client.SolveStatus
+	// summary collects the target summaries of all projects.
+	summary *bakeSummary
+	// failureMode is whether a failed target cancels the other targets.
+	failureMode build.FailureMode
+	// buildArgsFile is a file of build args of every target.
+	buildArgsFile string
+	// allowSecretCmd allows the "cmd" credential processes of the secrets of
+	// bake files.
+	allowSecretCmd bool
 	commonOptions
 	DepotOptions
 }
 
-func RunBake(dockerCli command.Cli, in BakeOptions, validator BakeValidator, printer *progresshelper.SharedPrinter) (err error) {
-	ctx := appcontext.Context()
+// stdin returns the reader of the bake file of "--file -".
+func (in BakeOptions) stdin() io.Reader {
+	if in.stdinFile != nil {
+		return bytes.NewReader(in.stdinFile)
+	}
+	return os.Stdin
+}
 
+func RunBake(ctx context.Context, dockerCli command.Cli, in BakeOptions, validator BakeValidator, printer *progresshelper.SharedPrinter) (err error) {
 	ctx, end, err := tracing.TraceCurrentCommand(ctx, "bake")
 	if err != nil {
 		return err
@@ -54,9 +94,7 @@ func RunBake(dockerCli command.Cli, in BakeOptions, validator BakeValidator, pri
 		end(err)
 	}()
 
-	if os.Getenv("DEPOT_NO_SUMMARY_LINK") == "" {
-		progress.Write(printer, "[depot] build: "+in.buildURL, func() error { return err })
-	}
+	progresshelper.LogBuildURL(printer, in.buildURL)
 
 	contextPathHash, _ := os.Getwd()
 	builderOpts := append([]builder.Option{builder.WithName(in.builder),
@@ -83,6 +121,66 @@ func RunBake(dockerCli command.Cli, in BakeOptions, validator BakeValidator, pri
 		return fmt.Errorf("project %s build options not found", in.project)
 	}
 
+	err = pinSBOMGenerators(ctx, imagetools.New(imagetools.Opt{Auth: dockerCli.ConfigFile()}), buildOpts, in.sbomGenerator, depotconfig.GetSBOMGeneratorDigests(), printer)
+	if err != nil {
+		return err
+	}
+	if in.locked {
+		if err := applyLockfile(buildOpts, in.lockfile); err != nil {
+			return err
+		}
+	}
+	if err := inlineStdinDockerfile(buildOpts, os.Stdin); err != nil {
+		return err
+	}
+	destinations, err := takePushTo(buildOpts, in.pushTo, in.save)
+	if err != nil {
+		return err
+	}
+	if in.build != nil {
+		if err := helpers.ResolveDepotCacheImports(ctx, *in.build, buildOpts); err != nil {
+			return err
+		}
+	}
+
+	var (
+		fingerprints map[string]string
+		skipped      map[string]skippedTarget
+	)
+	// The images of skipped targets are copied to their --push-to names.
+	skippedImages := map[string]string{}
+	if in.skipUnchanged {
+		targetTags := map[string][]string{}
+		for target, opt := range buildOpts {
+			targetTags[target] = opt.Tags
+		}
+		fingerprints, skipped = skipUnchangedTargets(ctx, in.token, in.project, imagetools.New(imagetools.Opt{Auth: dockerCli.ConfigFile()}), buildOpts, printer)
+		skippedImages = skippedTargetImages(skipped, targetTags)
+		if len(buildOpts) == 0 {
+			if err := copyToDestinations(ctx, imagetools.New(imagetools.Opt{Auth: dockerCli.ConfigFile()}), printer, skippedImages, destinations); err != nil {
+				_ = printer.Wait()
+				return err
+			}
+			summaries := summarizeSkippedTargets(skipped)
+			in.summary.Set(in.project, summaries)
+			if in.metadataFile != "" {
+				if err := writeMetadataFile(in.metadataFile, in.project, in.buildID, nil, summaries, skippedMetadata(skipped)); err != nil {
+					return err
+				}
+			}
+			_ = printer.Wait()
+			return nil
+		}
+	}
+	// The templates are expanded after the targets are fingerprinted, so that
+	// a target whose labels change with every build can still be skipped.
+	if err := expandLabelTemplates(ctx, buildOpts, in.buildID, in.project); err != nil {
+		return err
+	}
+	if err := checkFrontendAttrsSize(buildOpts, false); err != nil {
+		return err
+	}
+
 	requestedTargets := make([]string, 0, len(buildOpts))
 	for target := range buildOpts {
 		requestedTargets = append(requestedTargets, target)
@@ -102,10 +200,14 @@ func RunBake(dockerCli command.Cli, in BakeOptions, validator BakeValidator, pri
 				BuildID:      in.DepotOptions.buildID,
 				IsBake:       true,
 				ProgressMode: in.progress,
+				Platform:     in.loadPlatform,
 			},
 		)
 	}
+	buildOpts = registry.WithCredentials(buildOpts, in.registryCredentials)
+	var pushTags map[string][]string
 	if in.save {
+		pushTags = registry.TakePushTags(buildOpts)
 		opts := registry.SaveOptions{
 			ProjectID:             in.project,
 			BuildID:               in.buildID,
@@ -116,6 +218,11 @@ func RunBake(dockerCli command.Cli, in BakeOptions, validator BakeValidator, pri
 		buildOpts = registry.WithDepotSave(buildOpts, opts)
 	}
 
+	artifactOutputs, err := artifact.ExtractOutputs(buildOpts)
+	if err != nil {
+		return err
+	}
+
 	buildxNodes := builder.ToBuildxNodes(nodes)
 	buildxNodes, err = build.FilterAvailableNodes(buildxNodes)
 	if err != nil {
@@ -133,16 +240,63 @@ func RunBake(dockerCli command.Cli, in BakeOptions, validator BakeValidator, pri
 	}
 
 	linter := NewLinter(printer, NewLintFailureMode(in.lint, in.lintFailOn), clients, buildxNodes)
-	resp, err := build.DepotBuild(ctx, buildxNodes, buildOpts, dockerClient, dockerConfigDir, printer, linter, in.DepotOptions.build)
+	linter.suppressions = in.warnings
+	policy := NewPolicy(printer, in.policyFile, buildOpts, linter)
+	buildArgChecker := NewBuildArgChecker(printer, buildOpts, in.DepotOptions)
+	definitions := NewDefinitionRecorder(in.DepotOptions)
+	baseImages, err := NewBaseImageChecker(printer, imagetools.New(imagetools.Opt{Auth: dockerCli.ConfigFile()}), buildOpts, in.DepotOptions)
+	if err != nil {
+		return err
+	}
+	requestedTargets = sortedCopy(requestedTargets)
+	targetWriter := printer.ForTargets(in.project, requestedTargets)
+	transfers := progresshelper.NewTransferMonitor(progresshelper.TrackSteps(targetWriter, in.buildID), in.progress)
+	tracker := progresshelper.NewTargetTracker(transfers, requestedTargets)
+	resp, err := build.DepotBuild(ctx, buildxNodes, buildOpts, dockerClient, dockerConfigDir, tracker, build.NewDockerfileHandlers(linter, baseImages, buildArgChecker, policy, definitions), in.DepotOptions.build, in.failureMode, in.runLimits(), in.includeGitDir, in.cacheMountPolicy, in.contextIgnores)
+	transfers.Stop()
+	definitions.Report(ctx, in.token, in.buildID, validatedOpts.Files)
+	targetWriter.Flush()
+	summaries := summarizeTargets(tracker, requestedTargets, buildOpts, resp, err)
+	// With --keep-going, the targets that built are still reported and
+	// loaded before the failed targets fail the bake.
+	var failedTargets *build.TargetsError
+	if errors.As(err, &failedTargets) {
+		err = nil
+	}
+	if err == nil && len(fingerprints) > 0 {
+		reportFingerprints(ctx, in.token, in.buildID, fingerprints, summaries)
+	}
+	summaries = append(summaries, summarizeSkippedTargets(skipped)...)
+	in.summary.Set(in.project, summaries)
 	if err != nil {
 		if errors.Is(err, LintFailed) {
 			linter.Print(os.Stderr, in.progress)
 		}
+		if errors.Is(err, PolicyFailed) {
+			policy.Print(os.Stderr, in.progress)
+		}
+		if errors.Is(err, StaleBaseImages) {
+			baseImages.Print(os.Stderr, in.progress)
+		}
+		if errors.Is(err, UnusedBuildArgs) {
+			buildArgChecker.Print(os.Stderr, in.progress)
+		}
+		if in.printSecretsUsage {
+			printSecretsUsage(os.Stderr, in.progress, buildOpts)
+		}
 		return wrapBuildError(err, true)
 	}
 
+	var rekorEntries []attestation.LogEntry
+	if in.attestationBundle != "" {
+		rekorEntries, err = writeAttestationBundle(ctx, imagetools.New(imagetools.Opt{Auth: dockerCli.ConfigFile()}), buildOpts, resp, in.DepotOptions)
+		if err != nil {
+			return err
+		}
+	}
+
 	if in.metadataFile != "" {
-		dt := make(map[string]interface{})
+		dt := skippedMetadata(skipped)
 		for _, buildRes := range resp {
 			metadata := map[string]interface{}{}
 			for _, nodeRes := range buildRes.NodeResponses {
@@ -151,14 +305,27 @@ func RunBake(dockerCli command.Cli, in BakeOptions, validator BakeValidator, pri
 					metadata[k] = v
 				}
 			}
+			withSavedTags(metadata, append(slices.Clone(pushTags[buildRes.Name]), destinations[buildRes.Name]...))
 			dt[buildRes.Name] = metadata
 		}
-		err = writeMetadataFile(in.metadataFile, in.project, in.buildID, requestedTargets, dt)
+		if machines := buildMachines(resp); len(machines) > 0 {
+			dt["depot.machines"] = machines
+		}
+		if len(rekorEntries) > 0 {
+			dt["depot.rekor"] = rekorEntries
+		}
+		err = writeMetadataFile(in.metadataFile, in.project, in.buildID, requestedTargets, summaries, dt)
 		if err != nil {
 			return err
 		}
 	}
 
+	for _, buildRes := range resp {
+		for _, nodeRes := range buildRes.NodeResponses {
+			in.notification.AddDigests(nodeRes.SolveResponse.ExporterResponse[exptypes.ExporterImageDigestKey])
+		}
+	}
+
 	if in.sbomDir != "" {
 		err = sbom.Save(ctx, in.sbomDir, resp)
 		if err != nil {
@@ -166,10 +333,35 @@ func RunBake(dockerCli command.Cli, in BakeOptions, validator BakeValidator, pri
 		}
 	}
 
+	if len(artifactOutputs) > 0 {
+		err = artifact.Push(ctx, imagetools.Opt{Auth: dockerCli.ConfigFile()}, resp, artifactOutputs, printer)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(destinations) > 0 {
+		images := pushedImages(resp)
+		for target, image := range skippedImages {
+			images[target] = image
+		}
+		if failedTargets != nil {
+			for target := range failedTargets.Errors {
+				delete(destinations, target)
+			}
+		}
+		err = copyToDestinations(ctx, imagetools.New(imagetools.Opt{Auth: dockerCli.ConfigFile()}), printer, images, destinations)
+		if err != nil {
+			return err
+		}
+	}
+
 	if len(pullOpts) > 0 {
 		eg, ctx2 := errgroup.WithContext(ctx)
 		// Three concurrent pulls at a time to avoid overwhelming the registry.
 		eg.SetLimit(3)
+		// Failures to load into the cluster do not fall back to a rebuild.
+		clusterErrs := make([]error, len(resp))
 		for i := range resp {
 			func(i int, requestedTargets []string) {
 				eg.Go(func() error {
@@ -177,9 +369,13 @@ func RunBake(dockerCli command.Cli, in BakeOptions, validator BakeValidator, pri
 					var err error
 					// Only load images from requested targets to avoid pulling unnecessary images.
 					if slices.Contains(requestedTargets, resp[i].Name) {
-						reportingPrinter := progresshelper.NewReporter(ctx2, printer, in.buildID, in.token)
+						reportingPrinter := progresshelper.NewReporter(ctx2, printer, in.buildID, in.token, progresshelper.WithProgressSampling(time.Second))
 						defer reportingPrinter.Close()
 						err = load.DepotFastLoad(ctx2, dockerCli.Client(), depotResponses, pullOpts, reportingPrinter)
+						// The cluster reads the image from the builder, before its lease is deleted.
+						if err == nil && (failedTargets == nil || failedTargets.Errors[resp[i].Name] == nil) {
+							clusterErrs[i] = loadIntoCluster(ctx2, dockerCli, in.loadCluster, depotResponses, pullOpts, reportingPrinter)
+						}
 					}
 					load.DeleteExportLeases(ctx2, depotResponses)
 					return err
@@ -193,19 +389,44 @@ func RunBake(dockerCli command.Cli, in BakeOptions, validator BakeValidator, pri
 			if in.exportLoad {
 				progress.Write(printer, "[load] fast load failed; retrying", func() error { return err })
 				buildOpts = load.WithDockerLoad(fallbackOpts)
-				_, err = build.DepotBuild(ctx, buildxNodes, buildOpts, dockerClient, dockerConfigDir, printer, nil, in.DepotOptions.build)
+				_, err = build.DepotBuild(ctx, buildxNodes, buildOpts, dockerClient, dockerConfigDir, printer, nil, in.DepotOptions.build, in.failureMode, in.runLimits(), in.includeGitDir, in.cacheMountPolicy, in.contextIgnores)
 			}
 
 			return err
 		}
+		for _, err := range clusterErrs {
+			if err != nil {
+				_ = printer.Wait()
+				return err
+			}
+		}
 	}
 
 	_ = printer.Wait()
 
 	if in.save {
 		printSaveHelp(in.project, in.buildID, in.progress, requestedTargets)
+		if err := pushSavedTags(ctx, dockerCli, in.DepotOptions, in.progress, pushTags, failedTargets, true); err != nil {
+			return err
+		}
 	}
 	linter.Print(os.Stderr, in.progress)
+	printSuppressedWarnings(os.Stderr, in.progress, in.warnings, linter.Suppressed())
+	baseImages.Print(os.Stderr, in.progress)
+	buildArgChecker.Print(os.Stderr, in.progress)
+	if in.printSecretsUsage {
+		printSecretsUsage(os.Stderr, in.progress, buildOpts)
+	}
+	if in.optimizeHints {
+		printOptimizeHints(os.Stderr, in.progress, buildOptimizeHints(in.buildID))
+	}
+	if failedTargets != nil {
+		return failedTargets
+	}
+	if violations := checkBuildBudget(in.buildID, in.budget, in.warnings); len(violations) > 0 {
+		printBudgetViolations(os.Stderr, in.progress, violations)
+		return BudgetExceeded
+	}
 	return nil
 }
 
@@ -216,6 +437,10 @@ func BakeCmd() *cobra.Command {
 		Use:     "bake [OPTIONS] [TARGET...]",
 		Aliases: []string{"f"},
 		Short:   "Build from a file",
+		Long: `Build the targets of bake files.
+
+The "validate" subcommand checks the bake files without building. To build a
+target named "validate", separate it with "--": "depot bake -- validate".`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			dockerCli, err := dockerclient.NewDockerCLI()
 			if err != nil {
@@ -229,6 +454,22 @@ func BakeCmd() *cobra.Command {
 				}
 			}
 
+			if slices.Contains(options.files, "-") {
+				if options.stdinFile, err = io.ReadAll(os.Stdin); err != nil {
+					return err
+				}
+			}
+
+			if options.interactive && len(args) == 0 {
+				if !helpers.IsTerminal() {
+					return errors.New("--interactive requires a terminal")
+				}
+				args, err = selectBakeTargets(options)
+				if err != nil {
+					return err
+				}
+			}
+
 			if options.printOnly {
 				if isRemoteTarget(args) {
 					return errors.New("cannot use remote target with --print")
@@ -236,6 +477,17 @@ func BakeCmd() *cobra.Command {
 				return BakePrint(dockerCli, args, options)
 			}
 
+			token, err := helpers.ResolveToken(context.Background(), options.token)
+			if err != nil {
+				return err
+			}
+			if token == "" {
+				return fmt.Errorf("missing API token, please run `depot login`")
+			}
+			if err := applyProfile(cmd.Context(), cmd.Flags(), token, helpers.ResolveProjectID(options.project, options.files...)); err != nil {
+				return err
+			}
+
 			// reset to nil to avoid override is unset
 			if !cmd.Flags().Lookup("no-cache").Changed {
 				options.noCache = nil
@@ -244,129 +496,324 @@ func BakeCmd() *cobra.Command {
 				options.pull = nil
 			}
 
-			token, err := helpers.ResolveToken(context.Background(), options.token)
-			if err != nil {
+			if err := validateLoadCluster(&options.DepotOptions, &options.exportLoad); err != nil {
 				return err
 			}
-
-			if token == "" {
-				return fmt.Errorf("missing API token, please run `depot login`")
+			if err := validateBaseImageAge(&options.DepotOptions); err != nil {
+				return err
 			}
-
-			options.project = helpers.ResolveProjectID(options.project, options.files...)
-
-			buildPlatform, err := helpers.ResolveBuildPlatform(options.buildPlatform)
-			if err != nil {
+			if err := validatePolicyFile(options.policyFile); err != nil {
 				return err
 			}
-
-			var (
-				validator     BakeValidator
-				validatedOpts *bake.DepotBakeOptions
-			)
-			if isRemoteTarget(args) {
-				validator = NewRemoteBakeValidator(options, args)
-			} else {
-				validator = NewLocalBakeValidator(options, args)
-				// Parse the local bake file before starting the build to catch errors early.
-				validatedOpts, _, err = validator.Validate(context.Background(), nil, nil)
-				if err != nil {
-					return err
-				}
+			if err := options.runLimits().Validate(); err != nil {
+				return err
 			}
-
-			projectIDs := validatedOpts.ProjectIDs()
-
-			printer, err := progresshelper.NewSharedPrinter(options.progress)
-			if err != nil {
+			if err := build.ValidateCacheMountPolicy(options.cacheMountPolicy); err != nil {
 				return err
 			}
-
-			for range projectIDs {
-				printer.Add()
+			if err := validateAttestationBundle(&options.DepotOptions); err != nil {
+				return err
 			}
-
-			eg, ctx := errgroup.WithContext(context.Background())
-			for _, projectID := range projectIDs {
-				options.project = projectID
-				bakeOpts := validatedOpts.ProjectOpts(projectID)
-
-				req := helpers.NewBakeRequest(
-					options.project,
-					bakeOpts,
-					helpers.UsingDepotFeatures{
-						Push: options.exportPush,
-						Load: options.exportLoad,
-						Save: options.save,
-						Lint: options.lint,
-					},
-				)
-				build, err := helpers.BeginBuild(context.Background(), req, token)
-				if err != nil {
-					return err
-				}
-				var buildErr error
-				defer func() {
-					build.Finish(buildErr)
-					PrintBuildURL(build.BuildURL, options.progress)
-				}()
-
-				options.builderOptions = []builder.Option{builder.WithDepotOptions(buildPlatform, build)}
-
-				buildProject := build.BuildProject()
-				if buildProject != "" {
-					options.project = buildProject
-				}
-				if options.save {
-					options.additionalCredentials = build.AdditionalCredentials()
-					options.additionalTags = build.AdditionalTags()
-				}
-				options.buildID = build.ID
-				options.buildURL = build.BuildURL
-				options.token = build.Token
-				options.build = &build
-
-				if options.allowNoOutput {
-					_ = os.Setenv("BUILDX_NO_DEFAULT_LOAD", "1")
-				}
-
-				func(c command.Cli, o BakeOptions, v BakeValidator, p *progresshelper.SharedPrinter) {
-					eg.Go(func() error {
-						buildErr = retryRetryableErrors(ctx, func() error {
-							return RunBake(c, o, v, p)
-						})
-						if buildErr != nil {
-							_ = p.Wait()
-						}
-
-						return rewriteFriendlyErrors(buildErr)
-					})
-				}(dockerCli, options, validator, printer)
+			if err := loadWarningsFile(&options.DepotOptions); err != nil {
+				return err
+			}
+			if err := loadRegistryAuth(&options.DepotOptions); err != nil {
+				return err
+			}
+			if err := loadContextIgnores(&options.DepotOptions); err != nil {
+				return err
+			}
+			options.progress = progresshelper.ResolveMode(options.progress)
+			if options.groupOutput && options.progress != progress.PrinterModePlain {
+				return errors.New(`--group-output requires "--progress=plain"`)
+			}
+			if options.skipUnchanged && (options.exportLoad || options.save) {
+				return errors.New("--skip-unchanged-targets cannot be used with --load or --save")
+			}
+			options.loadPlatform, err = validateLoadPlatform(options.loadPlatform, options.exportLoad, nil)
+			if err != nil {
+				return err
+			}
+			options.failureMode, err = parseFailureMode(cmd.Flags())
+			if err != nil {
+				return err
 			}
 
-			return eg.Wait()
+			return runBakeBuilds(dockerCli, options, args)
 		},
 	}
 
 	flags := cmd.Flags()
 
 	flags.StringArrayVarP(&options.files, "file", "f", []string{}, "Build definition file")
+	flags.StringVar(&options.buildArgsFile, "build-args-file", "", `File of "KEY=VALUE" build-time variables of every target, also mounted as the "build-args" secret`)
 	flags.BoolVar(&options.exportLoad, "load", false, `Shorthand for "--set=*.output=type=docker"`)
+	flags.StringVar(&options.loadPlatform, "load-platform", "", `Platform of multi-platform targets to load with "--load" (default: host platform)`)
+	flags.StringVar(&options.loadCluster, "load-cluster", "", `Load images into a local Kubernetes cluster (format: "kind|k3d|minikube[:name]"), implies "--load"`)
 	flags.BoolVar(&options.printOnly, "print", false, "Print the options without building")
+	flags.BoolVar(&options.interactive, "interactive", false, "Choose the targets to build from a list when none are given")
+	flags.BoolVar(&options.groupOutput, "group-output", false, `Print the progress of each target contiguously after the build (requires "--progress=plain")`)
 	flags.BoolVar(&options.exportPush, "push", false, `Shorthand for "--set=*.output=type=registry"`)
+	flags.StringArrayVar(&options.pushTo, "push-to", nil, `Push the image of a target to "[target=]name" as well, copied concurrently after the build`)
 	flags.StringVar(&options.sbom, "sbom", "", `Shorthand for "--set=*.attest=type=sbom"`)
 	flags.StringVar(&options.provenance, "provenance", "", `Shorthand for "--set=*.attest=type=provenance"`)
 	flags.StringArrayVar(&options.overrides, "set", nil, `Override target value (e.g., "targetpattern.key=value")`)
+	flags.StringArrayVar(&options.overrideFiles, "set-file", nil, "JSON or YAML file of target overrides and patches")
+	flags.BoolVar(&options.allowSecretCmd, "allow-secret-cmd", false, `Allow the secrets of bake files to run "cmd" credential processes`)
+	flags.BoolVar(&options.skipUnchanged, "skip-unchanged-targets", false, "Skip targets whose context, Dockerfile, and options match a previous successful build")
+	flags.Bool("fail-fast", true, "Cancel the other targets and projects when a target fails")
+	flags.Bool("keep-going", false, "Keep building the targets that do not depend on a failed target and report all failures at the end")
 
 	commonBuildFlags(&options.commonOptions, flags)
 	depotFlags(cmd, &options.DepotOptions, flags)
 	depotRegistryFlags(cmd, &options.DepotOptions, flags)
 
+	cmd.AddCommand(bakeValidateCmd())
+
 	return cmd
 }
 
-func overrides(in BakeOptions) []string {
-	overrides := in.overrides
+// runBakeBuilds starts one depot build per project referenced by the bake
+// files and runs the requested targets concurrently.
+func runBakeBuilds(dockerCli command.Cli, options BakeOptions, args []string) error {
+	token, err := helpers.ResolveToken(context.Background(), options.token)
+	if err != nil {
+		return err
+	}
+
+	if token == "" {
+		return fmt.Errorf("missing API token, please run `depot login`")
+	}
+	options.token = token
+
+	options.project = helpers.ResolveProjectID(options.project, options.files...)
+
+	buildPlatform, err := helpers.ResolveBuildPlatform(options.buildPlatform)
+	if err != nil {
+		return err
+	}
+
+	var (
+		validator     BakeValidator
+		validatedOpts *bake.DepotBakeOptions
+	)
+	if isRemoteTarget(args) {
+		validator = NewRemoteBakeValidator(options, args)
+	} else {
+		validator = NewLocalBakeValidator(options, args)
+		// Parse the local bake file before starting the build to catch errors early.
+		validatedOpts, _, err = validator.Validate(context.Background(), nil, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	projectIDs := validatedOpts.ProjectIDs()
+
+	printer, err := progresshelper.NewSharedPrinter(options.progress)
+	if err != nil {
+		return err
+	}
+
+	if options.statusTee != nil {
+		printer.Tee(options.statusTee)
+	}
+	if options.groupOutput {
+		printer.GroupOutput()
+	}
+
+	for range projectIDs {
+		printer.Add()
+	}
+
+	options.summary = newBakeSummary()
+
+	ctx := appcontext.Context()
+	eg := &errgroup.Group{}
+	if options.failureMode == build.FailFast {
+		// The first failed project cancels the others.
+		eg, ctx = errgroup.WithContext(ctx)
+	}
+	for _, projectID := range projectIDs {
+		options.project = projectID
+		bakeOpts := validatedOpts.ProjectOpts(projectID)
+
+		req := helpers.NewBakeRequest(
+			options.project,
+			bakeOpts,
+			helpers.UsingDepotFeatures{
+				Push: options.exportPush,
+				Load: options.exportLoad,
+				Save: options.save,
+				Lint: options.lint,
+			},
+		)
+		build, err := helpers.BeginBuild(context.Background(), req, token)
+		if err != nil {
+			return err
+		}
+		notification := notify.New(options.notifyOptions(), build.ID, options.project, build.BuildURL)
+		var buildErr error
+		defer func() {
+			build.Finish(buildErr)
+			PrintBuildURL(build.BuildURL, options.progress)
+			notification.Send(buildErr)
+		}()
+
+		if buildErr = helpers.CheckEntitlements(build, bakeOpts); buildErr != nil {
+			return buildErr
+		}
+
+		options.builderOptions = []builder.Option{builder.WithDepotOptions(buildPlatform, build), builder.WithPlacement(options.region, options.near)}
+
+		buildProject := build.BuildProject()
+		if buildProject != "" {
+			options.project = buildProject
+		}
+		if options.save {
+			options.additionalCredentials = build.AdditionalCredentials()
+			options.additionalTags = build.AdditionalTags()
+		}
+		options.buildID = build.ID
+		options.buildURL = build.BuildURL
+		options.token = build.Token
+		options.build = &build
+		options.notification = notification
+
+		if options.allowNoOutput {
+			_ = os.Setenv("BUILDX_NO_DEFAULT_LOAD", "1")
+		}
+
+		func(c command.Cli, o BakeOptions, v BakeValidator, p *progresshelper.SharedPrinter) {
+			eg.Go(func() error {
+				buildErr = retryRetryableErrors(ctx, func() error {
+					return RunBake(ctx, c, o, v, p)
+				})
+				if buildErr != nil {
+					_ = p.Wait()
+				}
+
+				return rewriteFriendlyErrors(buildErr)
+			})
+		}(dockerCli, options, validator, printer)
+	}
+
+	err = eg.Wait()
+	options.summary.Print(os.Stderr, options.progress)
+	return err
+}
+
+// readTargets reads the targets of the bake files with the overrides and
+// patches of the options.
+func readTargets(ctx context.Context, files []bake.File, targets []string, in BakeOptions, defaults map[string]string) (map[string]*bake.Target, map[string]*bake.Group, error) {
+	var (
+		overrides []string
+		patches   []bake.PatchOp
+	)
+	for _, path := range in.overrideFiles {
+		f, err := bake.ReadOverrideFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		fileOverrides, err := f.Overrides()
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid override file %s", path)
+		}
+		overrides = append(overrides, fileOverrides...)
+		patches = append(patches, f.Patch...)
+	}
+	overrides = append(overrides, flagOverrides(in)...)
+
+	tgts, grps, err := bake.ReadTargets(ctx, files, targets, overrides, defaults)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := bake.ApplyPatches(tgts, patches); err != nil {
+		return nil, nil, err
+	}
+	if err := applyEnvPassthrough(tgts, in.envPassthrough); err != nil {
+		return nil, nil, err
+	}
+	if err := applyBuildArgsFile(tgts, in.buildArgsFile); err != nil {
+		return nil, nil, err
+	}
+	if err := validateBakeContextIgnoreNames(in.contextIgnores, tgts); err != nil {
+		return nil, nil, err
+	}
+	return tgts, grps, nil
+}
+
+// validateBakeContextIgnoreNames checks that every --context-ignorefile names
+// a context of at least one of the targets.
+func validateBakeContextIgnoreNames(ignores *build.ContextIgnores, tgts map[string]*bake.Target) error {
+	if ignores == nil || len(ignores.Files) == 0 {
+		return nil
+	}
+	named := map[string]struct{}{}
+	for _, t := range tgts {
+		for name := range t.Contexts {
+			if name, err := normalizeContextName(name); err == nil {
+				named[name] = struct{}{}
+			}
+		}
+	}
+	var problems []string
+	for name := range ignores.Files {
+		if _, ok := named[name]; !ok {
+			problems = append(problems, fmt.Sprintf("%s is not a context of any target", name))
+		}
+	}
+	if len(problems) > 0 {
+		slices.Sort(problems)
+		return fmt.Errorf("invalid --context-ignorefile:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// expandTargetPlatforms replaces the "all" and "depot-defaults" platforms of
+// the targets with the platforms configured for their projects.
+func expandTargetPlatforms(ctx context.Context, tgts map[string]*bake.Target, defaultProjectID, token string) error {
+	for name, t := range tgts {
+		if !slices.ContainsFunc(t.Platforms, helpers.IsProjectPlatforms) {
+			continue
+		}
+		projectID := t.ProjectID
+		if projectID == "" {
+			projectID = defaultProjectID
+		}
+		platforms, err := helpers.ExpandProjectPlatforms(ctx, token, projectID, t.Platforms)
+		if err != nil {
+			return errors.Wrapf(err, "target %s", name)
+		}
+		t.Platforms = platforms
+	}
+	return nil
+}
+
+// applyEnvPassthrough adds the --env-passthrough secrets to every target.
+func applyEnvPassthrough(tgts map[string]*bake.Target, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	specs, err := depotbuildflags.ParseEnvPassthrough(names)
+	if err != nil {
+		return err
+	}
+	for name, t := range tgts {
+		args := make([]string, 0, len(t.Args))
+		for k := range t.Args {
+			args = append(args, k)
+		}
+		if err := depotbuildflags.CheckEnvPassthrough(names, t.Secrets, args); err != nil {
+			return errors.Wrapf(err, "target %s", name)
+		}
+		t.Secrets = append(t.Secrets, specs...)
+	}
+	return nil
+}
+
+func flagOverrides(in BakeOptions) []string {
+	overrides := slices.Clone(in.overrides)
 	if in.exportPush {
 		overrides = append(overrides, "*.push=true")
 	}
@@ -386,6 +833,19 @@ func overrides(in BakeOptions) []string {
 	return overrides
 }
 
+// parseFailureMode resolves --fail-fast and --keep-going, which are opposites.
+func parseFailureMode(flags *pflag.FlagSet) (build.FailureMode, error) {
+	failFast, _ := flags.GetBool("fail-fast")
+	keepGoing, _ := flags.GetBool("keep-going")
+	if flags.Changed("fail-fast") && flags.Changed("keep-going") && failFast == keepGoing {
+		return build.FailFast, errors.New("--fail-fast and --keep-going cannot be used together")
+	}
+	if keepGoing || !failFast {
+		return build.KeepGoing, nil
+	}
+	return build.FailFast, nil
+}
+
 func isRemoteTarget(targets []string) bool {
 	if len(targets) == 0 {
 		return false
@@ -407,6 +867,8 @@ type BakeValidator interface {
 type LocalBakeValidator struct {
 	options     BakeOptions
 	bakeTargets bakeTargets
+	// checkProjects also checks that the projects of the targets exist.
+	checkProjects bool
 
 	once      sync.Once
 	buildOpts *bake.DepotBakeOptions
@@ -425,19 +887,18 @@ func (t *LocalBakeValidator) Validate(ctx context.Context, _ []builder.Node, _ p
 	// Using a sync.Once because I _think_ the bake file may not always be read
 	// more than one time such as passed over stdin.
 	t.once.Do(func() {
-		files, err := bake.ReadLocalFiles(t.options.files, os.Stdin)
+		files, err := bake.ReadLocalFiles(t.options.files, t.options.stdin())
 		if err != nil {
 			t.err = err
 			return
 		}
 
-		overrides := overrides(t.options)
 		defaults := map[string]string{
 			"BAKE_CMD_CONTEXT":    t.bakeTargets.CmdContext,
 			"BAKE_LOCAL_PLATFORM": platforms.DefaultString(),
 		}
 
-		targets, groups, err := bake.ReadTargets(ctx, files, t.bakeTargets.Targets, overrides, defaults)
+		targets, groups, err := readTargets(ctx, files, t.bakeTargets.Targets, t.options, defaults)
 		if err != nil {
 			t.err = err
 			return
@@ -470,7 +931,15 @@ func (t *LocalBakeValidator) Validate(ctx context.Context, _ []builder.Node, _ p
 			}
 		}
 
-		t.buildOpts, t.err = bake.NewDepotBakeOptions(t.options.project, targets, nil)
+		if err := validateTargets(ctx, targets, t.options, t.checkProjects); err != nil {
+			t.err = err
+			return
+		}
+
+		t.buildOpts, t.err = bake.NewDepotBakeOptions(t.options.project, targets, nil, t.options.allowSecretCmd)
+		if t.err == nil {
+			t.buildOpts.Files = files
+		}
 	})
 
 	return t.buildOpts, t.targets, t.err
@@ -494,16 +963,18 @@ func (t *RemoteBakeValidator) Validate(ctx context.Context, nodes []builder.Node
 		return nil, nil, err
 	}
 
-	overrides := overrides(t.options)
 	defaults := map[string]string{
 		"BAKE_CMD_CONTEXT":    t.bakeTargets.CmdContext,
 		"BAKE_LOCAL_PLATFORM": platforms.DefaultString(),
 	}
 
-	targets, groups, err := bake.ReadTargets(ctx, files, t.bakeTargets.Targets, overrides, defaults)
+	targets, groups, err := readTargets(ctx, files, t.bakeTargets.Targets, t.options, defaults)
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := expandTargetPlatforms(ctx, targets, t.options.project, t.options.token); err != nil {
+		return nil, nil, err
+	}
 
 	requestedTargets := []string{}
 	uniqueTargets := map[string]struct{}{}
@@ -521,8 +992,12 @@ func (t *RemoteBakeValidator) Validate(ctx context.Context, nodes []builder.Node
 		requestedTargets = append(requestedTargets, target)
 	}
 
-	opts, err := bake.NewDepotBakeOptions(t.options.project, targets, inp)
-	return opts, requestedTargets, err
+	opts, err := bake.NewDepotBakeOptions(t.options.project, targets, inp, t.options.allowSecretCmd)
+	if err != nil {
+		return nil, nil, err
+	}
+	opts.Files = files
+	return opts, requestedTargets, nil
 }
 
 type bakeTargets struct {
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/depot/cli/pkg/buildx/bake"
+	"github.com/depot/cli/pkg/buildx/build"
+)
+
+func TestValidateBakeContextIgnoreNames(t *testing.T) {
+	tgts := map[string]*bake.Target{
+		"api": {Contexts: map[string]string{"tools": "../tools"}},
+		"web": {Contexts: map[string]string{"shared": "../shared", "alpine": "docker-image://alpine:3.19"}},
+		"cli": {},
+	}
+
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr bool
+	}{
+		{name: "no ignore files"},
+		{name: "context of one target", files: map[string]string{"tools": "tools.dockerignore"}},
+		{name: "contexts of several targets", files: map[string]string{"tools": "tools.dockerignore", "shared": "shared.dockerignore"}},
+		{name: "unknown context", files: map[string]string{"docs": "docs.dockerignore"}, wantErr: true},
+		{name: "known and unknown contexts", files: map[string]string{"tools": "tools.dockerignore", "docs": "docs.dockerignore"}, wantErr: true},
+		{name: "target name is not a context", files: map[string]string{"cli": "cli.dockerignore"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var ignores *build.ContextIgnores
+			if tt.files != nil {
+				ignores = &build.ContextIgnores{Files: tt.files}
+			}
+			err := validateBakeContextIgnoreNames(ignores, tgts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateBakeContextIgnoreNames() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/depot/cli/pkg/buildx/bake"
+	"github.com/depot/cli/pkg/helpers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+)
+
+func bakeValidateCmd() *cobra.Command {
+	var options BakeOptions
+
+	cmd := &cobra.Command{
+		Use:   "validate [OPTIONS] [TARGET...]",
+		Short: "Check bake files without building",
+		Long: `Check that the bake files parse and that every requested target has a project,
+valid platforms, and valid options, after applying variables and overrides.
+All problems are reported at once, and no build is started.
+
+This subcommand takes precedence over a bake target named "validate"; build
+that target with "depot bake -- validate".`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if isRemoteTarget(args) {
+				return errors.New("remote bake files cannot be validated, validate a local checkout instead")
+			}
+			// TODO: remove when upgrading to buildx 0.12
+			for idx, file := range options.files {
+				options.files[idx] = strings.TrimPrefix(file, "cwd://")
+			}
+
+			token, err := helpers.ResolveToken(context.Background(), options.token)
+			if err != nil {
+				return err
+			}
+			options.token = token
+			options.project = helpers.ResolveProjectID(options.project, options.files...)
+
+			targets, err := validateBake(cmd.Context(), options, args)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "%d bake targets are valid: %s\n", len(targets), strings.Join(targets, ", "))
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringArrayVarP(&options.files, "file", "f", []string{}, "Build definition file")
+	flags.StringArrayVar(&options.overrides, "set", nil, `Override target value (e.g., "targetpattern.key=value")`)
+	flags.StringArrayVar(&options.overrideFiles, "set-file", nil, "JSON or YAML file of target overrides and patches")
+	flags.BoolVar(&options.allowSecretCmd, "allow-secret-cmd", false, `Allow the secrets of bake files to run "cmd" credential processes`)
+	flags.StringVar(&options.project, "project", "", "Depot project ID")
+	flags.StringVar(&options.token, "token", "", "Depot token")
+
+	return cmd
+}
+
+// validateBake reads the targets of the bake files like a build would and
+// returns the names of the requested targets, or an error listing the
+// problems of every target.  Project IDs and "all" platforms are only checked
+// with the API if there is a token.
+func validateBake(ctx context.Context, options BakeOptions, args []string) ([]string, error) {
+	validator := NewLocalBakeValidator(options, args)
+	validator.checkProjects = true
+	_, targets, err := validator.Validate(ctx, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	names := slices.Clone(targets)
+	sort.Strings(names)
+	return names, nil
+}
+
+// validateTargets checks the tags, project, platforms, and options of every
+// target and reports the problems of every target at once.  The project
+// platforms of the targets are expanded in place.  With checkProjects, it
+// also checks that the projects exist.
+func validateTargets(ctx context.Context, targets map[string]*bake.Target, options BakeOptions, checkProjects bool) error {
+	names := make([]string, 0, len(targets))
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+	projects := map[string]error{}
+	for _, name := range names {
+		target := targets[name]
+		refs := append(slices.Clone(target.Tags), exportNames(parseTargetOutputs(target.Outputs))...)
+		for _, problem := range tagProblems(refs) {
+			problems = append(problems, fmt.Sprintf("target %s: %s", name, problem))
+		}
+
+		projectID := target.ProjectID
+		if projectID == "" {
+			projectID = options.project
+		}
+		switch {
+		case projectID == "":
+			problems = append(problems, fmt.Sprintf("target %s: project ID is missing, please specify with --project, DEPOT_PROJECT_ID, or run `depot init`", name))
+		case checkProjects && options.token != "":
+			if _, ok := projects[projectID]; !ok {
+				_, projects[projectID] = helpers.ProjectExists(ctx, options.token, projectID)
+			}
+			if err := projects[projectID]; err != nil {
+				problems = append(problems, fmt.Sprintf("target %s: %s", name, err))
+			}
+		}
+
+		if slices.ContainsFunc(target.Platforms, helpers.IsProjectPlatforms) {
+			if projectID == "" || projects[projectID] != nil {
+				continue
+			}
+			if options.token == "" {
+				problems = append(problems, fmt.Sprintf("target %s: the platforms of the project require an API token, please run `depot login`", name))
+				continue
+			}
+			expanded, err := helpers.ExpandProjectPlatforms(ctx, options.token, projectID, target.Platforms)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("target %s: %s", name, err))
+				continue
+			}
+			target.Platforms = expanded
+		}
+
+		if err := bake.ValidateTarget(target, options.allowSecretCmd); err != nil {
+			problems = append(problems, fmt.Sprintf("target %s: %s", name, err))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid bake targets:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
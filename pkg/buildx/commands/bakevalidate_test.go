@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateBake(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "docker-bake.hcl")
+	hcl := `
+group "default" {
+  targets = ["api", "web"]
+}
+
+target "api" {
+  tags = ["repo/api:latest"]
+}
+
+target "web" {
+  tags      = ["repo/Web:latest"]
+  platforms = ["not a platform!"]
+}
+`
+	if err := os.WriteFile(file, []byte(hcl), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	options := BakeOptions{files: []string{file}}
+	options.project = "abc123"
+	names, err := validateBake(context.Background(), options, []string{"api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(names, ",") != "api" {
+		t.Errorf("names = %v, want [api]", names)
+	}
+
+	_, err = validateBake(context.Background(), options, nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"target web: \"repo/Web:latest\"", "target web: \"not a platform!\""} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "target api") {
+		t.Errorf("error %q reports the valid target", err)
+	}
+}
+
+func TestValidateBakeStdinFile(t *testing.T) {
+	options := BakeOptions{files: []string{"-"}, stdinFile: []byte(`target "api" {}`)}
+	options.project = "abc123"
+	// The bake file of stdin is read again by each validator.
+	for i := 0; i < 2; i++ {
+		names, err := validateBake(context.Background(), options, []string{"api"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Join(names, ",") != "api" {
+			t.Errorf("names = %v, want [api]", names)
+		}
+	}
+}
@@ -0,0 +1,323 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	depotbuild "github.com/depot/cli/pkg/buildx/build"
+	"github.com/depot/cli/pkg/buildx/imagetools"
+	"github.com/depot/cli/pkg/debuglog"
+	"github.com/depot/cli/pkg/helpers"
+	"github.com/depot/cli/pkg/progresshelper"
+	"github.com/distribution/reference"
+	"github.com/docker/buildx/build"
+	"github.com/docker/buildx/util/progress"
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/identity"
+	"github.com/morikuni/aec"
+	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// StaleBaseImages is the error returned with --fail-on-stale-base when a base image is stale.
+var StaleBaseImages = errors.New("stale base images")
+
+// BaseImageChecker resolves the FROM images of each target before the build
+// and reports those that are older than the maximum age, pinned to an
+// outdated digest, or have a newer patch tag.
+type BaseImageChecker struct {
+	Enabled     bool
+	FailOnStale bool
+	MaxAge      time.Duration
+
+	resolver *imagetools.Resolver
+	opts     map[string]build.Options
+	printer  progress.Writer
+
+	mu     sync.Mutex
+	issues map[string][]string
+}
+
+func NewBaseImageChecker(printer progress.Writer, resolver *imagetools.Resolver, opts map[string]build.Options, depotOpts DepotOptions) (*BaseImageChecker, error) {
+	checker := &BaseImageChecker{
+		Enabled:     depotOpts.checkBaseImages || depotOpts.failOnStaleBase,
+		FailOnStale: depotOpts.failOnStaleBase,
+		resolver:    resolver,
+		opts:        opts,
+		printer:     printer,
+		issues:      make(map[string][]string),
+	}
+	if !checker.Enabled {
+		return checker, nil
+	}
+
+	maxAge, err := helpers.ParseDuration(depotOpts.maxBaseImageAge)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid --max-base-image-age")
+	}
+	checker.MaxAge = maxAge
+	return checker, nil
+}
+
+func (c *BaseImageChecker) Handle(ctx context.Context, target string, driverIndex int, dockerfile *depotbuild.DockerfileInputs, _ progress.Writer) error {
+	if !c.Enabled || dockerfile.Err != nil || len(dockerfile.Content) == 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	if _, ok := c.issues[target]; ok {
+		c.mu.Unlock()
+		return nil
+	}
+	c.issues[target] = nil
+	c.mu.Unlock()
+
+	name := "[base images]"
+	if target != defaultTargetName {
+		name = fmt.Sprintf("[%s base images]", target)
+	}
+	dgst := digest.Canonical.FromString(identity.NewID())
+	tm := time.Now()
+	c.printer.Write(&client.SolveStatus{Vertexes: []*client.Vertex{{Digest: dgst, Name: name, Started: &tm}}})
+
+	var platform *ocispecs.Platform
+	if opt := c.opts[target]; len(opt.Platforms) > 0 {
+		platform = &opt.Platforms[0]
+	}
+
+	baseImages, _, _ := dockerfileUsage(dockerfile.Content)
+	var issues []string
+	for _, image := range baseImages {
+		// Images depending on build args are not resolved.
+		if strings.Contains(image, "$") {
+			continue
+		}
+		imageIssues, err := c.check(ctx, image, platform)
+		if err != nil {
+			debuglog.Log("unable to check base image %s: %v", image, err)
+			continue
+		}
+		issues = append(issues, imageIssues...)
+	}
+
+	doneTm := time.Now()
+	vertex := &client.Vertex{Digest: dgst, Name: name, Started: &tm, Completed: &doneTm}
+	var statuses []*client.VertexStatus
+	for _, issue := range issues {
+		statuses = append(statuses, &client.VertexStatus{Vertex: dgst, ID: "STALE " + issue, Timestamp: tm, Started: &tm, Completed: &doneTm})
+	}
+	if len(issues) > 0 && c.FailOnStale {
+		vertex.Error = strings.Join(issues, "\n")
+	}
+	c.printer.Write(&client.SolveStatus{Vertexes: []*client.Vertex{vertex}, Statuses: statuses})
+
+	c.mu.Lock()
+	c.issues[target] = issues
+	c.mu.Unlock()
+
+	if len(issues) > 0 && c.FailOnStale {
+		return StaleBaseImages
+	}
+	return nil
+}
+
+// check returns the staleness issues of one base image.
+func (c *BaseImageChecker) check(ctx context.Context, image string, platform *ocispecs.Platform) ([]string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return nil, err
+	}
+	tag := "latest"
+	if t, ok := named.(reference.Tagged); ok {
+		tag = t.Tag()
+	}
+	tagged, err := reference.WithTag(reference.TrimNamed(named), tag)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []string
+
+	// The build resolves the tag to its current digest unless the digest is pinned.
+	ref := tagged.String()
+	if pinned, ok := named.(reference.Digested); ok {
+		ref = reference.TrimNamed(named).String() + "@" + pinned.Digest().String()
+		if _, isTagged := named.(reference.Tagged); isTagged {
+			_, desc, err := c.resolver.Resolve(ctx, tagged.String())
+			if err == nil && desc.Digest != pinned.Digest() {
+				issues = append(issues, fmt.Sprintf("%s is pinned to %s but %s is now %s", image, shortDigest(pinned.Digest()), reference.FamiliarString(tagged), shortDigest(desc.Digest)))
+			}
+		}
+	}
+
+	created, err := c.created(ctx, ref, platform)
+	if err != nil {
+		return nil, err
+	}
+	if created != nil && c.MaxAge > 0 {
+		if age := time.Since(*created); age > c.MaxAge {
+			issues = append(issues, fmt.Sprintf("%s was created %d days ago", image, int(age.Hours()/24)))
+		}
+	}
+
+	if _, ok := parsePatchTag(tag); ok {
+		tags, err := c.resolver.Tags(ctx, reference.TrimNamed(named).String())
+		if err == nil {
+			if newer := newerPatchTag(tag, tags); newer != "" {
+				issues = append(issues, fmt.Sprintf("%s has a newer patch tag %s", image, newer))
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// created returns the creation time from the image config of the platform.
+func (c *BaseImageChecker) created(ctx context.Context, ref string, platform *ocispecs.Platform) (*time.Time, error) {
+	dt, desc, err := c.resolver.Get(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if images.IsIndexType(desc.MediaType) {
+		var index ocispecs.Index
+		if err := json.Unmarshal(dt, &index); err != nil {
+			return nil, err
+		}
+		matcher := platforms.Default()
+		if platform != nil {
+			matcher = platforms.Only(*platform)
+		}
+		var found bool
+		for _, m := range index.Manifests {
+			if m.Platform != nil && matcher.Match(*m.Platform) {
+				desc, found = m, true
+				break
+			}
+		}
+		if !found {
+			return nil, nil
+		}
+		if dt, err = c.resolver.GetDescriptor(ctx, ref, desc); err != nil {
+			return nil, err
+		}
+	}
+
+	var manifest ocispecs.Manifest
+	if err := json.Unmarshal(dt, &manifest); err != nil {
+		return nil, err
+	}
+	dt, err = c.resolver.GetDescriptor(ctx, ref, manifest.Config)
+	if err != nil {
+		return nil, err
+	}
+	var config ocispecs.Image
+	if err := json.Unmarshal(dt, &config); err != nil {
+		return nil, err
+	}
+	return config.Created, nil
+}
+
+// Print writes the stale base images after the build progress has finished.
+func (c *BaseImageChecker) Print(w io.Writer, mode string) {
+	if !c.Enabled || mode == progress.PrinterModeQuiet {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	targets := make([]string, 0, len(c.issues))
+	numIssues := 0
+	for target, issues := range c.issues {
+		targets = append(targets, target)
+		numIssues += len(issues)
+	}
+	if numIssues == 0 {
+		return
+	}
+	sort.Strings(targets)
+
+	summary := "1 stale base image issue found"
+	if numIssues > 1 {
+		summary = fmt.Sprintf("%d stale base image issues found", numIssues)
+	}
+	if progresshelper.UseColor(mode) {
+		color := aec.YellowF
+		if c.FailOnStale {
+			color = aec.RedF
+		}
+		summary = color.Apply(summary)
+	}
+	fmt.Fprintf(w, "\n %s:\n", summary)
+
+	for _, target := range targets {
+		prefix := ""
+		if target != defaultTargetName {
+			prefix = fmt.Sprintf("[%s] ", target)
+		}
+		for _, issue := range c.issues[target] {
+			fmt.Fprintf(w, "STALE %s%s\n", prefix, issue)
+		}
+	}
+	fmt.Fprintf(w, "\n")
+}
+
+var patchTagRe = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(-.+)?$`)
+
+type patchTag struct {
+	major, minor, patch int
+	suffix              string
+}
+
+// parsePatchTag parses tags with a full version like 1.21.3 or 1.21.3-alpine.
+func parsePatchTag(tag string) (patchTag, bool) {
+	m := patchTagRe.FindStringSubmatch(tag)
+	if m == nil {
+		return patchTag{}, false
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	patch, _ := strconv.Atoi(m[3])
+	return patchTag{major: major, minor: minor, patch: patch, suffix: m[4]}, true
+}
+
+// newerPatchTag returns the highest tag of the same major, minor, and suffix
+// with a higher patch version, if any.
+func newerPatchTag(current string, tags []string) string {
+	cur, ok := parsePatchTag(current)
+	if !ok {
+		return ""
+	}
+
+	newest, newestPatch := "", cur.patch
+	for _, tag := range tags {
+		t, ok := parsePatchTag(tag)
+		if !ok || t.major != cur.major || t.minor != cur.minor || t.suffix != cur.suffix {
+			continue
+		}
+		if t.patch > newestPatch {
+			newest, newestPatch = tag, t.patch
+		}
+	}
+	return newest
+}
+
+func shortDigest(d digest.Digest) string {
+	encoded := d.Encoded()
+	if len(encoded) > 12 {
+		encoded = encoded[:12]
+	}
+	return d.Algorithm().String() + ":" + encoded
+}
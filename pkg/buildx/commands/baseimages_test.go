@@ -0,0 +1,22 @@
+package commands
+
+import "testing"
+
+func TestNewerPatchTag(t *testing.T) {
+	tags := []string{"1.21", "1.21.3", "1.21.4", "1.21.10-alpine", "1.21.5-alpine", "1.22.0", "latest"}
+	tests := []struct {
+		current string
+		want    string
+	}{
+		{current: "1.21.3", want: "1.21.4"},
+		{current: "1.21.4", want: ""},
+		{current: "1.21.4-alpine", want: "1.21.10-alpine"},
+		{current: "1.21", want: ""},
+		{current: "latest", want: ""},
+	}
+	for _, tt := range tests {
+		if got := newerPatchTag(tt.current, tags); got != tt.want {
+			t.Errorf("newerPatchTag(%q) = %q, want %q", tt.current, got, tt.want)
+		}
+	}
+}
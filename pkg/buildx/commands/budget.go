@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/depot/cli/pkg/progresshelper"
+	"github.com/docker/buildx/util/progress"
+	"github.com/moby/buildkit/client"
+	"github.com/morikuni/aec"
+	"github.com/pkg/errors"
+)
+
+// BudgetExceeded is the error returned when a build exceeds one of its budgets.
+var BudgetExceeded = errors.New("build budget exceeded")
+
+// maxBudgetSteps is how many of the slowest steps are named for time budgets.
+const maxBudgetSteps = 5
+
+// BuildBudget are the quality gates of a build.  Negative or zero values
+// disable a budget.
+type BuildBudget struct {
+	// MaxWarnings is the number of warnings allowed; -1 allows any.
+	MaxWarnings int
+	// MaxDuration is the time from the first step starting to the last step completing.
+	MaxDuration time.Duration
+	// MaxUncached is the total time of the steps that were not cached.
+	MaxUncached time.Duration
+}
+
+func (b BuildBudget) enabled() bool {
+	return b.MaxWarnings >= 0 || b.MaxDuration > 0 || b.MaxUncached > 0
+}
+
+// BudgetViolation is a budget the build exceeded and the steps responsible.
+type BudgetViolation struct {
+	Budget string
+	Limit  string
+	Actual string
+	Steps  []BudgetStep
+}
+
+// BudgetStep is a step responsible for a violation, with its duration in
+// seconds or its warning.
+type BudgetStep struct {
+	Name     string
+	Duration float64
+	Warning  string
+}
+
+// checkBuildBudget checks the steps and unsuppressed warnings of a build
+// tracked with progresshelper.TrackSteps against its budget.
+func checkBuildBudget(buildID string, budget BuildBudget, suppressions *WarningSuppressions) []BudgetViolation {
+	if !budget.enabled() {
+		return nil
+	}
+	warnings, _ := suppressions.Filter(progresshelper.BuildWarnings(buildID))
+	return checkBudget(budget, progresshelper.BuildVertexes(buildID), warnings)
+}
+
+// checkBudget compares the steps and warnings of a build to its budget.
+func checkBudget(budget BuildBudget, vertexes []client.Vertex, warnings []client.VertexWarning) []BudgetViolation {
+	var violations []BudgetViolation
+
+	if budget.MaxWarnings >= 0 && len(warnings) > budget.MaxWarnings {
+		names := map[string]string{}
+		for _, v := range vertexes {
+			names[v.Digest.String()] = v.Name
+		}
+		violation := BudgetViolation{
+			Budget: "warnings",
+			Limit:  fmt.Sprint(budget.MaxWarnings),
+			Actual: fmt.Sprint(len(warnings)),
+		}
+		for _, w := range warnings {
+			violation.Steps = append(violation.Steps, BudgetStep{Name: names[w.Vertex.String()], Warning: string(w.Short)})
+		}
+		violations = append(violations, violation)
+	}
+
+	var (
+		first, last time.Time
+		uncached    time.Duration
+		steps       []BudgetStep
+	)
+	for _, v := range vertexes {
+		if v.Started == nil || v.Completed == nil {
+			continue
+		}
+		if first.IsZero() || v.Started.Before(first) {
+			first = *v.Started
+		}
+		if v.Completed.After(last) {
+			last = *v.Completed
+		}
+		if !v.Cached {
+			d := v.Completed.Sub(*v.Started)
+			uncached += d
+			steps = append(steps, BudgetStep{Name: v.Name, Duration: d.Round(100 * time.Millisecond).Seconds()})
+		}
+	}
+	sort.SliceStable(steps, func(i, j int) bool { return steps[i].Duration > steps[j].Duration })
+	if len(steps) > maxBudgetSteps {
+		steps = steps[:maxBudgetSteps]
+	}
+
+	if duration := last.Sub(first); budget.MaxDuration > 0 && duration > budget.MaxDuration {
+		violations = append(violations, BudgetViolation{
+			Budget: "duration",
+			Limit:  budget.MaxDuration.String(),
+			Actual: duration.Round(100 * time.Millisecond).String(),
+			Steps:  steps,
+		})
+	}
+	if budget.MaxUncached > 0 && uncached > budget.MaxUncached {
+		violations = append(violations, BudgetViolation{
+			Budget: "uncached step time",
+			Limit:  budget.MaxUncached.String(),
+			Actual: uncached.Round(100 * time.Millisecond).String(),
+			Steps:  steps,
+		})
+	}
+	return violations
+}
+
+func printBudgetViolations(w io.Writer, mode string, violations []BudgetViolation) {
+	if len(violations) == 0 || mode == progress.PrinterModeQuiet {
+		return
+	}
+
+	summary := "1 build budget exceeded"
+	if len(violations) > 1 {
+		summary = fmt.Sprintf("%d build budgets exceeded", len(violations))
+	}
+	if progresshelper.UseColor(mode) {
+		summary = aec.RedF.Apply(summary)
+	}
+	fmt.Fprintf(w, "\n %s:\n", summary)
+
+	for _, v := range violations {
+		fmt.Fprintf(w, "BUDGET %s: %s exceeds %s\n", v.Budget, v.Actual, v.Limit)
+		for _, step := range v.Steps {
+			name := strings.TrimSpace(step.Name)
+			if name == "" {
+				name = "-"
+			}
+			if step.Warning != "" {
+				fmt.Fprintf(w, "  %s: %s\n", name, step.Warning)
+			} else {
+				fmt.Fprintf(w, "  %6.1fs %s\n", step.Duration, name)
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+)
+
+func TestCheckBudget(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	at := func(d time.Duration) *time.Time {
+		t := start.Add(d)
+		return &t
+	}
+	vertexes := []client.Vertex{
+		{Digest: digest.FromString("a"), Name: "[1/3] FROM alpine", Started: at(0), Completed: at(time.Second), Cached: true},
+		{Digest: digest.FromString("b"), Name: "[2/3] RUN make", Started: at(time.Second), Completed: at(40 * time.Second)},
+		{Digest: digest.FromString("c"), Name: "[3/3] RUN make test", Started: at(40 * time.Second), Completed: at(60 * time.Second)},
+		{Digest: digest.FromString("d"), Name: "exporting", Started: at(60 * time.Second)},
+	}
+	warnings := []client.VertexWarning{{Vertex: digest.FromString("b"), Short: []byte("deprecated flag")}}
+
+	if violations := checkBudget(BuildBudget{MaxWarnings: -1}, vertexes, warnings); len(violations) != 0 {
+		t.Fatalf("expected no violations with disabled budgets, got %v", violations)
+	}
+	if violations := checkBudget(BuildBudget{MaxWarnings: 1, MaxDuration: time.Minute, MaxUncached: time.Minute}, vertexes, warnings); len(violations) != 0 {
+		t.Fatalf("expected no violations within budget, got %v", violations)
+	}
+
+	violations := checkBudget(BuildBudget{MaxWarnings: 0, MaxDuration: 30 * time.Second, MaxUncached: 50 * time.Second}, vertexes, warnings)
+	if len(violations) != 3 {
+		t.Fatalf("expected 3 violations, got %v", violations)
+	}
+
+	if v := violations[0]; v.Budget != "warnings" || v.Actual != "1" || len(v.Steps) != 1 || v.Steps[0].Name != "[2/3] RUN make" || v.Steps[0].Warning != "deprecated flag" {
+		t.Errorf("unexpected warnings violation: %+v", v)
+	}
+	if v := violations[1]; v.Budget != "duration" || v.Actual != "1m0s" || v.Limit != "30s" {
+		t.Errorf("unexpected duration violation: %+v", v)
+	}
+	v := violations[2]
+	if v.Budget != "uncached step time" || v.Actual != "59s" {
+		t.Errorf("unexpected uncached violation: %+v", v)
+	}
+	if len(v.Steps) != 2 || v.Steps[0].Name != "[2/3] RUN make" || v.Steps[0].Duration != 39 || v.Steps[1].Name != "[3/3] RUN make test" {
+		t.Errorf("expected the slowest uncached steps first, got %+v", v.Steps)
+	}
+}
@@ -5,6 +5,7 @@ package commands
 import (
 	"bytes"
 	"context"
+	"crypto"
 	"encoding/base64"
 	"encoding/csv"
 	"encoding/json"
@@ -13,21 +14,33 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 
 	"github.com/containerd/console"
+	"github.com/containerd/containerd/platforms"
+	"github.com/depot/cli/pkg/artifact"
+	"github.com/depot/cli/pkg/attestation"
 	depotbuild "github.com/depot/cli/pkg/build"
 	depotbuildxbuild "github.com/depot/cli/pkg/buildx/build"
 	"github.com/depot/cli/pkg/buildx/builder"
+	depotbuildflags "github.com/depot/cli/pkg/buildx/buildflags"
+	"github.com/depot/cli/pkg/buildx/imagetools"
+	"github.com/depot/cli/pkg/buildxdriver"
 	"github.com/depot/cli/pkg/ci"
 	"github.com/depot/cli/pkg/cmd/docker"
+	depotconfig "github.com/depot/cli/pkg/config"
 	"github.com/depot/cli/pkg/debuglog"
 	"github.com/depot/cli/pkg/dockerclient"
 	"github.com/depot/cli/pkg/helpers"
 	"github.com/depot/cli/pkg/load"
+	"github.com/depot/cli/pkg/machine"
+	"github.com/depot/cli/pkg/notify"
+	"github.com/depot/cli/pkg/profile"
 	"github.com/depot/cli/pkg/progresshelper"
 	"github.com/depot/cli/pkg/registry"
 	"github.com/depot/cli/pkg/sbom"
@@ -51,34 +64,42 @@ import (
 	"github.com/docker/go-units"
 	"github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/session/auth/authprovider"
 	"github.com/moby/buildkit/solver/errdefs"
 	"github.com/moby/buildkit/util/appcontext"
 	"github.com/moby/buildkit/util/grpcerrors"
 	"github.com/morikuni/aec"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 	"google.golang.org/grpc/codes"
 )
 
 const defaultTargetName = "default"
 
 type buildOptions struct {
-	contextPath    string
-	dockerfileName string
-	printFunc      string
+	contextPath     string
+	dockerfileName  string
+	dockerfileNames []string
+	printFunc       string
 
 	allow         []string
 	attests       []string
+	autoTagKinds  []string
 	buildArgs     []string
+	buildArgsFile string
 	cacheFrom     []string
 	cacheTo       []string
 	cgroupParent  string
 	contexts      []string
 	extraHosts    []string
+	frontend      string
+	frontendOpts  []string
 	imageIDFile   string
 	invoke        string
 	labels        []string
@@ -87,12 +108,18 @@ type buildOptions struct {
 	outputs       []string
 	platforms     []string
 	quiet         bool
+	// quietFormat is the Go template of each image printed by --quiet.
+	quietFormat   string
+	quietTemplate *template.Template
 	secrets       []string
 	shmSize       dockeropts.MemBytes
 	ssh           []string
 	tags          []string
 	target        string
 	ulimits       *dockeropts.UlimitOpt
+	localBuildkit string
+	// noBuild only runs the lint phase of the build; see runLintOnly.
+	noBuild bool
 	commonOptions
 	DepotOptions
 }
@@ -118,15 +145,90 @@ type DepotOptions struct {
 	buildURL      string
 	buildPlatform string
 	build         *depotbuild.Build
+	// region and near are placement hints of the build machines.
+	region string
+	near   []string
 
 	save                  bool
 	additionalTags        []string
 	additionalCredentials []depotbuild.Credential
+	// registryAuth are the sources of registry credentials used instead of
+	// the Docker config, loaded into registryCredentials.
+	registryAuth        []string
+	registryAuthFiles   []string
+	registryCredentials []depotbuild.Credential
+	// pushTo are the "[target=]name"s that the image is pushed to, either
+	// by the builder or copied from the first pushed name after the build.
+	pushTo []string
+	// uploadConcurrency and uploadAttempts limit the pushes of the tags of
+	// a --save build, as for "depot push".
+	uploadConcurrency int
+	uploadAttempts    int
 
 	lint       bool
 	lintFailOn string
-
-	sbomDir string
+	policyFile string
+	// warningsFile suppresses warnings and lint issues; see WarningSuppressions.
+	warningsFile string
+	warnings     *WarningSuppressions
+
+	printSecretsUsage bool
+	// envPassthrough are host environment variables exposed as secrets.
+	envPassthrough []string
+
+	checkBaseImages bool
+	failOnStaleBase bool
+	maxBaseImageAge string
+	// locked pins base images and image contexts to the digests of the lockfile.
+	locked   bool
+	lockfile string
+
+	// runMemory and runCPUShares cap the containers of RUN steps.
+	runMemory    dockeropts.MemBytes
+	runCPUShares int64
+
+	// checkBuildArgs reports the build args the Dockerfile does not declare.
+	checkBuildArgs bool
+	// strictBuildArgs fails the build on them, implying checkBuildArgs.
+	strictBuildArgs bool
+	// recordDefinitions stores the Dockerfiles and bake files with the build.
+	recordDefinitions bool
+	// includeGitDir sends the .git directory of the context even if the
+	// Dockerfile does not appear to use it.
+	includeGitDir bool
+	// cacheMountPolicy is the sharing mode of cache mounts that set none.
+	cacheMountPolicy string
+	// materializeDockerignore applies the .dockerignore of local named
+	// contexts on the client, and contextIgnoreFiles the "name=path" ignore
+	// files given for them, parsed into contextIgnores.
+	materializeDockerignore bool
+	contextIgnoreFiles      []string
+	contextIgnores          *depotbuildxbuild.ContextIgnores
+
+	budget BuildBudget
+	// optimizeHints prints hints to cache more of the build.
+	optimizeHints bool
+
+	sbomDir       string
+	sbomGenerator string
+
+	// attestationBundle is the in-toto bundle of the provenance and SBOMs.
+	attestationBundle string
+	attestationKey    string
+	attestationSigner crypto.Signer
+	rekorUpload       bool
+	rekorURL          string
+
+	loadPlatform string
+	loadCluster  string
+
+	frontend *depotbuildxbuild.Frontend
+	// autoTags are the tags computed by --auto-tag.
+	autoTags []string
+
+	notifyWebhook string
+	notifyExec    string
+	notification  *notify.Notification
 
 	allowNoOutput  bool
 	builderOptions []builder.Option
@@ -163,7 +265,7 @@ func runBuild(dockerCli command.Cli, validatedOpts map[string]build.Options, in
 		return err
 	}
 
-	imageIDs, res, err := buildTargets(ctx, dockerCli, nodes, validatedOpts, in.DepotOptions, in.progress, in.metadataFile, in.exportLoad, in.invoke != "")
+	images, res, err := buildTargets(ctx, dockerCli, nodes, validatedOpts, in.DepotOptions, in.progress, in.metadataFile, in.exportLoad, in.invoke != "")
 	err = wrapBuildError(err, false)
 	if err != nil {
 		return err
@@ -190,9 +292,7 @@ func runBuild(dockerCli command.Cli, validatedOpts map[string]build.Options, in
 	}
 
 	if in.quiet {
-		for _, imageID := range imageIDs {
-			fmt.Println(imageID)
-		}
+		return printQuiet(os.Stdout, in.quietTemplate, images)
 	}
 	return nil
 }
@@ -203,7 +303,7 @@ type nopCloser struct {
 
 func (c nopCloser) Close() error { return nil }
 
-func buildTargets(ctx context.Context, dockerCli command.Cli, nodes []builder.Node, opts map[string]build.Options, depotOpts DepotOptions, progressMode, metadataFile string, exportLoad, allowNoOutput bool) (imageIDs []string, res *build.ResultContext, err error) {
+func buildTargets(ctx context.Context, dockerCli command.Cli, nodes []builder.Node, opts map[string]build.Options, depotOpts DepotOptions, progressMode, metadataFile string, exportLoad, allowNoOutput bool) (images []builtImage, res *build.ResultContext, err error) {
 	ctx2, cancel := context.WithCancel(context.TODO())
 
 	printer, err := progress.NewPrinter(ctx2, os.Stderr, os.Stderr, progressMode)
@@ -213,8 +313,16 @@ func buildTargets(ctx context.Context, dockerCli command.Cli, nodes []builder.No
 	}
 	defer cancel()
 
-	if os.Getenv("DEPOT_NO_SUMMARY_LINK") == "" {
-		progress.Write(printer, "[depot] build: "+depotOpts.buildURL, func() error { return err })
+	progresshelper.LogBuildURL(printer, depotOpts.buildURL)
+
+	if err := expandLabelTemplates(ctx, opts, depotOpts.buildID, depotOpts.project); err != nil {
+		_ = printer.Wait()
+		return nil, nil, err
+	}
+	destinations, err := takePushTo(opts, depotOpts.pushTo, depotOpts.save)
+	if err != nil {
+		_ = printer.Wait()
+		return nil, nil, err
 	}
 
 	var (
@@ -231,10 +339,14 @@ func buildTargets(ctx context.Context, dockerCli command.Cli, nodes []builder.No
 				BuildID:      depotOpts.buildID,
 				IsBake:       false,
 				ProgressMode: progressMode,
+				Platform:     depotOpts.loadPlatform,
 			},
 		)
 	}
+	opts = registry.WithCredentials(opts, depotOpts.registryCredentials)
+	var pushTags map[string][]string
 	if depotOpts.save {
+		pushTags = registry.TakePushTags(opts)
 		saveOpts := registry.SaveOptions{
 			ProjectID:             depotOpts.project,
 			BuildID:               depotOpts.buildID,
@@ -244,6 +356,12 @@ func buildTargets(ctx context.Context, dockerCli command.Cli, nodes []builder.No
 		opts = registry.WithDepotSave(opts, saveOpts)
 	}
 
+	artifactOutputs, err := artifact.ExtractOutputs(opts)
+	if err != nil {
+		_ = printer.Wait()
+		return nil, nil, err
+	}
+
 	buildxNodes := builder.ToBuildxNodes(nodes)
 	buildxNodes, err = depotbuildxbuild.FilterAvailableNodes(buildxNodes)
 	if err != nil {
@@ -269,15 +387,32 @@ func buildTargets(ctx context.Context, dockerCli command.Cli, nodes []builder.No
 	dockerClient := dockerutil.NewClient(dockerCli)
 	dockerConfigDir := confutil.ConfigDir(dockerCli)
 
+	if err := pinSBOMGenerators(ctx, imagetools.New(imagetools.Opt{Auth: dockerCli.ConfigFile()}), opts, depotOpts.sbomGenerator, depotconfig.GetSBOMGeneratorDigests(), printer); err != nil {
+		_ = printer.Wait()
+		return nil, nil, err
+	}
+
 	linter := NewLinter(printer, NewLintFailureMode(depotOpts.lint, depotOpts.lintFailOn), clients, buildxNodes)
+	linter.suppressions = depotOpts.warnings
+	policy := NewPolicy(printer, depotOpts.policyFile, opts, linter)
+	buildArgChecker := NewBuildArgChecker(printer, opts, depotOpts)
+	definitions := NewDefinitionRecorder(depotOpts)
+	baseImages, err := NewBaseImageChecker(printer, imagetools.New(imagetools.Opt{Auth: dockerCli.ConfigFile()}), opts, depotOpts)
+	if err != nil {
+		_ = printer.Wait()
+		return nil, nil, err
+	}
 
-	resp, err := depotbuildxbuild.DepotBuildWithResultHandler(ctx, buildxNodes, opts, dockerClient, dockerConfigDir, printer, linter, func(driverIndex int, gotRes *build.ResultContext) {
+	transfers := progresshelper.NewTransferMonitor(progresshelper.TrackSteps(printer, depotOpts.buildID), progressMode)
+	resp, err := depotbuildxbuild.DepotBuildWithResultHandler(ctx, buildxNodes, opts, dockerClient, dockerConfigDir, transfers, depotbuildxbuild.NewDockerfileHandlers(linter, baseImages, buildArgChecker, policy, definitions), func(driverIndex int, gotRes *build.ResultContext) {
 		mu.Lock()
 		defer mu.Unlock()
 		if res == nil || driverIndex < idx {
 			idx, res = driverIndex, gotRes
 		}
-	}, allowNoOutput, depotOpts.build)
+	}, allowNoOutput, depotOpts.build, depotOpts.frontend, depotOpts.runLimits(), depotOpts.includeGitDir, depotOpts.cacheMountPolicy, depotOpts.contextIgnores)
+	transfers.Stop()
+	definitions.Report(ctx, depotOpts.token, depotOpts.buildID, nil)
 
 	if err != nil {
 		// Make sure that the printer has completed before returning failed builds.
@@ -287,10 +422,58 @@ func buildTargets(ctx context.Context, dockerCli command.Cli, nodes []builder.No
 		if errors.Is(err, LintFailed) {
 			linter.Print(os.Stderr, progressMode)
 		}
+		if errors.Is(err, PolicyFailed) {
+			policy.Print(os.Stderr, progressMode)
+		}
+		if errors.Is(err, StaleBaseImages) {
+			baseImages.Print(os.Stderr, progressMode)
+		}
+		if errors.Is(err, UnusedBuildArgs) {
+			buildArgChecker.Print(os.Stderr, progressMode)
+		}
+		if depotOpts.printSecretsUsage {
+			printSecretsUsage(os.Stderr, progressMode, opts)
+		}
 		return nil, nil, err
 	}
 
-	if metadataFile != "" && resp != nil {
+	var rekorEntries []attestation.LogEntry
+	if depotOpts.attestationBundle != "" {
+		rekorEntries, err = writeAttestationBundle(ctx, imagetools.New(imagetools.Opt{Auth: dockerCli.ConfigFile()}), opts, resp, depotOpts)
+		if err != nil {
+			_ = printer.Wait()
+			return nil, nil, err
+		}
+	}
+
+	if metadataFile != "" && len(resp) > 1 {
+		// Builds of multiple Dockerfiles use the bake format keyed by target.
+		dt := map[string]interface{}{}
+		targets := make([]string, 0, len(resp))
+		for _, buildRes := range resp {
+			metadata := map[string]interface{}{}
+			for _, nodeRes := range buildRes.NodeResponses {
+				for k, v := range decodeExporterResponse(nodeRes.SolveResponse.ExporterResponse) {
+					metadata[k] = v
+				}
+			}
+			withSavedTags(metadata, append(slices.Clone(pushTags[buildRes.Name]), destinations[buildRes.Name]...))
+			dt[buildRes.Name] = metadata
+			targets = append(targets, buildRes.Name)
+		}
+		if machines := buildMachines(resp); len(machines) > 0 {
+			dt["depot.machines"] = machines
+		}
+		if len(depotOpts.autoTags) > 0 {
+			dt["depot.auto-tags"] = depotOpts.autoTags
+		}
+		if len(rekorEntries) > 0 {
+			dt["depot.rekor"] = rekorEntries
+		}
+		if err := writeMetadataFile(metadataFile, depotOpts.project, depotOpts.buildID, targets, nil, dt); err != nil {
+			return nil, nil, err
+		}
+	} else if metadataFile != "" && resp != nil {
 		// DEPOT: Apparently, the build metadata file is a different format than the bake one.
 		for _, buildRes := range resp {
 			metadata := map[string]interface{}{}
@@ -300,8 +483,18 @@ func buildTargets(ctx context.Context, dockerCli command.Cli, nodes []builder.No
 					metadata[k] = v
 				}
 			}
+			withSavedTags(metadata, append(slices.Clone(pushTags[buildRes.Name]), destinations[buildRes.Name]...))
+			if machines := buildMachines(resp); len(machines) > 0 {
+				metadata["depot.machines"] = machines
+			}
+			if len(depotOpts.autoTags) > 0 {
+				metadata["depot.auto-tags"] = depotOpts.autoTags
+			}
+			if len(rekorEntries) > 0 {
+				metadata["depot.rekor"] = rekorEntries
+			}
 
-			if err := writeMetadataFile(metadataFile, depotOpts.project, depotOpts.buildID, nil, metadata); err != nil {
+			if err := writeMetadataFile(metadataFile, depotOpts.project, depotOpts.buildID, nil, nil, metadata); err != nil {
 				return nil, nil, err
 			}
 		}
@@ -309,8 +502,9 @@ func buildTargets(ctx context.Context, dockerCli command.Cli, nodes []builder.No
 
 	for _, buildRes := range resp {
 		for _, nodeRes := range buildRes.NodeResponses {
-			digest := nodeRes.SolveResponse.ExporterResponse[exptypes.ExporterImageDigestKey]
-			imageIDs = append(imageIDs, digest)
+			image := newBuiltImage(buildRes.Name, nodeRes.SolveResponse.ExporterResponse)
+			images = append(images, image)
+			depotOpts.notification.AddDigests(image.Digest)
 		}
 	}
 
@@ -321,8 +515,24 @@ func buildTargets(ctx context.Context, dockerCli command.Cli, nodes []builder.No
 		}
 	}
 
+	if len(artifactOutputs) > 0 {
+		err := artifact.Push(ctx, imagetools.Opt{Auth: dockerCli.ConfigFile()}, resp, artifactOutputs, printer)
+		if err != nil {
+			_ = printer.Wait()
+			return nil, nil, err
+		}
+	}
+
+	if len(destinations) > 0 {
+		err := copyToDestinations(ctx, imagetools.New(imagetools.Opt{Auth: dockerCli.ConfigFile()}), printer, pushedImages(resp), destinations)
+		if err != nil {
+			_ = printer.Wait()
+			return nil, nil, err
+		}
+	}
+
 	// NOTE: the err is returned at the end of this function after the final prints.
-	reportingPrinter := progresshelper.NewReporter(ctx, printer, depotOpts.buildID, depotOpts.token)
+	reportingPrinter := progresshelper.NewReporter(ctx, printer, depotOpts.buildID, depotOpts.token, progresshelper.WithProgressSampling(time.Second))
 	err = load.DepotFastLoad(ctx, dockerCli.Client(), resp, pullOpts, reportingPrinter)
 	if err != nil && !errors.Is(err, context.Canceled) {
 		// For now, we will fallback by rebuilding with load.
@@ -339,10 +549,13 @@ func buildTargets(ctx context.Context, dockerCli command.Cli, nodes []builder.No
 			if retryable {
 				progress.Write(reportingPrinter, "[load] fast load failed; retrying", func() error { return err })
 				opts = load.WithDockerLoad(fallbackOpts)
-				_, err = depotbuildxbuild.DepotBuildWithResultHandler(ctx, buildxNodes, opts, dockerClient, dockerConfigDir, printer, nil, nil, allowNoOutput, depotOpts.build)
+				_, err = depotbuildxbuild.DepotBuildWithResultHandler(ctx, buildxNodes, opts, dockerClient, dockerConfigDir, printer, nil, nil, allowNoOutput, depotOpts.build, depotOpts.frontend, depotOpts.runLimits(), depotOpts.includeGitDir, depotOpts.cacheMountPolicy, depotOpts.contextIgnores)
 			}
 		}
 	}
+	if err == nil {
+		err = loadIntoCluster(ctx, dockerCli, depotOpts.loadCluster, resp, pullOpts, reportingPrinter)
+	}
 	reportingPrinter.Close()
 
 	load.DeleteExportLeases(ctx, resp)
@@ -351,11 +564,33 @@ func buildTargets(ctx context.Context, dockerCli command.Cli, nodes []builder.No
 		return nil, nil, err
 	}
 
-	printWarnings(os.Stderr, printer.Warnings(), progressMode)
+	warnings, suppressed := depotOpts.warnings.Filter(printer.Warnings())
+	printWarnings(os.Stderr, warnings, progressMode)
 	if depotOpts.save {
 		printSaveHelp(depotOpts.project, depotOpts.buildID, progressMode, nil)
+		if err == nil {
+			if err := pushSavedTags(ctx, dockerCli, depotOpts, progressMode, pushTags, nil, false); err != nil {
+				return nil, nil, err
+			}
+		}
 	}
 	linter.Print(os.Stderr, progressMode)
+	printSuppressedWarnings(os.Stderr, progressMode, depotOpts.warnings, suppressed+linter.Suppressed())
+	baseImages.Print(os.Stderr, progressMode)
+	buildArgChecker.Print(os.Stderr, progressMode)
+	if depotOpts.printSecretsUsage {
+		printSecretsUsage(os.Stderr, progressMode, opts)
+	}
+	if depotOpts.optimizeHints {
+		printOptimizeHints(os.Stderr, progressMode, buildOptimizeHints(depotOpts.buildID))
+	}
+	// A failed load is reported as such rather than as a budget violation.
+	if err == nil {
+		if violations := checkBuildBudget(depotOpts.buildID, depotOpts.budget, depotOpts.warnings); len(violations) > 0 {
+			printBudgetViolations(os.Stderr, progressMode, violations)
+			return nil, nil, BudgetExceeded
+		}
+	}
 
 	for _, buildRes := range resp {
 		if opts[buildRes.Name].PrintFunc != nil {
@@ -367,7 +602,7 @@ func buildTargets(ctx context.Context, dockerCli command.Cli, nodes []builder.No
 		}
 	}
 
-	return imageIDs, res, err
+	return images, res, err
 }
 
 func parseInvokeConfig(invoke string) (cfg build.ContainerConfig, err error) {
@@ -478,27 +713,56 @@ func validateBuildOptions(in *buildOptions) (map[string]build.Options, error) {
 		return nil, errors.Errorf("--no-cache and --no-cache-filter cannot currently be used together")
 	}
 
+	if in.quietFormat != "" {
+		var err error
+		if in.quietTemplate, err = parseQuietFormat(in.quietFormat); err != nil {
+			return nil, err
+		}
+		in.quiet = true
+	}
 	if in.quiet && in.progress != progress.PrinterModeAuto && in.progress != progress.PrinterModeQuiet {
 		return nil, errors.Errorf("progress=%s and quiet cannot be used together", in.progress)
 	} else if in.quiet {
 		in.progress = "quiet"
 	}
 
-	_, isCI := ci.Provider()
-	if in.progress == progress.PrinterModeAuto && isCI {
-		in.progress = progress.PrinterModePlain
-	}
+	in.progress = progresshelper.ResolveMode(in.progress)
 
 	contexts, err := parseContextNames(in.contexts)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := applyPushTo(in); err != nil {
+		return nil, err
+	}
+
 	printFunc, err := parsePrintFunc(in.printFunc)
 	if err != nil {
 		return nil, err
 	}
 
+	in.DepotOptions.frontend, err = parseFrontend(in.frontend, in.frontendOpts)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateFrontend(in.DepotOptions.frontend, printFunc != nil, &in.DepotOptions); err != nil {
+		return nil, err
+	}
+
+	var fileArgs map[string]string
+	if in.buildArgsFile != "" {
+		fileArgs, err = readBuildArgsFile(in.buildArgsFile)
+		if err != nil {
+			return nil, err
+		}
+		in.secrets = append(in.secrets, buildArgsFileSecret(in.buildArgsFile))
+	}
+
+	if err := depotbuildflags.CheckEnvPassthrough(in.envPassthrough, in.secrets, in.buildArgs); err != nil {
+		return nil, err
+	}
+
 	opts := build.Options{
 		Inputs: build.Inputs{
 			ContextPath:    in.contextPath,
@@ -506,7 +770,7 @@ func validateBuildOptions(in *buildOptions) (map[string]build.Options, error) {
 			InStream:       os.Stdin,
 			NamedContexts:  contexts,
 		},
-		BuildArgs:     listToMap(in.buildArgs, true),
+		BuildArgs:     mergeBuildArgs(listToMap(in.buildArgs, true), fileArgs),
 		ExtraHosts:    in.extraHosts,
 		ImageIDFile:   in.imageIDFile,
 		Labels:        listToMap(in.labels, false),
@@ -527,24 +791,15 @@ func validateBuildOptions(in *buildOptions) (map[string]build.Options, error) {
 	}
 	opts.Platforms = platforms
 
-	dockerConfig := config.LoadDefaultConfigFile(os.Stderr)
-	opts.Session = append(opts.Session, authprovider.NewDockerAuthProvider(dockerConfig))
-
-	secrets, err := buildflags.ParseSecretSpecs(in.secrets)
+	in.loadPlatform, err = validateLoadPlatform(in.loadPlatform, in.exportLoad, platforms)
 	if err != nil {
 		return nil, err
 	}
-	opts.Session = append(opts.Session, secrets)
 
-	sshSpecs := in.ssh
-	if len(sshSpecs) == 0 && buildflags.IsGitSSH(in.contextPath) {
-		sshSpecs = []string{"default"}
-	}
-	ssh, err := buildflags.ParseSSHSpecs(sshSpecs)
+	opts.Session, err = buildSession(in)
 	if err != nil {
 		return nil, err
 	}
-	opts.Session = append(opts.Session, ssh)
 
 	outputs, err := buildflags.ParseOutputs(in.outputs)
 	if err != nil {
@@ -572,6 +827,55 @@ func validateBuildOptions(in *buildOptions) (map[string]build.Options, error) {
 
 	opts.Exports = outputs
 
+	var dockerfileTargets []dockerfileTarget
+	if len(in.dockerfileNames) > 1 {
+		if in.imageIDFile != "" {
+			return nil, errors.Errorf("--iidfile cannot be used with multiple Dockerfiles")
+		}
+		if len(exportNames(outputs)) > 0 {
+			return nil, errors.Errorf("output image names cannot be used with multiple Dockerfiles, use --tag <target>=<name> instead")
+		}
+		dockerfileTargets, err = parseDockerfileTargets(in.dockerfileNames, in.tags)
+		if err != nil {
+			return nil, err
+		}
+		opts.Tags = nil
+		for _, target := range dockerfileTargets {
+			opts.Tags = append(opts.Tags, target.tags...)
+		}
+	}
+
+	if err := validateAutoTags(in.autoTagKinds, in.tags); err != nil {
+		return nil, err
+	}
+	if len(in.autoTagKinds) > 0 {
+		vars := readAutoTagVars(context.Background(), in.contextPath)
+		in.DepotOptions.autoTags = nil
+		if len(dockerfileTargets) > 0 {
+			opts.Tags = nil
+			for i, target := range dockerfileTargets {
+				tags, err := autoTags(in.autoTagKinds, target.tags, vars)
+				if err != nil {
+					return nil, err
+				}
+				dockerfileTargets[i].tags = append(target.tags, tags...)
+				opts.Tags = append(opts.Tags, dockerfileTargets[i].tags...)
+				in.DepotOptions.autoTags = append(in.DepotOptions.autoTags, tags...)
+			}
+		} else {
+			tags, err := autoTags(in.autoTagKinds, opts.Tags, vars)
+			if err != nil {
+				return nil, err
+			}
+			opts.Tags = append(opts.Tags, tags...)
+			in.DepotOptions.autoTags = tags
+		}
+	}
+
+	if err := validateTags(append(append([]string{}, opts.Tags...), exportNames(outputs)...)); err != nil {
+		return nil, err
+	}
+
 	inAttests := append([]string{}, in.attests...)
 	if in.provenance != "" {
 		inAttests = append(inAttests, buildflags.CanonicalizeAttest("provenance", in.provenance))
@@ -602,7 +906,196 @@ func validateBuildOptions(in *buildOptions) (map[string]build.Options, error) {
 	}
 	opts.Allow = allow
 
-	return map[string]build.Options{defaultTargetName: opts}, nil
+	validatedOpts := map[string]build.Options{defaultTargetName: opts}
+	if len(dockerfileTargets) > 0 {
+		validatedOpts, err = splitDockerfileTargets(in, opts, dockerfileTargets)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := inlineStdinDockerfile(validatedOpts, os.Stdin); err != nil {
+		return nil, err
+	}
+
+	gateway := in.DepotOptions.frontend != nil && in.DepotOptions.frontend.Name == depotbuildxbuild.GatewayFrontend
+	if err := checkFrontendAttrsSize(validatedOpts, gateway); err != nil {
+		return nil, errors.Errorf("%s; read bulk values in RUN steps from a --secret file instead", err)
+	}
+	return validatedOpts, nil
+}
+
+// validateLoadCluster checks the --load-cluster flag, which implies --load.
+func validateLoadCluster(o *DepotOptions, exportLoad *bool) error {
+	if o.loadCluster == "" {
+		return nil
+	}
+	if _, err := load.ParseCluster(o.loadCluster); err != nil {
+		return err
+	}
+	*exportLoad = true
+	return nil
+}
+
+// runLimits returns the limits of the RUN steps, nil if they are unlimited.
+func (o *DepotOptions) runLimits() *depotbuildxbuild.RunLimits {
+	if o.runMemory == 0 && o.runCPUShares == 0 {
+		return nil
+	}
+	return &depotbuildxbuild.RunLimits{Memory: o.runMemory.Value(), CPUShares: o.runCPUShares}
+}
+
+// validateBaseImageAge checks the --max-base-image-age flag when base images are checked.
+func validateBaseImageAge(o *DepotOptions) error {
+	if !o.checkBaseImages && !o.failOnStaleBase {
+		return nil
+	}
+	if _, err := helpers.ParseDuration(o.maxBaseImageAge); err != nil {
+		return errors.Wrap(err, "invalid --max-base-image-age")
+	}
+	return nil
+}
+
+// loadWarningsFile reads the suppressed warnings of --warnings-file or the
+// repository's .depot/warnings.yaml.
+func loadWarningsFile(o *DepotOptions) (err error) {
+	o.warnings, err = loadWarningSuppressions(o.warningsFile, time.Now())
+	return err
+}
+
+// loadRegistryAuth reads the registry credentials of --registry-auth-file and
+// --registry-auth, the later taking precedence for the same registry.
+func loadRegistryAuth(o *DepotOptions) (err error) {
+	sources := make([]string, 0, len(o.registryAuthFiles)+len(o.registryAuth))
+	for _, file := range o.registryAuthFiles {
+		sources = append(sources, "file:"+file)
+	}
+	sources = append(sources, o.registryAuth...)
+	o.registryCredentials, err = registry.LoadCredentials(sources)
+	return err
+}
+
+// loadContextIgnores parses --materialize-dockerignore and the ignore files of
+// --context-ignorefile, which must exist.
+func loadContextIgnores(o *DepotOptions) error {
+	if !o.materializeDockerignore && len(o.contextIgnoreFiles) == 0 {
+		return nil
+	}
+	ignores := &depotbuildxbuild.ContextIgnores{Dockerignore: o.materializeDockerignore, Files: map[string]string{}}
+	for _, value := range o.contextIgnoreFiles {
+		name, path, ok := strings.Cut(value, "=")
+		if !ok || name == "" || path == "" {
+			return errors.Errorf("invalid --context-ignorefile %s, expected name=path", value)
+		}
+		name, err := normalizeContextName(name)
+		if err != nil {
+			return err
+		}
+		if fi, err := os.Stat(path); err != nil {
+			return errors.Wrap(err, "invalid --context-ignorefile")
+		} else if fi.IsDir() {
+			return errors.Errorf("invalid --context-ignorefile %s: %s is a directory", value, path)
+		}
+		ignores.Files[name] = path
+	}
+	o.contextIgnores = ignores
+	return nil
+}
+
+// validateContextIgnoreNames checks that every --context-ignorefile names a
+// --build-context of the build.
+func validateContextIgnoreNames(ignores *depotbuildxbuild.ContextIgnores, contexts []string) error {
+	if ignores == nil || len(ignores.Files) == 0 {
+		return nil
+	}
+	named, err := parseContextNames(contexts)
+	if err != nil {
+		return err
+	}
+	var problems []string
+	for name := range ignores.Files {
+		if _, ok := named[name]; !ok {
+			problems = append(problems, fmt.Sprintf("%s is not a --build-context", name))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("invalid --context-ignorefile:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// loadIntoCluster copies the loaded images into the --load-cluster cluster.
+// The export leases of the builds must not be deleted yet.
+func loadIntoCluster(ctx context.Context, dockerCli command.Cli, loadCluster string, resp []depotbuildxbuild.DepotBuildResponse, pullOpts map[string]load.PullOptions, w progress.Writer) error {
+	if loadCluster == "" || len(pullOpts) == 0 {
+		return nil
+	}
+	cluster, err := load.ParseCluster(loadCluster)
+	if err != nil {
+		return err
+	}
+	return load.LoadIntoCluster(ctx, dockerCli.Client(), cluster, resp, pullOpts, w)
+}
+
+// validateLoadPlatform normalizes the --load-platform flag and checks that the
+// platform is one of the platforms being built.
+func validateLoadPlatform(loadPlatform string, exportLoad bool, buildPlatforms []specs.Platform) (string, error) {
+	if loadPlatform == "" {
+		return "", nil
+	}
+	if !exportLoad {
+		return "", errors.Errorf("--load-platform requires --load")
+	}
+
+	p, err := platforms.Parse(loadPlatform)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid load platform %q", loadPlatform)
+	}
+	p = platforms.Normalize(p)
+
+	if len(buildPlatforms) > 0 {
+		matcher := platforms.Only(p)
+		var found bool
+		for _, bp := range buildPlatforms {
+			if matcher.Match(bp) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", errors.Errorf("load platform %s is not one of the build platforms", platforms.Format(p))
+		}
+	}
+
+	return platforms.Format(p), nil
+}
+
+// buildSession returns the session attachables providing registry auth,
+// secrets, and SSH agents to a build.
+func buildSession(in *buildOptions) ([]session.Attachable, error) {
+	dockerConfig := config.LoadDefaultConfigFile(os.Stderr)
+	attachables := []session.Attachable{authprovider.NewDockerAuthProvider(dockerConfig)}
+
+	envSecrets, err := depotbuildflags.ParseEnvPassthrough(in.envPassthrough)
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := depotbuildflags.ParseSecretSpecs(append(append([]string{}, in.secrets...), envSecrets...), true)
+	if err != nil {
+		return nil, err
+	}
+	attachables = append(attachables, secrets)
+
+	sshSpecs := in.ssh
+	if len(sshSpecs) == 0 && buildflags.IsGitSSH(in.contextPath) {
+		sshSpecs = []string{"default"}
+	}
+	ssh, err := depotbuildflags.ParseSSHSpecs(sshSpecs)
+	if err != nil {
+		return nil, err
+	}
+	return append(attachables, ssh), nil
 }
 
 func BuildCmd() *cobra.Command {
@@ -620,7 +1113,19 @@ func BuildCmd() *cobra.Command {
 			}
 
 			options.contextPath = args[0]
-			cmd.Flags().VisitAll(checkWarnedFlags)
+			if len(options.dockerfileNames) > 0 {
+				options.dockerfileName = options.dockerfileNames[0]
+			}
+
+			if options.noBuild {
+				return runLintOnly(cmd.Context(), &options)
+			}
+			if options.localBuildkit != "" {
+				if err := expandLocalPlatforms(cmd.Context(), &options); err != nil {
+					return err
+				}
+				return runLocalBuild(cmd, dockerCli, options)
+			}
 
 			token, err := helpers.ResolveToken(context.Background(), options.token)
 			if err != nil {
@@ -632,6 +1137,45 @@ func BuildCmd() *cobra.Command {
 			}
 
 			options.project = helpers.ResolveProjectID(options.project, options.contextPath, options.dockerfileName)
+			if err := applyProfile(cmd.Context(), cmd.Flags(), token, options.project); err != nil {
+				return err
+			}
+			options.platforms, err = helpers.ExpandProjectPlatforms(cmd.Context(), token, options.project, options.platforms)
+			if err != nil {
+				return err
+			}
+
+			if err := validateLoadCluster(&options.DepotOptions, &options.exportLoad); err != nil {
+				return err
+			}
+			if err := validateBaseImageAge(&options.DepotOptions); err != nil {
+				return err
+			}
+			if err := validatePolicyFile(options.policyFile); err != nil {
+				return err
+			}
+			if err := options.runLimits().Validate(); err != nil {
+				return err
+			}
+			if err := depotbuildxbuild.ValidateCacheMountPolicy(options.cacheMountPolicy); err != nil {
+				return err
+			}
+			if err := validateAttestationBundle(&options.DepotOptions); err != nil {
+				return err
+			}
+			if err := loadWarningsFile(&options.DepotOptions); err != nil {
+				return err
+			}
+			if err := loadRegistryAuth(&options.DepotOptions); err != nil {
+				return err
+			}
+			if err := loadContextIgnores(&options.DepotOptions); err != nil {
+				return err
+			}
+			if err := validateContextIgnoreNames(options.contextIgnores, options.contexts); err != nil {
+				return err
+			}
+			cmd.Flags().VisitAll(checkWarnedFlags)
 
 			buildPlatform, err := helpers.ResolveBuildPlatform(options.buildPlatform)
 			if err != nil {
@@ -642,6 +1186,11 @@ func BuildCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if options.locked {
+				if err := applyLockfile(validatedOpts, options.lockfile); err != nil {
+					return err
+				}
+			}
 
 			req := helpers.NewBuildRequest(
 				options.project,
@@ -666,14 +1215,24 @@ func BuildCmd() *cobra.Command {
 				_ = docker.UpdateDrivers(ctxDriverUpdate, dockerCli)
 			}()
 
+			options.notification = notify.New(options.notifyOptions(), build.ID, options.project, build.BuildURL)
+
 			var buildErr error
 			defer func() {
 				driverUpdateCancel()
 				build.Finish(buildErr)
 				PrintBuildURL(build.BuildURL, options.progress)
+				options.notification.Send(buildErr)
 			}()
 
-			options.builderOptions = []builder.Option{builder.WithDepotOptions(buildPlatform, build)}
+			if buildErr = helpers.CheckEntitlements(build, validatedOpts); buildErr != nil {
+				return buildErr
+			}
+			if buildErr = helpers.ResolveDepotCacheImports(cmd.Context(), build, validatedOpts); buildErr != nil {
+				return buildErr
+			}
+
+			options.builderOptions = []builder.Option{builder.WithDepotOptions(buildPlatform, build), builder.WithPlacement(options.region, options.near)}
 			buildProject := build.BuildProject()
 			if buildProject != "" {
 				options.project = buildProject
@@ -712,6 +1271,8 @@ func BuildCmd() *cobra.Command {
 
 	flags.StringArrayVar(&options.buildArgs, "build-arg", []string{}, "Set build-time variables")
 
+	flags.StringVar(&options.buildArgsFile, "build-args-file", "", `File of "KEY=VALUE" build-time variables, also mounted as the "build-args" secret`)
+
 	flags.StringArrayVar(&options.cacheFrom, "cache-from", []string{}, `External cache sources (e.g., "user/app:cache", "type=local,src=path/to/dir")`)
 
 	flags.StringArrayVar(&options.cacheTo, "cache-to", []string{}, `Cache export destinations (e.g., "user/app:cache", "type=local,dest=path/to/dir")`)
@@ -721,30 +1282,45 @@ func BuildCmd() *cobra.Command {
 
 	flags.StringArrayVar(&options.contexts, "build-context", []string{}, "Additional build contexts (e.g., name=path)")
 
-	flags.StringVarP(&options.dockerfileName, "file", "f", "", `Name of the Dockerfile (default: "PATH/Dockerfile")`)
+	flags.StringArrayVarP(&options.dockerfileNames, "file", "f", []string{}, `Name of the Dockerfile (default: "PATH/Dockerfile"); repeat to build several Dockerfiles concurrently`)
 	_ = flags.SetAnnotation("file", annotation.ExternalURL, []string{"https://docs.docker.com/engine/reference/commandline/build/#file"})
 
+	flags.StringVar(&options.frontend, "frontend", "", `Frontend that reads the build definition ("dockerfile.v0", "gateway.v0") (default "dockerfile.v0")`)
+
+	flags.StringArrayVar(&options.frontendOpts, "opt", []string{}, `Frontend option (e.g., "source=docker/dockerfile:1", "build-arg:foo=bar")`)
+
 	flags.StringVar(&options.imageIDFile, "iidfile", "", "Write the image ID to the file")
 
 	flags.StringArrayVar(&options.labels, "label", []string{}, "Set metadata for an image")
 
 	flags.BoolVar(&options.exportLoad, "load", false, `Shorthand for "--output=type=docker"`)
 
+	flags.StringVar(&options.loadPlatform, "load-platform", "", `Platform of a multi-platform build to load with "--load" (default: host platform)`)
+
+	flags.StringVar(&options.localBuildkit, "local-buildkit", "", `Build on a local buildkitd instead of a Depot machine, in a privileged container without a value, or at --local-buildkit=ADDR (e.g., "tcp://localhost:1234")`)
+	flags.Lookup("local-buildkit").NoOptDefVal = "auto"
+
+	flags.StringVar(&options.loadCluster, "load-cluster", "", `Load the image into a local Kubernetes cluster (format: "kind|k3d|minikube[:name]"), implies "--load"`)
+
 	flags.StringVar(&options.networkMode, "network", "default", `Set the networking mode for the "RUN" instructions during build`)
 
 	flags.StringArrayVar(&options.noCacheFilter, "no-cache-filter", []string{}, "Do not cache specified stages")
 
+	flags.BoolVar(&options.noBuild, "no-build", false, `Only lint the Dockerfiles and print the issues as JSON, with "--lint"`)
+
 	flags.StringArrayVarP(&options.outputs, "output", "o", []string{}, `Output destination (format: "type=local,dest=path")`)
 
-	flags.StringArrayVar(&options.platforms, "platform", platformsDefault, "Set target platform for build")
+	flags.StringArrayVar(&options.platforms, "platform", platformsDefault, `Set target platform for build ("all" builds the platforms configured for the project)`)
 
 	if isExperimental() {
 		flags.StringVar(&options.printFunc, "print", "", "Print result of information request (e.g., outline, targets) [experimental]")
 	}
 
 	flags.BoolVar(&options.exportPush, "push", false, `Shorthand for "--output=type=registry"`)
+	flags.StringArrayVar(&options.pushTo, "push-to", nil, `Push the image to this name as well, copied concurrently after the build, can be repeated`)
 
 	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Suppress the build output and print image ID on success")
+	flags.StringVar(&options.quietFormat, "quiet-format", "", `Print each image with a Go template on success (e.g., "{{.Digest}} {{.Tags}}"), implies "--quiet"`)
 
 	flags.StringArrayVar(&options.secrets, "secret", []string{}, `Secret to expose to the build (format: "id=mysecret[,src=/local/secret]")`)
 
@@ -754,6 +1330,7 @@ func BuildCmd() *cobra.Command {
 
 	flags.StringArrayVarP(&options.tags, "tag", "t", []string{}, `Name and optionally a tag (format: "name:tag")`)
 	_ = flags.SetAnnotation("tag", annotation.ExternalURL, []string{"https://docs.docker.com/engine/reference/commandline/build/#tag"})
+	flags.StringSliceVar(&options.autoTagKinds, "auto-tag", nil, `Also tag the repositories of "--tag" from the repository state ("sha", "gitdescribe", "calver")`)
 
 	flags.StringVar(&options.target, "target", "", "Set the target build stage to build")
 	_ = flags.SetAnnotation("target", annotation.ExternalURL, []string{"https://docs.docker.com/engine/reference/commandline/build/#target"})
@@ -833,12 +1410,17 @@ func depotFlags(cmd *cobra.Command, options *DepotOptions, flags *pflag.FlagSet)
 	depotBuildFlags(options, flags)
 	depotLintFlags(cmd, options, flags)
 	depotAttestationFlags(cmd, options, flags)
+	depotNotifyFlags(options, flags)
+	depotBudgetFlags(options, flags)
+	depotSecretFlags(options, flags)
 }
 
 func depotBuildFlags(options *DepotOptions, flags *pflag.FlagSet) {
 	flags.StringVar(&options.project, "project", "", "Depot project ID")
 	flags.StringVar(&options.token, "token", "", "Depot token")
 	flags.StringVar(&options.buildPlatform, "build-platform", "dynamic", `Run builds on this platform ("dynamic", "linux/amd64", "linux/arm64")`)
+	flags.StringVar(&options.region, "region", os.Getenv("DEPOT_REGION"), `Run the build machines in this region if it has capacity (e.g., "us-east-1")`)
+	flags.StringArrayVar(&options.near, "near", nil, "Place the build machines close to this host, such as a registry or CI runner")
 
 	allowNoOutput := false
 	if v := os.Getenv("DEPOT_SUPPRESS_NO_OUTPUT_WARNING"); v != "" {
@@ -846,11 +1428,76 @@ func depotBuildFlags(options *DepotOptions, flags *pflag.FlagSet) {
 	}
 	flags.BoolVar(&options.allowNoOutput, "suppress-no-output-warning", allowNoOutput, "Suppress warning if no output is generated")
 	_ = flags.MarkHidden("suppress-no-output-warning")
+
+	flags.BoolVar(&options.printSecretsUsage, "print-secrets-usage", false, "Print which declared secrets and SSH agents the builder requested during the build")
+	flags.BoolVar(&options.locked, "locked", false, `Pin base images to the digests of the lockfile and fail if it is stale (see "depot lock update")`)
+	flags.StringVar(&options.lockfile, "lockfile", defaultLockfile, `Lockfile used by "--locked"`)
+	flags.Var(&options.runMemory, "run-memory", `Memory limit of each RUN step container (e.g., "4g")`)
+	flags.Int64Var(&options.runCPUShares, "run-cpu-shares", 0, "CPU shares (relative weight) of each RUN step container")
+	flags.BoolVar(&options.checkBuildArgs, "check-build-args", false, "Warn about build args that are not declared by the Dockerfile, parsed before the build")
+	flags.BoolVar(&options.strictBuildArgs, "strict-build-args", false, `Fail the build when a build arg is not declared by the Dockerfile, implies "--check-build-args"`)
+	flags.BoolVar(&options.recordDefinitions, "record-definitions", false, "Store the Dockerfiles and bake or compose files with the build")
+	flags.StringArrayVar(&options.registryAuth, "registry-auth", nil, `Registry credentials to use instead of the Docker config ("env:VAR" or "file:PATH")`)
+	flags.StringArrayVar(&options.registryAuthFiles, "registry-auth-file", nil, `Docker config or .dockerconfigjson file of registry credentials (same as "--registry-auth file:PATH")`)
+	flags.BoolVar(&options.includeGitDir, "include-git-dir", false, "Send the .git directory of the context even if the Dockerfile does not appear to use it")
+	flags.StringVar(&options.cacheMountPolicy, "cache-mount-policy", "", `Sharing mode of RUN cache mounts that do not set one ("shared", "private", "locked")`)
+	flags.BoolVar(&options.materializeDockerignore, "materialize-dockerignore", false, "Apply the .dockerignore of each local named context before sending it to the builder")
+	flags.StringArrayVar(&options.contextIgnoreFiles, "context-ignorefile", nil, `Ignore file of a named context to apply instead of its .dockerignore (format: "name=path")`)
+}
+
+func depotSecretFlags(options *DepotOptions, flags *pflag.FlagSet) {
+	flags.StringSliceVar(&options.envPassthrough, "env-passthrough", nil, "Expose these host environment variables to RUN steps as secrets of the same name")
+}
+
+func depotNotifyFlags(options *DepotOptions, flags *pflag.FlagSet) {
+	flags.StringVar(&options.notifyWebhook, "notify-webhook", "", "POST a JSON summary of the build to this URL when it finishes")
+	flags.StringVar(&options.notifyExec, "notify-exec", "", "Run this command with a JSON summary of the build on stdin when it finishes")
+}
+
+func depotBudgetFlags(options *DepotOptions, flags *pflag.FlagSet) {
+	flags.IntVar(&options.budget.MaxWarnings, "fail-on-warnings", -1, "Fail the build when it has more than this many warnings (default 0 when set without a value)")
+	flags.Lookup("fail-on-warnings").NoOptDefVal = "0"
+	flags.DurationVar(&options.budget.MaxDuration, "max-build-duration-budget", 0, `Fail the build when its steps take longer than this in total (e.g., "10m")`)
+	flags.DurationVar(&options.budget.MaxUncached, "max-uncached-duration-budget", 0, `Fail the build when its uncached steps take longer than this combined (e.g., "5m")`)
+	flags.BoolVar(&options.optimizeHints, "optimize-hints", false, `Print hints to cache more of the build, such as "COPY --link" or reordering steps`)
+}
+
+// applyProfile applies the organization profile to the flags of the command.
+// A profile that cannot be loaded is only a warning when none was ever cached
+// for the token and project; otherwise the organization has a profile, and
+// the build fails rather than run without it.
+func applyProfile(ctx context.Context, flags *pflag.FlagSet, token, project string) error {
+	p, err := profile.Load(ctx, token, project)
+	if err != nil {
+		if profile.HasCache(token, project) {
+			return errors.Wrap(err, "unable to load your organization's profile")
+		}
+		logrus.Warnf("unable to load your organization's profile, building without it: %v", err)
+		return nil
+	}
+	return profile.Apply(p, flags)
+}
+
+// notifyOptions falls back to the notification defaults of the depot config.
+func (o *DepotOptions) notifyOptions() notify.Options {
+	opts := notify.Options{Webhook: o.notifyWebhook, Exec: o.notifyExec}
+	if opts.Webhook == "" {
+		opts.Webhook = depotconfig.GetNotifyWebhook()
+	}
+	if opts.Exec == "" {
+		opts.Exec = depotconfig.GetNotifyExec()
+	}
+	return opts
 }
 
 func depotLintFlags(cmd *cobra.Command, options *DepotOptions, flags *pflag.FlagSet) {
 	flags.BoolVar(&options.lint, "lint", false, `Lint Dockerfiles`)
 	flags.StringVar(&options.lintFailOn, "lint-fail-on", "error", `controls lint severity that fails the build ("info", "warn", "error", "none")`)
+	flags.BoolVar(&options.checkBaseImages, "check-base-images", false, `Warn about base images that are outdated before the build`)
+	flags.BoolVar(&options.failOnStaleBase, "fail-on-stale-base", false, `Fail the build on outdated base images, implies "--check-base-images"`)
+	flags.StringVar(&options.maxBaseImageAge, "max-base-image-age", "90d", `Age after which a base image is outdated (e.g., "30d", "720h")`)
+	flags.StringVar(&options.warningsFile, "warnings-file", "", `File of warning codes and lint rules to suppress (default ".depot/warnings.yaml")`)
+	flags.StringVar(&options.policyFile, "policy-file", "", `Evaluate the build options and lint issues against a rego policy ("data.depot.deny") with the opa CLI before building`)
 	_ = cmd.RegisterFlagCompletionFunc("lint-fail-on", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return []string{
 			"error\tFail on errors [default]",
@@ -863,10 +1510,17 @@ func depotLintFlags(cmd *cobra.Command, options *DepotOptions, flags *pflag.Flag
 
 func depotAttestationFlags(_ *cobra.Command, options *DepotOptions, flags *pflag.FlagSet) {
 	flags.StringVar(&options.sbomDir, "sbom-dir", "", `directory to store SBOM attestations`)
+	flags.StringVar(&options.sbomGenerator, "sbom-generator", "", `SBOM generator image, pinned to its current digest (implies "--sbom=true")`)
+	flags.StringVar(&options.attestationBundle, "attestation-bundle", "", `Write the provenance and SBOM statements to an in-toto JSON Lines bundle`)
+	flags.StringVar(&options.attestationKey, "attestation-key", "", `PEM private key that signs the attestation bundle`)
+	flags.BoolVar(&options.rekorUpload, "rekor-upload", false, `Upload the signed attestations to a Rekor transparency log`)
+	flags.StringVar(&options.rekorURL, "rekor-url", attestation.DefaultRekorURL, `URL of the Rekor instance for "--rekor-upload"`)
 }
 
 func depotRegistryFlags(_ *cobra.Command, options *DepotOptions, flags *pflag.FlagSet) {
 	flags.BoolVar(&options.save, "save", false, `Saves the build to the depot registry`)
+	flags.IntVar(&options.uploadConcurrency, "max-concurrent-uploads", registry.DefaultConcurrency, `Maximum concurrent uploads to each registry of the tags of "--save --push"`)
+	flags.IntVar(&options.uploadAttempts, "max-attempts", registry.DefaultAttempts, `Maximum attempts of an upload of "--save --push" that is rate limited or fails with a server error`)
 }
 
 func checkWarnedFlags(f *pflag.Flag) {
@@ -911,16 +1565,25 @@ func parseContextNames(values []string) (map[string]build.NamedContext, error) {
 		if len(kv) != 2 {
 			return nil, errors.Errorf("invalid context value: %s, expected key=value", value)
 		}
-		named, err := reference.ParseNormalizedNamed(kv[0])
+		name, err := normalizeContextName(kv[0])
 		if err != nil {
-			return nil, errors.Wrapf(err, "invalid context name %s", kv[0])
+			return nil, err
 		}
-		name := strings.TrimSuffix(reference.FamiliarString(named), ":latest")
 		result[name] = build.NamedContext{Path: kv[1]}
 	}
 	return result, nil
 }
 
+// normalizeContextName returns the name of a named context as the frontend
+// refers to it, such as "alpine" for "docker.io/library/alpine:latest".
+func normalizeContextName(name string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid context name %s", name)
+	}
+	return strings.TrimSuffix(reference.FamiliarString(named), ":latest"), nil
+}
+
 func parsePrintFunc(str string) (*build.PrintFunc, error) {
 	if str == "" {
 		return nil, nil
@@ -949,15 +1612,21 @@ func parsePrintFunc(str string) (*build.PrintFunc, error) {
 	return f, nil
 }
 
-func writeMetadataFile(filename, projectID, buildID string, targets []string, metadata map[string]interface{}) error {
+func writeMetadataFile(filename, projectID, buildID string, targets []string, summary []TargetSummary, metadata map[string]interface{}) error {
 	depotBuild := struct {
-		BuildID   string   `json:"buildID"`
-		ProjectID string   `json:"projectID"`
-		Targets   []string `json:"targets,omitempty"`
+		BuildID   string          `json:"buildID"`
+		ProjectID string          `json:"projectID"`
+		Targets   []string        `json:"targets,omitempty"`
+		Summary   []TargetSummary `json:"summary,omitempty"`
+		CI        *ci.Context     `json:"ci,omitempty"`
 	}{
 		BuildID:   buildID,
 		ProjectID: projectID,
 		Targets:   targets,
+		Summary:   summary,
+	}
+	if ciContext, isCI := ci.ProviderContext(); isCI {
+		depotBuild.CI = ciContext
 	}
 
 	metadata["depot.build"] = depotBuild
@@ -968,6 +1637,30 @@ func writeMetadataFile(filename, projectID, buildID string, targets []string, me
 	return ioutils.AtomicWriteFile(filename, b, 0644)
 }
 
+// buildMachine is a machine of the build in the metadata file.
+type buildMachine struct {
+	Platform string `json:"platform"`
+	machine.Info
+}
+
+// buildMachines returns the machines that built the responses, once per platform.
+func buildMachines(resp []depotbuildxbuild.DepotBuildResponse) []buildMachine {
+	var machines []buildMachine
+	seen := map[string]bool{}
+	for _, buildRes := range resp {
+		for _, nodeRes := range buildRes.NodeResponses {
+			platform := nodeRes.Node.DriverOpts["platform"]
+			info := buildxdriver.MachineInfo(nodeRes.Node.DriverOpts)
+			if seen[platform] || info.MachineID == "" {
+				continue
+			}
+			seen[platform] = true
+			machines = append(machines, buildMachine{Platform: platform, Info: info})
+		}
+	}
+	return machines
+}
+
 func decodeExporterResponse(exporterResponse map[string]string) map[string]interface{} {
 	out := make(map[string]interface{})
 	for k, v := range exporterResponse {
@@ -1064,12 +1757,20 @@ func retryRetryableErrors(ctx context.Context, f func() error) error {
 			return err
 		}
 		retryCount++
-		fmt.Printf("\nReceived retryable BuildKit error, retrying: %v\n", err)
+		wait := 100 * time.Millisecond
+		if depotbuildxbuild.IsRegistryServerError(err) {
+			// The steps are cached, so the retry only pushes the blobs and
+			// manifests that the registry is missing.
+			fmt.Printf("\nRegistry push failed with a server error, retrying: %v\n", err)
+			wait = time.Duration(retryCount) * time.Second
+		} else {
+			fmt.Printf("\nReceived retryable BuildKit error, retrying: %v\n", err)
+		}
 		fmt.Println()
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-time.After(100 * time.Millisecond):
+		case <-time.After(wait):
 		}
 	}
 }
@@ -1087,6 +1788,10 @@ func shouldRetryError(err error) bool {
 		return true
 	}
 
+	if depotbuildxbuild.IsRegistryServerError(err) {
+		return true
+	}
+
 	return false
 }
 
@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/depot/cli/pkg/buildx/bake"
+	"github.com/docker/buildx/build"
+	"github.com/docker/go-units"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Gateway frontends receive each frontend attribute as an environment
+	// variable, so an attribute is limited by the kernel's limit on a single
+	// argument string, and all attributes together by the request size.
+	maxFrontendAttrSize  = 128 << 10
+	maxFrontendAttrsSize = 1 << 20
+
+	// buildArgsSecretID is the secret that --build-args-file mounts, so that
+	// RUN steps can read the args without declaring each one with ARG.
+	buildArgsSecretID = "build-args"
+)
+
+// checkFrontendAttrsSize fails before the build starts when the build args
+// and labels of a target are too large for a gateway frontend to receive.
+// The targets built by the Dockerfile frontend of the builder are not
+// limited.  gateway is whether --frontend selects the gateway frontend.
+func checkFrontendAttrsSize(opts map[string]build.Options, gateway bool) error {
+	names := make([]string, 0, len(opts))
+	for name := range opts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		opt := opts[name]
+		if !gateway && !usesFrontendImage(opt) {
+			continue
+		}
+		argsSize, err := frontendAttrsSize("build-arg", "build arg", name, opt.BuildArgs)
+		if err != nil {
+			return err
+		}
+		labelsSize, err := frontendAttrsSize("label", "label", name, opt.Labels)
+		if err != nil {
+			return err
+		}
+		if argsSize+labelsSize > maxFrontendAttrsSize {
+			return errors.Errorf("target %q has %d build args (%s) and %d labels (%s), more than the %s the frontend accepts in total",
+				name, len(opt.BuildArgs), units.BytesSize(float64(argsSize)), len(opt.Labels), units.BytesSize(float64(labelsSize)), units.BytesSize(maxFrontendAttrsSize))
+		}
+	}
+	return nil
+}
+
+// usesFrontendImage reports whether a target is built by a frontend image,
+// selected with the BUILDKIT_SYNTAX build arg or a syntax directive of the
+// Dockerfile.  A Dockerfile that cannot be read locally is not checked.
+func usesFrontendImage(opt build.Options) bool {
+	if opt.BuildArgs["BUILDKIT_SYNTAX"] != "" {
+		return true
+	}
+	dt, err := readDockerfile(opt)
+	if err != nil {
+		return false
+	}
+	_, _, _, ok := parser.DetectSyntax(dt)
+	return ok
+}
+
+// frontendAttrsSize returns the size of the attributes sent for values, and
+// fails if any one of them is too large.
+func frontendAttrsSize(prefix, kind, target string, values map[string]string) (int, error) {
+	size := 0
+	for k, v := range values {
+		n := len(prefix) + 1 + len(k) + len(v)
+		if n > maxFrontendAttrSize {
+			return 0, errors.Errorf("%s %s of target %q is %s, more than the %s the frontend accepts for one value",
+				kind, k, target, units.BytesSize(float64(n)), units.BytesSize(maxFrontendAttrSize))
+		}
+		size += n
+	}
+	return size, nil
+}
+
+// readBuildArgsFile reads the build args of a file of KEY=VALUE lines.  As
+// with --build-arg, a KEY line without a value takes the value of the
+// environment variable.  Blank lines and lines starting with # are ignored.
+func readBuildArgsFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read --build-args-file")
+	}
+	defer f.Close()
+
+	var args []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, maxFrontendAttrsSize)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		key, _, _ := strings.Cut(text, "=")
+		if key == "" || strings.ContainsAny(key, " \t") {
+			return nil, errors.Errorf("%s:%d: expected KEY=VALUE", path, line)
+		}
+		args = append(args, text)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to read %s", path)
+	}
+	return listToMap(args, true), nil
+}
+
+// mergeBuildArgs adds the args of the --build-args-file that are not set
+// otherwise, so that --build-arg and the args of bake targets take
+// precedence over the file.
+func mergeBuildArgs(args, fileArgs map[string]string) map[string]string {
+	if len(fileArgs) == 0 {
+		return args
+	}
+	merged := make(map[string]string, len(args)+len(fileArgs))
+	for k, v := range fileArgs {
+		merged[k] = v
+	}
+	for k, v := range args {
+		merged[k] = v
+	}
+	return merged
+}
+
+// applyBuildArgsFile adds the args of the --build-args-file to the bake
+// targets that do not set them, and mounts the file as a secret.
+func applyBuildArgsFile(tgts map[string]*bake.Target, path string) error {
+	if path == "" {
+		return nil
+	}
+	fileArgs, err := readBuildArgsFile(path)
+	if err != nil {
+		return err
+	}
+	for _, t := range tgts {
+		if t.Args == nil {
+			t.Args = map[string]*string{}
+		}
+		for k, v := range fileArgs {
+			if _, ok := t.Args[k]; !ok {
+				v := v
+				t.Args[k] = &v
+			}
+		}
+		t.Secrets = append(t.Secrets, buildArgsFileSecret(path))
+	}
+	return nil
+}
+
+// buildArgsFileSecret returns the secret that mounts the --build-args-file
+// at /run/secrets/build-args.
+func buildArgsFileSecret(path string) string {
+	return fmt.Sprintf("id=%s,src=%s", buildArgsSecretID, path)
+}
@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/depot/cli/pkg/buildx/bake"
+	"github.com/docker/buildx/build"
+	"golang.org/x/exp/slices"
+)
+
+func TestCheckFrontendAttrsSize(t *testing.T) {
+	args := map[string]string{}
+	for i := 0; i < 300; i++ {
+		args[fmt.Sprintf("ARG_%d", i)] = strings.Repeat("x", 4<<10)
+	}
+	err := checkFrontendAttrsSize(map[string]build.Options{"app": {BuildArgs: args}}, true)
+	if err == nil || !strings.Contains(err.Error(), `target "app" has 300 build args`) {
+		t.Errorf("expected the total size to be rejected, got %v", err)
+	}
+
+	err = checkFrontendAttrsSize(map[string]build.Options{"app": {Labels: map[string]string{"big": strings.Repeat("x", 200<<10)}}}, true)
+	if err == nil || !strings.Contains(err.Error(), "label big") {
+		t.Errorf("expected the label to be rejected, got %v", err)
+	}
+
+	if err := checkFrontendAttrsSize(map[string]build.Options{"app": {BuildArgs: map[string]string{"A": "1"}}}, true); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCheckFrontendAttrsSizeFrontendImage(t *testing.T) {
+	args := map[string]string{}
+	for i := 0; i < 300; i++ {
+		args[fmt.Sprintf("ARG_%d", i)] = strings.Repeat("x", 4<<10)
+	}
+	dir := t.TempDir()
+	builtin := filepath.Join(dir, "Dockerfile")
+	if err := os.WriteFile(builtin, []byte("FROM alpine\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	syntax := filepath.Join(dir, "syntax.Dockerfile")
+	if err := os.WriteFile(syntax, []byte("# syntax=docker/dockerfile:1\nFROM alpine\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		opt     build.Options
+		wantErr bool
+	}{
+		{name: "dockerfile frontend", opt: build.Options{Inputs: build.Inputs{ContextPath: dir, DockerfilePath: builtin}}},
+		{name: "syntax directive", opt: build.Options{Inputs: build.Inputs{ContextPath: dir, DockerfilePath: syntax}}, wantErr: true},
+		{name: "BUILDKIT_SYNTAX", opt: build.Options{Inputs: build.Inputs{ContextPath: dir, DockerfilePath: builtin}, BuildArgs: map[string]string{"BUILDKIT_SYNTAX": "docker/dockerfile:1"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opt := tt.opt
+			opt.BuildArgs = mergeBuildArgs(opt.BuildArgs, args)
+			err := checkFrontendAttrsSize(map[string]build.Options{"app": opt}, false)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkFrontendAttrsSize() = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadBuildArgsFile(t *testing.T) {
+	t.Setenv("FROM_ENV", "env")
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.env")
+	if err := os.WriteFile(valid, []byte("# comment\nA=1\n\nB=two=2\nFROM_ENV\nUNSET\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	args, err := readBuildArgsFile(valid)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"A": "1", "B": "two=2", "FROM_ENV": "env"}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("readBuildArgsFile() = %v, want %v", args, want)
+	}
+
+	invalid := filepath.Join(dir, "invalid.env")
+	if err := os.WriteFile(invalid, []byte("A=1\nB C=2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readBuildArgsFile(invalid); err == nil || !strings.Contains(err.Error(), ":2:") {
+		t.Errorf("expected line 2 to be rejected, got %v", err)
+	}
+}
+
+func TestMergeBuildArgs(t *testing.T) {
+	got := mergeBuildArgs(map[string]string{"A": "flag"}, map[string]string{"A": "file", "B": "file"})
+	want := map[string]string{"A": "flag", "B": "file"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeBuildArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestApplyBuildArgsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "args.env")
+	if err := os.WriteFile(path, []byte("A=file\nB=file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	target := "target"
+	tgts := map[string]*bake.Target{"app": {Args: map[string]*string{"A": &target}}}
+	if err := applyBuildArgsFile(tgts, path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	app := tgts["app"]
+	if *app.Args["A"] != "target" || *app.Args["B"] != "file" {
+		t.Errorf("expected the target args to take precedence over the file, got A=%s B=%s", *app.Args["A"], *app.Args["B"])
+	}
+	if !slices.Contains(app.Secrets, buildArgsFileSecret(path)) {
+		t.Errorf("expected the file to be mounted as a secret, got %v", app.Secrets)
+	}
+}
@@ -0,0 +1,290 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	depotbuild "github.com/depot/cli/pkg/buildx/build"
+	"github.com/depot/cli/pkg/progresshelper"
+	"github.com/distribution/reference"
+	"github.com/docker/buildx/build"
+	"github.com/docker/buildx/util/progress"
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/moby/buildkit/identity"
+	"github.com/morikuni/aec"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// UnusedBuildArgs is the error returned with --strict-build-args when a build
+// arg is not declared by the Dockerfile.
+var UnusedBuildArgs = errors.New("unused build args")
+
+// predefinedBuildArgs are accepted by the Dockerfile frontend without an ARG
+// instruction.
+var predefinedBuildArgs = map[string]struct{}{
+	"HTTP_PROXY": {}, "http_proxy": {},
+	"HTTPS_PROXY": {}, "https_proxy": {},
+	"FTP_PROXY": {}, "ftp_proxy": {},
+	"NO_PROXY": {}, "no_proxy": {},
+	"ALL_PROXY": {}, "all_proxy": {},
+	"SOURCE_DATE_EPOCH": {},
+	// DEPOT_TARGET is set for every bake target.
+	"DEPOT_TARGET": {},
+}
+
+// BuildArgChecker reports the build args of each target that its Dockerfile
+// does not declare with ARG, which the frontend silently ignores, with the
+// declared arg they are likely a misspelling of.  The Dockerfile is parsed
+// before it is solved, so it is only checked with --check-build-args or
+// --strict-build-args, and not with frontends that do not read Dockerfiles.
+// As the frontend does not report the args it ignores, Dockerfiles that are
+// not read before the solve, such as of remote contexts, or that select
+// another frontend with a syntax directive are reported as not checked.
+type BuildArgChecker struct {
+	Enabled bool
+	Strict  bool
+
+	opts    map[string]build.Options
+	printer progress.Writer
+
+	mu     sync.Mutex
+	issues map[string][]string
+}
+
+func NewBuildArgChecker(printer progress.Writer, opts map[string]build.Options, depotOpts DepotOptions) *BuildArgChecker {
+	return &BuildArgChecker{
+		Enabled: (depotOpts.checkBuildArgs || depotOpts.strictBuildArgs) && depotOpts.frontend.UsesDockerfile(),
+		Strict:  depotOpts.strictBuildArgs,
+		opts:    opts,
+		printer: printer,
+		issues:  make(map[string][]string),
+	}
+}
+
+func (c *BuildArgChecker) Handle(_ context.Context, target string, _ int, dockerfile *depotbuild.DockerfileInputs, _ progress.Writer) error {
+	if !c.Enabled {
+		return nil
+	}
+
+	c.mu.Lock()
+	if _, ok := c.issues[target]; ok {
+		c.mu.Unlock()
+		return nil
+	}
+	c.issues[target] = nil
+	c.mu.Unlock()
+
+	name := "[build args]"
+	if target != defaultTargetName {
+		name = fmt.Sprintf("[%s build args]", target)
+	}
+	dgst := digest.Canonical.FromString(identity.NewID())
+	tm := time.Now()
+	vertex := &client.Vertex{Digest: dgst, Name: name, Started: &tm, Completed: &tm}
+
+	declared, skipped := checkableBuildArgs(c.opts[target], dockerfile)
+	if skipped != "" {
+		c.printer.Write(&client.SolveStatus{
+			Vertexes: []*client.Vertex{vertex},
+			Statuses: []*client.VertexStatus{{Vertex: dgst, ID: "NOT CHECKED " + skipped, Timestamp: tm, Started: &tm, Completed: &tm}},
+		})
+		return nil
+	}
+	issues := unusedBuildArgs(c.opts[target].BuildArgs, declared)
+	if len(issues) == 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	c.issues[target] = issues
+	c.mu.Unlock()
+
+	var statuses []*client.VertexStatus
+	for _, issue := range issues {
+		statuses = append(statuses, &client.VertexStatus{Vertex: dgst, ID: "UNUSED " + issue, Timestamp: tm, Started: &tm, Completed: &tm})
+	}
+	if c.Strict {
+		vertex.Error = strings.Join(issues, "\n")
+	}
+	c.printer.Write(&client.SolveStatus{Vertexes: []*client.Vertex{vertex}, Statuses: statuses})
+
+	if c.Strict {
+		return UnusedBuildArgs
+	}
+	return nil
+}
+
+func (c *BuildArgChecker) Print(w io.Writer, mode string) {
+	if !c.Enabled || mode == progress.PrinterModeQuiet {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	targets := make([]string, 0, len(c.issues))
+	numIssues := 0
+	for target, issues := range c.issues {
+		targets = append(targets, target)
+		numIssues += len(issues)
+	}
+	if numIssues == 0 {
+		return
+	}
+	sort.Strings(targets)
+
+	summary := "1 unused build arg found"
+	if numIssues > 1 {
+		summary = fmt.Sprintf("%d unused build args found", numIssues)
+	}
+	if progresshelper.UseColor(mode) {
+		color := aec.YellowF
+		if c.Strict {
+			color = aec.RedF
+		}
+		summary = color.Apply(summary)
+	}
+	fmt.Fprintf(w, "\n %s:\n", summary)
+
+	for _, target := range targets {
+		prefix := ""
+		if target != defaultTargetName {
+			prefix = fmt.Sprintf("[%s] ", target)
+		}
+		for _, issue := range c.issues[target] {
+			fmt.Fprintf(w, "UNUSED %s%s\n", prefix, issue)
+		}
+	}
+	fmt.Fprintf(w, "\n")
+}
+
+// checkableBuildArgs returns the args declared by the Dockerfile, or why its
+// build args cannot be checked before the solve.
+func checkableBuildArgs(opt build.Options, dockerfile *depotbuild.DockerfileInputs) (map[string]struct{}, string) {
+	if dockerfile.Err != nil || len(dockerfile.Content) == 0 {
+		return nil, "the Dockerfile is not read before the build"
+	}
+	syntax := opt.BuildArgs["BUILDKIT_SYNTAX"]
+	if syntax == "" {
+		syntax, _, _, _ = parser.DetectSyntax(dockerfile.Content)
+	}
+	if syntax != "" && !isDockerfileFrontend(syntax) {
+		return nil, fmt.Sprintf("the Dockerfile is built by the frontend %s", syntax)
+	}
+	declared, ok := dockerfileArgs(dockerfile.Content)
+	if !ok {
+		return nil, "the Dockerfile cannot be parsed before the build"
+	}
+	return declared, ""
+}
+
+// isDockerfileFrontend reports whether the image of a syntax directive is a
+// release of the Dockerfile frontend, whose ARG instructions are parsed here.
+func isDockerfileFrontend(syntax string) bool {
+	named, err := reference.ParseNormalizedNamed(syntax)
+	if err != nil {
+		return false
+	}
+	switch reference.FamiliarName(named) {
+	case "docker/dockerfile", "docker/dockerfile-upstream":
+		return true
+	}
+	return false
+}
+
+// dockerfileArgs returns the names of the ARG instructions of every stage and
+// of the global scope.  It returns false if the Dockerfile cannot be parsed.
+func dockerfileArgs(content []byte) (map[string]struct{}, bool) {
+	ast, err := parser.Parse(bytes.NewReader(content))
+	if err != nil {
+		return nil, false
+	}
+	stages, metaArgs, err := instructions.Parse(ast.AST)
+	if err != nil {
+		return nil, false
+	}
+
+	args := map[string]struct{}{}
+	for _, arg := range metaArgs {
+		for _, kv := range arg.Args {
+			args[kv.Key] = struct{}{}
+		}
+	}
+	for _, stage := range stages {
+		for _, cmd := range stage.Commands {
+			if arg, ok := cmd.(*instructions.ArgCommand); ok {
+				for _, kv := range arg.Args {
+					args[kv.Key] = struct{}{}
+				}
+			}
+		}
+	}
+	return args, true
+}
+
+// unusedBuildArgs returns the build args that are not declared, sorted, with
+// the declared arg of the closest name if it is likely misspelled.
+func unusedBuildArgs(buildArgs map[string]string, declared map[string]struct{}) []string {
+	var issues []string
+	for name := range buildArgs {
+		if _, ok := declared[name]; ok {
+			continue
+		}
+		if _, ok := predefinedBuildArgs[name]; ok || strings.HasPrefix(name, "BUILDKIT_") {
+			continue
+		}
+		issue := fmt.Sprintf("build arg %s is not declared by the Dockerfile", name)
+		if suggestion := closestArg(name, declared, buildArgs); suggestion != "" {
+			issue += fmt.Sprintf(" (did you mean %s?)", suggestion)
+		}
+		issues = append(issues, issue)
+	}
+	sort.Strings(issues)
+	return issues
+}
+
+// closestArg returns the declared arg that is at most two edits from name,
+// ignoring case, and is not already passed.
+func closestArg(name string, declared map[string]struct{}, buildArgs map[string]string) string {
+	best, bestDistance := "", 3
+	for arg := range declared {
+		if _, ok := buildArgs[arg]; ok {
+			continue
+		}
+		d := editDistance(strings.ToUpper(name), strings.ToUpper(arg))
+		if d < bestDistance || (d == bestDistance && arg < best) {
+			best, bestDistance = arg, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance of two strings.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	depotbuild "github.com/depot/cli/pkg/buildx/build"
+	"github.com/docker/buildx/build"
+)
+
+func TestUnusedBuildArgs(t *testing.T) {
+	dockerfile := []byte(`ARG BASE=alpine
+FROM ${BASE}
+ARG NODE_VERSION
+ARG APP_ENV=production
+RUN echo $NODE_VERSION $APP_ENV
+`)
+	declared, ok := dockerfileArgs(dockerfile)
+	if !ok {
+		t.Fatal("expected the Dockerfile to parse")
+	}
+
+	buildArgs := map[string]string{
+		"BASE":            "debian",
+		"NODE_VERSOIN":    "20",
+		"GIT_SHA":         "abc",
+		"HTTP_PROXY":      "http://proxy",
+		"BUILDKIT_SYNTAX": "docker/dockerfile:1",
+	}
+	want := []string{
+		"build arg GIT_SHA is not declared by the Dockerfile",
+		"build arg NODE_VERSOIN is not declared by the Dockerfile (did you mean NODE_VERSION?)",
+	}
+	if got := unusedBuildArgs(buildArgs, declared); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestCheckableBuildArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		buildArgs  map[string]string
+		dockerfile *depotbuild.DockerfileInputs
+		wantSkip   bool
+	}{
+		{
+			name:       "local Dockerfile",
+			dockerfile: &depotbuild.DockerfileInputs{Content: []byte("FROM alpine\nARG VERSION\n")},
+		},
+		{
+			name:       "Dockerfile frontend syntax",
+			dockerfile: &depotbuild.DockerfileInputs{Content: []byte("# syntax=docker/dockerfile:1.6\nFROM alpine\n")},
+		},
+		{
+			name:       "remote Dockerfile",
+			dockerfile: &depotbuild.DockerfileInputs{},
+			wantSkip:   true,
+		},
+		{
+			name:       "other frontend syntax",
+			dockerfile: &depotbuild.DockerfileInputs{Content: []byte("# syntax=example/frontend:1\nFROM alpine\n")},
+			wantSkip:   true,
+		},
+		{
+			name:       "BUILDKIT_SYNTAX build arg",
+			buildArgs:  map[string]string{"BUILDKIT_SYNTAX": "example/frontend:1"},
+			dockerfile: &depotbuild.DockerfileInputs{Content: []byte("FROM alpine\n")},
+			wantSkip:   true,
+		},
+		{
+			name:       "unparsable Dockerfile",
+			dockerfile: &depotbuild.DockerfileInputs{Content: []byte("FROM\n")},
+			wantSkip:   true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			declared, skipped := checkableBuildArgs(build.Options{BuildArgs: tt.buildArgs}, tt.dockerfile)
+			if (skipped != "") != tt.wantSkip {
+				t.Errorf("checkableBuildArgs() skipped = %q, want skipped %v", skipped, tt.wantSkip)
+			}
+			if !tt.wantSkip && declared == nil {
+				t.Errorf("checkableBuildArgs() declared = nil, want the declared args")
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"connectrpc.com/connect"
+	depotapi "github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/buildx/bake"
+	depotbuild "github.com/depot/cli/pkg/buildx/build"
+	"github.com/depot/cli/pkg/debuglog"
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+	"github.com/docker/buildx/util/progress"
+	"github.com/opencontainers/go-digest"
+)
+
+// DefinitionRecorder keeps the Dockerfile of each target as it was read so
+// that, with --record-definitions, the definitions of the build are stored
+// with it.
+type DefinitionRecorder struct {
+	Enabled bool
+
+	mu          sync.Mutex
+	dockerfiles map[string]*cliv1.Dockerfile
+}
+
+func NewDefinitionRecorder(depotOpts DepotOptions) *DefinitionRecorder {
+	return &DefinitionRecorder{
+		Enabled:     depotOpts.recordDefinitions,
+		dockerfiles: make(map[string]*cliv1.Dockerfile),
+	}
+}
+
+func (r *DefinitionRecorder) Handle(_ context.Context, target string, _ int, dockerfile *depotbuild.DockerfileInputs, _ progress.Writer) error {
+	if !r.Enabled || dockerfile.Err != nil || len(dockerfile.Content) == 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.dockerfiles[target]; ok {
+		return nil
+	}
+	r.dockerfiles[target] = &cliv1.Dockerfile{
+		Target:   target,
+		Filename: dockerfile.Filename,
+		Contents: string(dockerfile.Content),
+		Digest:   digest.FromBytes(dockerfile.Content).String(),
+	}
+	return nil
+}
+
+// Report stores the recorded Dockerfiles and the bake or compose files of
+// the build.  A failure to store them does not fail the build.
+func (r *DefinitionRecorder) Report(ctx context.Context, token, buildID string, files []bake.File) {
+	if !r.Enabled || buildID == "" {
+		return
+	}
+
+	req := &cliv1.ReportBuildContextRequest{BuildId: buildID}
+	r.mu.Lock()
+	for _, dockerfile := range r.dockerfiles {
+		req.Dockerfiles = append(req.Dockerfiles, dockerfile)
+	}
+	r.mu.Unlock()
+	sort.Slice(req.Dockerfiles, func(i, j int) bool { return req.Dockerfiles[i].Target < req.Dockerfiles[j].Target })
+
+	for _, file := range files {
+		req.DefinitionFiles = append(req.DefinitionFiles, &cliv1.BuildDefinitionFile{
+			Filename: file.Name,
+			Digest:   digest.FromBytes(file.Data).String(),
+			Contents: string(file.Data),
+		})
+	}
+	if len(req.Dockerfiles) == 0 && len(req.DefinitionFiles) == 0 {
+		return
+	}
+
+	_, err := depotapi.NewBuildClient().ReportBuildContext(ctx, depotapi.WithAuthentication(connect.NewRequest(req), token))
+	if err != nil {
+		debuglog.Log("unable to report build definitions: %v", err)
+	}
+}
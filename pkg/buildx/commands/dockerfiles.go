@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/docker/buildx/build"
+	"github.com/moby/buildkit/client"
+	"github.com/pkg/errors"
+	"golang.org/x/exp/maps"
+)
+
+// dockerfileTarget is one Dockerfile of a build given several -f flags.
+type dockerfileTarget struct {
+	name       string
+	dockerfile string
+	tags       []string
+}
+
+// parseDockerfileTargets names a target after each Dockerfile and assigns the
+// tags scoped to it with --tag <target>=<name>.
+func parseDockerfileTargets(dockerfiles, tags []string) ([]dockerfileTarget, error) {
+	targets := make([]dockerfileTarget, 0, len(dockerfiles))
+	byName := map[string]int{}
+	for _, dockerfile := range dockerfiles {
+		name := dockerfileTargetName(dockerfile)
+		if _, ok := byName[name]; ok {
+			return nil, errors.Errorf("Dockerfiles %s and %s both map to target %q, rename one of them or use a bake file", targets[byName[name]].dockerfile, dockerfile, name)
+		}
+		byName[name] = len(targets)
+		targets = append(targets, dockerfileTarget{name: name, dockerfile: dockerfile})
+	}
+
+	for _, tag := range tags {
+		name, ref, ok := strings.Cut(tag, "=")
+		if !ok {
+			return nil, errors.Errorf("tag %q must be scoped to a Dockerfile when building multiple Dockerfiles (e.g., --tag %s=%s)", tag, targets[0].name, tag)
+		}
+		i, ok := byName[name]
+		if !ok {
+			names := maps.Keys(byName)
+			sort.Strings(names)
+			return nil, errors.Errorf("tag %q refers to unknown target %q (targets: %s)", tag, name, strings.Join(names, ", "))
+		}
+		targets[i].tags = append(targets[i].tags, ref)
+	}
+
+	return targets, nil
+}
+
+// dockerfileTargetName derives a target name from a Dockerfile path:
+// api.Dockerfile and Dockerfile.api become "api" and services/api/Dockerfile
+// becomes "api".
+func dockerfileTargetName(dockerfile string) string {
+	base := filepath.Base(dockerfile)
+	lower := strings.ToLower(base)
+
+	var name string
+	switch {
+	case strings.HasSuffix(lower, ".dockerfile"):
+		name = base[:len(base)-len(".dockerfile")]
+	case strings.HasPrefix(lower, "dockerfile."):
+		name = base[len("dockerfile."):]
+	case lower == "dockerfile":
+		name = filepath.Base(filepath.Dir(dockerfile))
+	default:
+		name = base
+	}
+
+	if name == "" || name == "." || name == string(filepath.Separator) {
+		return defaultTargetName
+	}
+	return sanitizeName(name)
+}
+
+func sanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+// splitDockerfileTargets creates build options for every Dockerfile target.
+// The targets share the build context; identical context paths are synced
+// only once per builder because they use the same session shared key.
+func splitDockerfileTargets(in *buildOptions, opts build.Options, targets []dockerfileTarget) (map[string]build.Options, error) {
+	out := make(map[string]build.Options, len(targets))
+	for _, target := range targets {
+		targetOpts := opts
+		targetOpts.Inputs.DockerfilePath = target.dockerfile
+		targetOpts.Tags = target.tags
+		targetOpts.BuildArgs = maps.Clone(opts.BuildArgs)
+		targetOpts.Labels = maps.Clone(opts.Labels)
+		targetOpts.Attests = maps.Clone(opts.Attests)
+
+		targetOpts.Exports = make([]client.ExportEntry, len(opts.Exports))
+		for i, export := range opts.Exports {
+			export.Attrs = maps.Clone(export.Attrs)
+			targetOpts.Exports[i] = export
+		}
+
+		// Session attachables cannot be shared between concurrent solves.
+		session, err := buildSession(in)
+		if err != nil {
+			return nil, err
+		}
+		targetOpts.Session = session
+
+		out[target.name] = targetOpts
+	}
+	return out, nil
+}
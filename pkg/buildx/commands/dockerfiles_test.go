@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDockerfileTargets(t *testing.T) {
+	targets, err := parseDockerfileTargets(
+		[]string{"api.Dockerfile", "Dockerfile.worker", "services/web/Dockerfile"},
+		[]string{"api=repo/api:latest", "web=repo/web:latest", "api=repo/api:v1"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []dockerfileTarget{
+		{name: "api", dockerfile: "api.Dockerfile", tags: []string{"repo/api:latest", "repo/api:v1"}},
+		{name: "worker", dockerfile: "Dockerfile.worker"},
+		{name: "web", dockerfile: "services/web/Dockerfile", tags: []string{"repo/web:latest"}},
+	}
+	if !reflect.DeepEqual(targets, want) {
+		t.Errorf("parseDockerfileTargets() = %+v, want %+v", targets, want)
+	}
+
+	errorCases := map[string][]string{
+		"unscoped tag":   {"repo/api:latest"},
+		"unknown target": {"db=repo/db:latest"},
+	}
+	for name, tags := range errorCases {
+		if _, err := parseDockerfileTargets([]string{"api.Dockerfile", "Dockerfile.worker"}, tags); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if _, err := parseDockerfileTargets([]string{"a/Dockerfile.api", "b/api.Dockerfile"}, nil); err == nil {
+		t.Error("duplicate target names: expected an error")
+	}
+}
@@ -0,0 +1,407 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/containerd/containerd/platforms"
+	depotapi "github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/contextd"
+	"github.com/depot/cli/pkg/debuglog"
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+	"github.com/distribution/reference"
+	"github.com/docker/buildx/build"
+	"github.com/docker/buildx/util/progress"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	"github.com/moby/buildkit/frontend/dockerfile/dockerignore"
+	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/exp/maps"
+)
+
+const targetSkipped = "skipped"
+
+// skippedTarget is a target that was not built because a previous build had
+// the same fingerprint.
+type skippedTarget struct {
+	BuildID string
+	Digest  string
+}
+
+// targetInputs are the inputs of a target that decide its result.
+type targetInputs struct {
+	Context       string             `json:"context"`
+	Dockerfile    string             `json:"dockerfile"`
+	NamedContexts map[string]string  `json:"namedContexts,omitempty"`
+	Target        string             `json:"target,omitempty"`
+	BuildArgs     map[string]string  `json:"buildArgs,omitempty"`
+	Labels        map[string]string  `json:"labels,omitempty"`
+	Platforms     []string           `json:"platforms,omitempty"`
+	Tags          []string           `json:"tags,omitempty"`
+	Attests       map[string]*string `json:"attests,omitempty"`
+	Exports       []exportInputs     `json:"exports,omitempty"`
+	Allow         []string           `json:"allow,omitempty"`
+	ExtraHosts    []string           `json:"extraHosts,omitempty"`
+	NetworkMode   string             `json:"networkMode,omitempty"`
+	ShmSize       int64              `json:"shmSize,omitempty"`
+}
+
+type exportInputs struct {
+	Type  string            `json:"type"`
+	Attrs map[string]string `json:"attrs,omitempty"`
+}
+
+// fingerprintTarget hashes the context, Dockerfile, and options of a target,
+// and the fingerprints of the targets it uses as "target:" named contexts.
+// Targets whose result cannot be reused are not fingerprinted: those with a
+// remote or stdin context, that pull or disable the cache, that export
+// anywhere but a registry, or that use a target without a fingerprint.  The
+// tags are part of the fingerprint, so that a target whose tags changed is
+// built and pushed to them rather than skipped.
+func fingerprintTarget(opt build.Options, targets map[string]string) (string, bool, error) {
+	if opt.Pull || opt.NoCache || opt.Inputs.ContextState != nil || opt.Inputs.InStream != nil {
+		return "", false, nil
+	}
+	for _, export := range opt.Exports {
+		if export.Type != "image" && export.Type != "registry" {
+			return "", false, nil
+		}
+	}
+
+	contextHash, ok, err := hashLocalDir(opt.Inputs.ContextPath, opt.Inputs.DockerfilePath)
+	if err != nil || !ok {
+		return "", false, err
+	}
+
+	inputs := targetInputs{
+		Context:     contextHash,
+		Target:      opt.Target,
+		BuildArgs:   opt.BuildArgs,
+		Labels:      opt.Labels,
+		Tags:        sortedCopy(opt.Tags),
+		Attests:     opt.Attests,
+		ExtraHosts:  opt.ExtraHosts,
+		NetworkMode: opt.NetworkMode,
+		ShmSize:     int64(opt.ShmSize),
+	}
+
+	if opt.Inputs.DockerfileInline != "" {
+		inputs.Dockerfile = digest.FromString(opt.Inputs.DockerfileInline).String()
+	} else {
+		dockerfile := opt.Inputs.DockerfilePath
+		if dockerfile == "-" {
+			return "", false, nil
+		}
+		if dockerfile == "" {
+			dockerfile = filepath.Join(opt.Inputs.ContextPath, "Dockerfile")
+		}
+		dt, err := os.ReadFile(dockerfile)
+		if err != nil {
+			return "", false, err
+		}
+		inputs.Dockerfile = digest.FromBytes(dt).String()
+	}
+
+	for name, named := range opt.Inputs.NamedContexts {
+		if named.State != nil {
+			return "", false, nil
+		}
+		value := named.Path
+		switch {
+		case strings.HasPrefix(value, "target:"):
+			fingerprint, ok := targets[strings.TrimPrefix(value, "target:")]
+			if !ok {
+				return "", false, nil
+			}
+			value = "target:" + fingerprint
+		case strings.HasPrefix(value, "docker-image://"):
+		default:
+			hash, ok, err := hashLocalDir(value, "")
+			if err != nil || !ok {
+				return "", false, err
+			}
+			value = hash
+		}
+		if inputs.NamedContexts == nil {
+			inputs.NamedContexts = map[string]string{}
+		}
+		inputs.NamedContexts[name] = value
+	}
+
+	for _, platform := range opt.Platforms {
+		inputs.Platforms = append(inputs.Platforms, platforms.Format(platform))
+	}
+	for _, export := range opt.Exports {
+		inputs.Exports = append(inputs.Exports, exportInputs{Type: export.Type, Attrs: export.Attrs})
+	}
+	for _, entitlement := range opt.Allow {
+		inputs.Allow = append(inputs.Allow, string(entitlement))
+	}
+
+	dt, err := json.Marshal(inputs)
+	if err != nil {
+		return "", false, err
+	}
+	return digest.FromBytes(dt).String(), true, nil
+}
+
+// hashLocalDir hashes the paths, modes, and contents of the files of a local
+// context that are not excluded by its .dockerignore, from the index of depot
+// contextd when it watches the context.  It returns false for contexts that
+// are not local directories.
+func hashLocalDir(dir, dockerfile string) (string, bool, error) {
+	if dir == "" || dir == "-" || strings.Contains(dir, "://") {
+		return "", false, nil
+	}
+	if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+		return "", false, nil
+	}
+
+	excludes, err := readDockerignore(dir, dockerfile)
+	if err != nil {
+		return "", false, err
+	}
+	hash, err := contextd.Hash(dir, excludes)
+	if err != nil {
+		return "", false, err
+	}
+	return hash, true, nil
+}
+
+// readDockerignore reads the Dockerfile-specific ignore file if there is
+// one, and the .dockerignore of the context otherwise.  Like the context,
+// the Dockerfile path is relative to the working directory.
+func readDockerignore(dir, dockerfile string) ([]string, error) {
+	candidates := []string{filepath.Join(dir, ".dockerignore")}
+	if dockerfile != "" && dockerfile != "-" {
+		candidates = append([]string{dockerfile + ".dockerignore"}, candidates...)
+	}
+	for _, path := range candidates {
+		f, err := os.Open(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return dockerignore.ReadAll(f)
+	}
+	return nil, nil
+}
+
+// imageTagger resolves and pushes the tags of skipped targets, as
+// imagetools.Resolver does.
+type imageTagger interface {
+	Resolve(ctx context.Context, in string) (string, ocispecs.Descriptor, error)
+	Get(ctx context.Context, in string) ([]byte, ocispecs.Descriptor, error)
+	Push(ctx context.Context, ref reference.Named, desc ocispecs.Descriptor, dt []byte) error
+}
+
+// skipUnchangedTargets fingerprints the targets and removes those that a
+// recent successful build of the project had the same fingerprint for.  The
+// tags of a skipped target that pushes are pointed at the digest it reuses,
+// and a target whose tags cannot be is built.  The API is best effort: when
+// it fails, every target is built.
+func skipUnchangedTargets(ctx context.Context, token, project string, tagger imageTagger, opts map[string]build.Options, pw progress.Writer) (fingerprints map[string]string, skipped map[string]skippedTarget) {
+	fingerprints = fingerprintTargets(opts)
+	if len(fingerprints) == 0 {
+		return fingerprints, nil
+	}
+
+	req := &cliv1.GetTargetFingerprintsRequest{ProjectId: project}
+	for _, fingerprint := range fingerprints {
+		req.Fingerprints = append(req.Fingerprints, fingerprint)
+	}
+	sort.Strings(req.Fingerprints)
+	res, err := depotapi.NewBuildClient().GetTargetFingerprints(ctx, depotapi.WithAuthentication(connect.NewRequest(req), token))
+	if err != nil {
+		progress.Write(pw, fmt.Sprintf("[depot] unable to find unchanged targets, building all targets: %v", err), func() error { return nil })
+		return fingerprints, nil
+	}
+
+	previous := map[string]*cliv1.TargetFingerprint{}
+	for _, t := range res.Msg.Targets {
+		if t.Digest != "" {
+			previous[t.Fingerprint] = t
+		}
+	}
+
+	skipped = map[string]skippedTarget{}
+	for target, fingerprint := range fingerprints {
+		t, ok := previous[fingerprint]
+		if !ok {
+			continue
+		}
+		if opt := opts[target]; isPushed(opt) {
+			if err := retagImage(ctx, tagger, opt.Tags, digest.Digest(t.Digest)); err != nil {
+				progress.Write(pw, fmt.Sprintf("[depot] building unchanged target %s, unable to tag %s: %v", target, t.Digest, err), func() error { return nil })
+				continue
+			}
+		}
+		skipped[target] = skippedTarget{BuildID: t.BuildId, Digest: t.Digest}
+	}
+	keepDependencies(opts, skipped)
+
+	targets := maps.Keys(skipped)
+	sort.Strings(targets)
+	for _, target := range targets {
+		s := skipped[target]
+		delete(opts, target)
+		progress.Write(pw, fmt.Sprintf("[depot] skipping unchanged target %s, reusing %s from build %s", target, s.Digest, s.BuildID), func() error { return nil })
+	}
+	return fingerprints, skipped
+}
+
+// retagImage points the tags at the image of the digest, which a previous
+// build pushed to their repositories, for the tags that were moved since.
+func retagImage(ctx context.Context, tagger imageTagger, tags []string, dgst digest.Digest) error {
+	for _, tag := range tags {
+		named, err := reference.ParseNormalizedNamed(tag)
+		if err != nil {
+			return err
+		}
+		named = reference.TagNameOnly(named)
+		if _, desc, err := tagger.Resolve(ctx, named.String()); err == nil && desc.Digest == dgst {
+			continue
+		}
+
+		pinned, err := reference.WithDigest(reference.TrimNamed(named), dgst)
+		if err != nil {
+			return err
+		}
+		dt, desc, err := tagger.Get(ctx, pinned.String())
+		if err != nil {
+			return err
+		}
+		if err := tagger.Push(ctx, named, desc, dt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fingerprintTargets fingerprints the targets, each after the targets it uses
+// as named contexts so that their fingerprints are part of its own.
+func fingerprintTargets(opts map[string]build.Options) map[string]string {
+	fingerprints := map[string]string{}
+	visited := map[string]bool{}
+	var visit func(target string)
+	visit = func(target string) {
+		if visited[target] {
+			return
+		}
+		visited[target] = true
+		opt := opts[target]
+		for _, dep := range targetDependencies(opt) {
+			if _, ok := opts[dep]; ok {
+				visit(dep)
+			}
+		}
+
+		fingerprint, ok, err := fingerprintTarget(opt, fingerprints)
+		if err != nil {
+			debuglog.Log("unable to fingerprint target %s: %v", target, err)
+			return
+		}
+		if ok {
+			fingerprints[target] = fingerprint
+		}
+	}
+	for target := range opts {
+		visit(target)
+	}
+	return fingerprints
+}
+
+// targetDependencies are the targets a target uses as named contexts.
+func targetDependencies(opt build.Options) []string {
+	var deps []string
+	for _, named := range opt.Inputs.NamedContexts {
+		if dep, ok := strings.CutPrefix(named.Path, "target:"); ok {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// keepDependencies builds the skipped targets that a built target uses as a
+// named context, as the build needs them.
+func keepDependencies(opts map[string]build.Options, skipped map[string]skippedTarget) {
+	for changed := true; changed; {
+		changed = false
+		for target, opt := range opts {
+			if _, ok := skipped[target]; ok {
+				continue
+			}
+			for _, dep := range targetDependencies(opt) {
+				if _, ok := skipped[dep]; ok {
+					delete(skipped, dep)
+					changed = true
+				}
+			}
+		}
+	}
+}
+
+func sortedCopy(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// reportFingerprints records the fingerprints of the targets that were built
+// so that later bakes can skip them.
+func reportFingerprints(ctx context.Context, token, buildID string, fingerprints map[string]string, summaries []TargetSummary) {
+	req := &cliv1.ReportTargetFingerprintsRequest{BuildId: buildID}
+	for _, summary := range summaries {
+		fingerprint, ok := fingerprints[summary.Target]
+		if !ok || summary.Status != targetDone || summary.Digest == "" {
+			continue
+		}
+		req.Targets = append(req.Targets, &cliv1.TargetFingerprint{
+			Target:      summary.Target,
+			Fingerprint: fingerprint,
+			BuildId:     buildID,
+			Digest:      summary.Digest,
+		})
+	}
+	if len(req.Targets) == 0 {
+		return
+	}
+
+	_, err := depotapi.NewBuildClient().ReportTargetFingerprints(ctx, depotapi.WithAuthentication(connect.NewRequest(req), token))
+	if err != nil {
+		debuglog.Log("unable to report target fingerprints: %v", err)
+	}
+}
+
+func summarizeSkippedTargets(skipped map[string]skippedTarget) []TargetSummary {
+	summaries := make([]TargetSummary, 0, len(skipped))
+	for target, s := range skipped {
+		summaries = append(summaries, TargetSummary{Target: target, Status: targetSkipped, Digest: s.Digest})
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Target < summaries[j].Target })
+	return summaries
+}
+
+// skippedMetadata is the metadata file entry of each skipped target.
+func skippedMetadata(skipped map[string]skippedTarget) map[string]interface{} {
+	metadata := map[string]interface{}{}
+	for target, s := range skipped {
+		metadata[target] = map[string]interface{}{
+			exptypes.ExporterImageDigestKey: s.Digest,
+			"depot.reused-build-id":         s.BuildID,
+		}
+	}
+	return metadata
+}
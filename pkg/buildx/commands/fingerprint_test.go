@@ -0,0 +1,216 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/distribution/reference"
+	"github.com/docker/buildx/build"
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestFingerprintTarget(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("Dockerfile", "FROM alpine\nCOPY . .\n")
+	write(".dockerignore", "*.log\n")
+	write("main.go", "package main\n")
+
+	opt := build.Options{
+		Inputs:    build.Inputs{ContextPath: dir},
+		BuildArgs: map[string]string{"VERSION": "1"},
+		Exports:   []client.ExportEntry{{Type: "image", Attrs: map[string]string{"push": "true"}}},
+	}
+	fingerprint := func(opt build.Options) string {
+		t.Helper()
+		fp, ok, err := fingerprintTarget(opt, nil)
+		if err != nil || !ok {
+			t.Fatalf("fingerprintTarget() = %v, %v", ok, err)
+		}
+		return fp
+	}
+
+	first := fingerprint(opt)
+	write("debug.log", "ignored")
+	if fp := fingerprint(opt); fp != first {
+		t.Error("an ignored file changed the fingerprint")
+	}
+	write("main.go", "package main\n\nfunc main() {}\n")
+	changed := fingerprint(opt)
+	if changed == first {
+		t.Error("a changed file did not change the fingerprint")
+	}
+	opt.BuildArgs = map[string]string{"VERSION": "2"}
+	if fp := fingerprint(opt); fp == changed {
+		t.Error("a changed build arg did not change the fingerprint")
+	}
+
+	opt.Exports = []client.ExportEntry{{Type: "docker"}}
+	if _, ok, _ := fingerprintTarget(opt, nil); ok {
+		t.Error("targets loaded locally must not be fingerprinted")
+	}
+	opt.Exports = nil
+	opt.Pull = true
+	if _, ok, _ := fingerprintTarget(opt, nil); ok {
+		t.Error("targets that pull must not be fingerprinted")
+	}
+}
+
+func TestFingerprintTargets(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base")
+	app := filepath.Join(dir, "app")
+	for _, d := range []string{base, app} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(d, "Dockerfile"), []byte("FROM alpine\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	opts := map[string]build.Options{
+		"base": {Inputs: build.Inputs{ContextPath: base}, Tags: []string{"repo/base:1"}},
+		"app": {Inputs: build.Inputs{
+			ContextPath:   app,
+			NamedContexts: map[string]build.NamedContext{"base": {Path: "target:base"}},
+		}},
+	}
+
+	first := fingerprintTargets(opts)
+	if len(first) != 2 {
+		t.Fatalf("expected both targets to be fingerprinted, got %v", first)
+	}
+	if err := os.WriteFile(filepath.Join(base, "file"), []byte("changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	changed := fingerprintTargets(opts)
+	if changed["app"] == first["app"] {
+		t.Error("a changed dependency did not change the fingerprint of its dependent")
+	}
+
+	opt := opts["base"]
+	opt.Tags = []string{"repo/base:1", "repo/base:2"}
+	opts["base"] = opt
+	if fingerprintTargets(opts)["base"] == changed["base"] {
+		t.Error("a new tag did not change the fingerprint")
+	}
+
+	delete(opts, "base")
+	if _, ok := fingerprintTargets(opts)["app"]; ok {
+		t.Error("a target using a target without a fingerprint must not be fingerprinted")
+	}
+}
+
+func TestKeepDependencies(t *testing.T) {
+	uses := func(deps ...string) build.Options {
+		named := map[string]build.NamedContext{}
+		for _, dep := range deps {
+			named[dep] = build.NamedContext{Path: "target:" + dep}
+		}
+		return build.Options{Inputs: build.Inputs{NamedContexts: named}}
+	}
+	opts := map[string]build.Options{
+		"base": uses(),
+		"lib":  uses("base"),
+		"app":  uses("lib"),
+		"docs": uses(),
+	}
+	skipped := map[string]skippedTarget{"base": {}, "lib": {}, "docs": {}}
+	keepDependencies(opts, skipped)
+	if len(skipped) != 1 {
+		t.Errorf("expected the dependencies of app to be built, got skipped %v", skipped)
+	}
+	if _, ok := skipped["docs"]; !ok {
+		t.Error("expected docs to stay skipped")
+	}
+
+	skipped = map[string]skippedTarget{"base": {}, "lib": {}, "app": {}, "docs": {}}
+	keepDependencies(opts, skipped)
+	if len(skipped) != 4 {
+		t.Errorf("expected a skipped chain to stay skipped, got %v", skipped)
+	}
+}
+
+type fakeTagger struct {
+	tags   map[string]digest.Digest
+	pushed []string
+}
+
+func (f *fakeTagger) Resolve(ctx context.Context, in string) (string, ocispecs.Descriptor, error) {
+	dgst, ok := f.tags[in]
+	if !ok {
+		return "", ocispecs.Descriptor{}, errors.New("not found")
+	}
+	return in, ocispecs.Descriptor{Digest: dgst}, nil
+}
+
+func (f *fakeTagger) Get(ctx context.Context, in string) ([]byte, ocispecs.Descriptor, error) {
+	if in != "docker.io/library/app@"+testDigest.String() {
+		return nil, ocispecs.Descriptor{}, errors.New("not found")
+	}
+	return []byte("{}"), ocispecs.Descriptor{Digest: testDigest}, nil
+}
+
+func (f *fakeTagger) Push(ctx context.Context, ref reference.Named, desc ocispecs.Descriptor, dt []byte) error {
+	f.tags[ref.String()] = desc.Digest
+	f.pushed = append(f.pushed, ref.String())
+	return nil
+}
+
+var testDigest = digest.FromString("app")
+
+func TestRetagImage(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    map[string]digest.Digest
+		in      []string
+		pushed  int
+		wantErr bool
+	}{
+		{
+			name:   "current tag",
+			tags:   map[string]digest.Digest{"docker.io/library/app:latest": testDigest},
+			in:     []string{"app"},
+			pushed: 0,
+		},
+		{
+			name:   "moved tag",
+			tags:   map[string]digest.Digest{"docker.io/library/app:v1": digest.FromString("other")},
+			in:     []string{"app:latest", "app:v1"},
+			pushed: 2,
+		},
+		{
+			name:    "digest missing from repository",
+			tags:    map[string]digest.Digest{},
+			in:      []string{"other:latest"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagger := &fakeTagger{tags: tt.tags}
+			err := retagImage(context.Background(), tagger, tt.in, testDigest)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("retagImage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(tagger.pushed) != tt.pushed {
+				t.Errorf("retagImage() pushed %v, want %d tags", tagger.pushed, tt.pushed)
+			}
+			for _, tag := range tagger.pushed {
+				if tagger.tags[tag] != testDigest {
+					t.Errorf("tag %s points at %s, want %s", tag, tagger.tags[tag], testDigest)
+				}
+			}
+		})
+	}
+}
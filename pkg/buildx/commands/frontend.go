@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"strings"
+
+	depotbuildxbuild "github.com/depot/cli/pkg/buildx/build"
+	"github.com/pkg/errors"
+)
+
+// parseFrontend parses --frontend and its --opt key=value options.  It
+// returns nil for the default Dockerfile frontend without options.
+func parseFrontend(name string, opts []string) (*depotbuildxbuild.Frontend, error) {
+	switch name {
+	case "", depotbuildxbuild.DockerfileFrontend, depotbuildxbuild.GatewayFrontend:
+	default:
+		return nil, errors.Errorf("unsupported frontend %q (expected %q or %q)", name, depotbuildxbuild.DockerfileFrontend, depotbuildxbuild.GatewayFrontend)
+	}
+	if name == "" && len(opts) == 0 {
+		return nil, nil
+	}
+
+	frontend := &depotbuildxbuild.Frontend{Name: name, Opts: make(map[string]string, len(opts))}
+	for _, opt := range opts {
+		k, v, ok := strings.Cut(opt, "=")
+		if !ok || k == "" {
+			return nil, errors.Errorf("invalid frontend option %q (expected key=value)", opt)
+		}
+		frontend.Opts[k] = v
+	}
+
+	source := frontend.Opts["source"]
+	if name == depotbuildxbuild.GatewayFrontend && source == "" {
+		return nil, errors.Errorf(`--frontend %s requires the frontend image, e.g. "--opt source=docker/dockerfile:1"`, depotbuildxbuild.GatewayFrontend)
+	}
+	if name != depotbuildxbuild.GatewayFrontend && source != "" {
+		return nil, errors.Errorf(`--opt source requires "--frontend %s"`, depotbuildxbuild.GatewayFrontend)
+	}
+	return frontend, nil
+}
+
+// validateFrontend rejects the options that read the Dockerfile when the
+// frontend builds something else.
+func validateFrontend(frontend *depotbuildxbuild.Frontend, print bool, depotOpts *DepotOptions) error {
+	if frontend.UsesDockerfile() {
+		return nil
+	}
+
+	var flags []string
+	if depotOpts.lint {
+		flags = append(flags, "--lint")
+	}
+	if depotOpts.checkBaseImages || depotOpts.failOnStaleBase {
+		flags = append(flags, "--check-base-images")
+	}
+	if depotOpts.policyFile != "" {
+		flags = append(flags, "--policy-file")
+	}
+	if depotOpts.checkBuildArgs || depotOpts.strictBuildArgs {
+		flags = append(flags, "--check-build-args")
+	}
+	if print {
+		flags = append(flags, "--print")
+	}
+	if len(flags) > 0 {
+		return errors.Errorf("%s cannot be used with the frontend %s, which does not build a Dockerfile", strings.Join(flags, ", "), frontend.Opts["source"])
+	}
+	return nil
+}
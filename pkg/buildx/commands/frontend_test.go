@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"testing"
+)
+
+func TestParseFrontend(t *testing.T) {
+	frontend, err := parseFrontend("", nil)
+	if err != nil || frontend != nil {
+		t.Fatalf("expected no frontend by default, got %+v, %v", frontend, err)
+	}
+
+	frontend, err = parseFrontend("gateway.v0", []string{"source=example/mockerfile", "filename=mockerfile.yaml"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if frontend.Name != "gateway.v0" || frontend.Opts["source"] != "example/mockerfile" || frontend.Opts["filename"] != "mockerfile.yaml" {
+		t.Errorf("unexpected frontend: %+v", frontend)
+	}
+	if frontend.UsesDockerfile() {
+		t.Errorf("expected a custom frontend not to build a Dockerfile")
+	}
+	if err := validateFrontend(frontend, false, &DepotOptions{lint: true}); err == nil {
+		t.Errorf("expected --lint to be rejected with a custom frontend")
+	}
+
+	frontend, err = parseFrontend("gateway.v0", []string{"source=docker/dockerfile:1.6"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !frontend.UsesDockerfile() {
+		t.Errorf("expected the Dockerfile frontend image to build a Dockerfile")
+	}
+	if err := validateFrontend(frontend, false, &DepotOptions{lint: true}); err != nil {
+		t.Errorf("expected --lint to be allowed with the Dockerfile frontend image, got %v", err)
+	}
+
+	for _, invalid := range []struct {
+		name string
+		opts []string
+	}{
+		{name: "llb.v0"},
+		{name: "gateway.v0"},
+		{name: "gateway.v0", opts: []string{"source"}},
+		{opts: []string{"source=example/mockerfile"}},
+	} {
+		if _, err := parseFrontend(invalid.name, invalid.opts); err == nil {
+			t.Errorf("expected %q with %v to be invalid", invalid.name, invalid.opts)
+		}
+	}
+}
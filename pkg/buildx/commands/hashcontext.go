@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/docker/buildx/build"
+	"github.com/docker/buildx/util/platformutil"
+	"github.com/docker/cli/cli"
+	"github.com/moby/buildkit/client"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// HashContextCmd prints the fingerprint that "depot bake
+// --skip-unchanged-targets" computes for a target, so that other CI systems
+// can skip builds of unchanged inputs the same way.
+func HashContextCmd() *cobra.Command {
+	var (
+		dockerfile string
+		buildArgs  []string
+		contexts   []string
+		labels     []string
+		platforms  []string
+		tags       []string
+		target     string
+		push       bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "hash-context [OPTIONS] PATH",
+		Short: "Print the fingerprint of a build context, Dockerfile, and build options",
+		Long: `Print the fingerprint of a local build context, its Dockerfile, and the
+build options that decide the result of a build.  Files excluded by the
+.dockerignore do not change the fingerprint.  It is the fingerprint used by
+"depot bake --skip-unchanged-targets" for a target with the same options.`,
+		Args: cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namedContexts, err := parseContextNames(contexts)
+			if err != nil {
+				return err
+			}
+			opt := build.Options{
+				Inputs: build.Inputs{
+					ContextPath:    args[0],
+					DockerfilePath: dockerfile,
+					NamedContexts:  namedContexts,
+				},
+				BuildArgs: listToMap(buildArgs, true),
+				Labels:    listToMap(labels, false),
+				Tags:      tags,
+				Target:    target,
+			}
+			opt.Platforms, err = platformutil.Parse(platforms)
+			if err != nil {
+				return err
+			}
+			if push {
+				opt.Exports = []client.ExportEntry{{Type: client.ExporterImage, Attrs: map[string]string{"push": "true"}}}
+			}
+
+			fingerprint, ok, err := fingerprintTarget(opt, nil)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return errors.Errorf("%s cannot be hashed: only contexts in local directories can be hashed", args[0])
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), fingerprint)
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&dockerfile, "file", "f", "", `Name of the Dockerfile (default: "PATH/Dockerfile")`)
+	flags.StringArrayVar(&buildArgs, "build-arg", nil, "Build-time variables")
+	flags.StringArrayVar(&contexts, "build-context", nil, "Additional build contexts (e.g., name=path)")
+	flags.StringArrayVar(&labels, "label", nil, "Image labels")
+	flags.StringArrayVar(&platforms, "platform", nil, "Target platforms")
+	flags.StringArrayVarP(&tags, "tag", "t", nil, `Image names (format: "name:tag")`)
+	flags.StringVar(&target, "target", "", "Target build stage")
+	flags.BoolVar(&push, "push", false, "Hash the options of a build that pushes its image")
+
+	return cmd
+}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/depot/cli/pkg/buildx/bake"
+)
+
+func TestHashContextMatchesBake(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"Dockerfile":    "FROM alpine\nCOPY . .\n",
+		".dockerignore": "*.log\n",
+		"main.go":       "package main\n",
+		"debug.log":     "ignored",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := HashContextCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{
+		"--build-arg", "VERSION=1",
+		"--label", "team=build",
+		"--platform", "linux/amd64,linux/arm64",
+		"--tag", "repo/app:1",
+		"--target", "release",
+		"--push",
+		dir,
+	})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	hcl := fmt.Sprintf(`target "app" {
+  context = %q
+  args = { VERSION = "1" }
+  labels = { team = "build" }
+  platforms = ["linux/amd64", "linux/arm64"]
+  tags = ["repo/app:1"]
+  target = "release"
+  output = ["type=image,push=true"]
+}`, dir)
+	tgts, _, err := bake.ReadTargets(context.Background(), []bake.File{{Name: "docker-bake.hcl", Data: []byte(hcl)}}, []string{"app"}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts, err := bake.NewDepotBakeOptions("project", tgts, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fingerprints := fingerprintTargets(opts.ProjectOpts("project"))
+
+	if got, want := strings.TrimSpace(out.String()), fingerprints["app"]; got == "" || got != want {
+		t.Errorf("expected hash-context to print the bake fingerprint %q, got %q", want, got)
+	}
+}
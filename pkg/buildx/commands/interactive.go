@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/containerd/containerd/platforms"
+	"github.com/depot/cli/pkg/buildx/bake"
+	"github.com/pkg/errors"
+)
+
+// selectBakeTargets lets the user pick the groups and targets of the bake
+// files to build.
+func selectBakeTargets(in BakeOptions) ([]string, error) {
+	files, err := bake.ReadLocalFiles(in.files, in.stdin())
+	if err != nil {
+		return nil, err
+	}
+	names, err := bake.ListTargets(files)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, errors.New("the bake files define no targets")
+	}
+
+	defaults := map[string]string{
+		"BAKE_CMD_CONTEXT":    "cwd://",
+		"BAKE_LOCAL_PLATFORM": platforms.DefaultString(),
+	}
+	tgts, grps, err := readTargets(context.Background(), files, names, in, defaults)
+	if err != nil {
+		return nil, err
+	}
+
+	programOpts := []tea.ProgramOption{tea.WithAltScreen()}
+	if in.stdinFile != nil {
+		// Stdin was the bake file, so read the keys from the terminal.
+		programOpts = append(programOpts, tea.WithInputTTY())
+	}
+	final, err := tea.NewProgram(newTargetSelectModel(bakeTargetItems(tgts, grps)), programOpts...).Run()
+	if err != nil {
+		return nil, err
+	}
+	m, ok := final.(targetSelectModel)
+	if !ok {
+		return nil, errors.Errorf("invalid model: %T", final)
+	}
+	if !m.done {
+		return nil, errors.New("no bake targets selected")
+	}
+	return m.Selected(), nil
+}
+
+// targetItem is a group or target of the bake files.
+type targetItem struct {
+	name  string
+	group bool
+	// details are the lines of the preview.
+	details []string
+}
+
+// bakeTargetItems lists the groups, then the targets, by name.
+func bakeTargetItems(tgts map[string]*bake.Target, grps map[string]*bake.Group) []targetItem {
+	var groups, targets []targetItem
+	for name, g := range grps {
+		groups = append(groups, targetItem{
+			name:    name,
+			group:   true,
+			details: []string{"Targets: " + strings.Join(g.Targets, ", ")},
+		})
+	}
+	for name, t := range tgts {
+		item := targetItem{name: name}
+		if t.Context != nil {
+			item.details = append(item.details, "Context: "+*t.Context)
+		}
+		if t.Dockerfile != nil {
+			item.details = append(item.details, "Dockerfile: "+*t.Dockerfile)
+		}
+		if len(t.Platforms) > 0 {
+			item.details = append(item.details, "Platforms: "+strings.Join(t.Platforms, ", "))
+		}
+		if len(t.Tags) > 0 {
+			item.details = append(item.details, "Tags: "+strings.Join(t.Tags, ", "))
+		}
+		targets = append(targets, item)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].name < groups[j].name })
+	sort.Slice(targets, func(i, j int) bool { return targets[i].name < targets[j].name })
+	return append(groups, targets...)
+}
+
+type targetSelectModel struct {
+	items    []targetItem
+	cursor   int
+	selected map[int]bool
+	// done is set when the selection is confirmed rather than canceled.
+	done bool
+}
+
+func newTargetSelectModel(items []targetItem) targetSelectModel {
+	return targetSelectModel{items: items, selected: map[int]bool{}}
+}
+
+// Selected returns the checked items, or the item under the cursor if none
+// are checked.
+func (m targetSelectModel) Selected() []string {
+	var names []string
+	for i, item := range m.items {
+		if m.selected[i] {
+			names = append(names, item.name)
+		}
+	}
+	if len(names) == 0 && len(m.items) > 0 {
+		names = []string{m.items[m.cursor].name}
+	}
+	return names
+}
+
+func (m targetSelectModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m targetSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch key.String() {
+	case "ctrl+c", "esc", "q":
+		return m, tea.Quit
+	case "enter":
+		m.done = true
+		return m, tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.items)-1 {
+			m.cursor++
+		}
+	case " ", "x":
+		if m.selected[m.cursor] {
+			delete(m.selected, m.cursor)
+		} else {
+			m.selected[m.cursor] = true
+		}
+	case "a":
+		// Select everything, or clear the selection if everything is selected.
+		all := len(m.selected) < len(m.items)
+		m.selected = map[int]bool{}
+		if all {
+			for i := range m.items {
+				m.selected[i] = true
+			}
+		}
+	}
+	return m, nil
+}
+
+var (
+	selectCursorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+	selectHelpStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+)
+
+func (m targetSelectModel) View() string {
+	var b strings.Builder
+	b.WriteString("Choose the bake targets to build\n\n")
+
+	width := 0
+	for _, item := range m.items {
+		width = max(width, len(item.name))
+	}
+	for i, item := range m.items {
+		check := "[ ]"
+		if m.selected[i] {
+			check = "[x]"
+		}
+		kind := "target"
+		if item.group {
+			kind = "group"
+		}
+		line := fmt.Sprintf("%s %-*s  %s", check, width, item.name, kind)
+		if i == m.cursor {
+			line = selectCursorStyle.Render(line)
+		}
+		b.WriteString("  " + line + "\n")
+	}
+
+	if len(m.items) > 0 {
+		b.WriteString("\n")
+		for _, detail := range m.items[m.cursor].details {
+			b.WriteString("  " + detail + "\n")
+		}
+	}
+
+	b.WriteString("\n" + selectHelpStyle.Render("  space: select • a: select all • enter: build • q: quit") + "\n")
+	return b.String()
+}
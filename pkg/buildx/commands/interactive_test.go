@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/depot/cli/pkg/buildx/bake"
+)
+
+func TestTargetSelectModel(t *testing.T) {
+	items := bakeTargetItems(
+		map[string]*bake.Target{
+			"web": {Platforms: []string{"linux/amd64"}, Tags: []string{"example/web"}},
+			"api": {},
+		},
+		map[string]*bake.Group{"default": {Targets: []string{"api", "web"}}},
+	)
+	names := []string{}
+	for _, item := range items {
+		names = append(names, item.name)
+	}
+	if expected := []string{"default", "api", "web"}; !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+
+	var m tea.Model = newTargetSelectModel(items)
+	if got := m.(targetSelectModel).Selected(); !reflect.DeepEqual(got, []string{"default"}) {
+		t.Errorf("expected the item under the cursor, got %v", got)
+	}
+
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyDown},
+		{Type: tea.KeySpace, Runes: []rune{' '}},
+		{Type: tea.KeyDown},
+		{Type: tea.KeySpace, Runes: []rune{' '}},
+		{Type: tea.KeyEnter},
+	} {
+		m, _ = m.Update(key)
+	}
+	if got := m.(targetSelectModel).Selected(); !reflect.DeepEqual(got, []string{"api", "web"}) {
+		t.Errorf("expected api and web, got %v", got)
+	}
+	if !m.(targetSelectModel).done {
+		t.Errorf("expected enter to confirm the selection")
+	}
+}
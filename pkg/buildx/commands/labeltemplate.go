@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/depot/cli/pkg/ci"
+	"github.com/docker/buildx/build"
+	"github.com/docker/buildx/util/gitutil"
+	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
+)
+
+// labelVars are the variables of label and annotation templates such as
+// "{{.BuildID}}".
+type labelVars struct {
+	BuildID   string
+	ProjectID string
+	// Target is the bake target, or "default" for builds.
+	Target string
+	// GitSHA is the commit of the CI job, or the HEAD of the context's repository.
+	GitSHA string
+	// Timestamp is the start of the build in RFC 3339, or SOURCE_DATE_EPOCH when set.
+	Timestamp string
+}
+
+// expandLabelTemplates expands the templates in the label values and the
+// annotation attributes of the outputs of every target.
+func expandLabelTemplates(ctx context.Context, opts map[string]build.Options, buildID, projectID string) error {
+	now := time.Now().UTC()
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		if sec, err := strconv.ParseInt(epoch, 10, 64); err == nil {
+			now = time.Unix(sec, 0).UTC()
+		}
+	}
+	gitSHAs := map[string]string{}
+
+	for target, opt := range opts {
+		if !hasLabelTemplates(opt) {
+			continue
+		}
+
+		contextPath := opt.Inputs.ContextPath
+		if _, ok := gitSHAs[contextPath]; !ok {
+			gitSHAs[contextPath] = gitSHA(ctx, contextPath)
+		}
+		vars := labelVars{
+			BuildID:   buildID,
+			ProjectID: projectID,
+			Target:    target,
+			GitSHA:    gitSHAs[contextPath],
+			Timestamp: now.Format(time.RFC3339),
+		}
+
+		labels := make(map[string]string, len(opt.Labels))
+		for k, v := range opt.Labels {
+			expanded, err := expandLabelTemplate(v, vars)
+			if err != nil {
+				return errors.Wrapf(err, "invalid template in label %s", k)
+			}
+			labels[k] = expanded
+		}
+		opt.Labels = labels
+
+		opt.Exports = slices.Clone(opt.Exports)
+		for i, export := range opt.Exports {
+			attrs := make(map[string]string, len(export.Attrs))
+			for k, v := range export.Attrs {
+				if strings.HasPrefix(k, "annotation") {
+					expanded, err := expandLabelTemplate(v, vars)
+					if err != nil {
+						return errors.Wrapf(err, "invalid template in %s", k)
+					}
+					v = expanded
+				}
+				attrs[k] = v
+			}
+			opt.Exports[i].Attrs = attrs
+		}
+		opts[target] = opt
+	}
+	return nil
+}
+
+func hasLabelTemplates(opt build.Options) bool {
+	for _, v := range opt.Labels {
+		if strings.Contains(v, "{{") {
+			return true
+		}
+	}
+	for _, export := range opt.Exports {
+		for k, v := range export.Attrs {
+			if strings.HasPrefix(k, "annotation") && strings.Contains(v, "{{") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func expandLabelTemplate(value string, vars labelVars) (string, error) {
+	if !strings.Contains(value, "{{") {
+		return value, nil
+	}
+	tmpl, err := template.New("label").Option("missingkey=error").Parse(value)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// gitSHA returns the commit being built, or an empty string outside of a
+// repository.
+func gitSHA(ctx context.Context, contextPath string) string {
+	if ciContext, ok := ci.ProviderContext(); ok && ciContext.CommitSHA != "" {
+		return ciContext.CommitSHA
+	}
+
+	wd, err := filepath.Abs(contextPath)
+	if err != nil {
+		return ""
+	}
+	gitc, err := gitutil.New(gitutil.WithContext(ctx), gitutil.WithWorkingDir(wd))
+	if err != nil || !gitc.IsInsideWorkTree() {
+		return ""
+	}
+	sha, _ := gitc.FullCommit()
+	return sha
+}
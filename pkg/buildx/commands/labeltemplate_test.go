@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/buildx/build"
+	"github.com/moby/buildkit/client"
+)
+
+func TestExpandLabelTemplates(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_SHA", "abc123")
+	t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+
+	opts := map[string]build.Options{
+		"api": {
+			Labels: map[string]string{
+				"org.opencontainers.image.revision": "{{.GitSHA}}",
+				"org.opencontainers.image.created":  "{{.Timestamp}}",
+				"build":                             "{{.ProjectID}}/{{.BuildID}}/{{.Target}}",
+				"plain":                             "unchanged",
+			},
+			Exports: []client.ExportEntry{{Type: "image", Attrs: map[string]string{
+				"annotation.org.opencontainers.image.revision": "{{.GitSHA}}",
+				"name": "example/{{.Target}}",
+			}}},
+		},
+	}
+	if err := expandLabelTemplates(context.Background(), opts, "build1", "project1"); err != nil {
+		t.Fatal(err)
+	}
+
+	labels := opts["api"].Labels
+	for k, want := range map[string]string{
+		"org.opencontainers.image.revision": "abc123",
+		"org.opencontainers.image.created":  "2023-11-14T22:13:20Z",
+		"build":                             "project1/build1/api",
+		"plain":                             "unchanged",
+	} {
+		if labels[k] != want {
+			t.Errorf("label %s = %q, want %q", k, labels[k], want)
+		}
+	}
+	attrs := opts["api"].Exports[0].Attrs
+	if got := attrs["annotation.org.opencontainers.image.revision"]; got != "abc123" {
+		t.Errorf("annotation = %q, want abc123", got)
+	}
+	if got := attrs["name"]; got != "example/{{.Target}}" {
+		t.Errorf("name = %q, only annotations are expanded", got)
+	}
+
+	opts = map[string]build.Options{"default": {Labels: map[string]string{"x": "{{.Unknown}}"}}}
+	if err := expandLabelTemplates(context.Background(), opts, "build1", "project1"); err == nil {
+		t.Error("expected an error for an unknown variable")
+	}
+}
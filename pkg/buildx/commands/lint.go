@@ -86,8 +86,13 @@ type Linter struct {
 	BuildxNodes []builder.Node
 	printer     progress.Writer
 
+	// suppressions are the lint rules that are not reported.
+	suppressions *WarningSuppressions
+
 	mu     sync.Mutex
 	issues map[string][]client.VertexWarning
+	// suppressed counts the lint issues of suppressed rules.
+	suppressed int
 }
 
 func NewLinter(printer progress.Writer, failureMode LintFailure, clients []*client.Client, nodes []builder.Node) *Linter {
@@ -146,35 +151,21 @@ func (l *Linter) Handle(ctx context.Context, target string, driverIndex int, doc
 	tm := time.Now()
 	progresshelper.WriteLint(l.printer, client.Vertex{Digest: dgst, Name: lintName, Started: &tm}, nil, nil)
 
-	output, err := RunHadolint(ctx, l.Clients[driverIndex], l.BuildxNodes[driverIndex].Platforms[0], dockerfile)
+	lints, err := RunLinters(ctx, l.Clients[driverIndex], l.BuildxNodes[driverIndex].Platforms[0], dockerfile)
 	if err != nil {
 		if l.FailureMode != LintNone {
 			return err
 		}
 	}
-	lints := UnmarshalHadolints(&output)
-
-	output, err = RunSemgrep(ctx, l.Clients[driverIndex], l.BuildxNodes[driverIndex].Platforms[0], dockerfile)
-	if err != nil {
-		if l.FailureMode != LintNone {
-			return err
-		}
-	}
-	semgrepLints := UnmarshalSemgreps(&output)
-	for _, semgrepLint := range semgrepLints {
-		duplicate := false
-		for i, hadoLint := range lints {
-			if semgrepLint.Line == hadoLint.Line && semgrepLint.SourceRuleURL == hadoLint.SourceRuleURL {
-				// Prefer the semgrep message.  It has a lot of great information
-				lints[i] = semgrepLint
-				duplicate = true
-				break
-			}
-		}
 
-		if !duplicate {
-			lints = append(lints, semgrepLint)
+	suppressed := 0
+	for i := 0; i < len(lints); {
+		if l.suppressions.Suppressed(lints[i].Code) {
+			lints = append(lints[:i], lints[i+1:]...)
+			suppressed++
+			continue
 		}
+		i++
 	}
 
 	var (
@@ -286,10 +277,42 @@ func (l *Linter) Handle(ctx context.Context, target string, driverIndex int, doc
 		l.issues = make(map[string][]client.VertexWarning)
 	}
 	l.issues[target] = warnings
+	l.suppressed += suppressed
 
 	return lintErr
 }
 
+// RunLinters runs hadolint and semgrep on the Dockerfile and returns their
+// issues.  Issues found by both are reported once with the semgrep message.
+// Both linters run even if one fails, and the last error is returned.
+func RunLinters(ctx context.Context, c *client.Client, platform ocispecs.Platform, dockerfile *build.DockerfileInputs) ([]Lint, error) {
+	output, hadolintErr := RunHadolint(ctx, c, platform, dockerfile)
+	lints := UnmarshalHadolints(&output)
+
+	output, err := RunSemgrep(ctx, c, platform, dockerfile)
+	semgrepLints := UnmarshalSemgreps(&output)
+	for _, semgrepLint := range semgrepLints {
+		duplicate := false
+		for i, hadoLint := range lints {
+			if semgrepLint.Line == hadoLint.Line && semgrepLint.SourceRuleURL == hadoLint.SourceRuleURL {
+				// Prefer the semgrep message.  It has a lot of great information
+				lints[i] = semgrepLint
+				duplicate = true
+				break
+			}
+		}
+
+		if !duplicate {
+			lints = append(lints, semgrepLint)
+		}
+	}
+
+	if err == nil {
+		err = hadolintErr
+	}
+	return lints, err
+}
+
 func RunImage(ctx context.Context, imageName string, args []string, c *client.Client, platform ocispecs.Platform, dockerfile *build.DockerfileInputs) (CaptureOutput, error) {
 	output := CaptureOutput{}
 	_, err := c.Build(ctx, client.SolveOpt{}, "buildx", func(ctx context.Context, c gateway.Client) (*gateway.Result, error) {
@@ -392,6 +415,7 @@ func UnmarshalHadolints(output *CaptureOutput) []Lint {
 			lints[i].URL = fmt.Sprintf("https://github.com/hadolint/hadolint/wiki/%s", lints[i].Code)
 			// SourceRuleURL is used to deduplicate hadolint and semgrep lint issues.
 			lints[i].SourceRuleURL = lints[i].URL
+			lints[i].Source = "hadolint"
 		}
 
 		allLints = append(allLints, lints...)
@@ -407,6 +431,8 @@ type Lint struct {
 	// SourceRuleURL is used to deduplicate hadolint and semgrep issues as the
 	// semgrep SourceRuleURL is the same as the hadolint URL field.
 	SourceRuleURL string `json:"-"`
+	// Source is the linter that reported the issue, "hadolint" or "semgrep".
+	Source string `json:"-"`
 
 	Column int    `json:"column"`
 	File   string `json:"file"`
@@ -480,6 +506,7 @@ func UnmarshalSemgreps(output *CaptureOutput) []Lint {
 				Code:          result.Extra.Metadata.SemgrepDev.Rule.RuleID,
 				URL:           result.Extra.Metadata.Source,
 				SourceRuleURL: result.Extra.Metadata.SourceRuleURL,
+				Source:        "semgrep",
 				Column:        result.Start.Col,
 				File:          result.Path,
 				Level:         result.Extra.Severity,
@@ -533,6 +560,20 @@ type Results struct {
 	Path  string   `json:"path"`
 }
 
+// Issues returns the lint issues found for the target.
+func (l *Linter) Issues(target string) []client.VertexWarning {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.issues[target]
+}
+
+// Suppressed returns the number of lint issues of suppressed rules.
+func (l *Linter) Suppressed() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.suppressed
+}
+
 func (l *Linter) Print(w io.Writer, mode string) {
 	// Copied from printWarnings with a few modifications for errors.
 	if l.FailureMode == LintSkip {
@@ -557,7 +598,7 @@ func (l *Linter) Print(w io.Writer, mode string) {
 		summary = fmt.Sprintf("%d linter issues found", numIssues)
 	}
 
-	if mode != progress.PrinterModePlain {
+	if progresshelper.UseColor(mode) {
 		summary = l.FailureMode.Color().Apply(summary)
 	}
 	fmt.Fprintf(w, "%s:\n", summary)
@@ -572,7 +613,7 @@ func (l *Linter) Print(w io.Writer, mode string) {
 		for _, issue := range issues {
 			lintLevel := LintLevel(issue.Level)
 			level := lintLevel.String()
-			if mode != progress.PrinterModePlain {
+			if progresshelper.UseColor(mode) {
 				level = lintLevel.Color().Apply(level)
 			}
 
@@ -637,7 +678,7 @@ func PrintFileContext(w io.Writer, issue *client.VertexWarning, lintColor LintLe
 		pfx := "   "
 		if containsLine(issue.Range, i) {
 			pfx = ">>>"
-			if progressMode != progress.PrinterModePlain {
+			if progresshelper.UseColor(progressMode) {
 				pfx = lintColor.Color().Apply(pfx)
 			}
 		}
@@ -0,0 +1,549 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/containerd/containerd/platforms"
+	depotbuildxbuild "github.com/depot/cli/pkg/buildx/build"
+	"github.com/depot/cli/pkg/buildx/builder"
+	"github.com/depot/cli/pkg/dockerclient"
+	"github.com/depot/cli/pkg/helpers"
+	"github.com/depot/cli/pkg/progresshelper"
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+	"github.com/docker/buildx/util/progress"
+	"github.com/docker/cli/cli/command"
+	"github.com/fsnotify/fsnotify"
+	"github.com/moby/buildkit/client"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// lintDebounce is how long --watch waits for more changes after a Dockerfile
+// changes, as editors often write a file more than once when saving it.
+const lintDebounce = 150 * time.Millisecond
+
+type lintOptions struct {
+	project       string
+	token         string
+	buildPlatform string
+	localBuildkit string
+	lintFailOn    string
+	warningsFile  string
+	format        string
+	progress      string
+	watch         bool
+}
+
+// LintCmd lints Dockerfiles with hadolint and semgrep without building them.
+// With --watch the Dockerfiles are linted again each time they are saved on
+// the same builder, so only the first run waits for a machine.
+func LintCmd() *cobra.Command {
+	var options lintOptions
+
+	cmd := &cobra.Command{
+		Use:   "lint [OPTIONS] [DOCKERFILE...]",
+		Short: "Lint Dockerfiles without building",
+		Long: `Lint Dockerfiles with hadolint and semgrep, the linters of "depot build --lint",
+without building them.  The Dockerfile of the current directory is linted
+if none is given.
+
+With --watch, the Dockerfiles are linted again each time they are saved,
+printing the issues that were added and fixed.  --format json prints the
+diagnostics of every run as a line of JSON instead.`,
+		Example: `  # Lint the Dockerfile of the current directory
+  depot lint
+
+  # Lint on every save, reusing a local buildkitd
+  depot lint --watch --local-buildkit`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				args = []string{"Dockerfile"}
+			}
+			return runLint(cmd.Context(), options, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&options.watch, "watch", false, "Lint the Dockerfiles again each time they are saved")
+	flags.StringVar(&options.format, "format", "text", `Format of the lint issues ("text", "json")`)
+	flags.StringVar(&options.lintFailOn, "lint-fail-on", "error", `controls lint severity that fails the command ("info", "warn", "error", "none")`)
+	flags.StringVar(&options.warningsFile, "warnings-file", "", `File of lint rules to suppress (default ".depot/warnings.yaml")`)
+	flags.StringVar(&options.localBuildkit, "local-buildkit", "", `Lint on a local buildkitd instead of a Depot machine, in a privileged container without a value, or at --local-buildkit=ADDR (e.g., "tcp://localhost:1234")`)
+	flags.Lookup("local-buildkit").NoOptDefVal = "auto"
+	flags.StringVar(&options.buildPlatform, "build-platform", "", `Run the linters on this platform ("linux/amd64", "linux/arm64")`)
+	flags.StringVar(&options.project, "project", "", "Depot project ID")
+	flags.StringVar(&options.token, "token", "", "Depot token")
+	flags.StringVar(&options.progress, "progress", "auto", `Set type of progress output ("auto", "plain", "tty", "quiet")`)
+
+	return cmd
+}
+
+func runLint(ctx context.Context, options lintOptions, files []string) (err error) {
+	if options.format != "text" && options.format != "json" {
+		return errors.Errorf("unknown format: %s. Requires text or json", options.format)
+	}
+	suppressions, err := loadWarningSuppressions(options.warningsFile, time.Now())
+	if err != nil {
+		return err
+	}
+
+	c, platform, finish, err := connectLinters(ctx, options, files)
+	if err != nil {
+		return err
+	}
+	defer func() { finish(err) }()
+
+	runner := &lintRunner{
+		client:       c,
+		platform:     platform,
+		failureMode:  NewLintFailureMode(true, options.lintFailOn),
+		suppressions: suppressions,
+		format:       options.format,
+		out:          os.Stdout,
+		files:        map[string]*lintedFile{},
+	}
+
+	failed := false
+	for _, file := range files {
+		exceeded, err := runner.lint(ctx, file)
+		if err != nil {
+			return err
+		}
+		failed = failed || exceeded
+	}
+
+	if options.watch {
+		return runner.watch(ctx, files)
+	}
+	if failed {
+		return LintFailed
+	}
+	return nil
+}
+
+// connectLinters boots the builder that runs the linters, a Depot machine or
+// the --local-buildkit.  finish ends the Depot build of the machine.
+func connectLinters(ctx context.Context, options lintOptions, files []string) (*client.Client, ocispecs.Platform, func(error), error) {
+	platform := ocispecs.Platform{}
+	finish := func(error) {}
+
+	dockerCli, err := dockerclient.NewDockerCLI()
+	if err != nil {
+		return nil, platform, finish, err
+	}
+
+	var builderOpts []builder.Option
+	if options.localBuildkit != "" {
+		endpoint, err := localBuildkitEndpoint(ctx, dockerCli, options.localBuildkit)
+		if err != nil {
+			return nil, platform, finish, err
+		}
+		builderOpts = []builder.Option{builder.WithLocalBuildkit(endpoint)}
+	} else {
+		token, err := helpers.ResolveToken(ctx, options.token)
+		if err != nil {
+			return nil, platform, finish, err
+		}
+		if token == "" {
+			return nil, platform, finish, fmt.Errorf("missing API token, please run `depot login`")
+		}
+		projectID := helpers.ResolveProjectID(options.project, files...)
+		if projectID == "" {
+			return nil, platform, finish, errors.Errorf("unknown project ID (run `depot init` or use --project or $DEPOT_PROJECT_ID)")
+		}
+		buildPlatform, err := resolveLintBuildPlatform(options.buildPlatform)
+		if err != nil {
+			return nil, platform, finish, err
+		}
+
+		req := &cliv1.CreateBuildRequest{
+			ProjectId: &projectID,
+			Options:   []*cliv1.BuildOptions{{Command: cliv1.Command_COMMAND_BUILD, Lint: true}},
+		}
+		build, err := helpers.BeginBuild(ctx, req, token)
+		if err != nil {
+			return nil, platform, finish, fmt.Errorf("unable to begin build: %w", err)
+		}
+		finish = build.Finish
+		builderOpts = []builder.Option{builder.WithDepotOptions(buildPlatform, build)}
+	}
+
+	c, platform, err := bootLintBuilder(ctx, dockerCli, builderOpts, options.progress)
+	if err != nil {
+		finish(err)
+		return nil, platform, func(error) {}, err
+	}
+	return c, platform, finish, nil
+}
+
+func bootLintBuilder(ctx context.Context, dockerCli command.Cli, builderOpts []builder.Option, progressMode string) (*client.Client, ocispecs.Platform, error) {
+	platform := platforms.Normalize(platforms.DefaultSpec())
+	platform.OS = "linux"
+
+	b, err := builder.New(dockerCli, builderOpts...)
+	if err != nil {
+		return nil, platform, err
+	}
+	nodes, err := b.LoadNodes(ctx, false)
+	if err != nil {
+		return nil, platform, err
+	}
+	buildxNodes, err := depotbuildxbuild.FilterAvailableNodes(builder.ToBuildxNodes(nodes))
+	if err != nil {
+		return nil, platform, err
+	}
+	// A single node runs every linter.
+	buildxNodes = buildxNodes[:1]
+
+	printer, err := progress.NewPrinter(ctx, os.Stderr, os.Stderr, progresshelper.ResolveMode(progressMode))
+	if err != nil {
+		return nil, platform, err
+	}
+	_, clients, err := depotbuildxbuild.ResolveDrivers(ctx, buildxNodes, map[string]depotbuildxbuild.Options{defaultTargetName: {}}, printer)
+	if waitErr := printer.Wait(); err == nil {
+		err = waitErr
+	}
+	if err != nil {
+		return nil, platform, err
+	}
+	if len(clients) == 0 || clients[0] == nil {
+		return nil, platform, errors.New("no builder is available to run the linters")
+	}
+
+	if len(buildxNodes[0].Platforms) > 0 {
+		platform = buildxNodes[0].Platforms[0]
+	}
+	return clients[0], platform, nil
+}
+
+// resolveLintBuildPlatform picks the machine that runs the linters.  Unlike a
+// build, a lint never needs more than one machine.
+func resolveLintBuildPlatform(buildPlatform string) (string, error) {
+	buildPlatform, err := helpers.ResolveBuildPlatform(buildPlatform)
+	if err != nil {
+		return "", err
+	}
+	if buildPlatform != "dynamic" {
+		return buildPlatform, nil
+	}
+	if strings.HasPrefix(runtime.GOARCH, "arm") {
+		return "linux/arm64", nil
+	}
+	return "linux/amd64", nil
+}
+
+type lintRunner struct {
+	client       *client.Client
+	platform     ocispecs.Platform
+	failureMode  LintFailure
+	suppressions *WarningSuppressions
+	format       string
+	out          io.Writer
+
+	files map[string]*lintedFile
+}
+
+// lintedFile is the last lint of a Dockerfile.
+type lintedFile struct {
+	content []byte
+	lints   []Lint
+	version int
+}
+
+// lint lints the Dockerfile and prints its issues.  It reports whether an
+// issue is as severe as --lint-fail-on.
+func (r *lintRunner) lint(ctx context.Context, file string) (bool, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to read Dockerfile %s", file)
+	}
+	last := r.files[file]
+	if last != nil && string(last.content) == string(content) {
+		return false, nil
+	}
+
+	lints, err := r.run(ctx, file, content)
+	if err != nil {
+		return false, err
+	}
+
+	linted := &lintedFile{content: content, lints: lints, version: 1}
+	if last != nil {
+		linted.version = last.version + 1
+	}
+	r.files[file] = linted
+
+	if r.format == "json" {
+		if err := json.NewEncoder(r.out).Encode(newLintDiagnostics(file, linted)); err != nil {
+			return false, err
+		}
+	} else {
+		r.printChanges(file, last, linted)
+	}
+
+	return r.exceeds(lints), nil
+}
+
+// run lints the content of the Dockerfile, leaving out suppressed issues.
+func (r *lintRunner) run(ctx context.Context, file string, content []byte) ([]Lint, error) {
+	dockerfile := &depotbuildxbuild.DockerfileInputs{Filename: filepath.Base(file), Content: content}
+	lints, err := RunLinters(ctx, r.client, r.platform, dockerfile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to lint %s", file)
+	}
+	kept := lints[:0]
+	for _, lint := range lints {
+		if !r.suppressions.Suppressed(lint.Code) {
+			kept = append(kept, lint)
+		}
+	}
+	return kept, nil
+}
+
+// exceeds returns whether an issue is as severe as --lint-fail-on.
+func (r *lintRunner) exceeds(lints []Lint) bool {
+	for _, lint := range lints {
+		if r.failureMode != LintNone && int(lint.LintLevel) <= int(r.failureMode) {
+			return true
+		}
+	}
+	return false
+}
+
+// printChanges prints every issue of the first lint of a Dockerfile, and then
+// only the issues that were added (+) and fixed (-) since the last lint.
+func (r *lintRunner) printChanges(file string, last, linted *lintedFile) {
+	summary := fmt.Sprintf("%s: %d linter issues", file, len(linted.lints))
+	if last == nil {
+		fmt.Fprintln(r.out, summary)
+		for _, lint := range linted.lints {
+			fmt.Fprintf(r.out, "  %s\n", formatLint(file, lint))
+		}
+		return
+	}
+
+	added, fixed := diffLints(last, linted)
+	fmt.Fprintf(r.out, "[%s] %s (%d new, %d fixed)\n", time.Now().Format(time.TimeOnly), summary, len(added), len(fixed))
+	for _, lint := range added {
+		fmt.Fprintf(r.out, "+ %s\n", formatLint(file, lint))
+	}
+	for _, lint := range fixed {
+		fmt.Fprintf(r.out, "- %s\n", formatLint(file, lint))
+	}
+}
+
+func formatLint(file string, lint Lint) string {
+	return fmt.Sprintf("%s %s:%d %s: %s", lint.LintLevel, file, lint.Line, lint.Code, lint.Message)
+}
+
+// diffLints returns the issues of linted that last did not have and the issues
+// of last that linted no longer has.  Issues are matched by their rule and the
+// text of their line, so that editing other lines does not report them again.
+func diffLints(last, linted *lintedFile) (added, fixed []Lint) {
+	lastKeys := map[string]int{}
+	for _, lint := range last.lints {
+		lastKeys[lintKey(last.content, lint)]++
+	}
+	for _, lint := range linted.lints {
+		key := lintKey(linted.content, lint)
+		if lastKeys[key] > 0 {
+			lastKeys[key]--
+			continue
+		}
+		added = append(added, lint)
+	}
+
+	lintedKeys := map[string]int{}
+	for _, lint := range linted.lints {
+		lintedKeys[lintKey(linted.content, lint)]++
+	}
+	for _, lint := range last.lints {
+		key := lintKey(last.content, lint)
+		if lintedKeys[key] > 0 {
+			lintedKeys[key]--
+			continue
+		}
+		fixed = append(fixed, lint)
+	}
+	return added, fixed
+}
+
+func lintKey(content []byte, lint Lint) string {
+	return lint.Code + "\x00" + strings.TrimSpace(dockerfileLine(content, lint.Line))
+}
+
+func dockerfileLine(content []byte, line int) string {
+	lines := strings.Split(string(content), "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	return lines[line-1]
+}
+
+// watch lints the Dockerfiles again when they are saved until interrupted.
+// The directories are watched rather than the files as editors often save by
+// replacing the file.
+func (r *lintRunner) watch(ctx context.Context, files []string) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "unable to watch Dockerfiles")
+	}
+	defer func() { _ = watcher.Close() }()
+
+	watched := map[string]string{}
+	dirs := map[string]bool{}
+	for _, file := range files {
+		abs, err := filepath.Abs(file)
+		if err != nil {
+			return err
+		}
+		watched[abs] = file
+		dir := filepath.Dir(abs)
+		if dirs[dir] {
+			continue
+		}
+		if err := watcher.Add(dir); err != nil {
+			return errors.Wrapf(err, "unable to watch %s", dir)
+		}
+		dirs[dir] = true
+	}
+	fmt.Fprintf(os.Stderr, "Watching %s for changes, press Ctrl+C to stop\n", strings.Join(files, ", "))
+
+	var (
+		pending  = map[string]bool{}
+		debounce <-chan time.Time
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			return errors.Wrap(err, "unable to watch Dockerfiles")
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			file, ok := watched[filepath.Clean(event.Name)]
+			if !ok || !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
+				continue
+			}
+			pending[file] = true
+			debounce = time.After(lintDebounce)
+		case <-debounce:
+			changed := make([]string, 0, len(pending))
+			for file := range pending {
+				changed = append(changed, file)
+			}
+			sort.Strings(changed)
+			pending = map[string]bool{}
+
+			for _, file := range changed {
+				// A failed lint, such as of a file that is being replaced,
+				// waits for the next save.
+				if _, err := r.lint(ctx, file); err != nil && ctx.Err() == nil {
+					fmt.Fprintf(os.Stderr, "%v\n", err)
+				}
+			}
+		}
+	}
+}
+
+// lintDiagnostics is the JSON of a lint, shaped like the parameters of a
+// textDocument/publishDiagnostics notification of the language server
+// protocol so that editors can show the issues.  Lines and characters are
+// zero-based.
+type lintDiagnostics struct {
+	URI         string           `json:"uri"`
+	Version     int              `json:"version"`
+	Diagnostics []lintDiagnostic `json:"diagnostics"`
+}
+
+type lintDiagnostic struct {
+	Range           lintRange            `json:"range"`
+	Severity        int                  `json:"severity"`
+	Code            string               `json:"code"`
+	CodeDescription *lintCodeDescription `json:"codeDescription,omitempty"`
+	Source          string               `json:"source"`
+	Message         string               `json:"message"`
+}
+
+type lintRange struct {
+	Start lintPosition `json:"start"`
+	End   lintPosition `json:"end"`
+}
+
+type lintPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lintCodeDescription struct {
+	Href string `json:"href"`
+}
+
+func newLintDiagnostics(file string, linted *lintedFile) lintDiagnostics {
+	uri := file
+	if abs, err := filepath.Abs(file); err == nil {
+		uri = "file://" + filepath.ToSlash(abs)
+	}
+
+	diagnostics := lintDiagnostics{URI: uri, Version: linted.version, Diagnostics: []lintDiagnostic{}}
+	for _, lint := range linted.lints {
+		line := lint.Line - 1
+		if line < 0 {
+			line = 0
+		}
+		character := lint.Column - 1
+		if character < 0 {
+			character = 0
+		}
+
+		diagnostic := lintDiagnostic{
+			Range: lintRange{
+				Start: lintPosition{Line: line, Character: character},
+				End:   lintPosition{Line: line, Character: len(dockerfileLine(linted.content, lint.Line))},
+			},
+			Severity: lintSeverity(lint.LintLevel),
+			Code:     lint.Code,
+			Source:   lint.Source,
+			Message:  lint.Message,
+		}
+		if lint.URL != "" {
+			diagnostic.CodeDescription = &lintCodeDescription{Href: lint.URL}
+		}
+		diagnostics.Diagnostics = append(diagnostics.Diagnostics, diagnostic)
+	}
+	return diagnostics
+}
+
+// lintSeverity is the language server protocol DiagnosticSeverity of a level.
+func lintSeverity(level LintLevel) int {
+	switch level {
+	case LintLevelError:
+		return 1
+	case LintLevelWarn:
+		return 2
+	case LintLevelInfo:
+		return 3
+	default:
+		return 4
+	}
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"testing"
+)
+
+func TestDiffLints(t *testing.T) {
+	last := &lintedFile{
+		content: []byte("FROM ubuntu\nRUN apt-get install curl\nRUN cd /app\n"),
+		lints: []Lint{
+			{Code: "DL3008", Line: 2},
+			{Code: "DL3003", Line: 3},
+		},
+	}
+	// A line was added at the top, the cd was fixed, and the FROM is unpinned.
+	linted := &lintedFile{
+		content: []byte("# syntax=docker/dockerfile:1\nFROM ubuntu\nRUN apt-get install curl\nWORKDIR /app\n"),
+		lints: []Lint{
+			{Code: "DL3007", Line: 2},
+			{Code: "DL3008", Line: 3},
+		},
+	}
+
+	added, fixed := diffLints(last, linted)
+	if len(added) != 1 || added[0].Code != "DL3007" {
+		t.Errorf("added = %+v, want DL3007", added)
+	}
+	if len(fixed) != 1 || fixed[0].Code != "DL3003" {
+		t.Errorf("fixed = %+v, want DL3003", fixed)
+	}
+}
+
+func TestNewLintDiagnostics(t *testing.T) {
+	linted := &lintedFile{
+		content: []byte("FROM ubuntu\nRUN apt-get install curl\n"),
+		lints: []Lint{
+			{Code: "DL3008", Source: "hadolint", Line: 2, Column: 1, LintLevel: LintLevelWarn, Message: "Pin versions", URL: "https://github.com/hadolint/hadolint/wiki/DL3008"},
+		},
+		version: 3,
+	}
+
+	diagnostics := newLintDiagnostics("Dockerfile", linted)
+	if diagnostics.Version != 3 || len(diagnostics.Diagnostics) != 1 {
+		t.Fatalf("diagnostics = %+v", diagnostics)
+	}
+	diagnostic := diagnostics.Diagnostics[0]
+	want := lintRange{Start: lintPosition{Line: 1, Character: 0}, End: lintPosition{Line: 1, Character: 24}}
+	if diagnostic.Range != want {
+		t.Errorf("range = %+v, want %+v", diagnostic.Range, want)
+	}
+	if diagnostic.Severity != 2 || diagnostic.Source != "hadolint" || diagnostic.CodeDescription == nil {
+		t.Errorf("diagnostic = %+v", diagnostic)
+	}
+}
@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// lintResult is the JSON result of "depot build --lint --no-build".
+type lintResult struct {
+	Files  []lintResultFile `json:"files"`
+	Counts lintCounts       `json:"counts"`
+	FailOn string           `json:"failOn"`
+	// Failed is set when an issue is as severe as --lint-fail-on, which is
+	// also when the command exits with an error.
+	Failed bool `json:"failed"`
+}
+
+type lintResultFile struct {
+	File   string     `json:"file"`
+	Lints  []Lint     `json:"lints"`
+	Counts lintCounts `json:"counts"`
+}
+
+// lintCounts counts the issues by severity.
+type lintCounts struct {
+	Error   int `json:"error"`
+	Warning int `json:"warning"`
+	Info    int `json:"info"`
+}
+
+func (c *lintCounts) add(lints []Lint) {
+	for _, lint := range lints {
+		switch lint.LintLevel {
+		case LintLevelError:
+			c.Error++
+		case LintLevelWarn:
+			c.Warning++
+		default:
+			c.Info++
+		}
+	}
+}
+
+// runLintOnly runs the lint phase of a build without building: it lints the
+// Dockerfiles on a single machine, or on the --local-buildkit, prints the
+// issues as JSON, and fails with LintFailed per --lint-fail-on.
+func runLintOnly(ctx context.Context, in *buildOptions) (err error) {
+	if !in.lint {
+		return errors.New("--no-build requires --lint")
+	}
+	files, err := lintOnlyDockerfiles(in)
+	if err != nil {
+		return err
+	}
+	if err := loadWarningsFile(&in.DepotOptions); err != nil {
+		return err
+	}
+
+	options := lintOptions{
+		project:       in.project,
+		token:         in.token,
+		buildPlatform: in.buildPlatform,
+		localBuildkit: in.localBuildkit,
+		progress:      in.progress,
+	}
+	c, platform, finish, err := connectLinters(ctx, options, files)
+	if err != nil {
+		return err
+	}
+	defer func() { finish(err) }()
+
+	runner := &lintRunner{
+		client:       c,
+		platform:     platform,
+		failureMode:  NewLintFailureMode(true, in.lintFailOn),
+		suppressions: in.warnings,
+	}
+	result := lintResult{Files: []lintResultFile{}, FailOn: strings.ToLower(in.lintFailOn)}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return errors.Wrapf(err, "unable to read Dockerfile %s", file)
+		}
+		lints, err := runner.run(ctx, file, content)
+		if err != nil {
+			return err
+		}
+		result.add(file, lints, runner.exceeds(lints))
+	}
+
+	if err := result.write(os.Stdout); err != nil {
+		return err
+	}
+	if result.Failed {
+		return LintFailed
+	}
+	return nil
+}
+
+func (r *lintResult) add(file string, lints []Lint, exceeded bool) {
+	if lints == nil {
+		lints = []Lint{}
+	}
+	linted := lintResultFile{File: file, Lints: lints}
+	linted.Counts.add(lints)
+	r.Files = append(r.Files, linted)
+	r.Counts.add(lints)
+	r.Failed = r.Failed || exceeded
+}
+
+func (r *lintResult) write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// lintOnlyDockerfiles returns the Dockerfiles of --file, or else the
+// Dockerfile of the context, which must be local.
+func lintOnlyDockerfiles(in *buildOptions) ([]string, error) {
+	if len(in.dockerfileNames) == 0 {
+		if fi, err := os.Stat(in.contextPath); err != nil || !fi.IsDir() {
+			return nil, errors.Errorf("--no-build lints local Dockerfiles: %s is not a local directory, use --file", in.contextPath)
+		}
+		return []string{filepath.Join(in.contextPath, "Dockerfile")}, nil
+	}
+	for _, file := range in.dockerfileNames {
+		if file == "-" || strings.Contains(file, "://") {
+			return nil, errors.Errorf("--no-build lints local Dockerfiles, not %s", file)
+		}
+	}
+	return in.dockerfileNames, nil
+}
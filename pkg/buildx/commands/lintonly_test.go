@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestLintResult(t *testing.T) {
+	runner := &lintRunner{failureMode: NewLintFailureMode(true, "warn")}
+	info := []Lint{{Code: "DL3059", Level: "info", LintLevel: LintLevelInfo, Line: 3}}
+	issues := []Lint{
+		{Code: "DL3006", Level: "warning", LintLevel: LintLevelWarn, Line: 1},
+		{Code: "DL3000", Level: "error", LintLevel: LintLevelError, Line: 2},
+	}
+
+	result := lintResult{Files: []lintResultFile{}, FailOn: "warn"}
+	result.add("Dockerfile", info, runner.exceeds(info))
+	if result.Failed {
+		t.Error("expected info issues not to fail with --lint-fail-on warn")
+	}
+	result.add("api.Dockerfile", issues, runner.exceeds(issues))
+	result.add("web.Dockerfile", nil, runner.exceeds(nil))
+	if !result.Failed {
+		t.Error("expected a warning to fail with --lint-fail-on warn")
+	}
+
+	var buf bytes.Buffer
+	if err := result.write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Files []struct {
+			File   string           `json:"file"`
+			Lints  []map[string]any `json:"lints"`
+			Counts map[string]int   `json:"counts"`
+		} `json:"files"`
+		Counts map[string]int `json:"counts"`
+		Failed bool           `json:"failed"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Files) != 3 || got.Files[2].Lints == nil || len(got.Files[2].Lints) != 0 {
+		t.Fatalf("unexpected files %+v", got.Files)
+	}
+	if got.Files[1].Counts["error"] != 1 || got.Files[1].Counts["warning"] != 1 {
+		t.Errorf("unexpected counts of api.Dockerfile %v", got.Files[1].Counts)
+	}
+	want := map[string]int{"error": 1, "warning": 1, "info": 1}
+	for severity, n := range want {
+		if got.Counts[severity] != n {
+			t.Errorf("expected %d %s issues, got %v", n, severity, got.Counts)
+		}
+	}
+}
+
+func TestLintOnlyDockerfiles(t *testing.T) {
+	dir := t.TempDir()
+	files, err := lintOnlyDockerfiles(&buildOptions{contextPath: dir})
+	if err != nil || len(files) != 1 || files[0] != filepath.Join(dir, "Dockerfile") {
+		t.Errorf("expected the Dockerfile of the context, got %v, %v", files, err)
+	}
+	if _, err := lintOnlyDockerfiles(&buildOptions{contextPath: "https://github.com/depot/cli.git"}); err == nil {
+		t.Error("expected a remote context without --file to fail")
+	}
+	if _, err := lintOnlyDockerfiles(&buildOptions{contextPath: dir, dockerfileNames: []string{"-"}}); err == nil {
+		t.Error("expected a Dockerfile from stdin to fail")
+	}
+}
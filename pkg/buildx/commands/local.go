@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	depotbuild "github.com/depot/cli/pkg/build"
+	"github.com/depot/cli/pkg/buildx/builder"
+	"github.com/depot/cli/pkg/helpers"
+	"github.com/depot/cli/pkg/load"
+	"github.com/depot/cli/pkg/notify"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// localBuildkitContainer is the container started by --local-buildkit
+	// when no endpoint is given.
+	localBuildkitContainer = "depot-local-buildkit"
+	localBuildkitImage     = "moby/buildkit:buildx-stable-1"
+)
+
+// runLocalBuild runs the build on a local buildkitd rather than on a Depot
+// machine.  No build is registered with the API, so --save is unavailable
+// and --load exports to the local Docker daemon directly.
+func runLocalBuild(cmd *cobra.Command, dockerCli command.Cli, options buildOptions) error {
+	if options.save {
+		return errors.New("--save is not supported with --local-buildkit")
+	}
+	if options.loadCluster != "" {
+		return errors.New("--load-cluster is not supported with --local-buildkit")
+	}
+	if err := validateBaseImageAge(&options.DepotOptions); err != nil {
+		return err
+	}
+	if err := validatePolicyFile(options.policyFile); err != nil {
+		return err
+	}
+	if options.runLimits() != nil {
+		// Stock BuildKit ignores the depot.run-memory and depot.run-cpu-shares frontend options.
+		return errors.New("--run-memory and --run-cpu-shares are not supported with --local-buildkit")
+	}
+	if options.cacheMountPolicy != "" {
+		// Stock BuildKit ignores the depot.cache-mount-sharing frontend option.
+		return errors.New("--cache-mount-policy is not supported with --local-buildkit, set sharing= on the cache mounts instead")
+	}
+	if err := validateAttestationBundle(&options.DepotOptions); err != nil {
+		return err
+	}
+	if err := loadWarningsFile(&options.DepotOptions); err != nil {
+		return err
+	}
+	if err := loadRegistryAuth(&options.DepotOptions); err != nil {
+		return err
+	}
+	if err := loadContextIgnores(&options.DepotOptions); err != nil {
+		return err
+	}
+	if err := validateContextIgnoreNames(options.contextIgnores, options.contexts); err != nil {
+		return err
+	}
+	cmd.Flags().VisitAll(checkWarnedFlags)
+
+	validatedOpts, err := validateBuildOptions(&options)
+	if err != nil {
+		return err
+	}
+	if options.locked {
+		if err := applyLockfile(validatedOpts, options.lockfile); err != nil {
+			return err
+		}
+	}
+	if options.exportLoad {
+		validatedOpts = load.WithDockerLoad(validatedOpts)
+		options.exportLoad = false
+	}
+
+	endpoint, err := localBuildkitEndpoint(cmd.Context(), dockerCli, options.localBuildkit)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Building on local buildkitd %s\n", endpoint)
+
+	options.builderOptions = []builder.Option{builder.WithLocalBuildkit(endpoint)}
+	options.build = &depotbuild.Build{}
+	options.notification = notify.New(options.notifyOptions(), "", options.project, "")
+
+	if options.allowNoOutput {
+		_ = os.Setenv("BUILDX_NO_DEFAULT_LOAD", "1")
+	}
+
+	err = runBuild(dockerCli, validatedOpts, options)
+	options.notification.Send(err)
+	return rewriteFriendlyErrors(err)
+}
+
+// expandLocalPlatforms replaces "--platform all" with the platforms of the
+// project for a local build, which needs a token and a project only then.
+func expandLocalPlatforms(ctx context.Context, options *buildOptions) error {
+	projectPlatforms := false
+	for _, value := range options.platforms {
+		for _, platform := range strings.Split(value, ",") {
+			projectPlatforms = projectPlatforms || helpers.IsProjectPlatforms(strings.TrimSpace(platform))
+		}
+	}
+	if !projectPlatforms {
+		return nil
+	}
+
+	token, err := helpers.ResolveToken(ctx, options.token)
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		return errors.New("missing API token for the platforms of the project, please run `depot login`")
+	}
+	options.project = helpers.ResolveProjectID(options.project, options.contextPath, options.dockerfileName)
+	options.platforms, err = helpers.ExpandProjectPlatforms(ctx, token, options.project, options.platforms)
+	return err
+}
+
+// localBuildkitEndpoint resolves the --local-buildkit endpoint.  A
+// docker-container:// endpoint names a buildkitd container that is created
+// and started when needed; "auto" uses the depot-local-buildkit container.
+func localBuildkitEndpoint(ctx context.Context, dockerCli command.Cli, value string) (string, error) {
+	if value == "auto" {
+		value = "docker-container://" + localBuildkitContainer
+	}
+
+	name, ok := strings.CutPrefix(value, "docker-container://")
+	if !ok {
+		return value, nil
+	}
+	if name == "" {
+		return "", errors.Errorf("invalid --local-buildkit endpoint %q", value)
+	}
+
+	if err := ensureBuildkitContainer(ctx, dockerCli, name); err != nil {
+		return "", errors.Wrapf(err, "unable to start buildkitd container %s", name)
+	}
+	return value, nil
+}
+
+// ensureBuildkitContainer starts the named buildkitd container, creating it
+// from the buildkit image if it does not exist.
+func ensureBuildkitContainer(ctx context.Context, dockerCli command.Cli, name string) error {
+	dockerapi := dockerCli.Client()
+
+	inspect, err := dockerapi.ContainerInspect(ctx, name)
+	if err == nil {
+		if inspect.State != nil && inspect.State.Running {
+			return nil
+		}
+		return dockerapi.ContainerStart(ctx, inspect.ID, types.ContainerStartOptions{})
+	}
+	if !errdefs.IsNotFound(err) {
+		return err
+	}
+
+	body, err := dockerapi.ImagePull(ctx, localBuildkitImage, types.ImagePullOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = body.Close() }()
+	if _, err := io.Copy(io.Discard, body); err != nil {
+		return err
+	}
+
+	// buildkitd needs a privileged container to run its own containers.
+	fmt.Fprintf(os.Stderr, "Creating privileged buildkitd container %s from %s, remove it with: docker rm -f %s\n", name, localBuildkitImage, name)
+	resp, err := dockerapi.ContainerCreate(ctx,
+		&container.Config{Image: localBuildkitImage},
+		&container.HostConfig{Privileged: true},
+		nil,
+		nil,
+		name,
+	)
+	if err != nil {
+		return err
+	}
+	return dockerapi.ContainerStart(ctx, resp.ID, types.ContainerStartOptions{})
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"connectrpc.com/connect"
+	cliv1beta1 "github.com/depot/cli/pkg/proto/depot/cli/v1beta1"
+	"github.com/depot/cli/pkg/proto/depot/cli/v1beta1/cliv1beta1connect"
+)
+
+type projectsService struct {
+	cliv1beta1connect.UnimplementedProjectsServiceHandler
+}
+
+func (projectsService) ListProjects(context.Context, *connect.Request[cliv1beta1.ListProjectsRequest]) (*connect.Response[cliv1beta1.ListProjectsResponse], error) {
+	return connect.NewResponse(&cliv1beta1.ListProjectsResponse{
+		Projects: []*cliv1beta1.ListProjectsResponse_Project{
+			{Id: "local-platforms", Platforms: []string{"linux/amd64", "linux/arm64"}},
+		},
+	}), nil
+}
+
+func TestExpandLocalPlatforms(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle(cliv1beta1connect.NewProjectsServiceHandler(projectsService{}))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	t.Setenv("DEPOT_API_URL", server.URL)
+
+	options := buildOptions{}
+	options.token = "token"
+	options.project = "local-platforms"
+	options.platforms = []string{"all,linux/riscv64"}
+	if err := expandLocalPlatforms(context.Background(), &options); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"linux/amd64", "linux/arm64", "linux/riscv64"}
+	if !reflect.DeepEqual(options.platforms, want) {
+		t.Errorf("expected %v, got %v", want, options.platforms)
+	}
+
+	// Without "all", a local build needs no token.
+	options = buildOptions{}
+	options.platforms = []string{"linux/amd64"}
+	if err := expandLocalPlatforms(context.Background(), &options); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"linux/amd64"}; !reflect.DeepEqual(options.platforms, want) {
+		t.Errorf("expected %v, got %v", want, options.platforms)
+	}
+}
@@ -0,0 +1,392 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/containerd/containerd/platforms"
+	"github.com/depot/cli/pkg/buildx/bake"
+	"github.com/depot/cli/pkg/buildx/imagetools"
+	"github.com/depot/cli/pkg/dockerclient"
+	"github.com/distribution/reference"
+	"github.com/docker/buildx/build"
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/moby/buildkit/frontend/dockerfile/shell"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	defaultLockfile = "depot.lock"
+	lockfileVersion = 1
+	// lockProject groups the bake targets of "depot lock update", which do
+	// not need a project to resolve their images.
+	lockProject = "lock"
+)
+
+// Lockfile pins the base images and image contexts of builds to digests.
+type Lockfile struct {
+	Version int `json:"version"`
+	// Images maps the image references of Dockerfiles and bake contexts, as
+	// named by their build contexts, to the reference pinned to a digest.
+	Images map[string]string `json:"images"`
+}
+
+func readLockfile(path string) (*Lockfile, error) {
+	dt, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Errorf("lockfile %s not found, run \"depot lock update\" to create it", path)
+		}
+		return nil, err
+	}
+	var lock Lockfile
+	if err := json.Unmarshal(dt, &lock); err != nil {
+		return nil, errors.Wrapf(err, "invalid lockfile %s", path)
+	}
+	if lock.Version != lockfileVersion {
+		return nil, errors.Errorf("unsupported lockfile %s version %d", path, lock.Version)
+	}
+	return &lock, nil
+}
+
+func (l *Lockfile) write(path string) error {
+	dt, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(dt, '\n'), 0644)
+}
+
+// lockName returns the build context name of an image reference.  Images
+// that are already pinned to a digest or depend on build args are not locked.
+func lockName(image string) (string, bool) {
+	if strings.Contains(image, "$") {
+		return "", false
+	}
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", false
+	}
+	if _, ok := named.(reference.Digested); ok {
+		return "", false
+	}
+	return strings.TrimSuffix(reference.FamiliarString(named), ":latest"), true
+}
+
+// lockRef is an image of a build that the lockfile pins.  Name is the
+// build context that is replaced by the pinned image.
+type lockRef struct {
+	Name  string
+	Image string
+}
+
+// lockRefs returns the images of the FROM instructions and docker-image
+// contexts of a build.  FROM instructions of a named context are pinned
+// by their context instead.
+func lockRefs(opt build.Options) ([]lockRef, error) {
+	var refs []lockRef
+	for name, named := range opt.Inputs.NamedContexts {
+		image, ok := strings.CutPrefix(named.Path, "docker-image://")
+		if !ok {
+			continue
+		}
+		if _, ok := lockName(image); ok {
+			refs = append(refs, lockRef{Name: name, Image: image})
+		}
+	}
+
+	dockerfile, err := readDockerfile(opt)
+	if err != nil {
+		return nil, err
+	}
+	baseImages, err := lockBaseImages(dockerfile, opt.BuildArgs)
+	if err != nil {
+		return nil, err
+	}
+	for _, image := range baseImages {
+		name, ok := lockName(image)
+		if !ok {
+			continue
+		}
+		if _, ok := opt.Inputs.NamedContexts[name]; ok {
+			continue
+		}
+		refs = append(refs, lockRef{Name: name, Image: image})
+	}
+	return refs, nil
+}
+
+// lockBaseImages returns the base images of the FROM instructions of a
+// Dockerfile.  Build args in the images are expanded as the Dockerfile
+// frontend expands them, with the global ARGs whose defaults are overridden
+// by the build args, so that the images are locked by the names that the
+// build looks up.  An image that does not expand to a reference fails, as
+// it cannot be pinned.
+func lockBaseImages(dockerfile []byte, buildArgs map[string]string) ([]string, error) {
+	ast, err := parser.Parse(bytes.NewReader(dockerfile))
+	if err != nil {
+		return nil, err
+	}
+	stages, metaArgs, err := instructions.Parse(ast.AST)
+	if err != nil {
+		return nil, err
+	}
+
+	lex := shell.NewLex(ast.EscapeToken)
+	args := map[string]string{}
+	for _, cmd := range metaArgs {
+		for _, arg := range cmd.Args {
+			if v, ok := buildArgs[arg.Key]; ok {
+				args[arg.Key] = v
+				continue
+			}
+			if arg.Value == nil {
+				continue
+			}
+			v, err := lex.ProcessWordWithMap(*arg.Value, args)
+			if err != nil {
+				return nil, err
+			}
+			args[arg.Key] = v
+		}
+	}
+
+	var images []string
+	stageNames := map[string]struct{}{}
+	for _, stage := range stages {
+		image, err := lex.ProcessWordWithMap(stage.BaseName, args)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := stageNames[strings.ToLower(image)]; !ok && image != "scratch" {
+			if _, err := reference.ParseNormalizedNamed(image); err != nil {
+				return nil, errors.Errorf("FROM %s expands to %q, which cannot be locked; set its build args", stage.BaseName, image)
+			}
+			if !slices.Contains(images, image) {
+				images = append(images, image)
+			}
+		}
+		if stage.Name != "" {
+			stageNames[strings.ToLower(stage.Name)] = struct{}{}
+		}
+	}
+	return images, nil
+}
+
+// readDockerfile reads the Dockerfile of a build with a local context.
+func readDockerfile(opt build.Options) ([]byte, error) {
+	if opt.Inputs.DockerfileInline != "" {
+		return []byte(opt.Inputs.DockerfileInline), nil
+	}
+	dockerfile := opt.Inputs.DockerfilePath
+	if dockerfile == "-" || opt.Inputs.ContextPath == "-" || strings.Contains(opt.Inputs.ContextPath, "://") || strings.Contains(dockerfile, "://") {
+		return nil, errors.New("Dockerfiles from stdin or remote contexts cannot be locked")
+	}
+	if dockerfile == "" {
+		dockerfile = filepath.Join(opt.Inputs.ContextPath, "Dockerfile")
+	}
+	return os.ReadFile(dockerfile)
+}
+
+// applyLockfile replaces the base images and image contexts of the builds
+// with the images pinned by the lockfile.  It fails if the lockfile does
+// not pin every image, as the Dockerfiles or bake files have changed since
+// it was updated.
+func applyLockfile(opts map[string]build.Options, path string) error {
+	lock, err := readLockfile(path)
+	if err != nil {
+		return err
+	}
+
+	stale := map[string]struct{}{}
+	for target, opt := range opts {
+		refs, err := lockRefs(opt)
+		if err != nil {
+			return errors.Wrapf(err, "failed to lock target %s", target)
+		}
+		contexts := maps.Clone(opt.Inputs.NamedContexts)
+		if contexts == nil {
+			contexts = map[string]build.NamedContext{}
+		}
+		for _, ref := range refs {
+			name, _ := lockName(ref.Image)
+			pinned, ok := lock.Images[name]
+			if !ok {
+				stale[ref.Image] = struct{}{}
+				continue
+			}
+			contexts[ref.Name] = build.NamedContext{Path: "docker-image://" + pinned}
+		}
+		opt.Inputs.NamedContexts = contexts
+		opts[target] = opt
+	}
+
+	if len(stale) > 0 {
+		images := maps.Keys(stale)
+		sort.Strings(images)
+		return errors.Errorf("lockfile %s is stale, run \"depot lock update\": %s not pinned", path, strings.Join(images, ", "))
+	}
+	return nil
+}
+
+// updateLockfile resolves every image of the builds to its current digest.
+func updateLockfile(ctx context.Context, resolver *imagetools.Resolver, opts map[string]build.Options) (*Lockfile, error) {
+	lock := &Lockfile{Version: lockfileVersion, Images: map[string]string{}}
+	for target, opt := range opts {
+		refs, err := lockRefs(opt)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to lock target %s", target)
+		}
+		for _, ref := range refs {
+			name, _ := lockName(ref.Image)
+			if _, ok := lock.Images[name]; ok {
+				continue
+			}
+			named, err := reference.ParseNormalizedNamed(ref.Image)
+			if err != nil {
+				return nil, err
+			}
+			named = reference.TagNameOnly(named)
+			_, desc, err := resolver.Resolve(ctx, named.String())
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to resolve %s", ref.Image)
+			}
+			pinned, err := reference.WithDigest(named, desc.Digest)
+			if err != nil {
+				return nil, err
+			}
+			lock.Images[name] = reference.FamiliarString(pinned)
+		}
+	}
+	return lock, nil
+}
+
+// LockCmd manages the lockfile that pins base images for "--locked" builds.
+func LockCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lock",
+		Short: "Pin the base images of builds to digests",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return errors.New("missing subcommand, please run `depot lock --help`")
+		},
+	}
+	cmd.AddCommand(lockUpdateCmd())
+	return cmd
+}
+
+func lockUpdateCmd() *cobra.Command {
+	var (
+		dockerfiles []string
+		bakeFiles   []string
+		lockfile    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update [OPTIONS] [PATH] [TARGET...]",
+		Short: "Resolve the base images of Dockerfiles and bake targets to digests and write the lockfile",
+		Long: `Resolve the FROM images of Dockerfiles and the docker-image contexts of bake
+targets to their current digests and write them to the lockfile.
+
+Without --bake-file, PATH is the build context (default ".") and -f names
+its Dockerfiles.  With --bake-file, the arguments are the bake targets
+(default "default").`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			opts, err := lockBuildOptions(ctx, dockerfiles, bakeFiles, args)
+			if err != nil {
+				return err
+			}
+
+			dockerCli, err := dockerclient.NewDockerCLI()
+			if err != nil {
+				return err
+			}
+			resolver := imagetools.New(imagetools.Opt{Auth: dockerCli.ConfigFile()})
+
+			lock, err := updateLockfile(ctx, resolver, opts)
+			if err != nil {
+				return err
+			}
+			if err := lock.write(lockfile); err != nil {
+				return err
+			}
+
+			names := maps.Keys(lock.Images)
+			sort.Strings(names)
+			for _, name := range names {
+				fmt.Printf("%s\t%s\n", name, lock.Images[name])
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringArrayVarP(&dockerfiles, "file", "f", nil, `Name of the Dockerfile (default: "PATH/Dockerfile")`)
+	flags.StringArrayVar(&bakeFiles, "bake-file", nil, "Bake file whose targets are locked")
+	flags.StringVar(&lockfile, "lockfile", defaultLockfile, "Lockfile to write")
+
+	return cmd
+}
+
+// lockBuildOptions returns the build options of the Dockerfiles or bake
+// targets to lock.
+func lockBuildOptions(ctx context.Context, dockerfiles, bakeFiles, args []string) (map[string]build.Options, error) {
+	if len(bakeFiles) == 0 {
+		if len(args) > 1 {
+			return nil, errors.New("only one build context can be locked without --bake-file")
+		}
+		contextPath := "."
+		if len(args) == 1 {
+			contextPath = args[0]
+		}
+		if len(dockerfiles) == 0 {
+			dockerfiles = []string{""}
+		}
+		opts := make(map[string]build.Options, len(dockerfiles))
+		for _, dockerfile := range dockerfiles {
+			opts[dockerfile] = build.Options{Inputs: build.Inputs{ContextPath: contextPath, DockerfilePath: dockerfile}}
+		}
+		return opts, nil
+	}
+
+	if len(dockerfiles) > 0 {
+		return nil, errors.New("--file cannot be used with --bake-file")
+	}
+	targets := args
+	if len(targets) == 0 {
+		targets = []string{"default"}
+	}
+	files, err := bake.ReadLocalFiles(bakeFiles, os.Stdin)
+	if err != nil {
+		return nil, err
+	}
+	tgts, _, err := bake.ReadTargets(ctx, files, targets, nil, map[string]string{
+		"BAKE_LOCAL_PLATFORM": platforms.DefaultString(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Nothing is built, so no credential process runs.
+	bakeOpts, err := bake.NewDepotBakeOptions(lockProject, tgts, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	opts := map[string]build.Options{}
+	for _, projectID := range bakeOpts.ProjectIDs() {
+		for target, opt := range bakeOpts.ProjectOpts(projectID) {
+			opts[target] = opt
+		}
+	}
+	return opts, nil
+}
@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/docker/buildx/build"
+)
+
+func TestApplyLockfile(t *testing.T) {
+	dir := t.TempDir()
+	dockerfile := `FROM golang:1.22 AS build
+FROM build AS test
+FROM alpine@sha256:c5b1261d6d3e43071626931fc004f70149baeba2c8ec672bd4f27761f8e1ad6b
+FROM base
+FROM node
+`
+	if err := os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte(dockerfile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	lockfile := filepath.Join(dir, "depot.lock")
+	lock := &Lockfile{Version: lockfileVersion, Images: map[string]string{
+		"golang:1.22": "golang:1.22@sha256:1111111111111111111111111111111111111111111111111111111111111111",
+		"debian:12":   "debian:12@sha256:2222222222222222222222222222222222222222222222222222222222222222",
+		"node":        "node:latest@sha256:3333333333333333333333333333333333333333333333333333333333333333",
+	}}
+	if err := lock.write(lockfile); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := map[string]build.Options{
+		defaultTargetName: {Inputs: build.Inputs{
+			ContextPath:   dir,
+			NamedContexts: map[string]build.NamedContext{"base": {Path: "docker-image://debian:12"}},
+		}},
+	}
+	if err := applyLockfile(opts, lockfile); err != nil {
+		t.Fatal(err)
+	}
+
+	contexts := opts[defaultTargetName].Inputs.NamedContexts
+	want := map[string]string{
+		"golang:1.22": "docker-image://" + lock.Images["golang:1.22"],
+		"base":        "docker-image://" + lock.Images["debian:12"],
+		"node":        "docker-image://" + lock.Images["node"],
+	}
+	if len(contexts) != len(want) {
+		t.Errorf("expected %d contexts, got %v", len(want), contexts)
+	}
+	for name, path := range want {
+		if contexts[name].Path != path {
+			t.Errorf("context %s = %q, want %q", name, contexts[name].Path, path)
+		}
+	}
+
+	delete(lock.Images, "node")
+	if err := lock.write(lockfile); err != nil {
+		t.Fatal(err)
+	}
+	opts = map[string]build.Options{defaultTargetName: {Inputs: build.Inputs{ContextPath: dir}}}
+	err := applyLockfile(opts, lockfile)
+	if err == nil || !strings.Contains(err.Error(), "node not pinned") {
+		t.Errorf("expected stale lockfile error, got %v", err)
+	}
+}
+
+func TestLockBaseImages(t *testing.T) {
+	tests := []struct {
+		name       string
+		dockerfile string
+		buildArgs  map[string]string
+		want       []string
+		wantErr    bool
+	}{
+		{
+			name:       "stages",
+			dockerfile: "FROM golang:1.22 AS build\nFROM build\nFROM scratch\n",
+			want:       []string{"golang:1.22"},
+		},
+		{
+			name:       "arg default",
+			dockerfile: "ARG VERSION=20\nARG IMAGE=node:${VERSION}\nFROM $IMAGE\n",
+			want:       []string{"node:20"},
+		},
+		{
+			name:       "build arg",
+			dockerfile: "ARG VERSION=20\nFROM node:${VERSION}\n",
+			buildArgs:  map[string]string{"VERSION": "22"},
+			want:       []string{"node:22"},
+		},
+		{
+			name:       "stage arg",
+			dockerfile: "ARG BASE=build\nFROM alpine AS build\nFROM ${BASE}\n",
+			want:       []string{"alpine"},
+		},
+		{
+			name:       "undeclared build arg",
+			dockerfile: "FROM node:${VERSION}\n",
+			buildArgs:  map[string]string{"VERSION": "22"},
+			wantErr:    true,
+		},
+		{
+			name:       "unset arg",
+			dockerfile: "ARG REGISTRY\nFROM ${REGISTRY}/node:20\n",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lockBaseImages([]byte(tt.dockerfile), tt.buildArgs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("lockBaseImages() error = %v, want error %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lockBaseImages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/depot/cli/pkg/progresshelper"
+	"github.com/docker/buildx/util/progress"
+	"github.com/moby/buildkit/client"
+	"github.com/morikuni/aec"
+	"github.com/opencontainers/go-digest"
+)
+
+const (
+	// minHintCost is the uncached time after a step for which a hint is given.
+	minHintCost = 10 * time.Second
+	// maxHints is how many of the costliest hints are printed.
+	maxHints = 5
+)
+
+// copyStep matches the COPY and ADD instructions of Dockerfile steps, e.g.
+// "[build 3/7] COPY . .".
+var copyStep = regexp.MustCompile(`^\[[^\]]+\]\s+(COPY|ADD)\s`)
+
+// OptimizeHint is a change to a Dockerfile that would cache more of the build.
+type OptimizeHint struct {
+	Step       string
+	Suggestion string
+	// Downstream is the time of the uncached steps that depend on the step.
+	Downstream time.Duration
+}
+
+// buildOptimizeHints analyzes the steps of a build tracked with
+// progresshelper.TrackSteps.
+func buildOptimizeHints(buildID string) []OptimizeHint {
+	return optimizeHints(progresshelper.BuildVertexes(buildID))
+}
+
+// optimizeHints finds the uncached COPY and ADD steps that invalidated the
+// cache of costly steps after them.  A step that was rebuilt because the step
+// before it changed can use COPY --link; a step that was rebuilt because the
+// copied files changed should move after the steps that do not need them.
+func optimizeHints(vertexes []client.Vertex) []OptimizeHint {
+	byDigest := make(map[digest.Digest]*client.Vertex, len(vertexes))
+	children := map[digest.Digest][]digest.Digest{}
+	for i := range vertexes {
+		v := &vertexes[i]
+		byDigest[v.Digest] = v
+		for _, input := range v.Inputs {
+			children[input] = append(children[input], v.Digest)
+		}
+	}
+
+	var hints []OptimizeHint
+	for _, v := range vertexes {
+		if v.Cached || v.Completed == nil || !copyStep.MatchString(v.Name) || strings.Contains(v.Name, "--link") {
+			continue
+		}
+
+		downstream := uncachedDescendants(v.Digest, byDigest, children)
+		if downstream < minHintCost {
+			continue
+		}
+
+		suggestion := "Move this step after the steps that do not need its files, or copy only the files those steps need first"
+		if parentUncached(v, byDigest) {
+			suggestion = "Use COPY --link so this layer is reused when the steps before it change"
+		}
+		hints = append(hints, OptimizeHint{Step: strings.TrimSpace(v.Name), Suggestion: suggestion, Downstream: downstream})
+	}
+
+	sort.SliceStable(hints, func(i, j int) bool { return hints[i].Downstream > hints[j].Downstream })
+	if len(hints) > maxHints {
+		hints = hints[:maxHints]
+	}
+	return hints
+}
+
+// uncachedDescendants sums the time of the uncached steps that depend on the step.
+func uncachedDescendants(dgst digest.Digest, byDigest map[digest.Digest]*client.Vertex, children map[digest.Digest][]digest.Digest) time.Duration {
+	var (
+		total time.Duration
+		seen  = map[digest.Digest]bool{dgst: true}
+		queue = append([]digest.Digest(nil), children[dgst]...)
+	)
+	for len(queue) > 0 {
+		d := queue[0]
+		queue = queue[1:]
+		if seen[d] {
+			continue
+		}
+		seen[d] = true
+
+		if v, ok := byDigest[d]; ok && !v.Cached && v.Started != nil && v.Completed != nil {
+			total += v.Completed.Sub(*v.Started)
+		}
+		queue = append(queue, children[d]...)
+	}
+	return total
+}
+
+// parentUncached reports whether a Dockerfile step the step builds on was
+// rebuilt too.  Inputs such as the build context are not Dockerfile steps.
+func parentUncached(v client.Vertex, byDigest map[digest.Digest]*client.Vertex) bool {
+	for _, input := range v.Inputs {
+		parent, ok := byDigest[input]
+		if !ok || strings.HasPrefix(parent.Name, "[internal]") || !strings.HasPrefix(parent.Name, "[") {
+			continue
+		}
+		if !parent.Cached {
+			return true
+		}
+	}
+	return false
+}
+
+func printOptimizeHints(w io.Writer, mode string, hints []OptimizeHint) {
+	if len(hints) == 0 || mode == progress.PrinterModeQuiet {
+		return
+	}
+
+	summary := "1 optimization hint"
+	if len(hints) > 1 {
+		summary = fmt.Sprintf("%d optimization hints", len(hints))
+	}
+	if progresshelper.UseColor(mode) {
+		summary = aec.YellowF.Apply(summary)
+	}
+	fmt.Fprintf(w, "\n %s:\n", summary)
+
+	for _, hint := range hints {
+		fmt.Fprintf(w, "HINT %s (%.1fs of uncached steps after it)\n", hint.Step, hint.Downstream.Round(100*time.Millisecond).Seconds())
+		fmt.Fprintf(w, "  %s\n", hint.Suggestion)
+	}
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+)
+
+func TestOptimizeHints(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	at := func(d time.Duration) *time.Time {
+		t := start.Add(d)
+		return &t
+	}
+	d := digest.FromString
+	vertexes := []client.Vertex{
+		{Digest: d("context"), Name: "[internal] load build context", Started: at(0), Completed: at(time.Second)},
+		{Digest: d("from"), Name: "[1/5] FROM node", Started: at(0), Completed: at(time.Second), Cached: true},
+		{Digest: d("copy"), Name: "[2/5] COPY . .", Inputs: []digest.Digest{d("from"), d("context")}, Started: at(time.Second), Completed: at(2 * time.Second)},
+		{Digest: d("install"), Name: "[3/5] RUN npm ci", Inputs: []digest.Digest{d("copy")}, Started: at(2 * time.Second), Completed: at(32 * time.Second)},
+		{Digest: d("assets"), Name: "[4/5] COPY assets /assets", Inputs: []digest.Digest{d("install"), d("context")}, Started: at(32 * time.Second), Completed: at(33 * time.Second)},
+		{Digest: d("build"), Name: "[5/5] RUN npm run build", Inputs: []digest.Digest{d("assets")}, Started: at(33 * time.Second), Completed: at(53 * time.Second)},
+		{Digest: d("linked"), Name: "[2/2] COPY --link . .", Inputs: []digest.Digest{d("from"), d("context")}, Started: at(time.Second), Completed: at(2 * time.Second)},
+	}
+
+	hints := optimizeHints(vertexes)
+	if len(hints) != 2 {
+		t.Fatalf("expected 2 hints, got %+v", hints)
+	}
+	if h := hints[0]; h.Step != "[2/5] COPY . ." || h.Downstream != 51*time.Second || !strings.Contains(h.Suggestion, "Move this step") {
+		t.Errorf("unexpected hint for the context copy: %+v", h)
+	}
+	if h := hints[1]; h.Step != "[4/5] COPY assets /assets" || h.Downstream != 20*time.Second || !strings.Contains(h.Suggestion, "COPY --link") {
+		t.Errorf("unexpected hint for the assets copy: %+v", h)
+	}
+
+	vertexes[3].Cached = true
+	vertexes[5].Cached = true
+	if hints := optimizeHints(vertexes); len(hints) != 0 {
+		t.Errorf("expected no hints when the steps after the copies are cached, got %+v", hints)
+	}
+}
@@ -0,0 +1,378 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/platforms"
+	depotbuild "github.com/depot/cli/pkg/buildx/build"
+	"github.com/depot/cli/pkg/progresshelper"
+	"github.com/docker/buildx/build"
+	"github.com/docker/buildx/util/progress"
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/moby/buildkit/identity"
+	"github.com/morikuni/aec"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// PolicyFailed is the error returned when the build violates the policy.
+var PolicyFailed = errors.New("policy violation")
+
+// policyQuery is the rego rule evaluated for violations, e.g.
+//
+//	package depot
+//
+//	deny contains msg if {
+//		some image in input.baseImages
+//		not startswith(image, "registry.example.com/")
+//		msg := sprintf("base image %s is not from the internal registry", [image])
+//	}
+const policyQuery = "data.depot.deny"
+
+// PolicyInput is the document the policy is evaluated against for each target.
+type PolicyInput struct {
+	Target     string            `json:"target"`
+	Dockerfile string            `json:"dockerfile"`
+	BaseImages []string          `json:"baseImages"`
+	Platforms  []string          `json:"platforms"`
+	Tags       []string          `json:"tags"`
+	Labels     map[string]string `json:"labels"`
+	BuildArgs  map[string]string `json:"buildArgs"`
+	Outputs    []string          `json:"outputs"`
+	// Secrets and SSH are the IDs mounted by RUN instructions of the Dockerfile.
+	Secrets []string     `json:"secrets"`
+	SSH     []string     `json:"ssh"`
+	Lints   []PolicyLint `json:"lints"`
+}
+
+type PolicyLint struct {
+	Level   string `json:"level"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// Policy evaluates a rego policy with the OPA CLI against the resolved build
+// options and lint findings of each target before the solve starts.
+type Policy struct {
+	File   string
+	opts   map[string]build.Options
+	linter *Linter
+
+	printer progress.Writer
+
+	mu         sync.Mutex
+	violations map[string][]string
+}
+
+func NewPolicy(printer progress.Writer, file string, opts map[string]build.Options, linter *Linter) *Policy {
+	return &Policy{
+		File:       file,
+		opts:       opts,
+		linter:     linter,
+		printer:    printer,
+		violations: make(map[string][]string),
+	}
+}
+
+func (p *Policy) Handle(ctx context.Context, target string, driverIndex int, dockerfile *depotbuild.DockerfileInputs, _ progress.Writer) error {
+	if p.File == "" {
+		return nil
+	}
+
+	// Like linting, the policy is evaluated once per target rather than per platform.
+	p.mu.Lock()
+	if _, ok := p.violations[target]; ok {
+		p.mu.Unlock()
+		return nil
+	}
+	p.violations[target] = nil
+	p.mu.Unlock()
+
+	policyName := "[policy]"
+	if target != defaultTargetName {
+		policyName = fmt.Sprintf("[%s policy]", target)
+	}
+	dgst := digest.Canonical.FromString(identity.NewID())
+	tm := time.Now()
+	p.printer.Write(&client.SolveStatus{Vertexes: []*client.Vertex{{Digest: dgst, Name: policyName, Started: &tm}}})
+
+	input := p.input(target, dockerfile)
+	violations, err := evalPolicy(ctx, p.File, input)
+
+	doneTm := time.Now()
+	vertex := &client.Vertex{Digest: dgst, Name: policyName, Started: &tm, Completed: &doneTm}
+	var statuses []*client.VertexStatus
+	for _, violation := range violations {
+		statuses = append(statuses, &client.VertexStatus{
+			Vertex:    dgst,
+			ID:        "DENY " + violation,
+			Timestamp: tm,
+			Started:   &tm,
+			Completed: &doneTm,
+		})
+	}
+	if err != nil {
+		vertex.Error = err.Error()
+	} else if len(violations) > 0 {
+		vertex.Error = strings.Join(violations, "\n")
+	}
+	p.printer.Write(&client.SolveStatus{Vertexes: []*client.Vertex{vertex}, Statuses: statuses})
+
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.violations[target] = violations
+	p.mu.Unlock()
+
+	if len(violations) > 0 {
+		return PolicyFailed
+	}
+	return nil
+}
+
+func (p *Policy) input(target string, dockerfile *depotbuild.DockerfileInputs) PolicyInput {
+	opt := p.opts[target]
+	input := PolicyInput{
+		Target:     target,
+		Dockerfile: dockerfile.Filename,
+		BaseImages: []string{},
+		Platforms:  []string{},
+		Tags:       opt.Tags,
+		Labels:     opt.Labels,
+		BuildArgs:  opt.BuildArgs,
+		Outputs:    []string{},
+		Secrets:    []string{},
+		SSH:        []string{},
+		Lints:      []PolicyLint{},
+	}
+	for _, platform := range opt.Platforms {
+		input.Platforms = append(input.Platforms, platforms.Format(platform))
+	}
+	for _, export := range opt.Exports {
+		input.Outputs = append(input.Outputs, export.Type)
+	}
+
+	if dockerfile.Err == nil && len(dockerfile.Content) > 0 {
+		input.BaseImages, input.Secrets, input.SSH = dockerfileUsage(dockerfile.Content)
+	}
+
+	if p.linter != nil {
+		for _, issue := range p.linter.Issues(target) {
+			lint := PolicyLint{Level: LintLevel(issue.Level).String(), Message: string(issue.Short)}
+			if issue.SourceInfo != nil {
+				lint.File = issue.SourceInfo.Filename
+			}
+			if len(issue.Range) > 0 {
+				lint.Line = int(issue.Range[0].Start.Line)
+			}
+			input.Lints = append(input.Lints, lint)
+		}
+	}
+
+	return input
+}
+
+// dockerfileUsage returns the base images and the secret and ssh mount IDs of a Dockerfile.
+// Parsing errors are left for the solve to report.
+func dockerfileUsage(content []byte) (baseImages, secrets, ssh []string) {
+	baseImages, secrets, ssh = []string{}, []string{}, []string{}
+
+	ast, err := parser.Parse(bytes.NewReader(content))
+	if err != nil {
+		return
+	}
+	stages, _, err := instructions.Parse(ast.AST)
+	if err != nil {
+		return
+	}
+
+	stageNames := map[string]struct{}{}
+	seen := map[string]struct{}{}
+	add := func(list *[]string, kind, value string) {
+		if _, ok := seen[kind+value]; ok {
+			return
+		}
+		seen[kind+value] = struct{}{}
+		*list = append(*list, value)
+	}
+
+	for _, stage := range stages {
+		if _, ok := stageNames[strings.ToLower(stage.BaseName)]; !ok && stage.BaseName != "scratch" {
+			add(&baseImages, "image", stage.BaseName)
+		}
+		if stage.Name != "" {
+			stageNames[strings.ToLower(stage.Name)] = struct{}{}
+		}
+
+		for _, cmd := range stage.Commands {
+			run, ok := cmd.(*instructions.RunCommand)
+			if !ok {
+				continue
+			}
+			// Mounts are only parsed once expanded; build args are left unexpanded.
+			if err := run.Expand(func(word string) (string, error) { return word, nil }); err != nil {
+				continue
+			}
+			for _, mount := range instructions.GetMounts(run) {
+				switch mount.Type {
+				case instructions.MountTypeSecret:
+					// Like the Dockerfile frontend, the source takes precedence over the
+					// ID, and the ID defaults to the target file name.
+					id := mount.CacheID
+					if mount.Source != "" {
+						id = mount.Source
+					}
+					if id == "" {
+						id = path.Base(mount.Target)
+					}
+					add(&secrets, "secret", id)
+				case instructions.MountTypeSSH:
+					id := mount.CacheID
+					if id == "" {
+						id = "default"
+					}
+					add(&ssh, "ssh", id)
+				}
+			}
+		}
+	}
+
+	sort.Strings(secrets)
+	sort.Strings(ssh)
+	return
+}
+
+// validatePolicyFile checks that the policy file exists and that the opa CLI
+// that evaluates it is installed, so that the build fails before it starts.
+func validatePolicyFile(policyFile string) error {
+	if policyFile == "" {
+		return nil
+	}
+	if _, err := os.Stat(policyFile); err != nil {
+		return errors.Wrap(err, "--policy-file")
+	}
+	if _, err := exec.LookPath("opa"); err != nil {
+		return errors.New(opaMissing)
+	}
+	return nil
+}
+
+const opaMissing = "--policy-file requires the opa CLI on the PATH, see https://www.openpolicyagent.org/docs/latest/#running-opa"
+
+// evalPolicy evaluates the policy with `opa eval` and returns the violations.
+func evalPolicy(ctx context.Context, policyFile string, input PolicyInput) ([]string, error) {
+	opa, err := exec.LookPath("opa")
+	if err != nil {
+		return nil, errors.New(opaMissing)
+	}
+
+	dt, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, opa, "eval", "--format", "json", "--stdin-input", "--data", policyFile, policyQuery)
+	cmd.Stdin = bytes.NewReader(dt)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Errorf("unable to evaluate policy %s: %v: %s", policyFile, err, strings.TrimSpace(stderr.String()+stdout.String()))
+	}
+
+	return parsePolicyResult(stdout.Bytes())
+}
+
+func parsePolicyResult(output []byte) ([]string, error) {
+	var res struct {
+		Result []struct {
+			Expressions []struct {
+				Value interface{} `json:"value"`
+			} `json:"expressions"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(output, &res); err != nil {
+		return nil, errors.Wrap(err, "unable to parse policy result")
+	}
+
+	var violations []string
+	for _, result := range res.Result {
+		for _, expr := range result.Expressions {
+			values, ok := expr.Value.([]interface{})
+			if !ok {
+				continue
+			}
+			for _, value := range values {
+				if msg, ok := value.(string); ok {
+					violations = append(violations, msg)
+					continue
+				}
+				dt, _ := json.Marshal(value)
+				violations = append(violations, string(dt))
+			}
+		}
+	}
+	sort.Strings(violations)
+	return violations, nil
+}
+
+// Print writes the policy violation report after the build progress has finished.
+func (p *Policy) Print(w io.Writer, mode string) {
+	if p.File == "" || mode == progress.PrinterModeQuiet {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	numViolations := 0
+	for _, violations := range p.violations {
+		numViolations += len(violations)
+	}
+	if numViolations == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n ")
+	summary := "1 policy violation found"
+	if numViolations > 1 {
+		summary = fmt.Sprintf("%d policy violations found", numViolations)
+	}
+	if progresshelper.UseColor(mode) {
+		summary = aec.RedF.Apply(summary)
+	}
+	fmt.Fprintf(w, "%s in %s:\n", summary, p.File)
+
+	targets := make([]string, 0, len(p.violations))
+	for target := range p.violations {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	for _, target := range targets {
+		prefix := ""
+		if target != defaultTargetName {
+			prefix = fmt.Sprintf("[%s] ", target)
+		}
+		for _, violation := range p.violations[target] {
+			fmt.Fprintf(w, "DENY %s%s\n", prefix, violation)
+		}
+	}
+	fmt.Fprintf(w, "\n")
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDockerfileUsage(t *testing.T) {
+	dockerfile := `FROM golang:1.21 AS builder
+RUN --mount=type=secret,id=npmrc --mount=type=ssh go build
+RUN --mount=type=secret,target=/root/.netrc true
+RUN --mount=type=secret,source=aws,target=/root/.aws/credentials true
+
+FROM builder AS test
+FROM scratch
+COPY --from=builder /app /app
+`
+	baseImages, secrets, ssh := dockerfileUsage([]byte(dockerfile))
+	if want := []string{"golang:1.21"}; !reflect.DeepEqual(baseImages, want) {
+		t.Errorf("baseImages = %v, want %v", baseImages, want)
+	}
+	if want := []string{".netrc", "aws", "npmrc"}; !reflect.DeepEqual(secrets, want) {
+		t.Errorf("secrets = %v, want %v", secrets, want)
+	}
+	if want := []string{"default"}; !reflect.DeepEqual(ssh, want) {
+		t.Errorf("ssh = %v, want %v", ssh, want)
+	}
+}
+
+func TestParsePolicyResult(t *testing.T) {
+	output := `{"result":[{"expressions":[{"value":["tag latest is not allowed",{"code":"DL3007"}],"text":"data.depot.deny"}]}]}`
+	got, err := parsePolicyResult([]byte(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"tag latest is not allowed", `{"code":"DL3007"}`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePolicyResult() = %v, want %v", got, want)
+	}
+
+	got, err = parsePolicyResult([]byte(`{}`))
+	if err != nil || len(got) != 0 {
+		t.Errorf("parsePolicyResult() of undefined rule = %v, %v", got, err)
+	}
+}
+
+func TestValidatePolicyFile(t *testing.T) {
+	if err := validatePolicyFile(""); err != nil {
+		t.Errorf("validatePolicyFile(\"\") = %v, want nil", err)
+	}
+	if err := validatePolicyFile(filepath.Join(t.TempDir(), "missing.rego")); err == nil {
+		t.Error("validatePolicyFile() of a missing file = nil, want an error")
+	}
+
+	policyFile := filepath.Join(t.TempDir(), "policy.rego")
+	if err := os.WriteFile(policyFile, []byte("package depot\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", t.TempDir())
+	if err := validatePolicyFile(policyFile); err == nil || err.Error() != opaMissing {
+		t.Errorf("validatePolicyFile() without opa = %v, want %q", err, opaMissing)
+	}
+}
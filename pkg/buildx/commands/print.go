@@ -12,8 +12,8 @@ import (
 
 	"github.com/containerd/containerd/platforms"
 	"github.com/depot/cli/pkg/buildx/bake"
+	"github.com/depot/cli/pkg/progresshelper"
 	"github.com/docker/buildx/build"
-	buildxprogress "github.com/docker/buildx/util/progress"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/docker/api/types/versions"
 	"github.com/mgutz/ansi"
@@ -28,22 +28,25 @@ func BakePrint(dockerCli command.Cli, targets []string, in BakeOptions) (err err
 		targets = []string{"default"}
 	}
 
-	files, err := bake.ReadLocalFiles(in.files, os.Stdin)
+	files, err := bake.ReadLocalFiles(in.files, in.stdin())
 	if err != nil {
 		return err
 	}
 
-	overrides := overrides(in)
 	defaults := map[string]string{
 		"BAKE_CMD_CONTEXT":    "cwd://",
 		"BAKE_LOCAL_PLATFORM": platforms.DefaultString(),
 	}
-	tgts, grps, err := bake.ReadTargets(context.Background(), files, targets, overrides, defaults)
+	tgts, grps, err := readTargets(context.Background(), files, targets, in, defaults)
 	if err != nil {
 		return err
 	}
 
-	dt, err := json.MarshalIndent(BakePrintOutput{grps, tgts}, "", "  ")
+	out := BakePrintOutput{Group: grps, Target: tgts}
+	if len(files) > 1 {
+		out.Sources = bake.ReadSources(files, tgts, defaults)
+	}
+	dt, err := json.MarshalIndent(out, "", "  ")
 	if err != nil {
 		return err
 	}
@@ -55,6 +58,9 @@ func BakePrint(dockerCli command.Cli, targets []string, in BakeOptions) (err err
 type BakePrintOutput struct {
 	Group  map[string]*bake.Group  `json:"group,omitempty"`
 	Target map[string]*bake.Target `json:"target"`
+	// Sources are the files that set each attribute of the targets when
+	// several files are merged.
+	Sources map[string]map[string]string `json:"sources,omitempty"`
 }
 
 func printResult(f *build.PrintFunc, res map[string]string) error {
@@ -101,7 +107,7 @@ func PrintBuildURL(buildURL, progress string) {
 // PrintURLLink will print a link that is clickable in supported terminals.
 func PrintURLLink(w io.Writer, title, url, progress string) {
 	if url != "" {
-		if progress == buildxprogress.PrinterModePlain {
+		if !progresshelper.UseColor(progress) {
 			fmt.Fprintf(w, "%s: %s\n", title, url)
 		} else {
 			title := ansi.Color(title, "cyan+b")
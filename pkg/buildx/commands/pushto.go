@@ -0,0 +1,193 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	depotbuild "github.com/depot/cli/pkg/buildx/build"
+	"github.com/depot/cli/pkg/buildx/imagetools"
+	"github.com/distribution/reference"
+	"github.com/docker/buildx/build"
+	"github.com/docker/buildx/util/progress"
+	"github.com/moby/buildkit/client"
+	"github.com/pkg/errors"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"golang.org/x/sync/errgroup"
+)
+
+// applyPushTo pushes the build when --push-to is given.  The --push-to names
+// are assigned to the targets by takePushTo once the build options exist.
+func applyPushTo(in *buildOptions) error {
+	if len(in.pushTo) == 0 {
+		return nil
+	}
+	if len(in.dockerfileNames) > 1 {
+		return errors.Errorf("--push-to cannot be used with multiple Dockerfiles, use --tag <target>=<name> with --push instead")
+	}
+	in.exportPush = true
+	return nil
+}
+
+// takePushTo assigns the --push-to names, "[target=]name", to the targets
+// and makes the targets push.  A name without a target is of the only
+// target.  A target without tags pushes to its first name, and the returned
+// names are copied from the pushed image after the build.  With --save, the
+// names are pushed from the depot registry with the tags of the target.
+func takePushTo(opts map[string]build.Options, pushTo []string, save bool) (map[string][]string, error) {
+	if len(pushTo) == 0 {
+		return nil, nil
+	}
+
+	destinations := map[string][]string{}
+	for _, value := range pushTo {
+		target, name, ok := strings.Cut(value, "=")
+		if !ok {
+			if len(opts) != 1 {
+				return nil, errors.Errorf("--push-to %s: more than one target is built, use --push-to <target>=<name>", value)
+			}
+			target, name = maps.Keys(opts)[0], value
+		}
+		if _, ok := opts[target]; !ok {
+			return nil, errors.Errorf("--push-to %s: unknown target %q", value, target)
+		}
+		destinations[target] = append(destinations[target], name)
+	}
+
+	for target, names := range destinations {
+		opt := opts[target]
+		if err := validateTags(append(slices.Clone(opt.Tags), names...)); err != nil {
+			return nil, err
+		}
+		exports, err := pushExports(opt.Exports)
+		if err != nil {
+			return nil, errors.Wrapf(err, "--push-to %s", target)
+		}
+		opt.Exports = exports
+
+		switch {
+		case save:
+			opt.Tags = append(slices.Clone(opt.Tags), names...)
+			delete(destinations, target)
+		case len(opt.Tags) == 0:
+			opt.Tags = names[:1:1]
+			destinations[target] = names[1:]
+		}
+		opts[target] = opt
+	}
+	return destinations, nil
+}
+
+// pushExports makes the image exports push, or adds one when there are no
+// exports.
+func pushExports(exports []client.ExportEntry) ([]client.ExportEntry, error) {
+	if len(exports) == 0 {
+		return []client.ExportEntry{{Type: client.ExporterImage, Attrs: map[string]string{"push": "true"}}}, nil
+	}
+
+	exports = slices.Clone(exports)
+	pushed := false
+	for i, export := range exports {
+		if export.Type != client.ExporterImage {
+			continue
+		}
+		attrs := map[string]string{}
+		for k, v := range export.Attrs {
+			attrs[k] = v
+		}
+		attrs["push"] = "true"
+		exports[i].Attrs = attrs
+		pushed = true
+	}
+	if !pushed {
+		return nil, errors.New("the target does not export an image to push")
+	}
+	return exports, nil
+}
+
+// pushedImages returns the image each target pushed, pinned to its digest.
+func pushedImages(resp []depotbuild.DepotBuildResponse) map[string]string {
+	images := map[string]string{}
+	for _, buildRes := range resp {
+		for _, nodeRes := range buildRes.NodeResponses {
+			if nodeRes.SolveResponse == nil {
+				continue
+			}
+			if ref, ok := pushedImageRef(nodeRes.SolveResponse.ExporterResponse); ok {
+				images[buildRes.Name] = ref
+				break
+			}
+		}
+	}
+	return images
+}
+
+// skippedTargetImages returns the image of each skipped target, pinned to
+// the digest it reuses, by the first of its tags.
+func skippedTargetImages(skipped map[string]skippedTarget, targetTags map[string][]string) map[string]string {
+	images := map[string]string{}
+	for target, s := range skipped {
+		tags := targetTags[target]
+		if len(tags) == 0 || s.Digest == "" {
+			continue
+		}
+		named, err := reference.ParseNormalizedNamed(tags[0])
+		if err != nil {
+			continue
+		}
+		images[target] = reference.TrimNamed(named).String() + "@" + s.Digest
+	}
+	return images
+}
+
+// copyToDestinations copies the pushed image of each target to its --push-to
+// names concurrently, with the credentials of each registry.  The builder
+// only pushes the image once, to the tags of the target.
+func copyToDestinations(ctx context.Context, resolver *imagetools.Resolver, pw progress.Writer, images map[string]string, destinations map[string][]string) error {
+	type imageCopy struct {
+		image        string
+		source, dest reference.Named
+	}
+	var copies []imageCopy
+
+	targets := maps.Keys(destinations)
+	sort.Strings(targets)
+	for _, target := range targets {
+		names := destinations[target]
+		if len(names) == 0 {
+			continue
+		}
+		image, ok := images[target]
+		if !ok {
+			return errors.Errorf("target %s did not push an image to copy to --push-to", target)
+		}
+		source, err := reference.ParseNormalizedNamed(image)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			dest, err := reference.ParseNormalizedNamed(name)
+			if err != nil {
+				return err
+			}
+			copies = append(copies, imageCopy{image: image, source: source, dest: dest})
+		}
+	}
+
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, c := range copies {
+		c := c
+		eg.Go(func() error {
+			return progress.Wrap(fmt.Sprintf("[push-to] copying %s to %s", c.image, reference.FamiliarString(c.dest)), pw.Write, func(progress.SubLogger) error {
+				_, desc, err := resolver.Resolve(ctx, c.image)
+				if err != nil {
+					return err
+				}
+				return resolver.Copy(ctx, &imagetools.Source{Ref: c.source, Desc: desc}, c.dest)
+			})
+		})
+	}
+	return eg.Wait()
+}
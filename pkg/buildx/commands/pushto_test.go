@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/buildx/build"
+	"github.com/moby/buildkit/client"
+)
+
+func TestApplyPushTo(t *testing.T) {
+	in := &buildOptions{DepotOptions: DepotOptions{pushTo: []string{"registry-a.example.com/app:1.0"}}}
+	if err := applyPushTo(in); err != nil {
+		t.Fatal(err)
+	}
+	if !in.exportPush {
+		t.Error("--push-to does not push")
+	}
+
+	in = &buildOptions{}
+	if err := applyPushTo(in); err != nil {
+		t.Fatal(err)
+	}
+	if in.exportPush {
+		t.Error("pushed without --push-to")
+	}
+
+	in = &buildOptions{
+		dockerfileNames: []string{"a.Dockerfile", "b.Dockerfile"},
+		DepotOptions:    DepotOptions{pushTo: []string{"repo/image:tag"}},
+	}
+	if err := applyPushTo(in); err == nil {
+		t.Error("--push-to with several Dockerfiles did not fail")
+	}
+}
+
+func TestTakePushTo(t *testing.T) {
+	pushed := []client.ExportEntry{{Type: client.ExporterImage, Attrs: map[string]string{"push": "true"}}}
+	tests := []struct {
+		name             string
+		opts             map[string]build.Options
+		pushTo           []string
+		save             bool
+		wantTags         map[string][]string
+		wantDestinations map[string][]string
+		wantErr          bool
+	}{
+		{
+			name:             "copies to the names after the tags",
+			opts:             map[string]build.Options{"default": {Tags: []string{"repo/app:1.0"}}},
+			pushTo:           []string{"registry-a.example.com/app:1.0", "registry-b.example.com/app:1.0"},
+			wantTags:         map[string][]string{"default": {"repo/app:1.0"}},
+			wantDestinations: map[string][]string{"default": {"registry-a.example.com/app:1.0", "registry-b.example.com/app:1.0"}},
+		},
+		{
+			name:             "pushes to the first name without tags",
+			opts:             map[string]build.Options{"default": {}},
+			pushTo:           []string{"registry-a.example.com/app:1.0", "registry-b.example.com/app:1.0"},
+			wantTags:         map[string][]string{"default": {"registry-a.example.com/app:1.0"}},
+			wantDestinations: map[string][]string{"default": {"registry-b.example.com/app:1.0"}},
+		},
+		{
+			name:             "saved builds push the names as tags",
+			opts:             map[string]build.Options{"default": {Tags: []string{"repo/app:1.0"}}},
+			pushTo:           []string{"registry-a.example.com/app:1.0"},
+			save:             true,
+			wantTags:         map[string][]string{"default": {"repo/app:1.0", "registry-a.example.com/app:1.0"}},
+			wantDestinations: map[string][]string{},
+		},
+		{
+			name:             "names of targets",
+			opts:             map[string]build.Options{"api": {Tags: []string{"repo/api"}}, "web": {Tags: []string{"repo/web"}}},
+			pushTo:           []string{"web=registry-a.example.com/web"},
+			wantTags:         map[string][]string{"api": {"repo/api"}, "web": {"repo/web"}},
+			wantDestinations: map[string][]string{"web": {"registry-a.example.com/web"}},
+		},
+		{
+			name:    "name without a target of several",
+			opts:    map[string]build.Options{"api": {}, "web": {}},
+			pushTo:  []string{"registry-a.example.com/web"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown target",
+			opts:    map[string]build.Options{"api": {}},
+			pushTo:  []string{"web=registry-a.example.com/web"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid name",
+			opts:    map[string]build.Options{"default": {}},
+			pushTo:  []string{"Registry/App"},
+			wantErr: true,
+		},
+		{
+			name:    "target without an image",
+			opts:    map[string]build.Options{"default": {Exports: []client.ExportEntry{{Type: client.ExporterLocal}}}},
+			pushTo:  []string{"registry-a.example.com/app"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			destinations, err := takePushTo(tt.opts, tt.pushTo, tt.save)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("takePushTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(destinations, tt.wantDestinations) {
+				t.Errorf("takePushTo() = %v, want %v", destinations, tt.wantDestinations)
+			}
+			for target, opt := range tt.opts {
+				if !reflect.DeepEqual(opt.Tags, tt.wantTags[target]) {
+					t.Errorf("tags of %s = %v, want %v", target, opt.Tags, tt.wantTags[target])
+				}
+				if _, ok := tt.wantDestinations[target]; ok && !reflect.DeepEqual(opt.Exports, pushed) {
+					t.Errorf("exports of %s = %v, want %v", target, opt.Exports, pushed)
+				}
+			}
+		})
+	}
+}
+
+func TestSkippedTargetImages(t *testing.T) {
+	skipped := map[string]skippedTarget{
+		"api": {Digest: "sha256:1234"},
+		"web": {Digest: "sha256:5678"},
+	}
+	got := skippedTargetImages(skipped, map[string][]string{"api": {"repo/api:1.0", "repo/api:latest"}})
+	want := map[string]string{"api": "docker.io/repo/api@sha256:1234"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("skippedTargetImages() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	"github.com/pkg/errors"
+)
+
+// builtImage is an image of a build as printed by --quiet.
+type builtImage struct {
+	// Target is the target of the Dockerfile with multiple Dockerfiles.
+	Target string
+	// Digest is the digest of the image manifest or index.
+	Digest string
+	// ImageID is the digest of the image config.
+	ImageID string
+	// Tags are the names the image was exported with.
+	Tags imageTags
+}
+
+// imageTags prints as a comma-separated list in templates.
+type imageTags []string
+
+func (t imageTags) String() string {
+	return strings.Join(t, ",")
+}
+
+// newBuiltImage reads the image of an exporter response.
+func newBuiltImage(target string, exporterResponse map[string]string) builtImage {
+	image := builtImage{
+		Target:  target,
+		Digest:  exporterResponse[exptypes.ExporterImageDigestKey],
+		ImageID: exporterResponse[exptypes.ExporterImageConfigDigestKey],
+	}
+	if names := exporterResponse["image.name"]; names != "" {
+		image.Tags = strings.Split(names, ",")
+	}
+	if image.ImageID == "" {
+		image.ImageID = image.Digest
+	}
+	return image
+}
+
+// parseQuietFormat parses the Go template of --quiet-format.
+func parseQuietFormat(format string) (*template.Template, error) {
+	if format == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("quiet-format").Option("missingkey=error").Parse(format)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid --quiet-format")
+	}
+	// Catch unknown fields before building rather than after.
+	if err := tmpl.Execute(io.Discard, builtImage{}); err != nil {
+		return nil, errors.Wrap(err, "invalid --quiet-format")
+	}
+	return tmpl, nil
+}
+
+// printQuiet prints a line for each image, with the template if one is given
+// or otherwise the digest.
+func printQuiet(w io.Writer, tmpl *template.Template, images []builtImage) error {
+	for _, image := range images {
+		if tmpl == nil {
+			fmt.Fprintln(w, image.Digest)
+			continue
+		}
+		var line strings.Builder
+		if err := tmpl.Execute(&line, image); err != nil {
+			return err
+		}
+		fmt.Fprintln(w, strings.TrimSuffix(line.String(), "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrintQuiet(t *testing.T) {
+	images := []builtImage{
+		newBuiltImage("default", map[string]string{
+			"containerimage.digest":        "sha256:aaa",
+			"containerimage.config.digest": "sha256:bbb",
+			"image.name":                   "ghcr.io/org/app:1.0,ghcr.io/org/app:latest",
+		}),
+	}
+
+	tmpl, err := parseQuietFormat("{{.Digest}} {{.Tags}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := printQuiet(&buf, tmpl, images); err != nil {
+		t.Fatal(err)
+	}
+	if want := "sha256:aaa ghcr.io/org/app:1.0,ghcr.io/org/app:latest\n"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := printQuiet(&buf, nil, images); err != nil {
+		t.Fatal(err)
+	}
+	if want := "sha256:aaa\n"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+
+	if _, err := parseQuietFormat("{{.Unknown}}"); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+}
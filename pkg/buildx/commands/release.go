@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/depot/cli/pkg/dockerclient"
+	"github.com/depot/cli/pkg/release"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// ReleaseCmd builds and pushes the bake targets of a release file and then
+// pins the pushed digests in the manifests it references.
+func ReleaseCmd() *cobra.Command {
+	var (
+		options     BakeOptions
+		releaseFile string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "release [OPTIONS]",
+		Short: "Build and push bake targets, then update manifests with the image digests",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := release.ReadConfig(releaseFile)
+			if err != nil {
+				return err
+			}
+
+			dockerCli, err := dockerclient.NewDockerCLI()
+			if err != nil {
+				return err
+			}
+
+			if !cmd.Flags().Lookup("no-cache").Changed {
+				options.noCache = nil
+			}
+			if !cmd.Flags().Lookup("pull").Changed {
+				options.pull = nil
+			}
+
+			// Bake files and manifests are relative to the release file.
+			dir := filepath.Dir(releaseFile)
+			for _, file := range cfg.Files {
+				options.files = append(options.files, relativeTo(dir, file))
+			}
+			options.overrides = append(cfg.Set, options.overrides...)
+			options.exportPush = true
+
+			metadataFile := options.metadataFile
+			if metadataFile == "" {
+				f, err := os.CreateTemp("", "depot-release-*.json")
+				if err != nil {
+					return err
+				}
+				_ = f.Close()
+				defer os.Remove(f.Name())
+				metadataFile = f.Name()
+			}
+			options.metadataFile = metadataFile
+
+			if err := runBakeBuilds(dockerCli, options, cfg.Targets); err != nil {
+				return err
+			}
+
+			images, err := releaseImages(metadataFile)
+			if err != nil {
+				return err
+			}
+			for _, image := range images {
+				fmt.Printf("%s\t%s\n", image.Target, image.Ref())
+			}
+
+			for _, manifest := range cfg.Manifests {
+				manifest.Path = relativeTo(dir, manifest.Path)
+				if err := release.Patch(manifest, images); err != nil {
+					return err
+				}
+				fmt.Fprintf(os.Stderr, "updated %s\n", manifest.Path)
+			}
+
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&releaseFile, "file", "f", "release.yaml", "Release file")
+	flags.StringArrayVar(&options.overrides, "set", nil, `Override target value (e.g., "targetpattern.key=value")`)
+
+	commonBuildFlags(&options.commonOptions, flags)
+	depotBuildFlags(&options.DepotOptions, flags)
+
+	return cmd
+}
+
+// releaseImages reads the pushed images of every target from the bake metadata file.
+func releaseImages(metadataFile string) ([]release.Image, error) {
+	dt, err := os.ReadFile(metadataFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata map[string]json.RawMessage
+	if err := json.Unmarshal(dt, &metadata); err != nil {
+		return nil, err
+	}
+
+	targets := make([]string, 0, len(metadata))
+	for target := range metadata {
+		if target != "depot.build" {
+			targets = append(targets, target)
+		}
+	}
+	sort.Strings(targets)
+
+	var images []release.Image
+	for _, target := range targets {
+		var res map[string]interface{}
+		if err := json.Unmarshal(metadata[target], &res); err != nil {
+			continue
+		}
+		digest, _ := res[exptypes.ExporterImageDigestKey].(string)
+		names, _ := res["image.name"].(string)
+		if digest == "" || names == "" {
+			continue
+		}
+
+		targetImages, err := release.NewImages(target, names, digest)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, targetImages...)
+	}
+
+	if len(images) == 0 {
+		return nil, errors.New("no images were pushed, make sure the release targets have tags")
+	}
+	return images, nil
+}
+
+func relativeTo(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/depot/cli/pkg/buildx/build"
+	"github.com/depot/cli/pkg/cmd/push"
+	"github.com/depot/cli/pkg/registry"
+	"github.com/docker/buildx/util/progress"
+	"github.com/docker/cli/cli/command"
+	"golang.org/x/sync/errgroup"
+)
+
+// pushSavedTags pushes the tags of the targets of a --save build from the
+// depot registry, as "depot push" does, so that the uploads to each registry
+// are limited, retried with backoff and resumed, with the credentials of
+// --registry-auth.  The targets are pushed concurrently, and the first tag of
+// a target before its others, which mount its blobs.  The tags of failed
+// targets are not pushed.  Bake saves each target under its name, a build
+// under none.
+func pushSavedTags(ctx context.Context, dockerCli command.Cli, depotOpts DepotOptions, progressMode string, pushTags map[string][]string, failedTargets *build.TargetsError, bake bool) error {
+	targets := make([]string, 0, len(pushTags))
+	for target := range pushTags {
+		if failedTargets != nil && failedTargets.Errors[target] != nil {
+			continue
+		}
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	// Concurrent pushes cannot share the terminal, so each prints plainly.
+	tagCount := 0
+	for _, target := range targets {
+		tagCount += len(pushTags[target])
+	}
+	if tagCount > 1 && (progressMode == progress.PrinterModeAuto || progressMode == progress.PrinterModeTty) {
+		progressMode = progress.PrinterModePlain
+	}
+
+	policy := registry.NewPushPolicy(depotOpts.uploadConcurrency, depotOpts.uploadAttempts)
+	pushTag := func(ctx context.Context, savedTarget, tag string, mountFrom []string) error {
+		finish, err := push.StartPush(ctx, depotOpts.buildID, tag, depotOpts.token)
+		if err != nil {
+			return err
+		}
+		err = push.Push(ctx, policy, depotOpts.registryCredentials, mountFrom, progressMode, depotOpts.buildID, savedTarget, tag, depotOpts.token, dockerCli)
+		return finish(err)
+	}
+
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, target := range targets {
+		savedTarget := ""
+		if bake {
+			savedTarget = target
+		}
+		tags := pushTags[target]
+		eg.Go(func() error {
+			if err := pushTag(ctx, savedTarget, tags[0], nil); err != nil {
+				return err
+			}
+			tagGroup, ctx := errgroup.WithContext(ctx)
+			for _, tag := range tags[1:] {
+				tag := tag
+				tagGroup.Go(func() error {
+					return pushTag(ctx, savedTarget, tag, tags[:1])
+				})
+			}
+			return tagGroup.Wait()
+		})
+	}
+	return eg.Wait()
+}
+
+// withSavedTags adds the tags that are pushed after the build, by --save or
+// --push-to, to the image names of the metadata of a target.
+func withSavedTags(metadata map[string]interface{}, tags []string) {
+	if len(tags) == 0 {
+		return
+	}
+	names := strings.Join(tags, ",")
+	if name, ok := metadata["image.name"].(string); ok && name != "" {
+		names += "," + name
+	}
+	metadata["image.name"] = names
+}
@@ -0,0 +1,38 @@
+package commands
+
+import "testing"
+
+func TestWithSavedTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]interface{}
+		tags     []string
+		want     interface{}
+	}{
+		{
+			name:     "saved name",
+			metadata: map[string]interface{}{"image.name": "registry.depot.dev/project:build"},
+			tags:     []string{"repo/app:v1", "repo/app:latest"},
+			want:     "repo/app:v1,repo/app:latest,registry.depot.dev/project:build",
+		},
+		{
+			name:     "no name",
+			metadata: map[string]interface{}{},
+			tags:     []string{"repo/app:v1"},
+			want:     "repo/app:v1",
+		},
+		{
+			name:     "no tags",
+			metadata: map[string]interface{}{"image.name": "registry.depot.dev/project:build"},
+			want:     "registry.depot.dev/project:build",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withSavedTags(tt.metadata, tt.tags)
+			if got := tt.metadata["image.name"]; got != tt.want {
+				t.Errorf("image.name = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
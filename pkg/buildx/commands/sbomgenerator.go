@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/depot/cli/pkg/buildx/imagetools"
+	"github.com/distribution/reference"
+	"github.com/docker/buildx/build"
+	"github.com/docker/buildx/util/progress"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+const (
+	sbomAttestKey = "attest:sbom"
+	// defaultSBOMGenerator is the generator buildkit uses when none is set.
+	defaultSBOMGenerator = "docker/buildkit-syft-scanner:stable-1"
+)
+
+// pinSBOMGenerators sets the SBOM generator of every target with an SBOM
+// attestation, pins it to the digest its tag currently resolves to, and
+// checks the digest against the allowed digests when there are any.
+// A generator enables the SBOM attestation of targets that do not disable it.
+func pinSBOMGenerators(ctx context.Context, resolver *imagetools.Resolver, opts map[string]build.Options, generator string, allowed []string, pw progress.Writer) error {
+	if generator == "" && len(allowed) == 0 {
+		return nil
+	}
+
+	pinned := map[string]string{}
+	for target, opt := range opts {
+		attest, ok := opt.Attests[sbomAttestKey]
+		if ok && attest == nil {
+			// The attestation is disabled.
+			continue
+		}
+		if !ok {
+			if generator == "" {
+				continue
+			}
+			enabled := "type=sbom"
+			attest = &enabled
+		}
+
+		attrs, err := parseAttestAttrs(*attest)
+		if err != nil {
+			return err
+		}
+		ref := attrs.get("generator")
+		if generator != "" {
+			ref = generator
+		}
+		if ref == "" {
+			ref = defaultSBOMGenerator
+		}
+
+		if _, ok := pinned[ref]; !ok {
+			pinnedRef, err := pinSBOMGenerator(ctx, resolver, ref, allowed)
+			if err != nil {
+				return err
+			}
+			pinned[ref] = pinnedRef
+			if pinnedRef != ref {
+				progress.Write(pw, fmt.Sprintf("[sbom] pinned generator %s to %s", ref, pinnedRef), func() error { return nil })
+			}
+		}
+
+		attrs.set("generator", pinned[ref])
+		value, err := attrs.String()
+		if err != nil {
+			return err
+		}
+		if opt.Attests == nil {
+			opt.Attests = map[string]*string{}
+		}
+		opt.Attests[sbomAttestKey] = &value
+		opts[target] = opt
+	}
+	return nil
+}
+
+// pinSBOMGenerator returns the generator reference with its digest.
+func pinSBOMGenerator(ctx context.Context, resolver *imagetools.Resolver, ref string, allowed []string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid sbom generator %s", ref)
+	}
+
+	var dgst digest.Digest
+	if digested, ok := named.(reference.Digested); ok {
+		dgst = digested.Digest()
+	} else {
+		named = reference.TagNameOnly(named)
+		_, desc, err := resolver.Resolve(ctx, named.String())
+		if err != nil {
+			return "", errors.Wrapf(err, "unable to resolve sbom generator %s", ref)
+		}
+		dgst = desc.Digest
+		if named, err = reference.WithDigest(named, dgst); err != nil {
+			return "", err
+		}
+	}
+
+	if len(allowed) > 0 && !sbomGeneratorAllowed(dgst, allowed) {
+		return "", errors.Errorf("sbom generator %s (%s) is not in the allowed sbom_generator_digests", ref, dgst)
+	}
+	return reference.FamiliarString(named), nil
+}
+
+func sbomGeneratorAllowed(dgst digest.Digest, allowed []string) bool {
+	for _, a := range allowed {
+		// Entries may be a digest or a reference pinned to one.
+		if _, d, ok := strings.Cut(a, "@"); ok {
+			a = d
+		}
+		if a == dgst.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// attestAttrs are the ordered attributes of an --attest value.
+type attestAttrs [][2]string
+
+func parseAttestAttrs(value string) (attestAttrs, error) {
+	fields, err := csv.NewReader(strings.NewReader(value)).Read()
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid attestation %s", value)
+	}
+	attrs := make(attestAttrs, 0, len(fields))
+	for _, field := range fields {
+		key, value, _ := strings.Cut(field, "=")
+		attrs = append(attrs, [2]string{key, value})
+	}
+	return attrs, nil
+}
+
+func (a attestAttrs) get(key string) string {
+	for _, attr := range a {
+		if attr[0] == key {
+			return attr[1]
+		}
+	}
+	return ""
+}
+
+func (a *attestAttrs) set(key, value string) {
+	for i, attr := range *a {
+		if attr[0] == key {
+			(*a)[i][1] = value
+			return
+		}
+	}
+	*a = append(*a, [2]string{key, value})
+}
+
+func (a attestAttrs) String() (string, error) {
+	fields := make([]string, 0, len(a))
+	for _, attr := range a {
+		fields = append(fields, attr[0]+"="+attr[1])
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(fields); err != nil {
+		return "", err
+	}
+	w.Flush()
+	return strings.TrimSuffix(buf.String(), "\n"), w.Error()
+}
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+)
+
+func TestAttestAttrs(t *testing.T) {
+	attrs, err := parseAttestAttrs(`type=sbom,generator=syft:1,"scan-args=--exclude a,b"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attrs.set("generator", "syft:1@sha256:abc")
+	got, err := attrs.String()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `type=sbom,generator=syft:1@sha256:abc,"scan-args=--exclude a,b"`; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+}
+
+func TestSBOMGeneratorAllowed(t *testing.T) {
+	dgst := digest.FromString("syft")
+	if !sbomGeneratorAllowed(dgst, []string{"ghcr.io/org/syft@" + dgst.String()}) {
+		t.Errorf("expected a pinned reference to be allowed")
+	}
+	if !sbomGeneratorAllowed(dgst, []string{dgst.String()}) {
+		t.Errorf("expected a digest to be allowed")
+	}
+	if sbomGeneratorAllowed(dgst, []string{digest.FromString("other").String()}) {
+		t.Errorf("expected other digests not to be allowed")
+	}
+}
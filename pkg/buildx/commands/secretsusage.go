@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	depotbuildflags "github.com/depot/cli/pkg/buildx/buildflags"
+	"github.com/depot/cli/pkg/progresshelper"
+	"github.com/docker/buildx/build"
+	"github.com/docker/buildx/util/progress"
+	"github.com/morikuni/aec"
+)
+
+const (
+	secretUsed         = "used"
+	secretUnused       = "declared but not requested"
+	secretNotDeclared  = "requested but not declared"
+	secretNotAvailable = "requested but not available"
+)
+
+// secretUsage is how one declared or requested secret or SSH agent was used.
+type secretUsage struct {
+	Kind   string
+	ID     string
+	Status string
+}
+
+// secretsUsage compares the declared secrets and SSH agents with those the
+// builder requested during the build.
+func secretsUsage(kind string, usage depotbuildflags.Usage) []secretUsage {
+	declared := map[string]bool{}
+	var usages []secretUsage
+	for _, id := range usage.Declared {
+		declared[id] = true
+		status := secretUnused
+		if provided, ok := usage.Requested[id]; ok {
+			status = secretUsed
+			if !provided {
+				status = secretNotAvailable
+			}
+		}
+		usages = append(usages, secretUsage{Kind: kind, ID: id, Status: status})
+	}
+
+	var undeclared []string
+	for id := range usage.Requested {
+		if !declared[id] {
+			undeclared = append(undeclared, id)
+		}
+	}
+	sort.Strings(undeclared)
+	for _, id := range undeclared {
+		usages = append(usages, secretUsage{Kind: kind, ID: id, Status: secretNotDeclared})
+	}
+	return usages
+}
+
+// printSecretsUsage writes the usage of the secrets and SSH agents of each target.
+func printSecretsUsage(w io.Writer, mode string, opts map[string]build.Options) {
+	if mode == progress.PrinterModeQuiet {
+		return
+	}
+
+	targets := make([]string, 0, len(opts))
+	for target := range opts {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	var lines []string
+	unused := false
+	for _, target := range targets {
+		var usages []secretUsage
+		for _, attachable := range opts[target].Session {
+			switch provider := attachable.(type) {
+			case *depotbuildflags.SecretProvider:
+				usages = append(usages, secretsUsage("secret", provider.Usage())...)
+			case *depotbuildflags.SSHProvider:
+				usages = append(usages, secretsUsage("ssh", provider.Usage())...)
+			}
+		}
+
+		prefix := ""
+		if target != defaultTargetName {
+			prefix = fmt.Sprintf("[%s] ", target)
+		}
+		for _, usage := range usages {
+			status := usage.Status
+			if progresshelper.UseColor(mode) {
+				switch status {
+				case secretUsed:
+					status = aec.GreenF.Apply(status)
+				case secretUnused:
+					status = aec.YellowF.Apply(status)
+				default:
+					status = aec.RedF.Apply(status)
+				}
+			}
+			unused = unused || usage.Status == secretUnused
+			lines = append(lines, fmt.Sprintf("%s%s %s: %s", prefix, usage.Kind, usage.ID, status))
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n secrets usage:\n")
+	for _, line := range lines {
+		fmt.Fprintln(w, line)
+	}
+	if unused {
+		fmt.Fprintln(w, "Steps restored from the cache do not request their secrets or SSH agents.")
+	}
+	fmt.Fprintf(w, "\n")
+}
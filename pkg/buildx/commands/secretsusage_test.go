@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	depotbuildflags "github.com/depot/cli/pkg/buildx/buildflags"
+)
+
+func TestSecretsUsage(t *testing.T) {
+	usage := depotbuildflags.Usage{
+		Declared:  []string{"aws", "npm", "old"},
+		Requested: map[string]bool{"npm": true, "aws": false, "github": false},
+	}
+	want := []secretUsage{
+		{Kind: "secret", ID: "aws", Status: secretNotAvailable},
+		{Kind: "secret", ID: "npm", Status: secretUsed},
+		{Kind: "secret", ID: "old", Status: secretUnused},
+		{Kind: "secret", ID: "github", Status: secretNotDeclared},
+	}
+	if got := secretsUsage("secret", usage); !reflect.DeepEqual(got, want) {
+		t.Errorf("secretsUsage() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"bytes"
+	"io"
+	"sort"
+
+	"github.com/docker/buildx/build"
+	"github.com/pkg/errors"
+)
+
+// inlineStdinDockerfile reads a Dockerfile given as "-" into memory once.
+// Otherwise only the first builder node of a multi-platform build, and only
+// the first attempt of a retried build, would read it from stdin.  Named
+// contexts are still read from their local directories.
+func inlineStdinDockerfile(opts map[string]build.Options, stdin io.Reader) error {
+	var stdinTargets []string
+	for target, opt := range opts {
+		for name, named := range opt.Inputs.NamedContexts {
+			if named.Path == "-" {
+				return errors.Errorf("named context %s cannot be read from stdin, only the context or the Dockerfile can", name)
+			}
+		}
+		if opt.Inputs.DockerfilePath == "-" {
+			stdinTargets = append(stdinTargets, target)
+		}
+	}
+	if len(stdinTargets) == 0 {
+		return nil
+	}
+	sort.Strings(stdinTargets)
+	if len(stdinTargets) > 1 {
+		return errors.Errorf("only one Dockerfile can be read from stdin (targets %q and %q both use -)", stdinTargets[0], stdinTargets[1])
+	}
+
+	target := stdinTargets[0]
+	opt := opts[target]
+	if opt.Inputs.ContextPath == "-" {
+		return errors.New("the context and the Dockerfile cannot both be read from stdin")
+	}
+	if opt.Inputs.DockerfileInline != "" {
+		return errors.New("an inline Dockerfile cannot be combined with a Dockerfile read from stdin")
+	}
+
+	dt, err := io.ReadAll(stdin)
+	if err != nil {
+		return errors.Wrap(err, "failed to read the Dockerfile from stdin")
+	}
+	if len(bytes.TrimSpace(dt)) == 0 {
+		return errors.New("the Dockerfile read from stdin is empty")
+	}
+
+	opt.Inputs.DockerfileInline = string(dt)
+	opt.Inputs.DockerfilePath = ""
+	opts[target] = opt
+	return nil
+}
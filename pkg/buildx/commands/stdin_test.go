@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/buildx/build"
+)
+
+func TestInlineStdinDockerfile(t *testing.T) {
+	opts := map[string]build.Options{
+		"default": {Inputs: build.Inputs{
+			ContextPath:    ".",
+			DockerfilePath: "-",
+			NamedContexts:  map[string]build.NamedContext{"assets": {Path: "../assets"}},
+		}},
+	}
+	if err := inlineStdinDockerfile(opts, strings.NewReader("FROM scratch\nCOPY --from=assets . /\n")); err != nil {
+		t.Fatal(err)
+	}
+	inputs := opts["default"].Inputs
+	if inputs.DockerfilePath != "" || inputs.DockerfileInline != "FROM scratch\nCOPY --from=assets . /\n" {
+		t.Errorf("inputs = %+v, want the Dockerfile inlined", inputs)
+	}
+	if inputs.NamedContexts["assets"].Path != "../assets" {
+		t.Errorf("named context = %+v, want it unchanged", inputs.NamedContexts["assets"])
+	}
+
+	errorCases := map[string]map[string]build.Options{
+		"stdin context and Dockerfile": {
+			"default": {Inputs: build.Inputs{ContextPath: "-", DockerfilePath: "-"}},
+		},
+		"stdin named context": {
+			"default": {Inputs: build.Inputs{ContextPath: ".", NamedContexts: map[string]build.NamedContext{"assets": {Path: "-"}}}},
+		},
+		"multiple stdin Dockerfiles": {
+			"api": {Inputs: build.Inputs{ContextPath: ".", DockerfilePath: "-"}},
+			"web": {Inputs: build.Inputs{ContextPath: ".", DockerfilePath: "-"}},
+		},
+		"empty stdin": {
+			"default": {Inputs: build.Inputs{ContextPath: ".", DockerfilePath: "-"}},
+		},
+	}
+	for name, opts := range errorCases {
+		stdin := "FROM scratch\n"
+		if name == "empty stdin" {
+			stdin = " \n"
+		}
+		if err := inlineStdinDockerfile(opts, strings.NewReader(stdin)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
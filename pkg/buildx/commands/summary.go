@@ -0,0 +1,183 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/containerd/containerd/platforms"
+	depotbuild "github.com/depot/cli/pkg/buildx/build"
+	"github.com/depot/cli/pkg/load"
+	"github.com/depot/cli/pkg/progresshelper"
+	"github.com/docker/buildx/build"
+	"github.com/docker/buildx/util/progress"
+	"github.com/docker/go-units"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	"github.com/morikuni/aec"
+)
+
+// TargetSummary is the outcome of one bake target, printed at the end of the
+// bake and written to the metadata file.
+type TargetSummary struct {
+	Target        string   `json:"target"`
+	Status        string   `json:"status"`
+	Duration      float64  `json:"durationSeconds"`
+	CachedPercent int      `json:"cachedPercent"`
+	Platforms     []string `json:"platforms,omitempty"`
+	Digest        string   `json:"digest,omitempty"`
+	Size          int64    `json:"size,omitempty"`
+}
+
+const (
+	targetDone     = "done"
+	targetFailed   = "failed"
+	targetCanceled = "canceled"
+)
+
+// summarizeTargets builds the summary of each target from its progress and,
+// for successful builds, its exporter response.
+func summarizeTargets(tracker *progresshelper.TargetTracker, targets []string, opts map[string]build.Options, resp []depotbuild.DepotBuildResponse, buildErr error) []TargetSummary {
+	responses := map[string]depotbuild.DepotBuildResponse{}
+	for _, res := range resp {
+		responses[res.Name] = res
+	}
+
+	var failed *depotbuild.TargetsError
+	errors.As(buildErr, &failed)
+
+	summaries := make([]TargetSummary, 0, len(targets))
+	for _, target := range targets {
+		p := tracker.Progress(target)
+		summary := TargetSummary{Target: target, Status: targetDone}
+		switch {
+		case p.Error != "", failed != nil && failed.Errors[target] != nil:
+			summary.Status = targetFailed
+		case failed != nil:
+			// With --keep-going, the other targets finished.
+		case buildErr != nil && (p.Incomplete > 0 || p.Steps == 0):
+			summary.Status = targetCanceled
+		}
+		if p.Started != nil && p.Completed != nil {
+			summary.Duration = p.Completed.Sub(*p.Started).Round(100 * time.Millisecond).Seconds()
+		}
+		if p.Steps > 0 {
+			summary.CachedPercent = p.Cached * 100 / p.Steps
+		}
+		for _, platform := range opts[target].Platforms {
+			summary.Platforms = append(summary.Platforms, platforms.Format(platform))
+		}
+
+		for _, nodeRes := range responses[target].NodeResponses {
+			exporterResponse := nodeRes.SolveResponse.ExporterResponse
+			if d := exporterResponse[exptypes.ExporterImageDigestKey]; d != "" {
+				summary.Digest = d
+			}
+			summary.Size += exportedSize(exporterResponse)
+		}
+
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// exportedSize is the size of the layers and configs of the exported images.
+func exportedSize(exporterResponse map[string]string) int64 {
+	encoded, err := load.EncodedExportedImages(exporterResponse)
+	if err != nil {
+		return 0
+	}
+	_, manifests, _, err := load.DecodeExportImages(encoded)
+	if err != nil {
+		return 0
+	}
+
+	var size int64
+	for _, manifest := range manifests {
+		size += manifest.Config.Size
+		for _, layer := range manifest.Layers {
+			size += layer.Size
+		}
+	}
+	return size
+}
+
+// bakeSummary collects the target summaries of every project of a bake.
+type bakeSummary struct {
+	mu       sync.Mutex
+	projects map[string][]TargetSummary
+}
+
+func newBakeSummary() *bakeSummary {
+	return &bakeSummary{projects: map[string][]TargetSummary{}}
+}
+
+// Set records the summaries of a project, replacing those of a retried build.
+func (s *bakeSummary) Set(project string, summaries []TargetSummary) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.projects[project] = summaries
+}
+
+// Print writes the summary table when more than one target was built.
+func (s *bakeSummary) Print(w io.Writer, mode string) {
+	if mode == progress.PrinterModeQuiet {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var summaries []TargetSummary
+	for _, projectSummaries := range s.projects {
+		summaries = append(summaries, projectSummaries...)
+	}
+	if len(summaries) < 2 {
+		return
+	}
+	sort.SliceStable(summaries, func(i, j int) bool { return summaries[i].Target < summaries[j].Target })
+
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TARGET\tSTATUS\tDURATION\tCACHED\tPLATFORMS\tDIGEST\tSIZE")
+	for _, summary := range summaries {
+		status := summary.Status
+		if progresshelper.UseColor(mode) {
+			switch status {
+			case targetFailed:
+				status = aec.RedF.Apply(status)
+			case targetCanceled:
+				status = aec.YellowF.Apply(status)
+			case targetSkipped:
+				status = aec.CyanF.Apply(status)
+			default:
+				status = aec.GreenF.Apply(status)
+			}
+		}
+
+		digest, size := "-", "-"
+		if summary.Digest != "" {
+			digest = summary.Digest
+			if len(digest) > len("sha256:")+12 {
+				digest = digest[:len("sha256:")+12]
+			}
+		}
+		if summary.Size > 0 {
+			size = units.HumanSize(float64(summary.Size))
+		}
+		platforms := "-"
+		if len(summary.Platforms) > 0 {
+			platforms = strings.Join(summary.Platforms, ",")
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%.1fs\t%d%%\t%s\t%s\t%s\n", summary.Target, status, summary.Duration, summary.CachedPercent, platforms, digest, size)
+	}
+	_ = tw.Flush()
+}
@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/platforms"
+	depotbuild "github.com/depot/cli/pkg/buildx/build"
+	"github.com/depot/cli/pkg/progresshelper"
+	"github.com/docker/buildx/build"
+	"github.com/docker/buildx/util/progress"
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+type discardWriter struct{}
+
+func (discardWriter) Write(*client.SolveStatus)                         {}
+func (discardWriter) ValidateLogSource(digest.Digest, interface{}) bool { return true }
+func (discardWriter) ClearLogSource(interface{})                        {}
+
+func TestSummarizeTargets(t *testing.T) {
+	t0 := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	t1 := t0.Add(1500 * time.Millisecond)
+
+	tracker := progresshelper.NewTargetTracker(discardWriter{}, []string{"api", "web", "worker"})
+	tracker.Write(&client.SolveStatus{Vertexes: []*client.Vertex{
+		{Digest: "api1", Name: "[api 1/2] FROM alpine", Started: &t0, Completed: &t0, Cached: true},
+		{Digest: "api2", Name: "[api 2/2] RUN make", Started: &t0, Completed: &t1},
+		{Digest: "web1", Name: "[web 1/1] RUN make", Started: &t0, Completed: &t1, Error: "exit code: 1"},
+		{Digest: "worker1", Name: "[worker 1/1] RUN make", Started: &t0},
+	}})
+
+	opts := map[string]build.Options{
+		"api": {Platforms: []specs.Platform{platforms.MustParse("linux/amd64"), platforms.MustParse("linux/arm64")}},
+	}
+	resp := []depotbuild.DepotBuildResponse{{
+		Name: "api",
+		NodeResponses: []depotbuild.DepotNodeResponse{{
+			SolveResponse: &client.SolveResponse{ExporterResponse: map[string]string{exptypes.ExporterImageDigestKey: "sha256:abc"}},
+		}},
+	}}
+
+	tests := []struct {
+		name     string
+		buildErr error
+		want     map[string]string
+	}{
+		{
+			name: "failed step",
+			want: map[string]string{"api": targetDone, "web": targetFailed, "worker": targetDone},
+		},
+		{
+			name:     "canceled",
+			buildErr: errors.New("context canceled"),
+			want:     map[string]string{"api": targetDone, "web": targetFailed, "worker": targetCanceled},
+		},
+		{
+			name:     "keep going",
+			buildErr: &depotbuild.TargetsError{Errors: map[string]error{"api": errors.New("failed to push")}},
+			want:     map[string]string{"api": targetFailed, "web": targetFailed, "worker": targetDone},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			summaries := summarizeTargets(tracker, []string{"api", "web", "worker"}, opts, resp, tt.buildErr)
+			got := map[string]string{}
+			for _, summary := range summaries {
+				got[summary.Target] = summary.Status
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("summarizeTargets() statuses = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	api := summarizeTargets(tracker, []string{"api"}, opts, resp, nil)[0]
+	want := TargetSummary{
+		Target:        "api",
+		Status:        targetDone,
+		Duration:      1.5,
+		CachedPercent: 50,
+		Platforms:     []string{"linux/amd64", "linux/arm64"},
+		Digest:        "sha256:abc",
+	}
+	if !reflect.DeepEqual(api, want) {
+		t.Errorf("summarizeTargets() = %+v, want %+v", api, want)
+	}
+}
+
+func TestBakeSummaryPrint(t *testing.T) {
+	t.Setenv("CLICOLOR_FORCE", "")
+
+	s := newBakeSummary()
+	s.Set("proj2", []TargetSummary{{Target: "web", Status: targetFailed, Duration: 2}})
+	s.Set("proj1", []TargetSummary{{Target: "old", Status: targetFailed}})
+	// A retried build replaces the summaries of its project.
+	s.Set("proj1", []TargetSummary{
+		{Target: "api", Status: targetDone, Duration: 1.5, CachedPercent: 50, Platforms: []string{"linux/amd64", "linux/arm64"}, Digest: "sha256:0123456789abcdef", Size: 2000},
+	})
+
+	var buf bytes.Buffer
+	s.Print(&buf, progress.PrinterModePlain)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	want := []string{
+		"TARGET  STATUS  DURATION  CACHED  PLATFORMS                DIGEST               SIZE",
+		"api     done    1.5s      50%     linux/amd64,linux/arm64  sha256:0123456789ab  2kB",
+		"web     failed  2.0s      0%      -                        -                    -",
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("Print() =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+
+	buf.Reset()
+	s.Print(&buf, progress.PrinterModeQuiet)
+	if buf.Len() != 0 {
+		t.Errorf("Print() in quiet mode = %q, want nothing", buf.String())
+	}
+
+	buf.Reset()
+	single := newBakeSummary()
+	single.Set("proj1", []TargetSummary{{Target: "api", Status: targetDone}})
+	single.Print(&buf, progress.PrinterModePlain)
+	if buf.Len() != 0 {
+		t.Errorf("Print() of a single target = %q, want nothing", buf.String())
+	}
+
+	// A nil summary, as when the summary is not collected, ignores results.
+	var disabled *bakeSummary
+	disabled.Set("proj1", nil)
+}
+
+func TestSortedCopy(t *testing.T) {
+	targets := []string{"web", "api", "worker"}
+	got := sortedCopy(targets)
+	if want := []string{"api", "web", "worker"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sortedCopy() = %v, want %v", got, want)
+	}
+	if want := []string{"web", "api", "worker"}; !reflect.DeepEqual(targets, want) {
+		t.Errorf("sortedCopy() modified its argument to %v", targets)
+	}
+	if got := sortedCopy(nil); got != nil {
+		t.Errorf("sortedCopy(nil) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/distribution/reference"
+	"github.com/docker/buildx/util/buildflags"
+	"github.com/moby/buildkit/client"
+)
+
+// validateTags checks all image references locally before a builder is
+// acquired.  Every problem is reported at once, with a suggestion when
+// there is an obvious fix.
+func validateTags(tags []string) error {
+	problems := tagProblems(tags)
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("invalid image tags:\n  - %s", strings.Join(problems, "\n  - "))
+}
+
+// exportNames returns the image names of the image exporters.
+func exportNames(exports []client.ExportEntry) []string {
+	var names []string
+	for _, export := range exports {
+		if export.Type != client.ExporterImage || export.Attrs["name"] == "" {
+			continue
+		}
+		names = append(names, strings.Split(export.Attrs["name"], ",")...)
+	}
+	return names
+}
+
+// parseTargetOutputs ignores invalid outputs as they are reported when the
+// build options are created.
+func parseTargetOutputs(outputs []string) []client.ExportEntry {
+	exports, _ := buildflags.ParseOutputs(outputs)
+	return exports
+}
+
+func tagProblems(tags []string) []string {
+	var problems []string
+	seen := map[string]struct{}{}
+
+	for _, tag := range tags {
+		if strings.TrimSpace(tag) == "" {
+			problems = append(problems, "empty tag")
+			continue
+		}
+
+		ref, err := reference.ParseNormalizedNamed(tag)
+		if err != nil {
+			if lower := lowercaseName(tag); lower != tag && isValidReference(lower) {
+				problems = append(problems, fmt.Sprintf("%q: repository name must be lowercase (did you mean %q?)", tag, lower))
+			} else {
+				problems = append(problems, fmt.Sprintf("%q: %v", tag, err))
+			}
+			continue
+		}
+
+		if _, ok := ref.(reference.Digested); ok {
+			suggestion := reference.FamiliarName(ref)
+			if tagged, ok := ref.(reference.Tagged); ok {
+				suggestion += ":" + tagged.Tag()
+			}
+			problems = append(problems, fmt.Sprintf("%q: tags cannot include a digest (did you mean %q?)", tag, suggestion))
+			continue
+		}
+
+		normalized := reference.TagNameOnly(ref).String()
+		if _, ok := seen[normalized]; ok {
+			problems = append(problems, fmt.Sprintf("%q: duplicate tag", tag))
+			continue
+		}
+		seen[normalized] = struct{}{}
+	}
+
+	return problems
+}
+
+// lowercaseName lowercases the repository of a reference while leaving the
+// tag and digest as they are, since tags may contain uppercase letters.
+func lowercaseName(tag string) string {
+	name, suffix := tag, ""
+	if i := strings.Index(tag, "@"); i >= 0 {
+		name, suffix = tag[:i], tag[i:]
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, suffix = name[:i], name[i:]+suffix
+	}
+	return strings.ToLower(name) + suffix
+}
+
+func isValidReference(s string) bool {
+	_, err := reference.ParseNormalizedNamed(s)
+	return err == nil
+}
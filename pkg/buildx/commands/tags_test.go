@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTagProblems(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{
+			name: "valid tags",
+			tags: []string{"repo/image:tag", "ghcr.io/org/image", "localhost:5000/image:v1"},
+			want: nil,
+		},
+		{
+			name: "uppercase repository",
+			tags: []string{"Repo/Image:Latest"},
+			want: []string{`"Repo/Image:Latest": repository name must be lowercase (did you mean "repo/image:Latest"?)`},
+		},
+		{
+			name: "digest",
+			tags: []string{"repo/image:tag@sha256:6839c1808eab334a9b0f400f119773a0a7d494631c083aef6d3447e3798b544f"},
+			want: []string{`"repo/image:tag@sha256:6839c1808eab334a9b0f400f119773a0a7d494631c083aef6d3447e3798b544f": tags cannot include a digest (did you mean "repo/image:tag"?)`},
+		},
+		{
+			name: "duplicates after normalization",
+			tags: []string{"image", "docker.io/library/image:latest"},
+			want: []string{`"docker.io/library/image:latest": duplicate tag`},
+		},
+		{
+			name: "reports every problem",
+			tags: []string{"", "repo/image:"},
+			want: []string{"empty tag", `"repo/image:": invalid reference format`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tagProblems(tt.tags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tagProblems() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/depot/cli/pkg/dockerclient"
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+	"github.com/spf13/cobra"
+)
+
+var defaultWarmPlatforms = []string{"linux/amd64", "linux/arm64"}
+
+// WarmCmd builds bake targets without exporting any images so that the
+// project cache is populated for later builds.
+func WarmCmd() *cobra.Command {
+	var (
+		options   BakeOptions
+		platforms []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "warm [OPTIONS] [TARGET...]",
+		Short: "Build bake targets to populate the project cache without exporting images",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dockerCli, err := dockerclient.NewDockerCLI()
+			if err != nil {
+				return err
+			}
+
+			if !cmd.Flags().Lookup("no-cache").Changed {
+				options.noCache = nil
+			}
+			if !cmd.Flags().Lookup("pull").Changed {
+				options.pull = nil
+			}
+
+			// Warm overrides go last so that they win over any user overrides.
+			options.overrides = append(options.overrides,
+				"*.platform="+strings.Join(platforms, ","),
+				"*.output=type=cacheonly",
+			)
+			options.allowNoOutput = true
+
+			statusCh := make(chan *client.SolveStatus)
+			report := newWarmReport()
+			done := make(chan struct{})
+			go func() {
+				report.Collect(statusCh)
+				close(done)
+			}()
+			options.statusTee = statusCh
+
+			err = runBakeBuilds(dockerCli, options, args)
+			close(statusCh)
+			<-done
+
+			if err != nil {
+				return err
+			}
+
+			if options.progress != "quiet" {
+				report.Print(os.Stderr)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringArrayVarP(&options.files, "file", "f", []string{}, "Build definition file")
+	flags.StringArrayVar(&options.overrides, "set", nil, `Override target value (e.g., "targetpattern.key=value")`)
+	flags.StringSliceVar(&platforms, "platform", defaultWarmPlatforms, "Platforms to warm the cache for")
+
+	commonBuildFlags(&options.commonOptions, flags)
+	depotBuildFlags(&options.DepotOptions, flags)
+
+	return cmd
+}
+
+// warmReport tracks which build steps were executed and which were
+// already present in the cache.
+type warmReport struct {
+	vertexes map[digest.Digest]*client.Vertex
+}
+
+func newWarmReport() *warmReport {
+	return &warmReport{vertexes: map[digest.Digest]*client.Vertex{}}
+}
+
+func (r *warmReport) Collect(ch <-chan *client.SolveStatus) {
+	for status := range ch {
+		for _, v := range status.Vertexes {
+			if v == nil || v.Completed == nil {
+				continue
+			}
+			// Internal steps such as loading the Dockerfile or build context
+			// are not interesting to report.
+			if strings.HasPrefix(v.Name, "[internal]") || strings.HasPrefix(v.Name, "[depot]") {
+				continue
+			}
+			r.vertexes[v.Digest] = v
+		}
+	}
+}
+
+func (r *warmReport) Print(w io.Writer) {
+	var refreshed, cached, failed []string
+	for _, v := range r.vertexes {
+		switch {
+		case v.Error != "":
+			failed = append(failed, v.Name)
+		case v.Cached:
+			cached = append(cached, v.Name)
+		default:
+			refreshed = append(refreshed, v.Name)
+		}
+	}
+	sort.Strings(refreshed)
+	sort.Strings(failed)
+
+	fmt.Fprintf(w, "Cache warm complete: %d steps refreshed, %d steps already cached\n", len(refreshed), len(cached))
+	for _, name := range refreshed {
+		fmt.Fprintf(w, "  refreshed: %s\n", name)
+	}
+	for _, name := range failed {
+		fmt.Fprintf(w, "  failed:    %s\n", name)
+	}
+}
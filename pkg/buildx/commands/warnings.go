@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/depot/cli/pkg/progresshelper"
+	"github.com/docker/buildx/util/progress"
+	"github.com/moby/buildkit/client"
+	"github.com/morikuni/aec"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultWarningsFile is found in the current directory or the closest parent
+// directory that has one.
+const defaultWarningsFile = ".depot/warnings.yaml"
+
+// WarningSuppressions are the buildkit warnings and lint rules that a
+// repository has chosen to ignore.
+//
+//	suppress:
+//	  - id: DL3008
+//	    reason: Packages are pinned by the base image.
+//	    expires: 2025-06-30
+type WarningSuppressions struct {
+	Suppress []WarningSuppression `yaml:"suppress"`
+
+	// path is the file the suppressions were read from.
+	path string
+	// expired are the suppressions that no longer apply.
+	expired []WarningSuppression
+}
+
+// WarningSuppression suppresses the warnings with a buildkit warning code or
+// lint rule ID until it expires.
+type WarningSuppression struct {
+	ID string `yaml:"id"`
+	// Reason is required so that every suppression can be audited.
+	Reason string `yaml:"reason"`
+	// Expires is the date after which the suppression no longer applies
+	// (format: "YYYY-MM-DD").  Suppressions without one never expire.
+	Expires string `yaml:"expires,omitempty"`
+}
+
+// loadWarningSuppressions reads the --warnings-file, or .depot/warnings.yaml
+// when it is not set.  It returns nil when there is no file.
+func loadWarningSuppressions(path string, now time.Time) (*WarningSuppressions, error) {
+	if path == "" {
+		path = findWarningsFile()
+		if path == "" {
+			return nil, nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read warnings file")
+	}
+	s, err := parseWarningSuppressions(data, now)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid warnings file %s", path)
+	}
+	s.path = path
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, path); err == nil {
+			s.path = rel
+		}
+	}
+	return s, nil
+}
+
+func parseWarningSuppressions(data []byte, now time.Time) (*WarningSuppressions, error) {
+	var s WarningSuppressions
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+
+	active := make([]WarningSuppression, 0, len(s.Suppress))
+	for i, suppression := range s.Suppress {
+		if suppression.ID == "" {
+			return nil, errors.Errorf("suppression %d has no id", i+1)
+		}
+		if strings.TrimSpace(suppression.Reason) == "" {
+			return nil, errors.Errorf("suppression of %s has no reason", suppression.ID)
+		}
+		if suppression.Expires != "" {
+			expires, err := time.Parse(time.DateOnly, suppression.Expires)
+			if err != nil {
+				return nil, errors.Errorf("suppression of %s has an invalid expiry %q, expected YYYY-MM-DD", suppression.ID, suppression.Expires)
+			}
+			// The suppression applies through the end of the day it expires.
+			if !now.Before(expires.AddDate(0, 0, 1)) {
+				s.expired = append(s.expired, suppression)
+				continue
+			}
+		}
+		active = append(active, suppression)
+	}
+	s.Suppress = active
+	return &s, nil
+}
+
+// findWarningsFile searches the current directory and its parents for
+// .depot/warnings.yaml.
+func findWarningsFile() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	for {
+		path := filepath.Join(dir, defaultWarningsFile)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Suppressed reports whether id, a buildkit warning code or lint rule ID,
+// is suppressed.  IDs are compared ignoring case, dashes and underscores so
+// that "FromAsCasing" matches the "from-as-casing" documentation URL.
+func (s *WarningSuppressions) Suppressed(id string) bool {
+	if s == nil || id == "" {
+		return false
+	}
+	id = normalizeWarningID(id)
+	for _, suppression := range s.Suppress {
+		if normalizeWarningID(suppression.ID) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns the warnings that are not suppressed and the number that were.
+func (s *WarningSuppressions) Filter(warnings []client.VertexWarning) ([]client.VertexWarning, int) {
+	if s == nil || len(s.Suppress) == 0 {
+		return warnings, 0
+	}
+	kept := make([]client.VertexWarning, 0, len(warnings))
+	for _, w := range warnings {
+		suppressed := false
+		for _, id := range warningIDs(w) {
+			if s.Suppressed(id) {
+				suppressed = true
+				break
+			}
+		}
+		if !suppressed {
+			kept = append(kept, w)
+		}
+	}
+	return kept, len(warnings) - len(kept)
+}
+
+// warningIDs returns the codes that identify a buildkit warning: the rule
+// name before the colon of its message, such as "FromAsCasing: ...", and the
+// last element of its documentation URL.
+func warningIDs(w client.VertexWarning) []string {
+	var ids []string
+	if code, _, ok := strings.Cut(string(w.Short), ":"); ok && code != "" && !strings.ContainsAny(code, " \t") {
+		ids = append(ids, code)
+	}
+	if w.URL != "" {
+		if i := strings.LastIndex(strings.TrimRight(w.URL, "/"), "/"); i >= 0 {
+			ids = append(ids, strings.TrimRight(w.URL, "/")[i+1:])
+		}
+	}
+	return ids
+}
+
+func normalizeWarningID(id string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(id))
+}
+
+// printSuppressedWarnings prints how many warnings and lint issues were
+// suppressed, and the suppressions that have expired.
+func printSuppressedWarnings(w io.Writer, mode string, s *WarningSuppressions, suppressed int) {
+	if s == nil || mode == progress.PrinterModeQuiet {
+		return
+	}
+	for _, suppression := range s.expired {
+		msg := fmt.Sprintf("\nThe suppression of %s expired on %s (see %s)\n", suppression.ID, suppression.Expires, s.path)
+		if progresshelper.UseColor(mode) {
+			msg = aec.Apply(msg, aec.YellowF)
+		}
+		fmt.Fprint(w, msg)
+	}
+	if suppressed == 0 {
+		return
+	}
+	noun := "warnings"
+	if suppressed == 1 {
+		noun = "warning"
+	}
+	fmt.Fprintf(w, "\n%d %s suppressed (see %s)\n", suppressed, noun, s.path)
+}
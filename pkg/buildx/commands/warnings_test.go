@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moby/buildkit/client"
+)
+
+func TestWarningSuppressions(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	s, err := parseWarningSuppressions([]byte(`
+suppress:
+  - id: DL3008
+    reason: Packages are pinned by the base image.
+  - id: FromAsCasing
+    reason: Legacy Dockerfiles.
+    expires: 2024-06-01
+  - id: DL3006
+    reason: Expired.
+    expires: 2024-05-31
+`), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !s.Suppressed("DL3008") || s.Suppressed("DL3006") {
+		t.Errorf("expected only the unexpired suppressions to apply")
+	}
+	if len(s.expired) != 1 || s.expired[0].ID != "DL3006" {
+		t.Errorf("expected DL3006 to be expired, got %v", s.expired)
+	}
+
+	warnings := []client.VertexWarning{
+		{Short: []byte("FromAsCasing: 'as' and 'FROM' keywords' casing do not match")},
+		{Short: []byte("Stage name casing"), URL: "https://docs.docker.com/go/dockerfile/rule/from-as-casing/"},
+		{Short: []byte("Empty continuation line")},
+	}
+	kept, suppressed := s.Filter(warnings)
+	if suppressed != 2 || len(kept) != 1 || string(kept[0].Short) != "Empty continuation line" {
+		t.Errorf("expected FromAsCasing warnings to be suppressed, got %d suppressed and %v kept", suppressed, kept)
+	}
+
+	if _, err := parseWarningSuppressions([]byte("suppress:\n  - id: DL3008\n"), now); err == nil {
+		t.Errorf("expected a suppression without a reason to be rejected")
+	}
+
+	var none *WarningSuppressions
+	if kept, suppressed := none.Filter(warnings); suppressed != 0 || len(kept) != 3 {
+		t.Errorf("expected no suppressions to keep every warning")
+	}
+}
@@ -0,0 +1,189 @@
+package imagetools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/remotes"
+	"github.com/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// mediaTypeEmptyJSON is the config of artifact manifests, which have none.
+const mediaTypeEmptyJSON = "application/vnd.oci.empty.v1+json"
+
+// Artifact is a blob attached to an image, such as an SBOM.
+type Artifact struct {
+	// ArtifactType is the media type of Data, such as "application/spdx+json".
+	ArtifactType string
+	Data         []byte
+	Annotations  map[string]string
+}
+
+// artifactManifest is an image manifest with the artifactType of image-spec
+// v1.1, which the vendored image-spec does not have yet.
+type artifactManifest struct {
+	specs.Versioned
+	MediaType    string               `json:"mediaType"`
+	ArtifactType string               `json:"artifactType"`
+	Config       ocispec.Descriptor   `json:"config"`
+	Layers       []ocispec.Descriptor `json:"layers"`
+	Subject      *ocispec.Descriptor  `json:"subject"`
+	Annotations  map[string]string    `json:"annotations,omitempty"`
+}
+
+// DEPOT: AttachReferrer pushes a manifest of the artifact whose subject is
+// the manifest of ref, so that the registry lists it as a referrer of the
+// image.  Registries without the referrers API list it in the index of the
+// referrers tag schema instead, whose tag is returned.
+func (r *Resolver) AttachReferrer(ctx context.Context, ref string, artifact Artifact) (ocispec.Descriptor, string, error) {
+	named, err := parseRef(ref)
+	if err != nil {
+		return ocispec.Descriptor{}, "", err
+	}
+	repo := reference.TrimNamed(named)
+
+	_, subject, err := r.Resolve(ctx, ref)
+	if err != nil {
+		return ocispec.Descriptor{}, "", err
+	}
+	subject = ocispec.Descriptor{MediaType: subject.MediaType, Digest: subject.Digest, Size: subject.Size}
+
+	ctx = remotes.WithMediaTypeKeyPrefix(ctx, mediaTypeEmptyJSON, "config")
+	ctx = remotes.WithMediaTypeKeyPrefix(ctx, artifact.ArtifactType, "artifact")
+
+	config := []byte("{}")
+	configDesc := ocispec.Descriptor{MediaType: mediaTypeEmptyJSON, Digest: digest.FromBytes(config), Size: int64(len(config))}
+	layerDesc := ocispec.Descriptor{MediaType: artifact.ArtifactType, Digest: digest.FromBytes(artifact.Data), Size: int64(len(artifact.Data))}
+	for _, blob := range []struct {
+		desc ocispec.Descriptor
+		dt   []byte
+	}{{configDesc, config}, {layerDesc, artifact.Data}} {
+		if err := r.Push(ctx, repo, blob.desc, blob.dt); err != nil {
+			return ocispec.Descriptor{}, "", errors.Wrapf(err, "failed to push %s", blob.desc.MediaType)
+		}
+	}
+
+	manifest := artifactManifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: artifact.ArtifactType,
+		Config:       configDesc,
+		Layers:       []ocispec.Descriptor{layerDesc},
+		Subject:      &subject,
+		Annotations:  artifact.Annotations,
+	}
+	dt, err := json.Marshal(manifest)
+	if err != nil {
+		return ocispec.Descriptor{}, "", errors.Wrap(err, "failed to marshal artifact manifest")
+	}
+	desc := ocispec.Descriptor{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: artifact.ArtifactType,
+		Digest:       digest.FromBytes(dt),
+		Size:         int64(len(dt)),
+		Annotations:  artifact.Annotations,
+	}
+
+	manifestRef, err := reference.WithDigest(repo, desc.Digest)
+	if err != nil {
+		return ocispec.Descriptor{}, "", err
+	}
+	// The descriptor pushed to the registry has no annotations.
+	if err := r.Push(ctx, manifestRef, ocispec.Descriptor{MediaType: desc.MediaType, Digest: desc.Digest, Size: desc.Size}, dt); err != nil {
+		return ocispec.Descriptor{}, "", errors.Wrap(err, "failed to push artifact manifest")
+	}
+
+	supported, err := r.supportsReferrers(ctx, repo, subject.Digest)
+	if err != nil {
+		return ocispec.Descriptor{}, "", err
+	}
+	if supported {
+		return desc, "", nil
+	}
+
+	tag, err := r.addToReferrersTag(ctx, repo, subject.Digest, desc)
+	if err != nil {
+		return ocispec.Descriptor{}, "", err
+	}
+	return desc, tag, nil
+}
+
+// supportsReferrers reports whether the registry of repo has the referrers
+// API, which answers a request for the referrers of a manifest with 200.
+func (r *Resolver) supportsReferrers(ctx context.Context, repo reference.Named, subject digest.Digest) (bool, error) {
+	hosts, err := r.hosts(reference.Domain(repo))
+	if err != nil {
+		return false, err
+	}
+	if len(hosts) == 0 {
+		return false, errors.Errorf("no registry host for %s", repo)
+	}
+	// Mirrors are listed before the registry itself.
+	host := hosts[len(hosts)-1]
+
+	u := fmt.Sprintf("%s://%s%s/%s/referrers/%s", host.Scheme, host.Host, host.Path, reference.Path(repo), subject)
+	resp, err := r.requestAuthorized(ctx, host, u)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, errors.Errorf("unexpected status %s listing referrers of %s", resp.Status, subject)
+	}
+}
+
+// addToReferrersTag adds the manifest to the index tagged "<alg>-<hex>" after
+// the subject digest, the referrers tag schema of the OCI distribution spec.
+func (r *Resolver) addToReferrersTag(ctx context.Context, repo reference.Named, subject digest.Digest, desc ocispec.Descriptor) (string, error) {
+	tag := strings.Replace(subject.String(), ":", "-", 1)
+	tagged, err := reference.WithTag(repo, tag)
+	if err != nil {
+		return "", err
+	}
+
+	index := ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+	}
+	dt, _, err := r.Get(ctx, tagged.String())
+	switch {
+	case errdefs.IsNotFound(err):
+	case err != nil:
+		return "", err
+	default:
+		if err := json.Unmarshal(dt, &index); err != nil {
+			return "", errors.Wrapf(err, "invalid referrers index %s", tagged)
+		}
+	}
+
+	for _, m := range index.Manifests {
+		if m.Digest == desc.Digest {
+			return tag, nil
+		}
+	}
+	index.Manifests = append(index.Manifests, desc)
+
+	dt, err = json.Marshal(index)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal referrers index")
+	}
+	indexDesc := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageIndex, Digest: digest.FromBytes(dt), Size: int64(len(dt))}
+	if err := r.Push(ctx, tagged, indexDesc, dt); err != nil {
+		return "", errors.Wrap(err, "failed to push referrers index")
+	}
+	return tag, nil
+}
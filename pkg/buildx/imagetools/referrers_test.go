@@ -0,0 +1,146 @@
+package imagetools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/docker/buildx/util/resolver"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// testRegistry is an in-memory registry of a single repository.
+type testRegistry struct {
+	referrers bool
+
+	mu        sync.Mutex
+	blobs     map[digest.Digest][]byte
+	manifests map[string][]byte
+	types     map[string]string
+}
+
+func newTestRegistry(referrers bool) *testRegistry {
+	return &testRegistry{referrers: referrers, blobs: map[digest.Digest][]byte{}, manifests: map[string][]byte{}, types: map[string]string{}}
+}
+
+func (r *testRegistry) putManifest(ref, mediaType string, dt []byte) digest.Digest {
+	dgst := digest.FromBytes(dt)
+	r.manifests[ref], r.types[ref] = dt, mediaType
+	r.manifests[dgst.String()], r.types[dgst.String()] = dt, mediaType
+	return dgst
+}
+
+func (r *testRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	path := strings.TrimPrefix(req.URL.Path, "/v2/app/")
+	switch {
+	case req.URL.Path == "/v2/":
+		w.WriteHeader(http.StatusOK)
+	case strings.HasPrefix(path, "referrers/"):
+		if !r.referrers {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", ocispec.MediaTypeImageIndex)
+		_, _ = w.Write([]byte(`{"schemaVersion":2,"manifests":[]}`))
+	case strings.HasPrefix(path, "manifests/"):
+		ref := strings.TrimPrefix(path, "manifests/")
+		if req.Method == http.MethodPut {
+			dt, _ := io.ReadAll(req.Body)
+			dgst := r.putManifest(ref, req.Header.Get("Content-Type"), dt)
+			w.Header().Set("Docker-Content-Digest", dgst.String())
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		dt, ok := r.manifests[ref]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", r.types[ref])
+		w.Header().Set("Docker-Content-Digest", digest.FromBytes(dt).String())
+		w.Header().Set("Content-Length", fmt.Sprint(len(dt)))
+		if req.Method == http.MethodGet {
+			_, _ = w.Write(dt)
+		}
+	case path == "blobs/uploads/":
+		w.Header().Set("Location", "/v2/app/blobs/uploads/1")
+		w.WriteHeader(http.StatusAccepted)
+	case path == "blobs/uploads/1":
+		dt, _ := io.ReadAll(req.Body)
+		dgst := digest.Digest(req.URL.Query().Get("digest"))
+		r.blobs[dgst] = dt
+		w.Header().Set("Docker-Content-Digest", dgst.String())
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(path, "blobs/"):
+		dt, ok := r.blobs[digest.Digest(strings.TrimPrefix(path, "blobs/"))]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", fmt.Sprint(len(dt)))
+		if req.Method == http.MethodGet {
+			_, _ = w.Write(dt)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestAttachReferrer(t *testing.T) {
+	for _, referrers := range []bool{true, false} {
+		registry := newTestRegistry(referrers)
+		image := []byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},"layers":[]}`)
+		subject := registry.putManifest("1.0", ocispec.MediaTypeImageManifest, image)
+
+		srv := httptest.NewServer(registry)
+		host := strings.TrimPrefix(srv.URL, "http://")
+		plainHTTP := true
+		r := New(Opt{RegistryConfig: map[string]resolver.RegistryConfig{host: {PlainHTTP: &plainHTTP}}})
+
+		sbom := []byte(`{"spdxVersion":"SPDX-2.3"}`)
+		desc, tag, err := r.AttachReferrer(context.Background(), host+"/app:1.0", Artifact{ArtifactType: "application/spdx+json", Data: sbom})
+		srv.Close()
+		if err != nil {
+			t.Fatalf("referrers %v: %v", referrers, err)
+		}
+
+		var manifest artifactManifest
+		if err := json.Unmarshal(registry.manifests[desc.Digest.String()], &manifest); err != nil {
+			t.Fatalf("referrers %v: %v", referrers, err)
+		}
+		if manifest.Subject == nil || manifest.Subject.Digest != subject || manifest.ArtifactType != "application/spdx+json" {
+			t.Errorf("referrers %v: manifest = %+v, want subject %s", referrers, manifest, subject)
+		}
+		if string(registry.blobs[manifest.Layers[0].Digest]) != string(sbom) {
+			t.Errorf("referrers %v: the SBOM was not pushed", referrers)
+		}
+
+		if referrers {
+			if tag != "" {
+				t.Errorf("tag = %q, want none with the referrers API", tag)
+			}
+			continue
+		}
+		wantTag := strings.Replace(subject.String(), ":", "-", 1)
+		if tag != wantTag {
+			t.Errorf("tag = %q, want %q", tag, wantTag)
+		}
+		var index ocispec.Index
+		if err := json.Unmarshal(registry.manifests[wantTag], &index); err != nil {
+			t.Fatal(err)
+		}
+		if len(index.Manifests) != 1 || index.Manifests[0].Digest != desc.Digest || index.Manifests[0].ArtifactType != "application/spdx+json" {
+			t.Errorf("referrers index = %+v, want the artifact manifest", index)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package imagetools
+
+import (
+	"context"
+	"sort"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/remotes"
+)
+
+// Statement is an in-toto statement of an attestation manifest.
+type Statement struct {
+	// Platform is the platform of the image the statement describes.
+	Platform      string
+	PredicateType string
+	// Payload is the statement without any DSSE envelope.
+	Payload []byte
+}
+
+// Statements returns the in-toto statements, such as the provenance and
+// SBOMs, that are attached to the images of ref by attestation manifests.
+func (r *Resolver) Statements(ctx context.Context, ref string) ([]Statement, error) {
+	l := newLoader(r.resolver())
+	res, err := l.Load(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	named, err := parseRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	fetcher, err := r.resolver().Fetcher(ctx, named.String())
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = withIntotoMediaTypes(ctx)
+	var statements []Statement
+	for _, platform := range res.platforms {
+		refs := res.refs[res.images[platform]]
+		sort.Slice(refs, func(i, j int) bool { return refs[i] < refs[j] })
+		for _, dgst := range refs {
+			mfst, ok := res.manifests[dgst]
+			if !ok {
+				continue
+			}
+			for _, layer := range mfst.manifest.Layers {
+				if layer.MediaType != inTotoGenericMime && !isInTotoDSSE(layer.MediaType) {
+					continue
+				}
+				if _, err := remotes.FetchHandler(l.cache, fetcher)(ctx, layer); err != nil {
+					return nil, err
+				}
+				dt, err := content.ReadBlob(ctx, l.cache, layer)
+				if err != nil {
+					return nil, err
+				}
+				dt, err = decodeDSSE(dt, layer.MediaType)
+				if err != nil {
+					return nil, err
+				}
+				statements = append(statements, Statement{
+					Platform:      platform,
+					PredicateType: layer.Annotations["in-toto.io/predicate-type"],
+					Payload:       dt,
+				})
+			}
+		}
+	}
+	return statements, nil
+}
@@ -0,0 +1,105 @@
+package imagetools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/depot/cli/pkg/registry"
+	"github.com/distribution/reference"
+	"github.com/pkg/errors"
+)
+
+// maxTagPages limits the tags listed for repositories with very many tags.
+const maxTagPages = 20
+
+// DEPOT: Tags lists the tags of a repository with the registry tags API.
+func (r *Resolver) Tags(ctx context.Context, repo string) ([]string, error) {
+	named, err := reference.ParseNormalizedNamed(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts, err := r.hosts(reference.Domain(named))
+	if err != nil {
+		return nil, err
+	}
+	if len(hosts) == 0 {
+		return nil, errors.Errorf("no registry host for %s", repo)
+	}
+	// Mirrors are listed before the registry itself.
+	host := hosts[len(hosts)-1]
+
+	u := fmt.Sprintf("%s://%s%s/%s/tags/list?n=1000", host.Scheme, host.Host, host.Path, reference.Path(named))
+	var tags []string
+	for page := 0; page < maxTagPages && u != ""; page++ {
+		resp, err := r.doAuthorized(ctx, host, u)
+		if err != nil {
+			return nil, err
+		}
+
+		var list struct {
+			Tags []string `json:"tags"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&list)
+		resp.Body.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid tags list for %s", repo)
+		}
+		tags = append(tags, list.Tags...)
+
+		u, err = registry.NextPage(u, resp.Header.Get("Link"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tags, nil
+}
+
+// doAuthorized makes a GET request and retries once after authorizing with
+// the challenge of an unauthorized response.
+func (r *Resolver) doAuthorized(ctx context.Context, host docker.RegistryHost, u string) (*http.Response, error) {
+	resp, err := r.requestAuthorized(ctx, host, u)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Errorf("unexpected status %s listing tags", resp.Status)
+	}
+	return resp, nil
+}
+
+// requestAuthorized is doAuthorized that returns responses of any status.
+func (r *Resolver) requestAuthorized(ctx context.Context, host docker.RegistryHost, u string) (*http.Response, error) {
+	client := host.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.auth.Authorize(ctx, req); err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusUnauthorized && attempt == 0 {
+			err := r.auth.AddResponses(ctx, []*http.Response{resp})
+			resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		return resp, nil
+	}
+}
@@ -3,6 +3,7 @@ package buildxdriver
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"time"
 
 	depotbuild "github.com/depot/cli/pkg/build"
@@ -12,8 +13,6 @@ import (
 	"github.com/docker/buildx/driver"
 	"github.com/docker/buildx/util/progress"
 	"github.com/moby/buildkit/client"
-	"github.com/moby/buildkit/identity"
-	"github.com/opencontainers/go-digest"
 )
 
 var _ driver.Driver = (*Driver)(nil)
@@ -46,29 +45,31 @@ func (d *Driver) Bootstrap(ctx context.Context, reporter progress.Logger) error
 
 	message := "[depot] launching " + platform + " machine"
 
-	// Try to acquire machine twice
+	finishLog := progresshelper.StartLog(reportingLogger, message)
 	var err error
-	for i := 0; i < 2; i++ {
-		finishLog := StartLog(message, reportingLogger)
-		d.buildkit, err = machine.Acquire(ctx, buildID, token, platform)
-		finishLog(err)
-		if err == nil {
-			break
-		}
-	}
-
+	d.buildkit, err = machine.Acquire(ctx, buildID, token, platform, machine.WithPlacement(placement(d.cfg.DriverOpts)))
+	finishLog(err)
 	if err != nil {
 		return err
 	}
 
 	message = "[depot] connecting to " + platform + " machine"
-	finishLog := StartLog(message, reportingLogger)
+	finishLog = progresshelper.StartLog(reportingLogger, message)
 
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
 	defer cancel()
 
 	_, err = d.buildkit.Connect(ctx)
 	finishLog(err)
+	if err == nil && d.buildkit.BuildkitVersion != "" {
+		progresshelper.Log(reportingLogger, "[depot] "+platform+" machine running buildkit "+d.buildkit.BuildkitVersion, nil)
+	}
+	if err == nil && d.buildkit.Info.MachineID != "" {
+		progresshelper.Log(reportingLogger, "[depot] "+platform+" machine "+d.buildkit.Info.String(), nil)
+	}
+	if err == nil && d.buildkit.Transport() == "websocket" {
+		progresshelper.Log(reportingLogger, "[depot] "+platform+" machine connected over websocket", nil)
+	}
 
 	// Store the machine connection details in the driver config so they can be
 	// accessed by clients that need to create new connections to the machine.
@@ -79,10 +80,34 @@ func (d *Driver) Bootstrap(ctx context.Context, reporter progress.Logger) error
 	d.cfg.DriverOpts["caCert"] = d.buildkit.CACert
 	d.cfg.DriverOpts["key"] = d.buildkit.Key
 	d.cfg.DriverOpts["cert"] = d.buildkit.Cert
+	d.cfg.DriverOpts["machineID"] = d.buildkit.Info.MachineID
+	d.cfg.DriverOpts["machineRegion"] = d.buildkit.Info.Region
+	d.cfg.DriverOpts["machineInstanceType"] = d.buildkit.Info.InstanceType
+	d.cfg.DriverOpts["machineIPAddress"] = d.buildkit.Info.IPAddress
 
 	return err
 }
 
+// placement reads the placement hints set with builder.WithPlacement.
+func placement(opts map[string]string) machine.Placement {
+	p := machine.Placement{Region: opts["region"]}
+	if near := opts["near"]; near != "" {
+		p.Near = strings.Split(near, ",")
+	}
+	return p
+}
+
+// MachineInfo returns the machine details stored in the driver options of a
+// node after it bootstrapped.
+func MachineInfo(opts map[string]string) machine.Info {
+	return machine.Info{
+		MachineID:    opts["machineID"],
+		Region:       opts["machineRegion"],
+		InstanceType: opts["machineInstanceType"],
+		IPAddress:    opts["machineIPAddress"],
+	}
+}
+
 func (d *Driver) Info(ctx context.Context) (*driver.Info, error) {
 	debuglog.Log("Driver Info() called")
 	defer debuglog.Log("Driver Info() done")
@@ -134,34 +159,8 @@ func (d *Driver) Stop(ctx context.Context, force bool) error {
 }
 
 func (d *Driver) Version(ctx context.Context) (string, error) {
-	return "", nil
-}
-
-func StartLog(message string, logger *progresshelper.Reporter) func(err error) {
-	dgst := digest.FromBytes([]byte(identity.NewID()))
-	tm := time.Now()
-	logger.Write(&client.SolveStatus{
-		Vertexes: []*client.Vertex{{
-			Digest:  dgst,
-			Name:    message,
-			Started: &tm,
-		}},
-	})
-
-	return func(err error) {
-		tm2 := time.Now()
-		errMsg := ""
-		if err != nil {
-			errMsg = err.Error()
-		}
-		logger.Write(&client.SolveStatus{
-			Vertexes: []*client.Vertex{{
-				Digest:    dgst,
-				Name:      message,
-				Started:   &tm,
-				Completed: &tm2,
-				Error:     errMsg,
-			}},
-		})
+	if d.buildkit == nil {
+		return "", nil
 	}
+	return d.buildkit.BuildkitVersion, nil
 }
@@ -74,3 +74,51 @@ func TestProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestProviderContext(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want Context
+	}{
+		{
+			name: "GitHub Actions pull request",
+			env: map[string]string{
+				"GITHUB_ACTIONS":    "true",
+				"GITHUB_REF":        "refs/pull/123/merge",
+				"GITHUB_HEAD_REF":   "feature",
+				"GITHUB_RUN_ID":     "42",
+				"GITHUB_SHA":        "abc",
+				"GITHUB_SERVER_URL": "https://github.com",
+				"GITHUB_REPOSITORY": "depot/cli",
+			},
+			want: Context{Provider: "GitHub Actions", PullRequest: "123", WorkflowID: "42", CommitSHA: "abc", Ref: "feature", URL: "https://github.com/depot/cli/actions/runs/42"},
+		},
+		{
+			name: "CircleCI pull request URL",
+			env:  map[string]string{"CIRCLECI": "true", "CIRCLE_PULL_REQUEST": "https://github.com/depot/cli/pull/7", "CIRCLE_BUILD_NUM": "9"},
+			want: Context{Provider: "CircleCI", PullRequest: "7", JobID: "9"},
+		},
+		{
+			name: "Buildkite without pull request",
+			env:  map[string]string{"BUILDKITE": "true", "BUILDKITE_PULL_REQUEST": "false", "BUILDKITE_BRANCH": "main"},
+			want: Context{Provider: "Buildkite", Ref: "main"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Clearenv()
+			for k, v := range tt.env {
+				os.Setenv(k, v)
+			}
+
+			got, ok := ProviderContext()
+			if !ok {
+				t.Fatalf("ProviderContext() not in CI")
+			}
+			if *got != tt.want {
+				t.Errorf("ProviderContext() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package ci
+
+import (
+	"os"
+	"path"
+	"strings"
+)
+
+// Context describes the CI job that started a build.  Fields the provider
+// does not expose are empty.
+type Context struct {
+	Provider    string `json:"provider"`
+	PullRequest string `json:"pullRequest,omitempty"`
+	WorkflowID  string `json:"workflowID,omitempty"`
+	JobID       string `json:"jobID,omitempty"`
+	CommitSHA   string `json:"commitSHA,omitempty"`
+	Actor       string `json:"actor,omitempty"`
+	Ref         string `json:"ref,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// ProviderContext returns the context of the CI job when running in CI.
+// GitHub Actions, GitLab CI, CircleCI, and Buildkite provide the job details;
+// other providers only provide their name.
+func ProviderContext() (*Context, bool) {
+	provider, isCI := Provider()
+	if !isCI {
+		return nil, false
+	}
+
+	ctx := &Context{Provider: provider}
+	switch provider {
+	case "GitHub Actions":
+		ctx.PullRequest = githubPullRequest(os.Getenv("GITHUB_REF"))
+		ctx.WorkflowID = os.Getenv("GITHUB_RUN_ID")
+		ctx.JobID = os.Getenv("GITHUB_JOB")
+		ctx.CommitSHA = os.Getenv("GITHUB_SHA")
+		ctx.Actor = os.Getenv("GITHUB_ACTOR")
+		ctx.Ref = firstEnv("GITHUB_HEAD_REF", "GITHUB_REF_NAME")
+		if server, repo := os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"); server != "" && repo != "" && ctx.WorkflowID != "" {
+			ctx.URL = server + "/" + repo + "/actions/runs/" + ctx.WorkflowID
+		}
+	case "GitLab CI":
+		ctx.PullRequest = os.Getenv("CI_MERGE_REQUEST_IID")
+		ctx.WorkflowID = os.Getenv("CI_PIPELINE_ID")
+		ctx.JobID = os.Getenv("CI_JOB_ID")
+		ctx.CommitSHA = os.Getenv("CI_COMMIT_SHA")
+		ctx.Actor = os.Getenv("GITLAB_USER_LOGIN")
+		ctx.Ref = firstEnv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "CI_COMMIT_REF_NAME")
+		ctx.URL = os.Getenv("CI_PIPELINE_URL")
+	case "CircleCI":
+		// CIRCLE_PULL_REQUEST is the URL of the pull request.
+		if pr := os.Getenv("CIRCLE_PULL_REQUEST"); pr != "" {
+			ctx.PullRequest = path.Base(pr)
+		}
+		ctx.WorkflowID = os.Getenv("CIRCLE_WORKFLOW_ID")
+		ctx.JobID = firstEnv("CIRCLE_WORKFLOW_JOB_ID", "CIRCLE_BUILD_NUM")
+		ctx.CommitSHA = os.Getenv("CIRCLE_SHA1")
+		ctx.Actor = os.Getenv("CIRCLE_USERNAME")
+		ctx.Ref = firstEnv("CIRCLE_BRANCH", "CIRCLE_TAG")
+		ctx.URL = os.Getenv("CIRCLE_BUILD_URL")
+	case "Buildkite":
+		// BUILDKITE_PULL_REQUEST is "false" for builds not started by a pull request.
+		if pr := os.Getenv("BUILDKITE_PULL_REQUEST"); pr != "false" {
+			ctx.PullRequest = pr
+		}
+		ctx.WorkflowID = os.Getenv("BUILDKITE_BUILD_ID")
+		ctx.JobID = os.Getenv("BUILDKITE_JOB_ID")
+		ctx.CommitSHA = os.Getenv("BUILDKITE_COMMIT")
+		ctx.Actor = os.Getenv("BUILDKITE_BUILD_CREATOR")
+		ctx.Ref = os.Getenv("BUILDKITE_BRANCH")
+		ctx.URL = os.Getenv("BUILDKITE_BUILD_URL")
+	}
+
+	return ctx, true
+}
+
+// githubPullRequest returns the pull request number of refs like refs/pull/123/merge.
+func githubPullRequest(ref string) string {
+	rest, ok := strings.CutPrefix(ref, "refs/pull/")
+	if !ok {
+		return ""
+	}
+	number, _, _ := strings.Cut(rest, "/")
+	return number
+}
+
+func firstEnv(keys ...string) string {
+	for _, key := range keys {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
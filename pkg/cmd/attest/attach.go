@@ -0,0 +1,265 @@
+package attest
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	"github.com/depot/cli/pkg/buildx/imagetools"
+	"github.com/depot/cli/pkg/dockerclient"
+	"github.com/depot/cli/pkg/helpers"
+	"github.com/distribution/reference"
+	"github.com/docker/cli/cli"
+	clitypes "github.com/docker/cli/cli/config/types"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	depotRegistry = "registry.depot.dev"
+
+	mediaTypeSPDX      = "application/spdx+json"
+	mediaTypeCycloneDX = "application/vnd.cyclonedx+json"
+	mediaTypeInToto    = "application/vnd.in-toto+json"
+	mediaTypeDSSE      = "application/vnd.dsse.envelope.v1+json"
+
+	annotationCreated       = "org.opencontainers.image.created"
+	annotationPredicateType = "in-toto.io/predicate-type"
+)
+
+type attestationKind string
+
+const (
+	kindSBOM       attestationKind = "sbom"
+	kindProvenance attestationKind = "provenance"
+)
+
+func NewCmdAttestAttach() *cobra.Command {
+	var (
+		sboms       []string
+		provenances []string
+		platform    string
+		token       string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "attach [flags] IMAGE",
+		Short: "Attach SBOMs and provenance to a pushed image as OCI referrers",
+		Long: `Attach SBOMs and provenance to an image that was already pushed, such as the
+SBOM of a later rescan, without rebuilding it.  Each file is pushed to the
+repository of the image as an artifact whose subject is the image, so that
+the referrers API of the registry lists it.  For registries without the
+referrers API, it is added to the referrers tag of the image instead.`,
+		Example: `  # Attach the SBOM of a rescan to an image
+  depot attest attach registry.example.com/app:1.2.3 --sbom sbom.spdx.json
+
+  # Attach provenance to the linux/arm64 image of a multi-platform image
+  depot attest attach registry.example.com/app:1.2.3 --platform linux/arm64 --provenance provenance.intoto.json`,
+		Args: cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(sboms) == 0 && len(provenances) == 0 {
+				return errors.New("specify the attestations to attach with --sbom or --provenance")
+			}
+
+			type attachment struct {
+				path     string
+				artifact imagetools.Artifact
+			}
+			var attachments []attachment
+			for _, files := range []struct {
+				kind  attestationKind
+				paths []string
+			}{{kindSBOM, sboms}, {kindProvenance, provenances}} {
+				for _, path := range files.paths {
+					artifact, err := readAttestation(path, files.kind)
+					if err != nil {
+						return err
+					}
+					attachments = append(attachments, attachment{path: path, artifact: artifact})
+				}
+			}
+
+			ctx := cmd.Context()
+			named, err := reference.ParseNormalizedNamed(args[0])
+			if err != nil {
+				return err
+			}
+			if reference.Domain(named) == depotRegistry {
+				token, err = helpers.ResolveToken(ctx, token)
+				if err != nil {
+					return err
+				}
+			}
+
+			dockerCli, err := dockerclient.NewDockerCLI()
+			if err != nil {
+				return err
+			}
+			resolver := imagetools.New(imagetools.Opt{Auth: depotRegistryAuth{Auth: dockerCli.ConfigFile(), token: token}})
+
+			subject, err := resolveSubject(ctx, resolver, named, platform)
+			if err != nil {
+				return err
+			}
+
+			for _, attachment := range attachments {
+				desc, tag, err := resolver.AttachReferrer(ctx, subject, attachment.artifact)
+				if err != nil {
+					return errors.Wrapf(err, "unable to attach %s", attachment.path)
+				}
+				fmt.Printf("Attached %s to %s as %s\n", attachment.path, subject, desc.Digest)
+				if tag != "" {
+					fmt.Printf("  The registry has no referrers API, the attestation is listed in the %s tag\n", tag)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&sboms, "sbom", nil, "SPDX or CycloneDX JSON SBOM, or an in-toto statement or DSSE envelope of one, to attach")
+	cmd.Flags().StringArrayVar(&provenances, "provenance", nil, "SLSA provenance in-toto statement or DSSE envelope to attach")
+	cmd.Flags().StringVar(&platform, "platform", "", "Attach to the image of this platform of a multi-platform image instead of the image index")
+	cmd.Flags().StringVar(&token, "token", "", "Depot token for images of the Depot registry")
+
+	return cmd
+}
+
+// resolveSubject returns the digest reference of the image to attach to: the
+// image of the platform of a multi-platform image or else the image itself.
+func resolveSubject(ctx context.Context, resolver *imagetools.Resolver, named reference.Named, platform string) (string, error) {
+	dt, desc, err := resolver.Get(ctx, named.String())
+	if err != nil {
+		return "", err
+	}
+	repo := reference.TrimNamed(named)
+
+	if platform != "" {
+		if !images.IsIndexType(desc.MediaType) {
+			return "", errors.Errorf("%s is not a multi-platform image, omit --platform", named)
+		}
+		p, err := platforms.Parse(platform)
+		if err != nil {
+			return "", err
+		}
+		var index ocispec.Index
+		if err := json.Unmarshal(dt, &index); err != nil {
+			return "", errors.Wrapf(err, "invalid image index %s", named)
+		}
+
+		matcher := platforms.OnlyStrict(p)
+		found := false
+		for _, m := range index.Manifests {
+			if m.Platform != nil && matcher.Match(*m.Platform) {
+				desc, found = m, true
+				break
+			}
+		}
+		if !found {
+			return "", errors.Errorf("%s has no image for platform %s", named, platforms.Format(p))
+		}
+	}
+
+	subject, err := reference.WithDigest(repo, desc.Digest)
+	if err != nil {
+		return "", err
+	}
+	return subject.String(), nil
+}
+
+// readAttestation reads an attestation file and detects its media type.
+func readAttestation(path string, kind attestationKind) (imagetools.Artifact, error) {
+	dt, err := os.ReadFile(path)
+	if err != nil {
+		return imagetools.Artifact{}, err
+	}
+	dt = bytes.TrimSpace(dt)
+
+	var doc struct {
+		Type          string `json:"_type"`
+		PredicateType string `json:"predicateType"`
+		PayloadType   string `json:"payloadType"`
+		Payload       string `json:"payload"`
+		SPDXVersion   string `json:"spdxVersion"`
+		BOMFormat     string `json:"bomFormat"`
+	}
+	if err := json.Unmarshal(dt, &doc); err != nil {
+		return imagetools.Artifact{}, errors.Wrapf(err, "%s is not a JSON %s", path, kind)
+	}
+
+	artifact := imagetools.Artifact{
+		Data:        dt,
+		Annotations: map[string]string{annotationCreated: time.Now().UTC().Format(time.RFC3339)},
+	}
+	predicateType := doc.PredicateType
+	switch {
+	case doc.PayloadType != "":
+		artifact.ArtifactType = mediaTypeDSSE
+		predicateType, err = envelopePredicateType(doc.PayloadType, doc.Payload)
+		if err != nil {
+			return imagetools.Artifact{}, errors.Wrapf(err, "invalid DSSE envelope %s", path)
+		}
+	case strings.HasPrefix(doc.Type, "https://in-toto.io/Statement/"):
+		artifact.ArtifactType = mediaTypeInToto
+	case kind == kindSBOM && doc.SPDXVersion != "":
+		artifact.ArtifactType = mediaTypeSPDX
+	case kind == kindSBOM && doc.BOMFormat == "CycloneDX":
+		artifact.ArtifactType = mediaTypeCycloneDX
+	case kind == kindSBOM:
+		return imagetools.Artifact{}, errors.Errorf("%s is not an SPDX or CycloneDX SBOM, an in-toto statement, or a DSSE envelope", path)
+	default:
+		return imagetools.Artifact{}, errors.Errorf("%s is not an in-toto statement or a DSSE envelope", path)
+	}
+
+	if kind == kindProvenance && !strings.HasPrefix(predicateType, "https://slsa.dev/provenance/") {
+		return imagetools.Artifact{}, errors.Errorf("%s is not SLSA provenance (predicate type %q)", path, predicateType)
+	}
+	if predicateType != "" {
+		artifact.Annotations[annotationPredicateType] = predicateType
+	}
+	return artifact, nil
+}
+
+// envelopePredicateType returns the predicate type of the in-toto statement of
+// a DSSE envelope.
+func envelopePredicateType(payloadType, payload string) (string, error) {
+	if payloadType != mediaTypeInToto {
+		return "", errors.Errorf("unsupported payload type %s", payloadType)
+	}
+	dt, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", err
+	}
+	var statement struct {
+		PredicateType string `json:"predicateType"`
+	}
+	if err := json.Unmarshal(dt, &statement); err != nil {
+		return "", err
+	}
+	return statement.PredicateType, nil
+}
+
+// depotRegistryAuth authenticates to the Depot registry with the Depot token
+// unless the Docker config has credentials for it.
+type depotRegistryAuth struct {
+	imagetools.Auth
+	token string
+}
+
+func (a depotRegistryAuth) GetAuthConfig(host string) (clitypes.AuthConfig, error) {
+	config, err := a.Auth.GetAuthConfig(host)
+	if err != nil || host != depotRegistry || a.token == "" {
+		return config, err
+	}
+	if config.Username != "" || config.Password != "" || config.Auth != "" || config.IdentityToken != "" {
+		return config, nil
+	}
+	return clitypes.AuthConfig{ServerAddress: host, Username: "x-token", Password: a.token}, nil
+}
@@ -0,0 +1,53 @@
+package attest
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadAttestation(t *testing.T) {
+	statement := `{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"https://slsa.dev/provenance/v0.2","subject":[],"predicate":{}}`
+	envelope := `{"payloadType":"application/vnd.in-toto+json","payload":"` + base64.StdEncoding.EncodeToString([]byte(statement)) + `","signatures":[]}`
+
+	tests := []struct {
+		name          string
+		kind          attestationKind
+		content       string
+		artifactType  string
+		predicateType string
+		wantErr       bool
+	}{
+		{name: "spdx", kind: kindSBOM, content: `{"spdxVersion":"SPDX-2.3"}`, artifactType: mediaTypeSPDX},
+		{name: "cyclonedx", kind: kindSBOM, content: `{"bomFormat":"CycloneDX","specVersion":"1.5"}`, artifactType: mediaTypeCycloneDX},
+		{name: "statement", kind: kindProvenance, content: statement, artifactType: mediaTypeInToto, predicateType: "https://slsa.dev/provenance/v0.2"},
+		{name: "envelope", kind: kindProvenance, content: envelope, artifactType: mediaTypeDSSE, predicateType: "https://slsa.dev/provenance/v0.2"},
+		{name: "unknown sbom", kind: kindSBOM, content: `{"packages":[]}`, wantErr: true},
+		{name: "sbom as provenance", kind: kindProvenance, content: `{"spdxVersion":"SPDX-2.3"}`, wantErr: true},
+		{name: "not json", kind: kindSBOM, content: `SPDXVersion: SPDX-2.3`, wantErr: true},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "attestation.json")
+		if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		artifact, err := readAttestation(path, tt.kind)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected an error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if artifact.ArtifactType != tt.artifactType {
+			t.Errorf("%s: artifact type = %q, want %q", tt.name, artifact.ArtifactType, tt.artifactType)
+		}
+		if artifact.Annotations[annotationPredicateType] != tt.predicateType {
+			t.Errorf("%s: predicate type = %q, want %q", tt.name, artifact.Annotations[annotationPredicateType], tt.predicateType)
+		}
+	}
+}
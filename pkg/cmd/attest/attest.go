@@ -0,0 +1,21 @@
+package attest
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmdAttest() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attest",
+		Short: "Manage the attestations of images",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fmt.Errorf("missing subcommand, please run `depot attest --help`")
+		},
+	}
+
+	cmd.AddCommand(NewCmdAttestAttach())
+
+	return cmd
+}
@@ -3,6 +3,7 @@ package build
 import (
 	"github.com/depot/cli/pkg/buildx/commands"
 	_ "github.com/depot/cli/pkg/buildxdriver"
+	_ "github.com/docker/buildx/driver/remote"
 	"github.com/spf13/cobra"
 )
 
@@ -137,32 +137,29 @@ func run() error {
 			ctx2 := context.TODO()
 			ctx2, cancelStatus = context.WithCancel(ctx2)
 
-			state.Reporter, err = progress.NewPrinter(ctx2, os.Stderr, os.Stderr, "quiet")
+			printer, err := progress.NewPrinter(ctx2, os.Stderr, os.Stderr, "quiet")
 			if err != nil {
 				state.Err = fmt.Errorf("unable to create buildx printer: %w", err)
 				cancel()
 				return
 			}
-			state.Reporter = progresshelper.Tee(state.Reporter, status)
 
-			reportingWriter := progresshelper.NewReporter(ctx2, state.Reporter, build.ID, build.Token)
-			defer reportingWriter.Close()
+			// Statuses go to the API and, through the status channel, to buildx.
+			reporter := progresshelper.NewReporter(ctx2, progresshelper.Tee(printer, status), build.ID, build.Token)
+			finishStatus = func() {
+				reporter.Close()
+				reporter.Wait()
+			}
+			state.Reporter = reporter
 
 			state.SummaryURL = build.BuildURL
 			buildFinish = build.Finish
 
-			if os.Getenv("DEPOT_NO_SUMMARY_LINK") == "" {
-				progresshelper.Log(state.Reporter, "[depot] build: "+state.SummaryURL, nil)
-			}
+			progresshelper.LogBuildURL(state.Reporter, state.SummaryURL)
 
 			var builder *machine.Machine
-			state.Err = progresshelper.WithLog(reportingWriter, "[depot] launching "+platform+" machine", func() error {
-				for i := 0; i < 2; i++ {
-					builder, state.Err = machine.Acquire(ctx, build.ID, build.Token, platform)
-					if state.Err == nil {
-						break
-					}
-				}
+			state.Err = progresshelper.WithLog(state.Reporter, "[depot] launching "+platform+" machine", func() error {
+				builder, state.Err = machine.Acquire(ctx, build.ID, build.Token, platform)
 				return state.Err
 			})
 			if state.Err != nil {
@@ -172,7 +169,7 @@ func run() error {
 
 			machineRelease = builder.Release
 
-			state.Err = progresshelper.WithLog(reportingWriter, "[depot] connecting to "+platform+" machine", func() error {
+			state.Err = progresshelper.WithLog(state.Reporter, "[depot] connecting to "+platform+" machine", func() error {
 				buildkitConn, err := tlsConn(ctx, builder)
 				if err != nil {
 					state.Err = fmt.Errorf("unable to connect: %w", err)
@@ -13,7 +13,8 @@ import (
 
 	content "github.com/containerd/containerd/api/services/content/v1"
 	"github.com/containerd/containerd/api/services/leases/v1"
-	"github.com/containerd/containerd/defaults"
+	"github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/config"
 	"github.com/depot/cli/pkg/progresshelper"
 	"github.com/docker/buildx/util/progress"
 	"github.com/gogo/protobuf/types"
@@ -60,15 +61,19 @@ func BuildkitdClient(ctx context.Context, conn net.Conn, buildkitdAddress string
 		return nil, err
 	}
 
+	// The DEPOT_GRPC_KEEPALIVE_* settings override the DEPOT_KEEPALIVE_*_MS
+	// variables that the depot.LoadKeepalive* defaults are read from.
 	opts := []grpc.DialOption{
 		grpc.WithBlock(),
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(defaults.DefaultMaxRecvMsgSize)),
-		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(defaults.DefaultMaxSendMsgSize)),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(config.GRPCMaxRecvMsgSize())),
+		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(config.GRPCMaxSendMsgSize())),
+		grpc.WithKeepaliveParams(config.GRPCClientKeepalive(depot.LoadKeepaliveClientParams())),
 		grpc.WithContextDialer(dialContext),
 		grpc.WithAuthority(uri.Host),
 		// conn is already a TLS connection.
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 	}
+	opts = append(opts, api.GRPCTraceOptions("proxy")...)
 
 	return grpc.DialContext(ctx, buildkitdAddress, opts...)
 }
@@ -79,8 +84,10 @@ func Proxy(ctx context.Context, conn net.Conn, acquireState func() *ProxyState,
 	defer cancel()
 
 	opts := []grpc.ServerOption{
-		grpc.KeepaliveEnforcementPolicy(depot.LoadKeepaliveEnforcementPolicy()),
-		grpc.KeepaliveParams(depot.LoadKeepaliveServerParams()),
+		grpc.KeepaliveEnforcementPolicy(config.GRPCEnforcementPolicy(depot.LoadKeepaliveEnforcementPolicy())),
+		grpc.KeepaliveParams(config.GRPCServerKeepalive(depot.LoadKeepaliveServerParams())),
+		grpc.MaxRecvMsgSize(config.GRPCMaxRecvMsgSize()),
+		grpc.MaxSendMsgSize(config.GRPCMaxSendMsgSize()),
 	}
 	server := grpc.NewServer(opts...)
 
@@ -103,7 +110,7 @@ func Proxy(ctx context.Context, conn net.Conn, acquireState func() *ProxyState,
 type ProxyState struct {
 	Conn       *grpc.ClientConn // Conn is the connection to the buildkitd server.
 	SummaryURL string           // SummaryURL is the UI summary page.
-	Reporter   progress.Writer  // Reporter forwards status events to the API.
+	Reporter   progress.Writer  // Reporter forwards status events to the API and buildx.
 	Err        error            // Err is set when the connection cannot be established or the build fails.
 }
 
@@ -217,11 +224,8 @@ func (p *ControlProxy) Status(in *control.StatusRequest, toBuildx control.Contro
 				return
 			}
 
-			// Drop if the buffer is backed up.
-			select {
-			case p.status <- client.NewSolveStatus(msg):
-			default:
-			}
+			// Reports the status to the API and forwards it to buildx.
+			state.Reporter.Write(client.NewSolveStatus(msg))
 		}
 	}()
 
@@ -0,0 +1,21 @@
+package buildkit
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmdBuildkit() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "buildkit",
+		Short: "Connect BuildKit clients to Depot builders [experimental]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fmt.Errorf("missing subcommand, please run `depot buildkit --help`")
+		},
+	}
+
+	cmd.AddCommand(NewCmdEndpoint())
+
+	return cmd
+}
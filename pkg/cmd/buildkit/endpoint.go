@@ -0,0 +1,231 @@
+package buildkit
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/depot/cli/pkg/cmd/exec"
+	"github.com/depot/cli/pkg/connection"
+	"github.com/depot/cli/pkg/helpers"
+	"github.com/depot/cli/pkg/machine"
+	"github.com/depot/cli/pkg/progresshelper"
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+	"github.com/docker/buildx/util/progress"
+	"github.com/docker/cli/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdEndpoint() *cobra.Command {
+	var (
+		token        string
+		projectID    string
+		platform     string
+		socket       string
+		tcpAddr      string
+		tlsOpts      tlsOptions
+		envVar       string
+		progressMode string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "endpoint [flags]",
+		Short: "Serve a BUILDKIT_HOST endpoint backed by a Depot builder [experimental]",
+		Long: `Acquire a Depot builder and serve a BuildKit endpoint for it until interrupted,
+so that BuildKit-native tools such as buildctl, Dagger, and Earthly can build
+on Depot by setting one environment variable.
+
+The endpoint is printed to stdout once the builder is ready.  Connections to
+the builder are authenticated by the CLI, so anyone who can connect to the
+endpoint can build on the builder.  By default it listens on a unix socket in
+a new directory that only the current user can open; --socket sets the path
+of the socket.  With --tcp it listens on a TCP address instead, which
+requires --tls-cert and --tls-key for the endpoint and --tls-ca to verify
+the certificates of the clients.`,
+		Example: `  depot buildkit endpoint --socket /tmp/depot-buildkit.sock &
+  BUILDKIT_HOST=unix:///tmp/depot-buildkit.sock buildctl build ...`,
+		Args: cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			token, err := helpers.ResolveToken(ctx, token)
+			if err != nil {
+				return err
+			}
+			if token == "" {
+				return fmt.Errorf("missing API token, please run `depot login`")
+			}
+			projectID = helpers.ResolveProjectID(projectID)
+			if projectID == "" {
+				selectedProject, err := helpers.OnboardProject(ctx, token)
+				if err != nil {
+					return err
+				}
+				projectID = selectedProject.ID
+			}
+
+			platform, err = exec.ResolveMachinePlatform(platform)
+			if err != nil {
+				return err
+			}
+
+			var tlsConfig *tls.Config
+			if tcpAddr != "" {
+				if socket != "" {
+					return fmt.Errorf("--socket and --tcp cannot be used together")
+				}
+				tlsConfig, err = tlsOpts.config()
+				if err != nil {
+					return err
+				}
+			} else if tlsOpts != (tlsOptions{}) {
+				return fmt.Errorf("--tls-cert, --tls-key, and --tls-ca require --tcp")
+			}
+
+			req := &cliv1.CreateBuildRequest{
+				ProjectId: &projectID,
+				Options:   []*cliv1.BuildOptions{{Command: cliv1.Command_COMMAND_EXEC}},
+			}
+			build, err := helpers.BeginBuild(ctx, req, token)
+			if err != nil {
+				return fmt.Errorf("unable to begin build: %w", err)
+			}
+			var buildErr error
+			defer func() {
+				build.Finish(buildErr)
+			}()
+
+			builder, buildErr := acquire(ctx, build.ID, build.Token, platform, progressMode)
+			if buildErr != nil {
+				return buildErr
+			}
+			defer func() { _ = builder.Release() }()
+
+			var (
+				listener net.Listener
+				endpoint string
+			)
+			switch {
+			case tcpAddr != "":
+				listener, buildErr = net.Listen("tcp", tcpAddr)
+				if buildErr == nil {
+					listener = tls.NewListener(listener, tlsConfig)
+					endpoint = "tcp://" + listener.Addr().String()
+				}
+			case socket != "":
+				listener, endpoint, buildErr = connection.UnixListener(socket)
+				if buildErr == nil {
+					defer os.Remove(socket)
+				}
+			default:
+				var dir string
+				dir, buildErr = os.MkdirTemp("", "depot-buildkit-")
+				if buildErr != nil {
+					return buildErr
+				}
+				defer os.RemoveAll(dir)
+				listener, endpoint, buildErr = connection.UnixListener(filepath.Join(dir, "buildkitd.sock"))
+			}
+			if buildErr != nil {
+				return buildErr
+			}
+
+			fmt.Println(endpoint)
+			fmt.Fprintf(os.Stderr, "[depot] serving the %s builder; export %s=%s and press Ctrl-C to stop\n", platform, envVar, endpoint)
+
+			// The proxy returns the last connection error, which does not
+			// fail the endpoint as clients come and go.
+			_ = connection.NewProxy(listener, builder).Start(ctx)
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&projectID, "project", "", "Depot project ID")
+	flags.StringVar(&token, "token", "", "Depot token")
+	flags.StringVar(&platform, "platform", "", `Platform of the builder ("linux/amd64", "linux/arm64")`)
+	flags.StringVar(&socket, "socket", "", "Serve the endpoint on this unix socket (default: a socket in a new private directory)")
+	flags.StringVar(&tcpAddr, "tcp", "", `Serve the endpoint on this TCP address with TLS instead (e.g., "127.0.0.1:1234")`)
+	flags.StringVar(&tlsOpts.cert, "tls-cert", "", "TLS certificate of the --tcp endpoint")
+	flags.StringVar(&tlsOpts.key, "tls-key", "", "TLS key of the --tcp endpoint")
+	flags.StringVar(&tlsOpts.ca, "tls-ca", "", "CA certificate that the client certificates of the --tcp endpoint must be signed by")
+	flags.StringVar(&envVar, "env-var", "BUILDKIT_HOST", "Environment variable name printed for the endpoint")
+	flags.StringVar(&progressMode, "progress", "auto", `Set type of progress output ("auto", "plain", "tty")`)
+
+	return cmd
+}
+
+// tlsOptions are the certificates of a TCP endpoint, which only accepts
+// clients with a certificate signed by the CA, as the builder is otherwise
+// open to anyone who can reach the address.
+type tlsOptions struct {
+	cert, key, ca string
+}
+
+func (o tlsOptions) config() (*tls.Config, error) {
+	if o.cert == "" || o.key == "" || o.ca == "" {
+		return nil, fmt.Errorf("--tcp requires --tls-cert, --tls-key, and --tls-ca")
+	}
+	cert, err := tls.LoadX509KeyPair(o.cert, o.key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the TLS certificate: %w", err)
+	}
+	ca, err := os.ReadFile(o.ca)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the TLS CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("no certificates found in %s", o.ca)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// acquire launches the builder and waits until it accepts connections.
+func acquire(ctx context.Context, buildID, token, platform, progressMode string) (*machine.Machine, error) {
+	printCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	printer, err := progress.NewPrinter(printCtx, os.Stderr, os.Stderr, progresshelper.ResolveMode(progressMode))
+	if err != nil {
+		return nil, err
+	}
+	reportingWriter := progresshelper.NewReporter(printCtx, printer, buildID, token)
+	defer func() {
+		reportingWriter.Close()
+		_ = printer.Wait()
+	}()
+
+	var builder *machine.Machine
+	err = progresshelper.WithLog(reportingWriter, fmt.Sprintf("[depot] launching %s machine", platform), func() error {
+		builder, err = machine.Acquire(ctx, buildID, token, platform)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = progresshelper.WithLog(reportingWriter, fmt.Sprintf("[depot] connecting to %s machine", platform), func() error {
+		conn, err := connection.TLSConn(ctx, builder)
+		if err != nil {
+			return fmt.Errorf("unable to connect: %w", err)
+		}
+		return conn.Close()
+	})
+	if err != nil {
+		_ = builder.Release()
+		return nil, err
+	}
+	return builder, nil
+}
@@ -11,16 +11,60 @@ import (
 )
 
 func NewMockBuildkit() *cobra.Command {
+	var (
+		addr      string
+		opts      MockOptions
+		errorCode string
+	)
+
 	var cmd = &cobra.Command{
 		Use:   "buildkitd <command> [flags]",
 		Short: "Mock buildkitd for buildx container driver",
-		Run: func(cmd *cobra.Command, args []string) {
+		Long:  "Mock buildkitd for buildx container driver. With --addr, it serves a mock buildkitd API with configurable worker platforms and injected failures for testing.",
+		RunE: func(cmd *cobra.Command, args []string) error {
 			sigCh := make(chan os.Signal, 1)
 			signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
-			<-sigCh
+
+			if addr == "" {
+				<-sigCh
+				return nil
+			}
+
+			code, err := ParseErrorCode(errorCode)
+			if err != nil {
+				return err
+			}
+			opts.ErrorCode = code
+
+			server, err := NewMockServer(opts)
+			if err != nil {
+				return err
+			}
+			l, err := listen(addr)
+			if err != nil {
+				return err
+			}
+
+			errCh := make(chan error, 1)
+			go func() { errCh <- server.Serve(l) }()
+			select {
+			case <-sigCh:
+				server.Stop()
+				return nil
+			case err := <-errCh:
+				return err
+			}
 		},
 	}
 
+	flags := cmd.Flags()
+	flags.StringVar(&addr, "addr", "", `Serve the mock buildkitd API on this address (e.g., "unix:///run/buildkit/buildkitd.sock", "tcp://127.0.0.1:1234")`)
+	flags.StringSliceVar(&opts.Platforms, "platform", nil, "Platforms of the mock worker (default: host platform)")
+	flags.DurationVar(&opts.Latency, "latency", 0, "Delay every call by this long")
+	flags.IntVar(&opts.DropStreamsAfter, "drop-streams-after", 0, "Fail status streams after this many messages")
+	flags.Float64Var(&opts.ErrorRate, "error-rate", 0, "Fraction of calls that fail, from 0 to 1")
+	flags.StringVar(&errorCode, "error-code", "unavailable", `gRPC code of the injected errors (e.g., "unavailable", "resource_exhausted")`)
+
 	cmd.SetVersionTemplate(`{{with .Name}}{{printf "%s github.com/depot/cli " .}}{{end}}{{printf "%s\n" .Version}}`)
 	cmd.Version = fmt.Sprintf("%s 2951a28cd7085eb18979b1f710678623d94ed578", depot.Version)
 
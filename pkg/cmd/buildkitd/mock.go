@@ -0,0 +1,212 @@
+package buildkitd
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/platforms"
+	depot "github.com/depot/cli/internal/build"
+	"github.com/depot/cli/pkg/connection"
+	control "github.com/moby/buildkit/api/services/control"
+	types "github.com/moby/buildkit/api/types"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MockOptions are the behavior and failure modes of the mock buildkitd API.
+type MockOptions struct {
+	// Platforms are the platforms of the mock worker, e.g. "linux/arm64".
+	// The host platform is used when empty.
+	Platforms []string
+	// Latency delays every call.
+	Latency time.Duration
+	// DropStreamsAfter fails status streams with codes.Unavailable after this
+	// many messages.  Zero never drops streams.
+	DropStreamsAfter int
+	// ErrorRate is the fraction of calls, from 0 to 1, that fail with ErrorCode.
+	ErrorRate float64
+	// ErrorCode is the code of the injected errors (default codes.Unavailable).
+	ErrorCode codes.Code
+}
+
+// NewMockServer returns a gRPC server of the buildkitd control API that reports
+// the worker platforms and injects the latency and failures of the options.
+// Solves succeed without building anything, and their status streams report a
+// single step.
+func NewMockServer(opts MockOptions) (*grpc.Server, error) {
+	specs := opts.Platforms
+	if len(specs) == 0 {
+		specs = []string{platforms.DefaultString()}
+	}
+	var workerPlatforms []pb.Platform
+	for _, spec := range specs {
+		p, err := platforms.Parse(spec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid platform %q", spec)
+		}
+		workerPlatforms = append(workerPlatforms, pb.PlatformFromSpec(platforms.Normalize(p)))
+	}
+	if opts.ErrorRate < 0 || opts.ErrorRate > 1 {
+		return nil, errors.Errorf("invalid error rate %v (expected 0 to 1)", opts.ErrorRate)
+	}
+	if opts.ErrorCode == codes.OK {
+		opts.ErrorCode = codes.Unavailable
+	}
+
+	f := &faults{opts: opts, rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+	server := grpc.NewServer(grpc.UnaryInterceptor(f.unary), grpc.StreamInterceptor(f.stream))
+	control.RegisterControlServer(server, &mockControl{opts: opts, platforms: workerPlatforms})
+	return server, nil
+}
+
+// ParseErrorCode parses a gRPC code name such as "unavailable" or
+// "resource_exhausted".
+func ParseErrorCode(name string) (codes.Code, error) {
+	var code codes.Code
+	if err := code.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(name)))); err != nil {
+		return 0, errors.Errorf("unknown gRPC code %q", name)
+	}
+	return code, nil
+}
+
+// listen listens on a buildkitd address, e.g. "unix:///run/buildkit/buildkitd.sock"
+// or "tcp://127.0.0.1:1234".
+func listen(addr string) (net.Listener, error) {
+	scheme, address, ok := strings.Cut(addr, "://")
+	if !ok {
+		return nil, errors.Errorf("invalid address %q (expected unix:// or tcp://)", addr)
+	}
+	switch scheme {
+	case "unix":
+		l, _, err := connection.UnixListener(address)
+		if err != nil {
+			return nil, err
+		}
+		return &unixListener{Listener: l, path: address}, nil
+	case "tcp":
+		return net.Listen("tcp", address)
+	}
+	return nil, errors.Errorf("unsupported address scheme %q (expected unix or tcp)", scheme)
+}
+
+// unixListener removes its socket when it is closed, as a socket of
+// connection.UnixListener is not removed by the listener.
+type unixListener struct {
+	net.Listener
+	path string
+}
+
+func (l *unixListener) Close() error {
+	err := l.Listener.Close()
+	_ = os.Remove(l.path)
+	return err
+}
+
+// faults injects the latency and errors of the options into every call.
+type faults struct {
+	opts MockOptions
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func (f *faults) inject(ctx context.Context, method string) error {
+	if f.opts.Latency > 0 {
+		select {
+		case <-time.After(f.opts.Latency):
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+
+	f.mu.Lock()
+	fail := f.opts.ErrorRate > 0 && f.rand.Float64() < f.opts.ErrorRate
+	f.mu.Unlock()
+	if fail {
+		return status.Errorf(f.opts.ErrorCode, "mock buildkitd: injected error for %s", method)
+	}
+	return nil
+}
+
+func (f *faults) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := f.inject(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (f *faults) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := f.inject(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+type mockControl struct {
+	control.UnimplementedControlServer
+	opts      MockOptions
+	platforms []pb.Platform
+}
+
+func (c *mockControl) ListWorkers(ctx context.Context, req *control.ListWorkersRequest) (*control.ListWorkersResponse, error) {
+	return &control.ListWorkersResponse{
+		Record: []*types.WorkerRecord{{
+			ID:              "mock",
+			Platforms:       c.platforms,
+			BuildkitVersion: c.version(),
+		}},
+	}, nil
+}
+
+func (c *mockControl) Info(ctx context.Context, req *control.InfoRequest) (*control.InfoResponse, error) {
+	return &control.InfoResponse{BuildkitVersion: c.version()}, nil
+}
+
+func (c *mockControl) DiskUsage(ctx context.Context, req *control.DiskUsageRequest) (*control.DiskUsageResponse, error) {
+	return &control.DiskUsageResponse{}, nil
+}
+
+func (c *mockControl) Prune(req *control.PruneRequest, srv control.Control_PruneServer) error {
+	return nil
+}
+
+func (c *mockControl) Solve(ctx context.Context, req *control.SolveRequest) (*control.SolveResponse, error) {
+	return &control.SolveResponse{ExporterResponse: map[string]string{}}, nil
+}
+
+func (c *mockControl) Status(req *control.StatusRequest, srv control.Control_StatusServer) error {
+	dgst := digest.FromString(req.Ref)
+	started := time.Now()
+	messages := []*control.StatusResponse{
+		{Vertexes: []*control.Vertex{{Digest: dgst, Name: "[mock] solve " + req.Ref, Started: &started}}},
+		{Logs: []*control.VertexLog{{Vertex: dgst, Timestamp: started, Stream: 1, Msg: []byte("mock buildkitd does not build\n")}}},
+	}
+	completed := time.Now()
+	messages = append(messages, &control.StatusResponse{
+		Vertexes: []*control.Vertex{{Digest: dgst, Name: "[mock] solve " + req.Ref, Started: &started, Completed: &completed}},
+	})
+
+	for i, msg := range messages {
+		if c.opts.DropStreamsAfter > 0 && i >= c.opts.DropStreamsAfter {
+			return status.Error(codes.Unavailable, "mock buildkitd: dropped status stream")
+		}
+		if err := srv.Send(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *mockControl) version() *types.BuildkitVersion {
+	return &types.BuildkitVersion{Package: "github.com/depot/cli", Version: depot.Version, Revision: "mock"}
+}
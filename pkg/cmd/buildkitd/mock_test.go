@@ -0,0 +1,72 @@
+package buildkitd
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	control "github.com/moby/buildkit/api/services/control"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func startMock(t *testing.T, opts MockOptions) control.ControlClient {
+	t.Helper()
+	server, err := NewMockServer(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = server.Serve(l) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(l.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return control.NewControlClient(conn)
+}
+
+func TestMockServer(t *testing.T) {
+	ctx := context.Background()
+
+	client := startMock(t, MockOptions{Platforms: []string{"linux/amd64", "linux/arm64"}, DropStreamsAfter: 1})
+	res, err := client.ListWorkers(ctx, &control.ListWorkersRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Record) != 1 || len(res.Record[0].Platforms) != 2 || res.Record[0].Platforms[1].Architecture != "arm64" {
+		t.Errorf("unexpected workers: %v", res.Record)
+	}
+
+	stream, err := client.Status(ctx, &control.StatusRequest{Ref: "ref"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("expected the first status, got %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Errorf("expected the stream to be dropped, got %v", err)
+	}
+
+	client = startMock(t, MockOptions{ErrorRate: 1, ErrorCode: codes.ResourceExhausted})
+	if _, err := client.Info(ctx, &control.InfoRequest{}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected an injected error, got %v", err)
+	}
+}
+
+func TestParseErrorCode(t *testing.T) {
+	if code, err := ParseErrorCode("resource_exhausted"); err != nil || code != codes.ResourceExhausted {
+		t.Errorf("expected resource_exhausted, got %v, %v", code, err)
+	}
+	if _, err := ParseErrorCode("flaky"); err == nil {
+		t.Errorf("expected an unknown code to be rejected")
+	}
+}
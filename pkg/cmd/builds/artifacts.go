@@ -0,0 +1,225 @@
+package builds
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"connectrpc.com/connect"
+	depotapi "github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/helpers"
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+	"github.com/docker/cli/cli"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdArtifacts() *cobra.Command {
+	var (
+		token     string
+		filters   []string
+		outputDir string
+		output    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "artifacts [flags] <buildID>",
+		Short: "List and download the artifacts of a build",
+		Long: `List and download the artifacts of a build, such as SBOMs, provenance,
+exported files, and logs.
+
+Artifacts are listed by default.  With --output-dir, the artifacts matching
+the --filter globs are downloaded into the directory.  A filter is a glob of
+the artifact name, or of its kind or target with "kind=" or "target=".
+Filters of different fields must all match, and filters of the same field
+are alternatives.`,
+		Example: `  depot builds artifacts abc123 --filter 'sbom/*' --output-dir ./artifacts`,
+		Args:    cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "" && output != "json" {
+				return fmt.Errorf("unknown output format %q (expected json)", output)
+			}
+			artifactFilters, err := parseArtifactFilters(filters)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			token, err = helpers.ResolveToken(ctx, token)
+			if err != nil {
+				return err
+			}
+			if token == "" {
+				return fmt.Errorf("missing API token, please run `depot login`")
+			}
+
+			client := depotapi.NewBuildClient()
+			req := &cliv1.ListBuildArtifactsRequest{BuildId: args[0]}
+			res, err := client.ListBuildArtifacts(ctx, depotapi.WithAuthentication(connect.NewRequest(req), token))
+			if err != nil {
+				return err
+			}
+
+			var artifacts []*cliv1.BuildArtifact
+			for _, artifact := range res.Msg.Artifacts {
+				if matchesFilters(artifact, artifactFilters) {
+					artifacts = append(artifacts, artifact)
+				}
+			}
+
+			if outputDir == "" {
+				return printArtifacts(artifacts, output)
+			}
+			for _, artifact := range artifacts {
+				if err := downloadArtifact(ctx, artifact, outputDir); err != nil {
+					return fmt.Errorf("unable to download %s: %w", artifact.Name, err)
+				}
+				fmt.Fprintf(os.Stderr, "Downloaded %s (%s)\n", artifact.Name, units.HumanSize(float64(artifact.SizeBytes)))
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&token, "token", "", "Depot token")
+	flags.StringArrayVar(&filters, "filter", nil, `Only include artifacts whose name, or "kind=" or "target=", matches this glob (e.g., "sbom/*", "kind=sbom")`)
+	flags.StringVar(&outputDir, "output-dir", "", "Download the artifacts into this directory")
+	flags.StringVar(&output, "output", "", `Output format of the list ("json")`)
+
+	return cmd
+}
+
+// artifactFilterKeys are the fields of an artifact that --filter matches.
+var artifactFilterKeys = []string{"name", "kind", "target"}
+
+// parseArtifactFilters parses the --filter values, "key=glob" for the keys of
+// artifactFilterKeys or a glob of the name, into the globs of each key.
+func parseArtifactFilters(filters []string) (map[string][]string, error) {
+	parsed := map[string][]string{}
+	for _, filter := range filters {
+		key, glob := "name", filter
+		if k, v, ok := strings.Cut(filter, "="); ok && slices.Contains(artifactFilterKeys, k) {
+			key, glob = k, v
+		}
+		if _, err := path.Match(glob, ""); err != nil {
+			return nil, fmt.Errorf("invalid filter %q: %w", filter, err)
+		}
+		parsed[key] = append(parsed[key], glob)
+	}
+	return parsed, nil
+}
+
+// matchesFilters reports whether the artifact matches a glob of every key of
+// the filters.  The globs of one key are alternatives, and an artifact without
+// a target does not match any target glob.
+func matchesFilters(a *cliv1.BuildArtifact, filters map[string][]string) bool {
+	values := map[string]string{"name": a.Name, "kind": a.Kind, "target": a.GetTarget()}
+	for key, globs := range filters {
+		if values[key] == "" {
+			return false
+		}
+		matched := false
+		for _, glob := range globs {
+			if ok, _ := path.Match(glob, values[key]); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+type artifact struct {
+	Name   string `json:"name"`
+	Kind   string `json:"kind"`
+	Target string `json:"target,omitempty"`
+	Size   int64  `json:"size"`
+	Digest string `json:"digest,omitempty"`
+}
+
+func printArtifacts(artifacts []*cliv1.BuildArtifact, output string) error {
+	if output == "json" {
+		out := make([]artifact, 0, len(artifacts))
+		for _, a := range artifacts {
+			out = append(out, artifact{Name: a.Name, Kind: a.Kind, Target: a.GetTarget(), Size: a.SizeBytes, Digest: a.Digest})
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+
+	if len(artifacts) == 0 {
+		fmt.Fprintln(os.Stderr, "No artifacts found")
+		return nil
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tKIND\tTARGET\tSIZE")
+	for _, a := range artifacts {
+		target := a.GetTarget()
+		if target == "" {
+			target = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", a.Name, a.Kind, target, units.HumanSize(float64(a.SizeBytes)))
+	}
+	return tw.Flush()
+}
+
+// downloadArtifact downloads an artifact to its name below dir and checks
+// its digest.  The download URL is pre-signed, so the token is not sent.
+func downloadArtifact(ctx context.Context, artifact *cliv1.BuildArtifact, dir string) error {
+	name := path.Clean(artifact.Name)
+	if !fs.ValidPath(name) || name == "." {
+		return fmt.Errorf("invalid artifact name")
+	}
+	dest := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, artifact.DownloadUrl, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(dest), ".download-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, h), resp.Body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if want, ok := strings.CutPrefix(artifact.Digest, "sha256:"); ok {
+		if got := fmt.Sprintf("%x", h.Sum(nil)); got != want {
+			return fmt.Errorf("digest mismatch: got sha256:%s, expected %s", got, artifact.Digest)
+		}
+	}
+	return os.Rename(f.Name(), dest)
+}
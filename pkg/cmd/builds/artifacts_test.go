@@ -0,0 +1,54 @@
+package builds
+
+import (
+	"testing"
+
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+)
+
+func TestMatchesFilters(t *testing.T) {
+	target := "api"
+	sbom := &cliv1.BuildArtifact{Name: "sbom/api-linux-amd64.spdx.json", Kind: "sbom", Target: &target}
+	logs := &cliv1.BuildArtifact{Name: "logs/build.log", Kind: "log"}
+
+	tests := []struct {
+		name     string
+		filters  []string
+		artifact *cliv1.BuildArtifact
+		want     bool
+	}{
+		{name: "no filters", artifact: logs, want: true},
+		{name: "name glob", filters: []string{"sbom/*"}, artifact: sbom, want: true},
+		{name: "name glob does not match", filters: []string{"sbom/*"}, artifact: logs, want: false},
+		{name: "name key", filters: []string{"name=logs/*.log"}, artifact: logs, want: true},
+		{name: "kind key", filters: []string{"kind=sbom"}, artifact: sbom, want: true},
+		{name: "kind key does not match", filters: []string{"kind=sbom"}, artifact: logs, want: false},
+		{name: "target key", filters: []string{"target=a*"}, artifact: sbom, want: true},
+		{name: "target key without a target", filters: []string{"target=*"}, artifact: logs, want: false},
+		{name: "unknown key is a name glob", filters: []string{"foo=bar"}, artifact: &cliv1.BuildArtifact{Name: "foo=bar"}, want: true},
+		{name: "same key is either", filters: []string{"kind=provenance", "kind=sbom"}, artifact: sbom, want: true},
+		{name: "different keys are both", filters: []string{"kind=sbom", "target=api"}, artifact: sbom, want: true},
+		{name: "different keys with one mismatch", filters: []string{"kind=sbom", "target=web"}, artifact: sbom, want: false},
+		{name: "name and kind", filters: []string{"logs/*", "kind=log"}, artifact: logs, want: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			filters, err := parseArtifactFilters(tt.filters)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := matchesFilters(tt.artifact, filters); got != tt.want {
+				t.Errorf("matchesFilters(%s, %q) = %v, want %v", tt.artifact.Name, tt.filters, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseArtifactFiltersInvalid(t *testing.T) {
+	for _, filter := range []string{"[", "kind=["} {
+		if _, err := parseArtifactFilters([]string{filter}); err == nil {
+			t.Errorf("parseArtifactFilters(%q) = nil error, want an error", filter)
+		}
+	}
+}
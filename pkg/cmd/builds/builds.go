@@ -0,0 +1,25 @@
+package builds
+
+import (
+	"fmt"
+
+	"github.com/depot/cli/pkg/cmd/list"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdBuilds() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "builds",
+		Short: "Manage depot builds",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fmt.Errorf("missing subcommand, please run `depot builds --help`")
+		},
+	}
+
+	cmd.AddCommand(NewCmdArtifacts())
+	cmd.AddCommand(list.NewCmdBuilds("list", "ls"))
+	cmd.AddCommand(NewCmdReap())
+	cmd.AddCommand(NewCmdSubmit())
+
+	return cmd
+}
@@ -0,0 +1,54 @@
+package builds
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	depotbuild "github.com/depot/cli/pkg/build"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdReap() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reap",
+		Short: "Cancel builds left running by CLI processes that exited without finishing them",
+		Long: `Cancel builds left running by CLI processes that exited without finishing them.
+
+Builds are recorded while the CLI runs them.  If the process crashes or is
+killed, the build keeps its machines until it times out.  Reaping cancels
+those builds so their machines are released immediately.  Orphaned builds
+are also reaped automatically when the next build starts.  Only the builds
+started on this host, and in the same container, are reaped.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			builds, err := depotbuild.RunningBuilds()
+			if err != nil {
+				return err
+			}
+
+			var reaped int
+			for _, build := range builds {
+				if !build.Orphaned() {
+					continue
+				}
+
+				ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+				err := build.Reap(ctx)
+				cancel()
+				if err != nil {
+					return fmt.Errorf("unable to cancel build %s: %w", build.BuildID, err)
+				}
+				fmt.Printf("Canceled build %s (started %s)\n", build.BuildID, build.StartedAt.Format(time.RFC3339))
+				reaped++
+			}
+
+			if reaped == 0 {
+				fmt.Println("No orphaned builds found")
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
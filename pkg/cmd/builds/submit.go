@@ -0,0 +1,305 @@
+package builds
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/depot/cli/pkg/helpers"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// BatchFile lists independent builds that are submitted together.
+type BatchFile struct {
+	Builds []BatchBuild `yaml:"builds"`
+}
+
+// BatchBuild is one build of a batch file.  Paths are relative to the
+// directory of the batch file.
+type BatchBuild struct {
+	Name       string            `yaml:"name"`
+	Project    string            `yaml:"project"`
+	Context    string            `yaml:"context"`
+	Dockerfile string            `yaml:"dockerfile"`
+	Target     string            `yaml:"target"`
+	Tags       []string          `yaml:"tags"`
+	Platforms  []string          `yaml:"platforms"`
+	BuildArgs  map[string]string `yaml:"build-args"`
+	Push       bool              `yaml:"push"`
+}
+
+// BatchResult is the outcome of one build of a batch.
+type BatchResult struct {
+	Name     string  `json:"name"`
+	Status   string  `json:"status"`
+	Duration float64 `json:"durationSeconds"`
+	BuildID  string  `json:"buildID,omitempty"`
+	Digest   string  `json:"digest,omitempty"`
+	Error    string  `json:"error,omitempty"`
+
+	output []byte
+}
+
+func NewCmdSubmit() *cobra.Command {
+	var (
+		file        string
+		concurrency int
+		token       string
+		output      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "submit -f builds.yaml",
+		Short: "Run the independent builds listed in a file concurrently",
+		Long: `Run the independent builds listed in a file concurrently and print a
+summary of their results.
+
+Each build has a name, a project, a context, and optionally a dockerfile,
+target, tags, platforms, build-args, and push.  Paths are relative to the
+directory of the file.  The output of failed builds is printed after the
+summary, and the command fails if any build failed.`,
+		Example: `  builds:
+    - name: api
+      project: abc123
+      context: ./api
+      tags: [ghcr.io/org/api:1.0]
+      platforms: [linux/amd64, linux/arm64]
+      push: true
+    - name: web
+      project: def456
+      context: ./web
+      build-args:
+        VERSION: "1.0"
+
+  depot builds submit -f builds.yaml --concurrency 8`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "" && output != "json" {
+				return fmt.Errorf("unknown output format %q (expected json)", output)
+			}
+			if concurrency < 1 {
+				return fmt.Errorf("--concurrency must be at least 1")
+			}
+
+			batch, err := readBatchFile(file)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			token, err := helpers.ResolveToken(ctx, token)
+			if err != nil {
+				return err
+			}
+			if token == "" {
+				return fmt.Errorf("missing API token, please run `depot login`")
+			}
+
+			self, err := os.Executable()
+			if err != nil {
+				return fmt.Errorf("could not find executable: %w", err)
+			}
+
+			results := runBatch(ctx, self, token, batch.Builds, concurrency)
+
+			if output == "json" {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(results); err != nil {
+					return err
+				}
+			} else if err := printBatchResults(results); err != nil {
+				return err
+			}
+
+			var failed int
+			for _, result := range results {
+				if result.Error == "" {
+					continue
+				}
+				failed++
+				fmt.Fprintf(os.Stderr, "\n==> %s failed: %s\n%s", result.Name, result.Error, result.output)
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d builds failed", failed, len(results))
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&file, "file", "f", "builds.yaml", "File that lists the builds")
+	flags.IntVar(&concurrency, "concurrency", 4, "Number of builds to run at the same time")
+	flags.StringVar(&token, "token", "", "Depot token")
+	flags.StringVar(&output, "output", "", `Output format ("json")`)
+
+	return cmd
+}
+
+// readBatchFile reads and validates a batch file, resolving its paths
+// relative to its directory.
+func readBatchFile(path string) (*BatchFile, error) {
+	dt, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var batch BatchFile
+	if err := yaml.Unmarshal(dt, &batch); err != nil {
+		return nil, fmt.Errorf("invalid batch file %s: %w", path, err)
+	}
+	if len(batch.Builds) == 0 {
+		return nil, fmt.Errorf("batch file %s has no builds", path)
+	}
+
+	dir := filepath.Dir(path)
+	names := map[string]struct{}{}
+	for i, b := range batch.Builds {
+		if b.Name == "" {
+			return nil, fmt.Errorf("build %d of %s has no name", i+1, path)
+		}
+		if _, ok := names[b.Name]; ok {
+			return nil, fmt.Errorf("build %s is listed more than once in %s", b.Name, path)
+		}
+		names[b.Name] = struct{}{}
+		if b.Context == "" {
+			b.Context = "."
+		}
+		b.Context = relativeTo(dir, b.Context)
+		if b.Dockerfile != "" {
+			b.Dockerfile = relativeTo(dir, b.Dockerfile)
+		}
+		batch.Builds[i] = b
+	}
+	return &batch, nil
+}
+
+func relativeTo(dir, path string) string {
+	if filepath.IsAbs(path) || strings.Contains(path, "://") {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// runBatch runs the builds with at most concurrency at a time and returns
+// their results in the order of the batch file.
+func runBatch(ctx context.Context, self, token string, builds []BatchBuild, concurrency int) []BatchResult {
+	results := make([]BatchResult, len(builds))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, b := range builds {
+		wg.Add(1)
+		go func(i int, b BatchBuild) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			fmt.Fprintf(os.Stderr, "Building %s\n", b.Name)
+			results[i] = runBatchBuild(ctx, self, token, b)
+			fmt.Fprintf(os.Stderr, "Finished %s (%s) in %s\n", b.Name, results[i].Status, time.Duration(results[i].Duration*float64(time.Second)).Round(time.Second))
+		}(i, b)
+	}
+	wg.Wait()
+	return results
+}
+
+// runBatchBuild runs one build with "depot build" and reads its build ID and
+// image digest from the metadata file.
+func runBatchBuild(ctx context.Context, self, token string, b BatchBuild) BatchResult {
+	result := BatchResult{Name: b.Name, Status: "failed"}
+
+	metadata, err := os.CreateTemp("", "depot-batch-*.json")
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	_ = metadata.Close()
+	defer os.Remove(metadata.Name())
+
+	var out bytes.Buffer
+	c := exec.CommandContext(ctx, self, batchBuildArgs(b, metadata.Name())...)
+	c.Env = append(os.Environ(), "DEPOT_TOKEN="+token, "DEPOT_NO_SUMMARY_LINK=1")
+	c.Stdout = &out
+	c.Stderr = &out
+
+	start := time.Now()
+	err = c.Run()
+	result.Duration = time.Since(start).Seconds()
+	result.output = out.Bytes()
+
+	if dt, readErr := os.ReadFile(metadata.Name()); readErr == nil && len(dt) > 0 {
+		var md struct {
+			Digest string `json:"containerimage.digest"`
+			Build  struct {
+				BuildID string `json:"buildID"`
+			} `json:"depot.build"`
+		}
+		if json.Unmarshal(dt, &md) == nil {
+			result.BuildID = md.Build.BuildID
+			result.Digest = md.Digest
+		}
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Status = "succeeded"
+	return result
+}
+
+// batchBuildArgs are the arguments of "depot build" for a build.
+func batchBuildArgs(b BatchBuild, metadataFile string) []string {
+	args := []string{"build", "--progress=plain", "--metadata-file", metadataFile}
+	if b.Project != "" {
+		args = append(args, "--project", b.Project)
+	}
+	if b.Dockerfile != "" {
+		args = append(args, "--file", b.Dockerfile)
+	}
+	if b.Target != "" {
+		args = append(args, "--target", b.Target)
+	}
+	for _, tag := range b.Tags {
+		args = append(args, "--tag", tag)
+	}
+	if len(b.Platforms) > 0 {
+		args = append(args, "--platform", strings.Join(b.Platforms, ","))
+	}
+	keys := make([]string, 0, len(b.BuildArgs))
+	for k := range b.BuildArgs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "--build-arg", k+"="+b.BuildArgs[k])
+	}
+	if b.Push {
+		args = append(args, "--push")
+	}
+	return append(args, b.Context)
+}
+
+func printBatchResults(results []BatchResult) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tSTATUS\tDURATION\tBUILD ID\tDIGEST")
+	for _, r := range results {
+		buildID, digest := r.BuildID, r.Digest
+		if buildID == "" {
+			buildID = "-"
+		}
+		if digest == "" {
+			digest = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", r.Name, r.Status, time.Duration(r.Duration*float64(time.Second)).Round(time.Second), buildID, digest)
+	}
+	return tw.Flush()
+}
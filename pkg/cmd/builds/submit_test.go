@@ -0,0 +1,172 @@
+package builds
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadBatchFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []BatchBuild
+		wantErr bool
+	}{
+		{
+			name: "relative paths",
+			content: `builds:
+  - name: api
+    context: api
+    dockerfile: api/Dockerfile
+  - name: web
+`,
+			want: []BatchBuild{
+				{Name: "api", Context: "DIR/api", Dockerfile: "DIR/api/Dockerfile"},
+				{Name: "web", Context: "DIR"},
+			},
+		},
+		{
+			name: "absolute and remote contexts",
+			content: `builds:
+  - name: api
+    context: /src/api
+  - name: web
+    context: https://github.com/example/web.git
+`,
+			want: []BatchBuild{
+				{Name: "api", Context: "/src/api"},
+				{Name: "web", Context: "https://github.com/example/web.git"},
+			},
+		},
+		{
+			name: "build args and platforms",
+			content: `builds:
+  - name: api
+    platforms: [linux/amd64, linux/arm64]
+    build-args:
+      VERSION: "1.0"
+    push: true
+`,
+			want: []BatchBuild{
+				{Name: "api", Context: "DIR", Platforms: []string{"linux/amd64", "linux/arm64"}, BuildArgs: map[string]string{"VERSION": "1.0"}, Push: true},
+			},
+		},
+		{
+			name:    "malformed yaml",
+			content: "builds:\n  - name: api\n   context: .\n",
+			wantErr: true,
+		},
+		{
+			name:    "builds is not a list",
+			content: "builds: api\n",
+			wantErr: true,
+		},
+		{
+			name:    "no builds",
+			content: "builds: []\n",
+			wantErr: true,
+		},
+		{
+			name:    "empty file",
+			content: "",
+			wantErr: true,
+		},
+		{
+			name:    "missing name",
+			content: "builds:\n  - context: api\n",
+			wantErr: true,
+		},
+		{
+			name:    "duplicate name",
+			content: "builds:\n  - name: api\n  - name: api\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "builds.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := readBatchFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readBatchFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			// DIR stands for the directory of the batch file.
+			for i, b := range tt.want {
+				tt.want[i].Context = strings.Replace(b.Context, "DIR", dir, 1)
+				tt.want[i].Dockerfile = strings.Replace(b.Dockerfile, "DIR", dir, 1)
+			}
+			if !reflect.DeepEqual(got.Builds, tt.want) {
+				t.Errorf("readBatchFile() = %+v, want %+v", got.Builds, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadBatchFileMissing(t *testing.T) {
+	if _, err := readBatchFile(filepath.Join(t.TempDir(), "builds.yaml")); err == nil {
+		t.Error("readBatchFile() of a missing file = nil error, want an error")
+	}
+}
+
+func TestBatchBuildArgs(t *testing.T) {
+	tests := []struct {
+		name  string
+		build BatchBuild
+		want  []string
+	}{
+		{
+			name:  "context only",
+			build: BatchBuild{Name: "api", Context: "api"},
+			want:  []string{"build", "--progress=plain", "--metadata-file", "md.json", "api"},
+		},
+		{
+			name: "every field",
+			build: BatchBuild{
+				Name:       "api",
+				Project:    "abc123",
+				Context:    "api",
+				Dockerfile: "api/Dockerfile",
+				Target:     "release",
+				Tags:       []string{"example/api:1", "example/api:latest"},
+				Platforms:  []string{"linux/amd64", "linux/arm64"},
+				BuildArgs:  map[string]string{"VERSION": "1", "COMMIT": "abc"},
+				Push:       true,
+			},
+			want: []string{
+				"build", "--progress=plain", "--metadata-file", "md.json",
+				"--project", "abc123",
+				"--file", "api/Dockerfile",
+				"--target", "release",
+				"--tag", "example/api:1", "--tag", "example/api:latest",
+				"--platform", "linux/amd64,linux/arm64",
+				"--build-arg", "COMMIT=abc", "--build-arg", "VERSION=1",
+				"--push",
+				"api",
+			},
+		},
+		{
+			name:  "build arg values with equals signs",
+			build: BatchBuild{Name: "api", Context: ".", BuildArgs: map[string]string{"FLAGS": "a=b"}},
+			want:  []string{"build", "--progress=plain", "--metadata-file", "md.json", "--build-arg", "FLAGS=a=b", "."},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := batchBuildArgs(tt.build, "md.json"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("batchBuildArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -16,6 +16,9 @@ func NewCmdCache() *cobra.Command {
 	}
 
 	cmd.AddCommand(NewCmdResetCache())
+	cmd.AddCommand(NewCmdWarmCache())
+	cmd.AddCommand(NewCmdCacheStats())
+	cmd.AddCommand(NewCmdCacheMounts())
 
 	return cmd
 }
@@ -0,0 +1,190 @@
+package init
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/helpers"
+	cliv1beta1 "github.com/depot/cli/pkg/proto/depot/cli/v1beta1"
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdCacheMounts() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mounts",
+		Short: "List or clear the RUN --mount=type=cache mounts of a project",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fmt.Errorf("missing subcommand, please run `depot cache mounts --help`")
+		},
+	}
+
+	cmd.AddCommand(NewCmdCacheMountsList())
+	cmd.AddCommand(NewCmdCacheMountsClear())
+
+	return cmd
+}
+
+func NewCmdCacheMountsList() *cobra.Command {
+	var (
+		projectID    string
+		token        string
+		platform     string
+		outputFormat string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the cache mounts of a project with their sizes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if outputFormat != "" && outputFormat != "json" {
+				return errors.Errorf("unknown format: %s. Requires json", outputFormat)
+			}
+			projectID, token, arch, err := resolveCacheMountsArgs(projectID, token, platform)
+			if err != nil {
+				return err
+			}
+
+			client := api.NewProjectsClient()
+			req := cliv1beta1.ListCacheMountsRequest{ProjectId: projectID, Platform: arch}
+			res, err := client.ListCacheMounts(cmd.Context(), api.WithAuthentication(connect.NewRequest(&req), token))
+			if err != nil {
+				return err
+			}
+
+			mounts := res.Msg.CacheMounts
+			if outputFormat == "json" {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(mounts)
+			}
+			if len(mounts) == 0 {
+				fmt.Printf("Project %s has no cache mounts\n", projectID)
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tPLATFORM\tSIZE\tLAST USED")
+			for _, mount := range mounts {
+				lastUsed := "-"
+				if mount.LastUsedAt != nil {
+					lastUsed = mount.LastUsedAt.AsTime().Local().Format(time.RFC3339)
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", mount.Id, mount.Platform, units.BytesSize(float64(mount.SizeBytes)), lastUsed)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().StringVar(&projectID, "project", "", "Depot project ID")
+	cmd.Flags().StringVar(&token, "token", "", "Depot token")
+	cmd.Flags().StringVar(&platform, "platform", "", `Only list the cache mounts of this architecture ("amd64", "arm64")`)
+	cmd.Flags().StringVar(&outputFormat, "output", "", "Non-interactive output format (json)")
+
+	return cmd
+}
+
+func NewCmdCacheMountsClear() *cobra.Command {
+	var (
+		projectID string
+		token     string
+		platform  string
+		all       bool
+		force     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "clear [ID...]",
+		Short: "Clear cache mounts of a project, such as a corrupted package manager cache",
+		Example: `  # Clear the cache mount of RUN --mount=type=cache,target=/root/.npm
+  depot cache mounts clear /root/.npm
+
+  # Clear every cache mount of the project
+  depot cache mounts clear --all`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && !all {
+				return errors.Errorf("specify the IDs of the cache mounts to clear (see `depot cache mounts list`) or use --all")
+			}
+			if len(args) > 0 && all {
+				return errors.Errorf("cache mount IDs cannot be combined with --all")
+			}
+			projectID, token, arch, err := resolveCacheMountsArgs(projectID, token, platform)
+			if err != nil {
+				return err
+			}
+
+			if !force {
+				if !helpers.IsTerminal() {
+					return errors.Errorf("refusing to clear cache mounts without confirmation; use --force in non-interactive environments")
+				}
+
+				target := "every cache mount"
+				if len(args) > 0 {
+					target = "cache mounts " + strings.Join(args, ", ")
+				}
+				if arch != "" {
+					target += " on " + arch
+				}
+				prompt := fmt.Sprintf("Clear %s of project %s? Builds will start with empty cache mounts.", target, projectID)
+				if !helpers.Confirm(prompt, false) {
+					return errors.Errorf("cache mount clear canceled")
+				}
+			}
+
+			client := api.NewProjectsClient()
+			req := cliv1beta1.ClearCacheMountsRequest{ProjectId: projectID, Ids: args, Platform: arch}
+			res, err := client.ClearCacheMounts(cmd.Context(), api.WithAuthentication(connect.NewRequest(&req), token))
+			if err != nil {
+				return err
+			}
+
+			if len(res.Msg.Ids) == 0 {
+				fmt.Println("No cache mounts matched")
+				return nil
+			}
+			fmt.Printf("Cleared %d cache mounts (%s): %s\n", len(res.Msg.Ids), units.BytesSize(float64(res.Msg.SizeBytes)), strings.Join(res.Msg.Ids, ", "))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&projectID, "project", "", "Depot project ID")
+	cmd.Flags().StringVar(&token, "token", "", "Depot token")
+	cmd.Flags().StringVar(&platform, "platform", "", `Only clear the cache mounts of this architecture ("amd64", "arm64")`)
+	cmd.Flags().BoolVar(&all, "all", false, "Clear every cache mount of the project")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Clear the cache mounts without asking for confirmation")
+
+	return cmd
+}
+
+// resolveCacheMountsArgs resolves the project of the current directory, the
+// token, and the architecture of the platform.
+func resolveCacheMountsArgs(projectID, token, platform string) (string, string, string, error) {
+	cwd, _ := os.Getwd()
+	projectID = helpers.ResolveProjectID(projectID, cwd)
+	if projectID == "" {
+		return "", "", "", errors.Errorf("unknown project ID (run `depot init` or use --project or $DEPOT_PROJECT_ID)")
+	}
+
+	arch, err := parseArchitecture(platform)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	token, err = helpers.ResolveToken(context.Background(), token)
+	if err != nil {
+		return "", "", "", err
+	}
+	if token == "" {
+		return "", "", "", fmt.Errorf("missing API token, please run `depot login`")
+	}
+	return projectID, token, arch, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"connectrpc.com/connect"
 	"github.com/depot/cli/pkg/api"
@@ -17,6 +18,8 @@ import (
 func NewCmdResetCache() *cobra.Command {
 	var projectID string
 	var token string
+	var platform string
+	var force bool
 
 	cmd := &cobra.Command{
 		Use:   "reset",
@@ -32,7 +35,11 @@ func NewCmdResetCache() *cobra.Command {
 				return errors.Errorf("unknown project ID (run `depot init` or use --project or $DEPOT_PROJECT_ID)")
 			}
 
-			var err error
+			arch, err := parseArchitecture(platform)
+			if err != nil {
+				return err
+			}
+
 			token, err = helpers.ResolveToken(context.Background(), token)
 			if err != nil {
 				return err
@@ -42,14 +49,36 @@ func NewCmdResetCache() *cobra.Command {
 				return fmt.Errorf("missing API token, please run `depot login`")
 			}
 
+			if !force {
+				if !helpers.IsTerminal() {
+					return errors.Errorf("refusing to reset the cache without confirmation; use --force in non-interactive environments")
+				}
+
+				target := "the cache"
+				if arch != "" {
+					target = fmt.Sprintf("the %s cache", arch)
+				}
+				prompt := fmt.Sprintf("Reset %s for project %s? Builds will start with an empty cache.", target, projectID)
+				if !helpers.Confirm(prompt, false) {
+					return errors.Errorf("cache reset canceled")
+				}
+			}
+
 			client := api.NewProjectsClient()
 			req := cliv1beta1.ResetProjectCacheRequest{ProjectId: projectID}
+			if arch != "" {
+				req.Platform = &arch
+			}
 			resp, err := client.ResetProjectCache(context.TODO(), api.WithAuthentication(connect.NewRequest(&req), token))
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Cache reset for %s (%s)\n", resp.Msg.Name, resp.Msg.OrgName)
+			if arch != "" {
+				fmt.Printf("Cache reset for %s (%s) on %s\n", resp.Msg.Name, resp.Msg.OrgName, arch)
+			} else {
+				fmt.Printf("Cache reset for %s (%s)\n", resp.Msg.Name, resp.Msg.OrgName)
+			}
 
 			return nil
 		},
@@ -57,6 +86,21 @@ func NewCmdResetCache() *cobra.Command {
 
 	cmd.Flags().StringVar(&projectID, "project", "", "Depot project ID for the cache to reset")
 	cmd.Flags().StringVar(&token, "token", "", "Depot token")
+	cmd.Flags().StringVar(&platform, "platform", "", `Only reset the cache of this architecture ("amd64", "arm64")`)
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Reset the cache without asking for confirmation")
 
 	return cmd
 }
+
+// parseArchitecture accepts both "arm64" and "linux/arm64" forms.
+func parseArchitecture(platform string) (string, error) {
+	switch strings.TrimPrefix(platform, "linux/") {
+	case "":
+		return "", nil
+	case "amd64", "x86_64":
+		return "amd64", nil
+	case "arm64", "aarch64":
+		return "arm64", nil
+	}
+	return "", errors.Errorf("unsupported platform %q, expected amd64 or arm64", platform)
+}
@@ -0,0 +1,200 @@
+package init
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/helpers"
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+	"github.com/docker/cli/cli"
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Step categories, in the order they are printed.
+const (
+	categoryFrontend   = "frontend"
+	categoryBaseImage  = "base image layers"
+	categoryRun        = "RUN steps"
+	categoryCacheMount = "cache mounts"
+	categoryOther      = "other steps"
+)
+
+var categories = []string{categoryFrontend, categoryBaseImage, categoryRun, categoryCacheMount, categoryOther}
+
+// CategoryStats are the cache statistics of the steps of a category.
+type CategoryStats struct {
+	Category     string  `json:"category"`
+	Steps        int64   `json:"steps"`
+	CachedSteps  int64   `json:"cached_steps"`
+	HitRate      float64 `json:"hit_rate"`
+	DurationMs   int64   `json:"uncached_duration_ms"`
+	StorageBytes int64   `json:"storage_bytes"`
+}
+
+func NewCmdCacheStats() *cobra.Command {
+	var (
+		projectID    string
+		token        string
+		days         int
+		outputFormat string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show cache hit rates and storage of a project by step category",
+		Long: `Show the cache hit rates, time spent on uncached steps, and cache storage of a
+project by step category, computed from the step timings reported by its builds.`,
+		Example: `  # Cache statistics of the last 30 days
+  depot cache stats --project abc123
+
+  # Cache statistics of the last week as JSON
+  depot cache stats --days 7 --output json`,
+		Args: cli.RequiresMaxArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var cwd string
+			if len(args) > 0 {
+				cwd, _ = filepath.Abs(args[0])
+			}
+			projectID := helpers.ResolveProjectID(projectID, cwd)
+			if projectID == "" {
+				return errors.Errorf("unknown project ID (run `depot init` or use --project or $DEPOT_PROJECT_ID)")
+			}
+			if days < 1 {
+				return errors.Errorf("--days must be at least 1")
+			}
+			if outputFormat != "" && outputFormat != "json" {
+				return errors.Errorf("unknown format: %s. Requires json", outputFormat)
+			}
+
+			token, err := helpers.ResolveToken(context.Background(), token)
+			if err != nil {
+				return err
+			}
+			if token == "" {
+				return fmt.Errorf("missing API token, please run `depot login`")
+			}
+
+			end := time.Now()
+			start := end.AddDate(0, 0, -days)
+			req := cliv1.GetCacheStatsRequest{
+				ProjectId: projectID,
+				StartTime: timestamppb.New(start),
+				EndTime:   timestamppb.New(end),
+			}
+			client := api.NewUsageClient()
+			res, err := client.GetCacheStats(cmd.Context(), api.WithAuthentication(connect.NewRequest(&req), token))
+			if err != nil {
+				return err
+			}
+
+			stats := categoryStats(res.Msg.Steps)
+			if outputFormat == "json" {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(stats)
+			}
+
+			fmt.Printf("Cache statistics of project %s for the last %d days\n\n", projectID, days)
+			return writeCategoryStats(stats)
+		},
+	}
+
+	cmd.Flags().StringVar(&projectID, "project", "", "Depot project ID")
+	cmd.Flags().StringVar(&token, "token", "", "Depot token")
+	cmd.Flags().IntVar(&days, "days", 30, "Number of days to report")
+	cmd.Flags().StringVar(&outputFormat, "output", "", "Non-interactive output format (json)")
+
+	return cmd
+}
+
+// categoryStats sums the statistics of the steps by category.
+func categoryStats(steps []*cliv1.StepCacheStats) []CategoryStats {
+	byCategory := map[string]*CategoryStats{}
+	for _, category := range categories {
+		byCategory[category] = &CategoryStats{Category: category}
+	}
+	for _, step := range steps {
+		s := byCategory[stepCategory(step.Name)]
+		s.Steps += step.Count
+		s.CachedSteps += step.CachedCount
+		s.DurationMs += step.DurationMs
+		s.StorageBytes += step.StorageBytes
+	}
+
+	stats := make([]CategoryStats, 0, len(categories))
+	for _, category := range categories {
+		s := byCategory[category]
+		if s.Steps > 0 {
+			s.HitRate = float64(s.CachedSteps) / float64(s.Steps)
+		}
+		stats = append(stats, *s)
+	}
+	return stats
+}
+
+// stepCategory categorizes a step by its vertex name, such as
+// "[build 2/5] RUN --mount=type=cache,target=/root/.npm npm ci".
+func stepCategory(name string) string {
+	if strings.HasPrefix(name, "[internal] load metadata for ") {
+		return categoryBaseImage
+	}
+	if strings.HasPrefix(name, "[internal]") || strings.HasPrefix(name, "resolve image config for ") {
+		return categoryFrontend
+	}
+
+	if strings.HasPrefix(name, "[") {
+		if _, rest, ok := strings.Cut(name, "] "); ok {
+			name = rest
+		}
+	}
+	instruction, args, _ := strings.Cut(strings.TrimSpace(name), " ")
+	switch strings.ToUpper(instruction) {
+	case "FROM":
+		return categoryBaseImage
+	case "RUN":
+		if strings.Contains(args, "type=cache") {
+			return categoryCacheMount
+		}
+		return categoryRun
+	}
+	return categoryOther
+}
+
+func writeCategoryStats(stats []CategoryStats) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CATEGORY\tSTEPS\tCACHED\tHIT RATE\tUNCACHED TIME\tSTORAGE")
+
+	var total CategoryStats
+	for _, s := range stats {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\n", s.Category, s.Steps, s.CachedSteps, formatHitRate(s), formatDuration(s.DurationMs), units.BytesSize(float64(s.StorageBytes)))
+		total.Steps += s.Steps
+		total.CachedSteps += s.CachedSteps
+		total.DurationMs += s.DurationMs
+		total.StorageBytes += s.StorageBytes
+	}
+	fmt.Fprintf(w, "TOTAL\t%d\t%d\t%s\t%s\t%s\n", total.Steps, total.CachedSteps, formatHitRate(total), formatDuration(total.DurationMs), units.BytesSize(float64(total.StorageBytes)))
+
+	return w.Flush()
+}
+
+func formatHitRate(s CategoryStats) string {
+	if s.Steps == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", 100*float64(s.CachedSteps)/float64(s.Steps))
+}
+
+func formatDuration(ms int64) string {
+	return (time.Duration(ms) * time.Millisecond).Round(time.Second).String()
+}
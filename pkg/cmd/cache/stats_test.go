@@ -0,0 +1,48 @@
+package init
+
+import (
+	"reflect"
+	"testing"
+
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+)
+
+func TestStepCategory(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"[internal] load metadata for docker.io/library/node:20", categoryBaseImage},
+		{"[internal] load build definition from Dockerfile", categoryFrontend},
+		{"resolve image config for docker.io/docker/dockerfile:1", categoryFrontend},
+		{"[build 1/5] FROM docker.io/library/node:20@sha256:abc", categoryBaseImage},
+		{"[build 2/5] RUN --mount=type=cache,target=/root/.npm npm ci", categoryCacheMount},
+		{"[build 3/5] RUN npm run build", categoryRun},
+		{"[build 4/5] COPY . .", categoryOther},
+		{"run go build", categoryRun},
+		{"exporting to image", categoryOther},
+	}
+	for _, tt := range tests {
+		if got := stepCategory(tt.name); got != tt.want {
+			t.Errorf("stepCategory(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCategoryStats(t *testing.T) {
+	steps := []*cliv1.StepCacheStats{
+		{Name: "[build 2/4] RUN npm ci", Count: 4, CachedCount: 3, DurationMs: 1000, StorageBytes: 100},
+		{Name: "[build 3/4] RUN make", Count: 4, CachedCount: 1, DurationMs: 3000, StorageBytes: 50},
+		{Name: "[build 1/4] FROM docker.io/library/node:20", Count: 2, CachedCount: 2},
+	}
+	want := []CategoryStats{
+		{Category: categoryFrontend},
+		{Category: categoryBaseImage, Steps: 2, CachedSteps: 2, HitRate: 1},
+		{Category: categoryRun, Steps: 8, CachedSteps: 4, HitRate: 0.5, DurationMs: 4000, StorageBytes: 150},
+		{Category: categoryCacheMount},
+		{Category: categoryOther},
+	}
+	if got := categoryStats(steps); !reflect.DeepEqual(got, want) {
+		t.Errorf("categoryStats() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,10 @@
+package init
+
+import (
+	"github.com/depot/cli/pkg/buildx/commands"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdWarmCache() *cobra.Command {
+	return commands.WarmCmd()
+}
@@ -0,0 +1,20 @@
+// Shows the configuration the CLI runs with.
+package config
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewCmdConfig() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Show the configuration of the CLI",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(NewCmdEffective())
+
+	return cmd
+}
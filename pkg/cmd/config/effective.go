@@ -0,0 +1,165 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	depotconfig "github.com/depot/cli/pkg/config"
+	"github.com/depot/cli/pkg/helpers"
+	"github.com/depot/cli/pkg/profile"
+	"github.com/docker/cli/cli"
+	"github.com/spf13/cobra"
+)
+
+// The sources of a default flag value.
+const (
+	sourceOrganization = "organization"
+	sourceConfigFile   = "config file"
+)
+
+// Effective is the merged configuration of the organization profile and the
+// depot config file.
+type Effective struct {
+	OrganizationID    string     `json:"organization_id,omitempty"`
+	ProfileVersion    string     `json:"profile_version,omitempty"`
+	FetchedAt         *time.Time `json:"fetched_at,omitempty"`
+	Cached            bool       `json:"cached,omitempty"`
+	RequireProvenance bool       `json:"require_provenance"`
+	ForbiddenFlags    []string   `json:"forbidden_flags"`
+	Defaults          []Default  `json:"defaults"`
+}
+
+// Default is the default value of a build and bake flag.
+type Default struct {
+	Flag   string `json:"flag"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+func NewCmdEffective() *cobra.Command {
+	var (
+		token     string
+		projectID string
+		output    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "effective [flags]",
+		Short: "Show the defaults and restrictions that apply to builds",
+		Long:  "Show the organization profile merged with the depot config file. Flags given on the command line take precedence over these defaults.",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "" && output != "json" {
+				return fmt.Errorf("unknown output format %q (expected json)", output)
+			}
+
+			ctx := cmd.Context()
+			token, err := helpers.ResolveToken(ctx, token)
+			if err != nil {
+				return err
+			}
+			if token == "" {
+				return fmt.Errorf("missing API token, please run `depot login`")
+			}
+
+			p, err := profile.Load(ctx, token, helpers.ResolveProjectID(projectID))
+			if err != nil {
+				return fmt.Errorf("unable to load organization profile: %w", err)
+			}
+
+			effective := merge(p, map[string]string{
+				"notify-webhook": depotconfig.GetNotifyWebhook(),
+				"notify-exec":    depotconfig.GetNotifyExec(),
+			})
+			if output == "json" {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(effective)
+			}
+			return writeEffective(effective)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&projectID, "project", "", "Depot project ID")
+	flags.StringVar(&token, "token", "", "Depot token")
+	flags.StringVar(&output, "output", "", `Output format ("json")`)
+
+	return cmd
+}
+
+// merge combines the profile with the defaults of the config file.  The
+// profile takes precedence, as it is applied to the flags first.
+func merge(p *profile.Profile, local map[string]string) Effective {
+	effective := Effective{ForbiddenFlags: []string{}, Defaults: []Default{}}
+
+	if p != nil {
+		fetchedAt := p.FetchedAt
+		effective.OrganizationID = p.OrganizationId
+		effective.ProfileVersion = p.Version
+		effective.FetchedAt = &fetchedAt
+		effective.Cached = p.Cached
+		effective.RequireProvenance = p.RequireProvenance
+		effective.ForbiddenFlags = append(effective.ForbiddenFlags, p.ForbiddenFlags...)
+		for flag, value := range p.DefaultFlags {
+			effective.Defaults = append(effective.Defaults, Default{Flag: flag, Value: value, Source: sourceOrganization})
+		}
+		if _, ok := p.DefaultFlags["provenance"]; !ok && p.RequireProvenance {
+			effective.Defaults = append(effective.Defaults, Default{Flag: "provenance", Value: "mode=min", Source: sourceOrganization})
+		}
+	}
+
+	for flag, value := range local {
+		if value == "" {
+			continue
+		}
+		if p != nil {
+			if _, ok := p.DefaultFlags[flag]; ok {
+				continue
+			}
+		}
+		effective.Defaults = append(effective.Defaults, Default{Flag: flag, Value: value, Source: sourceConfigFile})
+	}
+
+	sort.Strings(effective.ForbiddenFlags)
+	sort.Slice(effective.Defaults, func(i, j int) bool { return effective.Defaults[i].Flag < effective.Defaults[j].Flag })
+	return effective
+}
+
+func writeEffective(effective Effective) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	if effective.OrganizationID == "" {
+		fmt.Fprintln(w, "Organization profile:\tnone")
+	} else {
+		fetched := effective.FetchedAt.Local().Format(time.DateTime)
+		if effective.Cached {
+			fetched += " (cached, the API was unreachable)"
+		}
+		fmt.Fprintf(w, "Organization profile:\t%s (version %s)\n", effective.OrganizationID, effective.ProfileVersion)
+		fmt.Fprintf(w, "Fetched:\t%s\n", fetched)
+	}
+	requireProvenance := "no"
+	if effective.RequireProvenance {
+		requireProvenance = "yes"
+	}
+	fmt.Fprintf(w, "Require provenance:\t%s\n", requireProvenance)
+	forbidden := "none"
+	if len(effective.ForbiddenFlags) > 0 {
+		forbidden = "--" + strings.Join(effective.ForbiddenFlags, ", --")
+	}
+	fmt.Fprintf(w, "Forbidden flags:\t%s\n", forbidden)
+
+	if len(effective.Defaults) > 0 {
+		fmt.Fprintln(w, "\nFLAG\tDEFAULT\tSOURCE")
+		for _, d := range effective.Defaults {
+			fmt.Fprintf(w, "--%s\t%s\t%s\n", d.Flag, d.Value, d.Source)
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,27 @@
+package contextd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmdContextd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "contextd",
+		Short: "Keep the content hashes of build contexts warm in a background daemon",
+		Long: `Run a daemon that watches project directories and keeps the digests of their
+files, so that hashing a build context, as "depot bake --skip-unchanged-targets"
+and "depot hash-context" do, only reads the files that changed.  Sending the
+context of a build to the builder still walks the context.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fmt.Errorf("missing subcommand, please run `depot contextd --help`")
+		},
+	}
+
+	cmd.AddCommand(NewCmdRun())
+	cmd.AddCommand(NewCmdStatus())
+	cmd.AddCommand(NewCmdStop())
+
+	return cmd
+}
@@ -0,0 +1,52 @@
+package contextd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/depot/cli/pkg/config"
+	"github.com/depot/cli/pkg/contextd"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdRun() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run [DIR...]",
+		Short: "Run the daemon in the foreground",
+		Long: `Run the daemon in the foreground, watching the directories and their
+subdirectories until interrupted.  Without directories, those of
+contextd_dirs in the config file or $DEPOT_CONTEXTD_DIRS are watched.`,
+		Example: `  # Watch two projects
+  depot contextd run ~/src/api ~/src/web
+
+  # Watch the directories of the config in the background
+  DEPOT_CONTEXTD_DIRS="$HOME/src/api $HOME/src/web" depot contextd run &`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dirs := args
+			if len(dirs) == 0 {
+				dirs = config.GetContextdDirs()
+			}
+			if len(dirs) == 0 {
+				return errors.New("no directories to watch: pass them as arguments or set contextd_dirs")
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			daemon, err := contextd.NewDaemon(dirs)
+			if err != nil {
+				return err
+			}
+			status := daemon.Status()
+			fmt.Fprintf(os.Stderr, "Watching %s for changes, press Ctrl+C to stop\n", strings.Join(status.Dirs, ", "))
+			return daemon.Serve(ctx)
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,59 @@
+package contextd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/depot/cli/pkg/contextd"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdStatus() *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the directories and index of the running daemon",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if outputFormat != "" && outputFormat != "json" {
+				return errors.Errorf("unknown format: %s. Requires json", outputFormat)
+			}
+
+			status, err := contextd.GetStatus()
+			if err != nil {
+				return err
+			}
+
+			if outputFormat == "json" {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(status)
+			}
+
+			fmt.Printf("PID:      %d\n", status.PID)
+			fmt.Printf("Uptime:   %s\n", time.Since(status.Started).Round(time.Second))
+			fmt.Printf("Files:    %d\n", status.Files)
+			fmt.Printf("Contexts: %d\n", status.Results)
+			fmt.Println("Watching:")
+			unwatched := map[string]bool{}
+			for _, dir := range status.Unwatched {
+				unwatched[dir] = true
+			}
+			for _, dir := range status.Dirs {
+				if unwatched[dir] {
+					fmt.Printf("  %s (partially, hashes are not kept)\n", dir)
+				} else {
+					fmt.Printf("  %s\n", dir)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&outputFormat, "output", "", `Output format ("json")`)
+
+	return cmd
+}
@@ -0,0 +1,24 @@
+package contextd
+
+import (
+	"fmt"
+
+	"github.com/depot/cli/pkg/contextd"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdStop() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stop",
+		Short: "Stop the running daemon",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := contextd.Stop(); err != nil {
+				return err
+			}
+			fmt.Println("Stopped contextd")
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,228 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/containerd/containerd/platforms"
+	"github.com/depot/cli/pkg/helpers"
+	"github.com/depot/cli/pkg/imagediff"
+	"github.com/depot/cli/pkg/registryapi"
+	"github.com/docker/cli/cli"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+// defaultMaxFiles is how many changed files are printed by default.
+const defaultMaxFiles = 50
+
+func NewCmdDiff() *cobra.Command {
+	var (
+		token        string
+		target       string
+		platform     string
+		maxLayerSize string
+		maxFiles     int
+		output       string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff [flags] <build-a> <build-b>",
+		Short: "Compare the images of two saved builds",
+		Long: `Compare the images that two builds saved to the Depot ephemeral registry.
+
+The diff lists the size change of each layer, the differences of the image
+configs such as environment variables and the entrypoint, the files that
+changed, and the packages that changed when both images have an SBOM.
+
+Files are compared by reading the layers that differ from the registry.
+Layers larger than --max-layer-size are not read, and the file comparison
+is skipped.`,
+		Example: `  depot diff abc123 def456
+  depot diff abc123 def456 --target api --platform linux/arm64`,
+		Args: cli.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "" && output != "json" {
+				return fmt.Errorf("unknown output format %q (expected json)", output)
+			}
+			if platform != "" {
+				if _, err := platforms.Parse(platform); err != nil {
+					return err
+				}
+			}
+			maxLayerBytes, err := units.RAMInBytes(maxLayerSize)
+			if err != nil {
+				return fmt.Errorf("invalid --max-layer-size %q: %w", maxLayerSize, err)
+			}
+
+			ctx := cmd.Context()
+			token, err := helpers.ResolveToken(ctx, token)
+			if err != nil {
+				return err
+			}
+			if token == "" {
+				return fmt.Errorf("missing API token, please run `depot login`")
+			}
+
+			l := &loader{client: registryapi.NewClient(token), target: target, platform: platform, maxLayerSize: maxLayerBytes}
+			a, err := l.load(ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("unable to read build %s: %w", args[0], err)
+			}
+			b, err := l.load(ctx, args[1])
+			if err != nil {
+				return fmt.Errorf("unable to read build %s: %w", args[1], err)
+			}
+			if err := l.readFiles(ctx, a, b); err != nil {
+				return err
+			}
+
+			diff := imagediff.Compare(a.Image, b.Image)
+			if output == "json" {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(diff)
+			}
+			printDiff(os.Stdout, diff, a, b, maxFiles)
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&token, "token", "", "Depot token")
+	flags.StringVar(&target, "target", "", "Compare the images of this bake target")
+	flags.StringVar(&platform, "platform", "", `Compare the images of this platform of multi-platform builds (e.g., "linux/arm64")`)
+	flags.StringVar(&maxLayerSize, "max-layer-size", "512MiB", `Skip the file comparison when a layer to read is larger than this ("0" to always skip)`)
+	flags.IntVar(&maxFiles, "max-files", defaultMaxFiles, "Maximum number of changed files to print")
+	flags.StringVar(&output, "output", "", `Output format ("json")`)
+
+	return cmd
+}
+
+func printDiff(w io.Writer, diff *imagediff.Diff, a, b *image, maxFiles int) {
+	fmt.Fprintf(w, "A: %s\nB: %s\n", a.name, b.name)
+
+	fmt.Fprintf(w, "\nLayers (%s):\n", signedSize(diff.SizeDelta))
+	for _, layer := range diff.Layers {
+		switch layer.Status {
+		case imagediff.StatusUnchanged:
+			fmt.Fprintf(w, "  #%-3d %-9s %s %s\n", layer.Index, layer.Status, shortDigest(layer.DigestA), units.HumanSize(float64(layer.SizeA)))
+		case imagediff.StatusAdded:
+			fmt.Fprintf(w, "  #%-3d %-9s %s %s\n", layer.Index, layer.Status, shortDigest(layer.DigestB), signedSize(layer.SizeB))
+		case imagediff.StatusRemoved:
+			fmt.Fprintf(w, "  #%-3d %-9s %s %s\n", layer.Index, layer.Status, shortDigest(layer.DigestA), signedSize(-layer.SizeA))
+		default:
+			fmt.Fprintf(w, "  #%-3d %-9s %s -> %s %s -> %s (%s)\n", layer.Index, layer.Status,
+				shortDigest(layer.DigestA), shortDigest(layer.DigestB),
+				units.HumanSize(float64(layer.SizeA)), units.HumanSize(float64(layer.SizeB)), signedSize(layer.SizeB-layer.SizeA))
+		}
+	}
+
+	fmt.Fprintln(w, "\nConfig:")
+	if len(diff.Config) == 0 {
+		fmt.Fprintln(w, "  no changes")
+	}
+	for _, change := range diff.Config {
+		fmt.Fprintf(w, "  %s: %s -> %s\n", change.Field, orNone(change.A), orNone(change.B))
+	}
+
+	fmt.Fprintln(w, "\nFiles:")
+	switch {
+	case diff.Files == nil:
+		fmt.Fprintf(w, "  not compared: %s\n", orNone(firstNonEmpty(a.filesSkipped, b.filesSkipped)))
+	case len(diff.Files) == 0:
+		fmt.Fprintln(w, "  no changes")
+	default:
+		files := append([]imagediff.FileChange(nil), diff.Files...)
+		// The largest changes are the most interesting.
+		sort.SliceStable(files, func(i, j int) bool { return abs(files[i].SizeB-files[i].SizeA) > abs(files[j].SizeB-files[j].SizeA) })
+		fmt.Fprintf(w, "  %s\n", countStatuses(len(files), func(i int) string { return files[i].Status }))
+		for i, file := range files {
+			if i == maxFiles {
+				fmt.Fprintf(w, "  ... and %d more, use --output json to list all\n", len(files)-maxFiles)
+				break
+			}
+			fmt.Fprintf(w, "  %s %s %s\n", statusSymbol(file.Status), file.Path, signedSize(file.SizeB-file.SizeA))
+		}
+	}
+
+	fmt.Fprintln(w, "\nPackages:")
+	switch {
+	case diff.Packages == nil:
+		fmt.Fprintln(w, "  not compared: both builds need an SBOM (--sbom)")
+	case len(diff.Packages) == 0:
+		fmt.Fprintln(w, "  no changes")
+	default:
+		fmt.Fprintf(w, "  %s\n", countStatuses(len(diff.Packages), func(i int) string { return diff.Packages[i].Status }))
+		for _, pkg := range diff.Packages {
+			switch pkg.Status {
+			case imagediff.StatusAdded:
+				fmt.Fprintf(w, "  + %s %s\n", pkg.Name, pkg.VersionB)
+			case imagediff.StatusRemoved:
+				fmt.Fprintf(w, "  - %s %s\n", pkg.Name, pkg.VersionA)
+			default:
+				fmt.Fprintf(w, "  ~ %s %s -> %s\n", pkg.Name, pkg.VersionA, pkg.VersionB)
+			}
+		}
+	}
+}
+
+func countStatuses(n int, status func(int) string) string {
+	counts := map[string]int{}
+	for i := 0; i < n; i++ {
+		counts[status(i)]++
+	}
+	return fmt.Sprintf("%d added, %d removed, %d modified", counts[imagediff.StatusAdded], counts[imagediff.StatusRemoved], counts[imagediff.StatusModified])
+}
+
+func statusSymbol(status string) string {
+	switch status {
+	case imagediff.StatusAdded:
+		return "+"
+	case imagediff.StatusRemoved:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+func signedSize(delta int64) string {
+	if delta < 0 {
+		return "-" + units.HumanSize(float64(-delta))
+	}
+	return "+" + units.HumanSize(float64(delta))
+}
+
+func shortDigest(dgst fmt.Stringer) string {
+	s := dgst.String()
+	if len(s) > 19 {
+		return s[:19]
+	}
+	return s
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,198 @@
+package diff
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	"github.com/depot/cli/pkg/imagediff"
+	"github.com/depot/cli/pkg/registryapi"
+	"github.com/docker/go-units"
+	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	annotationReferenceType   = "vnd.docker.reference.type"
+	annotationReferenceDigest = "vnd.docker.reference.digest"
+	attestationManifest       = "attestation-manifest"
+)
+
+// image is the saved image of a build that is compared.
+type image struct {
+	*imagediff.Image
+
+	buildID string
+	name    string
+	// filesSkipped is why the files of the image were not read.
+	filesSkipped string
+}
+
+// loader reads the saved images of builds from the ephemeral registry.
+type loader struct {
+	client       *registryapi.Client
+	target       string
+	platform     string
+	maxLayerSize int64
+}
+
+// load reads the manifest, config, and SBOM of the image of a build.
+func (l *loader) load(ctx context.Context, buildID string) (*image, error) {
+	desc, dt, err := l.client.GetImageManifest(ctx, buildID, l.target)
+	if err != nil {
+		return nil, err
+	}
+
+	img := &image{Image: &imagediff.Image{}, buildID: buildID, name: buildID}
+	if l.target != "" {
+		img.name += " " + l.target
+	}
+
+	var attestation *ocispecs.Descriptor
+	if images.IsIndexType(desc.MediaType) {
+		var index ocispecs.Index
+		if err := json.Unmarshal(dt, &index); err != nil {
+			return nil, err
+		}
+		manifest, err := l.selectManifest(index.Manifests)
+		if err != nil {
+			return nil, err
+		}
+		img.name += " " + platforms.Format(*manifest.Platform)
+		for _, m := range index.Manifests {
+			if m.Annotations[annotationReferenceType] == attestationManifest && m.Annotations[annotationReferenceDigest] == manifest.Digest.String() {
+				m := m
+				attestation = &m
+			}
+		}
+		if dt, err = l.client.GetManifest(ctx, buildID, manifest.Digest); err != nil {
+			return nil, err
+		}
+	} else if l.platform != "" {
+		return nil, fmt.Errorf("the image is not a multi-platform image, omit --platform")
+	}
+
+	if err := json.Unmarshal(dt, &img.Manifest); err != nil {
+		return nil, err
+	}
+	config, err := l.client.GetBlob(ctx, buildID, img.Manifest.Config.Digest)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(config, &img.Config); err != nil {
+		return nil, err
+	}
+
+	if attestation != nil {
+		if img.Packages, err = l.readPackages(ctx, buildID, attestation.Digest); err != nil {
+			return nil, err
+		}
+	}
+	return img, nil
+}
+
+// selectManifest returns the image manifest of the platform, or of the
+// first platform if none was requested.
+func (l *loader) selectManifest(manifests []ocispecs.Descriptor) (ocispecs.Descriptor, error) {
+	var available []string
+	for _, m := range manifests {
+		if m.Platform == nil || m.Annotations[annotationReferenceType] == attestationManifest {
+			continue
+		}
+		if l.platform == "" || platforms.Only(platforms.MustParse(l.platform)).Match(*m.Platform) {
+			return m, nil
+		}
+		available = append(available, platforms.Format(*m.Platform))
+	}
+	if l.platform == "" {
+		return ocispecs.Descriptor{}, fmt.Errorf("the image has no platform manifests")
+	}
+	return ocispecs.Descriptor{}, fmt.Errorf("the image has no %s manifest (available: %v)", l.platform, available)
+}
+
+// readPackages reads the packages of the SPDX SBOMs of an attestation
+// manifest.  It returns nil if the manifest has no SBOM.
+func (l *loader) readPackages(ctx context.Context, buildID string, dgst digest.Digest) (map[string][]string, error) {
+	dt, err := l.client.GetManifest(ctx, buildID, dgst)
+	if err != nil {
+		return nil, err
+	}
+	var manifest ocispecs.Manifest
+	if err := json.Unmarshal(dt, &manifest); err != nil {
+		return nil, err
+	}
+
+	var packages map[string][]string
+	for _, layer := range manifest.Layers {
+		if !imagediff.IsSPDXLayer(layer.Annotations) {
+			continue
+		}
+		dt, err := l.client.GetBlob(ctx, buildID, layer.Digest)
+		if err != nil {
+			return nil, err
+		}
+		if packages == nil {
+			packages = map[string][]string{}
+		}
+		if err := imagediff.ReadPackages(packages, dt, layer.MediaType); err != nil {
+			return nil, fmt.Errorf("invalid SBOM %s: %w", layer.Digest, err)
+		}
+	}
+	return packages, nil
+}
+
+// readFiles reads the filesystems of both images.  The layers the images
+// share at the bottom of their stacks are only read once.
+func (l *loader) readFiles(ctx context.Context, a, b *image) error {
+	if l.maxLayerSize <= 0 {
+		a.filesSkipped = "disabled with --max-layer-size 0"
+		return nil
+	}
+	for _, img := range []*image{a, b} {
+		for _, layer := range img.Manifest.Layers {
+			if layer.Size > l.maxLayerSize {
+				a.filesSkipped = fmt.Sprintf("layer %s of %s is larger than --max-layer-size %s", layer.Digest, img.buildID, units.BytesSize(float64(l.maxLayerSize)))
+				return nil
+			}
+		}
+	}
+
+	shared := 0
+	for shared < len(a.Manifest.Layers) && shared < len(b.Manifest.Layers) && a.Manifest.Layers[shared].Digest == b.Manifest.Layers[shared].Digest {
+		shared++
+	}
+
+	base := map[string]imagediff.File{}
+	for _, layer := range a.Manifest.Layers[:shared] {
+		if err := l.applyLayer(ctx, base, a.buildID, layer); err != nil {
+			return err
+		}
+	}
+	for _, img := range []*image{a, b} {
+		files := make(map[string]imagediff.File, len(base))
+		for name, f := range base {
+			files[name] = f
+		}
+		for _, layer := range img.Manifest.Layers[shared:] {
+			if err := l.applyLayer(ctx, files, img.buildID, layer); err != nil {
+				return err
+			}
+		}
+		img.Files = files
+	}
+	return nil
+}
+
+func (l *loader) applyLayer(ctx context.Context, files map[string]imagediff.File, buildID string, layer ocispecs.Descriptor) error {
+	r, err := l.client.OpenBlob(ctx, buildID, layer.Digest)
+	if err != nil {
+		return fmt.Errorf("unable to read layer %s: %w", layer.Digest, err)
+	}
+	defer r.Close()
+	if err := imagediff.ApplyLayer(files, r, layer.MediaType); err != nil {
+		return fmt.Errorf("unable to read layer %s: %w", layer.Digest, err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package docs
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmdDocs() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "docs",
+		Short: "Generate documentation for the Depot CLI",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fmt.Errorf("missing subcommand, please run `depot docs --help`")
+		},
+	}
+
+	cmd.AddCommand(NewCmdMan())
+
+	return cmd
+}
+
+func NewCmdMan() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "man",
+		Short: "Generate man pages for every depot command",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return err
+			}
+			return GenManTree(cmd.Root(), dir)
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "man", "Directory to write the man pages to")
+
+	return cmd
+}
@@ -0,0 +1,18 @@
+package docs
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+// GenManTree writes a section 1 man page for cmd and each of its available
+// subcommands into dir.  $SOURCE_DATE_EPOCH is honored so that packaged man
+// pages are reproducible.
+func GenManTree(cmd *cobra.Command, dir string) error {
+	header := &doc.GenManHeader{
+		Section: "1",
+		Source:  "Depot",
+		Manual:  "Depot Manual",
+	}
+	return doc.GenManTree(cmd, header, dir)
+}
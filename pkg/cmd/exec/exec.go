@@ -78,22 +78,18 @@ func NewCmdExec() *cobra.Command {
 		}()
 
 		printCtx, cancel := context.WithCancel(ctx)
-		printer, buildErr := progress.NewPrinter(printCtx, os.Stderr, os.Stderr, progressMode)
+		printer, buildErr := progress.NewPrinter(printCtx, os.Stderr, os.Stderr, progresshelper.ResolveMode(progressMode))
 		if buildErr != nil {
 			cancel()
 			return buildErr
 		}
 
 		reportingWriter := progresshelper.NewReporter(printCtx, printer, build.ID, build.Token)
+		progresshelper.LogBuildURL(reportingWriter, build.BuildURL)
 
 		var builder *machine.Machine
 		buildErr = progresshelper.WithLog(reportingWriter, fmt.Sprintf("[depot] launching %s machine", platform), func() error {
-			for i := 0; i < 2; i++ {
-				builder, buildErr = machine.Acquire(ctx, build.ID, build.Token, platform)
-				if buildErr == nil {
-					break
-				}
-			}
+			builder, buildErr = machine.Acquire(ctx, build.ID, build.Token, platform)
 			return buildErr
 		})
 		if buildErr != nil {
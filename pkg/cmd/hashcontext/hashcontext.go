@@ -0,0 +1,10 @@
+package hashcontext
+
+import (
+	"github.com/depot/cli/pkg/buildx/commands"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdHashContext() *cobra.Command {
+	return commands.HashContextCmd()
+}
@@ -0,0 +1,10 @@
+package lint
+
+import (
+	"github.com/depot/cli/pkg/buildx/commands"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdLint() *cobra.Command {
+	return commands.LintCmd()
+}
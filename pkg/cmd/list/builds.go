@@ -9,19 +9,22 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/apicache"
 	"github.com/depot/cli/pkg/helpers"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
-func NewCmdBuilds() *cobra.Command {
+func NewCmdBuilds(commandName, commandAlias string) *cobra.Command {
 	var projectID string
 	var token string
 	var outputFormat string
+	var sortBy string
+	var noCache bool
 
 	cmd := &cobra.Command{
-		Use:     "builds",
-		Aliases: []string{"b"},
+		Use:     commandName,
+		Aliases: []string{commandAlias},
 		Short:   "List builds for a project",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cwd, _ := os.Getwd()
@@ -39,6 +42,13 @@ func NewCmdBuilds() *cobra.Command {
 				return fmt.Errorf("missing API token, please run `depot login`")
 			}
 
+			if err := (helpers.DepotBuilds{}).Sort(sortBy); err != nil {
+				return err
+			}
+			if noCache {
+				apicache.Disable()
+			}
+
 			client := api.NewBuildClient()
 			if !helpers.IsTerminal() && outputFormat == "" {
 				outputFormat = "csv"
@@ -47,10 +57,15 @@ func NewCmdBuilds() *cobra.Command {
 				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 				defer cancel()
 
+				// Scripts list the builds from the API, as they often run
+				// right after a build.
 				depotBuilds, err := helpers.Builds(ctx, token, projectID, client)
 				if err != nil {
 					return err
 				}
+				if err := depotBuilds.Sort(sortBy); err != nil {
+					return err
+				}
 
 				switch outputFormat {
 				case "csv":
@@ -63,6 +78,7 @@ func NewCmdBuilds() *cobra.Command {
 			}
 
 			m := helpers.NewBuildsModel(projectID, token, client)
+			m.SortBy = sortBy
 			_, err = tea.NewProgram(m, tea.WithAltScreen()).Run()
 			return err
 		},
@@ -73,6 +89,8 @@ func NewCmdBuilds() *cobra.Command {
 	flags.StringVar(&projectID, "project", "", "Depot project ID")
 	flags.StringVar(&token, "token", "", "Depot token")
 	flags.StringVar(&outputFormat, "output", "", "Non-interactive output format (json, csv)")
+	flags.StringVar(&sortBy, "sort", helpers.SortBuildsByCreated, "Sort builds by created, duration, or cost")
+	flags.BoolVar(&noCache, "no-cache", false, "Do not use builds listed in the last few seconds")
 
 	return cmd
 }
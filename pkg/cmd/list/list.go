@@ -17,7 +17,7 @@ func NewCmdList() *cobra.Command {
 	}
 
 	cmd.AddCommand(NewCmdProjects("projects", "p"))
-	cmd.AddCommand(NewCmdBuilds())
+	cmd.AddCommand(NewCmdBuilds("builds", "b"))
 
 	return cmd
 }
@@ -9,11 +9,11 @@ import (
 	"os"
 	"time"
 
-	"connectrpc.com/connect"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/apicache"
 	"github.com/depot/cli/pkg/helpers"
 	cliv1beta1 "github.com/depot/cli/pkg/proto/depot/cli/v1beta1"
 	"github.com/depot/cli/pkg/proto/depot/cli/v1beta1/cliv1beta1connect"
@@ -25,6 +25,7 @@ func NewCmdProjects(commandName, commandAlias string) *cobra.Command {
 	var (
 		token        string
 		outputFormat string
+		noCache      bool
 	)
 
 	cmd := &cobra.Command{
@@ -41,6 +42,10 @@ func NewCmdProjects(commandName, commandAlias string) *cobra.Command {
 				return fmt.Errorf("missing API token, please run `depot login`")
 			}
 
+			if noCache {
+				apicache.Disable()
+			}
+
 			columns := []table.Column{
 				{Title: "Project ID", Width: 24},
 				{Title: "Name", Width: 64},
@@ -72,7 +77,9 @@ func NewCmdProjects(commandName, commandAlias string) *cobra.Command {
 				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 				defer cancel()
 
-				project, err := depotProjects(ctx, token, projectClient)
+				// Scripts list the projects from the API, as they often run
+				// right after a change.
+				project, err := depotProjects(ctx, token, projectClient, helpers.RefreshProjects)
 				if err != nil {
 					return err
 				}
@@ -104,6 +111,7 @@ func NewCmdProjects(commandName, commandAlias string) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&token, "token", "", "Depot token")
 	flags.StringVar(&outputFormat, "output", "", "Non-interactive output format (json, csv)")
+	flags.BoolVar(&noCache, "no-cache", false, "Do not use projects listed in the last few seconds")
 
 	return cmd
 }
@@ -123,7 +131,7 @@ type projectsModel struct {
 }
 
 func (m projectsModel) Init() tea.Cmd {
-	return m.loadProjects()
+	return m.loadProjects(false)
 }
 
 func (m projectsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -154,7 +162,7 @@ func (m projectsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		if msg.String() == "r" {
-			return m, m.loadProjects()
+			return m, m.loadProjects(true)
 		}
 
 		if msg.Type == tea.KeyEnter {
@@ -211,12 +219,18 @@ func (m projectsModel) View() string {
 type projects []table.Row
 type projectErrMsg struct{ error }
 
-func (m projectsModel) loadProjects() tea.Cmd {
+// loadProjects lists the projects, using a cached response unless refresh is
+// set by the r key.
+func (m projectsModel) loadProjects(refresh bool) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		res, err := depotProjects(ctx, m.token, m.projectClient)
+		list := helpers.ListProjects
+		if refresh {
+			list = helpers.RefreshProjects
+		}
+		res, err := depotProjects(ctx, m.token, m.projectClient, list)
 		if err != nil {
 			return projectErrMsg{err}
 		}
@@ -235,14 +249,15 @@ type depotProject struct {
 	Name string `json:"name"`
 }
 
-func depotProjects(ctx context.Context, token string, client cliv1beta1connect.ProjectsServiceClient) ([]depotProject, error) {
-	req := cliv1beta1.ListProjectsRequest{}
-	resp, err := client.ListProjects(ctx, api.WithAuthentication(connect.NewRequest(&req), token))
+type listProjectsFunc func(context.Context, string, cliv1beta1connect.ProjectsServiceClient) (*cliv1beta1.ListProjectsResponse, error)
+
+func depotProjects(ctx context.Context, token string, client cliv1beta1connect.ProjectsServiceClient, list listProjectsFunc) ([]depotProject, error) {
+	resp, err := list(ctx, token, client)
 	if err != nil {
 		return nil, err
 	}
 	projects := []depotProject{}
-	for _, project := range resp.Msg.Projects {
+	for _, project := range resp.Projects {
 		projects = append(projects, depotProject{ID: project.Id, Name: project.Name})
 	}
 
@@ -0,0 +1,10 @@
+package lock
+
+import (
+	"github.com/depot/cli/pkg/buildx/commands"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdLock() *cobra.Command {
+	return commands.LockCmd()
+}
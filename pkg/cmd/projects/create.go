@@ -8,6 +8,7 @@ import (
 	corev1 "buf.build/gen/go/depot/api/protocolbuffers/go/depot/core/v1"
 	"connectrpc.com/connect"
 	"github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/apicache"
 	"github.com/depot/cli/pkg/helpers"
 	"github.com/spf13/cobra"
 )
@@ -57,6 +58,7 @@ func NewCmdCreate() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			apicache.Clear("projects")
 			project := NewCreateResponse(res.Msg.GetProject())
 			buf, err := json.Marshal(project)
 			if err != nil {
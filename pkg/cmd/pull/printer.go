@@ -3,7 +3,6 @@ package pull
 import (
 	"context"
 	"io"
-	"os"
 	"sync"
 
 	"github.com/containerd/console"
@@ -65,10 +64,6 @@ func NewPrinter(ctx context.Context, displayPhrase string, w io.Writer, out cons
 		logSourceMap: map[digest.Digest]interface{}{},
 	}
 
-	if v := os.Getenv("BUILDKIT_PROGRESS"); v != "" && mode == prog.PrinterModeAuto {
-		mode = v
-	}
-
 	var c console.Console
 	switch mode {
 	case prog.PrinterModeQuiet:
@@ -4,14 +4,13 @@ import (
 	"context"
 	"fmt"
 
-	"connectrpc.com/connect"
 	depotapi "github.com/depot/cli/pkg/api"
-	"github.com/depot/cli/pkg/ci"
 	"github.com/depot/cli/pkg/dockerclient"
 	"github.com/depot/cli/pkg/helpers"
 	"github.com/depot/cli/pkg/load"
+	"github.com/depot/cli/pkg/progresshelper"
 	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
-	prog "github.com/docker/buildx/util/progress"
+	"github.com/depot/cli/pkg/registryapi"
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/spf13/cobra"
@@ -42,10 +41,7 @@ func NewCmdPull() *cobra.Command {
 			if len(args) > 0 {
 				buildID = args[0]
 			}
-			_, isCI := ci.Provider()
-			if progress == prog.PrinterModeAuto && isCI {
-				progress = prog.PrinterModePlain
-			}
+			progress = progresshelper.ResolveMode(progress)
 
 			ctx := cmd.Context()
 
@@ -95,22 +91,20 @@ func NewCmdPull() *cobra.Command {
 				}
 			}
 
-			client := depotapi.NewBuildClient()
-			req := &cliv1.GetPullInfoRequest{BuildId: buildID}
-			res, err := client.GetPullInfo(ctx, depotapi.WithAuthentication(connect.NewRequest(req), token))
+			info, err := registryapi.NewClient(token).GetPullInfo(ctx, buildID)
 			if err != nil {
 				return err
 			}
 
-			buildOptions := res.Msg.Options
+			buildOptions := info.Options
 			if len(buildOptions) > 0 && !isSavedBuild(buildOptions) {
 				return fmt.Errorf("build %s is not a saved build. To use the ephemeral registry use --save when building", buildID)
 			}
 
 			if isBake(buildOptions) {
-				return pullBake(ctx, dockerCli, res.Msg, targets, userTags, platform, progress)
+				return pullBake(ctx, dockerCli, info, targets, userTags, platform, progress)
 			} else {
-				return pullBuild(ctx, dockerCli, res.Msg, userTags, platform, progress)
+				return pullBuild(ctx, dockerCli, info, userTags, platform, progress)
 			}
 		},
 	}
@@ -1,7 +1,11 @@
 package pulltoken
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"os"
+	"time"
 
 	"connectrpc.com/connect"
 	depotapi "github.com/depot/cli/pkg/api"
@@ -16,6 +20,7 @@ func NewCmdPullToken() *cobra.Command {
 		token     string
 		projectID string
 		buildID   string
+		output    string
 	)
 
 	cmd := &cobra.Command{
@@ -26,6 +31,9 @@ func NewCmdPullToken() *cobra.Command {
 			if len(args) > 0 {
 				buildID = args[0]
 			}
+			if output != "" && output != "json" && output != "docker-config" {
+				return fmt.Errorf("unknown output format %q (expected json or docker-config)", output)
+			}
 
 			ctx := cmd.Context()
 
@@ -46,13 +54,63 @@ func NewCmdPullToken() *cobra.Command {
 				return err
 			}
 
-			fmt.Println(res.Msg.Token)
-			return nil
+			return printPullToken(res.Msg, output)
 		},
 	}
 
 	cmd.Flags().StringVar(&projectID, "project", "", "Depot project ID")
 	cmd.Flags().StringVar(&token, "token", "", "Depot token")
+	cmd.Flags().StringVar(&output, "output", "", `Output format ("json" or "docker-config"); prints only the token by default`)
 
 	return cmd
 }
+
+const registryHost = "registry.depot.dev"
+
+type pullToken struct {
+	Registry  string     `json:"registry"`
+	Username  string     `json:"username"`
+	Token     string     `json:"token"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// printPullToken prints the token alone, as JSON, or as a docker config.json
+// that can be used as a Kubernetes image pull secret.
+func printPullToken(res *cliv1.GetPullTokenResponse, output string) error {
+	username := res.GetUsername()
+	if username == "" {
+		username = "x-token"
+	}
+
+	switch output {
+	case "":
+		fmt.Println(res.Token)
+		return nil
+	case "json":
+		creds := pullToken{Registry: registryHost, Username: username, Token: res.Token}
+		if res.ExpiresAt != nil {
+			expiresAt := res.ExpiresAt.AsTime()
+			creds.ExpiresAt = &expiresAt
+		}
+		return writeJSON(creds)
+	case "docker-config":
+		auth := base64.StdEncoding.EncodeToString([]byte(username + ":" + res.Token))
+		return writeJSON(map[string]interface{}{
+			"auths": map[string]interface{}{
+				registryHost: map[string]string{
+					"username": username,
+					"password": res.Token,
+					"auth":     auth,
+				},
+			},
+		})
+	default:
+		return fmt.Errorf("unknown output format %q (expected json or docker-config)", output)
+	}
+}
+
+func writeJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
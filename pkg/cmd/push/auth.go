@@ -2,6 +2,7 @@ package push
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"net/http"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"github.com/containerd/containerd/remotes/docker"
 	"github.com/containerd/containerd/remotes/docker/auth"
 	depotapi "github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/build"
 	"github.com/docker/cli/cli/command"
 	configtypes "github.com/docker/cli/cli/config/types"
 	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
@@ -17,12 +19,21 @@ import (
 
 // GetAuthToken gets an auth token for a registry.
 // It does this by loading the local docker auth, determining the authorization schema via a HEAD request,
-// and then requesting a token from the realm.
-func GetAuthToken(ctx context.Context, dockerCli command.Cli, parsedTag *ParsedTag, manifest ocispecs.Descriptor) (*Token, error) {
-	authConfig, err := GetAuthConfig(dockerCli, parsedTag.Host)
+// and then requesting a token from the realm.  The token can also pull from the
+// mount source repositories.
+// The credentials, such as those of --registry-auth, take precedence over the
+// local docker auth for their registries.
+func GetAuthToken(ctx context.Context, dockerCli command.Cli, credentials []build.Credential, parsedTag *ParsedTag, manifest ocispecs.Descriptor, sources []string) (*Token, error) {
+	authConfig, err := credentialAuthConfig(credentials, parsedTag.Host)
 	if err != nil {
 		return nil, err
 	}
+	if authConfig == nil {
+		authConfig, err = GetAuthConfig(dockerCli, parsedTag.Host)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	push := true
 	scope, err := docker.RepositoryScope(parsedTag.Refspec, push)
@@ -35,7 +46,11 @@ func GetAuthToken(ctx context.Context, dockerCli command.Cli, parsedTag *ParsedT
 		return nil, err
 	}
 
-	return FetchToken(ctx, authConfig, challenge, []string{scope})
+	scopes := []string{scope}
+	for _, source := range sources {
+		scopes = append(scopes, fmt.Sprintf("repository:%s:pull", source))
+	}
+	return FetchToken(ctx, authConfig, challenge, scopes)
 }
 
 // GetAuthConfig gets the auth config from the local docker login.
@@ -51,6 +66,31 @@ func GetAuthConfig(dockerCli command.Cli, host string) (*configtypes.AuthConfig,
 	return &config, nil
 }
 
+// credentialAuthConfig returns the auth config of the credential for host, or
+// nil if there is none.
+func credentialAuthConfig(credentials []build.Credential, host string) (*configtypes.AuthConfig, error) {
+	for _, c := range credentials {
+		if c.Host != host {
+			continue
+		}
+		if c.IdentityToken != "" {
+			return &configtypes.AuthConfig{IdentityToken: c.IdentityToken, ServerAddress: host}, nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(c.Token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid credential for %s: %w", host, err)
+		}
+		username, password, _ := strings.Cut(string(decoded), ":")
+		return &configtypes.AuthConfig{
+			Username:      username,
+			Password:      password,
+			Auth:          c.Token,
+			ServerAddress: host,
+		}, nil
+	}
+	return nil, nil
+}
+
 // AuthKind tries to do a HEAD request to the manifest to try to get the WWW-Authenticate header.
 // If HEAD is not supported, it will try to get a GET.  Apparently, this is for older registries.
 func AuthKind(ctx context.Context, refspec reference.Spec, manifest ocispecs.Descriptor) (*auth.Challenge, error) {
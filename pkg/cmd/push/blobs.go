@@ -1,15 +1,17 @@
 package push
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
-	"time"
 
+	depotapi "github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/registry"
 	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 type BlobToPush struct {
@@ -20,10 +22,9 @@ type BlobToPush struct {
 }
 
 // PushBlob requests a blob to be pushed from Depot to a destination registry.
-func PushBlob(ctx context.Context, depotToken string, blob *BlobToPush) error {
-	var err error
-	var req *http.Request
-
+// The blob service uploads the blob, so a failed upload is retried as a whole;
+// see UploadBlob to resume it instead.
+func PushBlob(ctx context.Context, policy *registry.PushPolicy, depotToken string, blob *BlobToPush) error {
 	pushRequest := struct {
 		RegistryHost        string `json:"registryHost"`
 		RepositoryNamespace string `json:"repositoryNamespace"`
@@ -38,34 +39,40 @@ func PushBlob(ctx context.Context, depotToken string, blob *BlobToPush) error {
 	buf, _ := json.MarshalIndent(pushRequest, "", "  ")
 	url := fmt.Sprintf("https://blob.depot.dev/blobs/%s/%s", blob.BuildID, blob.Digest.String())
 
-	attempts := 0
-	for {
-		attempts += 1
-
-		req, err = http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(string(buf)))
+	// Uploads are limited by the destination registry, which rate limits them.
+	resp, err := policy.Do(ctx, blob.ParsedTag.Host, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(buf))
 		if err != nil {
-			return err
+			return nil, err
 		}
 		req.Header.Add("Authorization", "Bearer "+depotToken)
 		req.Header.Set("Content-Type", "application/json")
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return err
-		}
-		_ = resp.Body.Close()
-
-		if resp.StatusCode/100 != 2 {
-			if resp.StatusCode >= 500 && attempts < 3 {
-				time.Sleep(5 * time.Second)
-				continue
-			}
-
-			body, _ := io.ReadAll(resp.Body)
-			err := fmt.Errorf("unexpected status code: %d %s", resp.StatusCode, string(body))
-			return err
-		}
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
 
-		return nil
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d %s", resp.StatusCode, string(body))
 	}
+	return nil
+}
 
+// UploadBlob uploads a blob from the Depot ephemeral registry to the
+// destination registry through the CLI, in chunks that resume after a failure.
+func UploadBlob(ctx context.Context, policy *registry.PushPolicy, descriptors *ImageDescriptors, blob *BlobToPush, desc ocispecs.Descriptor) error {
+	upload := registry.BlobUpload{
+		Host:          blob.ParsedTag.Host,
+		Repository:    blob.ParsedTag.Path,
+		Digest:        desc.Digest,
+		Size:          desc.Size,
+		Authorization: fmt.Sprintf("%s %s", blob.RegistryToken.Scheme, blob.RegistryToken.Token),
+		UserAgent:     depotapi.Agent(),
+	}
+	return policy.UploadBlob(ctx, upload, func(offset int64) (io.ReadCloser, error) {
+		return descriptors.OpenBlob(ctx, desc, offset)
+	})
 }
@@ -10,21 +10,21 @@ import (
 	"strings"
 	"sync"
 
-	"connectrpc.com/connect"
 	"github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/reference"
 	"github.com/containerd/containerd/remotes"
 	"github.com/containerd/containerd/remotes/docker"
 	depotapi "github.com/depot/cli/pkg/api"
-	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+	"github.com/depot/cli/pkg/registry"
+	"github.com/depot/cli/pkg/registryapi"
 	"github.com/opencontainers/go-digest"
 	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
 	"golang.org/x/sync/errgroup"
 )
 
 // PushManifest pushes a manifest to a registry.
-func PushManifest(ctx context.Context, registryToken *Token, refspec reference.Spec, tag string, manifest ocispecs.Descriptor, manifestBytes []byte) error {
+func PushManifest(ctx context.Context, policy *registry.PushPolicy, registryToken *Token, refspec reference.Spec, tag string, manifest ocispecs.Descriptor, manifestBytes []byte) error {
 	// Reversing the refspec's path.Join behavior.
 	i := strings.Index(refspec.Locator, "/")
 	host, repository := refspec.Locator[:i], refspec.Locator[i+1:]
@@ -34,15 +34,16 @@ func PushManifest(ctx context.Context, registryToken *Token, refspec reference.S
 
 	url := fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, repository, tag)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(manifestBytes))
-	if err != nil {
-		return err
-	}
-	req.Header.Set("User-Agent", depotapi.Agent())
-	req.Header.Set("Content-Type", manifest.MediaType)
-	req.Header.Set("Authorization", fmt.Sprintf("%s %s", registryToken.Scheme, registryToken.Token))
-
-	res, err := http.DefaultClient.Do(req)
+	res, err := policy.Do(ctx, host, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(manifestBytes))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", depotapi.Agent())
+		req.Header.Set("Content-Type", manifest.MediaType)
+		req.Header.Set("Authorization", fmt.Sprintf("%s %s", registryToken.Scheme, registryToken.Token))
+		return req, nil
+	})
 	if err != nil {
 		return err
 	}
@@ -66,19 +67,42 @@ type ImageDescriptors struct {
 
 	IndexBytes    map[digest.Digest][]byte `json:"indexBytes,omitempty"`
 	ManifestBytes map[digest.Digest][]byte `json:"manifestBytes,omitempty"`
+
+	// fetcher reads the blobs from the Depot ephemeral registry.
+	fetcher remotes.Fetcher
+}
+
+// OpenBlob reads a blob of the image from the Depot ephemeral registry,
+// starting at offset.
+func (d *ImageDescriptors) OpenBlob(ctx context.Context, desc ocispecs.Descriptor, offset int64) (io.ReadCloser, error) {
+	r, err := d.fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	if offset == 0 {
+		return r, nil
+	}
+	seeker, ok := r.(io.Seeker)
+	if !ok {
+		_ = r.Close()
+		return nil, fmt.Errorf("unable to read blob %s from offset %d", desc.Digest, offset)
+	}
+	if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+		_ = r.Close()
+		return nil, err
+	}
+	return r, nil
 }
 
 // GetImageDescriptors returns back all the descriptors for an image.
 func GetImageDescriptors(ctx context.Context, token, buildID, target string, logger StartLogDetailFunc) (*ImageDescriptors, error) {
 	// Download location and credentials of ephemeral image save.
-	client := depotapi.NewBuildClient()
-	req := &cliv1.GetPullInfoRequest{BuildId: buildID}
-	res, err := client.GetPullInfo(ctx, depotapi.WithAuthentication(connect.NewRequest(req), token))
+	info, err := registryapi.NewClient(token).GetPullInfo(ctx, buildID)
 	if err != nil {
 		return nil, err
 	}
 
-	username, password, ref := res.Msg.Username, res.Msg.Password, res.Msg.Reference
+	username, password, ref := info.Username, info.Password, info.Reference
 	if target != "" {
 		ref = ref + "-" + target
 	}
@@ -100,10 +124,16 @@ func GetImageDescriptors(ctx context.Context, token, buildID, target string, log
 		return nil, err
 	}
 
+	blobFetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
 	mu := sync.Mutex{}
 	descs := ImageDescriptors{
 		IndexBytes:    map[digest.Digest][]byte{},
 		ManifestBytes: map[digest.Digest][]byte{},
+		fetcher:       blobFetcher,
 	}
 
 	// Recursively fetch all the image descriptors. If a descriptor contains
@@ -0,0 +1,110 @@
+package push
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	depotapi "github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/registry"
+	ref "github.com/distribution/reference"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// unmountable holds the "host/repository" sources that a registry started an
+// upload for instead of mounting a blob, such as the repositories of ECR
+// registries without blob mounting enabled.  The other blobs are uploaded
+// without asking to mount them from those sources again.
+var unmountable sync.Map
+
+// MountSources returns the repositories of the destination registry that
+// blobs can be mounted from.  Repositories of other registries are skipped, as
+// registries only mount blobs between their own repositories.
+func MountSources(parsedTag *ParsedTag, repositories []string) []string {
+	var sources []string
+	for _, repository := range repositories {
+		named, err := ref.ParseNormalizedNamed(repository)
+		if err != nil {
+			continue
+		}
+		source, err := ParseTag(named.Name())
+		if err != nil || source.Host != parsedTag.Host || source.Path == parsedTag.Path {
+			continue
+		}
+		sources = append(sources, source.Path)
+	}
+	return sources
+}
+
+// MountBlob makes the blob available in the destination repository without
+// uploading it.  It returns false if the blob must be uploaded, and otherwise
+// the source repository it was mounted from, or "" if the repository already
+// has it.  Mounting is an optimization, so registry errors fall back to an
+// upload.
+func MountBlob(ctx context.Context, policy *registry.PushPolicy, registryToken *Token, parsedTag *ParsedTag, blob ocispecs.Descriptor, sources []string) (string, bool) {
+	if hasBlob(ctx, policy, registryToken, parsedTag.Host, parsedTag.Path, blob) {
+		return "", true
+	}
+
+	for _, source := range sources {
+		if _, ok := unmountable.Load(parsedTag.Host + "/" + source); ok {
+			continue
+		}
+		// Only mount from repositories that have the blob, as registries
+		// start a regular upload when the mount fails.
+		if !hasBlob(ctx, policy, registryToken, parsedTag.Host, source, blob) {
+			continue
+		}
+
+		query := url.Values{"mount": {blob.Digest.String()}, "from": {source}}
+		u := fmt.Sprintf("https://%s/v2/%s/blobs/uploads/?%s", parsedTag.Host, parsedTag.Path, query.Encode())
+		res, err := policy.Do(ctx, parsedTag.Host, func() (*http.Request, error) {
+			return registryRequest(ctx, http.MethodPost, u, registryToken)
+		})
+		if err != nil {
+			continue
+		}
+		_ = res.Body.Close()
+
+		switch res.StatusCode {
+		case http.StatusCreated:
+			return source, true
+		case http.StatusAccepted:
+			// The registry started an upload instead; cancel it.
+			unmountable.Store(parsedTag.Host+"/"+source, struct{}{})
+			if location, err := res.Location(); err == nil {
+				res, err := policy.Do(ctx, parsedTag.Host, func() (*http.Request, error) {
+					return registryRequest(ctx, http.MethodDelete, location.String(), registryToken)
+				})
+				if err == nil {
+					_ = res.Body.Close()
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+func hasBlob(ctx context.Context, policy *registry.PushPolicy, registryToken *Token, host, repository string, blob ocispecs.Descriptor) bool {
+	u := fmt.Sprintf("https://%s/v2/%s/blobs/%s", host, repository, blob.Digest)
+	res, err := policy.Do(ctx, host, func() (*http.Request, error) {
+		return registryRequest(ctx, http.MethodHead, u, registryToken)
+	})
+	if err != nil {
+		return false
+	}
+	_ = res.Body.Close()
+	return res.StatusCode == http.StatusOK
+}
+
+func registryRequest(ctx context.Context, method, url string, registryToken *Token) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", depotapi.Agent())
+	req.Header.Set("Authorization", fmt.Sprintf("%s %s", registryToken.Scheme, registryToken.Token))
+	return req, nil
+}
@@ -0,0 +1,60 @@
+package push
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMountSources(t *testing.T) {
+	tests := []struct {
+		name         string
+		tag          string
+		repositories []string
+		want         []string
+	}{
+		{
+			name:         "same registry",
+			tag:          "ghcr.io/acme/api:v1",
+			repositories: []string{"ghcr.io/acme/base", "ghcr.io/acme/web:v1"},
+			want:         []string{"acme/base", "acme/web"},
+		},
+		{
+			name:         "Docker Hub",
+			tag:          "acme/api:v1",
+			repositories: []string{"docker.io/acme/base", "library/alpine"},
+			want:         []string{"acme/base", "library/alpine"},
+		},
+		{
+			name:         "other registry",
+			tag:          "ghcr.io/acme/api:v1",
+			repositories: []string{"quay.io/acme/base"},
+		},
+		{
+			name:         "destination repository",
+			tag:          "ghcr.io/acme/api:v1",
+			repositories: []string{"ghcr.io/acme/api:v2"},
+		},
+		{
+			name:         "invalid repository",
+			tag:          "ghcr.io/acme/api:v1",
+			repositories: []string{"ghcr.io/Acme/base"},
+		},
+		{
+			name:         "ECR",
+			tag:          "123456789012.dkr.ecr.us-east-1.amazonaws.com/api:v1",
+			repositories: []string{"123456789012.dkr.ecr.us-east-1.amazonaws.com/base"},
+			want:         []string{"base"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsedTag, err := ParseTag(tt.tag)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := MountSources(parsedTag, tt.repositories); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MountSources() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -27,11 +27,6 @@ func NewProgress(ctx context.Context, progressFmt string) (*Progress, FinishFn,
 	// Buffer up to 1024 vertex slices before blocking.
 	const channelBufferSize = 1024
 
-	override := os.Getenv("BUILDKIT_PROGRESS")
-	if override != "" && progressFmt == prog.PrinterModeAuto {
-		progressFmt = override
-	}
-
 	var (
 		w io.Writer = os.Stderr
 		c console.Console
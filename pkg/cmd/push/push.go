@@ -7,15 +7,17 @@ import (
 	"connectrpc.com/connect"
 	"github.com/depot/cli/pkg/api"
 	depotapi "github.com/depot/cli/pkg/api"
-	"github.com/depot/cli/pkg/ci"
+	"github.com/depot/cli/pkg/build"
 	"github.com/depot/cli/pkg/dockerclient"
 	"github.com/depot/cli/pkg/helpers"
+	"github.com/depot/cli/pkg/progresshelper"
 	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
-	prog "github.com/docker/buildx/util/progress"
+	"github.com/depot/cli/pkg/registry"
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -28,6 +30,9 @@ func NewCmdPush() *cobra.Command {
 		target      string
 		progressFmt string
 		tags        []string
+		concurrency int
+		attempts    int
+		mountFrom   []string
 	)
 
 	cmd := &cobra.Command{
@@ -44,10 +49,7 @@ func NewCmdPush() *cobra.Command {
 				buildID = args[0]
 			}
 
-			_, isCI := ci.Provider()
-			if progressFmt == prog.PrinterModeAuto && isCI {
-				progressFmt = prog.PrinterModePlain
-			}
+			progressFmt = progresshelper.ResolveMode(progressFmt)
 
 			ctx := cmd.Context()
 
@@ -77,12 +79,15 @@ func NewCmdPush() *cobra.Command {
 				return fmt.Errorf("missing tag, please specify a tag with --tag")
 			}
 
-			for _, tag := range tags {
+			policy := registry.NewPushPolicy(concurrency, attempts)
+			for i, tag := range tags {
 				finishPush, err := StartPush(ctx, buildID, tag, token)
 				if err != nil {
 					return err
 				}
-				err = Push(ctx, progressFmt, buildID, target, tag, token, dockerCli)
+				// The blobs of the tags pushed before can be mounted.
+				sources := append(slices.Clone(mountFrom), tags[:i]...)
+				err = Push(ctx, policy, nil, sources, progressFmt, buildID, target, tag, token, dockerCli)
 				err = finishPush(err)
 				if err != nil {
 					return err
@@ -98,6 +103,9 @@ func NewCmdPush() *cobra.Command {
 	cmd.Flags().StringVar(&progressFmt, "progress", "auto", `Set type of progress output ("auto", "plain", "tty", "quiet")`)
 	cmd.Flags().StringArrayVarP(&tags, "tag", "t", []string{}, `Name and tag for the pushed image (format: "name:tag")`)
 	cmd.Flags().StringVar(&target, "target", "", "bake target")
+	cmd.Flags().IntVar(&concurrency, "max-concurrent-uploads", registry.DefaultConcurrency, "Maximum concurrent uploads to each registry")
+	cmd.Flags().IntVar(&attempts, "max-attempts", registry.DefaultAttempts, "Maximum attempts of an upload that is rate limited or fails with a server error")
+	cmd.Flags().StringArrayVar(&mountFrom, "mount-from", nil, "Mount the blobs that this repository of the destination registry already has instead of uploading them")
 
 	return cmd
 }
@@ -125,7 +133,9 @@ func StartPush(ctx context.Context, buildID, tag, token string) (func(error) err
 	return finish, nil
 }
 
-func Push(ctx context.Context, progressFmt, buildID, target, tag, token string, dockerCli command.Cli) error {
+// Push pushes a saved build as tag.  The credentials take precedence over
+// those of the Docker config for their registries.
+func Push(ctx context.Context, policy *registry.PushPolicy, credentials []build.Credential, mountFrom []string, progressFmt, buildID, target, tag, token string, dockerCli command.Cli) error {
 	reporter, done, err := NewProgress(ctx, progressFmt)
 	if err != nil {
 		return err
@@ -152,7 +162,8 @@ func Push(ctx context.Context, progressFmt, buildID, target, tag, token string,
 
 	fin := logger("Fetching auth token")
 	manifest := buildDescriptors.Manifests[0]
-	registryToken, err := GetAuthToken(ctx, dockerCli, parsedTag, manifest)
+	sources := MountSources(parsedTag, mountFrom)
+	registryToken, err := GetAuthToken(ctx, dockerCli, credentials, parsedTag, manifest, sources)
 	fin()
 	if err != nil {
 		finishReporting(err)
@@ -165,6 +176,15 @@ func Push(ctx context.Context, progressFmt, buildID, target, tag, token string,
 		i := i
 		blobGroup.Go(func() error {
 			blob := blobs[i]
+			if source, ok := MountBlob(blobCtx, policy, registryToken, parsedTag, blob, sources); ok {
+				if source == "" {
+					logger(fmt.Sprintf("Blob %s exists", blob.Digest.String()))()
+				} else {
+					logger(fmt.Sprintf("Mounted blob %s from %s", blob.Digest.String(), source))()
+				}
+				return nil
+			}
+
 			fin := logger(fmt.Sprintf("Pushing blob %s", blob.Digest.String()))
 
 			blobToPush := &BlobToPush{
@@ -173,7 +193,16 @@ func Push(ctx context.Context, progressFmt, buildID, target, tag, token string,
 				BuildID:       buildID,
 				Digest:        blob.Digest,
 			}
-			err := PushBlob(blobCtx, token, blobToPush)
+			err := PushBlob(blobCtx, policy, token, blobToPush)
+			fin()
+			if err == nil || blobCtx.Err() != nil {
+				return err
+			}
+
+			// The blob service failed; upload the blob through the CLI,
+			// which resumes the chunks that fail.
+			fin = logger(fmt.Sprintf("Uploading blob %s (%v)", blob.Digest.String(), err))
+			err = UploadBlob(blobCtx, policy, buildDescriptors, blobToPush, blob)
 			fin()
 			return err
 		})
@@ -199,7 +228,7 @@ func Push(ctx context.Context, progressFmt, buildID, target, tag, token string,
 			tag = manifest.Digest.String()
 		}
 
-		err := PushManifest(ctx, registryToken, parsedTag.Refspec, tag, manifest, buf)
+		err := PushManifest(ctx, policy, registryToken, parsedTag.Refspec, tag, manifest, buf)
 		fin()
 		if err != nil {
 			finishReporting(err)
@@ -211,7 +240,7 @@ func Push(ctx context.Context, progressFmt, buildID, target, tag, token string,
 		fin = logger(fmt.Sprintf("Pushing index %s", index.Digest.String()))
 
 		buf := buildDescriptors.IndexBytes[index.Digest]
-		err := PushManifest(ctx, registryToken, parsedTag.Refspec, parsedTag.Tag, index, buf)
+		err := PushManifest(ctx, policy, registryToken, parsedTag.Refspec, parsedTag.Tag, index, buf)
 		fin()
 		if err != nil {
 			finishReporting(err)
@@ -21,7 +21,9 @@ import (
 	"time"
 
 	contentv1 "github.com/containerd/containerd/api/services/content/v1"
-	"github.com/containerd/containerd/defaults"
+	"github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/config"
+	"github.com/depot/cli/pkg/load"
 	"github.com/opencontainers/go-digest"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
@@ -43,85 +45,149 @@ func NewCmdRegistry() *cobra.Command {
 }
 
 func run() error {
-	caCert, err := base64.StdEncoding.DecodeString(os.Getenv("CA_CERT"))
+	ln, err := net.Listen("tcp", ":8888")
 	if err != nil {
 		return err
 	}
 
-	keyPEM, err := base64.StdEncoding.DecodeString(os.Getenv("KEY"))
+	var srv http.Server
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	shutdown := make(chan struct{})
+	go func() {
+		sigint := make(chan os.Signal, 1)
+		signal.Notify(sigint, os.Interrupt)
+		<-sigint
+
+		if err := srv.Shutdown(context.Background()); err != nil {
+			log.Printf("HTTP server shutdown: %v", err)
+		}
+		close(shutdown)
+		cancel()
+	}()
+
+	var registry *Registry
+	if os.Getenv("INDEX") != "" {
+		registry, err = newIndexRegistry(ctx)
+	} else {
+		registry, err = newImageRegistry(ctx)
+	}
 	if err != nil {
 		return err
 	}
 
+	srv.Handler = registry
+	srv.Addr = ":8888"
+
+	if err := srv.Serve(ln); err != http.ErrServerClosed {
+		return err
+	}
+
+	<-shutdown
+	return nil
+}
+
+// newImageRegistry serves the single image described by the environment.
+func newImageRegistry(ctx context.Context) (*Registry, error) {
+	caCert, err := base64.StdEncoding.DecodeString(os.Getenv("CA_CERT"))
+	if err != nil {
+		return nil, err
+	}
+
+	keyPEM, err := base64.StdEncoding.DecodeString(os.Getenv("KEY"))
+	if err != nil {
+		return nil, err
+	}
+
 	certPEM, err := base64.StdEncoding.DecodeString(os.Getenv("CERT"))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	addr, err := base64.StdEncoding.DecodeString(os.Getenv("ADDR"))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	serverName, err := base64.StdEncoding.DecodeString(os.Getenv("SERVER_NAME"))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	rawConfig, err := base64.StdEncoding.DecodeString(os.Getenv("CONFIG"))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	rawManifest, err := base64.StdEncoding.DecodeString(os.Getenv("MANIFEST"))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var manifest Manifest
 	err = json.Unmarshal(rawManifest, &manifest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	ln, err := net.Listen("tcp", ":8888")
+	contentClient, err := NewContentClient(ctx, caCert, certPEM, keyPEM, string(serverName), string(addr))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var srv http.Server
-
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	return NewRegistry(rawConfig, rawManifest, manifest, contentClient), nil
+}
 
-	shutdown := make(chan struct{})
-	go func() {
-		sigint := make(chan os.Signal, 1)
-		signal.Notify(sigint, os.Interrupt)
-		<-sigint
+// newIndexRegistry serves an image index whose platform images may be stored
+// on different builders.
+func newIndexRegistry(ctx context.Context) (*Registry, error) {
+	rawIndex, err := base64.StdEncoding.DecodeString(os.Getenv("INDEX"))
+	if err != nil {
+		return nil, err
+	}
 
-		if err := srv.Shutdown(context.Background()); err != nil {
-			log.Printf("HTTP server shutdown: %v", err)
-		}
-		close(shutdown)
-		cancel()
-	}()
+	var index Manifest
+	if err := json.Unmarshal(rawIndex, &index); err != nil {
+		return nil, err
+	}
 
-	contentClient, err := NewContentClient(ctx, caCert, certPEM, keyPEM, string(serverName), string(addr))
+	rawBackends, err := base64.StdEncoding.DecodeString(os.Getenv("BACKENDS"))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	registry := NewRegistry(rawConfig, rawManifest, manifest, contentClient)
-	srv.Handler = registry
-	srv.Addr = ":8888"
+	var backends []load.ProxyBackend
+	if err := json.Unmarshal(rawBackends, &backends); err != nil {
+		return nil, err
+	}
 
-	if err := srv.Serve(ln); err != http.ErrServerClosed {
-		return err
+	registry := &Registry{
+		RawIndex:    rawIndex,
+		IndexDigest: FromBytes(rawIndex),
+		IndexType:   index.MediaType,
+		Manifests:   map[Digest]Content{},
+		Configs:     map[Digest][]byte{},
+		Blobs:       map[Digest]Blob{},
 	}
 
-	<-shutdown
-	return nil
+	for _, backend := range backends {
+		contentClient, err := NewContentClient(ctx, backend.CACert, backend.Cert, backend.Key, backend.ServerName, backend.Addr)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, image := range backend.Images {
+			var manifest Manifest
+			if err := json.Unmarshal(image.Manifest, &manifest); err != nil {
+				return nil, err
+			}
+			registry.AddImage(image.Config, image.Manifest, manifest, contentClient)
+		}
+	}
+
+	return registry, nil
 }
 
 func NewContentClient(ctx context.Context, caCert, certPEM, keyPEM []byte, serverName, buildkitdAddress string) (contentv1.ContentClient, error) {
@@ -139,8 +205,8 @@ func NewContentClient(ctx context.Context, caCert, certPEM, keyPEM []byte, serve
 
 	opts := []grpc.DialOption{
 		grpc.WithBlock(),
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(defaults.DefaultMaxRecvMsgSize)),
-		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(defaults.DefaultMaxSendMsgSize)),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(config.GRPCMaxRecvMsgSize())),
+		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(config.GRPCMaxSendMsgSize())),
 		grpc.WithAuthority(serverName),
 		grpc.WithTransportCredentials(credentials.NewTLS(cfg)),
 		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
@@ -149,8 +215,9 @@ func NewContentClient(ctx context.Context, caCert, certPEM, keyPEM []byte, serve
 		}),
 		grpc.FailOnNonTempDialError(true),
 		grpc.WithReturnConnectionError(),
-		grpc.WithKeepaliveParams(keepalive.ClientParameters{Time: 10 * time.Second}),
+		grpc.WithKeepaliveParams(config.GRPCClientKeepalive(keepalive.ClientParameters{Time: 10 * time.Second})),
 	}
+	opts = append(opts, api.GRPCTraceOptions("registry")...)
 
 	conn, err := grpc.DialContext(ctx, buildkitdAddress, opts...)
 	if err != nil {
@@ -160,36 +227,58 @@ func NewContentClient(ctx context.Context, caCert, certPEM, keyPEM []byte, serve
 	return contentv1.NewContentClient(conn), nil
 }
 
-// Registry is a small docker registry serving a single image by forwarding requests to the BuildKit cache.
+// Registry is a small docker registry serving images by forwarding requests to the BuildKit cache.
 type Registry struct {
-	RawConfig    []byte
-	ConfigDigest Digest
+	// RawIndex is served for tags if set; otherwise DefaultManifest is served.
+	RawIndex    []byte
+	IndexDigest Digest
+	IndexType   string
+
+	DefaultManifest Digest
 
-	RawManifest    []byte
-	ManifestDigest Digest
-	Manifest       Manifest
+	Manifests map[Digest]Content
+	Configs   map[Digest][]byte
+	Blobs     map[Digest]Blob
+}
 
+// Content is a manifest served directly from memory.
+type Content struct {
+	MediaType string
+	Data      []byte
+}
+
+// Blob is a layer read from the content store of the builder that produced it.
+type Blob struct {
+	Size          int64
 	ContentClient contentv1.ContentClient
 }
 
 func NewRegistry(rawConfig, rawManifest []byte, manifest Manifest, contentClient contentv1.ContentClient) *Registry {
-	return &Registry{
-		RawConfig:      rawConfig,
-		ConfigDigest:   FromBytes(rawConfig),
-		RawManifest:    rawManifest,
-		ManifestDigest: FromBytes(rawManifest),
-		Manifest:       manifest,
-		ContentClient:  contentClient,
-	}
+	registry := &Registry{
+		DefaultManifest: FromBytes(rawManifest),
+		Manifests:       map[Digest]Content{},
+		Configs:         map[Digest][]byte{},
+		Blobs:           map[Digest]Blob{},
+	}
+	registry.AddImage(rawConfig, rawManifest, manifest, contentClient)
+	return registry
 }
 
-func (r *Registry) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
-	if isConfig(req, r.ConfigDigest) {
-		r.handleConfig(resp, req)
-		return
+// AddImage makes the image manifest, config, and layers available by digest.
+func (r *Registry) AddImage(rawConfig, rawManifest []byte, manifest Manifest, contentClient contentv1.ContentClient) {
+	r.Manifests[FromBytes(rawManifest)] = Content{MediaType: manifest.MediaType, Data: rawManifest}
+	r.Configs[FromBytes(rawConfig)] = rawConfig
+	for _, layer := range manifest.Layers {
+		r.Blobs[layer.Digest] = Blob{Size: layer.Size, ContentClient: contentClient}
 	}
+}
 
+func (r *Registry) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 	if isBlob(req) {
+		if config, ok := r.Configs[lastElem(req)]; ok {
+			r.handleConfig(resp, req, config)
+			return
+		}
 		r.handleBlobs(resp, req)
 		return
 	}
@@ -216,28 +305,51 @@ func isManifest(req *http.Request) bool {
 	return elems[len(elems)-2] == "manifests"
 }
 
+// lastElem returns the reference or digest at the end of the request path.
+func lastElem(req *http.Request) Digest {
+	elems := strings.Split(strings.TrimSuffix(req.URL.Path, "/"), "/")
+	return Digest(elems[len(elems)-1])
+}
+
 func (r *Registry) handleManifests(resp http.ResponseWriter, req *http.Request) {
-	resp.Header().Set("Content-Length", strconv.FormatInt(int64(len(r.RawManifest)), 10))
-	resp.Header().Set("Docker-Content-Digest", r.ManifestDigest.String())
-	resp.Header().Set("Content-Type", r.Manifest.MediaType)
+	ref := lastElem(req)
+
+	var (
+		content Content
+		dgst    Digest
+	)
+	switch {
+	case r.RawIndex != nil && (ref == r.IndexDigest || !strings.HasPrefix(ref.String(), "sha256:")):
+		content, dgst = Content{MediaType: r.IndexType, Data: r.RawIndex}, r.IndexDigest
+	case !strings.HasPrefix(ref.String(), "sha256:"):
+		content, dgst = r.Manifests[r.DefaultManifest], r.DefaultManifest
+	default:
+		var ok bool
+		content, ok = r.Manifests[ref]
+		if !ok {
+			writeError(resp, http.StatusNotFound, "MANIFEST_UNKNOWN", "manifest not found")
+			return
+		}
+		dgst = ref
+	}
+
+	resp.Header().Set("Content-Length", strconv.FormatInt(int64(len(content.Data)), 10))
+	resp.Header().Set("Docker-Content-Digest", dgst.String())
+	resp.Header().Set("Content-Type", content.MediaType)
 
-	log.Printf("Manifest")
+	log.Printf("Manifest %s", dgst)
 	if req.Method == http.MethodGet {
-		_, _ = io.Copy(resp, bytes.NewReader(r.RawManifest))
+		_, _ = io.Copy(resp, bytes.NewReader(content.Data))
 	}
 }
 
-func isConfig(req *http.Request, config Digest) bool {
-	return strings.HasSuffix(req.URL.Path, config.String())
-}
-
-func (r *Registry) handleConfig(resp http.ResponseWriter, req *http.Request) {
-	resp.Header().Set("Content-Length", strconv.FormatInt(int64(len(r.RawConfig)), 10))
-	resp.Header().Set("Docker-Content-Digest", r.ConfigDigest.String())
+func (r *Registry) handleConfig(resp http.ResponseWriter, req *http.Request, config []byte) {
+	resp.Header().Set("Content-Length", strconv.FormatInt(int64(len(config)), 10))
+	resp.Header().Set("Docker-Content-Digest", FromBytes(config).String())
 
 	log.Printf("Config")
 	if req.Method == http.MethodGet {
-		_, _ = io.Copy(resp, bytes.NewReader(r.RawConfig))
+		_, _ = io.Copy(resp, bytes.NewReader(config))
 	}
 }
 
@@ -267,20 +379,14 @@ func (r *Registry) handleBlobs(resp http.ResponseWriter, req *http.Request) {
 	}
 	blobSHA := elem[len(elem)-1]
 
-	var found bool
-	for _, layer := range r.Manifest.Layers {
-		if layer.Digest.String() == blobSHA {
-			resp.Header().Set("Content-Length", strconv.FormatInt(layer.Size, 10))
-			resp.Header().Set("Docker-Content-Digest", layer.Digest.String())
-			found = true
-		}
-	}
-
+	blob, found := r.Blobs[Digest(blobSHA)]
 	if !found {
 		log.Printf("Unknown blob: %s", blobSHA)
 		writeError(resp, http.StatusNotFound, "BLOB_UNKNOWN", "blob not found")
 		return
 	}
+	resp.Header().Set("Content-Length", strconv.FormatInt(blob.Size, 10))
+	resp.Header().Set("Docker-Content-Digest", blobSHA)
 
 	log.Printf("%s Blob: %s", req.Method, blobSHA)
 
@@ -291,7 +397,7 @@ func (r *Registry) handleBlobs(resp http.ResponseWriter, req *http.Request) {
 	childCtx, cancel := context.WithCancel(req.Context())
 	defer cancel()
 
-	rc, err := r.ContentClient.Read(childCtx, &contentv1.ReadContentRequest{Digest: digest.Digest(blobSHA)})
+	rc, err := blob.ContentClient.Read(childCtx, &contentv1.ReadContentRequest{Digest: digest.Digest(blobSHA)})
 	if err != nil {
 		writeError(resp, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "unable to get blob")
 		return
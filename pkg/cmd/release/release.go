@@ -0,0 +1,10 @@
+package release
+
+import (
+	"github.com/depot/cli/pkg/buildx/commands"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdRelease() *cobra.Command {
+	return commands.ReleaseCmd()
+}
@@ -1,17 +1,31 @@
 package root
 
 import (
+	"fmt"
+	"net/http"
 	"os"
 
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"github.com/depot/cli/pkg/cmd/attest"
 	bakeCmd "github.com/depot/cli/pkg/cmd/bake"
 	buildCmd "github.com/depot/cli/pkg/cmd/build"
+	"github.com/depot/cli/pkg/cmd/buildkit"
+	"github.com/depot/cli/pkg/cmd/builds"
 	cacheCmd "github.com/depot/cli/pkg/cmd/cache"
+	"github.com/depot/cli/pkg/cmd/completion"
+	configCmd "github.com/depot/cli/pkg/cmd/config"
+	contextdCmd "github.com/depot/cli/pkg/cmd/contextd"
+	diffCmd "github.com/depot/cli/pkg/cmd/diff"
 	dockerCmd "github.com/depot/cli/pkg/cmd/docker"
+	"github.com/depot/cli/pkg/cmd/docs"
 	"github.com/depot/cli/pkg/cmd/exec"
+	"github.com/depot/cli/pkg/cmd/hashcontext"
 	initCmd "github.com/depot/cli/pkg/cmd/init"
+	"github.com/depot/cli/pkg/cmd/lint"
 	"github.com/depot/cli/pkg/cmd/list"
+	"github.com/depot/cli/pkg/cmd/lock"
 	loginCmd "github.com/depot/cli/pkg/cmd/login"
 	logout "github.com/depot/cli/pkg/cmd/logout"
 	"github.com/depot/cli/pkg/cmd/projects"
@@ -19,12 +33,22 @@ import (
 	"github.com/depot/cli/pkg/cmd/pulltoken"
 	"github.com/depot/cli/pkg/cmd/push"
 	"github.com/depot/cli/pkg/cmd/registry"
+	"github.com/depot/cli/pkg/cmd/release"
+	"github.com/depot/cli/pkg/cmd/supportbundle"
+	tokenCmd "github.com/depot/cli/pkg/cmd/token"
+	"github.com/depot/cli/pkg/cmd/usage"
 	versionCmd "github.com/depot/cli/pkg/cmd/version"
 	"github.com/depot/cli/pkg/config"
+	"github.com/depot/cli/pkg/debuglog"
+	"github.com/depot/cli/pkg/helpers"
 )
 
 func NewCmdRoot(version, buildDate string) *cobra.Command {
-	var dockerConfig string
+	var (
+		dockerConfig string
+		verbose      int
+		logFile      string
+	)
 
 	var cmd = &cobra.Command{
 		Use:          "depot <command> [flags]",
@@ -35,10 +59,30 @@ func NewCmdRoot(version, buildDate string) *cobra.Command {
 			_ = cmd.Usage()
 		},
 
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			if dockerConfig != "" {
 				os.Setenv("DOCKER_CONFIG", dockerConfig)
 			}
+
+			if logFile != "" {
+				f, err := os.OpenFile(logFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+				if err != nil {
+					return fmt.Errorf("unable to open log file: %w", err)
+				}
+				debuglog.SetOutput(f)
+				// A log file is only useful with diagnostics in it.
+				verbose = max(verbose, debuglog.LevelDebug)
+			}
+			debuglog.SetLevel(verbose)
+			if cmd.Name() == "build" || cmd.Name() == "bake" {
+				captureBuildLog(cmd)
+			}
+			if debuglog.Enabled(debuglog.LevelTrace) {
+				logrus.SetLevel(logrus.DebugLevel)
+			}
+			http.DefaultClient.Transport = &debuglog.Transport{Base: http.DefaultClient.Transport}
+
+			return nil
 		},
 	}
 
@@ -52,23 +96,58 @@ func NewCmdRoot(version, buildDate string) *cobra.Command {
 
 	cmd.PersistentFlags().StringVar(&dockerConfig, "config", "", "Override the location of Docker client config files")
 	_ = cmd.PersistentFlags().MarkHidden("config")
+	cmd.PersistentFlags().CountVarP(&verbose, "verbose", "v", "Increase log verbosity (-v for debug logs, -vv to also trace gRPC and HTTP requests)")
+	cmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Write diagnostic logs to the file instead of stderr")
 
 	// Child commands
+	cmd.AddCommand(attest.NewCmdAttest())
 	cmd.AddCommand(bakeCmd.NewCmdBake())
 	cmd.AddCommand(buildCmd.NewCmdBuild())
+	cmd.AddCommand(builds.NewCmdBuilds())
+	cmd.AddCommand(buildkit.NewCmdBuildkit())
 	cmd.AddCommand(cacheCmd.NewCmdCache())
+	cmd.AddCommand(contextdCmd.NewCmdContextd())
+	cmd.AddCommand(diffCmd.NewCmdDiff())
+	cmd.AddCommand(hashcontext.NewCmdHashContext())
 	cmd.AddCommand(initCmd.NewCmdInit())
+	cmd.AddCommand(lint.NewCmdLint())
 	cmd.AddCommand(list.NewCmdList())
+	cmd.AddCommand(lock.NewCmdLock())
 	cmd.AddCommand(loginCmd.NewCmdLogin())
 	cmd.AddCommand(logout.NewCmdLogout())
 	cmd.AddCommand(pull.NewCmdPull())
 	cmd.AddCommand(pulltoken.NewCmdPullToken())
 	cmd.AddCommand(push.NewCmdPush())
+	cmd.AddCommand(release.NewCmdRelease())
 	cmd.AddCommand(versionCmd.NewCmdVersion(version, buildDate))
 	cmd.AddCommand(dockerCmd.NewCmdConfigureDocker())
 	cmd.AddCommand(registry.NewCmdRegistry())
 	cmd.AddCommand(projects.NewCmdProjects())
 	cmd.AddCommand(exec.NewCmdExec())
+	cmd.AddCommand(usage.NewCmdUsage())
+	cmd.AddCommand(tokenCmd.NewCmdToken())
+	cmd.AddCommand(configCmd.NewCmdConfig())
+	cmd.AddCommand(supportbundle.NewCmdSupportBundle(version, buildDate))
+	cmd.AddCommand(completion.NewCmdCompletion())
+	cmd.AddCommand(docs.NewCmdDocs())
+
+	helpers.RegisterCompletions(cmd)
 
 	return cmd
 }
+
+// captureBuildLog keeps the debug logs of the current build so that they can
+// be attached to a support bundle.  Failures are ignored as the logs are
+// only a diagnostic aid.
+func captureBuildLog(cmd *cobra.Command) {
+	path, err := config.LastBuildLogFile()
+	if err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return
+	}
+	debuglog.Capture(f)
+	debuglog.Log("starting %s", cmd.CommandPath())
+}
@@ -0,0 +1,67 @@
+package supportbundle
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+var sensitiveKeys = []string{"token", "secret", "password", "passwd", "auth", "key", "credential"}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactValue hides values of sensitive keys and credentials in URLs such
+// as proxy settings.
+func redactValue(key, value string) string {
+	if value == "" {
+		return value
+	}
+	if isSensitive(key) {
+		return redacted
+	}
+	if u, err := url.Parse(value); err == nil && u.User != nil {
+		u.User = url.User("redacted")
+		return u.String()
+	}
+	return value
+}
+
+func redactMap(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			out[k] = redactMap(v)
+		case string:
+			out[k] = redactValue(k, v)
+		default:
+			if isSensitive(k) {
+				out[k] = redacted
+			} else {
+				out[k] = v
+			}
+		}
+	}
+	return out
+}
+
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(bearer|basic)\s+[A-Za-z0-9._~+/=-]+`),
+	regexp.MustCompile(`(?i)((?:token|secret|password)["']?\s*[:=]\s*["']?)[^\s"',]+`),
+}
+
+// redactText hides anything that looks like a credential in free-form logs.
+func redactText(s string) string {
+	s = secretPatterns[0].ReplaceAllString(s, "$1 "+redacted)
+	return secretPatterns[1].ReplaceAllString(s, "${1}"+redacted)
+}
@@ -0,0 +1,222 @@
+// Gathers client-side diagnostics into an archive for support requests.
+package supportbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/depot/cli/pkg/ci"
+	versionCmd "github.com/depot/cli/pkg/cmd/version"
+	"github.com/depot/cli/pkg/config"
+	"github.com/depot/cli/pkg/helpers"
+	"github.com/depot/cli/pkg/project"
+	dockerconfig "github.com/docker/cli/cli/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewCmdSupportBundle(version, buildDate string) *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "support-bundle",
+		Short: "Collect diagnostics about the last build into an archive for Depot support",
+		Long: `Collect diagnostics about the last build into an archive for Depot support.
+
+The archive contains the client-side debug logs of the last build, the detected
+environment, proxy settings, the Depot and Docker configuration, and the CLI
+version.  Tokens, passwords and other secrets are redacted.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output == "" {
+				output = fmt.Sprintf("depot-support-%s.tar.gz", time.Now().UTC().Format("20060102T150405Z"))
+			}
+
+			files := map[string][]byte{
+				"version.txt": []byte(versionCmd.Format(version, buildDate)),
+			}
+
+			env, err := json.MarshalIndent(environment(), "", "  ")
+			if err != nil {
+				return err
+			}
+			files["environment.json"] = env
+
+			settings, err := json.MarshalIndent(redactMap(viper.AllSettings()), "", "  ")
+			if err != nil {
+				return err
+			}
+			files["depot-config.json"] = settings
+
+			if docker, err := dockerConfig(); err == nil {
+				files["docker-config.json"] = docker
+			} else {
+				files["docker-config.error.txt"] = []byte(err.Error())
+			}
+
+			cwd, _ := os.Getwd()
+			if projectConfig, path, err := project.ReadConfig(cwd); err == nil {
+				files["depot.json"], _ = json.MarshalIndent(map[string]string{"id": projectConfig.ID, "path": path}, "", "  ")
+			}
+
+			if path, err := config.LastBuildLogFile(); err == nil {
+				if log, err := os.ReadFile(path); err == nil {
+					files["last-build-debug.log"] = []byte(redactText(string(log)))
+				}
+			}
+
+			if err := writeArchive(output, files); err != nil {
+				return err
+			}
+
+			fmt.Printf("Support bundle written to %s\n", output)
+			fmt.Println("Please review its contents before attaching it to a support request.")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Path of the archive (default: depot-support-<timestamp>.tar.gz)")
+
+	return cmd
+}
+
+type Environment struct {
+	OS         string            `json:"os"`
+	Arch       string            `json:"arch"`
+	GoVersion  string            `json:"go_version"`
+	CI         string            `json:"ci,omitempty"`
+	IsTerminal bool              `json:"is_terminal"`
+	Variables  map[string]string `json:"variables"`
+}
+
+// relevantEnv are variables outside of DEPOT_ that change how builds run.
+var relevantEnv = []string{
+	"BUILDKIT_HOST",
+	"BUILDX_BUILDER",
+	"BUILDX_NO_DEFAULT_LOAD",
+	"DOCKER_BUILDKIT",
+	"DOCKER_CONFIG",
+	"DOCKER_CONTEXT",
+	"DOCKER_DEFAULT_PLATFORM",
+	"DOCKER_HOST",
+	"HTTP_PROXY",
+	"HTTPS_PROXY",
+	"NO_PROXY",
+	"http_proxy",
+	"https_proxy",
+	"no_proxy",
+}
+
+func environment() Environment {
+	provider, _ := ci.Provider()
+	env := Environment{
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		GoVersion:  runtime.Version(),
+		CI:         provider,
+		IsTerminal: helpers.IsTerminal(),
+		Variables:  map[string]string{},
+	}
+
+	for _, kv := range os.Environ() {
+		key, value, _ := strings.Cut(kv, "=")
+		if strings.HasPrefix(key, "DEPOT_") || contains(relevantEnv, key) {
+			env.Variables[key] = redactValue(key, value)
+		}
+	}
+
+	return env
+}
+
+// dockerConfig returns the docker config.json with only the names of
+// the registries that have credentials.
+func dockerConfig() ([]byte, error) {
+	cfg, err := dockerconfig.Load(dockerconfig.Dir())
+	if err != nil {
+		return nil, err
+	}
+
+	registries := make([]string, 0, len(cfg.AuthConfigs))
+	for host := range cfg.AuthConfigs {
+		registries = append(registries, host)
+	}
+	sort.Strings(registries)
+
+	proxies := map[string]map[string]string{}
+	for host, p := range cfg.Proxies {
+		proxies[host] = map[string]string{
+			"httpProxy":  redactValue("httpProxy", p.HTTPProxy),
+			"httpsProxy": redactValue("httpsProxy", p.HTTPSProxy),
+			"noProxy":    p.NoProxy,
+			"ftpProxy":   redactValue("ftpProxy", p.FTPProxy),
+		}
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"path":           cfg.Filename,
+		"authRegistries": registries,
+		"credsStore":     cfg.CredentialsStore,
+		"credHelpers":    cfg.CredentialHelpers,
+		"currentContext": cfg.CurrentContext,
+		"proxies":        proxies,
+	}, "", "  ")
+}
+
+func writeArchive(path string, files map[string][]byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	dir := strings.TrimSuffix(filepath.Base(path), ".tar.gz")
+	now := time.Now()
+	for _, name := range names {
+		header := &tar.Header{
+			Name:    dir + "/" + name,
+			Mode:    0600,
+			Size:    int64(len(files[name])),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
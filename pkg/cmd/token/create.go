@@ -0,0 +1,81 @@
+package token
+
+import (
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/depot/cli/pkg/api"
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+	"github.com/docker/cli/cli"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func NewCmdCreate() *cobra.Command {
+	var (
+		token       string
+		projectID   string
+		description string
+		role        string
+		ttl         string
+		output      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create [flags]",
+		Short: "Create a project token",
+		Long: `Create a project token, for example for CI.
+
+The token is printed once and cannot be retrieved again.  Use --output json
+to print its ID and expiry as well, e.g. to revoke it when rotating tokens.`,
+		Example: `  depot token create --project abc123 --description "GitHub Actions" --ttl 30d`,
+		Args:    cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "" && output != "json" {
+				return fmt.Errorf("unknown output format %q (expected json)", output)
+			}
+			tokenRole, err := parseRole(role)
+			if err != nil {
+				return err
+			}
+			lifetime, err := parseTTL(ttl)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			token, projectID, err := resolve(ctx, token, projectID, true)
+			if err != nil {
+				return err
+			}
+
+			req := &cliv1.CreateTokenRequest{ProjectId: projectID, Description: description, Role: tokenRole}
+			if lifetime > 0 {
+				req.ExpiresAt = timestamppb.New(time.Now().Add(lifetime))
+			}
+			res, err := api.NewTokenClient().CreateToken(ctx, api.WithAuthentication(connect.NewRequest(req), token))
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				created := newToken(res.Msg.Token)
+				created.Secret = res.Msg.Secret
+				return writeJSON(created)
+			}
+			fmt.Println(res.Msg.Secret)
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&projectID, "project", "", "Depot project ID")
+	flags.StringVar(&token, "token", "", "Depot token")
+	flags.StringVar(&description, "description", "", "Description of the token, e.g. where it is used")
+	flags.StringVar(&role, "role", "build", `Role of the token ("build" or "pull")`)
+	flags.StringVar(&ttl, "ttl", "", `Lifetime of the token (e.g., "30d", "12h"); never expires by default`)
+	flags.StringVar(&output, "output", "", `Output format ("json"); prints only the token by default`)
+
+	return cmd
+}
@@ -0,0 +1,78 @@
+package token
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/depot/cli/pkg/api"
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+	"github.com/docker/cli/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdList() *cobra.Command {
+	var (
+		token     string
+		projectID string
+		output    string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "list [flags]",
+		Aliases: []string{"ls"},
+		Short:   "List the tokens of a project",
+		Args:    cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "" && output != "json" {
+				return fmt.Errorf("unknown output format %q (expected json)", output)
+			}
+
+			ctx := cmd.Context()
+			token, projectID, err := resolve(ctx, token, projectID, true)
+			if err != nil {
+				return err
+			}
+
+			req := &cliv1.ListTokensRequest{ProjectId: projectID}
+			res, err := api.NewTokenClient().ListTokens(ctx, api.WithAuthentication(connect.NewRequest(req), token))
+			if err != nil {
+				return err
+			}
+
+			tokens := make([]Token, 0, len(res.Msg.Tokens))
+			for _, t := range res.Msg.Tokens {
+				tokens = append(tokens, newToken(t))
+			}
+			if output == "json" {
+				return writeJSON(tokens)
+			}
+			return writeTable(tokens)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&projectID, "project", "", "Depot project ID")
+	flags.StringVar(&token, "token", "", "Depot token")
+	flags.StringVar(&output, "output", "", `Output format ("json")`)
+
+	return cmd
+}
+
+func writeTable(tokens []Token) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tDESCRIPTION\tROLE\tCREATED\tEXPIRES\tLAST USED")
+	for _, t := range tokens {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", t.ID, t.Description, t.Role, formatTime(t.CreatedAt, "-"), formatTime(t.ExpiresAt, "never"), formatTime(t.LastUsedAt, "never"))
+	}
+	return w.Flush()
+}
+
+func formatTime(t *time.Time, zero string) string {
+	if t == nil {
+		return zero
+	}
+	return t.Local().Format(time.DateTime)
+}
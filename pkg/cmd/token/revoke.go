@@ -0,0 +1,42 @@
+package token
+
+import (
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/depot/cli/pkg/api"
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+	"github.com/docker/cli/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdRevoke() *cobra.Command {
+	var token string
+
+	cmd := &cobra.Command{
+		Use:   "revoke [flags] <token-id>...",
+		Short: "Revoke project tokens",
+		Args:  cli.RequiresMinArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			token, _, err := resolve(ctx, token, "", false)
+			if err != nil {
+				return err
+			}
+
+			client := api.NewTokenClient()
+			for _, id := range args {
+				req := &cliv1.RevokeTokenRequest{TokenId: id}
+				if _, err := client.RevokeToken(ctx, api.WithAuthentication(connect.NewRequest(req), token)); err != nil {
+					return fmt.Errorf("unable to revoke token %s: %w", id, err)
+				}
+				fmt.Printf("Revoked token %s\n", id)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&token, "token", "", "Depot token")
+
+	return cmd
+}
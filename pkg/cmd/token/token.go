@@ -0,0 +1,123 @@
+// Manages the project tokens used by CI.
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/depot/cli/pkg/helpers"
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdToken() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Create, list, and revoke project tokens",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fmt.Errorf("missing subcommand, please run `depot token --help`")
+		},
+	}
+
+	cmd.AddCommand(NewCmdCreate())
+	cmd.AddCommand(NewCmdList())
+	cmd.AddCommand(NewCmdRevoke())
+
+	return cmd
+}
+
+// resolve returns the API token and, if required, the project of the command.
+func resolve(ctx context.Context, token, projectID string, requireProject bool) (string, string, error) {
+	token, err := helpers.ResolveToken(ctx, token)
+	if err != nil {
+		return "", "", err
+	}
+	if token == "" {
+		return "", "", fmt.Errorf("missing API token, please run `depot login`")
+	}
+	projectID = helpers.ResolveProjectID(projectID)
+	if requireProject && projectID == "" {
+		return "", "", fmt.Errorf("missing project, please pass --project or run `depot init`")
+	}
+	return token, projectID, nil
+}
+
+var roles = map[string]cliv1.TokenRole{
+	"build": cliv1.TokenRole_TOKEN_ROLE_BUILD,
+	"pull":  cliv1.TokenRole_TOKEN_ROLE_PULL,
+}
+
+func parseRole(role string) (cliv1.TokenRole, error) {
+	r, ok := roles[role]
+	if !ok {
+		return 0, errors.Errorf("unknown role %q (expected build or pull)", role)
+	}
+	return r, nil
+}
+
+func formatRole(role cliv1.TokenRole) string {
+	for name, r := range roles {
+		if r == role {
+			return name
+		}
+	}
+	return "unknown"
+}
+
+// parseTTL parses a token lifetime, which may be given in days, e.g. "30d".
+// An empty TTL never expires.
+func parseTTL(ttl string) (time.Duration, error) {
+	if ttl == "" {
+		return 0, nil
+	}
+	d, err := helpers.ParseDuration(ttl)
+	if err != nil || d <= 0 {
+		return 0, errors.Errorf("invalid --ttl %q (e.g., \"30d\", \"12h\")", ttl)
+	}
+	return d, nil
+}
+
+// Token is the JSON output of a token.  Secret is only set when the token is
+// created.
+type Token struct {
+	ID          string     `json:"id"`
+	ProjectID   string     `json:"project_id"`
+	Description string     `json:"description,omitempty"`
+	Role        string     `json:"role"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	Secret      string     `json:"token,omitempty"`
+}
+
+func newToken(t *cliv1.Token) Token {
+	token := Token{
+		ID:          t.GetTokenId(),
+		ProjectID:   t.GetProjectId(),
+		Description: t.GetDescription(),
+		Role:        formatRole(t.GetRole()),
+	}
+	if t.CreatedAt != nil {
+		createdAt := t.CreatedAt.AsTime()
+		token.CreatedAt = &createdAt
+	}
+	if t.ExpiresAt != nil {
+		expiresAt := t.ExpiresAt.AsTime()
+		token.ExpiresAt = &expiresAt
+	}
+	if t.LastUsedAt != nil {
+		lastUsedAt := t.LastUsedAt.AsTime()
+		token.LastUsedAt = &lastUsedAt
+	}
+	return token
+}
+
+func writeJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
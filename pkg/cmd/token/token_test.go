@@ -0,0 +1,38 @@
+package token
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTTL(t *testing.T) {
+	tests := []struct {
+		name    string
+		ttl     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "never expires", ttl: "", want: 0},
+		{name: "days", ttl: "30d", want: 30 * 24 * time.Hour},
+		{name: "hours", ttl: "12h", want: 12 * time.Hour},
+		{name: "minutes", ttl: "90m", want: 90 * time.Minute},
+		{name: "zero days", ttl: "0d", wantErr: true},
+		{name: "zero duration", ttl: "0s", wantErr: true},
+		{name: "negative", ttl: "-1d", wantErr: true},
+		{name: "fractional days", ttl: "1.5d", wantErr: true},
+		{name: "no number", ttl: "d", wantErr: true},
+		{name: "no unit", ttl: "30", wantErr: true},
+		{name: "unknown unit", ttl: "2w", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTTL(tt.ttl)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTTL(%q) error = %v, wantErr %v", tt.ttl, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseTTL(%q) = %s, want %s", tt.ttl, got, tt.want)
+			}
+		})
+	}
+}
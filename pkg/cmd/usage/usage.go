@@ -0,0 +1,206 @@
+// Reports build minutes and cache storage for an organization.
+package usage
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/helpers"
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const dateFormat = "2006-01-02"
+
+func NewCmdUsage() *cobra.Command {
+	var (
+		token        string
+		projectID    string
+		from         string
+		to           string
+		outputFormat string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Show build minutes and cache storage per project",
+		Example: `  # Usage for the current month
+  depot usage
+
+  # Usage for June 2024 as CSV
+  depot usage --from 2024-06-01 --to 2024-06-30 --output csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			start, end, err := parseRange(from, to, time.Now())
+			if err != nil {
+				return err
+			}
+
+			token, err := helpers.ResolveToken(context.Background(), token)
+			if err != nil {
+				return err
+			}
+
+			if token == "" {
+				return fmt.Errorf("missing API token, please run `depot login`")
+			}
+
+			req := cliv1.GetUsageRequest{
+				StartTime: timestamppb.New(start),
+				EndTime:   timestamppb.New(end),
+			}
+			if projectID != "" {
+				req.ProjectId = &projectID
+			}
+
+			client := api.NewUsageClient()
+			res, err := client.GetUsage(context.Background(), api.WithAuthentication(connect.NewRequest(&req), token))
+			if err != nil {
+				return err
+			}
+
+			usage := newUsage(res.Msg.Projects)
+			switch outputFormat {
+			case "":
+				return usage.WriteTable(start, end)
+			case "csv":
+				return usage.WriteCSV(os.Stdout)
+			case "json":
+				return usage.WriteJSON()
+			}
+
+			return errors.Errorf("unknown format: %s. Requires csv or json", outputFormat)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&token, "token", "", "Depot token")
+	flags.StringVar(&projectID, "project", "", "Only report usage for this Depot project ID")
+	flags.StringVar(&from, "from", "", "First day of the report (YYYY-MM-DD, default: first day of the current month)")
+	flags.StringVar(&to, "to", "", "Last day of the report, inclusive (YYYY-MM-DD, default: today)")
+	flags.StringVar(&outputFormat, "output", "", "Non-interactive output format (json, csv)")
+
+	return cmd
+}
+
+// parseRange returns the half-open UTC interval [start, end) covering the
+// days from and to inclusive.
+func parseRange(from, to string, now time.Time) (time.Time, time.Time, error) {
+	now = now.UTC()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var err error
+	if from != "" {
+		start, err = time.Parse(dateFormat, from)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.Errorf("invalid --from date %q, expected YYYY-MM-DD", from)
+		}
+	}
+	if to != "" {
+		end, err = time.Parse(dateFormat, to)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.Errorf("invalid --to date %q, expected YYYY-MM-DD", to)
+		}
+	}
+
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, errors.Errorf("--to %s is before --from %s", end.Format(dateFormat), start.Format(dateFormat))
+	}
+
+	return start, end.AddDate(0, 0, 1), nil
+}
+
+type ProjectUsage struct {
+	ProjectID         string  `json:"project_id"`
+	ProjectName       string  `json:"project_name"`
+	OrgID             string  `json:"org_id"`
+	OrgName           string  `json:"org_name"`
+	Builds            int64   `json:"builds"`
+	BuildMinutes      float64 `json:"build_minutes"`
+	CacheStorageBytes int64   `json:"cache_storage_bytes"`
+}
+
+type Usage []ProjectUsage
+
+func newUsage(projects []*cliv1.ProjectUsage) Usage {
+	usage := make(Usage, 0, len(projects))
+	for _, p := range projects {
+		usage = append(usage, ProjectUsage{
+			ProjectID:         p.ProjectId,
+			ProjectName:       p.ProjectName,
+			OrgID:             p.OrgId,
+			OrgName:           p.OrgName,
+			Builds:            p.BuildCount,
+			BuildMinutes:      p.BuildMinutes,
+			CacheStorageBytes: p.CacheStorageBytes,
+		})
+	}
+	return usage
+}
+
+func (usage Usage) WriteTable(start, end time.Time) error {
+	fmt.Printf("Usage from %s to %s\n\n", start.Format(dateFormat), end.AddDate(0, 0, -1).Format(dateFormat))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROJECT ID\tNAME\tORGANIZATION\tBUILDS\tBUILD MINUTES\tCACHE STORAGE")
+
+	var (
+		builds  int64
+		minutes float64
+		storage int64
+	)
+	for _, p := range usage {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%.1f\t%s\n", p.ProjectID, p.ProjectName, p.OrgName, p.Builds, p.BuildMinutes, units.BytesSize(float64(p.CacheStorageBytes)))
+		builds += p.Builds
+		minutes += p.BuildMinutes
+		storage += p.CacheStorageBytes
+	}
+	fmt.Fprintf(w, "TOTAL\t\t\t%d\t%.1f\t%s\n", builds, minutes, units.BytesSize(float64(storage)))
+
+	return w.Flush()
+}
+
+// WriteCSV writes a row per project after the header, which is written even
+// without projects.
+func (usage Usage) WriteCSV(out io.Writer) error {
+	w := csv.NewWriter(out)
+	if err := w.Write([]string{"Project ID", "Project Name", "Organization ID", "Organization Name", "Builds", "Build Minutes", "Cache Storage (bytes)"}); err != nil {
+		return err
+	}
+
+	for _, p := range usage {
+		row := []string{
+			p.ProjectID,
+			p.ProjectName,
+			p.OrgID,
+			p.OrgName,
+			fmt.Sprintf("%d", p.Builds),
+			fmt.Sprintf("%.2f", p.BuildMinutes),
+			fmt.Sprintf("%d", p.CacheStorageBytes),
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func (usage Usage) WriteJSON() error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(usage)
+}
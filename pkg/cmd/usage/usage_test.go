@@ -0,0 +1,71 @@
+package usage
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRange(t *testing.T) {
+	// June 16 in UTC.
+	now := time.Date(2024, 6, 15, 18, 30, 0, 0, time.FixedZone("PDT", -7*60*60))
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name      string
+		from, to  string
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   bool
+	}{
+		{name: "current month by default", wantStart: day(2024, 6, 1), wantEnd: day(2024, 6, 17)},
+		{name: "from until today in UTC", from: "2024-05-20", wantStart: day(2024, 5, 20), wantEnd: day(2024, 6, 17)},
+		{name: "month start until to", to: "2024-06-10", wantStart: day(2024, 6, 1), wantEnd: day(2024, 6, 11)},
+		{name: "absolute range", from: "2024-01-01", to: "2024-01-31", wantStart: day(2024, 1, 1), wantEnd: day(2024, 2, 1)},
+		{name: "single day", from: "2024-02-29", to: "2024-02-29", wantStart: day(2024, 2, 29), wantEnd: day(2024, 3, 1)},
+		{name: "invalid from", from: "06/01/2024", wantErr: true},
+		{name: "invalid to", to: "2024-13-01", wantErr: true},
+		{name: "to before from", from: "2024-06-10", to: "2024-06-01", wantErr: true},
+		{name: "from after today", from: "2024-07-01", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := parseRange(tt.from, tt.to, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Errorf("parseRange() = %v, %v, want %v, %v", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	const header = "Project ID,Project Name,Organization ID,Organization Name,Builds,Build Minutes,Cache Storage (bytes)\n"
+
+	var buf bytes.Buffer
+	if err := (Usage{}).WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != header {
+		t.Errorf("WriteCSV() without projects = %q, want the header", buf.String())
+	}
+
+	buf.Reset()
+	usage := Usage{{ProjectID: "p1", ProjectName: "api", OrgID: "o1", OrgName: "acme", Builds: 3, BuildMinutes: 12.345, CacheStorageBytes: 2048}}
+	if err := usage.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if want := header + "p1,api,o1,acme,3,12.35,2048\n"; buf.String() != want {
+		t.Errorf("WriteCSV() = %q, want %q", buf.String(), want)
+	}
+	if strings.Count(buf.String(), "\n") != 2 {
+		t.Errorf("expected a header and a row, got %q", buf.String())
+	}
+}
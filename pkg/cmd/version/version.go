@@ -1,24 +1,122 @@
 package version
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"regexp"
+	"runtime"
+	"runtime/debug"
 	"strings"
 
+	"github.com/depot/cli/internal/update"
+	"github.com/depot/cli/pkg/config"
 	"github.com/spf13/cobra"
 )
 
+// Features are the capabilities of this CLI that tooling can check for
+// before using them.
+var Features = []string{"fast-load", "save", "lint", "lockfile"}
+
+// libraries are the modules whose versions are reported as "buildx" and "buildkit".
+var libraries = map[string]string{
+	"buildx":   "github.com/docker/buildx",
+	"buildkit": "github.com/moby/buildkit",
+}
+
+// Info is the version and capabilities of the CLI.
+type Info struct {
+	Version   string            `json:"version"`
+	BuildDate string            `json:"build_date,omitempty"`
+	GoVersion string            `json:"go_version"`
+	Platform  string            `json:"platform"`
+	Libraries map[string]string `json:"libraries"`
+	Features  []string          `json:"features"`
+	Update    *Update           `json:"update,omitempty"`
+}
+
+// Update is the latest release of the CLI.
+type Update struct {
+	Available     bool   `json:"available"`
+	LatestVersion string `json:"latest_version"`
+	URL           string `json:"url"`
+}
+
 func NewCmdVersion(version, buildDate string) *cobra.Command {
+	var output string
+
 	cmd := &cobra.Command{
 		Use:    "version",
 		Hidden: true,
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Print(Format(version, buildDate))
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch output {
+			case "":
+				fmt.Print(Format(version, buildDate))
+				return nil
+			case "json":
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(NewInfo(version, buildDate))
+			default:
+				return fmt.Errorf("unknown output format %q (expected json)", output)
+			}
 		},
 	}
+	cmd.Flags().StringVar(&output, "output", "", `Output format ("json")`)
 	return cmd
 }
 
+// NewInfo returns the version and capabilities of the CLI.  Update
+// availability is omitted if it cannot be checked or the update notifier is
+// disabled with DEPOT_NO_UPDATE_NOTIFIER.
+func NewInfo(version, buildDate string) Info {
+	info := Info{
+		Version:   strings.TrimPrefix(version, "v"),
+		BuildDate: buildDate,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+		Libraries: map[string]string{},
+		Features:  Features,
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for name, path := range libraries {
+			if v := moduleVersion(bi, path); v != "" {
+				info.Libraries[name] = v
+			}
+		}
+	}
+
+	if os.Getenv("DEPOT_NO_UPDATE_NOTIFIER") == "" {
+		if stateFilePath, err := config.StateFile(); err == nil {
+			if release, err := update.LatestRelease(stateFilePath); err == nil {
+				info.Update = &Update{
+					Available:     update.IsNewer(release, version),
+					LatestVersion: release.Version,
+					URL:           release.URL,
+				}
+			}
+		}
+	}
+
+	return info
+}
+
+// moduleVersion returns the version of a dependency, following replacements
+// such as the depot fork of buildkit.
+func moduleVersion(bi *debug.BuildInfo, path string) string {
+	for _, dep := range bi.Deps {
+		if dep.Path != path {
+			continue
+		}
+		if dep.Replace != nil {
+			return dep.Replace.Version
+		}
+		return dep.Version
+	}
+	return ""
+}
+
 func Format(version, buildDate string) string {
 	version = strings.TrimPrefix(version, "v")
 	if buildDate != "" {
@@ -2,13 +2,17 @@ package config
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/adrg/xdg"
 	"github.com/spf13/viper"
 )
 
 func NewConfig() error {
-	configPath, err := xdg.ConfigFile("depot/depot.yaml")
+	configPath, err := ConfigFile()
 	if err != nil {
 		return err
 	}
@@ -35,6 +39,89 @@ func ClearApiToken() error {
 	return viper.WriteConfig()
 }
 
+// GetNotifyWebhook returns the default webhook for build notifications.
+func GetNotifyWebhook() string {
+	return viper.GetString("notify_webhook")
+}
+
+// GetNotifyExec returns the default command for build notifications.
+func GetNotifyExec() string {
+	return viper.GetString("notify_exec")
+}
+
+// GetSBOMGeneratorDigests returns the digests of the SBOM generator images
+// that builds are allowed to use.
+func GetSBOMGeneratorDigests() []string {
+	return viper.GetStringSlice("sbom_generator_digests")
+}
+
+// GetContextdDirs returns the directories depot contextd watches when none
+// are given, set with contextd_dirs.
+func GetContextdDirs() []string {
+	return viper.GetStringSlice("contextd_dirs")
+}
+
+// GetAPITimeout returns the timeout of each attempt of the API method, set
+// with api_timeout_<method> or api_timeout, or zero when neither is set.
+func GetAPITimeout(method string) time.Duration {
+	if d := viper.GetDuration("api_timeout_" + strings.ToLower(method)); d > 0 {
+		return d
+	}
+	return viper.GetDuration("api_timeout")
+}
+
+// GetAPIAttempts returns how many times the API method is attempted, set with
+// api_attempts_<method> or api_attempts.
+func GetAPIAttempts(method string) (int, bool) {
+	for _, key := range []string{"api_attempts_" + strings.ToLower(method), "api_attempts"} {
+		if viper.IsSet(key) {
+			return viper.GetInt(key), true
+		}
+	}
+	return 0, false
+}
+
 func StateFile() (string, error) {
 	return xdg.ConfigFile("depot/state.yaml")
 }
+
+// ConfigFile is the path of the depot config file.
+func ConfigFile() (string, error) {
+	return xdg.ConfigFile("depot/depot.yaml")
+}
+
+// ProfileCacheFile is where the organization profile fetched for key is cached.
+func ProfileCacheFile(key string) (string, error) {
+	return xdg.CacheFile(filepath.Join("depot", "profiles", key+".json"))
+}
+
+// APICacheDir is where the cached responses of the named API list are kept.
+func APICacheDir(name string) string {
+	return filepath.Join(xdg.CacheHome, "depot", "api", name)
+}
+
+// LastBuildLogFile is where the client-side debug logs of the most recent
+// build are kept, not its build output.
+func LastBuildLogFile() (string, error) {
+	return xdg.StateFile("depot/last-build.log")
+}
+
+// GRPCTraceFile is where DEPOT_GRPC_DEBUG=file traces gRPC and Connect calls.
+func GRPCTraceFile() (string, error) {
+	return xdg.StateFile("depot/grpc-trace.jsonl")
+}
+
+// ContextdSocket is where depot contextd listens for the CLI.
+func ContextdSocket() (string, error) {
+	return xdg.RuntimeFile("depot/contextd.sock")
+}
+
+// RunningBuildsDir holds a record of every build started by a running CLI
+// process so that builds of crashed processes can be released.
+func RunningBuildsDir() (string, error) {
+	dir := filepath.Join(xdg.StateHome, "depot", "builds")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"time"
+
+	"github.com/containerd/containerd/defaults"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/keepalive"
+)
+
+// The keepalives and message sizes of the gRPC connections of the buildctl
+// and registry proxies.  Each is set with DEPOT_GRPC_<KEY>, such as
+// DEPOT_GRPC_KEEPALIVE_TIME=30s, or grpc_<key> in the config file, and is
+// clamped to its bounds.  They override the defaults they are given, which
+// for the buildctl proxy are the DEPOT_KEEPALIVE_*_MS variables of the
+// buildkit fork, e.g. DEPOT_GRPC_KEEPALIVE_TIME overrides
+// DEPOT_KEEPALIVE_CLIENT_TIME_MS.  The fork's variables also apply to the
+// connections of builds to the builders, which these do not.
+const (
+	minKeepaliveTime    = 10 * time.Second
+	maxKeepaliveTime    = 2 * time.Hour
+	minKeepaliveTimeout = time.Second
+	maxKeepaliveTimeout = 10 * time.Minute
+	minMsgSize          = 4 << 20
+	maxMsgSize          = 1 << 30
+)
+
+// GRPCClientKeepalive returns the keepalive of the connections of the proxies
+// to the builders: how often they ping an idle connection, how long they wait
+// for the ping to be answered, and whether they ping without active calls.
+func GRPCClientKeepalive(params keepalive.ClientParameters) keepalive.ClientParameters {
+	params.Time = grpcDuration("grpc_keepalive_time", params.Time, minKeepaliveTime, maxKeepaliveTime)
+	params.Timeout = grpcDuration("grpc_keepalive_timeout", params.Timeout, minKeepaliveTimeout, maxKeepaliveTimeout)
+	params.PermitWithoutStream = grpcBool("grpc_keepalive_permit_without_stream", params.PermitWithoutStream)
+	return params
+}
+
+// GRPCServerKeepalive returns how often the buildctl proxy pings an idle
+// client connection and how long it waits for the ping to be answered.
+func GRPCServerKeepalive(params keepalive.ServerParameters) keepalive.ServerParameters {
+	params.Time = grpcDuration("grpc_keepalive_server_time", params.Time, minKeepaliveTimeout, maxKeepaliveTime)
+	params.Timeout = grpcDuration("grpc_keepalive_server_timeout", params.Timeout, minKeepaliveTimeout, maxKeepaliveTimeout)
+	return params
+}
+
+// GRPCEnforcementPolicy returns how often clients of the buildctl proxy may
+// ping it.  Clients that ping more often are sent GOAWAY and disconnected.
+func GRPCEnforcementPolicy(policy keepalive.EnforcementPolicy) keepalive.EnforcementPolicy {
+	policy.MinTime = grpcDuration("grpc_keepalive_min_time", policy.MinTime, minKeepaliveTimeout, maxKeepaliveTime)
+	policy.PermitWithoutStream = grpcBool("grpc_keepalive_server_permit_without_stream", policy.PermitWithoutStream)
+	return policy
+}
+
+// GRPCMaxRecvMsgSize returns the size of the largest message the proxies
+// receive, such as "64MB", 16MB by default.
+func GRPCMaxRecvMsgSize() int {
+	return grpcSize("grpc_max_recv_msg_size", defaults.DefaultMaxRecvMsgSize)
+}
+
+// GRPCMaxSendMsgSize returns the size of the largest message the proxies
+// send, 16MB by default.
+func GRPCMaxSendMsgSize() int {
+	return grpcSize("grpc_max_send_msg_size", defaults.DefaultMaxSendMsgSize)
+}
+
+func grpcDuration(key string, value, min, max time.Duration) time.Duration {
+	if !viper.IsSet(key) {
+		return value
+	}
+	d := viper.GetDuration(key)
+	switch {
+	case d < min:
+		logrus.Warnf("%s of %s is below the minimum of %s; using %s", key, d, min, min)
+		return min
+	case d > max:
+		logrus.Warnf("%s of %s is above the maximum of %s; using %s", key, d, max, max)
+		return max
+	}
+	return d
+}
+
+func grpcBool(key string, value bool) bool {
+	if !viper.IsSet(key) {
+		return value
+	}
+	return viper.GetBool(key)
+}
+
+func grpcSize(key string, value int) int {
+	if !viper.IsSet(key) {
+		return value
+	}
+	size := int(viper.GetSizeInBytes(key))
+	switch {
+	case size < minMsgSize:
+		logrus.Warnf("%s of %d bytes is below the minimum of %d; using %d", key, size, minMsgSize, minMsgSize)
+		return minMsgSize
+	case size > maxMsgSize:
+		logrus.Warnf("%s of %d bytes is above the maximum of %d; using %d", key, size, maxMsgSize, maxMsgSize)
+		return maxMsgSize
+	}
+	return size
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/keepalive"
+)
+
+func TestGRPCClientKeepalive(t *testing.T) {
+	defer viper.Reset()
+
+	params := GRPCClientKeepalive(keepalive.ClientParameters{Time: 10 * time.Second})
+	if params.Time != 10*time.Second {
+		t.Errorf("expected the default when unset, got %s", params.Time)
+	}
+
+	viper.Set("grpc_keepalive_time", "1s")
+	viper.Set("grpc_keepalive_timeout", "30s")
+	viper.Set("grpc_keepalive_permit_without_stream", true)
+	params = GRPCClientKeepalive(keepalive.ClientParameters{})
+	if params.Time != minKeepaliveTime {
+		t.Errorf("expected the time to be raised to %s, got %s", minKeepaliveTime, params.Time)
+	}
+	if params.Timeout != 30*time.Second || !params.PermitWithoutStream {
+		t.Errorf("unexpected keepalive %+v", params)
+	}
+}
+
+func TestGRPCMaxRecvMsgSize(t *testing.T) {
+	defer viper.Reset()
+
+	if size := GRPCMaxRecvMsgSize(); size != 16<<20 {
+		t.Errorf("expected 16MB by default, got %d", size)
+	}
+	viper.Set("grpc_max_recv_msg_size", "64MB")
+	if size := GRPCMaxRecvMsgSize(); size != 64<<20 {
+		t.Errorf("expected 64MB, got %d", size)
+	}
+	viper.Set("grpc_max_recv_msg_size", "4GB")
+	if size := GRPCMaxRecvMsgSize(); size != maxMsgSize {
+		t.Errorf("expected the size to be lowered to %d, got %d", maxMsgSize, size)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"crypto/x509"
 	"fmt"
 	"net"
-	"strings"
 	"time"
 
 	"github.com/depot/cli/pkg/machine"
@@ -34,15 +33,12 @@ func TLSConn(ctx context.Context, builder *machine.Machine) (net.Conn, error) {
 		cfg.Certificates = []tls.Certificate{cert}
 	}
 
-	dialer := &tls.Dialer{Config: cfg}
-	addr := strings.TrimPrefix(builder.Addr, "tcp://")
-
 	var (
 		conn net.Conn
 		err  error
 	)
 	for i := 0; i < 120; i++ {
-		conn, err = dialer.DialContext(ctx, "tcp", addr)
+		conn, err = tlsDial(ctx, builder, cfg)
 		if err == nil {
 			return conn, nil
 		}
@@ -56,3 +52,16 @@ func TLSConn(ctx context.Context, builder *machine.Machine) (net.Conn, error) {
 
 	return nil, err
 }
+
+func tlsDial(ctx context.Context, builder *machine.Machine, cfg *tls.Config) (net.Conn, error) {
+	rawConn, err := builder.Dial(ctx, builder.Addr)
+	if err != nil {
+		return nil, err
+	}
+	conn := tls.Client(rawConn, cfg)
+	if err := conn.HandshakeContext(ctx); err != nil {
+		rawConn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
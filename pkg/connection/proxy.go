@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"os"
+	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/depot/cli/pkg/machine"
 )
@@ -20,6 +23,52 @@ func LocalListener() (net.Listener, string, error) {
 	return l, addr, nil
 }
 
+// UnixListener returns a listener on a unix socket that only the current
+// user can connect to, replacing a stale socket at the path.  The socket is
+// created in a directory that only the current user can open and then moved
+// to the path, so that it is never open to others before its mode is set.
+// A file at the path that is not a socket, or a socket that is still
+// listened on, is not replaced.
+func UnixListener(path string) (net.Listener, string, error) {
+	if fi, err := os.Lstat(path); err == nil {
+		if fi.Mode()&os.ModeSocket == 0 {
+			return nil, "", fmt.Errorf("%s exists and is not a socket", path)
+		}
+		if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+			_ = conn.Close()
+			return nil, "", fmt.Errorf("%s is in use", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, "", err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, "", err
+	}
+
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".depot-socket-")
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	tmp := filepath.Join(dir, "socket")
+	l, err := net.Listen("unix", tmp)
+	if err != nil {
+		return nil, "", err
+	}
+	// The socket is moved, so the caller removes it from the path.
+	l.(*net.UnixListener).SetUnlinkOnClose(false)
+	if err := os.Chmod(tmp, 0o600); err != nil {
+		_ = l.Close()
+		return nil, "", err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = l.Close()
+		return nil, "", err
+	}
+	return l, "unix://" + path, nil
+}
+
 type Proxy struct {
 	listener net.Listener
 	builder  *machine.Machine
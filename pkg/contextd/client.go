@@ -0,0 +1,112 @@
+package contextd
+
+import (
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"time"
+
+	"github.com/depot/cli/pkg/config"
+	"github.com/depot/cli/pkg/debuglog"
+	"github.com/pkg/errors"
+)
+
+// dialTimeout is how long the CLI waits to connect to the daemon before it
+// hashes the context itself.
+const dialTimeout = 200 * time.Millisecond
+
+// ErrNotRunning is returned when no daemon listens on the socket.
+var ErrNotRunning = errors.New("contextd is not running")
+
+// The daemon reads one JSON request per connection and writes one response.
+const (
+	opHash   = "hash"
+	opStatus = "status"
+	opStop   = "stop"
+)
+
+type request struct {
+	Op       string   `json:"op"`
+	Dir      string   `json:"dir,omitempty"`
+	Excludes []string `json:"excludes,omitempty"`
+}
+
+type response struct {
+	Hash   string  `json:"hash,omitempty"`
+	Status *Status `json:"status,omitempty"`
+	Error  string  `json:"error,omitempty"`
+}
+
+// Status describes a running daemon.
+type Status struct {
+	PID       int       `json:"pid"`
+	Dirs      []string  `json:"dirs"`
+	Unwatched []string  `json:"unwatched,omitempty"`
+	Files     int       `json:"files"`
+	Results   int       `json:"results"`
+	Started   time.Time `json:"started"`
+}
+
+// Hash hashes dir like HashDir.  When a running daemon watches dir, it
+// answers from its index; otherwise, or when it fails, dir is hashed here.
+func Hash(dir string, excludes []string) (string, error) {
+	abs, err := absDir(dir)
+	if err == nil {
+		var res response
+		err = call(request{Op: opHash, Dir: abs, Excludes: excludes}, &res)
+		if err == nil {
+			return res.Hash, nil
+		}
+	}
+	if !errors.Is(err, ErrNotRunning) {
+		debuglog.Log("unable to hash %s with contextd, hashing it locally: %v", dir, err)
+	}
+	return HashDir(dir, excludes, nil)
+}
+
+// GetStatus asks the running daemon for its status.
+func GetStatus() (*Status, error) {
+	var res response
+	if err := call(request{Op: opStatus}, &res); err != nil {
+		return nil, err
+	}
+	return res.Status, nil
+}
+
+// Stop asks the running daemon to exit.
+func Stop() error {
+	return call(request{Op: opStop}, &response{})
+}
+
+func call(req request, res *response) error {
+	socket, err := config.ContextdSocket()
+	if err != nil {
+		return err
+	}
+	conn, err := net.DialTimeout("unix", socket, dialTimeout)
+	if err != nil {
+		return ErrNotRunning
+	}
+	defer conn.Close()
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return err
+	}
+	if err := json.NewDecoder(conn).Decode(res); err != nil {
+		return errors.Wrap(err, "unable to read the response of contextd")
+	}
+	if res.Error != "" {
+		return errors.New(res.Error)
+	}
+	return nil
+}
+
+// absDir resolves the symlinks of dir, such as /tmp on macOS, so that it
+// compares with the watched directories.
+func absDir(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(abs)
+}
@@ -0,0 +1,143 @@
+// Package contextd keeps a warm index of the content hashes of build
+// contexts in a background daemon, so that hashing a large context does not
+// read every file again.
+package contextd
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moby/patternmatcher"
+	"github.com/opencontainers/go-digest"
+)
+
+// HashDir hashes the paths, modes, and contents of the files of dir that are
+// not excluded by the .dockerignore patterns.  The content of a file is
+// hashed by its digest, taken from the cache if its size and modification
+// time did not change.  The cache may be nil.
+func HashDir(dir string, excludes []string, cache *DigestCache) (string, error) {
+	pm, err := patternmatcher.New(excludes)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		excluded, err := pm.MatchesOrParentMatches(rel)
+		if err != nil {
+			return err
+		}
+		if excluded {
+			if d.IsDir() && !pm.Exclusions() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s\x00%o\x00", rel, info.Mode())
+		switch {
+		case info.Mode()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(h, target)
+		case info.Mode().IsRegular():
+			dgst, err := cache.digest(path, info)
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(h, dgst)
+		}
+		_, _ = h.Write([]byte{0})
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("sha256:%x", h.Sum(nil)), nil
+}
+
+// DigestCache holds the digests of files by path.
+type DigestCache struct {
+	mu    sync.Mutex
+	files map[string]fileDigest
+}
+
+type fileDigest struct {
+	size    int64
+	modTime time.Time
+	digest  digest.Digest
+}
+
+func NewDigestCache() *DigestCache {
+	return &DigestCache{files: map[string]fileDigest{}}
+}
+
+func (c *DigestCache) digest(path string, info fs.FileInfo) (digest.Digest, error) {
+	if c != nil {
+		c.mu.Lock()
+		cached, ok := c.files[path]
+		c.mu.Unlock()
+		if ok && cached.size == info.Size() && cached.modTime.Equal(info.ModTime()) {
+			return cached.digest, nil
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	dgst, err := digest.FromReader(f)
+	if err != nil {
+		return "", err
+	}
+
+	if c != nil {
+		c.mu.Lock()
+		c.files[path] = fileDigest{size: info.Size(), modTime: info.ModTime(), digest: dgst}
+		c.mu.Unlock()
+	}
+	return dgst, nil
+}
+
+// Forget removes the digests of path and the files below it, such as after
+// the watcher reports a change that may keep the size and modification time.
+func (c *DigestCache) Forget(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.files, path)
+	prefix := path + string(filepath.Separator)
+	for p := range c.files {
+		if strings.HasPrefix(p, prefix) {
+			delete(c.files, p)
+		}
+	}
+}
+
+// Len is the number of files with a digest.
+func (c *DigestCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.files)
+}
@@ -0,0 +1,103 @@
+package contextd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHashDir(t *testing.T) {
+	dir := t.TempDir()
+	write := func(path, content string) {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("Dockerfile", "FROM alpine\n")
+	write("src/main.go", "package main\n")
+	write("node_modules/dep.js", "1")
+
+	cache := NewDigestCache()
+	hash, err := HashDir(dir, []string{"node_modules"}, cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uncached, _ := HashDir(dir, []string{"node_modules"}, nil); uncached != hash {
+		t.Errorf("expected the cache not to change the hash, got %s and %s", hash, uncached)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected the digests of 2 files, got %d", cache.Len())
+	}
+
+	write("node_modules/dep.js", "2")
+	if again, _ := HashDir(dir, []string{"node_modules"}, cache); again != hash {
+		t.Errorf("expected excluded files not to change the hash")
+	}
+
+	write("src/main.go", "package main // changed\n")
+	changed, _ := HashDir(dir, []string{"node_modules"}, cache)
+	if changed == hash {
+		t.Errorf("expected a changed file to change the hash")
+	}
+
+	cache.Forget(filepath.Join(dir, "src"))
+	if cache.Len() != 1 {
+		t.Errorf("expected the digests below src to be forgotten, got %d", cache.Len())
+	}
+}
+
+func TestDaemonForgetsChanges(t *testing.T) {
+	dir := t.TempDir()
+	dir, _ = filepath.EvalSymlinks(dir)
+	file := filepath.Join(dir, "sub", "file")
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := NewDaemon([]string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.watch(ctx)
+
+	if _, err := d.Hash(t.TempDir(), nil); err == nil {
+		t.Error("expected a directory that is not watched to fail")
+	}
+	hash, err := d.Hash(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Status().Dirs) != 1 || d.Status().Results != 1 {
+		t.Fatalf("expected the hash to be kept, got %+v", d.Status())
+	}
+
+	// The same size and modification time are only noticed by the watcher.
+	fi, _ := os.Stat(file)
+	if err := os.WriteFile(file, []byte("b"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(file, fi.ModTime(), fi.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	// The hash syncs with the watcher, so the change is seen right away.
+	if changed, _ := d.Hash(dir, nil); changed == hash {
+		t.Error("expected the change to change the hash")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected no cookie files in the watched directory, got %v", entries)
+	}
+	if entries, _ := os.ReadDir(d.cookieDir); len(entries) != 0 {
+		t.Errorf("expected the cookie files to be removed, got %v", entries)
+	}
+}
@@ -0,0 +1,416 @@
+package contextd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/depot/cli/pkg/config"
+	"github.com/depot/cli/pkg/debuglog"
+	"github.com/fsnotify/fsnotify"
+	"github.com/moby/buildkit/frontend/dockerfile/dockerignore"
+	"github.com/pkg/errors"
+)
+
+// Daemon watches directories and keeps the digests of their files and the
+// hashes of the contexts in them that were asked for.  A change below a
+// directory forgets the digests of the changed files and the hashes of the
+// contexts that contain them, so the next hash only reads the changed files.
+type Daemon struct {
+	dirs    []string
+	cache   *DigestCache
+	watcher *fsnotify.Watcher
+	started time.Time
+
+	mu sync.Mutex
+	// results are the hashes of contexts by directory and excludes.
+	results map[resultKey]string
+	// generation counts the changes so that a hash that raced a change is
+	// not kept.
+	generation uint64
+	// unwatched are the directories that could not be watched in full, such
+	// as over the limit of watches, whose hashes are never kept.
+	unwatched map[string]bool
+	// cookieDir is the watched directory of the daemon that Hash writes
+	// cookie files to, to sync with the watcher without writing to the
+	// watched projects.
+	cookieDir string
+	// cookies are the cookie files of pending syncs by path.
+	cookies   map[string]chan struct{}
+	cookieSeq int
+}
+
+// syncTimeout is how long Hash waits for the event of its cookie file.
+const syncTimeout = 2 * time.Second
+
+type resultKey struct {
+	dir      string
+	excludes string
+}
+
+// NewDaemon watches the directories and their subdirectories.
+func NewDaemon(dirs []string) (*Daemon, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the file watcher")
+	}
+	d := &Daemon{
+		cache:     NewDigestCache(),
+		watcher:   watcher,
+		started:   time.Now(),
+		results:   map[resultKey]string{},
+		unwatched: map[string]bool{},
+		cookies:   map[string]chan struct{}{},
+	}
+	for _, dir := range dirs {
+		abs, err := absDir(dir)
+		if err != nil {
+			_ = watcher.Close()
+			return nil, errors.Wrapf(err, "unable to watch %s", dir)
+		}
+		d.dirs = append(d.dirs, abs)
+	}
+	sort.Strings(d.dirs)
+	for _, dir := range d.dirs {
+		if err := d.watchTree(dir); err != nil {
+			d.unwatch(dir, err)
+		}
+	}
+
+	d.cookieDir, err = os.MkdirTemp("", "depot-contextd-")
+	if err == nil {
+		err = watcher.Add(d.cookieDir)
+	}
+	if err != nil {
+		d.Close()
+		return nil, errors.Wrap(err, "unable to watch the cookie directory")
+	}
+	return d, nil
+}
+
+// Close stops watching and removes the cookie directory.
+func (d *Daemon) Close() {
+	_ = d.watcher.Close()
+	if d.cookieDir != "" {
+		_ = os.RemoveAll(d.cookieDir)
+	}
+}
+
+// Serve warms the index and answers the requests of the CLI on the socket
+// until the context is done or a stop is requested.
+func (d *Daemon) Serve(ctx context.Context) error {
+	defer d.Close()
+
+	listener, err := listen()
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+	go d.watch(ctx)
+	go d.warm()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrap(err, "unable to accept connection")
+		}
+		go d.handle(conn, cancel)
+	}
+}
+
+// listen listens on the socket, replacing the socket of a daemon that did
+// not exit cleanly.
+func listen() (net.Listener, error) {
+	socket, err := config.ContextdSocket()
+	if err != nil {
+		return nil, err
+	}
+	if conn, err := net.DialTimeout("unix", socket, dialTimeout); err == nil {
+		conn.Close()
+		return nil, errors.Errorf("contextd is already running on %s", socket)
+	}
+	_ = os.Remove(socket)
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to listen on %s", socket)
+	}
+	return listener, nil
+}
+
+func (d *Daemon) handle(conn net.Conn, stop func()) {
+	defer conn.Close()
+
+	var (
+		req request
+		res response
+	)
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		return
+	}
+	switch req.Op {
+	case opHash:
+		hash, err := d.Hash(req.Dir, req.Excludes)
+		if err != nil {
+			res.Error = err.Error()
+		}
+		res.Hash = hash
+	case opStatus:
+		res.Status = d.Status()
+	case opStop:
+		defer stop()
+	default:
+		res.Error = fmt.Sprintf("unknown op %q", req.Op)
+	}
+	_ = json.NewEncoder(conn).Encode(res)
+}
+
+// Hash hashes dir, which must be in a watched directory, from the index.
+// It first syncs with the watcher so that the changes made before the call
+// are in the index; if it cannot, dir is hashed from its files.
+func (d *Daemon) Hash(dir string, excludes []string) (string, error) {
+	dir = filepath.Clean(dir)
+	root := d.rootOf(dir)
+	if root == "" {
+		return "", errors.Errorf("%s is not in a watched directory", dir)
+	}
+	d.mu.Lock()
+	unwatched := d.unwatched[root]
+	d.mu.Unlock()
+	if unwatched {
+		// Without events, only the size and modification time of the files
+		// tell whether their digests changed.
+		return HashDir(dir, excludes, d.cache)
+	}
+
+	if err := d.sync(); err != nil {
+		debuglog.Log("unable to sync with the watcher of %s, hashing %s from its files: %v", root, dir, err)
+		return HashDir(dir, excludes, nil)
+	}
+
+	key := resultKey{dir: dir, excludes: strings.Join(excludes, "\n")}
+	d.mu.Lock()
+	if hash, ok := d.results[key]; ok {
+		d.mu.Unlock()
+		return hash, nil
+	}
+	generation := d.generation
+	d.mu.Unlock()
+
+	hash, err := HashDir(dir, excludes, d.cache)
+	if err != nil {
+		return "", err
+	}
+
+	d.mu.Lock()
+	if d.generation == generation {
+		d.results[key] = hash
+	}
+	d.mu.Unlock()
+	return hash, nil
+}
+
+// sync waits until the watcher has delivered the events of the changes made
+// before the call, by writing a cookie file to the cookie directory and
+// waiting for its event, as watchman does.  The watcher delivers the events
+// of all its directories in order, so the earlier changes have then been
+// forgotten.
+func (d *Daemon) sync() error {
+	d.mu.Lock()
+	d.cookieSeq++
+	cookie := filepath.Join(d.cookieDir, strconv.Itoa(d.cookieSeq))
+	seen := make(chan struct{})
+	d.cookies[cookie] = seen
+	d.mu.Unlock()
+	defer func() {
+		d.mu.Lock()
+		delete(d.cookies, cookie)
+		d.mu.Unlock()
+		_ = os.Remove(cookie)
+	}()
+
+	if err := os.WriteFile(cookie, nil, 0600); err != nil {
+		return err
+	}
+	select {
+	case <-seen:
+		return nil
+	case <-time.After(syncTimeout):
+		return errors.New("timed out waiting for the event of the cookie file")
+	}
+}
+
+// Status describes the daemon.
+func (d *Daemon) Status() *Status {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	status := &Status{
+		PID:     os.Getpid(),
+		Dirs:    d.dirs,
+		Files:   d.cache.Len(),
+		Results: len(d.results),
+		Started: d.started,
+	}
+	for dir := range d.unwatched {
+		status.Unwatched = append(status.Unwatched, dir)
+	}
+	sort.Strings(status.Unwatched)
+	return status
+}
+
+func (d *Daemon) rootOf(dir string) string {
+	for _, root := range d.dirs {
+		if isWithin(dir, root) {
+			return root
+		}
+	}
+	return ""
+}
+
+// isWithin returns whether path is dir or below it.
+func isWithin(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
+
+// watchTree watches dir and its subdirectories, as watches are not recursive.
+func (d *Daemon) watchTree(dir string) error {
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// Directories removed while walking are not watched.
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		return d.watcher.Add(path)
+	})
+}
+
+// warm hashes the files of the watched directories, excluding those of
+// their .dockerignore, so that the first hash of a context does not read
+// them.
+func (d *Daemon) warm() {
+	for _, dir := range d.dirs {
+		excludes, _ := readDockerignore(dir)
+		if _, err := HashDir(dir, excludes, d.cache); err != nil {
+			fmt.Fprintf(os.Stderr, "unable to index %s: %v\n", dir, err)
+		}
+	}
+}
+
+func readDockerignore(dir string) ([]string, error) {
+	f, err := os.Open(filepath.Join(dir, ".dockerignore"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return dockerignore.ReadAll(f)
+}
+
+func (d *Daemon) watch(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err, ok := <-d.watcher.Errors:
+			if !ok {
+				return
+			}
+			// Dropped events may hide changes, so nothing is kept.
+			fmt.Fprintf(os.Stderr, "file watcher failed, forgetting the index: %v\n", err)
+			d.mu.Lock()
+			d.generation++
+			d.results = map[resultKey]string{}
+			d.mu.Unlock()
+			for _, dir := range d.dirs {
+				d.cache.Forget(dir)
+			}
+		case event, ok := <-d.watcher.Events:
+			if !ok {
+				return
+			}
+			d.changed(event)
+		}
+	}
+}
+
+// changed forgets what the event may have changed.
+func (d *Daemon) changed(event fsnotify.Event) {
+	path := filepath.Clean(event.Name)
+	if filepath.Dir(path) == d.cookieDir {
+		d.mu.Lock()
+		if seen, ok := d.cookies[path]; ok {
+			close(seen)
+			delete(d.cookies, path)
+		}
+		d.mu.Unlock()
+		return
+	}
+
+	d.mu.Lock()
+	d.generation++
+	d.cache.Forget(path)
+	for key := range d.results {
+		if isWithin(path, key.dir) {
+			delete(d.results, key)
+		}
+	}
+	d.mu.Unlock()
+
+	if event.Has(fsnotify.Create) {
+		if fi, err := os.Lstat(path); err == nil && fi.IsDir() {
+			if err := d.watchTree(path); err != nil {
+				d.unwatch(d.rootOf(path), err)
+			}
+		}
+	}
+}
+
+// unwatch stops watching a directory that could not be watched in full, to
+// free the watches for the other directories.  Its hashes are no longer kept,
+// but the digests of its files are, checked against their size and
+// modification time.
+func (d *Daemon) unwatch(root string, err error) {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "freebsd" {
+		// kqueue needs a file descriptor for every watched file.
+		err = errors.Wrap(err, "the watcher needs a file descriptor for every file, raise the open file limit or watch smaller directories")
+	}
+	fmt.Fprintf(os.Stderr, "unable to watch all of %s, its hashes will not be kept: %v\n", root, err)
+	for _, path := range d.watcher.WatchList() {
+		if isWithin(path, root) {
+			_ = d.watcher.Remove(path)
+		}
+	}
+	d.mu.Lock()
+	d.unwatched[root] = true
+	d.generation++
+	for key := range d.results {
+		if isWithin(key.dir, root) {
+			delete(d.results, key)
+		}
+	}
+	d.mu.Unlock()
+}
@@ -1,18 +1,111 @@
 package debuglog
 
 import (
+	"io"
 	"log"
 	"os"
+	"strconv"
+
+	"google.golang.org/grpc/grpclog"
+)
+
+const (
+	// LevelDebug enables depot debug logs.
+	LevelDebug = 1
+	// LevelTrace additionally enables gRPC logging and HTTP request tracing.
+	LevelTrace = 2
 )
 
-var Debug bool
+var (
+	level  int
+	logger = log.New(os.Stderr, "", log.LstdFlags)
+	// capture receives every message regardless of the verbosity.
+	capture *log.Logger
+	// closers are the files opened for the logs, closed by Close.
+	closers []io.Closer
+)
 
+// Log prints a debug message when the verbosity is at least LevelDebug.
 func Log(format string, args ...interface{}) {
-	if Debug {
-		log.Printf(format, args...)
+	if capture != nil {
+		capture.Printf(format, args...)
+	}
+	if Enabled(LevelDebug) {
+		logger.Printf(format, args...)
+	}
+}
+
+// Trace prints a message when the verbosity is at least LevelTrace.
+func Trace(format string, args ...interface{}) {
+	if capture != nil {
+		capture.Printf(format, args...)
+	}
+	if Enabled(LevelTrace) {
+		logger.Printf(format, args...)
+	}
+}
+
+// Capture records every debug and trace message to w, no matter the
+// verbosity.  It is used to keep the logs of the last build for support
+// bundles.
+func Capture(w io.Writer) {
+	capture = log.New(w, "", log.LstdFlags|log.Lmicroseconds)
+	if c, ok := w.(io.Closer); ok {
+		closers = append(closers, c)
+	}
+}
+
+// Enabled reports whether messages at the verbosity level are printed.
+func Enabled(l int) bool {
+	return level >= l
+}
+
+// SetLevel sets the verbosity, usually from the number of -v flags.
+// The level is never lowered below the one requested by $DEPOT_DEBUG.
+func SetLevel(l int) {
+	level = max(l, envLevel())
+	if Enabled(LevelTrace) {
+		grpclog.SetLoggerV2(grpclog.NewLoggerV2WithVerbosity(logger.Writer(), logger.Writer(), logger.Writer(), 2))
+	}
+}
+
+// SetOutput redirects all diagnostic output, for example to a --log-file.
+func SetOutput(w io.Writer) {
+	logger.SetOutput(w)
+	SetLevel(level)
+	if c, ok := w.(io.Closer); ok {
+		closers = append(closers, c)
+	}
+}
+
+// Close closes the files given to SetOutput and Capture when the command
+// exits.  Later messages are printed to stderr.
+func Close() {
+	logger.SetOutput(os.Stderr)
+	SetLevel(level)
+	capture = nil
+	for _, c := range closers {
+		_ = c.Close()
+	}
+	closers = nil
+}
+
+// envLevel reads the verbosity from $DEPOT_DEBUG.  Any non-numeric value
+// enables debug logs for backwards compatibility, as does $DEPOT_DEBUG_OIDC.
+func envLevel() int {
+	v := os.Getenv("DEPOT_DEBUG")
+	if v == "" {
+		if os.Getenv("DEPOT_DEBUG_OIDC") != "" {
+			return LevelDebug
+		}
+		return 0
+	}
+	if l, err := strconv.Atoi(v); err == nil {
+		return l
 	}
+	return LevelDebug
 }
 
 func init() {
-	Debug = os.Getenv("DEPOT_DEBUG") != ""
+	SetLevel(0)
 }
@@ -0,0 +1,33 @@
+package debuglog
+
+import (
+	"net/http"
+	"time"
+)
+
+// Transport wraps an http.RoundTripper and traces every request when the
+// verbosity is at least LevelTrace.  Headers are never logged as they
+// contain credentials.
+type Transport struct {
+	Base http.RoundTripper
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if !Enabled(LevelTrace) {
+		return base.RoundTrip(req)
+	}
+
+	start := time.Now()
+	res, err := base.RoundTrip(req)
+	if err != nil {
+		Trace("http %s %s failed after %s: %v", req.Method, req.URL.Redacted(), time.Since(start), err)
+		return nil, err
+	}
+	Trace("http %s %s %d %s", req.Method, req.URL.Redacted(), res.StatusCode, time.Since(start))
+	return res, nil
+}
@@ -3,12 +3,18 @@ package helpers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	"connectrpc.com/connect"
 	depotbuild "github.com/depot/cli/pkg/build"
+	"github.com/depot/cli/pkg/ci"
 	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
 	buildx "github.com/docker/buildx/build"
+	"github.com/moby/buildkit/util/entitlements"
+	"golang.org/x/exp/slices"
 )
 
 func BeginBuild(ctx context.Context, req *cliv1.CreateBuildRequest, token string) (depotbuild.Build, error) {
@@ -46,8 +52,16 @@ type UsingDepotFeatures struct {
 }
 
 func NewBuildRequest(project string, opts map[string]buildx.Options, features UsingDepotFeatures) *cliv1.CreateBuildRequest {
-	// There is only one target for a build request, "default".
-	for _, opts := range opts {
+	// A build has a single "default" target unless several Dockerfiles were given.
+	names := make([]string, 0, len(opts))
+	for name := range opts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	options := make([]*cliv1.BuildOptions, 0, len(opts))
+	for _, name := range names {
+		opts := opts[name]
 		outputs := make([]*cliv1.BuildOutput, len(opts.Exports))
 		for i := range opts.Exports {
 			outputs[i] = &cliv1.BuildOutput{
@@ -61,24 +75,28 @@ func NewBuildRequest(project string, opts map[string]buildx.Options, features Us
 			target = &opts.Target
 		}
 
-		return &cliv1.CreateBuildRequest{
-			ProjectId: &project,
-			Options: []*cliv1.BuildOptions{
-				{
-					Command:    cliv1.Command_COMMAND_BUILD,
-					Tags:       opts.Tags,
-					Outputs:    outputs,
-					Push:       features.Push,
-					Load:       features.Load,
-					Save:       features.Save,
-					Lint:       features.Lint,
-					TargetName: target,
-				},
-			},
-		}
+		options = append(options, &cliv1.BuildOptions{
+			Command:      cliv1.Command_COMMAND_BUILD,
+			Tags:         opts.Tags,
+			Outputs:      outputs,
+			Push:         features.Push,
+			Load:         features.Load,
+			Save:         features.Save,
+			Lint:         features.Lint,
+			TargetName:   target,
+			Entitlements: entitlementNames(opts.Allow),
+		})
+	}
+
+	if len(options) == 0 {
+		return &cliv1.CreateBuildRequest{ProjectId: &project, Ci: ciContext()}
 	}
 
-	return &cliv1.CreateBuildRequest{ProjectId: &project}
+	return &cliv1.CreateBuildRequest{
+		ProjectId: &project,
+		Options:   options,
+		Ci:        ciContext(),
+	}
 }
 
 func NewBakeRequest(project string, opts map[string]buildx.Options, features UsingDepotFeatures) *cliv1.CreateBuildRequest {
@@ -95,20 +113,93 @@ func NewBakeRequest(project string, opts map[string]buildx.Options, features Usi
 		}
 
 		targets = append(targets, &cliv1.BuildOptions{
-			Command:    cliv1.Command_COMMAND_BAKE,
-			Tags:       opts.Tags,
-			Outputs:    outputs,
-			Push:       features.Push,
-			Load:       features.Load,
-			Save:       features.Save,
-			Lint:       features.Lint,
-			TargetName: &name,
+			Command:      cliv1.Command_COMMAND_BAKE,
+			Tags:         opts.Tags,
+			Outputs:      outputs,
+			Push:         features.Push,
+			Load:         features.Load,
+			Save:         features.Save,
+			Lint:         features.Lint,
+			TargetName:   &name,
+			Entitlements: entitlementNames(opts.Allow),
 		})
 	}
 
 	return &cliv1.CreateBuildRequest{
 		ProjectId: &project,
 		Options:   targets,
+		Ci:        ciContext(),
+	}
+}
+
+func entitlementNames(allow []entitlements.Entitlement) []string {
+	names := make([]string, 0, len(allow))
+	for _, e := range allow {
+		names = append(names, string(e))
+	}
+	return names
+}
+
+// CheckEntitlements returns an error naming the targets that request
+// entitlements the project's policy forbids, so that the build fails before
+// it starts rather than inside the solve.
+func CheckEntitlements(build depotbuild.Build, opts map[string]buildx.Options) error {
+	forbidden := build.ForbiddenEntitlements()
+	if len(forbidden) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(opts))
+	for name := range opts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var denied []string
+	for _, name := range names {
+		for _, e := range opts[name].Allow {
+			if !slices.Contains(forbidden, string(e)) {
+				continue
+			}
+			if len(opts) > 1 {
+				denied = append(denied, fmt.Sprintf("%q (target %q)", e, name))
+			} else {
+				denied = append(denied, fmt.Sprintf("%q", e))
+			}
+		}
+	}
+	if len(denied) == 0 {
+		return nil
+	}
+	noun := "entitlement"
+	if len(denied) > 1 {
+		noun = "entitlements"
+	}
+	return fmt.Errorf("project %s does not allow the %s %s; remove --allow or ask an organization owner to allow it in the project settings", build.BuildProject(), strings.Join(denied, ", "), noun)
+}
+
+// ciContext describes the CI job so that builds can be grouped by pull request and pipeline.
+func ciContext() *cliv1.CIContext {
+	ctx, isCI := ci.ProviderContext()
+	if !isCI {
+		return nil
+	}
+
+	optional := func(s string) *string {
+		if s == "" {
+			return nil
+		}
+		return &s
+	}
+	return &cliv1.CIContext{
+		Provider:    ctx.Provider,
+		PullRequest: optional(ctx.PullRequest),
+		WorkflowId:  optional(ctx.WorkflowID),
+		JobId:       optional(ctx.JobID),
+		CommitSha:   optional(ctx.CommitSHA),
+		Actor:       optional(ctx.Actor),
+		Ref:         optional(ctx.Ref),
+		Url:         optional(ctx.URL),
 	}
 }
 
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/apicache"
 	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
 	"github.com/depot/cli/pkg/proto/depot/cli/v1/cliv1connect"
 	"github.com/pkg/errors"
@@ -41,6 +43,8 @@ func NewBuildsModel(projectID, token string, client cliv1connect.BuildServiceCli
 		{Title: "Status", Width: 16},
 		{Title: "Started", Width: 24},
 		{Title: "Duration (s)", Width: 16},
+		{Title: "Machine", Width: 20},
+		{Title: "Cost", Width: 10},
 	}
 
 	styles := table.DefaultStyles()
@@ -80,12 +84,14 @@ type BuildsModel struct {
 	ProjectID       string
 	Token           string
 	SelectedBuildID string
+	// SortBy orders the builds by created, duration, or cost.
+	SortBy string
 
 	err error
 }
 
 func (m BuildsModel) Init() tea.Cmd {
-	return m.loadBuilds()
+	return m.loadBuilds(true)
 }
 
 func (m BuildsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -101,7 +107,7 @@ func (m BuildsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		if msg.String() == "r" {
-			return m, m.loadBuilds()
+			return m, m.loadBuilds(false)
 		}
 
 		if msg.String() == "enter" {
@@ -128,7 +134,7 @@ func (m BuildsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.table.SetColumns(m.columns)
 
 	case tickMsg:
-		return m, m.loadBuilds()
+		return m, m.loadBuilds(false)
 	case buildRows:
 		m.err = nil
 
@@ -169,21 +175,29 @@ func (m BuildsModel) View() string {
 type buildRows []table.Row
 type errMsg struct{ error }
 
-func (m BuildsModel) loadBuilds() tea.Cmd {
+// loadBuilds lists the builds, using a cached response for the first load so
+// that the table shows at once.
+func (m BuildsModel) loadBuilds(cached bool) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
+		list := Builds
+		if cached {
+			list = CachedBuilds
+		}
+
 		rows := []table.Row{}
-		builds, err := builds(ctx, m.ProjectID, m.Token, m.client)
+		builds, err := list(ctx, m.Token, m.ProjectID, m.client)
 		if err != nil {
 			return errMsg{err}
 		}
+		if err := builds.Sort(m.SortBy); err != nil {
+			return errMsg{err}
+		}
 
 		for _, build := range builds {
-			rows = append(rows, table.Row{
-				build.ID, build.Status, build.StartTime, fmt.Sprintf("%d", build.Duration),
-			})
+			rows = append(rows, build.row())
 		}
 
 		return buildRows(rows)
@@ -198,72 +212,66 @@ func refreshBuildTimer() tea.Cmd {
 	})
 }
 
-type depotBuild struct {
-	ID        string `json:"id"`
-	Status    string `json:"status"`
-	StartTime string `json:"startTime"`
-	Duration  int    `json:"duration"`
-}
-
-func builds(ctx context.Context, projectID, token string, client cliv1connect.BuildServiceClient) ([]depotBuild, error) {
-	req := cliv1.ListBuildsRequest{ProjectId: projectID}
-	resp, err := client.ListBuilds(ctx, api.WithAuthentication(connect.NewRequest(&req), token))
-	if err != nil {
-		return nil, err
-	}
-
-	res := []depotBuild{}
-
-	for _, build := range resp.Msg.Builds {
-		createdAt := build.CreatedAt.AsTime()
-		if build.CreatedAt == nil {
-			createdAt = time.Now()
-		}
-
-		finishedAt := build.FinishedAt.AsTime()
-		// This will will cause the duration to increase until the build is complete.
-		if build.FinishedAt == nil {
-			finishedAt = time.Now()
-		}
-
-		startTime := createdAt.Format(time.RFC3339)
-		duration := int(finishedAt.Sub(createdAt).Seconds())
-		status := strings.ToLower(strings.TrimPrefix(build.Status.String(), "BUILD_STATUS_"))
-
-		res = append(res, depotBuild{
-			ID:        build.Id,
-			Status:    status,
-			StartTime: startTime,
-			Duration:  duration,
-		})
-	}
-
-	return res, nil
-}
-
 var baseStyle = lipgloss.NewStyle().
 	BorderStyle(lipgloss.NormalBorder()).
 	BorderForeground(lipgloss.Color("240"))
 
 type DepotBuild struct {
-	ID        string `json:"id"`
-	Status    string `json:"status"`
-	StartTime string `json:"startTime"`
-	Duration  int    `json:"duration"`
+	ID          string  `json:"id"`
+	Status      string  `json:"status"`
+	StartTime   string  `json:"startTime"`
+	Duration    int     `json:"duration"`
+	MachineType string  `json:"machineType,omitempty"`
+	Cost        float64 `json:"cost"`
+
+	createdAt time.Time
 }
 
+// Build sort orders, most recent, longest, or most expensive first.
+const (
+	SortBuildsByCreated  = "created"
+	SortBuildsByDuration = "duration"
+	SortBuildsByCost     = "cost"
+)
+
 type DepotBuilds []DepotBuild
 
+// Builds lists the builds of the project as they are now, for example to find
+// the latest build.  The response is cached for CachedBuilds.
 func Builds(ctx context.Context, token, projectID string, client cliv1connect.BuildServiceClient) (DepotBuilds, error) {
-	req := cliv1.ListBuildsRequest{ProjectId: projectID}
-	resp, err := client.ListBuilds(ctx, api.WithAuthentication(connect.NewRequest(&req), token))
+	req := &cliv1.ListBuildsRequest{ProjectId: projectID}
+	resp, err := apicache.Refresh(ctx, "builds", token, req, listBuilds(token, req, client))
 	if err != nil {
 		return nil, err
 	}
+	return newDepotBuilds(resp), nil
+}
+
+// CachedBuilds lists the builds of the project like Builds, but returns a
+// response cached in the last few seconds if there is one.
+func CachedBuilds(ctx context.Context, token, projectID string, client cliv1connect.BuildServiceClient) (DepotBuilds, error) {
+	req := &cliv1.ListBuildsRequest{ProjectId: projectID}
+	resp, err := apicache.Get(ctx, "builds", token, req, listBuilds(token, req, client))
+	if err != nil {
+		return nil, err
+	}
+	return newDepotBuilds(resp), nil
+}
+
+func listBuilds(token string, req *cliv1.ListBuildsRequest, client cliv1connect.BuildServiceClient) func(context.Context) (*cliv1.ListBuildsResponse, error) {
+	return func(ctx context.Context) (*cliv1.ListBuildsResponse, error) {
+		resp, err := client.ListBuilds(ctx, api.WithAuthentication(connect.NewRequest(req), token))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+}
 
+func newDepotBuilds(resp *cliv1.ListBuildsResponse) DepotBuilds {
 	res := []DepotBuild{}
 
-	for _, build := range resp.Msg.Builds {
+	for _, build := range resp.Builds {
 		createdAt := build.CreatedAt.AsTime()
 		if build.CreatedAt == nil {
 			createdAt = time.Now()
@@ -280,26 +288,56 @@ func Builds(ctx context.Context, token, projectID string, client cliv1connect.Bu
 		status := strings.ToLower(strings.TrimPrefix(build.Status.String(), "BUILD_STATUS_"))
 
 		res = append(res, DepotBuild{
-			ID:        build.Id,
-			Status:    status,
-			StartTime: startTime,
-			Duration:  duration,
+			ID:          build.Id,
+			Status:      status,
+			StartTime:   startTime,
+			Duration:    duration,
+			MachineType: build.MachineType,
+			// The cost of a running build grows with its duration.
+			Cost:      build.PricePerMinute * finishedAt.Sub(createdAt).Minutes(),
+			createdAt: createdAt,
 		})
 	}
 
-	return res, nil
+	return res
+}
+
+// Sort orders the builds by created, duration, or cost.  Ties keep the order
+// of the API, most recent first.
+func (depotBuilds DepotBuilds) Sort(by string) error {
+	switch by {
+	case "", SortBuildsByCreated:
+		sort.SliceStable(depotBuilds, func(i, j int) bool {
+			return depotBuilds[i].createdAt.After(depotBuilds[j].createdAt)
+		})
+	case SortBuildsByDuration:
+		sort.SliceStable(depotBuilds, func(i, j int) bool {
+			return depotBuilds[i].Duration > depotBuilds[j].Duration
+		})
+	case SortBuildsByCost:
+		sort.SliceStable(depotBuilds, func(i, j int) bool {
+			return depotBuilds[i].Cost > depotBuilds[j].Cost
+		})
+	default:
+		return errors.Errorf("unknown sort: %s. Requires created, duration, or cost", by)
+	}
+	return nil
+}
+
+func (build DepotBuild) row() []string {
+	return []string{build.ID, build.Status, build.StartTime, fmt.Sprintf("%d", build.Duration), build.MachineType, fmt.Sprintf("$%.2f", build.Cost)}
 }
 
 func (depotBuilds DepotBuilds) WriteCSV() error {
 	w := csv.NewWriter(os.Stdout)
 	if len(depotBuilds) > 0 {
-		if err := w.Write([]string{"Build ID", "Status", "Started", "Duration (s)"}); err != nil {
+		if err := w.Write([]string{"Build ID", "Status", "Started", "Duration (s)", "Machine", "Cost (USD)"}); err != nil {
 			return err
 		}
 	}
 
 	for _, build := range depotBuilds {
-		row := []string{build.ID, build.Status, build.StartTime, fmt.Sprintf("%d", build.Duration)}
+		row := []string{build.ID, build.Status, build.StartTime, fmt.Sprintf("%d", build.Duration), build.MachineType, fmt.Sprintf("%.2f", build.Cost)}
 		if err := w.Write(row); err != nil {
 			return err
 		}
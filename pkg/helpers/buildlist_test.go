@@ -0,0 +1,72 @@
+package helpers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDepotBuildsSort(t *testing.T) {
+	t0 := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	// The builds are in the order of the API, most recent first, and each
+	// sort key has a tie between b and c.
+	builds := DepotBuilds{
+		{ID: "a", Duration: 30, Cost: 0.10, createdAt: t0.Add(3 * time.Minute)},
+		{ID: "b", Duration: 60, Cost: 0.50, createdAt: t0.Add(time.Minute)},
+		{ID: "c", Duration: 60, Cost: 0.50, createdAt: t0.Add(time.Minute)},
+		{ID: "d", Duration: 90, Cost: 0.20, createdAt: t0.Add(2 * time.Minute)},
+	}
+
+	tests := []struct {
+		by   string
+		want []string
+	}{
+		{by: "", want: []string{"a", "d", "b", "c"}},
+		{by: SortBuildsByCreated, want: []string{"a", "d", "b", "c"}},
+		{by: SortBuildsByDuration, want: []string{"d", "b", "c", "a"}},
+		{by: SortBuildsByCost, want: []string{"b", "c", "d", "a"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.by, func(t *testing.T) {
+			sorted := append(DepotBuilds{}, builds...)
+			if err := sorted.Sort(tt.by); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, build := range sorted {
+				got = append(got, build.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Sort(%q) = %v, want %v", tt.by, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDepotBuildsSortTiesKeepOrder(t *testing.T) {
+	t0 := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	builds := DepotBuilds{
+		{ID: "c", Duration: 10, Cost: 0.10, createdAt: t0},
+		{ID: "a", Duration: 10, Cost: 0.10, createdAt: t0},
+		{ID: "b", Duration: 10, Cost: 0.10, createdAt: t0},
+	}
+	for _, by := range []string{SortBuildsByCreated, SortBuildsByDuration, SortBuildsByCost} {
+		if err := builds.Sort(by); err != nil {
+			t.Fatal(err)
+		}
+		if got := []string{builds[0].ID, builds[1].ID, builds[2].ID}; !reflect.DeepEqual(got, []string{"c", "a", "b"}) {
+			t.Errorf("Sort(%q) of ties = %v, want the original order", by, got)
+		}
+	}
+}
+
+func TestDepotBuildsSortUnknown(t *testing.T) {
+	builds := DepotBuilds{{ID: "a"}, {ID: "b"}}
+	if err := builds.Sort("status"); err == nil {
+		t.Error("Sort(status) = nil error, want an error")
+	}
+	if builds[0].ID != "a" || builds[1].ID != "b" {
+		t.Errorf("Sort(status) reordered the builds to %v", builds)
+	}
+}
@@ -0,0 +1,91 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	depotbuild "github.com/depot/cli/pkg/build"
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+	"github.com/depot/cli/pkg/registry"
+	buildx "github.com/docker/buildx/build"
+	"github.com/moby/buildkit/client"
+)
+
+// ResolveDepotCacheImports replaces the --cache-from entries that read the
+// cache of another Depot project, "type=depot,project=<id>" or
+// "depot:<id>", with the cache importer the API returns for the build, and
+// adds the credentials that importer needs to the session of the target.
+func ResolveDepotCacheImports(ctx context.Context, build depotbuild.Build, opts map[string]buildx.Options) error {
+	names := make([]string, 0, len(opts))
+	for name := range opts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	imports := map[string]*cliv1.GetCacheImportResponse{}
+	for _, name := range names {
+		opt := opts[name]
+		// Targets split from one build share the cache-from entries.
+		cacheFrom := append([]client.CacheOptionsEntry{}, opt.CacheFrom...)
+		var credentials []depotbuild.Credential
+		resolved := false
+		for i, entry := range cacheFrom {
+			projectID, ok := depotCacheProject(entry)
+			if !ok {
+				continue
+			}
+			if projectID == "" {
+				return fmt.Errorf("cache-from type=depot requires a project, e.g. type=depot,project=<id>")
+			}
+
+			res, ok := imports[projectID]
+			if !ok {
+				var err error
+				res, err = build.CacheImport(ctx, projectID)
+				if err != nil {
+					return fmt.Errorf("unable to read the cache of project %s: %w", projectID, err)
+				}
+				if res.Type == "" {
+					return fmt.Errorf("the cache of project %s is not available to this build", projectID)
+				}
+				imports[projectID] = res
+			}
+
+			attrs := make(map[string]string, len(res.Attrs))
+			for k, v := range res.Attrs {
+				attrs[k] = v
+			}
+			cacheFrom[i] = client.CacheOptionsEntry{Type: res.Type, Attrs: attrs}
+			for _, cred := range res.Credentials {
+				credentials = append(credentials, depotbuild.Credential{Host: cred.Host, Token: cred.Token})
+			}
+			resolved = true
+		}
+		if !resolved {
+			continue
+		}
+		opt.CacheFrom = cacheFrom
+		if len(credentials) > 0 {
+			opt.Session = registry.ReplaceDockerAuth(credentials, opt.Session)
+		}
+		opts[name] = opt
+	}
+	return nil
+}
+
+// depotCacheProject returns the project of a cache-from entry that reads the
+// cache of a Depot project.  "depot:<id>" is parsed as a registry reference,
+// so it is recognized here as well.
+func depotCacheProject(entry client.CacheOptionsEntry) (string, bool) {
+	switch entry.Type {
+	case "depot":
+		return entry.Attrs["project"], true
+	case "registry":
+		if projectID, ok := strings.CutPrefix(entry.Attrs["ref"], "depot:"); ok {
+			return projectID, true
+		}
+	}
+	return "", false
+}
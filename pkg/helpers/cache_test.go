@@ -0,0 +1,61 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/client"
+)
+
+func TestDepotCacheProject(t *testing.T) {
+	tests := []struct {
+		name        string
+		entry       client.CacheOptionsEntry
+		wantProject string
+		wantOK      bool
+	}{
+		{
+			name:        "type=depot",
+			entry:       client.CacheOptionsEntry{Type: "depot", Attrs: map[string]string{"project": "abc123"}},
+			wantProject: "abc123",
+			wantOK:      true,
+		},
+		{
+			name:   "type=depot without a project",
+			entry:  client.CacheOptionsEntry{Type: "depot", Attrs: map[string]string{}},
+			wantOK: true,
+		},
+		{
+			name:        "type=depot takes the project over a ref",
+			entry:       client.CacheOptionsEntry{Type: "depot", Attrs: map[string]string{"project": "abc123", "ref": "depot:def456"}},
+			wantProject: "abc123",
+			wantOK:      true,
+		},
+		{
+			name:        "depot: shorthand",
+			entry:       client.CacheOptionsEntry{Type: "registry", Attrs: map[string]string{"ref": "depot:abc123"}},
+			wantProject: "abc123",
+			wantOK:      true,
+		},
+		{
+			name:  "registry cache",
+			entry: client.CacheOptionsEntry{Type: "registry", Attrs: map[string]string{"ref": "example/app:cache"}},
+		},
+		{
+			name:  "registry cache ignores a project",
+			entry: client.CacheOptionsEntry{Type: "registry", Attrs: map[string]string{"ref": "example/app:cache", "project": "abc123"}},
+		},
+		{
+			name:  "other cache type",
+			entry: client.CacheOptionsEntry{Type: "gha", Attrs: map[string]string{"project": "abc123"}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			project, ok := depotCacheProject(tt.entry)
+			if project != tt.wantProject || ok != tt.wantOK {
+				t.Errorf("depotCacheProject() = %q, %v, want %q, %v", project, ok, tt.wantProject, tt.wantOK)
+			}
+		})
+	}
+}
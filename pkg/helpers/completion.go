@@ -0,0 +1,64 @@
+package helpers
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// CompleteProjectIDs completes a --project flag with the IDs of the projects
+// visible to the current token.  Completion never prompts for a login.
+func CompleteProjectIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	token, _ := cmd.Flags().GetString("token")
+	if token == "" {
+		token = os.Getenv("DEPOT_TOKEN")
+	}
+	if token == "" {
+		token = config.GetApiToken()
+	}
+	if token == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := ListProjects(ctx, token, api.NewProjectsClient())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	completions := make([]string, 0, len(res.Projects))
+	for _, p := range res.Projects {
+		completions = append(completions, p.Id+"\t"+p.Name+" ("+p.OrgName+")")
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteBuildPlatforms completes a --build-platform flag.
+func CompleteBuildPlatforms(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{
+		"dynamic\tUse the platform of the build target [default]",
+		"linux/amd64\tAlways build on an amd64 machine",
+		"linux/arm64\tAlways build on an arm64 machine",
+	}, cobra.ShellCompDirectiveNoFileComp
+}
+
+// RegisterCompletions walks the command tree and registers the depot
+// specific completions for flags that share a meaning across commands.
+func RegisterCompletions(cmd *cobra.Command) {
+	if cmd.Flags().Lookup("project") != nil {
+		_ = cmd.RegisterFlagCompletionFunc("project", CompleteProjectIDs)
+	}
+	if cmd.Flags().Lookup("build-platform") != nil {
+		_ = cmd.RegisterFlagCompletionFunc("build-platform", CompleteBuildPlatforms)
+	}
+
+	for _, sub := range cmd.Commands() {
+		RegisterCompletions(sub)
+	}
+}
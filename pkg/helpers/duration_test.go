@@ -0,0 +1,21 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	if got, err := ParseDuration("30d"); err != nil || got != 30*24*time.Hour {
+		t.Errorf("ParseDuration(30d) = %v, %v", got, err)
+	}
+	if got, err := ParseDuration("12h"); err != nil || got != 12*time.Hour {
+		t.Errorf("ParseDuration(12h) = %v, %v", got, err)
+	}
+	if _, err := ParseDuration("soon"); err == nil {
+		t.Errorf("ParseDuration(soon) expected error")
+	}
+	if _, err := ParseDuration("1.5d"); err == nil {
+		t.Errorf("ParseDuration(1.5d) expected error")
+	}
+}
@@ -32,8 +32,13 @@ func ConfirmSaveProject(p *SelectedProject) bool {
 	}
 
 	prompt := fmt.Sprintf("Selected project %s (%s)\nCreate a depot.json file to remember this project for future builds?", p.Name, p.ID)
+	return Confirm(prompt, true)
+}
 
-	input := confirmation.New(prompt, confirmation.NewValue(true))
+// Confirm asks the user a yes or no question in the terminal.
+// Any prompt error is treated as a no.
+func Confirm(prompt string, defaultValue bool) bool {
+	input := confirmation.New(prompt, confirmation.NewValue(defaultValue))
 	input.Template = confirmation.TemplateArrow
 	input.ResultTemplate = confirmation.ResultTemplateArrow
 
@@ -41,10 +46,10 @@ func ConfirmSaveProject(p *SelectedProject) bool {
 	input.KeyMap.SelectYes = append(input.KeyMap.SelectYes, "h")
 	input.KeyMap.SelectNo = append(input.KeyMap.SelectNo, "l")
 
-	shouldSave, err := input.RunPrompt()
+	ok, err := input.RunPrompt()
 	if err != nil {
 		return false
 	}
 
-	return shouldSave
+	return ok
 }
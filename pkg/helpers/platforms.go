@@ -0,0 +1,75 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"connectrpc.com/connect"
+	"github.com/depot/cli/pkg/api"
+	cliv1beta1 "github.com/depot/cli/pkg/proto/depot/cli/v1beta1"
+)
+
+var (
+	projectPlatformsMu sync.Mutex
+	projectPlatforms   = map[string][]string{}
+)
+
+// IsProjectPlatforms reports whether platform stands for the platforms
+// configured for the project.
+func IsProjectPlatforms(platform string) bool {
+	return platform == "all" || platform == "depot-defaults"
+}
+
+// ExpandProjectPlatforms replaces "all" and "depot-defaults" in the
+// comma-separated platforms with the platforms configured for the project.
+func ExpandProjectPlatforms(ctx context.Context, token, projectID string, platforms []string) ([]string, error) {
+	var expanded []string
+	for _, value := range platforms {
+		for _, platform := range strings.Split(value, ",") {
+			platform = strings.TrimSpace(platform)
+			if !IsProjectPlatforms(platform) {
+				expanded = append(expanded, platform)
+				continue
+			}
+			configured, err := ProjectPlatforms(ctx, token, projectID)
+			if err != nil {
+				return nil, err
+			}
+			expanded = append(expanded, configured...)
+		}
+	}
+	return expanded, nil
+}
+
+// ProjectPlatforms returns the platforms configured for builds of the project.
+func ProjectPlatforms(ctx context.Context, token, projectID string) ([]string, error) {
+	if projectID == "" {
+		return nil, fmt.Errorf(`"--platform all" requires a project, please specify with --project, DEPOT_PROJECT_ID, or run "depot init"`)
+	}
+
+	projectPlatformsMu.Lock()
+	defer projectPlatformsMu.Unlock()
+	if platforms, ok := projectPlatforms[projectID]; ok {
+		return platforms, nil
+	}
+
+	client := api.NewProjectsClient()
+	req := cliv1beta1.ListProjectsRequest{}
+	projects, err := client.ListProjects(ctx, api.WithAuthentication(connect.NewRequest(&req), token))
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range projects.Msg.Projects {
+		if p.Id != projectID {
+			continue
+		}
+		if len(p.Platforms) == 0 {
+			return nil, fmt.Errorf("project %s has no platforms configured", projectID)
+		}
+		projectPlatforms[projectID] = p.Platforms
+		return p.Platforms, nil
+	}
+	return nil, fmt.Errorf("Project with ID %s not found", projectID)
+}
@@ -13,8 +13,10 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/apicache"
 	"github.com/depot/cli/pkg/project"
 	cliv1beta1 "github.com/depot/cli/pkg/proto/depot/cli/v1beta1"
+	"github.com/depot/cli/pkg/proto/depot/cli/v1beta1/cliv1beta1connect"
 	"github.com/sirupsen/logrus"
 )
 
@@ -123,22 +125,56 @@ func (p *SelectedProject) SaveAs(configFilePath string) error {
 	return nil
 }
 
+// ListProjects lists the projects visible to the token, returning a response
+// cached in the last few seconds if there is one.
+func ListProjects(ctx context.Context, token string, client cliv1beta1connect.ProjectsServiceClient) (*cliv1beta1.ListProjectsResponse, error) {
+	req := &cliv1beta1.ListProjectsRequest{}
+	return apicache.Get(ctx, "projects", token, req, listProjects(token, req, client))
+}
+
+// RefreshProjects lists the projects visible to the token as they are now and
+// caches the response for ListProjects.
+func RefreshProjects(ctx context.Context, token string, client cliv1beta1connect.ProjectsServiceClient) (*cliv1beta1.ListProjectsResponse, error) {
+	req := &cliv1beta1.ListProjectsRequest{}
+	return apicache.Refresh(ctx, "projects", token, req, listProjects(token, req, client))
+}
+
+func listProjects(token string, req *cliv1beta1.ListProjectsRequest, client cliv1beta1connect.ProjectsServiceClient) func(context.Context) (*cliv1beta1.ListProjectsResponse, error) {
+	return func(ctx context.Context) (*cliv1beta1.ListProjectsResponse, error) {
+		res, err := client.ListProjects(ctx, api.WithAuthentication(connect.NewRequest(req), token))
+		if err != nil {
+			return nil, err
+		}
+		return res.Msg, nil
+	}
+}
+
+func findProject(projects *cliv1beta1.ListProjectsResponse, projectID string) *cliv1beta1.ListProjectsResponse_Project {
+	for _, p := range projects.Projects {
+		if p.Id == projectID {
+			return p
+		}
+	}
+	return nil
+}
+
 func ProjectExists(ctx context.Context, token, projectID string) (*SelectedProject, error) {
 	client := api.NewProjectsClient()
-	req := cliv1beta1.ListProjectsRequest{}
-	projects, err := client.ListProjects(ctx, api.WithAuthentication(connect.NewRequest(&req), token))
+	projects, err := ListProjects(ctx, token, client)
 	if err != nil {
 		return nil, err
 	}
 
 	// In the case that the user specified a project id on the command line with `--project`,
 	// we check to see if the project exists.  If it does not, we return an error.
-	var selectedProject *cliv1beta1.ListProjectsResponse_Project
-	for _, p := range projects.Msg.Projects {
-		if p.Id == projectID {
-			selectedProject = p
-			break
+	selectedProject := findProject(projects, projectID)
+	if selectedProject == nil {
+		// The project may be newer than the cached projects.
+		projects, err = RefreshProjects(ctx, token, client)
+		if err != nil {
+			return nil, err
 		}
+		selectedProject = findProject(projects, projectID)
 	}
 
 	if selectedProject == nil {
@@ -153,22 +189,19 @@ func ProjectExists(ctx context.Context, token, projectID string) (*SelectedProje
 }
 
 func InitializeProject(ctx context.Context, token, projectID string) (*SelectedProject, error) {
-	client := api.NewProjectsClient()
-
-	req := cliv1beta1.ListProjectsRequest{}
-	projects, err := client.ListProjects(ctx, api.WithAuthentication(connect.NewRequest(&req), token))
+	projects, err := ListProjects(ctx, token, api.NewProjectsClient())
 	if err != nil {
 		return nil, err
 	}
 
-	if len(projects.Msg.Projects) == 0 {
+	if len(projects.Projects) == 0 {
 		return nil, fmt.Errorf("No projects found. Please create a project first.")
 	}
 
 	// If we're not in a terminal, just print the projects and exit as we need
 	// user intervention to pick a project.
 	if !IsTerminal() {
-		err := printProjectsCSV(projects.Msg.Projects)
+		err := printProjectsCSV(projects.Projects)
 		if err != nil {
 			return nil, err
 		}
@@ -176,7 +209,7 @@ func InitializeProject(ctx context.Context, token, projectID string) (*SelectedP
 	}
 
 	if projectID == "" {
-		projectID, err = chooseProjectID(projects.Msg)
+		projectID, err = chooseProjectID(projects)
 		if err != nil {
 			return nil, fmt.Errorf("No project selected; please run `depot init`")
 		}
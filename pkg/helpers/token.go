@@ -7,6 +7,7 @@ import (
 
 	"github.com/depot/cli/pkg/api"
 	"github.com/depot/cli/pkg/config"
+	"github.com/depot/cli/pkg/debuglog"
 	"github.com/depot/cli/pkg/oidc"
 )
 
@@ -21,17 +22,14 @@ func ResolveToken(ctx context.Context, token string) (string, error) {
 
 	if token == "" {
 		var err error
-		debug := os.Getenv("DEPOT_DEBUG_OIDC") != ""
 
 		for _, provider := range oidc.Providers {
-			if debug {
-				fmt.Printf("Trying OIDC provider %s\n", provider.Name())
-			}
+			debuglog.Log("Trying OIDC provider %s", provider.Name())
 
 			token, err = provider.RetrieveToken(ctx)
 
-			if err != nil && debug {
-				fmt.Printf("OIDC provider %s failed: %v\n", provider.Name(), err)
+			if err != nil {
+				debuglog.Log("OIDC provider %s failed: %v", provider.Name(), err)
 			}
 
 			if token != "" {
@@ -0,0 +1,238 @@
+// Package imagediff compares the layers, configs, files, and packages of two
+// images.
+package imagediff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	StatusUnchanged = "unchanged"
+	StatusAdded     = "added"
+	StatusRemoved   = "removed"
+	StatusModified  = "modified"
+)
+
+// Image is the content of an image that is compared.
+type Image struct {
+	Manifest ocispecs.Manifest
+	Config   ocispecs.Image
+	// Files is the filesystem of the image, or nil if it was not read.
+	Files map[string]File
+	// Packages maps the names of the packages in the SBOM of the image to
+	// their versions, or is nil if the image has no SBOM.
+	Packages map[string][]string
+}
+
+// Diff are the differences between image A and image B.
+type Diff struct {
+	Layers    []LayerChange  `json:"layers"`
+	SizeDelta int64          `json:"sizeDelta"`
+	Config    []ConfigChange `json:"config,omitempty"`
+	// Files is nil if the files of either image were not read.
+	Files []FileChange `json:"files"`
+	// Packages is nil if either image has no SBOM.
+	Packages []PackageChange `json:"packages"`
+}
+
+// LayerChange compares the layers of both images at the same index.
+type LayerChange struct {
+	Index   int           `json:"index"`
+	Status  string        `json:"status"`
+	DigestA digest.Digest `json:"digestA,omitempty"`
+	DigestB digest.Digest `json:"digestB,omitempty"`
+	SizeA   int64         `json:"sizeA"`
+	SizeB   int64         `json:"sizeB"`
+}
+
+// ConfigChange is a config field such as an environment variable that differs.
+type ConfigChange struct {
+	Field string `json:"field"`
+	A     string `json:"a,omitempty"`
+	B     string `json:"b,omitempty"`
+}
+
+// FileChange is a file that was added, removed, or modified.
+type FileChange struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+	SizeA  int64  `json:"sizeA"`
+	SizeB  int64  `json:"sizeB"`
+}
+
+// PackageChange is a package that was added, removed, or changed version.
+type PackageChange struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	VersionA string `json:"versionA,omitempty"`
+	VersionB string `json:"versionB,omitempty"`
+}
+
+// Compare returns the differences between image a and image b.
+func Compare(a, b *Image) *Diff {
+	diff := &Diff{
+		Layers: compareLayers(a.Manifest.Layers, b.Manifest.Layers),
+		Config: compareConfigs(a.Config.Config, b.Config.Config),
+	}
+	for _, layer := range diff.Layers {
+		diff.SizeDelta += layer.SizeB - layer.SizeA
+	}
+	if a.Files != nil && b.Files != nil {
+		diff.Files = compareFiles(a.Files, b.Files)
+	}
+	if a.Packages != nil && b.Packages != nil {
+		diff.Packages = comparePackages(a.Packages, b.Packages)
+	}
+	return diff
+}
+
+func compareLayers(a, b []ocispecs.Descriptor) []LayerChange {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	changes := make([]LayerChange, 0, n)
+	for i := 0; i < n; i++ {
+		change := LayerChange{Index: i}
+		switch {
+		case i >= len(a):
+			change.Status = StatusAdded
+		case i >= len(b):
+			change.Status = StatusRemoved
+		case a[i].Digest == b[i].Digest:
+			change.Status = StatusUnchanged
+		default:
+			change.Status = StatusModified
+		}
+		if i < len(a) {
+			change.DigestA, change.SizeA = a[i].Digest, a[i].Size
+		}
+		if i < len(b) {
+			change.DigestB, change.SizeB = b[i].Digest, b[i].Size
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func compareConfigs(a, b ocispecs.ImageConfig) []ConfigChange {
+	var changes []ConfigChange
+	field := func(name, va, vb string) {
+		if va != vb {
+			changes = append(changes, ConfigChange{Field: name, A: va, B: vb})
+		}
+	}
+
+	field("User", a.User, b.User)
+	field("WorkingDir", a.WorkingDir, b.WorkingDir)
+	field("Entrypoint", quoteList(a.Entrypoint), quoteList(b.Entrypoint))
+	field("Cmd", quoteList(a.Cmd), quoteList(b.Cmd))
+	field("StopSignal", a.StopSignal, b.StopSignal)
+
+	envA, envB := envMap(a.Env), envMap(b.Env)
+	for _, name := range unionKeys(envA, envB) {
+		field("Env."+name, envA[name], envB[name])
+	}
+	for _, name := range unionKeys(a.Labels, b.Labels) {
+		field("Labels."+name, a.Labels[name], b.Labels[name])
+	}
+	for _, port := range unionKeys(a.ExposedPorts, b.ExposedPorts) {
+		_, inA := a.ExposedPorts[port]
+		_, inB := b.ExposedPorts[port]
+		field("ExposedPorts."+port, present(inA), present(inB))
+	}
+	for _, volume := range unionKeys(a.Volumes, b.Volumes) {
+		_, inA := a.Volumes[volume]
+		_, inB := b.Volumes[volume]
+		field("Volumes."+volume, present(inA), present(inB))
+	}
+	return changes
+}
+
+func compareFiles(a, b map[string]File) []FileChange {
+	changes := []FileChange{}
+	for _, path := range unionKeys(a, b) {
+		fa, inA := a[path]
+		fb, inB := b[path]
+		change := FileChange{Path: path, SizeA: fa.Size, SizeB: fb.Size}
+		switch {
+		case !inA:
+			change.Status = StatusAdded
+		case !inB:
+			change.Status = StatusRemoved
+		case fa != fb:
+			change.Status = StatusModified
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func comparePackages(a, b map[string][]string) []PackageChange {
+	changes := []PackageChange{}
+	for _, name := range unionKeys(a, b) {
+		va, inA := a[name]
+		vb, inB := b[name]
+		change := PackageChange{Name: name, VersionA: strings.Join(va, ", "), VersionB: strings.Join(vb, ", ")}
+		switch {
+		case !inA:
+			change.Status = StatusAdded
+		case !inB:
+			change.Status = StatusRemoved
+		case change.VersionA != change.VersionB:
+			change.Status = StatusModified
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func envMap(env []string) map[string]string {
+	m := make(map[string]string, len(env))
+	for _, kv := range env {
+		k, v, _ := strings.Cut(kv, "=")
+		m[k] = v
+	}
+	return m
+}
+
+func quoteList(list []string) string {
+	if list == nil {
+		return ""
+	}
+	quoted := make([]string, len(list))
+	for i, s := range list {
+		quoted[i] = fmt.Sprintf("%q", s)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func present(ok bool) string {
+	if ok {
+		return "yes"
+	}
+	return ""
+}
+
+func unionKeys[A, B any](a map[string]A, b map[string]B) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,177 @@
+package imagediff
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+type tarEntry struct {
+	name    string
+	content string
+	dir     bool
+}
+
+func layerTarball(t *testing.T, entries ...tarEntry) *bytes.Buffer {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0o644, Size: int64(len(e.content)), Typeflag: tar.TypeReg}
+		if e.dir {
+			hdr = &tar.Header{Name: e.name, Mode: 0o755, Typeflag: tar.TypeDir}
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestApplyLayer(t *testing.T) {
+	files := map[string]File{}
+	apply := func(entries ...tarEntry) {
+		t.Helper()
+		if err := ApplyLayer(files, layerTarball(t, entries...), ocispecs.MediaTypeImageLayerGzip); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	apply(
+		tarEntry{name: "app/", dir: true},
+		tarEntry{name: "app/main", content: "v1"},
+		tarEntry{name: "app/old", content: "old"},
+		tarEntry{name: "cache/", dir: true},
+		tarEntry{name: "cache/a", content: "a"},
+	)
+	apply(
+		tarEntry{name: "app/main", content: "v2!"},
+		tarEntry{name: "app/.wh.old"},
+		tarEntry{name: "cache/.wh..wh..opq"},
+		tarEntry{name: "cache/b", content: "b"},
+	)
+
+	for _, name := range []string{"/app/old", "/cache/a"} {
+		if _, ok := files[name]; ok {
+			t.Errorf("expected %s to be removed", name)
+		}
+	}
+	if f := files["/app/main"]; f.Size != 3 || f.Digest != digest.FromString("v2!") {
+		t.Errorf("unexpected /app/main: %+v", f)
+	}
+	if _, ok := files["/cache/b"]; !ok {
+		t.Errorf("expected /cache/b to survive the opaque whiteout of its own layer")
+	}
+	if _, ok := files["/cache"]; !ok {
+		t.Errorf("expected the opaque directory to remain")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a := &Image{
+		Manifest: ocispecs.Manifest{Layers: []ocispecs.Descriptor{
+			{Digest: digest.FromString("base"), Size: 100},
+			{Digest: digest.FromString("app1"), Size: 10},
+		}},
+		Config: ocispecs.Image{Config: ocispecs.ImageConfig{
+			Env:        []string{"PATH=/bin", "VERSION=1"},
+			Entrypoint: []string{"/app/main"},
+		}},
+		Files: map[string]File{
+			"/app/main": {Size: 2, Digest: digest.FromString("v1")},
+			"/app/old":  {Size: 3},
+		},
+		Packages: map[string][]string{"openssl": {"3.0.1"}, "zlib": {"1.2"}},
+	}
+	b := &Image{
+		Manifest: ocispecs.Manifest{Layers: []ocispecs.Descriptor{
+			{Digest: digest.FromString("base"), Size: 100},
+			{Digest: digest.FromString("app2"), Size: 15},
+			{Digest: digest.FromString("extra"), Size: 5},
+		}},
+		Config: ocispecs.Image{Config: ocispecs.ImageConfig{
+			Env:        []string{"PATH=/bin", "VERSION=2"},
+			Entrypoint: []string{"/app/main", "serve"},
+		}},
+		Files: map[string]File{
+			"/app/main": {Size: 3, Digest: digest.FromString("v2!")},
+			"/app/new":  {Size: 1},
+		},
+		Packages: map[string][]string{"openssl": {"3.0.2"}, "curl": {"8.0"}},
+	}
+
+	diff := Compare(a, b)
+
+	statuses := []string{StatusUnchanged, StatusModified, StatusAdded}
+	if len(diff.Layers) != len(statuses) {
+		t.Fatalf("expected %d layers, got %+v", len(statuses), diff.Layers)
+	}
+	for i, status := range statuses {
+		if diff.Layers[i].Status != status {
+			t.Errorf("layer %d: expected %s, got %s", i, status, diff.Layers[i].Status)
+		}
+	}
+	if diff.SizeDelta != 10 {
+		t.Errorf("expected a size delta of 10, got %d", diff.SizeDelta)
+	}
+
+	config := map[string]ConfigChange{}
+	for _, change := range diff.Config {
+		config[change.Field] = change
+	}
+	if len(config) != 2 || config["Env.VERSION"].A != "1" || config["Env.VERSION"].B != "2" || config["Entrypoint"].B != `["/app/main", "serve"]` {
+		t.Errorf("unexpected config changes: %+v", diff.Config)
+	}
+
+	files := map[string]string{}
+	for _, change := range diff.Files {
+		files[change.Path] = change.Status
+	}
+	if len(files) != 3 || files["/app/main"] != StatusModified || files["/app/old"] != StatusRemoved || files["/app/new"] != StatusAdded {
+		t.Errorf("unexpected file changes: %+v", diff.Files)
+	}
+
+	packages := map[string]string{}
+	for _, change := range diff.Packages {
+		packages[change.Name] = change.Status
+	}
+	if len(packages) != 3 || packages["openssl"] != StatusModified || packages["zlib"] != StatusRemoved || packages["curl"] != StatusAdded {
+		t.Errorf("unexpected package changes: %+v", diff.Packages)
+	}
+
+	b.Packages = nil
+	if diff := Compare(a, b); diff.Packages != nil {
+		t.Errorf("expected packages not to be compared without an SBOM, got %+v", diff.Packages)
+	}
+}
+
+func TestReadPackages(t *testing.T) {
+	statement := `{"predicateType":"https://spdx.dev/Document","predicate":{"packages":[
+		{"name":"openssl","versionInfo":"3.0.2"},
+		{"name":"openssl","versionInfo":"3.0.2"},
+		{"name":"musl","versionInfo":"1.2.3"}
+	]}}`
+	packages := map[string][]string{"openssl": {"1.1.1"}}
+	if err := ReadPackages(packages, []byte(statement), "application/vnd.in-toto+json"); err != nil {
+		t.Fatal(err)
+	}
+	if got := packages["openssl"]; len(got) != 2 || got[0] != "1.1.1" || got[1] != "3.0.2" {
+		t.Errorf("unexpected openssl versions: %v", got)
+	}
+	if got := packages["musl"]; len(got) != 1 || got[0] != "1.2.3" {
+		t.Errorf("unexpected musl versions: %v", got)
+	}
+}
@@ -0,0 +1,106 @@
+package imagediff
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/opencontainers/go-digest"
+)
+
+const (
+	whiteoutPrefix = ".wh."
+	whiteoutOpaque = ".wh..wh..opq"
+)
+
+// File is an entry of an image filesystem.
+type File struct {
+	Size     int64         `json:"size"`
+	Mode     fs.FileMode   `json:"mode"`
+	Digest   digest.Digest `json:"digest,omitempty"`
+	Linkname string        `json:"linkname,omitempty"`
+}
+
+// ApplyLayer applies a layer tarball with the media type to the files of
+// the layers below it, honoring whiteouts.
+func ApplyLayer(files map[string]File, r io.Reader, mediaType string) error {
+	switch {
+	case strings.HasSuffix(mediaType, "gzip"):
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	case strings.HasSuffix(mediaType, "zstd"):
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		r = zr
+	case strings.HasSuffix(mediaType, ".tar"):
+	default:
+		return fmt.Errorf("unsupported layer media type %s", mediaType)
+	}
+
+	// Whiteouts only remove the files of lower layers.
+	layer := map[string]File{}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		name := path.Clean("/" + hdr.Name)
+		dir, base := path.Split(name)
+		switch {
+		case base == whiteoutOpaque:
+			removeTree(files, path.Clean(dir), false)
+		case strings.HasPrefix(base, whiteoutPrefix):
+			removeTree(files, path.Join(dir, strings.TrimPrefix(base, whiteoutPrefix)), true)
+		default:
+			f := File{Size: hdr.Size, Mode: hdr.FileInfo().Mode(), Linkname: hdr.Linkname}
+			if hdr.Typeflag == tar.TypeReg {
+				h := sha256.New()
+				if _, err := io.Copy(h, tr); err != nil {
+					return err
+				}
+				f.Digest = digest.NewDigest(digest.SHA256, h)
+			}
+			if f.Mode.IsDir() {
+				f.Size = 0
+			}
+			layer[name] = f
+		}
+	}
+
+	for name, f := range layer {
+		files[name] = f
+	}
+	return nil
+}
+
+// removeTree removes the files below dir, and dir itself unless it is the
+// directory of an opaque whiteout.
+func removeTree(files map[string]File, dir string, self bool) {
+	if self {
+		delete(files, dir)
+	}
+	prefix := strings.TrimSuffix(dir, "/") + "/"
+	for name := range files {
+		if strings.HasPrefix(name, prefix) {
+			delete(files, name)
+		}
+	}
+}
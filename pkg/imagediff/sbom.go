@@ -0,0 +1,61 @@
+package imagediff
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// PredicateSPDX is the in-toto predicate type of SPDX SBOM attestations.
+	PredicateSPDX = "https://spdx.dev/Document"
+
+	annotationPredicate = "in-toto.io/predicate-type"
+)
+
+// IsSPDXLayer reports whether an attestation layer is an SPDX SBOM.
+func IsSPDXLayer(annotations map[string]string) bool {
+	return annotations[annotationPredicate] == PredicateSPDX
+}
+
+// ReadPackages adds the packages of an SPDX in-toto statement, which may be
+// wrapped in a DSSE envelope, to the versions of each package name.
+func ReadPackages(packages map[string][]string, dt []byte, mediaType string) error {
+	if strings.HasSuffix(mediaType, "+dsse") {
+		var envelope struct {
+			Payload string `json:"payload"`
+		}
+		if err := json.Unmarshal(dt, &envelope); err != nil {
+			return err
+		}
+		payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+		if err != nil {
+			return err
+		}
+		dt = payload
+	}
+
+	var statement struct {
+		Predicate struct {
+			Packages []struct {
+				Name        string `json:"name"`
+				VersionInfo string `json:"versionInfo"`
+			} `json:"packages"`
+		} `json:"predicate"`
+	}
+	if err := json.Unmarshal(dt, &statement); err != nil {
+		return err
+	}
+
+	for _, pkg := range statement.Predicate.Packages {
+		if pkg.Name == "" || slices.Contains(packages[pkg.Name], pkg.VersionInfo) {
+			continue
+		}
+		packages[pkg.Name] = append(packages[pkg.Name], pkg.VersionInfo)
+		sort.Strings(packages[pkg.Name])
+	}
+	return nil
+}
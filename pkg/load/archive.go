@@ -0,0 +1,175 @@
+package load
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	contentv1 "github.com/containerd/containerd/api/services/content/v1"
+	"github.com/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// archiveImage is an image written to an archive, with its layers read from
+// the content store of the builder that built it.
+type archiveImage struct {
+	names    []string
+	manifest []byte
+	config   []byte
+	readBlob func(ctx context.Context, dgst digest.Digest, w io.Writer) error
+}
+
+// dockerManifest is an entry of the manifest.json of a docker archive.
+type dockerManifest struct {
+	Config   string
+	RepoTags []string
+	Layers   []string
+}
+
+// writeArchive writes the images as an archive that is both an OCI image
+// layout, read by "ctr images import", and a docker archive, read by
+// "minikube image load".  The layers are streamed from the builders, so the
+// images are never stored locally.
+func writeArchive(ctx context.Context, w io.Writer, images []archiveImage) error {
+	tw := tar.NewWriter(w)
+
+	index := ocispecs.Index{MediaType: ocispecs.MediaTypeImageIndex, Manifests: []ocispecs.Descriptor{}}
+	index.SchemaVersion = 2
+	dockerManifests := []dockerManifest{}
+	written := map[digest.Digest]bool{}
+
+	writeBlob := func(dgst digest.Digest, size int64, write func(io.Writer) error) error {
+		if written[dgst] {
+			return nil
+		}
+		written[dgst] = true
+		if err := tw.WriteHeader(&tar.Header{Name: blobPath(dgst), Mode: 0444, Size: size, Typeflag: tar.TypeReg}); err != nil {
+			return err
+		}
+		return write(tw)
+	}
+	writeBytes := func(data []byte) error {
+		return writeBlob(digest.FromBytes(data), int64(len(data)), func(w io.Writer) error {
+			_, err := w.Write(data)
+			return err
+		})
+	}
+
+	for _, image := range images {
+		var manifest ocispecs.Manifest
+		if err := json.Unmarshal(image.manifest, &manifest); err != nil {
+			return fmt.Errorf("invalid image manifest: %w", err)
+		}
+		mediaType := manifest.MediaType
+		if mediaType == "" {
+			mediaType = ocispecs.MediaTypeImageManifest
+		}
+
+		if err := writeBytes(image.manifest); err != nil {
+			return err
+		}
+		if err := writeBytes(image.config); err != nil {
+			return err
+		}
+		layers := make([]string, 0, len(manifest.Layers))
+		for _, layer := range manifest.Layers {
+			layer := layer
+			err := writeBlob(layer.Digest, layer.Size, func(w io.Writer) error {
+				return image.readBlob(ctx, layer.Digest, w)
+			})
+			if err != nil {
+				return fmt.Errorf("unable to read layer %s: %w", layer.Digest, err)
+			}
+			layers = append(layers, blobPath(layer.Digest))
+		}
+
+		manifestDesc := ocispecs.Descriptor{
+			MediaType: mediaType,
+			Digest:    digest.FromBytes(image.manifest),
+			Size:      int64(len(image.manifest)),
+		}
+		repoTags := make([]string, 0, len(image.names))
+		for _, name := range image.names {
+			named, err := reference.ParseNormalizedNamed(name)
+			if err != nil {
+				return fmt.Errorf("invalid image name %s: %w", name, err)
+			}
+			named = reference.TagNameOnly(named)
+			desc := manifestDesc
+			desc.Annotations = map[string]string{
+				"io.containerd.image.name": named.String(),
+			}
+			if tagged, ok := named.(reference.Tagged); ok {
+				desc.Annotations[ocispecs.AnnotationRefName] = tagged.Tag()
+			}
+			index.Manifests = append(index.Manifests, desc)
+			repoTags = append(repoTags, reference.FamiliarString(named))
+		}
+		dockerManifests = append(dockerManifests, dockerManifest{
+			Config:   blobPath(digest.FromBytes(image.config)),
+			RepoTags: repoTags,
+			Layers:   layers,
+		})
+	}
+
+	layout, err := json.Marshal(ocispecs.ImageLayout{Version: ocispecs.ImageLayoutVersion})
+	if err != nil {
+		return err
+	}
+	rawIndex, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	rawDockerManifests, err := json.Marshal(dockerManifests)
+	if err != nil {
+		return err
+	}
+	for _, file := range []struct {
+		name string
+		data []byte
+	}{
+		{ocispecs.ImageLayoutFile, layout},
+		{"index.json", rawIndex},
+		{"manifest.json", rawDockerManifests},
+	} {
+		if err := tw.WriteHeader(&tar.Header{Name: file.name, Mode: 0444, Size: int64(len(file.data)), Typeflag: tar.TypeReg}); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, bytes.NewReader(file.data)); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func blobPath(dgst digest.Digest) string {
+	return path.Join("blobs", dgst.Algorithm().String(), dgst.Encoded())
+}
+
+// readContent copies a blob from the content store of a builder.
+func readContent(client contentv1.ContentClient) func(ctx context.Context, dgst digest.Digest, w io.Writer) error {
+	return func(ctx context.Context, dgst digest.Digest, w io.Writer) error {
+		r, err := client.Read(ctx, &contentv1.ReadContentRequest{Digest: dgst})
+		if err != nil {
+			return err
+		}
+		for {
+			resp, err := r.Recv()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(resp.Data); err != nil {
+				return err
+			}
+		}
+	}
+}
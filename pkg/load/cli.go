@@ -15,6 +15,7 @@ type DepotLoadOptions struct {
 	BuildID      string // Depot build ID; used to tag images.
 	IsBake       bool   // If run from bake, we add the bake target to the image tag.
 	ProgressMode string // ProgressMode quiet will not print progress.
+	Platform     string // If set, load this platform of a multi-platform build rather than the host's.
 }
 
 // Options to download from the Depot hosted registry and tag the image with the user provide tag.
@@ -69,6 +70,10 @@ func WithDepotImagePull(buildOpts map[string]build.Options, loadOpts DepotLoadOp
 				UserTags: userTags,
 				Quiet:    loadOpts.ProgressMode == progress.PrinterModeQuiet,
 			}
+			if loadOpts.Platform != "" {
+				platform := loadOpts.Platform
+				pullOpt.Platform = &platform
+			}
 			toPull[target] = pullOpt
 		}
 	}
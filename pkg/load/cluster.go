@@ -0,0 +1,210 @@
+package load
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	depotbuild "github.com/depot/cli/pkg/buildx/build"
+	"github.com/docker/buildx/util/progress"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	docker "github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// Cluster is a local Kubernetes cluster that loaded images are copied into.
+type Cluster struct {
+	Provider string // kind, k3d, or minikube.
+	Name     string
+}
+
+var defaultClusterNames = map[string]string{
+	"kind":     "kind",
+	"k3d":      "k3s-default",
+	"minikube": "minikube",
+}
+
+// ParseCluster parses a cluster in the format "provider[:name]".
+func ParseCluster(s string) (Cluster, error) {
+	provider, name, _ := strings.Cut(s, ":")
+	defaultName, ok := defaultClusterNames[provider]
+	if !ok {
+		return Cluster{}, fmt.Errorf("unsupported cluster %q (expected kind, k3d, or minikube, e.g., kind:mycluster)", s)
+	}
+	if name == "" {
+		name = defaultName
+	}
+	return Cluster{Provider: provider, Name: name}, nil
+}
+
+func (c Cluster) String() string {
+	return c.Provider + ":" + c.Name
+}
+
+// LoadIntoCluster imports the loaded image of every target into the container
+// runtime of each cluster node.  The images are streamed from the content
+// stores of the builders, which must still hold them.  Targets without tags
+// are reported and not imported, as the cluster could not refer to them.
+func LoadIntoCluster(ctx context.Context, dockerapi docker.APIClient, cluster Cluster, resp []depotbuild.DepotBuildResponse, pullOpts map[string]PullOptions, w progress.Writer) error {
+	var (
+		images     []archiveImage
+		imageNames []string
+	)
+	for _, buildRes := range resp {
+		pullOpt, ok := pullOpts[buildRes.Name]
+		if !ok {
+			continue
+		}
+		if len(pullOpt.UserTags) == 0 {
+			progress.Write(w, fmt.Sprintf("[cluster] skipping %s, which has no tags", buildRes.Name), func() error { return nil })
+			continue
+		}
+		image, err := clusterImage(ctx, buildRes.NodeResponses, pullOpt)
+		if err != nil {
+			return fmt.Errorf("unable to read the image of %s: %w", buildRes.Name, err)
+		}
+		images = append(images, image)
+		imageNames = append(imageNames, pullOpt.UserTags...)
+	}
+	if len(images) == 0 {
+		return nil
+	}
+
+	return progress.Wrap(fmt.Sprintf("[cluster] loading %s into %s", strings.Join(imageNames, ","), cluster), w.Write, func(logger progress.SubLogger) error {
+		switch cluster.Provider {
+		case "kind":
+			return importIntoNodes(ctx, dockerapi, "io.x-k8s.kind.cluster="+cluster.Name, images,
+				[]string{"ctr", "--namespace=k8s.io", "images", "import", "--all-platforms", "--digests", "--snapshotter=overlayfs", "-"})
+		case "k3d":
+			return importIntoNodes(ctx, dockerapi, "k3d.cluster="+cluster.Name, images,
+				[]string{"ctr", "--namespace=k8s.io", "images", "import", "--all-platforms", "-"})
+		default:
+			return importIntoMinikube(ctx, cluster.Name, images)
+		}
+	})
+}
+
+// clusterImage is the image of the target for the host platform, or the
+// --load-platform, read from the builder that built it.
+func clusterImage(ctx context.Context, nodeResponses []depotbuild.DepotNodeResponse, pullOpt PullOptions) (archiveImage, error) {
+	nodeRes, manifest, config, err := chooseImage(nodeResponses, pullOpt)
+	if err != nil {
+		return archiveImage{}, err
+	}
+	if nodeRes.Node.Driver == nil {
+		return archiveImage{}, fmt.Errorf("node %s does not have a driver", nodeRes.Node.Name)
+	}
+	client, err := nodeRes.Node.Driver.Client(ctx)
+	if err != nil {
+		return archiveImage{}, err
+	}
+	return archiveImage{
+		names:    pullOpt.UserTags,
+		manifest: manifest,
+		config:   config,
+		readBlob: readContent(client.ContentClient()),
+	}, nil
+}
+
+// importIntoMinikube loads the archive with the minikube CLI, as minikube
+// supports several drivers and container runtimes.
+func importIntoMinikube(ctx context.Context, profile string, images []archiveImage) error {
+	f, err := os.CreateTemp("", "depot-minikube-*.tar")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := writeArchive(ctx, f, images); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	output, err := exec.CommandContext(ctx, "minikube", "image", "load", "--profile", profile, f.Name()).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("minikube image load failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// importIntoNodes streams the images into every node container matching the
+// label.
+func importIntoNodes(ctx context.Context, dockerapi docker.APIClient, label string, images []archiveImage, importCmd []string) error {
+	nodes, err := dockerapi.ContainerList(ctx, types.ContainerListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", label)),
+	})
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return fmt.Errorf("no running nodes found for cluster (label %s)", label)
+	}
+
+	for _, node := range nodes {
+		// Load balancers and registries share the cluster label but do not run kubelet.
+		if strings.HasPrefix(node.Labels["k3d.role"], "loadbalancer") || node.Labels["k3d.role"] == "registry" {
+			continue
+		}
+		if err := importIntoNode(ctx, dockerapi, node.ID, images, importCmd); err != nil {
+			return fmt.Errorf("unable to load images into node %s: %w", strings.TrimPrefix(firstName(node.Names), "/"), err)
+		}
+	}
+	return nil
+}
+
+func importIntoNode(ctx context.Context, dockerapi docker.APIClient, containerID string, images []archiveImage, importCmd []string) error {
+	execID, err := dockerapi.ContainerExecCreate(ctx, containerID, types.ExecConfig{
+		Cmd:          importCmd,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	attached, err := dockerapi.ContainerExecAttach(ctx, execID.ID, types.ExecStartCheck{})
+	if err != nil {
+		return err
+	}
+	defer attached.Close()
+
+	var output bytes.Buffer
+	outputDone := make(chan error, 1)
+	go func() {
+		_, err := stdcopy.StdCopy(&output, &output, attached.Reader)
+		outputDone <- err
+	}()
+
+	if err := writeArchive(ctx, attached.Conn, images); err != nil {
+		return err
+	}
+	if err := attached.CloseWrite(); err != nil {
+		return err
+	}
+	if err := <-outputDone; err != nil {
+		return err
+	}
+
+	inspect, err := dockerapi.ContainerExecInspect(ctx, execID.ID)
+	if err != nil {
+		return err
+	}
+	if inspect.ExitCode != 0 {
+		return fmt.Errorf("%s exited with %d: %s", importCmd[0], inspect.ExitCode, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
+
+func firstName(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	return names[0]
+}
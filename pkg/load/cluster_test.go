@@ -0,0 +1,114 @@
+package load
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestParseCluster(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Cluster
+		wantErr bool
+	}{
+		{in: "kind", want: Cluster{Provider: "kind", Name: "kind"}},
+		{in: "kind:dev", want: Cluster{Provider: "kind", Name: "dev"}},
+		{in: "k3d", want: Cluster{Provider: "k3d", Name: "k3s-default"}},
+		{in: "minikube:", want: Cluster{Provider: "minikube", Name: "minikube"}},
+		{in: "eks:prod", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseCluster(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCluster(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseCluster(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriteArchive(t *testing.T) {
+	layer := []byte("layer")
+	config := []byte(`{"architecture":"amd64","os":"linux"}`)
+	manifest, err := json.Marshal(ocispecs.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispecs.MediaTypeImageManifest,
+		Config:    ocispecs.Descriptor{MediaType: ocispecs.MediaTypeImageConfig, Digest: digest.FromBytes(config), Size: int64(len(config))},
+		Layers:    []ocispecs.Descriptor{{MediaType: ocispecs.MediaTypeImageLayerGzip, Digest: digest.FromBytes(layer), Size: int64(len(layer))}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	readBlob := func(ctx context.Context, dgst digest.Digest, w io.Writer) error {
+		if dgst != digest.FromBytes(layer) {
+			return fmt.Errorf("unexpected blob %s", dgst)
+		}
+		_, err := w.Write(layer)
+		return err
+	}
+
+	var buf bytes.Buffer
+	images := []archiveImage{
+		{names: []string{"api", "ghcr.io/org/api:v1"}, manifest: manifest, config: config, readBlob: readBlob},
+		{names: []string{"worker:dev"}, manifest: manifest, config: config, readBlob: readBlob},
+	}
+	if err := writeArchive(context.Background(), &buf, images); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string][]byte{}
+	tr := tar.NewReader(&buf)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := files[hdr.Name]; ok {
+			t.Errorf("%s is written twice", hdr.Name)
+		}
+		files[hdr.Name], _ = io.ReadAll(tr)
+	}
+
+	if got := string(files[blobPath(digest.FromBytes(layer))]); got != "layer" {
+		t.Errorf("layer = %q, want %q", got, "layer")
+	}
+	if _, ok := files[blobPath(digest.FromBytes(manifest))]; !ok {
+		t.Errorf("manifest is missing")
+	}
+
+	var index ocispecs.Index
+	if err := json.Unmarshal(files["index.json"], &index); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, desc := range index.Manifests {
+		names = append(names, desc.Annotations["io.containerd.image.name"])
+	}
+	want := "docker.io/library/api:latest,ghcr.io/org/api:v1,docker.io/library/worker:dev"
+	if strings.Join(names, ",") != want {
+		t.Errorf("names = %v, want %s", names, want)
+	}
+
+	var dockerManifests []dockerManifest
+	if err := json.Unmarshal(files["manifest.json"], &dockerManifests); err != nil {
+		t.Fatal(err)
+	}
+	if len(dockerManifests) != 2 || strings.Join(dockerManifests[0].RepoTags, ",") != "api:latest,ghcr.io/org/api:v1" {
+		t.Errorf("docker manifests = %+v", dockerManifests)
+	}
+}
@@ -0,0 +1,169 @@
+package load
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/containerd/containerd/images"
+	depotbuild "github.com/depot/cli/pkg/buildx/build"
+	"github.com/depot/cli/pkg/debuglog"
+	docker "github.com/docker/docker/client"
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// containerdSnapshotter is the docker driver type of the containerd image store.
+const containerdSnapshotter = "io.containerd.snapshotter.v1"
+
+// The annotations buildkit marks the attestation manifests of an index with.
+const (
+	attestationReferenceType   = "vnd.docker.reference.type"
+	attestationReferenceDigest = "vnd.docker.reference.digest"
+	attestationManifestType    = "attestation-manifest"
+)
+
+// IsContainerdImageStore reports whether the docker daemon stores images with
+// the containerd snapshotter.  Unlike the classic image store, it keeps
+// multi-platform image indexes.
+func IsContainerdImageStore(ctx context.Context, dockerapi docker.APIClient) bool {
+	info, err := dockerapi.Info(ctx)
+	if err != nil {
+		return false
+	}
+
+	for _, status := range info.DriverStatus {
+		if len(status) == 2 && status[0] == "driver-type" && status[1] == containerdSnapshotter {
+			return true
+		}
+	}
+	return false
+}
+
+// indexProxyConfig creates a proxy configuration serving an image index of
+// every platform image built by the nodes, and of their attestation manifests
+// so that provenance is kept locally.  Each node serves the layers of the
+// images it built.
+func indexProxyConfig(nodeResponses []depotbuild.DepotNodeResponse) (*ProxyConfig, error) {
+	var (
+		backends  []ProxyBackend
+		manifests []ocispecs.Descriptor
+	)
+	for _, nodeRes := range nodeResponses {
+		encodedExportedImages, err := EncodedExportedImages(nodeRes.SolveResponse.ExporterResponse)
+		if err != nil {
+			return nil, err
+		}
+
+		exportedImages, imageManifests, imageConfigs, err := DecodeExportImages(encodedExportedImages)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			nodeImages   []RawExportedImage
+			platforms    []ocispecs.Descriptor
+			attestations []ocispecs.Descriptor
+			attested     []RawExportedImage
+		)
+		for i := range exportedImages {
+			mediaType := imageManifests[i].MediaType
+			if mediaType == "" {
+				mediaType = ocispecs.MediaTypeImageManifest
+			}
+			desc := ocispecs.Descriptor{
+				MediaType: mediaType,
+				Digest:    digest.FromBytes(exportedImages[i].Manifest),
+				Size:      int64(len(exportedImages[i].Manifest)),
+				Platform: &ocispecs.Platform{
+					OS:           imageConfigs[i].OS,
+					Architecture: imageConfigs[i].Architecture,
+					Variant:      imageConfigs[i].Variant,
+				},
+			}
+			if isAttestationManifest(imageManifests[i]) {
+				attestations = append(attestations, desc)
+				attested = append(attested, exportedImages[i])
+				continue
+			}
+			platforms = append(platforms, desc)
+			nodeImages = append(nodeImages, exportedImages[i])
+		}
+		manifests = append(manifests, platforms...)
+
+		// Like buildkit's index, the attestation manifests follow the
+		// platform images in the same order, which is the only way to tell
+		// the image each refers to.
+		if len(attestations) == len(platforms) {
+			for i, desc := range attestations {
+				desc.Annotations = map[string]string{
+					attestationReferenceType:   attestationManifestType,
+					attestationReferenceDigest: platforms[i].Digest.String(),
+				}
+				manifests = append(manifests, desc)
+			}
+			nodeImages = append(nodeImages, attested...)
+		} else if len(attestations) > 0 {
+			debuglog.Log("dropping %d attestation manifests of %d images on load", len(attestations), len(platforms))
+		}
+
+		if len(nodeImages) == 0 {
+			continue
+		}
+		backends = append(backends, ProxyBackend{
+			Addr:       nodeRes.Node.DriverOpts["addr"],
+			ServerName: nodeRes.Node.DriverOpts["serverName"],
+			CACert:     []byte(nodeRes.Node.DriverOpts["caCert"]),
+			Key:        []byte(nodeRes.Node.DriverOpts["key"]),
+			Cert:       []byte(nodeRes.Node.DriverOpts["cert"]),
+			Images:     nodeImages,
+		})
+	}
+
+	if len(manifests) == 0 {
+		return nil, fmt.Errorf("no images were exported")
+	}
+
+	indexMediaType := ocispecs.MediaTypeImageIndex
+	if manifests[0].MediaType == images.MediaTypeDockerSchema2Manifest {
+		indexMediaType = images.MediaTypeDockerSchema2ManifestList
+	}
+
+	rawIndex, err := json.Marshal(ocispecs.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: indexMediaType,
+		Manifests: manifests,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The first backend also serves the single image for older proxies.
+	first := backends[0]
+	return &ProxyConfig{
+		RawManifest: first.Images[0].Manifest,
+		RawConfig:   first.Images[0].Config,
+		Addr:        first.Addr,
+		ServerName:  first.ServerName,
+		CACert:      first.CACert,
+		Key:         first.Key,
+		Cert:        first.Cert,
+		RawIndex:    rawIndex,
+		Backends:    backends,
+	}, nil
+}
+
+// isAttestationManifest reports whether the manifest holds in-toto
+// attestations rather than the layers of an image.
+func isAttestationManifest(manifest ocispecs.Manifest) bool {
+	if len(manifest.Layers) == 0 {
+		return false
+	}
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != "application/vnd.in-toto+json" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package load
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	depotbuild "github.com/depot/cli/pkg/buildx/build"
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func exportedImage(t *testing.T, name, arch, layerType string) RawExportedImage {
+	t.Helper()
+	manifest, err := json.Marshal(ocispecs.Manifest{
+		MediaType: ocispecs.MediaTypeImageManifest,
+		Layers:    []ocispecs.Descriptor{{MediaType: layerType, Digest: digest.FromString(name)}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, err := json.Marshal(ocispecs.Image{OS: "linux", Architecture: arch})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return RawExportedImage{Manifest: manifest, Config: config}
+}
+
+func nodeResponse(t *testing.T, images ...RawExportedImage) depotbuild.DepotNodeResponse {
+	t.Helper()
+	data, err := json.Marshal(images)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return depotbuild.DepotNodeResponse{SolveResponse: &client.SolveResponse{
+		ExporterResponse: map[string]string{ImagesExported: base64.StdEncoding.EncodeToString(data)},
+	}}
+}
+
+func TestIndexProxyConfigKeepsAttestations(t *testing.T) {
+	amd64 := exportedImage(t, "amd64", "amd64", ocispecs.MediaTypeImageLayerGzip)
+	arm64 := exportedImage(t, "arm64", "arm64", ocispecs.MediaTypeImageLayerGzip)
+	amd64Attestation := exportedImage(t, "amd64 provenance", "unknown", "application/vnd.in-toto+json")
+	arm64Attestation := exportedImage(t, "arm64 provenance", "unknown", "application/vnd.in-toto+json")
+
+	cfg, err := indexProxyConfig([]depotbuild.DepotNodeResponse{
+		nodeResponse(t, amd64, arm64, amd64Attestation, arm64Attestation),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var index ocispecs.Index
+	if err := json.Unmarshal(cfg.RawIndex, &index); err != nil {
+		t.Fatal(err)
+	}
+	if len(index.Manifests) != 4 {
+		t.Fatalf("expected 4 manifests in the index, got %d", len(index.Manifests))
+	}
+	for i, subject := range []RawExportedImage{amd64, arm64} {
+		att := index.Manifests[2+i]
+		if got := att.Annotations[attestationReferenceType]; got != attestationManifestType {
+			t.Errorf("manifest %d has reference type %q, want %q", 2+i, got, attestationManifestType)
+		}
+		if got, want := att.Annotations[attestationReferenceDigest], digest.FromBytes(subject.Manifest).String(); got != want {
+			t.Errorf("attestation %d refers to %s, want %s", i, got, want)
+		}
+	}
+	if len(cfg.Backends[0].Images) != 4 {
+		t.Errorf("expected the backend to serve 4 images, got %d", len(cfg.Backends[0].Images))
+	}
+	if string(cfg.RawManifest) != string(amd64.Manifest) {
+		t.Errorf("expected the first platform image to be the default manifest")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/containerd/containerd/platforms"
 	depotbuild "github.com/depot/cli/pkg/buildx/build"
 	"github.com/docker/buildx/util/progress"
 	docker "github.com/docker/docker/client"
@@ -26,26 +27,18 @@ func DepotFastLoad(ctx context.Context, dockerapi docker.APIClient, resp []depot
 		return nil
 	}
 
+	// The containerd image store keeps image indexes, so all platforms are
+	// served rather than only the one matching the host.
+	containerdStore := IsContainerdImageStore(ctx, dockerapi)
+
 	for _, buildRes := range resp {
 		pw := progress.WithPrefix(printer, buildRes.Name, len(pullOpts) > 1)
-		// Pick the best node to pull from by checking against local architecture.
-		nodeRes := chooseNodeResponse(buildRes.NodeResponses)
 		pullOpt := pullOpts[buildRes.Name]
 
-		architecture := nodeRes.Node.DriverOpts["platform"]
-		manifest, config, err := decodeNodeResponse(architecture, nodeRes)
+		proxyOpts, err := proxyConfig(buildRes.NodeResponses, pullOpt, containerdStore)
 		if err != nil {
 			return err
 		}
-		proxyOpts := &ProxyConfig{
-			RawManifest: manifest,
-			RawConfig:   config,
-			Addr:        nodeRes.Node.DriverOpts["addr"],
-			ServerName:  nodeRes.Node.DriverOpts["serverName"],
-			CACert:      []byte(nodeRes.Node.DriverOpts["caCert"]),
-			Key:         []byte(nodeRes.Node.DriverOpts["key"]),
-			Cert:        []byte(nodeRes.Node.DriverOpts["cert"]),
-		}
 
 		// Start the depot registry proxy.
 		var registry *RegistryProxy
@@ -65,16 +58,55 @@ func DepotFastLoad(ctx context.Context, dockerapi docker.APIClient, resp []depot
 			cancel()
 		}()
 
-		// Pull the image and relabel it with the user specified tags.
-		err = PullImages(ctx, dockerapi, registry.ImageToPull, pullOpt, pw)
+		// Pull the image, relabel it with the user specified tags, and
+		// check that it is the image that was built.
+		err = pullAndVerify(ctx, dockerapi, registry.ImageToPull, pullOpt, proxyOpts, containerdStore, pw)
 		if err != nil {
-			return fmt.Errorf("failed to pull image: %w", err)
+			return err
 		}
 	}
 
 	return nil
 }
 
+func proxyConfig(nodeResponses []depotbuild.DepotNodeResponse, pullOpt PullOptions, containerdStore bool) (*ProxyConfig, error) {
+	if containerdStore && pullOpt.Platform == nil {
+		if proxyOpts, err := indexProxyConfig(nodeResponses); err == nil {
+			return proxyOpts, nil
+		}
+		// Builds not exporting the version 2 image format are loaded one platform at a time.
+	}
+
+	nodeRes, manifest, config, err := chooseImage(nodeResponses, pullOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProxyConfig{
+		RawManifest: manifest,
+		RawConfig:   config,
+		Addr:        nodeRes.Node.DriverOpts["addr"],
+		ServerName:  nodeRes.Node.DriverOpts["serverName"],
+		CACert:      []byte(nodeRes.Node.DriverOpts["caCert"]),
+		Key:         []byte(nodeRes.Node.DriverOpts["key"]),
+		Cert:        []byte(nodeRes.Node.DriverOpts["cert"]),
+	}, nil
+}
+
+// chooseImage returns the image of the --load-platform, or else the image of
+// the host architecture, and the node that built it.
+func chooseImage(nodeResponses []depotbuild.DepotNodeResponse, pullOpt PullOptions) (depotbuild.DepotNodeResponse, []byte, []byte, error) {
+	if pullOpt.Platform != nil {
+		return choosePlatformImage(nodeResponses, *pullOpt.Platform)
+	}
+
+	// Pick the best node to pull from by checking against local architecture.
+	nodeRes := chooseNodeResponse(nodeResponses)
+	architecture := nodeRes.Node.DriverOpts["platform"]
+	manifest, config, err := decodeNodeResponse(architecture, nodeRes)
+	return nodeRes, manifest, config, err
+}
+
 // For now if there is a multi-platform build we try to only download the
 // architecture of the depot CLI host.  If there is not a node with the same
 // architecture as the  depot CLI host, we take the first node in the list.
@@ -91,6 +123,41 @@ func chooseNodeResponse(nodeResponses []depotbuild.DepotNodeResponse) depotbuild
 	return nodeResponses[nodeIdx]
 }
 
+// choosePlatformImage finds the image manifest and config built for platform
+// across all nodes of a multi-platform build.
+func choosePlatformImage(nodeResponses []depotbuild.DepotNodeResponse, platform string) (depotbuild.DepotNodeResponse, []byte, []byte, error) {
+	p, err := platforms.Parse(platform)
+	if err != nil {
+		return depotbuild.DepotNodeResponse{}, nil, nil, err
+	}
+	matcher := platforms.Only(p)
+
+	for _, nodeRes := range nodeResponses {
+		encodedExportedImages, err := EncodedExportedImages(nodeRes.SolveResponse.ExporterResponse)
+		if err != nil {
+			continue
+		}
+
+		exportedImages, _, imageConfigs, err := DecodeExportImages(encodedExportedImages)
+		if err != nil {
+			return depotbuild.DepotNodeResponse{}, nil, nil, err
+		}
+
+		for i, imageConfig := range imageConfigs {
+			imagePlatform := ocispecs.Platform{
+				OS:           imageConfig.OS,
+				Architecture: imageConfig.Architecture,
+				Variant:      imageConfig.Variant,
+			}
+			if matcher.Match(imagePlatform) {
+				return nodeRes, exportedImages[i].Manifest, exportedImages[i].Config, nil
+			}
+		}
+	}
+
+	return depotbuild.DepotNodeResponse{}, nil, nil, fmt.Errorf("no image was built for load platform %s", platform)
+}
+
 // ImageExported is the solve response key added for `depot.export.image.version=2`.
 const ImagesExported = "depot/images.exported"
 
@@ -3,6 +3,7 @@ package load
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"sync"
@@ -35,6 +36,21 @@ type ProxyConfig struct {
 	RawManifest []byte
 	// RawConfig is the raw config bytes for the single image to serve.
 	RawConfig []byte
+
+	// RawIndex, if set, is served instead of RawManifest. The images it
+	// references are read from Backends.
+	RawIndex []byte
+	Backends []ProxyBackend
+}
+
+// ProxyBackend is a builder holding the content of some of the images of an index.
+type ProxyBackend struct {
+	Addr       string             `json:"addr"`
+	ServerName string             `json:"server_name"`
+	CACert     []byte             `json:"ca_cert"`
+	Key        []byte             `json:"key"`
+	Cert       []byte             `json:"cert"`
+	Images     []RawExportedImage `json:"images"`
 }
 
 // Runs a proxy container via the docker API so that the docker daemon can pull from the local depot registry.
@@ -45,21 +61,33 @@ func RunProxyImage(ctx context.Context, dockerapi docker.APIClient, config *Prox
 		return nil, err
 	}
 
+	env := []string{
+		fmt.Sprintf("CA_CERT=%s", base64.StdEncoding.EncodeToString(config.CACert)),
+		fmt.Sprintf("KEY=%s", base64.StdEncoding.EncodeToString(config.Key)),
+		fmt.Sprintf("CERT=%s", base64.StdEncoding.EncodeToString(config.Cert)),
+		fmt.Sprintf("ADDR=%s", base64.StdEncoding.EncodeToString([]byte(config.Addr))),
+		fmt.Sprintf("SERVER_NAME=%s", base64.StdEncoding.EncodeToString([]byte(config.ServerName))),
+		fmt.Sprintf("MANIFEST=%s", base64.StdEncoding.EncodeToString(config.RawManifest)),
+		fmt.Sprintf("CONFIG=%s", base64.StdEncoding.EncodeToString(config.RawConfig)),
+	}
+	if config.RawIndex != nil {
+		backends, err := json.Marshal(config.Backends)
+		if err != nil {
+			return nil, err
+		}
+		env = append(env,
+			fmt.Sprintf("INDEX=%s", base64.StdEncoding.EncodeToString(config.RawIndex)),
+			fmt.Sprintf("BACKENDS=%s", base64.StdEncoding.EncodeToString(backends)),
+		)
+	}
+
 	resp, err := dockerapi.ContainerCreate(ctx,
 		&container.Config{
 			Image: proxyImage,
 			ExposedPorts: nat.PortSet{
 				nat.Port("8888/tcp"): struct{}{},
 			},
-			Env: []string{
-				fmt.Sprintf("CA_CERT=%s", base64.StdEncoding.EncodeToString(config.CACert)),
-				fmt.Sprintf("KEY=%s", base64.StdEncoding.EncodeToString(config.Key)),
-				fmt.Sprintf("CERT=%s", base64.StdEncoding.EncodeToString(config.Cert)),
-				fmt.Sprintf("ADDR=%s", base64.StdEncoding.EncodeToString([]byte(config.Addr))),
-				fmt.Sprintf("SERVER_NAME=%s", base64.StdEncoding.EncodeToString([]byte(config.ServerName))),
-				fmt.Sprintf("MANIFEST=%s", base64.StdEncoding.EncodeToString(config.RawManifest)),
-				fmt.Sprintf("CONFIG=%s", base64.StdEncoding.EncodeToString(config.RawConfig)),
-			},
+			Env: env,
 			Cmd: []string{"registry"},
 			Healthcheck: &container.HealthConfig{
 				Test:        []string{"CMD", "curl", "-f", "http://localhost:8888/v2"},
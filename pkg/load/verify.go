@@ -0,0 +1,72 @@
+package load
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/buildx/util/progress"
+	docker "github.com/docker/docker/client"
+	"github.com/opencontainers/go-digest"
+)
+
+// pullAndVerify pulls the image and checks that the loaded image has the
+// digest of the exported image.  A mismatch, such as content corrupted by a
+// flaky connection, is retried once before failing the load.  An image
+// loaded without tags is verified by its ID.
+func pullAndVerify(ctx context.Context, dockerapi docker.APIClient, imageName string, pullOpt PullOptions, proxyOpts *ProxyConfig, containerdStore bool, pw progress.Writer) error {
+	expected := expectedImageID(proxyOpts, containerdStore)
+	for attempt := 1; ; attempt++ {
+		if err := PullImages(ctx, dockerapi, imageName, pullOpt, pw); err != nil {
+			return fmt.Errorf("failed to pull image: %w", err)
+		}
+		if expected == "" {
+			return nil
+		}
+
+		tag := loadedImageRef(pullOpt, expected)
+		err := verifyLoadedImage(ctx, dockerapi, tag, expected)
+		if err == nil {
+			return nil
+		}
+		if attempt > 1 {
+			progress.Write(pw, fmt.Sprintf("[load] verifying %s", tag), func() error { return err })
+			return err
+		}
+		progress.Write(pw, "[load] loaded image does not match the build; retrying", func() error { return err })
+	}
+}
+
+// expectedImageID is the ID that docker gives the loaded image: the digest of
+// the config, or with the containerd image store, the digest of the index or
+// manifest that was served.
+func expectedImageID(proxyOpts *ProxyConfig, containerdStore bool) digest.Digest {
+	switch {
+	case containerdStore && len(proxyOpts.RawIndex) > 0:
+		return digest.FromBytes(proxyOpts.RawIndex)
+	case containerdStore && len(proxyOpts.RawManifest) > 0:
+		return digest.FromBytes(proxyOpts.RawManifest)
+	case len(proxyOpts.RawConfig) > 0:
+		return digest.FromBytes(proxyOpts.RawConfig)
+	}
+	return ""
+}
+
+// loadedImageRef is the reference to inspect the loaded image by: its first
+// tag, or without tags, the expected ID, which only a matching image has.
+func loadedImageRef(pullOpt PullOptions, expected digest.Digest) string {
+	if len(pullOpt.UserTags) > 0 {
+		return pullOpt.UserTags[0]
+	}
+	return expected.String()
+}
+
+func verifyLoadedImage(ctx context.Context, dockerapi docker.APIClient, tag string, expected digest.Digest) error {
+	inspect, _, err := dockerapi.ImageInspectWithRaw(ctx, tag)
+	if err != nil {
+		return fmt.Errorf("unable to inspect loaded image %s: %w", tag, err)
+	}
+	if digest.Digest(inspect.ID) != expected {
+		return fmt.Errorf("loaded image %s has ID %s, expected %s", tag, inspect.ID, expected)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package load
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	docker "github.com/docker/docker/client"
+	"github.com/opencontainers/go-digest"
+)
+
+type inspectClient struct {
+	docker.APIClient
+	id string
+}
+
+func (c inspectClient) ImageInspectWithRaw(context.Context, string) (types.ImageInspect, []byte, error) {
+	return types.ImageInspect{ID: c.id}, nil, nil
+}
+
+func TestVerifyLoadedImage(t *testing.T) {
+	proxyOpts := &ProxyConfig{RawManifest: []byte(`{"manifest":true}`), RawConfig: []byte(`{"config":true}`)}
+
+	expected := expectedImageID(proxyOpts, false)
+	if expected != digest.FromBytes(proxyOpts.RawConfig) {
+		t.Fatalf("expected the config digest, got %s", expected)
+	}
+	if got := expectedImageID(proxyOpts, true); got != digest.FromBytes(proxyOpts.RawManifest) {
+		t.Fatalf("expected the manifest digest with the containerd store, got %s", got)
+	}
+
+	ctx := context.Background()
+	if err := verifyLoadedImage(ctx, inspectClient{id: expected.String()}, "app:latest", expected); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := verifyLoadedImage(ctx, inspectClient{id: digest.FromString("corrupt").String()}, "app:latest", expected); err == nil {
+		t.Error("expected an error for a mismatched image ID")
+	}
+
+	if ref := loadedImageRef(PullOptions{UserTags: []string{"app:latest", "app:1"}}, expected); ref != "app:latest" {
+		t.Errorf("expected the first tag to be inspected, got %s", ref)
+	}
+	if ref := loadedImageRef(PullOptions{}, expected); ref != expected.String() {
+		t.Errorf("expected an untagged image to be inspected by its ID, got %s", ref)
+	}
+}
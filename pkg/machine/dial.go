@@ -0,0 +1,243 @@
+package machine
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/depot/cli/pkg/debuglog"
+	"github.com/pkg/errors"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// connectTimeout is how long a direct connection may take before the
+	// WebSocket transport is tried.
+	connectTimeout = 10 * time.Second
+
+	transportTCP       = "tcp"
+	transportWebSocket = "websocket"
+)
+
+// transport returns the transport forced with DEPOT_TRANSPORT, if any.
+func transport() string {
+	return strings.ToLower(os.Getenv("DEPOT_TRANSPORT"))
+}
+
+// Dial connects to the machine directly and falls back to tunneling the
+// connection over WebSocket on port 443 when the direct connection fails or
+// times out.  Once the fallback has been used, later connections use it too.
+func (m *Machine) Dial(ctx context.Context, addr string) (net.Conn, error) {
+	addr = strings.TrimPrefix(addr, "tcp://")
+
+	forced := transport()
+	if forced == transportWebSocket || (m.useWebSocket.Load() && forced != transportTCP) {
+		return m.dialWebSocket(ctx)
+	}
+
+	dialer := net.Dialer{Timeout: connectTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err == nil || m.WebSocketEndpoint == "" || forced == transportTCP || ctx.Err() != nil {
+		return conn, err
+	}
+
+	debuglog.Log("unable to connect to %s, falling back to websocket: %v", addr, err)
+	conn, wsErr := m.dialWebSocket(ctx)
+	if wsErr != nil {
+		return nil, errors.Wrapf(err, "websocket fallback failed: %v", wsErr)
+	}
+	m.useWebSocket.Store(true)
+	return conn, nil
+}
+
+// Transport is the transport of the connections to the machine.
+func (m *Machine) Transport() string {
+	if transport() == transportWebSocket || m.useWebSocket.Load() {
+		return transportWebSocket
+	}
+	return transportTCP
+}
+
+// dialWebSocket returns a connection tunneled in binary WebSocket messages.
+func (m *Machine) dialWebSocket(ctx context.Context) (net.Conn, error) {
+	if m.WebSocketEndpoint == "" {
+		return nil, errors.New("the machine does not have a websocket endpoint")
+	}
+
+	config, err := websocket.NewConfig(m.WebSocketEndpoint, "https://depot.dev")
+	if err != nil {
+		return nil, err
+	}
+	// The build token is sent in the handshake, so it is only sent in the
+	// clear to a local endpoint, as when testing locally.
+	if config.Location.Scheme == "ws" && !isLocalHost(config.Location.Hostname()) {
+		return nil, errors.Errorf("refusing to send the build token to %s without TLS, the websocket endpoint must use wss://", config.Location.Host)
+	}
+	config.Header.Set("Authorization", "Bearer "+m.Token)
+	config.Header.Set("X-Depot-Build-Id", m.BuildID)
+
+	conn, err := dialURL(ctx, config.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	// The handshake does not take a context.
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(connectTimeout)
+	}
+	_ = conn.SetDeadline(deadline)
+	ws, err := websocket.NewClient(config, conn)
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "websocket handshake failed")
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	ws.PayloadType = websocket.BinaryFrame
+	return ws, nil
+}
+
+// isLocalHost reports whether host is the local machine.
+func isLocalHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// dialURL connects to the WebSocket endpoint, through the proxy of the
+// HTTPS_PROXY, HTTP_PROXY, and NO_PROXY environment variables if any, as
+// networks that block the builders often only allow port 443 via a proxy.
+func dialURL(ctx context.Context, u *url.URL) (net.Conn, error) {
+	host := u.Host
+	if u.Port() == "" {
+		port := "443"
+		if u.Scheme == "ws" {
+			port = "80"
+		}
+		host = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	var httpScheme string
+	switch u.Scheme {
+	case "wss":
+		httpScheme = "https"
+	case "ws":
+		httpScheme = "http"
+	default:
+		return nil, errors.Errorf("unsupported websocket scheme %q", u.Scheme)
+	}
+	proxyURL, err := http.ProxyFromEnvironment(&http.Request{URL: &url.URL{Scheme: httpScheme, Host: host}})
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid proxy")
+	}
+
+	var conn net.Conn
+	if proxyURL != nil {
+		conn, err = dialProxy(ctx, proxyURL, host)
+	} else {
+		dialer := net.Dialer{Timeout: connectTimeout}
+		conn, err = dialer.DialContext(ctx, "tcp", host)
+	}
+	if err != nil || u.Scheme == "ws" {
+		return conn, err
+	}
+
+	tlsConn := tls.Client(conn, &tls.Config{ServerName: u.Hostname()})
+	handshakeCtx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+	if err := tlsConn.HandshakeContext(handshakeCtx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
+}
+
+// dialProxy opens a tunnel to host with a CONNECT request to the proxy.
+func dialProxy(ctx context.Context, proxyURL *url.URL, host string) (net.Conn, error) {
+	proxyHost := proxyURL.Host
+	if proxyURL.Port() == "" {
+		port := "80"
+		if proxyURL.Scheme == "https" {
+			port = "443"
+		}
+		proxyHost = net.JoinHostPort(proxyURL.Hostname(), port)
+	}
+
+	var (
+		conn net.Conn
+		err  error
+	)
+	switch proxyURL.Scheme {
+	case "https":
+		dialer := tls.Dialer{NetDialer: &net.Dialer{Timeout: connectTimeout}, Config: &tls.Config{ServerName: proxyURL.Hostname()}}
+		conn, err = dialer.DialContext(ctx, "tcp", proxyHost)
+	case "http", "":
+		dialer := net.Dialer{Timeout: connectTimeout}
+		conn, err = dialer.DialContext(ctx, "tcp", proxyHost)
+	default:
+		return nil, errors.Errorf("unsupported proxy scheme %q", proxyURL.Scheme)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to connect to proxy %s", proxyURL.Host)
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: host},
+		Host:   host,
+		Header: http.Header{},
+	}
+	if user := proxyURL.User; user != nil {
+		password, _ := user.Password()
+		req.SetBasicAuth(user.Username(), password)
+		req.Header.Set("Proxy-Authorization", req.Header.Get("Authorization"))
+		req.Header.Del("Authorization")
+	}
+
+	// Neither the request nor the response take a context.
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(connectTimeout)
+	}
+	_ = conn.SetDeadline(deadline)
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, errors.Wrapf(err, "unable to connect through proxy %s", proxyURL.Host)
+	}
+	br := bufio.NewReader(conn)
+	res, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrapf(err, "unable to connect through proxy %s", proxyURL.Host)
+	}
+	_ = res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, errors.Errorf("proxy %s refused to connect to %s: %s", proxyURL.Host, host, res.Status)
+	}
+	_ = conn.SetDeadline(time.Time{})
+	if br.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn reads the data that was read past the response of the proxy
+// before the rest of the connection.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
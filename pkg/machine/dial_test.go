@@ -0,0 +1,79 @@
+package machine
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestDialProxy(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	requests := make(chan *http.Request, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		req, err := http.ReadRequest(bufio.NewReader(conn))
+		if err != nil {
+			return
+		}
+		requests <- req
+		_, _ = io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\ntunneled")
+	}()
+
+	proxyURL := &url.URL{Scheme: "http", Host: l.Addr().String(), User: url.UserPassword("user", "secret")}
+	conn, err := dialProxy(context.Background(), proxyURL, "builder.depot.dev:443")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	req := <-requests
+	if req.Method != http.MethodConnect || req.Host != "builder.depot.dev:443" {
+		t.Errorf("expected a CONNECT to builder.depot.dev:443, got %s %s", req.Method, req.Host)
+	}
+	if auth := req.Header.Get("Proxy-Authorization"); auth != "Basic dXNlcjpzZWNyZXQ=" {
+		t.Errorf("expected the proxy credentials, got %q", auth)
+	}
+	dt, err := io.ReadAll(conn)
+	if err != nil || string(dt) != "tunneled" {
+		t.Errorf("expected the tunneled data, got %q, %v", dt, err)
+	}
+}
+
+func TestDialWebSocketRequiresTLS(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		refused  bool
+	}{
+		{name: "remote ws", endpoint: "ws://builder.example.com/connect", refused: true},
+		{name: "localhost", endpoint: "ws://localhost:1/connect"},
+		{name: "loopback", endpoint: "ws://127.0.0.1:1/connect"},
+		{name: "ipv6 loopback", endpoint: "ws://[::1]:1/connect"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Machine{WebSocketEndpoint: tt.endpoint, Token: "secret"}
+			_, err := m.dialWebSocket(context.Background())
+			if err == nil {
+				t.Fatal("expected the dial to fail")
+			}
+			if refused := strings.Contains(err.Error(), "without TLS"); refused != tt.refused {
+				t.Errorf("dialWebSocket() = %v, want refused %v", err, tt.refused)
+			}
+		})
+	}
+}
@@ -4,14 +4,15 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"net"
 	"os"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"connectrpc.com/connect"
 	"github.com/depot/cli/pkg/api"
 	"github.com/depot/cli/pkg/cleanup"
+	"github.com/depot/cli/pkg/progresshelper"
 	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
 	"github.com/depot/cli/pkg/proto/depot/cli/v1/cliv1connect"
 	"github.com/moby/buildkit/client"
@@ -31,22 +32,86 @@ type Machine struct {
 	CACert     string
 	Cert       string
 	Key        string
+	// WebSocketEndpoint tunnels the connection over port 443 when Addr is unreachable.
+	WebSocketEndpoint string
+
+	// BuildkitVersion is the version reported by buildkitd when connected.
+	// It is empty if buildkitd is too old to report it.
+	BuildkitVersion string
+
+	// Info describes the machine when the API reports it.
+	Info Info
+	// Placement are the placement hints sent when acquiring the machine.
+	Placement Placement
 
 	client           *client.Client
 	useGzip          bool
+	useWebSocket     atomic.Bool
 	reportHealthDone chan struct{}
 }
 
+// Info is where a machine runs.
+type Info struct {
+	MachineID    string `json:"machineID,omitempty"`
+	Region       string `json:"region,omitempty"`
+	InstanceType string `json:"instanceType,omitempty"`
+	IPAddress    string `json:"ipAddress,omitempty"`
+}
+
+// String describes the machine, e.g. "i-0abc (c7i.4xlarge) in us-east-1 at 10.0.0.1".
+func (i Info) String() string {
+	s := i.MachineID
+	if i.InstanceType != "" {
+		s += " (" + i.InstanceType + ")"
+	}
+	if i.Region != "" {
+		s += " in " + i.Region
+	}
+	if i.IPAddress != "" {
+		s += " at " + i.IPAddress
+	}
+	return strings.TrimSpace(s)
+}
+
+// Placement are hints of where to run a machine, such as close to the
+// registry it pushes to.
+type Placement struct {
+	Region string
+	Near   []string
+}
+
+func (p Placement) proto() *cliv1.Placement {
+	if p.Region == "" && len(p.Near) == 0 {
+		return nil
+	}
+	placement := &cliv1.Placement{Near: p.Near}
+	if p.Region != "" {
+		placement.Region = &p.Region
+	}
+	return placement
+}
+
+// AcquireOption configures how a machine is acquired.
+type AcquireOption func(*Machine)
+
+// WithPlacement requests the machine to be placed according to the hints.
+func WithPlacement(placement Placement) AcquireOption {
+	return func(m *Machine) { m.Placement = placement }
+}
+
 // Platform can be "amd64" or "arm64".
 // This reports health continually to the Depot API and waits for the buildkit
 // machine to be ready.  This can be canceled by canceling the context.
-func Acquire(ctx context.Context, buildID, token, platform string) (*Machine, error) {
+func Acquire(ctx context.Context, buildID, token, platform string, opts ...AcquireOption) (*Machine, error) {
 	m := &Machine{
 		BuildID:          buildID,
 		Token:            token,
 		Platform:         platform,
 		reportHealthDone: make(chan struct{}),
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
 
 	go func() {
 		err := m.ReportHealth()
@@ -69,8 +134,9 @@ func Acquire(ctx context.Context, buildID, token, platform string) (*Machine, er
 
 	for {
 		req := cliv1.GetBuildKitConnectionRequest{
-			BuildId:  m.BuildID,
-			Platform: builderPlatform,
+			BuildId:   m.BuildID,
+			Platform:  builderPlatform,
+			Placement: m.Placement.proto(),
 		}
 		resp, err := client.GetBuildKitConnection(ctx, api.WithAuthentication(connect.NewRequest(&req), m.Token))
 		if err != nil {
@@ -81,6 +147,15 @@ func Acquire(ctx context.Context, buildID, token, platform string) (*Machine, er
 		case *cliv1.GetBuildKitConnectionResponse_Active:
 			m.Addr = connection.Active.Endpoint
 			m.ServerName = connection.Active.ServerName
+			m.WebSocketEndpoint = connection.Active.GetWebsocketEndpoint()
+			if info := connection.Active.GetMachine(); info != nil {
+				m.Info = Info{
+					MachineID:    info.MachineId,
+					Region:       info.Region,
+					InstanceType: info.InstanceType,
+					IPAddress:    info.IpAddress,
+				}
+			}
 			// When testing locally, we don't have TLS certs.
 			if connection.Active.CaCert == nil || connection.Active.Cert == nil {
 				return m, nil
@@ -122,7 +197,6 @@ func (m *Machine) ReportHealth() error {
 				return nil
 			}
 			fmt.Printf("error reporting health: %s", err.Error())
-			client = api.NewBuildClient()
 		}
 
 		// If canceling the build was requested, release the machine to interrupt the build step.
@@ -138,9 +212,16 @@ func (m *Machine) ReportHealth() error {
 }
 
 func (m *Machine) doReportHealth(ctx context.Context, client cliv1connect.BuildServiceClient, builderPlatform cliv1.BuilderPlatform) (*timestamppb.Timestamp, error) {
-	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-	defer cancel()
 	req := cliv1.ReportBuildHealthRequest{BuildId: m.BuildID, Platform: builderPlatform}
+	if steps := progresshelper.BuildSteps(m.BuildID); steps.Total > 0 {
+		req.Progress = &cliv1.BuildStepProgress{
+			CompletedSteps: int32(steps.Completed),
+			TotalSteps:     int32(steps.Total),
+		}
+		if steps.Current != "" {
+			req.Progress.CurrentStep = &steps.Current
+		}
+	}
 	res, err := client.ReportBuildHealth(ctx, api.WithAuthentication(connect.NewRequest(&req), m.Token))
 	if err != nil {
 		return nil, err
@@ -162,10 +243,7 @@ func (m *Machine) Client(ctx context.Context) (*client.Client, error) {
 	}
 
 	opts := []client.ClientOpt{
-		client.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
-			addr = strings.TrimPrefix(addr, "tcp://")
-			return net.Dial("tcp", addr)
-		}),
+		client.WithContextDialer(m.Dial),
 	}
 
 	// We create all these files as buildkit does not allow control of the gRPC client
@@ -215,6 +293,10 @@ func (m *Machine) Client(ctx context.Context) (*client.Client, error) {
 		opts = append(opts, useGzip)
 	}
 
+	for _, opt := range api.GRPCTraceOptions("machine") {
+		opts = append(opts, opt)
+	}
+
 	c, err := client.New(ctx, m.Addr, opts...)
 	if err != nil {
 		return nil, err
@@ -245,6 +327,7 @@ func (m *Machine) Connect(ctx context.Context) (*client.Client, error) {
 	)
 	client, err = m.CheckReady(ctx)
 	if err == nil {
+		m.recordVersion(ctx, client)
 		return client, nil
 	}
 
@@ -260,7 +343,19 @@ func (m *Machine) Connect(ctx context.Context) (*client.Client, error) {
 
 		client, err = m.CheckReady(ctx)
 		if err == nil {
+			m.recordVersion(ctx, client)
 			return client, nil
 		}
 	}
 }
+
+// recordVersion records the buildkitd version so that features the builder
+// does not support can be rejected before the build starts.
+func (m *Machine) recordVersion(ctx context.Context, client *client.Client) {
+	info, err := client.Info(ctx)
+	if err != nil {
+		// Older buildkitd versions do not implement Info.
+		return
+	}
+	m.BuildkitVersion = info.BuildkitVersion.Version
+}
@@ -0,0 +1,167 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/depot/cli/pkg/api"
+)
+
+const (
+	StatusSuccess  = "success"
+	StatusFailed   = "failed"
+	StatusCanceled = "canceled"
+)
+
+// Options configures where build notifications are delivered.
+type Options struct {
+	// Webhook is a URL that receives the notification as a JSON POST.
+	Webhook string
+	// Exec is a command that receives the notification as JSON on stdin.
+	Exec string
+}
+
+// Payload is the JSON document sent on build completion.
+type Payload struct {
+	BuildID         string   `json:"build_id"`
+	ProjectID       string   `json:"project_id"`
+	Status          string   `json:"status"`
+	Error           string   `json:"error,omitempty"`
+	DurationSeconds float64  `json:"duration_seconds"`
+	Digests         []string `json:"digests,omitempty"`
+	URL             string   `json:"url"`
+}
+
+// Notification collects the results of a single build and delivers them
+// once the build has finished.  A nil *Notification is valid and does nothing.
+type Notification struct {
+	opts      Options
+	buildID   string
+	projectID string
+	url       string
+	start     time.Time
+
+	mu      sync.Mutex
+	digests []string
+}
+
+// New returns nil when no notification target is configured.
+func New(opts Options, buildID, projectID, url string) *Notification {
+	if opts.Webhook == "" && opts.Exec == "" {
+		return nil
+	}
+
+	return &Notification{
+		opts:      opts,
+		buildID:   buildID,
+		projectID: projectID,
+		url:       url,
+		start:     time.Now(),
+	}
+}
+
+// AddDigests records the image digests produced by the build.
+func (n *Notification) AddDigests(digests ...string) {
+	if n == nil {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, digest := range digests {
+		if digest != "" {
+			n.digests = append(n.digests, digest)
+		}
+	}
+}
+
+// Send delivers the notification for the build result.  Delivery failures
+// are printed as warnings as they should not fail the build.
+func (n *Notification) Send(buildErr error) {
+	if n == nil {
+		return
+	}
+
+	payload := Payload{
+		BuildID:         n.buildID,
+		ProjectID:       n.projectID,
+		Status:          StatusSuccess,
+		DurationSeconds: time.Since(n.start).Seconds(),
+		URL:             n.url,
+	}
+	n.mu.Lock()
+	payload.Digests = append(payload.Digests, n.digests...)
+	n.mu.Unlock()
+
+	if buildErr != nil {
+		payload.Status = StatusFailed
+		if errors.Is(buildErr, context.Canceled) {
+			payload.Status = StatusCanceled
+		}
+		payload.Error = buildErr.Error()
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: unable to encode build notification: %v\n", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if n.opts.Webhook != "" {
+		if err := postWebhook(ctx, n.opts.Webhook, body); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: unable to send build notification webhook: %v\n", err)
+		}
+	}
+	if n.opts.Exec != "" {
+		if err := runExec(ctx, n.opts.Exec, payload, body); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: build notification command failed: %v\n", err)
+		}
+	}
+}
+
+func postWebhook(ctx context.Context, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", api.Agent())
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status code: %s", res.Status)
+	}
+	return nil
+}
+
+// runExec runs the hook with the payload on stdin.  The most commonly used
+// fields are also available as environment variables.
+func runExec(ctx context.Context, command string, payload Payload, body []byte) error {
+	cmd := exec.CommandContext(ctx, command)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"DEPOT_BUILD_ID="+payload.BuildID,
+		"DEPOT_PROJECT_ID="+payload.ProjectID,
+		"DEPOT_BUILD_STATUS="+payload.Status,
+		"DEPOT_BUILD_URL="+payload.URL,
+	)
+	return cmd.Run()
+}
@@ -0,0 +1,125 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestNewDisabled(t *testing.T) {
+	n := New(Options{}, "build", "project", "https://depot.dev/build")
+	if n != nil {
+		t.Fatalf("New() = %+v, want nil without a webhook or command", n)
+	}
+	// A disabled notification does nothing.
+	n.AddDigests("sha256:abc")
+	n.Send(errors.New("failed"))
+}
+
+func TestSendPayload(t *testing.T) {
+	tests := []struct {
+		name       string
+		buildErr   error
+		wantStatus string
+		wantError  string
+	}{
+		{name: "success", wantStatus: StatusSuccess},
+		{name: "failed", buildErr: errors.New("exit code 1"), wantStatus: StatusFailed, wantError: "exit code 1"},
+		{name: "canceled", buildErr: fmt.Errorf("build: %w", context.Canceled), wantStatus: StatusCanceled, wantError: "build: context canceled"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				got         Payload
+				contentType string
+			)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				contentType = r.Header.Get("Content-Type")
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Error(err)
+				}
+			}))
+			defer server.Close()
+
+			n := New(Options{Webhook: server.URL}, "build", "project", "https://depot.dev/build")
+			n.AddDigests("sha256:abc", "", "sha256:def")
+			n.Send(tt.buildErr)
+
+			if contentType != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", contentType)
+			}
+			if got.BuildID != "build" || got.ProjectID != "project" || got.URL != "https://depot.dev/build" {
+				t.Errorf("payload = %+v, want the build, project, and URL", got)
+			}
+			if got.Status != tt.wantStatus || got.Error != tt.wantError {
+				t.Errorf("payload status, error = %q, %q, want %q, %q", got.Status, got.Error, tt.wantStatus, tt.wantError)
+			}
+			if want := []string{"sha256:abc", "sha256:def"}; !reflect.DeepEqual(got.Digests, want) {
+				t.Errorf("payload digests = %v, want %v", got.Digests, want)
+			}
+		})
+	}
+}
+
+func TestPayloadOmitsEmptyFields(t *testing.T) {
+	dt, err := json.Marshal(Payload{BuildID: "build", Status: StatusSuccess})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"build_id":"build","project_id":"","status":"success","duration_seconds":0,"url":""}`
+	if string(dt) != want {
+		t.Errorf("json.Marshal(Payload) = %s, want %s", dt, want)
+	}
+}
+
+func TestPostWebhookStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	if err := postWebhook(context.Background(), server.URL, []byte("{}")); err == nil {
+		t.Error("postWebhook() = nil, want an error for a 502 response")
+	}
+}
+
+func TestRunExec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the hook is a shell script")
+	}
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	script := filepath.Join(dir, "hook.sh")
+	content := fmt.Sprintf("#!/bin/sh\n{ echo \"$DEPOT_BUILD_ID $DEPOT_PROJECT_ID $DEPOT_BUILD_STATUS $DEPOT_BUILD_URL\"; cat; } > %s\n", out)
+	if err := os.WriteFile(script, []byte(content), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	payload := Payload{BuildID: "build", ProjectID: "project", Status: StatusFailed, URL: "https://depot.dev/build"}
+	if err := runExec(context.Background(), script, payload, []byte(`{"build_id":"build"}`)); err != nil {
+		t.Fatal(err)
+	}
+	dt, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "build project failed https://depot.dev/build\n" + `{"build_id":"build"}`
+	if got := strings.TrimSpace(string(dt)); got != want {
+		t.Errorf("hook output = %q, want %q", got, want)
+	}
+
+	if err := runExec(context.Background(), filepath.Join(dir, "missing.sh"), payload, nil); err == nil {
+		t.Error("runExec() of a missing command = nil, want an error")
+	}
+}
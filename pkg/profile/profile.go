@@ -0,0 +1,189 @@
+// Applies the defaults and restrictions an organization sets for the CLI.
+package profile
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/config"
+	"github.com/depot/cli/pkg/debuglog"
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// cacheTTL is how long a cached profile is used before it is fetched again.
+const cacheTTL = time.Hour
+
+// Profile is the organization profile and where it came from.
+type Profile struct {
+	*cliv1.Profile
+	// FetchedAt is when the profile was fetched from the API.
+	FetchedAt time.Time
+	// Cached is set when the API could not be reached and a stale cached
+	// profile was used.
+	Cached bool
+}
+
+// Load returns the profile of the project's organization.  Profiles are cached
+// for an hour, and a stale cached profile is used when the API is unreachable.
+// Load returns nil if the organization has no profile or the API does not
+// support profiles.
+func Load(ctx context.Context, token, projectID string) (*Profile, error) {
+	path, err := config.ProfileCacheFile(cacheKey(token, projectID))
+	if err != nil {
+		return nil, err
+	}
+
+	cached, cachedAt := readCache(path)
+	if cached != nil && time.Since(cachedAt) < cacheTTL {
+		return newProfile(cached, cachedAt, false), nil
+	}
+
+	req := &cliv1.GetProfileRequest{}
+	if projectID != "" {
+		req.ProjectId = &projectID
+	}
+	res, err := api.NewProfileClient().GetProfile(ctx, api.WithAuthentication(connect.NewRequest(req), token))
+	if connect.CodeOf(err) == connect.CodeUnimplemented {
+		debuglog.Log("organization profiles are not supported by the API: %v", err)
+		return nil, nil
+	}
+	if err != nil {
+		if cached != nil {
+			debuglog.Log("unable to fetch organization profile, using cached profile: %v", err)
+			return newProfile(cached, cachedAt, true), nil
+		}
+		return nil, err
+	}
+
+	profile := res.Msg.GetProfile()
+	if profile == nil {
+		profile = &cliv1.Profile{}
+	}
+	if err := writeCache(path, profile); err != nil {
+		debuglog.Log("unable to cache organization profile: %v", err)
+	}
+	return newProfile(profile, time.Now(), false), nil
+}
+
+// HasCache reports whether a profile was cached for the token and project,
+// even if it is stale or can no longer be read.
+func HasCache(token, projectID string) bool {
+	path, err := config.ProfileCacheFile(cacheKey(token, projectID))
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+func newProfile(profile *cliv1.Profile, fetchedAt time.Time, cached bool) *Profile {
+	if profile.GetOrganizationId() == "" {
+		return nil
+	}
+	return &Profile{Profile: profile, FetchedAt: fetchedAt, Cached: cached}
+}
+
+// Apply sets the profile's default flags that were not given and rejects
+// forbidden flags.  Defaults for flags the command does not have are ignored.
+// Profiles with registry mirrors are rejected, as the CLI cannot apply them.
+func Apply(p *Profile, flags *pflag.FlagSet) error {
+	if p == nil {
+		return nil
+	}
+
+	if len(p.RegistryMirrors) > 0 {
+		return errors.New("your organization's profile sets registry mirrors, which this version of the CLI does not support")
+	}
+
+	for _, name := range p.ForbiddenFlags {
+		if f := flags.Lookup(name); f != nil && f.Changed {
+			return errors.Errorf("--%s is forbidden by your organization's profile", name)
+		}
+	}
+
+	if p.RequireProvenance {
+		if f := flags.Lookup("provenance"); f != nil && f.Changed && provenanceDisabled(f.Value.String()) {
+			return errors.New("provenance is required by your organization's profile and cannot be disabled")
+		}
+	}
+
+	for name, value := range p.DefaultFlags {
+		f := flags.Lookup(name)
+		if f == nil || f.Changed {
+			continue
+		}
+		if err := flags.Set(name, value); err != nil {
+			return errors.Wrapf(err, "invalid default --%s from your organization's profile", name)
+		}
+	}
+
+	if p.RequireProvenance {
+		if f := flags.Lookup("provenance"); f != nil && !f.Changed {
+			if err := flags.Set("provenance", "mode=min"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// provenanceDisabled reports whether a --provenance value turns provenance off,
+// e.g. "false" or "disabled=true".
+func provenanceDisabled(value string) bool {
+	if b, err := strconv.ParseBool(value); err == nil {
+		return !b
+	}
+	for _, field := range strings.Split(value, ",") {
+		if k, v, ok := strings.Cut(field, "="); ok && strings.TrimSpace(k) == "disabled" {
+			if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil && b {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// cacheKey keeps the profiles of different tokens and projects apart without
+// writing the token to disk.
+func cacheKey(token, projectID string) string {
+	sum := sha256.Sum256([]byte(token + "\x00" + projectID))
+	return hex.EncodeToString(sum[:8])
+}
+
+func readCache(path string) (*cliv1.Profile, time.Time) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}
+	}
+	var profile cliv1.Profile
+	if err := protojson.Unmarshal(data, &profile); err != nil {
+		return nil, time.Time{}
+	}
+	return &profile, info.ModTime()
+}
+
+func writeCache(path string, profile *cliv1.Profile) error {
+	data, err := protojson.Marshal(profile)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
@@ -0,0 +1,81 @@
+package profile
+
+import (
+	"testing"
+
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+	"github.com/spf13/pflag"
+)
+
+func newFlags(args ...string) *pflag.FlagSet {
+	flags := pflag.NewFlagSet("build", pflag.ContinueOnError)
+	flags.String("provenance", "", "")
+	flags.String("platform", "", "")
+	flags.Bool("no-cache", false, "")
+	_ = flags.Parse(args)
+	return flags
+}
+
+func TestApply(t *testing.T) {
+	p := &Profile{Profile: &cliv1.Profile{
+		OrganizationId:    "org",
+		DefaultFlags:      map[string]string{"platform": "linux/amd64", "cache-namespace": "team"},
+		ForbiddenFlags:    []string{"no-cache"},
+		RequireProvenance: true,
+	}}
+
+	flags := newFlags()
+	if err := Apply(p, flags); err != nil {
+		t.Fatal(err)
+	}
+	if got := flags.Lookup("platform").Value.String(); got != "linux/amd64" {
+		t.Errorf("expected the default platform, got %q", got)
+	}
+	if got := flags.Lookup("provenance").Value.String(); got != "mode=min" {
+		t.Errorf("expected provenance to be required, got %q", got)
+	}
+
+	flags = newFlags("--platform", "linux/arm64", "--provenance", "mode=max")
+	if err := Apply(p, flags); err != nil {
+		t.Fatal(err)
+	}
+	if got := flags.Lookup("platform").Value.String(); got != "linux/arm64" {
+		t.Errorf("expected the flag to take precedence, got %q", got)
+	}
+	if got := flags.Lookup("provenance").Value.String(); got != "mode=max" {
+		t.Errorf("expected the flag to take precedence, got %q", got)
+	}
+
+	if err := Apply(p, newFlags("--no-cache")); err == nil {
+		t.Errorf("expected a forbidden flag to be rejected")
+	}
+	if err := Apply(p, newFlags("--provenance", "false")); err == nil {
+		t.Errorf("expected disabling provenance to be rejected")
+	}
+	if err := Apply(nil, newFlags("--no-cache")); err != nil {
+		t.Errorf("expected no profile to allow everything, got %v", err)
+	}
+
+	mirrored := &Profile{Profile: &cliv1.Profile{
+		OrganizationId:  "org",
+		RegistryMirrors: []*cliv1.Profile_RegistryMirror{{Registry: "docker.io", Endpoints: []string{"mirror.example.com"}}},
+	}}
+	if err := Apply(mirrored, newFlags()); err == nil {
+		t.Errorf("expected a profile with registry mirrors to be rejected")
+	}
+}
+
+func TestProvenanceDisabled(t *testing.T) {
+	for value, expected := range map[string]bool{
+		"false":                   true,
+		"0":                       true,
+		"true":                    false,
+		"mode=max":                false,
+		"mode=min,disabled=true":  true,
+		"mode=min,disabled=false": false,
+	} {
+		if got := provenanceDisabled(value); got != expected {
+			t.Errorf("provenanceDisabled(%q) = %v, expected %v", value, got, expected)
+		}
+	}
+}
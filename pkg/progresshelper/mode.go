@@ -0,0 +1,54 @@
+package progresshelper
+
+import (
+	"os"
+
+	"github.com/depot/cli/pkg/ci"
+	"github.com/docker/buildx/util/progress"
+	"github.com/mattn/go-isatty"
+)
+
+// ResolveMode resolves the "auto" progress mode from the environment so that
+// every printer of a command agrees on it: $BUILDKIT_PROGRESS takes
+// precedence, and CI, TERM=dumb, or a stderr that is not a terminal select
+// plain progress.  Other modes are returned as they are.
+func ResolveMode(mode string) string {
+	_, isCI := ci.Provider()
+	return resolveMode(mode, os.Getenv, isCI, isatty.IsTerminal(os.Stderr.Fd()) || isatty.IsCygwinTerminal(os.Stderr.Fd()))
+}
+
+func resolveMode(mode string, getenv func(string) string, isCI, terminal bool) string {
+	if mode != progress.PrinterModeAuto {
+		return mode
+	}
+	if v := getenv("BUILDKIT_PROGRESS"); v != "" {
+		return v
+	}
+	if isCI || !terminal || getenv("TERM") == "dumb" {
+		return progress.PrinterModePlain
+	}
+	return mode
+}
+
+// colorAllowed reports whether output may be colored: NO_COLOR and TERM=dumb
+// disable colors, and CLICOLOR_FORCE enables them regardless.
+func colorAllowed(getenv func(string) string) bool {
+	if v := getenv("CLICOLOR_FORCE"); v != "" && v != "0" {
+		return true
+	}
+	return getenv("NO_COLOR") == "" && getenv("TERM") != "dumb"
+}
+
+// UseColor reports whether the summaries printed after a build with the
+// resolved progress mode are colored.  Plain progress is not colored unless
+// CLICOLOR_FORCE is set.
+func UseColor(mode string) bool {
+	return useColor(mode, os.Getenv)
+}
+
+func useColor(mode string, getenv func(string) string) bool {
+	if v := getenv("CLICOLOR_FORCE"); v != "" && v != "0" {
+		return true
+	}
+	return mode != progress.PrinterModePlain && colorAllowed(getenv)
+}
@@ -0,0 +1,53 @@
+package progresshelper
+
+import "testing"
+
+func TestResolveMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		env      map[string]string
+		isCI     bool
+		terminal bool
+		want     string
+	}{
+		{name: "terminal", mode: "auto", terminal: true, want: "auto"},
+		{name: "explicit mode", mode: "tty", env: map[string]string{"BUILDKIT_PROGRESS": "plain"}, want: "tty"},
+		{name: "BUILDKIT_PROGRESS", mode: "auto", env: map[string]string{"BUILDKIT_PROGRESS": "quiet"}, isCI: true, want: "quiet"},
+		{name: "CI", mode: "auto", isCI: true, terminal: true, want: "plain"},
+		{name: "dumb terminal", mode: "auto", env: map[string]string{"TERM": "dumb"}, terminal: true, want: "plain"},
+		{name: "not a terminal", mode: "auto", want: "plain"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(k string) string { return tt.env[k] }
+			if got := resolveMode(tt.mode, getenv, tt.isCI, tt.terminal); got != tt.want {
+				t.Errorf("resolveMode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseColor(t *testing.T) {
+	tests := []struct {
+		name string
+		mode string
+		env  map[string]string
+		want bool
+	}{
+		{name: "tty", mode: "tty", want: true},
+		{name: "plain", mode: "plain", want: false},
+		{name: "NO_COLOR", mode: "tty", env: map[string]string{"NO_COLOR": "1"}, want: false},
+		{name: "dumb terminal", mode: "auto", env: map[string]string{"TERM": "dumb"}, want: false},
+		{name: "CLICOLOR_FORCE", mode: "plain", env: map[string]string{"CLICOLOR_FORCE": "1", "NO_COLOR": "1"}, want: true},
+		{name: "CLICOLOR_FORCE=0", mode: "tty", env: map[string]string{"CLICOLOR_FORCE": "0", "NO_COLOR": "1"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(k string) string { return tt.env[k] }
+			if got := useColor(tt.mode, getenv); got != tt.want {
+				t.Errorf("useColor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
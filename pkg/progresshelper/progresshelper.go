@@ -1,6 +1,7 @@
 package progresshelper
 
 import (
+	"os"
 	"time"
 
 	"github.com/docker/buildx/util/progress"
@@ -52,3 +53,12 @@ func Log(w progress.Writer, message string, err error) {
 	finishLog := StartLog(w, message)
 	finishLog(err)
 }
+
+// LogBuildURL logs the link to the build in the Depot UI unless
+// DEPOT_NO_SUMMARY_LINK is set.
+func LogBuildURL(w progress.Writer, buildURL string) {
+	if buildURL == "" || os.Getenv("DEPOT_NO_SUMMARY_LINK") != "" {
+		return
+	}
+	Log(w, "[depot] build: "+buildURL, nil)
+}
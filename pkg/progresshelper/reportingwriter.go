@@ -18,6 +18,9 @@ import (
 
 var _ progress.Writer = (*Reporter)(nil)
 
+// Reporter forwards the statuses written to it to the API as well as to the
+// wrapped writer.  Statuses are buffered and sent in batches over a stream that
+// is reopened when it breaks.
 type Reporter struct {
 	// Using a function so we can support oth progress.Writer and progress.Logger.
 	writer   func(status *client.SolveStatus)
@@ -28,46 +31,91 @@ type Reporter struct {
 	token   string
 	client  cliv1connect.BuildServiceClient
 
+	bufferSize    int
+	flushInterval time.Duration
+	sampler       *sampler
+
 	ch chan *client.SolveStatus
+	// done is closed when Run returns.
+	done chan struct{}
 
 	closed bool
 	mu     sync.Mutex
 }
 
-func NewReporter(ctx context.Context, w progress.Writer, buildID, token string) *Reporter {
-	r := &Reporter{
-		writer:   w.Write,
-		validate: w.ValidateLogSource,
-		clear:    w.ClearLogSource,
-		buildID:  buildID,
-		token:    token,
-		client:   depotapi.NewBuildClient(),
-		ch:       make(chan *client.SolveStatus, 16384),
+// ReporterOption configures a Reporter.
+type ReporterOption func(*Reporter)
+
+// WithBufferSize sets how many statuses are queued for the API before new
+// statuses are dropped (default 16384).
+func WithBufferSize(n int) ReporterOption {
+	return func(r *Reporter) {
+		if n > 0 {
+			r.bufferSize = n
+		}
+	}
+}
+
+// WithFlushInterval sets how long statuses are buffered before they are sent,
+// and how long to wait before reconnecting a broken stream (default 1s).
+func WithFlushInterval(d time.Duration) ReporterOption {
+	return func(r *Reporter) {
+		if d > 0 {
+			r.flushInterval = d
+		}
+	}
+}
+
+// WithProgressSampling sends the progress of each vertex, such as the bytes of
+// a layer pull, at most once per interval.  Vertexes, logs, warnings, and
+// completed progress are always sent.
+func WithProgressSampling(interval time.Duration) ReporterOption {
+	return func(r *Reporter) {
+		if interval > 0 {
+			r.sampler = newSampler(interval)
+		}
 	}
+}
+
+func NewReporter(ctx context.Context, w progress.Writer, buildID, token string, opts ...ReporterOption) *Reporter {
+	r := newReporter(w.Write, buildID, token, opts)
+	r.validate = w.ValidateLogSource
+	r.clear = w.ClearLogSource
 	go r.Run(ctx)
 
 	return r
 }
 
-func NewReporterFromLogger(ctx context.Context, w progress.Logger, buildID, token string) *Reporter {
-	r := &Reporter{
-		writer:  w,
-		buildID: buildID,
-		token:   token,
-		client:  depotapi.NewBuildClient(),
-		ch:      make(chan *client.SolveStatus, 16384),
-	}
+func NewReporterFromLogger(ctx context.Context, w progress.Logger, buildID, token string, opts ...ReporterOption) *Reporter {
+	r := newReporter(w, buildID, token, opts)
 	go r.Run(ctx)
 
 	return r
 }
 
+func newReporter(writer func(*client.SolveStatus), buildID, token string, opts []ReporterOption) *Reporter {
+	r := &Reporter{
+		writer:        writer,
+		buildID:       buildID,
+		token:         token,
+		client:        depotapi.NewBuildClient(),
+		bufferSize:    16384,
+		flushInterval: time.Second,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.ch = make(chan *client.SolveStatus, r.bufferSize)
+	r.done = make(chan struct{})
+	return r
+}
+
 func (r *Reporter) Write(status *client.SolveStatus) {
 	r.writer(status)
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	if r.closed {
+	if r.closed || r.buildID == "" {
 		return
 	}
 
@@ -77,26 +125,39 @@ func (r *Reporter) Write(status *client.SolveStatus) {
 	}
 }
 
-// Make sure to call Close() after any call to Write.
+// Make sure to call Close() after any call to Write.  Close may be called
+// more than once.
 func (r *Reporter) Close() {
 	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
 	r.closed = true
-	r.mu.Unlock()
-
 	close(r.ch)
 }
 
+// Wait blocks until the statuses have been sent, which is after Close and
+// the cancellation of the context given to the Reporter.
+func (r *Reporter) Wait() {
+	<-r.done
+}
+
 func (r *Reporter) Run(ctx context.Context) {
+	defer close(r.done)
+	// Builds without an ID, such as builds on a local buildkitd, have nothing
+	// to report to.
+	if r.buildID == "" {
+		return
+	}
 	sender := r.client.ReportStatusStream(ctx)
 	sender.RequestHeader().Add("Authorization", "Bearer "+r.token)
 	defer func() {
 		_, _ = sender.CloseAndReceive()
 	}()
 
-	// Buffer 1 second before sending build timings to the server
-	const (
-		bufferTimeout = time.Second
-	)
+	// Buffer before sending build timings to the server.
+	bufferTimeout := r.flushInterval
 
 	// I'm using a timer here because I may need to retry sending data to the server.
 	// With a retry I need to track what data needs to be sent, however, because I
@@ -107,8 +168,18 @@ func (r *Reporter) Run(ctx context.Context) {
 
 	for {
 		select {
-		case status := <-r.ch:
-			if status == nil {
+		case status, ok := <-r.ch:
+			if !ok {
+				// Closed; send the remaining statuses on the open stream.
+				if len(statuses) > 0 {
+					_ = sender.Send(&cliv1.ReportStatusStreamRequest{
+						BuildId:  r.buildID,
+						Statuses: statuses,
+					})
+				}
+				return
+			}
+			if status = r.sampler.sample(status); status == nil {
 				continue
 			}
 
@@ -146,7 +217,7 @@ func (r *Reporter) Run(ctx context.Context) {
 		case <-ctx.Done():
 			// Attempt to send any remaining statuses.  This is best effort.  If it fails, we'll just give up.
 			for status := range r.ch {
-				if status == nil {
+				if status = r.sampler.sample(status); status == nil {
 					continue
 				}
 				statuses = append(statuses, toStatusResponse(status))
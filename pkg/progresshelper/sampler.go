@@ -0,0 +1,51 @@
+package progresshelper
+
+import (
+	"time"
+
+	"github.com/moby/buildkit/client"
+)
+
+// sampler drops the progress updates of a vertex that arrive within an
+// interval of the last one sent.
+type sampler struct {
+	interval time.Duration
+	// sent is the timestamp of the last progress sent per ID.
+	sent map[string]time.Time
+}
+
+func newSampler(interval time.Duration) *sampler {
+	return &sampler{interval: interval, sent: map[string]time.Time{}}
+}
+
+// sample returns the status without the progress updates that are too
+// frequent, or nil if nothing is left.  A nil sampler keeps everything.
+func (s *sampler) sample(status *client.SolveStatus) *client.SolveStatus {
+	if s == nil || status == nil {
+		return status
+	}
+
+	statuses := make([]*client.VertexStatus, 0, len(status.Statuses))
+	for _, vs := range status.Statuses {
+		if vs.Completed != nil {
+			delete(s.sent, vs.ID)
+			statuses = append(statuses, vs)
+			continue
+		}
+		if last, ok := s.sent[vs.ID]; ok && vs.Timestamp.Sub(last) < s.interval {
+			continue
+		}
+		s.sent[vs.ID] = vs.Timestamp
+		statuses = append(statuses, vs)
+	}
+
+	if len(statuses) == len(status.Statuses) {
+		return status
+	}
+	if len(statuses) == 0 && len(status.Vertexes) == 0 && len(status.Logs) == 0 && len(status.Warnings) == 0 {
+		return nil
+	}
+	sampled := *status
+	sampled.Statuses = statuses
+	return &sampled
+}
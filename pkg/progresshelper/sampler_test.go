@@ -0,0 +1,43 @@
+package progresshelper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+)
+
+func TestSampler(t *testing.T) {
+	s := newSampler(time.Second)
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	progress := func(offset time.Duration, completed bool) *client.SolveStatus {
+		vs := &client.VertexStatus{ID: "layer", Timestamp: start.Add(offset)}
+		if completed {
+			tm := start.Add(offset)
+			vs.Completed = &tm
+		}
+		return &client.SolveStatus{Statuses: []*client.VertexStatus{vs}}
+	}
+
+	if s.sample(progress(0, false)) == nil {
+		t.Errorf("expected the first progress to be sent")
+	}
+	if s.sample(progress(500*time.Millisecond, false)) != nil {
+		t.Errorf("expected progress within the interval to be dropped")
+	}
+	if s.sample(progress(700*time.Millisecond, true)) == nil {
+		t.Errorf("expected completed progress to be sent")
+	}
+
+	status := progress(800*time.Millisecond, false)
+	status.Vertexes = []*client.Vertex{{Digest: digest.FromString("pull")}}
+	if got := s.sample(status); got == nil || len(got.Vertexes) != 1 {
+		t.Errorf("expected vertexes to be sent, got %v", got)
+	}
+
+	var none *sampler
+	if none.sample(progress(0, false)) == nil {
+		t.Errorf("expected a nil sampler to keep everything")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"sync/atomic"
 
 	"github.com/docker/buildx/util/progress"
+	"github.com/mattn/go-isatty"
 	"github.com/moby/buildkit/client"
 	"github.com/opencontainers/go-digest"
 )
@@ -23,8 +24,14 @@ type SharedPrinter struct {
 	wg      sync.WaitGroup
 	printer *progress.Printer
 	cancel  context.CancelFunc
+	tee     chan<- *client.SolveStatus
+
+	// plain is set when the progress is printed as plain text.
+	plain       bool
+	groupOutput bool
 
 	numPrinters atomic.Int32
+	numProjects atomic.Int32
 }
 
 func NewSharedPrinter(mode string) (*SharedPrinter, error) {
@@ -35,17 +42,26 @@ func NewSharedPrinter(mode string) (*SharedPrinter, error) {
 		return nil, err
 	}
 
+	plain := mode == progress.PrinterModePlain || (mode == progress.PrinterModeAuto && !isatty.IsTerminal(os.Stderr.Fd()))
+
 	return &SharedPrinter{
 		printer: printer,
 		cancel:  cancel,
+		plain:   plain,
 	}, nil
 }
 
+// GroupOutput holds back the progress of each bake target until the build of
+// its project finishes so that plain logs of concurrent targets are not
+// interleaved.  It must be called before any writes happen.
+func (w *SharedPrinter) GroupOutput() { w.groupOutput = true }
+
 // Add increments the reference count of the writer.
 // Each call to Add() should be matched with a call to Wait().
 func (w *SharedPrinter) Add() {
 	w.wg.Add(1)
 	w.numPrinters.Add(1)
+	w.numProjects.Add(1)
 }
 
 func (w *SharedPrinter) Wait() error {
@@ -68,8 +84,19 @@ func (w *SharedPrinter) Wait() error {
 	return nil
 }
 
-func (w *SharedPrinter) Write(status *client.SolveStatus) { w.printer.Write(status) }
-func (w *SharedPrinter) ClearLogSource(v interface{})     { w.printer.ClearLogSource(v) }
+// Tee sends a copy of every status written to the printer to ch.
+// It must be called before any writes happen.
+func (w *SharedPrinter) Tee(ch chan<- *client.SolveStatus) { w.tee = ch }
+
+func (w *SharedPrinter) Write(status *client.SolveStatus) {
+	if w.tee != nil {
+		s := *status
+		w.tee <- &s
+	}
+	w.printer.Write(status)
+}
+
+func (w *SharedPrinter) ClearLogSource(v interface{}) { w.printer.ClearLogSource(v) }
 func (w *SharedPrinter) ValidateLogSource(d digest.Digest, v interface{}) bool {
 	return w.printer.ValidateLogSource(d, v)
 }
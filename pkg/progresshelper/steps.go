@@ -0,0 +1,132 @@
+package progresshelper
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/docker/buildx/util/progress"
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+)
+
+// StepProgress summarizes the build steps seen in the progress of a build.
+type StepProgress struct {
+	Completed int
+	Total     int
+	// Current is the most recently started step that has not completed.
+	Current string
+}
+
+var (
+	stepsMu sync.Mutex
+	steps   = map[string]*buildSteps{}
+)
+
+type buildSteps struct {
+	vertexes map[digest.Digest]*client.Vertex
+	warnings []client.VertexWarning
+}
+
+// BuildSteps returns the step progress of a build tracked with TrackSteps.
+func BuildSteps(buildID string) StepProgress {
+	stepsMu.Lock()
+	defer stepsMu.Unlock()
+
+	var progress StepProgress
+	b, ok := steps[buildID]
+	if !ok {
+		return progress
+	}
+
+	var current *client.Vertex
+	for _, v := range b.vertexes {
+		progress.Total++
+		if v.Completed != nil {
+			progress.Completed++
+			continue
+		}
+		if v.Started != nil && (current == nil || v.Started.After(*current.Started)) {
+			current = v
+		}
+	}
+	if current != nil {
+		progress.Current = current.Name
+	}
+	return progress
+}
+
+// BuildVertexes returns the steps of a build tracked with TrackSteps.
+func BuildVertexes(buildID string) []client.Vertex {
+	stepsMu.Lock()
+	defer stepsMu.Unlock()
+
+	b, ok := steps[buildID]
+	if !ok {
+		return nil
+	}
+	vertexes := make([]client.Vertex, 0, len(b.vertexes))
+	for _, v := range b.vertexes {
+		vertexes = append(vertexes, *v)
+	}
+	return vertexes
+}
+
+// BuildWarnings returns the warnings of a build tracked with TrackSteps.
+func BuildWarnings(buildID string) []client.VertexWarning {
+	stepsMu.Lock()
+	defer stepsMu.Unlock()
+
+	b, ok := steps[buildID]
+	if !ok {
+		return nil
+	}
+	return append([]client.VertexWarning(nil), b.warnings...)
+}
+
+// ForgetSteps drops the steps of a finished build.
+func ForgetSteps(buildID string) {
+	stepsMu.Lock()
+	delete(steps, buildID)
+	stepsMu.Unlock()
+}
+
+// TrackSteps records the steps written to w for health reports of the build.
+func TrackSteps(w progress.Writer, buildID string) progress.Writer {
+	if buildID == "" {
+		return w
+	}
+
+	stepsMu.Lock()
+	if _, ok := steps[buildID]; !ok {
+		steps[buildID] = &buildSteps{vertexes: map[digest.Digest]*client.Vertex{}}
+	}
+	stepsMu.Unlock()
+
+	return &stepsWriter{Writer: w, buildID: buildID}
+}
+
+type stepsWriter struct {
+	progress.Writer
+	buildID string
+}
+
+func (w *stepsWriter) Write(status *client.SolveStatus) {
+	stepsMu.Lock()
+	b := steps[w.buildID]
+	for _, v := range status.Vertexes {
+		// Depot log lines are not build steps.
+		if v == nil || strings.HasPrefix(v.Name, "[depot]") {
+			continue
+		}
+		vertex := *v
+		b.vertexes[v.Digest] = &vertex
+	}
+	for _, w := range status.Warnings {
+		if w != nil {
+			b.warnings = append(b.warnings, *w)
+		}
+	}
+	stepsMu.Unlock()
+
+	w.Writer.Write(status)
+}
@@ -0,0 +1,87 @@
+package progresshelper
+
+import (
+	"sync"
+	"time"
+
+	"github.com/docker/buildx/util/progress"
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+)
+
+// TargetProgress summarizes the steps of one bake target.
+type TargetProgress struct {
+	Started   *time.Time
+	Completed *time.Time
+	Steps     int
+	Cached    int
+	// Incomplete is the number of steps that started but did not complete.
+	Incomplete int
+	Error      string
+}
+
+// TargetTracker records the progress of each target from the target name
+// prefix buildx adds to vertex names, e.g. "[api 2/4] RUN make".
+type TargetTracker struct {
+	progress.Writer
+
+	mu       sync.Mutex
+	targets  []string
+	vertexes map[string]map[digest.Digest]*client.Vertex
+}
+
+func NewTargetTracker(w progress.Writer, targets []string) *TargetTracker {
+	return &TargetTracker{
+		Writer:   w,
+		targets:  targets,
+		vertexes: map[string]map[digest.Digest]*client.Vertex{},
+	}
+}
+
+func (t *TargetTracker) Write(status *client.SolveStatus) {
+	t.mu.Lock()
+	for _, v := range status.Vertexes {
+		if v == nil {
+			continue
+		}
+		target, ok := targetOf(v.Name, t.targets)
+		if !ok {
+			continue
+		}
+		if t.vertexes[target] == nil {
+			t.vertexes[target] = map[digest.Digest]*client.Vertex{}
+		}
+		vertex := *v
+		t.vertexes[target][v.Digest] = &vertex
+	}
+	t.mu.Unlock()
+
+	t.Writer.Write(status)
+}
+
+// Progress returns the progress of the target.
+func (t *TargetTracker) Progress(target string) TargetProgress {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var p TargetProgress
+	for _, v := range t.vertexes[target] {
+		p.Steps++
+		if v.Cached {
+			p.Cached++
+		}
+		if v.Error != "" && p.Error == "" {
+			p.Error = v.Error
+		}
+		if v.Started != nil && (p.Started == nil || v.Started.Before(*p.Started)) {
+			p.Started = v.Started
+		}
+		if v.Completed != nil && (p.Completed == nil || v.Completed.After(*p.Completed)) {
+			p.Completed = v.Completed
+		}
+		if v.Started != nil && v.Completed == nil {
+			p.Incomplete++
+		}
+	}
+	return p
+}
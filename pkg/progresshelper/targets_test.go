@@ -0,0 +1,63 @@
+package progresshelper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moby/buildkit/client"
+)
+
+func TestTargetTrackerProgress(t *testing.T) {
+	t0 := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	t1, t2, t3 := t0.Add(time.Second), t0.Add(2*time.Second), t0.Add(3*time.Second)
+
+	out := &recordingWriter{}
+	tracker := NewTargetTracker(out, []string{"api", "web"})
+	tracker.Write(&client.SolveStatus{Vertexes: []*client.Vertex{
+		{Digest: "api1", Name: "[api 1/3] FROM alpine", Started: &t1, Completed: &t2, Cached: true},
+		{Digest: "api2", Name: "[api 2/3] RUN make", Started: &t0, Completed: &t3},
+		{Digest: "api3", Name: "[api 3/3] RUN test", Started: &t2},
+		{Digest: "web1", Name: "[web 1/1] RUN make", Started: &t1, Completed: &t2, Error: "exit code: 1"},
+		{Digest: "depot", Name: "[depot] build: url"},
+	}})
+	// A later status of a vertex replaces the earlier one.
+	tracker.Write(&client.SolveStatus{Vertexes: []*client.Vertex{
+		{Digest: "api3", Name: "[api 3/3] RUN test", Started: &t2, Completed: &t3},
+	}})
+
+	if len(out.statuses) != 2 {
+		t.Errorf("expected the statuses to be written through, got %d", len(out.statuses))
+	}
+
+	api := tracker.Progress("api")
+	if api.Steps != 3 || api.Cached != 1 || api.Incomplete != 0 || api.Error != "" {
+		t.Errorf("Progress(api) = %+v, want 3 steps, 1 cached", api)
+	}
+	if api.Started == nil || !api.Started.Equal(t0) || api.Completed == nil || !api.Completed.Equal(t3) {
+		t.Errorf("Progress(api) = %v to %v, want %v to %v", api.Started, api.Completed, t0, t3)
+	}
+
+	web := tracker.Progress("web")
+	if web.Steps != 1 || web.Error != "exit code: 1" {
+		t.Errorf("Progress(web) = %+v, want 1 failed step", web)
+	}
+
+	if p := tracker.Progress("missing"); p.Steps != 0 || p.Started != nil {
+		t.Errorf("Progress(missing) = %+v, want no steps", p)
+	}
+}
+
+func TestTargetTrackerIncomplete(t *testing.T) {
+	t0 := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tracker := NewTargetTracker(&recordingWriter{}, []string{"default"})
+	tracker.Write(&client.SolveStatus{Vertexes: []*client.Vertex{
+		// A single target owns every vertex, even without a prefix.
+		{Digest: "v1", Name: "[1/2] RUN make", Started: &t0},
+		{Digest: "v2", Name: "[2/2] RUN test"},
+	}})
+
+	p := tracker.Progress("default")
+	if p.Steps != 2 || p.Incomplete != 1 || p.Completed != nil {
+		t.Errorf("Progress(default) = %+v, want 2 steps with 1 incomplete", p)
+	}
+}
@@ -0,0 +1,183 @@
+package progresshelper
+
+import (
+	"hash/fnv"
+	"strings"
+	"sync"
+
+	"github.com/docker/buildx/util/progress"
+	"github.com/moby/buildkit/client"
+	"github.com/morikuni/aec"
+	"github.com/opencontainers/go-digest"
+)
+
+var targetColors = []aec.ANSI{
+	aec.CyanF,
+	aec.MagentaF,
+	aec.BlueF,
+	aec.GreenF,
+	aec.YellowF,
+	aec.LightCyanF,
+	aec.LightMagentaF,
+	aec.LightBlueF,
+	aec.LightGreenF,
+	aec.LightYellowF,
+}
+
+// targetColor assigns the same color to a target on every run.
+func targetColor(name string) aec.ANSI {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	return targetColors[h.Sum32()%uint32(len(targetColors))]
+}
+
+// TargetWriter prefixes the vertexes of a project's bake targets with the
+// project when several projects share the printer, colors the prefix of each
+// target, and, when grouping output, holds back the progress of each target
+// until Flush so that its log is printed contiguously.
+type TargetWriter struct {
+	progress.Writer
+
+	project       string
+	targets       []string
+	prefixProject bool
+	color         bool
+	group         bool
+
+	mu       sync.Mutex
+	digests  map[digest.Digest]string
+	buffered map[string][]*client.SolveStatus
+}
+
+// ForTargets returns the writer for the targets of one project of a bake.
+func (w *SharedPrinter) ForTargets(project string, targets []string) *TargetWriter {
+	// Targets are only prefixed with plain progress, which is colored by the
+	// same rule as the summaries.
+	return &TargetWriter{
+		Writer:        w,
+		project:       project,
+		targets:       targets,
+		prefixProject: w.numProjects.Load() > 1,
+		color:         w.plain && UseColor(progress.PrinterModePlain),
+		group:         w.groupOutput,
+		digests:       map[digest.Digest]string{},
+		buffered:      map[string][]*client.SolveStatus{},
+	}
+}
+
+func (w *TargetWriter) Write(status *client.SolveStatus) {
+	w.mu.Lock()
+	target := ""
+	var vertexes []*client.Vertex
+	for i, v := range status.Vertexes {
+		if v == nil {
+			continue
+		}
+		t, ok := targetOf(v.Name, w.targets)
+		if !ok {
+			continue
+		}
+		target = t
+		w.digests[v.Digest] = t
+
+		// The status and its vertexes are shared with the other writers of
+		// the build, so the labeled vertex is a copy.
+		if vertexes == nil {
+			vertexes = append([]*client.Vertex{}, status.Vertexes...)
+		}
+		labeled := *v
+		labeled.Name = w.label(v.Name, t)
+		vertexes[i] = &labeled
+	}
+	if vertexes != nil {
+		copied := *status
+		copied.Vertexes = vertexes
+		status = &copied
+	}
+	if target == "" {
+		target = w.statusTarget(status)
+	}
+
+	if w.group && target != "" {
+		w.buffered[target] = append(w.buffered[target], status)
+		w.mu.Unlock()
+		return
+	}
+	w.mu.Unlock()
+
+	w.Writer.Write(status)
+}
+
+// label adds the project and the color to the target prefix of a vertex name.
+func (w *TargetWriter) label(name, target string) string {
+	prefixed := len(w.targets) > 1
+	if w.prefixProject {
+		if prefixed {
+			// "[api 2/4] RUN make" becomes "[project/api 2/4] RUN make".
+			name = "[" + w.project + "/" + name[1:]
+		} else if strings.HasPrefix(name, "[") {
+			name = "[" + w.project + " " + name[1:]
+		} else {
+			name = "[" + w.project + "] " + name
+		}
+		prefixed = true
+	}
+
+	if !w.color || !prefixed {
+		return name
+	}
+	end := strings.Index(name, "]")
+	if end < 0 {
+		return name
+	}
+	return targetColor(w.project+"/"+target).Apply(name[:end+1]) + name[end+1:]
+}
+
+// statusTarget finds the target of statuses and logs of vertexes already seen.
+func (w *TargetWriter) statusTarget(status *client.SolveStatus) string {
+	for _, s := range status.Statuses {
+		if t, ok := w.digests[s.Vertex]; ok {
+			return t
+		}
+	}
+	for _, l := range status.Logs {
+		if t, ok := w.digests[l.Vertex]; ok {
+			return t
+		}
+	}
+	for _, warning := range status.Warnings {
+		if t, ok := w.digests[warning.Vertex]; ok {
+			return t
+		}
+	}
+	return ""
+}
+
+// Flush writes the held back progress of each target in order.
+func (w *TargetWriter) Flush() {
+	w.mu.Lock()
+	buffered := w.buffered
+	w.buffered = map[string][]*client.SolveStatus{}
+	w.mu.Unlock()
+
+	for _, target := range w.targets {
+		for _, status := range buffered[target] {
+			w.Writer.Write(status)
+		}
+	}
+}
+
+// targetOf returns the target of a vertex name from the target prefix buildx
+// adds to vertex names, e.g. "[api 2/4] RUN make".  Single target builds are
+// not prefixed.
+func targetOf(name string, targets []string) (string, bool) {
+	if len(targets) == 1 {
+		return targets[0], true
+	}
+	for _, target := range targets {
+		if strings.HasPrefix(name, "["+target+" ") || strings.HasPrefix(name, "["+target+"]") {
+			return target, true
+		}
+	}
+	return "", false
+}
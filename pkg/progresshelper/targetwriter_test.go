@@ -0,0 +1,62 @@
+package progresshelper
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+)
+
+type recordingWriter struct {
+	statuses []*client.SolveStatus
+}
+
+func (w *recordingWriter) Write(s *client.SolveStatus)                       { w.statuses = append(w.statuses, s) }
+func (w *recordingWriter) ValidateLogSource(digest.Digest, interface{}) bool { return true }
+func (w *recordingWriter) ClearLogSource(interface{})                        {}
+
+func TestTargetWriterGroupsOutput(t *testing.T) {
+	out := &recordingWriter{}
+	w := &TargetWriter{
+		Writer:        out,
+		project:       "proj",
+		targets:       []string{"api", "web"},
+		prefixProject: true,
+		group:         true,
+		digests:       map[digest.Digest]string{},
+		buffered:      map[string][]*client.SolveStatus{},
+	}
+
+	shared := &client.SolveStatus{Vertexes: []*client.Vertex{{Digest: "web1", Name: "[web 1/2] RUN make"}}}
+	w.Write(shared)
+	if name := shared.Vertexes[0].Name; name != "[web 1/2] RUN make" {
+		t.Errorf("expected the written vertex to keep its name, got %q", name)
+	}
+	w.Write(&client.SolveStatus{Vertexes: []*client.Vertex{{Digest: "api1", Name: "[api 1/2] RUN make"}}})
+	w.Write(&client.SolveStatus{Logs: []*client.VertexLog{{Vertex: "web1", Data: []byte("ok")}}})
+	w.Write(&client.SolveStatus{Vertexes: []*client.Vertex{{Digest: "depot", Name: "[depot] build: url"}}})
+
+	if len(out.statuses) != 1 || out.statuses[0].Vertexes[0].Name != "[depot] build: url" {
+		t.Fatalf("expected only the untargeted status to be written before Flush, got %d", len(out.statuses))
+	}
+
+	w.Flush()
+	var names []string
+	for _, s := range out.statuses[1:] {
+		if len(s.Vertexes) > 0 {
+			names = append(names, s.Vertexes[0].Name)
+		} else {
+			names = append(names, "log")
+		}
+	}
+	want := []string{"[proj/api 1/2] RUN make", "[proj/web 1/2] RUN make", "log"}
+	if len(names) != len(want) {
+		t.Fatalf("flushed %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("flushed %v, want %v", names, want)
+			break
+		}
+	}
+}
@@ -12,7 +12,11 @@ type tee struct {
 
 func (t *tee) Write(v *client.SolveStatus) {
 	v2 := *v
-	t.ch <- &v2
+	// Drop if the buffer is backed up.
+	select {
+	case t.ch <- &v2:
+	default:
+	}
 	t.Writer.Write(v)
 }
 
@@ -0,0 +1,231 @@
+package progresshelper
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/buildx/util/progress"
+	"github.com/mattn/go-isatty"
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+)
+
+const (
+	// rateSmoothing is the weight of the latest second in the transfer rate.
+	rateSmoothing = 0.3
+	// defaultStallTimeout is how long a transfer may make no progress before
+	// a warning is printed.  DEPOT_STALL_TIMEOUT overrides it in seconds.
+	defaultStallTimeout = 60 * time.Second
+
+	rateStatusID = "transfer rate/s"
+)
+
+var _ progress.Writer = (*TransferMonitor)(nil)
+
+// TransferMonitor shows the transfer rate of context uploads and layer pulls
+// and warns when a transfer stops making progress.
+type TransferMonitor struct {
+	progress.Writer
+
+	showRate     bool
+	stallTimeout time.Duration
+
+	mu        sync.Mutex
+	vertexes  map[digest.Digest]*vertexTransfers
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+type vertexTransfers struct {
+	transfers map[string]*transfer
+	rate      float64
+	// sampled is the number of bytes transferred at the last tick.
+	sampled  int64
+	started  time.Time
+	reported bool
+}
+
+type transfer struct {
+	current      int64
+	lastProgress time.Time
+	completed    bool
+	warned       bool
+}
+
+// NewTransferMonitor wraps w to track the transfers written to it.  The rate
+// is only shown on a tty.  Stop must be called before w is closed.
+func NewTransferMonitor(w progress.Writer, mode string) *TransferMonitor {
+	tty := mode == progress.PrinterModeTty || (mode == progress.PrinterModeAuto && isatty.IsTerminal(os.Stderr.Fd()))
+	m := newTransferMonitor(w, tty, stallTimeout())
+	go m.run()
+	return m
+}
+
+func newTransferMonitor(w progress.Writer, showRate bool, stallTimeout time.Duration) *TransferMonitor {
+	return &TransferMonitor{
+		Writer:       w,
+		showRate:     showRate,
+		stallTimeout: stallTimeout,
+		vertexes:     map[digest.Digest]*vertexTransfers{},
+		done:         make(chan struct{}),
+		stopped:      make(chan struct{}),
+	}
+}
+
+func stallTimeout() time.Duration {
+	if v := os.Getenv("DEPOT_STALL_TIMEOUT"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return defaultStallTimeout
+}
+
+func (m *TransferMonitor) Write(status *client.SolveStatus) {
+	now := time.Now()
+
+	m.mu.Lock()
+	for _, s := range status.Statuses {
+		if s == nil || transferKind(s.ID) == "" {
+			continue
+		}
+		v, ok := m.vertexes[s.Vertex]
+		if !ok {
+			v = &vertexTransfers{transfers: map[string]*transfer{}, started: now}
+			m.vertexes[s.Vertex] = v
+		}
+		t, ok := v.transfers[s.ID]
+		if !ok {
+			t = &transfer{lastProgress: now}
+			v.transfers[s.ID] = t
+		}
+		if s.Current != t.current {
+			t.current = s.Current
+			t.lastProgress = now
+			t.warned = false
+		}
+		t.completed = s.Completed != nil
+	}
+	m.mu.Unlock()
+
+	m.Writer.Write(status)
+}
+
+// Stop stops updating the rates and checking for stalled transfers.
+func (m *TransferMonitor) Stop() {
+	m.closeOnce.Do(func() { close(m.done) })
+	<-m.stopped
+}
+
+func (m *TransferMonitor) run() {
+	defer close(m.stopped)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.done:
+			return
+		case now := <-ticker.C:
+			if status := m.tick(now); status != nil {
+				m.Writer.Write(status)
+			}
+		}
+	}
+}
+
+// tick updates the rate of every vertex with transfers and warns about
+// transfers without progress for longer than the stall timeout.
+func (m *TransferMonitor) tick(now time.Time) *client.SolveStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status := &client.SolveStatus{}
+	for dgst, v := range m.vertexes {
+		var (
+			total  int64
+			active bool
+		)
+		for id, t := range v.transfers {
+			total += t.current
+			if t.completed {
+				continue
+			}
+			active = true
+
+			if m.stallTimeout > 0 && !t.warned && now.Sub(t.lastProgress) >= m.stallTimeout {
+				t.warned = true
+				status.Logs = append(status.Logs, &client.VertexLog{
+					Vertex:    dgst,
+					Stream:    2,
+					Data:      []byte(stallWarning(id, now.Sub(t.lastProgress))),
+					Timestamp: now,
+				})
+			}
+		}
+
+		if v.reported && !active {
+			continue
+		}
+		v.rate = rateSmoothing*float64(total-v.sampled) + (1-rateSmoothing)*v.rate
+		v.sampled = total
+		if !m.showRate {
+			continue
+		}
+
+		started := v.started
+		rate := &client.VertexStatus{
+			ID:        rateStatusID,
+			Vertex:    dgst,
+			Current:   int64(math.Round(v.rate)),
+			Timestamp: now,
+			Started:   &started,
+		}
+		if !active {
+			// Once finished the average rate is more useful than the last one.
+			if elapsed := now.Sub(v.started).Seconds(); elapsed > 0 {
+				rate.Current = int64(float64(total) / elapsed)
+			}
+			rate.Completed = &now
+			v.reported = true
+		}
+		status.Statuses = append(status.Statuses, rate)
+	}
+
+	if len(status.Statuses) == 0 && len(status.Logs) == 0 {
+		return nil
+	}
+	return status
+}
+
+const (
+	transferUpload = "upload"
+	transferPull   = "pull"
+)
+
+// transferKind returns whether a status is an upload of local files to the
+// builder, a layer pull on the builder, or neither.
+func transferKind(id string) string {
+	switch {
+	case strings.HasPrefix(id, "transferring "):
+		return transferUpload
+	case strings.HasPrefix(id, "sha256:"):
+		return transferPull
+	default:
+		return ""
+	}
+}
+
+func stallWarning(id string, stalled time.Duration) string {
+	hint := "the builder may be unable to reach the registry, or the registry is slow or rate limiting pulls"
+	if transferKind(id) == transferUpload {
+		hint = "check the network connection, VPN, or proxy between this machine and the builder"
+	}
+	return fmt.Sprintf("[depot] warning: no progress on %s for %s; %s\n", strings.TrimSuffix(id, ":"), stalled.Round(time.Second), hint)
+}
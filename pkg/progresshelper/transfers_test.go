@@ -0,0 +1,57 @@
+package progresshelper
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+)
+
+func TestTransferMonitor(t *testing.T) {
+	m := newTransferMonitor(&recordingWriter{}, true, 30*time.Second)
+
+	vertex := digest.FromString("context")
+	m.Write(&client.SolveStatus{Statuses: []*client.VertexStatus{{ID: "transferring context:", Vertex: vertex, Current: 1000}}})
+	start := m.vertexes[vertex].transfers["transferring context:"].lastProgress
+
+	status := m.tick(start.Add(time.Second))
+	if len(status.Statuses) != 1 || status.Statuses[0].ID != rateStatusID || status.Statuses[0].Current != 300 {
+		t.Fatalf("tick() = %+v, want a rate of 300", status.Statuses)
+	}
+	if len(status.Logs) != 0 {
+		t.Errorf("unexpected stall warning %s", status.Logs[0].Data)
+	}
+
+	status = m.tick(start.Add(31 * time.Second))
+	if len(status.Logs) != 1 || !strings.Contains(string(status.Logs[0].Data), "no progress on transferring context for 31s; check the network") {
+		t.Fatalf("expected a stall warning, got %+v", status.Logs)
+	}
+	if status = m.tick(start.Add(32 * time.Second)); len(status.Logs) != 0 {
+		t.Errorf("stall warned twice")
+	}
+
+	now := time.Now()
+	m.Write(&client.SolveStatus{Statuses: []*client.VertexStatus{{ID: "transferring context:", Vertex: vertex, Current: 2000, Completed: &now}}})
+	status = m.tick(start.Add(33 * time.Second))
+	if len(status.Statuses) != 1 || status.Statuses[0].Completed == nil {
+		t.Fatalf("expected the completed rate, got %+v", status.Statuses)
+	}
+	if status = m.tick(start.Add(34 * time.Second)); status != nil {
+		t.Errorf("unexpected status after completion: %+v", status)
+	}
+}
+
+func TestTransferKind(t *testing.T) {
+	for id, want := range map[string]string{
+		"transferring dockerfile:": transferUpload,
+		"sha256:abc":               transferPull,
+		"extracting sha256:abc":    "",
+		"resolve image config":     "",
+	} {
+		if got := transferKind(id); got != want {
+			t.Errorf("transferKind(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
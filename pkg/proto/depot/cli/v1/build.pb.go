@@ -197,6 +197,8 @@ type CreateBuildRequest struct {
 	// This is an option per build target; in other words many for bake and one for build.
 	Options        []*BuildOptions                    `protobuf:"bytes,2,rep,name=options,proto3" json:"options,omitempty"`
 	RequiredEngine *CreateBuildRequest_RequiredEngine `protobuf:"bytes,3,opt,name=required_engine,json=requiredEngine,proto3,oneof" json:"required_engine,omitempty"`
+	// The CI environment the build was started from, used to group builds by pull request and pipeline.
+	Ci *CIContext `protobuf:"bytes,4,opt,name=ci,proto3,oneof" json:"ci,omitempty"`
 }
 
 func (x *CreateBuildRequest) Reset() {
@@ -252,6 +254,117 @@ func (x *CreateBuildRequest) GetRequiredEngine() *CreateBuildRequest_RequiredEng
 	return nil
 }
 
+func (x *CreateBuildRequest) GetCi() *CIContext {
+	if x != nil {
+		return x.Ci
+	}
+	return nil
+}
+
+type CIContext struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the CI provider such as "GitHub Actions".
+	Provider    string  `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	PullRequest *string `protobuf:"bytes,2,opt,name=pull_request,json=pullRequest,proto3,oneof" json:"pull_request,omitempty"`
+	WorkflowId  *string `protobuf:"bytes,3,opt,name=workflow_id,json=workflowId,proto3,oneof" json:"workflow_id,omitempty"`
+	JobId       *string `protobuf:"bytes,4,opt,name=job_id,json=jobId,proto3,oneof" json:"job_id,omitempty"`
+	CommitSha   *string `protobuf:"bytes,5,opt,name=commit_sha,json=commitSha,proto3,oneof" json:"commit_sha,omitempty"`
+	Actor       *string `protobuf:"bytes,6,opt,name=actor,proto3,oneof" json:"actor,omitempty"`
+	Ref         *string `protobuf:"bytes,7,opt,name=ref,proto3,oneof" json:"ref,omitempty"`
+	Url         *string `protobuf:"bytes,8,opt,name=url,proto3,oneof" json:"url,omitempty"`
+}
+
+func (x *CIContext) Reset() {
+	*x = CIContext{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_depot_cli_v1_build_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CIContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CIContext) ProtoMessage() {}
+
+func (x *CIContext) ProtoReflect() protoreflect.Message {
+	mi := &file_depot_cli_v1_build_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CIContext.ProtoReflect.Descriptor instead.
+func (*CIContext) Descriptor() ([]byte, []int) {
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CIContext) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *CIContext) GetPullRequest() string {
+	if x != nil && x.PullRequest != nil {
+		return *x.PullRequest
+	}
+	return ""
+}
+
+func (x *CIContext) GetWorkflowId() string {
+	if x != nil && x.WorkflowId != nil {
+		return *x.WorkflowId
+	}
+	return ""
+}
+
+func (x *CIContext) GetJobId() string {
+	if x != nil && x.JobId != nil {
+		return *x.JobId
+	}
+	return ""
+}
+
+func (x *CIContext) GetCommitSha() string {
+	if x != nil && x.CommitSha != nil {
+		return *x.CommitSha
+	}
+	return ""
+}
+
+func (x *CIContext) GetActor() string {
+	if x != nil && x.Actor != nil {
+		return *x.Actor
+	}
+	return ""
+}
+
+func (x *CIContext) GetRef() string {
+	if x != nil && x.Ref != nil {
+		return *x.Ref
+	}
+	return ""
+}
+
+func (x *CIContext) GetUrl() string {
+	if x != nil && x.Url != nil {
+		return *x.Url
+	}
+	return ""
+}
+
 type BuildOptions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -270,12 +383,14 @@ type BuildOptions struct {
 	Lint bool `protobuf:"varint,7,opt,name=lint,proto3" json:"lint,omitempty"`
 	// Save to depot registry.
 	Save bool `protobuf:"varint,8,opt,name=save,proto3" json:"save,omitempty"`
+	// Entitlements requested with --allow, such as network.host.
+	Entitlements []string `protobuf:"bytes,9,rep,name=entitlements,proto3" json:"entitlements,omitempty"`
 }
 
 func (x *BuildOptions) Reset() {
 	*x = BuildOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[1]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -288,7 +403,7 @@ func (x *BuildOptions) String() string {
 func (*BuildOptions) ProtoMessage() {}
 
 func (x *BuildOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[1]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -301,7 +416,7 @@ func (x *BuildOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BuildOptions.ProtoReflect.Descriptor instead.
 func (*BuildOptions) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{1}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *BuildOptions) GetCommand() Command {
@@ -360,6 +475,13 @@ func (x *BuildOptions) GetSave() bool {
 	return false
 }
 
+func (x *BuildOptions) GetEntitlements() []string {
+	if x != nil {
+		return x.Entitlements
+	}
+	return nil
+}
+
 type BuildOutput struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -374,7 +496,7 @@ type BuildOutput struct {
 func (x *BuildOutput) Reset() {
 	*x = BuildOutput{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[2]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -387,7 +509,7 @@ func (x *BuildOutput) String() string {
 func (*BuildOutput) ProtoMessage() {}
 
 func (x *BuildOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[2]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -400,7 +522,7 @@ func (x *BuildOutput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BuildOutput.ProtoReflect.Descriptor instead.
 func (*BuildOutput) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{2}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *BuildOutput) GetKind() string {
@@ -431,12 +553,14 @@ type CreateBuildResponse struct {
 	ProjectId             string                            `protobuf:"bytes,6,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
 	AdditionalCredentials []*CreateBuildResponse_Credential `protobuf:"bytes,7,rep,name=additional_credentials,json=additionalCredentials,proto3" json:"additional_credentials,omitempty"`
 	AdditionalTags        []*CreateBuildResponse_Tag        `protobuf:"bytes,8,rep,name=additional_tags,json=additionalTags,proto3" json:"additional_tags,omitempty"`
+	// Entitlements requested by the build that the project's policy forbids.
+	ForbiddenEntitlements []string `protobuf:"bytes,9,rep,name=forbidden_entitlements,json=forbiddenEntitlements,proto3" json:"forbidden_entitlements,omitempty"`
 }
 
 func (x *CreateBuildResponse) Reset() {
 	*x = CreateBuildResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[3]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -449,7 +573,7 @@ func (x *CreateBuildResponse) String() string {
 func (*CreateBuildResponse) ProtoMessage() {}
 
 func (x *CreateBuildResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[3]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -462,7 +586,7 @@ func (x *CreateBuildResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateBuildResponse.ProtoReflect.Descriptor instead.
 func (*CreateBuildResponse) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{3}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CreateBuildResponse) GetBuildId() string {
@@ -521,6 +645,13 @@ func (x *CreateBuildResponse) GetAdditionalTags() []*CreateBuildResponse_Tag {
 	return nil
 }
 
+func (x *CreateBuildResponse) GetForbiddenEntitlements() []string {
+	if x != nil {
+		return x.ForbiddenEntitlements
+	}
+	return nil
+}
+
 type GetBuildRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -532,7 +663,7 @@ type GetBuildRequest struct {
 func (x *GetBuildRequest) Reset() {
 	*x = GetBuildRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[4]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -545,7 +676,7 @@ func (x *GetBuildRequest) String() string {
 func (*GetBuildRequest) ProtoMessage() {}
 
 func (x *GetBuildRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[4]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -558,7 +689,7 @@ func (x *GetBuildRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBuildRequest.ProtoReflect.Descriptor instead.
 func (*GetBuildRequest) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{4}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetBuildRequest) GetBuildId() string {
@@ -581,7 +712,7 @@ type GetBuildResponse struct {
 func (x *GetBuildResponse) Reset() {
 	*x = GetBuildResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[5]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -594,7 +725,7 @@ func (x *GetBuildResponse) String() string {
 func (*GetBuildResponse) ProtoMessage() {}
 
 func (x *GetBuildResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[5]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -607,7 +738,7 @@ func (x *GetBuildResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBuildResponse.ProtoReflect.Descriptor instead.
 func (*GetBuildResponse) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{5}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetBuildResponse) GetBuildId() string {
@@ -643,7 +774,7 @@ type Registry struct {
 func (x *Registry) Reset() {
 	*x = Registry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[6]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -656,7 +787,7 @@ func (x *Registry) String() string {
 func (*Registry) ProtoMessage() {}
 
 func (x *Registry) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[6]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -669,7 +800,7 @@ func (x *Registry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Registry.ProtoReflect.Descriptor instead.
 func (*Registry) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{6}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Registry) GetCanUseLocalRegistry() bool {
@@ -703,7 +834,7 @@ type FinishBuildRequest struct {
 func (x *FinishBuildRequest) Reset() {
 	*x = FinishBuildRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[7]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -716,7 +847,7 @@ func (x *FinishBuildRequest) String() string {
 func (*FinishBuildRequest) ProtoMessage() {}
 
 func (x *FinishBuildRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[7]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -729,7 +860,7 @@ func (x *FinishBuildRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FinishBuildRequest.ProtoReflect.Descriptor instead.
 func (*FinishBuildRequest) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{7}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *FinishBuildRequest) GetBuildId() string {
@@ -798,7 +929,7 @@ type FinishBuildResponse struct {
 func (x *FinishBuildResponse) Reset() {
 	*x = FinishBuildResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[8]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -811,7 +942,7 @@ func (x *FinishBuildResponse) String() string {
 func (*FinishBuildResponse) ProtoMessage() {}
 
 func (x *FinishBuildResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[8]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -824,7 +955,7 @@ func (x *FinishBuildResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FinishBuildResponse.ProtoReflect.Descriptor instead.
 func (*FinishBuildResponse) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{8}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{9}
 }
 
 type GetBuildKitConnectionRequest struct {
@@ -832,14 +963,15 @@ type GetBuildKitConnectionRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BuildId  string          `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
-	Platform BuilderPlatform `protobuf:"varint,2,opt,name=platform,proto3,enum=depot.cli.v1.BuilderPlatform" json:"platform,omitempty"`
+	BuildId   string          `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	Platform  BuilderPlatform `protobuf:"varint,2,opt,name=platform,proto3,enum=depot.cli.v1.BuilderPlatform" json:"platform,omitempty"`
+	Placement *Placement      `protobuf:"bytes,3,opt,name=placement,proto3,oneof" json:"placement,omitempty"`
 }
 
 func (x *GetBuildKitConnectionRequest) Reset() {
 	*x = GetBuildKitConnectionRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[9]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -852,7 +984,7 @@ func (x *GetBuildKitConnectionRequest) String() string {
 func (*GetBuildKitConnectionRequest) ProtoMessage() {}
 
 func (x *GetBuildKitConnectionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[9]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -865,7 +997,7 @@ func (x *GetBuildKitConnectionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBuildKitConnectionRequest.ProtoReflect.Descriptor instead.
 func (*GetBuildKitConnectionRequest) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{9}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetBuildKitConnectionRequest) GetBuildId() string {
@@ -882,6 +1014,142 @@ func (x *GetBuildKitConnectionRequest) GetPlatform() BuilderPlatform {
 	return BuilderPlatform_BUILDER_PLATFORM_UNSPECIFIED
 }
 
+func (x *GetBuildKitConnectionRequest) GetPlacement() *Placement {
+	if x != nil {
+		return x.Placement
+	}
+	return nil
+}
+
+// Placement hints for the machine of a build.  They are best effort: the
+// machine may be placed elsewhere when no capacity matches.
+type Placement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Region *string `protobuf:"bytes,1,opt,name=region,proto3,oneof" json:"region,omitempty"`
+	// Hosts, such as registries or CI runners, the machine should be close to.
+	Near []string `protobuf:"bytes,2,rep,name=near,proto3" json:"near,omitempty"`
+}
+
+func (x *Placement) Reset() {
+	*x = Placement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_depot_cli_v1_build_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Placement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Placement) ProtoMessage() {}
+
+func (x *Placement) ProtoReflect() protoreflect.Message {
+	mi := &file_depot_cli_v1_build_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Placement.ProtoReflect.Descriptor instead.
+func (*Placement) Descriptor() ([]byte, []int) {
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Placement) GetRegion() string {
+	if x != nil && x.Region != nil {
+		return *x.Region
+	}
+	return ""
+}
+
+func (x *Placement) GetNear() []string {
+	if x != nil {
+		return x.Near
+	}
+	return nil
+}
+
+type MachineInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MachineId    string `protobuf:"bytes,1,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	Region       string `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	InstanceType string `protobuf:"bytes,3,opt,name=instance_type,json=instanceType,proto3" json:"instance_type,omitempty"`
+	IpAddress    string `protobuf:"bytes,4,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+}
+
+func (x *MachineInfo) Reset() {
+	*x = MachineInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_depot_cli_v1_build_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MachineInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineInfo) ProtoMessage() {}
+
+func (x *MachineInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_depot_cli_v1_build_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineInfo.ProtoReflect.Descriptor instead.
+func (*MachineInfo) Descriptor() ([]byte, []int) {
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MachineInfo) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *MachineInfo) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *MachineInfo) GetInstanceType() string {
+	if x != nil {
+		return x.InstanceType
+	}
+	return ""
+}
+
+func (x *MachineInfo) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
 type GetBuildKitConnectionResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -897,7 +1165,7 @@ type GetBuildKitConnectionResponse struct {
 func (x *GetBuildKitConnectionResponse) Reset() {
 	*x = GetBuildKitConnectionResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[10]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -910,7 +1178,7 @@ func (x *GetBuildKitConnectionResponse) String() string {
 func (*GetBuildKitConnectionResponse) ProtoMessage() {}
 
 func (x *GetBuildKitConnectionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[10]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -923,7 +1191,7 @@ func (x *GetBuildKitConnectionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBuildKitConnectionResponse.ProtoReflect.Descriptor instead.
 func (*GetBuildKitConnectionResponse) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{10}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{13}
 }
 
 func (m *GetBuildKitConnectionResponse) GetConnection() isGetBuildKitConnectionResponse_Connection {
@@ -975,7 +1243,7 @@ type Cert struct {
 func (x *Cert) Reset() {
 	*x = Cert{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[11]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -988,7 +1256,7 @@ func (x *Cert) String() string {
 func (*Cert) ProtoMessage() {}
 
 func (x *Cert) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[11]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1001,7 +1269,7 @@ func (x *Cert) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Cert.ProtoReflect.Descriptor instead.
 func (*Cert) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{11}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Cert) GetCert() string {
@@ -1025,12 +1293,14 @@ type ReportBuildHealthRequest struct {
 
 	BuildId  string          `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
 	Platform BuilderPlatform `protobuf:"varint,2,opt,name=platform,proto3,enum=depot.cli.v1.BuilderPlatform" json:"platform,omitempty"`
+	// Progress of the build steps as seen by the CLI.
+	Progress *BuildStepProgress `protobuf:"bytes,3,opt,name=progress,proto3,oneof" json:"progress,omitempty"`
 }
 
 func (x *ReportBuildHealthRequest) Reset() {
 	*x = ReportBuildHealthRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[12]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1043,7 +1313,7 @@ func (x *ReportBuildHealthRequest) String() string {
 func (*ReportBuildHealthRequest) ProtoMessage() {}
 
 func (x *ReportBuildHealthRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[12]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1056,7 +1326,7 @@ func (x *ReportBuildHealthRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportBuildHealthRequest.ProtoReflect.Descriptor instead.
 func (*ReportBuildHealthRequest) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{12}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ReportBuildHealthRequest) GetBuildId() string {
@@ -1073,19 +1343,89 @@ func (x *ReportBuildHealthRequest) GetPlatform() BuilderPlatform {
 	return BuilderPlatform_BUILDER_PLATFORM_UNSPECIFIED
 }
 
-type ReportBuildHealthResponse struct {
+func (x *ReportBuildHealthRequest) GetProgress() *BuildStepProgress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+type BuildStepProgress struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Cancel the build after this time.
+	CompletedSteps int32   `protobuf:"varint,1,opt,name=completed_steps,json=completedSteps,proto3" json:"completed_steps,omitempty"`
+	TotalSteps     int32   `protobuf:"varint,2,opt,name=total_steps,json=totalSteps,proto3" json:"total_steps,omitempty"`
+	CurrentStep    *string `protobuf:"bytes,3,opt,name=current_step,json=currentStep,proto3,oneof" json:"current_step,omitempty"`
+}
+
+func (x *BuildStepProgress) Reset() {
+	*x = BuildStepProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_depot_cli_v1_build_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BuildStepProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildStepProgress) ProtoMessage() {}
+
+func (x *BuildStepProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_depot_cli_v1_build_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildStepProgress.ProtoReflect.Descriptor instead.
+func (*BuildStepProgress) Descriptor() ([]byte, []int) {
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BuildStepProgress) GetCompletedSteps() int32 {
+	if x != nil {
+		return x.CompletedSteps
+	}
+	return 0
+}
+
+func (x *BuildStepProgress) GetTotalSteps() int32 {
+	if x != nil {
+		return x.TotalSteps
+	}
+	return 0
+}
+
+func (x *BuildStepProgress) GetCurrentStep() string {
+	if x != nil && x.CurrentStep != nil {
+		return *x.CurrentStep
+	}
+	return ""
+}
+
+type ReportBuildHealthResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Cancel the build after this time.
 	CancelsAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=cancels_at,json=cancelsAt,proto3" json:"cancels_at,omitempty"`
 }
 
 func (x *ReportBuildHealthResponse) Reset() {
 	*x = ReportBuildHealthResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[13]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1098,7 +1438,7 @@ func (x *ReportBuildHealthResponse) String() string {
 func (*ReportBuildHealthResponse) ProtoMessage() {}
 
 func (x *ReportBuildHealthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[13]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1111,7 +1451,7 @@ func (x *ReportBuildHealthResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportBuildHealthResponse.ProtoReflect.Descriptor instead.
 func (*ReportBuildHealthResponse) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{13}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ReportBuildHealthResponse) GetCancelsAt() *timestamppb.Timestamp {
@@ -1133,7 +1473,7 @@ type ReportTimingsRequest struct {
 func (x *ReportTimingsRequest) Reset() {
 	*x = ReportTimingsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[14]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1146,7 +1486,7 @@ func (x *ReportTimingsRequest) String() string {
 func (*ReportTimingsRequest) ProtoMessage() {}
 
 func (x *ReportTimingsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[14]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1159,7 +1499,7 @@ func (x *ReportTimingsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportTimingsRequest.ProtoReflect.Descriptor instead.
 func (*ReportTimingsRequest) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{14}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ReportTimingsRequest) GetBuildId() string {
@@ -1185,7 +1525,7 @@ type ReportTimingsResponse struct {
 func (x *ReportTimingsResponse) Reset() {
 	*x = ReportTimingsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[15]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1198,7 +1538,7 @@ func (x *ReportTimingsResponse) String() string {
 func (*ReportTimingsResponse) ProtoMessage() {}
 
 func (x *ReportTimingsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[15]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1211,7 +1551,7 @@ func (x *ReportTimingsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportTimingsResponse.ProtoReflect.Descriptor instead.
 func (*ReportTimingsResponse) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{15}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{19}
 }
 
 type BuildStep struct {
@@ -1232,7 +1572,7 @@ type BuildStep struct {
 func (x *BuildStep) Reset() {
 	*x = BuildStep{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[16]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1245,7 +1585,7 @@ func (x *BuildStep) String() string {
 func (*BuildStep) ProtoMessage() {}
 
 func (x *BuildStep) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[16]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1258,7 +1598,7 @@ func (x *BuildStep) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BuildStep.ProtoReflect.Descriptor instead.
 func (*BuildStep) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{16}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *BuildStep) GetStartTime() *timestamppb.Timestamp {
@@ -1330,7 +1670,7 @@ type ReportStatusRequest struct {
 func (x *ReportStatusRequest) Reset() {
 	*x = ReportStatusRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[17]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1343,7 +1683,7 @@ func (x *ReportStatusRequest) String() string {
 func (*ReportStatusRequest) ProtoMessage() {}
 
 func (x *ReportStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[17]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1356,7 +1696,7 @@ func (x *ReportStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportStatusRequest.ProtoReflect.Descriptor instead.
 func (*ReportStatusRequest) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{17}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ReportStatusRequest) GetBuildId() string {
@@ -1389,7 +1729,7 @@ type ReportStatusResponse struct {
 func (x *ReportStatusResponse) Reset() {
 	*x = ReportStatusResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[18]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1402,7 +1742,7 @@ func (x *ReportStatusResponse) String() string {
 func (*ReportStatusResponse) ProtoMessage() {}
 
 func (x *ReportStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[18]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1415,7 +1755,7 @@ func (x *ReportStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportStatusResponse.ProtoReflect.Descriptor instead.
 func (*ReportStatusResponse) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{18}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{22}
 }
 
 type ReportStatusStreamRequest struct {
@@ -1431,7 +1771,7 @@ type ReportStatusStreamRequest struct {
 func (x *ReportStatusStreamRequest) Reset() {
 	*x = ReportStatusStreamRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[19]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1444,7 +1784,7 @@ func (x *ReportStatusStreamRequest) String() string {
 func (*ReportStatusStreamRequest) ProtoMessage() {}
 
 func (x *ReportStatusStreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[19]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1457,7 +1797,7 @@ func (x *ReportStatusStreamRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportStatusStreamRequest.ProtoReflect.Descriptor instead.
 func (*ReportStatusStreamRequest) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{19}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ReportStatusStreamRequest) GetBuildId() string {
@@ -1490,7 +1830,7 @@ type ReportStatusStreamResponse struct {
 func (x *ReportStatusStreamResponse) Reset() {
 	*x = ReportStatusStreamResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[20]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1503,7 +1843,7 @@ func (x *ReportStatusStreamResponse) String() string {
 func (*ReportStatusStreamResponse) ProtoMessage() {}
 
 func (x *ReportStatusStreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[20]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1516,7 +1856,7 @@ func (x *ReportStatusStreamResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportStatusStreamResponse.ProtoReflect.Descriptor instead.
 func (*ReportStatusStreamResponse) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{20}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{24}
 }
 
 type ListBuildsRequest struct {
@@ -1535,7 +1875,7 @@ type ListBuildsRequest struct {
 func (x *ListBuildsRequest) Reset() {
 	*x = ListBuildsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[21]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1548,7 +1888,7 @@ func (x *ListBuildsRequest) String() string {
 func (*ListBuildsRequest) ProtoMessage() {}
 
 func (x *ListBuildsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[21]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1561,7 +1901,7 @@ func (x *ListBuildsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListBuildsRequest.ProtoReflect.Descriptor instead.
 func (*ListBuildsRequest) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{21}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *ListBuildsRequest) GetProjectId() string {
@@ -1598,7 +1938,7 @@ type ListBuildsResponse struct {
 func (x *ListBuildsResponse) Reset() {
 	*x = ListBuildsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[22]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1611,7 +1951,7 @@ func (x *ListBuildsResponse) String() string {
 func (*ListBuildsResponse) ProtoMessage() {}
 
 func (x *ListBuildsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[22]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1624,7 +1964,7 @@ func (x *ListBuildsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListBuildsResponse.ProtoReflect.Descriptor instead.
 func (*ListBuildsResponse) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{22}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *ListBuildsResponse) GetBuilds() []*Build {
@@ -1651,12 +1991,16 @@ type Build struct {
 	Status     BuildStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=depot.cli.v1.BuildStatus" json:"status,omitempty"`
 	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	FinishedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	// The machine type the build ran on, such as "16 CPUs, 32 GB".
+	MachineType string `protobuf:"bytes,6,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
+	// The price per minute of the machine type in US dollars.
+	PricePerMinute float64 `protobuf:"fixed64,7,opt,name=price_per_minute,json=pricePerMinute,proto3" json:"price_per_minute,omitempty"`
 }
 
 func (x *Build) Reset() {
 	*x = Build{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[23]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1669,7 +2013,7 @@ func (x *Build) String() string {
 func (*Build) ProtoMessage() {}
 
 func (x *Build) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[23]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1682,7 +2026,7 @@ func (x *Build) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Build.ProtoReflect.Descriptor instead.
 func (*Build) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{23}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *Build) GetId() string {
@@ -1713,6 +2057,20 @@ func (x *Build) GetFinishedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Build) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+func (x *Build) GetPricePerMinute() float64 {
+	if x != nil {
+		return x.PricePerMinute
+	}
+	return 0
+}
+
 type PageToken struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1725,7 +2083,7 @@ type PageToken struct {
 func (x *PageToken) Reset() {
 	*x = PageToken{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[24]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1738,7 +2096,7 @@ func (x *PageToken) String() string {
 func (*PageToken) ProtoMessage() {}
 
 func (x *PageToken) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[24]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1751,7 +2109,7 @@ func (x *PageToken) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PageToken.ProtoReflect.Descriptor instead.
 func (*PageToken) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{24}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *PageToken) GetProjectId() string {
@@ -1775,12 +2133,14 @@ type ReportBuildContextRequest struct {
 
 	BuildId     string        `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
 	Dockerfiles []*Dockerfile `protobuf:"bytes,2,rep,name=dockerfiles,proto3" json:"dockerfiles,omitempty"`
+	// The bake and compose files that defined the build.
+	DefinitionFiles []*BuildDefinitionFile `protobuf:"bytes,3,rep,name=definition_files,json=definitionFiles,proto3" json:"definition_files,omitempty"`
 }
 
 func (x *ReportBuildContextRequest) Reset() {
 	*x = ReportBuildContextRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[25]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1793,7 +2153,7 @@ func (x *ReportBuildContextRequest) String() string {
 func (*ReportBuildContextRequest) ProtoMessage() {}
 
 func (x *ReportBuildContextRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[25]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1806,7 +2166,7 @@ func (x *ReportBuildContextRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportBuildContextRequest.ProtoReflect.Descriptor instead.
 func (*ReportBuildContextRequest) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{25}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *ReportBuildContextRequest) GetBuildId() string {
@@ -1823,6 +2183,13 @@ func (x *ReportBuildContextRequest) GetDockerfiles() []*Dockerfile {
 	return nil
 }
 
+func (x *ReportBuildContextRequest) GetDefinitionFiles() []*BuildDefinitionFile {
+	if x != nil {
+		return x.DefinitionFiles
+	}
+	return nil
+}
+
 type Dockerfile struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1831,12 +2198,14 @@ type Dockerfile struct {
 	Target   string `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
 	Filename string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
 	Contents string `protobuf:"bytes,3,opt,name=contents,proto3" json:"contents,omitempty"`
+	// The sha256 digest of the contents.
+	Digest string `protobuf:"bytes,4,opt,name=digest,proto3" json:"digest,omitempty"`
 }
 
 func (x *Dockerfile) Reset() {
 	*x = Dockerfile{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[26]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1849,7 +2218,7 @@ func (x *Dockerfile) String() string {
 func (*Dockerfile) ProtoMessage() {}
 
 func (x *Dockerfile) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[26]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1862,7 +2231,7 @@ func (x *Dockerfile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Dockerfile.ProtoReflect.Descriptor instead.
 func (*Dockerfile) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{26}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *Dockerfile) GetTarget() string {
@@ -1886,6 +2255,78 @@ func (x *Dockerfile) GetContents() string {
 	return ""
 }
 
+func (x *Dockerfile) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+// A bake or compose file as it was read by the CLI.
+type BuildDefinitionFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	// The sha256 digest of the contents.
+	Digest   string `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+	Contents string `protobuf:"bytes,3,opt,name=contents,proto3" json:"contents,omitempty"`
+}
+
+func (x *BuildDefinitionFile) Reset() {
+	*x = BuildDefinitionFile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_depot_cli_v1_build_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BuildDefinitionFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildDefinitionFile) ProtoMessage() {}
+
+func (x *BuildDefinitionFile) ProtoReflect() protoreflect.Message {
+	mi := &file_depot_cli_v1_build_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildDefinitionFile.ProtoReflect.Descriptor instead.
+func (*BuildDefinitionFile) Descriptor() ([]byte, []int) {
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *BuildDefinitionFile) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *BuildDefinitionFile) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *BuildDefinitionFile) GetContents() string {
+	if x != nil {
+		return x.Contents
+	}
+	return ""
+}
+
 type ReportBuildContextResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1895,7 +2336,7 @@ type ReportBuildContextResponse struct {
 func (x *ReportBuildContextResponse) Reset() {
 	*x = ReportBuildContextResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[27]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1908,7 +2349,7 @@ func (x *ReportBuildContextResponse) String() string {
 func (*ReportBuildContextResponse) ProtoMessage() {}
 
 func (x *ReportBuildContextResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[27]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1921,7 +2362,7 @@ func (x *ReportBuildContextResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportBuildContextResponse.ProtoReflect.Descriptor instead.
 func (*ReportBuildContextResponse) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{27}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{32}
 }
 
 type GetPullInfoRequest struct {
@@ -1935,7 +2376,7 @@ type GetPullInfoRequest struct {
 func (x *GetPullInfoRequest) Reset() {
 	*x = GetPullInfoRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[28]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1948,7 +2389,7 @@ func (x *GetPullInfoRequest) String() string {
 func (*GetPullInfoRequest) ProtoMessage() {}
 
 func (x *GetPullInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[28]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1961,7 +2402,7 @@ func (x *GetPullInfoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPullInfoRequest.ProtoReflect.Descriptor instead.
 func (*GetPullInfoRequest) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{28}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *GetPullInfoRequest) GetBuildId() string {
@@ -1985,7 +2426,7 @@ type GetPullInfoResponse struct {
 func (x *GetPullInfoResponse) Reset() {
 	*x = GetPullInfoResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[29]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1998,7 +2439,7 @@ func (x *GetPullInfoResponse) String() string {
 func (*GetPullInfoResponse) ProtoMessage() {}
 
 func (x *GetPullInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[29]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2011,7 +2452,7 @@ func (x *GetPullInfoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPullInfoResponse.ProtoReflect.Descriptor instead.
 func (*GetPullInfoResponse) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{29}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *GetPullInfoResponse) GetReference() string {
@@ -2054,7 +2495,7 @@ type GetPullTokenRequest struct {
 func (x *GetPullTokenRequest) Reset() {
 	*x = GetPullTokenRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[30]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2067,7 +2508,7 @@ func (x *GetPullTokenRequest) String() string {
 func (*GetPullTokenRequest) ProtoMessage() {}
 
 func (x *GetPullTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[30]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2080,7 +2521,7 @@ func (x *GetPullTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPullTokenRequest.ProtoReflect.Descriptor instead.
 func (*GetPullTokenRequest) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{30}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *GetPullTokenRequest) GetProjectId() string {
@@ -2103,12 +2544,16 @@ type GetPullTokenResponse struct {
 	unknownFields protoimpl.UnknownFields
 
 	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// When the token stops being accepted by the registry.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`
+	// Registry username to use with the token.
+	Username *string `protobuf:"bytes,3,opt,name=username,proto3,oneof" json:"username,omitempty"`
 }
 
 func (x *GetPullTokenResponse) Reset() {
 	*x = GetPullTokenResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[31]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2121,7 +2566,7 @@ func (x *GetPullTokenResponse) String() string {
 func (*GetPullTokenResponse) ProtoMessage() {}
 
 func (x *GetPullTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[31]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2134,7 +2579,7 @@ func (x *GetPullTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPullTokenResponse.ProtoReflect.Descriptor instead.
 func (*GetPullTokenResponse) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{31}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *GetPullTokenResponse) GetToken() string {
@@ -2144,35 +2589,46 @@ func (x *GetPullTokenResponse) GetToken() string {
 	return ""
 }
 
-type CreateBuildRequest_RequiredEngine struct {
+func (x *GetPullTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *GetPullTokenResponse) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+type GetTargetFingerprintsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Engine:
-	//
-	//	*CreateBuildRequest_RequiredEngine_Buildkit
-	//	*CreateBuildRequest_RequiredEngine_Dagger
-	Engine isCreateBuildRequest_RequiredEngine_Engine `protobuf_oneof:"engine"`
+	ProjectId    string   `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Fingerprints []string `protobuf:"bytes,2,rep,name=fingerprints,proto3" json:"fingerprints,omitempty"`
 }
 
-func (x *CreateBuildRequest_RequiredEngine) Reset() {
-	*x = CreateBuildRequest_RequiredEngine{}
+func (x *GetTargetFingerprintsRequest) Reset() {
+	*x = GetTargetFingerprintsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[32]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CreateBuildRequest_RequiredEngine) String() string {
+func (x *GetTargetFingerprintsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateBuildRequest_RequiredEngine) ProtoMessage() {}
+func (*GetTargetFingerprintsRequest) ProtoMessage() {}
 
-func (x *CreateBuildRequest_RequiredEngine) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[32]
+func (x *GetTargetFingerprintsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_depot_cli_v1_build_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2183,16 +2639,594 @@ func (x *CreateBuildRequest_RequiredEngine) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateBuildRequest_RequiredEngine.ProtoReflect.Descriptor instead.
-func (*CreateBuildRequest_RequiredEngine) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{0, 0}
+// Deprecated: Use GetTargetFingerprintsRequest.ProtoReflect.Descriptor instead.
+func (*GetTargetFingerprintsRequest) Descriptor() ([]byte, []int) {
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{37}
 }
 
-func (m *CreateBuildRequest_RequiredEngine) GetEngine() isCreateBuildRequest_RequiredEngine_Engine {
-	if m != nil {
-		return m.Engine
+func (x *GetTargetFingerprintsRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
 	}
-	return nil
+	return ""
+}
+
+func (x *GetTargetFingerprintsRequest) GetFingerprints() []string {
+	if x != nil {
+		return x.Fingerprints
+	}
+	return nil
+}
+
+type GetTargetFingerprintsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The most recent successful build of each known fingerprint.
+	Targets []*TargetFingerprint `protobuf:"bytes,1,rep,name=targets,proto3" json:"targets,omitempty"`
+}
+
+func (x *GetTargetFingerprintsResponse) Reset() {
+	*x = GetTargetFingerprintsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_depot_cli_v1_build_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTargetFingerprintsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTargetFingerprintsResponse) ProtoMessage() {}
+
+func (x *GetTargetFingerprintsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_depot_cli_v1_build_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTargetFingerprintsResponse.ProtoReflect.Descriptor instead.
+func (*GetTargetFingerprintsResponse) Descriptor() ([]byte, []int) {
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetTargetFingerprintsResponse) GetTargets() []*TargetFingerprint {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+type TargetFingerprint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Target string `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	// Hash of the context, Dockerfile, and options of the target.
+	Fingerprint string `protobuf:"bytes,2,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	BuildId     string `protobuf:"bytes,3,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	// Digest of the image built for the target.
+	Digest string `protobuf:"bytes,4,opt,name=digest,proto3" json:"digest,omitempty"`
+}
+
+func (x *TargetFingerprint) Reset() {
+	*x = TargetFingerprint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_depot_cli_v1_build_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TargetFingerprint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetFingerprint) ProtoMessage() {}
+
+func (x *TargetFingerprint) ProtoReflect() protoreflect.Message {
+	mi := &file_depot_cli_v1_build_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TargetFingerprint.ProtoReflect.Descriptor instead.
+func (*TargetFingerprint) Descriptor() ([]byte, []int) {
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *TargetFingerprint) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *TargetFingerprint) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *TargetFingerprint) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *TargetFingerprint) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+type ReportTargetFingerprintsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BuildId string               `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	Targets []*TargetFingerprint `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
+}
+
+func (x *ReportTargetFingerprintsRequest) Reset() {
+	*x = ReportTargetFingerprintsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_depot_cli_v1_build_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportTargetFingerprintsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportTargetFingerprintsRequest) ProtoMessage() {}
+
+func (x *ReportTargetFingerprintsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_depot_cli_v1_build_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportTargetFingerprintsRequest.ProtoReflect.Descriptor instead.
+func (*ReportTargetFingerprintsRequest) Descriptor() ([]byte, []int) {
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ReportTargetFingerprintsRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *ReportTargetFingerprintsRequest) GetTargets() []*TargetFingerprint {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+type ReportTargetFingerprintsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ReportTargetFingerprintsResponse) Reset() {
+	*x = ReportTargetFingerprintsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_depot_cli_v1_build_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportTargetFingerprintsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportTargetFingerprintsResponse) ProtoMessage() {}
+
+func (x *ReportTargetFingerprintsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_depot_cli_v1_build_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportTargetFingerprintsResponse.ProtoReflect.Descriptor instead.
+func (*ReportTargetFingerprintsResponse) Descriptor() ([]byte, []int) {
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{41}
+}
+
+type ListBuildArtifactsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BuildId string `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+}
+
+func (x *ListBuildArtifactsRequest) Reset() {
+	*x = ListBuildArtifactsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_depot_cli_v1_build_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListBuildArtifactsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBuildArtifactsRequest) ProtoMessage() {}
+
+func (x *ListBuildArtifactsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_depot_cli_v1_build_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBuildArtifactsRequest.ProtoReflect.Descriptor instead.
+func (*ListBuildArtifactsRequest) Descriptor() ([]byte, []int) {
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListBuildArtifactsRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+type ListBuildArtifactsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Artifacts []*BuildArtifact `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+}
+
+func (x *ListBuildArtifactsResponse) Reset() {
+	*x = ListBuildArtifactsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_depot_cli_v1_build_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListBuildArtifactsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBuildArtifactsResponse) ProtoMessage() {}
+
+func (x *ListBuildArtifactsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_depot_cli_v1_build_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBuildArtifactsResponse.ProtoReflect.Descriptor instead.
+func (*ListBuildArtifactsResponse) Descriptor() ([]byte, []int) {
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListBuildArtifactsResponse) GetArtifacts() []*BuildArtifact {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+type BuildArtifact struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path of the artifact, e.g. "sbom/linux-amd64.spdx.json".
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// "sbom", "provenance", "export", or "log".
+	Kind      string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	SizeBytes int64  `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Digest    string `protobuf:"bytes,4,opt,name=digest,proto3" json:"digest,omitempty"`
+	// Pre-signed URL the artifact can be downloaded from without a token.
+	DownloadUrl string `protobuf:"bytes,5,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"`
+	// The bake target that produced the artifact, if any.
+	Target *string `protobuf:"bytes,6,opt,name=target,proto3,oneof" json:"target,omitempty"`
+}
+
+func (x *BuildArtifact) Reset() {
+	*x = BuildArtifact{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_depot_cli_v1_build_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BuildArtifact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildArtifact) ProtoMessage() {}
+
+func (x *BuildArtifact) ProtoReflect() protoreflect.Message {
+	mi := &file_depot_cli_v1_build_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildArtifact.ProtoReflect.Descriptor instead.
+func (*BuildArtifact) Descriptor() ([]byte, []int) {
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *BuildArtifact) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BuildArtifact) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *BuildArtifact) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *BuildArtifact) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *BuildArtifact) GetDownloadUrl() string {
+	if x != nil {
+		return x.DownloadUrl
+	}
+	return ""
+}
+
+func (x *BuildArtifact) GetTarget() string {
+	if x != nil && x.Target != nil {
+		return *x.Target
+	}
+	return ""
+}
+
+type GetCacheImportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BuildId string `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	// The project whose cache the build reads, which must belong to the same organization.
+	ProjectId string `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+}
+
+func (x *GetCacheImportRequest) Reset() {
+	*x = GetCacheImportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_depot_cli_v1_build_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCacheImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCacheImportRequest) ProtoMessage() {}
+
+func (x *GetCacheImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_depot_cli_v1_build_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCacheImportRequest.ProtoReflect.Descriptor instead.
+func (*GetCacheImportRequest) Descriptor() ([]byte, []int) {
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetCacheImportRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *GetCacheImportRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type GetCacheImportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The cache importer and its attributes, as given to --cache-from.
+	Type  string            `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Attrs map[string]string `protobuf:"bytes,2,rep,name=attrs,proto3" json:"attrs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Registry credentials that allow the build to read the cache.
+	Credentials []*CreateBuildResponse_Credential `protobuf:"bytes,3,rep,name=credentials,proto3" json:"credentials,omitempty"`
+}
+
+func (x *GetCacheImportResponse) Reset() {
+	*x = GetCacheImportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_depot_cli_v1_build_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCacheImportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCacheImportResponse) ProtoMessage() {}
+
+func (x *GetCacheImportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_depot_cli_v1_build_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCacheImportResponse.ProtoReflect.Descriptor instead.
+func (*GetCacheImportResponse) Descriptor() ([]byte, []int) {
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetCacheImportResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GetCacheImportResponse) GetAttrs() map[string]string {
+	if x != nil {
+		return x.Attrs
+	}
+	return nil
+}
+
+func (x *GetCacheImportResponse) GetCredentials() []*CreateBuildResponse_Credential {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+type CreateBuildRequest_RequiredEngine struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Engine:
+	//
+	//	*CreateBuildRequest_RequiredEngine_Buildkit
+	//	*CreateBuildRequest_RequiredEngine_Dagger
+	Engine isCreateBuildRequest_RequiredEngine_Engine `protobuf_oneof:"engine"`
+}
+
+func (x *CreateBuildRequest_RequiredEngine) Reset() {
+	*x = CreateBuildRequest_RequiredEngine{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_depot_cli_v1_build_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateBuildRequest_RequiredEngine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBuildRequest_RequiredEngine) ProtoMessage() {}
+
+func (x *CreateBuildRequest_RequiredEngine) ProtoReflect() protoreflect.Message {
+	mi := &file_depot_cli_v1_build_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBuildRequest_RequiredEngine.ProtoReflect.Descriptor instead.
+func (*CreateBuildRequest_RequiredEngine) Descriptor() ([]byte, []int) {
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (m *CreateBuildRequest_RequiredEngine) GetEngine() isCreateBuildRequest_RequiredEngine_Engine {
+	if m != nil {
+		return m.Engine
+	}
+	return nil
 }
 
 func (x *CreateBuildRequest_RequiredEngine) GetBuildkit() *CreateBuildRequest_RequiredEngine_BuildKitEngine {
@@ -2234,7 +3268,7 @@ type CreateBuildRequest_RequiredEngine_BuildKitEngine struct {
 func (x *CreateBuildRequest_RequiredEngine_BuildKitEngine) Reset() {
 	*x = CreateBuildRequest_RequiredEngine_BuildKitEngine{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[33]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2247,7 +3281,7 @@ func (x *CreateBuildRequest_RequiredEngine_BuildKitEngine) String() string {
 func (*CreateBuildRequest_RequiredEngine_BuildKitEngine) ProtoMessage() {}
 
 func (x *CreateBuildRequest_RequiredEngine_BuildKitEngine) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[33]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2274,7 +3308,7 @@ type CreateBuildRequest_RequiredEngine_DaggerEngine struct {
 func (x *CreateBuildRequest_RequiredEngine_DaggerEngine) Reset() {
 	*x = CreateBuildRequest_RequiredEngine_DaggerEngine{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[34]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2287,7 +3321,7 @@ func (x *CreateBuildRequest_RequiredEngine_DaggerEngine) String() string {
 func (*CreateBuildRequest_RequiredEngine_DaggerEngine) ProtoMessage() {}
 
 func (x *CreateBuildRequest_RequiredEngine_DaggerEngine) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[34]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2321,7 +3355,7 @@ type CreateBuildResponse_Profiler struct {
 func (x *CreateBuildResponse_Profiler) Reset() {
 	*x = CreateBuildResponse_Profiler{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[36]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[51]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2334,7 +3368,7 @@ func (x *CreateBuildResponse_Profiler) String() string {
 func (*CreateBuildResponse_Profiler) ProtoMessage() {}
 
 func (x *CreateBuildResponse_Profiler) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[36]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2347,7 +3381,7 @@ func (x *CreateBuildResponse_Profiler) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateBuildResponse_Profiler.ProtoReflect.Descriptor instead.
 func (*CreateBuildResponse_Profiler) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{3, 0}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{4, 0}
 }
 
 func (x *CreateBuildResponse_Profiler) GetToken() string {
@@ -2369,7 +3403,7 @@ type CreateBuildResponse_Credential struct {
 func (x *CreateBuildResponse_Credential) Reset() {
 	*x = CreateBuildResponse_Credential{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[37]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2382,7 +3416,7 @@ func (x *CreateBuildResponse_Credential) String() string {
 func (*CreateBuildResponse_Credential) ProtoMessage() {}
 
 func (x *CreateBuildResponse_Credential) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[37]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2395,7 +3429,7 @@ func (x *CreateBuildResponse_Credential) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateBuildResponse_Credential.ProtoReflect.Descriptor instead.
 func (*CreateBuildResponse_Credential) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{3, 1}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{4, 1}
 }
 
 func (x *CreateBuildResponse_Credential) GetHost() string {
@@ -2424,7 +3458,7 @@ type CreateBuildResponse_Tag struct {
 func (x *CreateBuildResponse_Tag) Reset() {
 	*x = CreateBuildResponse_Tag{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[38]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2437,7 +3471,7 @@ func (x *CreateBuildResponse_Tag) String() string {
 func (*CreateBuildResponse_Tag) ProtoMessage() {}
 
 func (x *CreateBuildResponse_Tag) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[38]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2450,7 +3484,7 @@ func (x *CreateBuildResponse_Tag) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateBuildResponse_Tag.ProtoReflect.Descriptor instead.
 func (*CreateBuildResponse_Tag) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{3, 2}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{4, 2}
 }
 
 func (x *CreateBuildResponse_Tag) GetTag() string {
@@ -2476,7 +3510,7 @@ type FinishBuildRequest_BuildSuccess struct {
 func (x *FinishBuildRequest_BuildSuccess) Reset() {
 	*x = FinishBuildRequest_BuildSuccess{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[39]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2489,7 +3523,7 @@ func (x *FinishBuildRequest_BuildSuccess) String() string {
 func (*FinishBuildRequest_BuildSuccess) ProtoMessage() {}
 
 func (x *FinishBuildRequest_BuildSuccess) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[39]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2502,7 +3536,7 @@ func (x *FinishBuildRequest_BuildSuccess) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FinishBuildRequest_BuildSuccess.ProtoReflect.Descriptor instead.
 func (*FinishBuildRequest_BuildSuccess) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{7, 0}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{8, 0}
 }
 
 type FinishBuildRequest_BuildError struct {
@@ -2516,7 +3550,7 @@ type FinishBuildRequest_BuildError struct {
 func (x *FinishBuildRequest_BuildError) Reset() {
 	*x = FinishBuildRequest_BuildError{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[40]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[55]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2529,7 +3563,7 @@ func (x *FinishBuildRequest_BuildError) String() string {
 func (*FinishBuildRequest_BuildError) ProtoMessage() {}
 
 func (x *FinishBuildRequest_BuildError) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[40]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[55]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2542,7 +3576,7 @@ func (x *FinishBuildRequest_BuildError) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FinishBuildRequest_BuildError.ProtoReflect.Descriptor instead.
 func (*FinishBuildRequest_BuildError) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{7, 1}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{8, 1}
 }
 
 func (x *FinishBuildRequest_BuildError) GetError() string {
@@ -2561,7 +3595,7 @@ type FinishBuildRequest_BuildCanceled struct {
 func (x *FinishBuildRequest_BuildCanceled) Reset() {
 	*x = FinishBuildRequest_BuildCanceled{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[41]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[56]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2574,7 +3608,7 @@ func (x *FinishBuildRequest_BuildCanceled) String() string {
 func (*FinishBuildRequest_BuildCanceled) ProtoMessage() {}
 
 func (x *FinishBuildRequest_BuildCanceled) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[41]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[56]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2587,7 +3621,7 @@ func (x *FinishBuildRequest_BuildCanceled) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FinishBuildRequest_BuildCanceled.ProtoReflect.Descriptor instead.
 func (*FinishBuildRequest_BuildCanceled) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{7, 2}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{8, 2}
 }
 
 type GetBuildKitConnectionResponse_PendingConnection struct {
@@ -2601,7 +3635,7 @@ type GetBuildKitConnectionResponse_PendingConnection struct {
 func (x *GetBuildKitConnectionResponse_PendingConnection) Reset() {
 	*x = GetBuildKitConnectionResponse_PendingConnection{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[42]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[57]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2614,7 +3648,7 @@ func (x *GetBuildKitConnectionResponse_PendingConnection) String() string {
 func (*GetBuildKitConnectionResponse_PendingConnection) ProtoMessage() {}
 
 func (x *GetBuildKitConnectionResponse_PendingConnection) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[42]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[57]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2627,7 +3661,7 @@ func (x *GetBuildKitConnectionResponse_PendingConnection) ProtoReflect() protore
 
 // Deprecated: Use GetBuildKitConnectionResponse_PendingConnection.ProtoReflect.Descriptor instead.
 func (*GetBuildKitConnectionResponse_PendingConnection) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{10, 0}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{13, 0}
 }
 
 func (x *GetBuildKitConnectionResponse_PendingConnection) GetWaitMs() int32 {
@@ -2651,12 +3685,15 @@ type GetBuildKitConnectionResponse_ActiveConnection struct {
 	//	*GetBuildKitConnectionResponse_ActiveConnection_Identity_
 	//	*GetBuildKitConnectionResponse_ActiveConnection_Gzip_
 	Compressor isGetBuildKitConnectionResponse_ActiveConnection_Compressor `protobuf_oneof:"compressor"`
+	// WebSocket URL tunneling the endpoint over port 443 for networks that block the endpoint's port.
+	WebsocketEndpoint *string      `protobuf:"bytes,7,opt,name=websocket_endpoint,json=websocketEndpoint,proto3,oneof" json:"websocket_endpoint,omitempty"`
+	Machine           *MachineInfo `protobuf:"bytes,8,opt,name=machine,proto3,oneof" json:"machine,omitempty"`
 }
 
 func (x *GetBuildKitConnectionResponse_ActiveConnection) Reset() {
 	*x = GetBuildKitConnectionResponse_ActiveConnection{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[43]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[58]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2669,7 +3706,7 @@ func (x *GetBuildKitConnectionResponse_ActiveConnection) String() string {
 func (*GetBuildKitConnectionResponse_ActiveConnection) ProtoMessage() {}
 
 func (x *GetBuildKitConnectionResponse_ActiveConnection) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[43]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[58]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2682,7 +3719,7 @@ func (x *GetBuildKitConnectionResponse_ActiveConnection) ProtoReflect() protoref
 
 // Deprecated: Use GetBuildKitConnectionResponse_ActiveConnection.ProtoReflect.Descriptor instead.
 func (*GetBuildKitConnectionResponse_ActiveConnection) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{10, 1}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{13, 1}
 }
 
 func (x *GetBuildKitConnectionResponse_ActiveConnection) GetEndpoint() string {
@@ -2734,6 +3771,20 @@ func (x *GetBuildKitConnectionResponse_ActiveConnection) GetGzip() *GetBuildKitC
 	return nil
 }
 
+func (x *GetBuildKitConnectionResponse_ActiveConnection) GetWebsocketEndpoint() string {
+	if x != nil && x.WebsocketEndpoint != nil {
+		return *x.WebsocketEndpoint
+	}
+	return ""
+}
+
+func (x *GetBuildKitConnectionResponse_ActiveConnection) GetMachine() *MachineInfo {
+	if x != nil {
+		return x.Machine
+	}
+	return nil
+}
+
 type isGetBuildKitConnectionResponse_ActiveConnection_Compressor interface {
 	isGetBuildKitConnectionResponse_ActiveConnection_Compressor()
 }
@@ -2761,7 +3812,7 @@ type GetBuildKitConnectionResponse_ActiveConnection_Identity struct {
 func (x *GetBuildKitConnectionResponse_ActiveConnection_Identity) Reset() {
 	*x = GetBuildKitConnectionResponse_ActiveConnection_Identity{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[44]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[59]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2774,7 +3825,7 @@ func (x *GetBuildKitConnectionResponse_ActiveConnection_Identity) String() strin
 func (*GetBuildKitConnectionResponse_ActiveConnection_Identity) ProtoMessage() {}
 
 func (x *GetBuildKitConnectionResponse_ActiveConnection_Identity) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[44]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[59]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2787,7 +3838,7 @@ func (x *GetBuildKitConnectionResponse_ActiveConnection_Identity) ProtoReflect()
 
 // Deprecated: Use GetBuildKitConnectionResponse_ActiveConnection_Identity.ProtoReflect.Descriptor instead.
 func (*GetBuildKitConnectionResponse_ActiveConnection_Identity) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{10, 1, 0}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{13, 1, 0}
 }
 
 type GetBuildKitConnectionResponse_ActiveConnection_Gzip struct {
@@ -2799,7 +3850,7 @@ type GetBuildKitConnectionResponse_ActiveConnection_Gzip struct {
 func (x *GetBuildKitConnectionResponse_ActiveConnection_Gzip) Reset() {
 	*x = GetBuildKitConnectionResponse_ActiveConnection_Gzip{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_depot_cli_v1_build_proto_msgTypes[45]
+		mi := &file_depot_cli_v1_build_proto_msgTypes[60]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2812,7 +3863,7 @@ func (x *GetBuildKitConnectionResponse_ActiveConnection_Gzip) String() string {
 func (*GetBuildKitConnectionResponse_ActiveConnection_Gzip) ProtoMessage() {}
 
 func (x *GetBuildKitConnectionResponse_ActiveConnection_Gzip) ProtoReflect() protoreflect.Message {
-	mi := &file_depot_cli_v1_build_proto_msgTypes[45]
+	mi := &file_depot_cli_v1_build_proto_msgTypes[60]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2825,7 +3876,7 @@ func (x *GetBuildKitConnectionResponse_ActiveConnection_Gzip) ProtoReflect() pro
 
 // Deprecated: Use GetBuildKitConnectionResponse_ActiveConnection_Gzip.ProtoReflect.Descriptor instead.
 func (*GetBuildKitConnectionResponse_ActiveConnection_Gzip) Descriptor() ([]byte, []int) {
-	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{10, 1, 1}
+	return file_depot_cli_v1_build_proto_rawDescGZIP(), []int{13, 1, 1}
 }
 
 var File_depot_cli_v1_build_proto protoreflect.FileDescriptor
@@ -2837,7 +3888,7 @@ var file_depot_cli_v1_build_proto_rawDesc = []byte{
 	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
 	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x6d, 0x6f, 0x62, 0x79, 0x2f,
 	0x62, 0x75, 0x69, 0x6c, 0x64, 0x6b, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6e, 0x74,
-	0x72, 0x6f, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xff, 0x03, 0x0a, 0x12, 0x43, 0x72,
+	0x72, 0x6f, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb4, 0x04, 0x0a, 0x12, 0x43, 0x72,
 	0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x12, 0x22, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49,
@@ -2850,440 +3901,637 @@ var file_depot_cli_v1_build_proto_rawDesc = []byte{
 	0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65,
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x45, 0x6e,
 	0x67, 0x69, 0x6e, 0x65, 0x48, 0x01, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
-	0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x88, 0x01, 0x01, 0x1a, 0x8c, 0x02, 0x0a, 0x0e, 0x52, 0x65,
-	0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x5c, 0x0a, 0x08,
-	0x62, 0x75, 0x69, 0x6c, 0x64, 0x6b, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e,
-	0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x2e, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e,
-	0x42, 0x75, 0x69, 0x6c, 0x64, 0x4b, 0x69, 0x74, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x48, 0x00,
-	0x52, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x6b, 0x69, 0x74, 0x12, 0x56, 0x0a, 0x06, 0x64, 0x61,
-	0x67, 0x67, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x64, 0x65, 0x70,
+	0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2c, 0x0a, 0x02, 0x63, 0x69, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x49, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x48, 0x02,
+	0x52, 0x02, 0x63, 0x69, 0x88, 0x01, 0x01, 0x1a, 0x8c, 0x02, 0x0a, 0x0e, 0x52, 0x65, 0x71, 0x75,
+	0x69, 0x72, 0x65, 0x64, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x5c, 0x0a, 0x08, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x6b, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x64,
+	0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x52,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x4b, 0x69, 0x74, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x48, 0x00, 0x52, 0x08,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x6b, 0x69, 0x74, 0x12, 0x56, 0x0a, 0x06, 0x64, 0x61, 0x67, 0x67,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74,
+	0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x69,
+	0x72, 0x65, 0x64, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x44, 0x61, 0x67, 0x67, 0x65, 0x72,
+	0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x48, 0x00, 0x52, 0x06, 0x64, 0x61, 0x67, 0x67, 0x65, 0x72,
+	0x1a, 0x10, 0x0a, 0x0e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4b, 0x69, 0x74, 0x45, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x1a, 0x28, 0x0a, 0x0c, 0x44, 0x61, 0x67, 0x67, 0x65, 0x72, 0x45, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0x0a, 0x06,
+	0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x70, 0x72, 0x6f, 0x6a, 0x65,
+	0x63, 0x74, 0x5f, 0x69, 0x64, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72,
+	0x65, 0x64, 0x5f, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x42, 0x05, 0x0a, 0x03, 0x5f, 0x63, 0x69,
+	0x22, 0xd3, 0x02, 0x0a, 0x09, 0x43, 0x49, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0c, 0x70, 0x75,
+	0x6c, 0x6c, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x0b, 0x70, 0x75, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x88,
+	0x01, 0x01, 0x12, 0x24, 0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x66,
+	0x6c, 0x6f, 0x77, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x1a, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f,
+	0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49,
+	0x64, 0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x73,
+	0x68, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x03, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x53, 0x68, 0x61, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x61, 0x63, 0x74, 0x6f,
+	0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x04, 0x52, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72,
+	0x88, 0x01, 0x01, 0x12, 0x15, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x05, 0x52, 0x03, 0x72, 0x65, 0x66, 0x88, 0x01, 0x01, 0x12, 0x15, 0x0a, 0x03, 0x75, 0x72,
+	0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x48, 0x06, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x88, 0x01,
+	0x01, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x70, 0x75, 0x6c, 0x6c, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x77, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x5f,
+	0x69, 0x64, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x42, 0x0d, 0x0a,
+	0x0b, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x73, 0x68, 0x61, 0x42, 0x08, 0x0a, 0x06,
+	0x5f, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x72, 0x65, 0x66, 0x42, 0x06,
+	0x0a, 0x04, 0x5f, 0x75, 0x72, 0x6c, 0x22, 0xb2, 0x02, 0x0a, 0x0c, 0x42, 0x75, 0x69, 0x6c, 0x64,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2f, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74,
+	0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52,
+	0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x33, 0x0a, 0x07,
+	0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x75, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x70, 0x75, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x24, 0x0a, 0x0b, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
+	0x52, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12,
+	0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6c,
+	0x69, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x61, 0x76, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x04, 0x73, 0x61, 0x76, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x65,
+	0x6e, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x42, 0x0e, 0x0a, 0x0c, 0x5f,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xab, 0x01, 0x0a, 0x0b,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12,
+	0x49, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x41,
+	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a,
+	0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x41, 0x74,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x8e, 0x05, 0x0a, 0x13, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x32, 0x0a,
+	0x08, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x52, 0x08, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
+	0x79, 0x12, 0x4b, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x72, 0x48,
+	0x00, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x1b,
+	0x0a, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x55, 0x72, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x63, 0x0a, 0x16, 0x61, 0x64,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x61, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x64, 0x65, 0x70,
 	0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x52, 0x65, 0x71,
-	0x75, 0x69, 0x72, 0x65, 0x64, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x44, 0x61, 0x67, 0x67,
-	0x65, 0x72, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x48, 0x00, 0x52, 0x06, 0x64, 0x61, 0x67, 0x67,
-	0x65, 0x72, 0x1a, 0x10, 0x0a, 0x0e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4b, 0x69, 0x74, 0x45, 0x6e,
-	0x67, 0x69, 0x6e, 0x65, 0x1a, 0x28, 0x0a, 0x0c, 0x44, 0x61, 0x67, 0x67, 0x65, 0x72, 0x45, 0x6e,
-	0x67, 0x69, 0x6e, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x08,
-	0x0a, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x70, 0x72, 0x6f,
-	0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x72, 0x65, 0x71, 0x75,
-	0x69, 0x72, 0x65, 0x64, 0x5f, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x22, 0x8e, 0x02, 0x0a, 0x0c,
-	0x42, 0x75, 0x69, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2f, 0x0a, 0x07,
-	0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e,
-	0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d,
-	0x6d, 0x61, 0x6e, 0x64, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a,
-	0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67,
-	0x73, 0x12, 0x33, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76,
-	0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x07, 0x6f,
-	0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x75, 0x73, 0x68, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x70, 0x75, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f,
-	0x61, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x24,
-	0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4e, 0x61, 0x6d,
-	0x65, 0x88, 0x01, 0x01, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x61, 0x76, 0x65,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x73, 0x61, 0x76, 0x65, 0x42, 0x0e, 0x0a, 0x0c,
-	0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xab, 0x01, 0x0a,
-	0x0b, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04,
-	0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64,
-	0x12, 0x49, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69,
-	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e,
-	0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x41,
-	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xd7, 0x04, 0x0a, 0x13, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x1f, 0x0a,
-	0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x32,
-	0x0a, 0x08, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x16, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e,
-	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x52, 0x08, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
-	0x72, 0x79, 0x12, 0x4b, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x72, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69,
-	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x72,
-	0x48, 0x00, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12,
-	0x1b, 0x0a, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x55, 0x72, 0x6c, 0x12, 0x1d, 0x0a, 0x0a,
-	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x63, 0x0a, 0x16, 0x61,
-	0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e,
-	0x74, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x64, 0x65,
-	0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43,
-	0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x15, 0x61, 0x64, 0x64, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73,
-	0x12, 0x4e, 0x0a, 0x0f, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x74,
-	0x61, 0x67, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x64, 0x65, 0x70, 0x6f,
-	0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42,
-	0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x54, 0x61, 0x67,
-	0x52, 0x0e, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x54, 0x61, 0x67, 0x73,
-	0x1a, 0x20, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05,
-	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b,
-	0x65, 0x6e, 0x1a, 0x36, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
-	0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x68, 0x6f, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x1a, 0x2b, 0x0a, 0x03, 0x54, 0x61,
-	0x67, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x74, 0x61, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x75, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x04, 0x70, 0x75, 0x73, 0x68, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x70, 0x72, 0x6f, 0x66,
-	0x69, 0x6c, 0x65, 0x72, 0x22, 0x2c, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64,
-	0x49, 0x64, 0x22, 0x69, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49,
-	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64,
-	0x12, 0x1b, 0x0a, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x55, 0x72, 0x6c, 0x22, 0x60, 0x0a,
-	0x08, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x12, 0x33, 0x0a, 0x16, 0x63, 0x61, 0x6e,
-	0x5f, 0x75, 0x73, 0x65, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x72, 0x65, 0x67, 0x69, 0x73,
-	0x74, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x63, 0x61, 0x6e, 0x55, 0x73,
-	0x65, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x12, 0x1f,
-	0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x22,
-	0xdc, 0x02, 0x0a, 0x12, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49,
-	0x64, 0x12, 0x49, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76,
-	0x31, 0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73,
-	0x73, 0x48, 0x00, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x43, 0x0a, 0x05,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x64, 0x65,
-	0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73,
-	0x68, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x42, 0x75,
-	0x69, 0x6c, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x12, 0x4c, 0x0a, 0x08, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e,
-	0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x61, 0x6e, 0x63, 0x65,
-	0x6c, 0x65, 0x64, 0x48, 0x00, 0x52, 0x08, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64, 0x1a,
-	0x0e, 0x0a, 0x0c, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x1a,
-	0x22, 0x0a, 0x0a, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a,
-	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72,
-	0x72, 0x6f, 0x72, 0x1a, 0x0f, 0x0a, 0x0d, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x61, 0x6e, 0x63,
-	0x65, 0x6c, 0x65, 0x64, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x15,
-	0x0a, 0x13, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x74, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c,
-	0x64, 0x4b, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64,
-	0x12, 0x39, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76,
-	0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72,
-	0x6d, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x22, 0x95, 0x05, 0x0a, 0x1d,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x72,
+	0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x15, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12,
+	0x4e, 0x0a, 0x0f, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x74, 0x61,
+	0x67, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74,
+	0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x54, 0x61, 0x67, 0x52,
+	0x0e, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x54, 0x61, 0x67, 0x73, 0x12,
+	0x35, 0x0a, 0x16, 0x66, 0x6f, 0x72, 0x62, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x15, 0x66, 0x6f, 0x72, 0x62, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x6c,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x20, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x1a, 0x36, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x1a, 0x2b, 0x0a, 0x03, 0x54, 0x61, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x75, 0x73,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x70, 0x75, 0x73, 0x68, 0x42, 0x0b, 0x0a,
+	0x09, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x72, 0x22, 0x2c, 0x0a, 0x0f, 0x47, 0x65,
+	0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x22, 0x69, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65,
+	0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f,
+	0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f,
+	0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x55, 0x72, 0x6c, 0x22, 0x60, 0x0a, 0x08, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x12,
+	0x33, 0x0a, 0x16, 0x63, 0x61, 0x6e, 0x5f, 0x75, 0x73, 0x65, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x5f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x13, 0x63, 0x61, 0x6e, 0x55, 0x73, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x72, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x5f, 0x69, 0x6d,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x78, 0x79,
+	0x49, 0x6d, 0x61, 0x67, 0x65, 0x22, 0xdc, 0x02, 0x0a, 0x12, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x49, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74,
+	0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64,
+	0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x12, 0x43, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x2b, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x4c, 0x0a, 0x08, 0x63, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x64, 0x65, 0x70, 0x6f,
+	0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64, 0x48, 0x00, 0x52, 0x08, 0x63, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x65, 0x64, 0x1a, 0x0e, 0x0a, 0x0c, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x53, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x1a, 0x22, 0x0a, 0x0a, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x1a, 0x0f, 0x0a, 0x0d, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x22, 0x15, 0x0a, 0x13, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xbe, 0x01, 0x0a, 0x1c,
 	0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4b, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a,
-	0x07, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3d,
-	0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
-	0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4b, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x65, 0x6e, 0x64,
-	0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52,
-	0x07, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x56, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69,
-	0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74,
-	0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64,
-	0x4b, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65,
-	0x1a, 0x2c, 0x0a, 0x11, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x6d, 0x73,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x77, 0x61, 0x69, 0x74, 0x4d, 0x73, 0x1a, 0x84,
-	0x03, 0x0a, 0x10, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12,
-	0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65,
-	0x12, 0x26, 0x0a, 0x04, 0x63, 0x65, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66,
+	0x6f, 0x72, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x64, 0x65, 0x70, 0x6f,
+	0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72,
+	0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f,
+	0x72, 0x6d, 0x12, 0x3a, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x00,
+	0x52, 0x09, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x42, 0x0c,
+	0x0a, 0x0a, 0x5f, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x47, 0x0a, 0x09,
+	0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x06, 0x72, 0x65, 0x67,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x67,
+	0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x65, 0x61, 0x72, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x65, 0x61, 0x72, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x72,
+	0x65, 0x67, 0x69, 0x6f, 0x6e, 0x22, 0x88, 0x01, 0x0a, 0x0b, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e,
+	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x61, 0x63, 0x68, 0x69,
+	0x6e, 0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x70, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x22, 0xa6, 0x06, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4b, 0x69, 0x74,
+	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x59, 0x0a, 0x07, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x3d, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4b, 0x69, 0x74, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x48, 0x00, 0x52, 0x07, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x56, 0x0a,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3c, 0x2e,
+	0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x4b, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x06, 0x61,
+	0x63, 0x74, 0x69, 0x76, 0x65, 0x1a, 0x2c, 0x0a, 0x11, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x77, 0x61,
+	0x69, 0x74, 0x5f, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x77, 0x61, 0x69,
+	0x74, 0x4d, 0x73, 0x1a, 0x95, 0x04, 0x0a, 0x10, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x04, 0x63, 0x65, 0x72, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x65, 0x72, 0x74, 0x52, 0x04, 0x63, 0x65, 0x72, 0x74, 0x12, 0x2b, 0x0a,
+	0x07, 0x63, 0x61, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
 	0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x65,
-	0x72, 0x74, 0x52, 0x04, 0x63, 0x65, 0x72, 0x74, 0x12, 0x2b, 0x0a, 0x07, 0x63, 0x61, 0x5f, 0x63,
-	0x65, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x64, 0x65, 0x70, 0x6f,
-	0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x65, 0x72, 0x74, 0x52, 0x06, 0x63,
-	0x61, 0x43, 0x65, 0x72, 0x74, 0x12, 0x63, 0x0a, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74,
-	0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x45, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e,
-	0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4b,
-	0x69, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x48, 0x00,
-	0x52, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x57, 0x0a, 0x04, 0x67, 0x7a,
-	0x69, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x41, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74,
-	0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64,
-	0x4b, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x47, 0x7a, 0x69, 0x70, 0x48, 0x00, 0x52, 0x04, 0x67,
-	0x7a, 0x69, 0x70, 0x1a, 0x0a, 0x0a, 0x08, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x1a,
-	0x06, 0x0a, 0x04, 0x47, 0x7a, 0x69, 0x70, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x72,
-	0x65, 0x73, 0x73, 0x6f, 0x72, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x22, 0x2c, 0x0a, 0x04, 0x43, 0x65, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63,
-	0x65, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x65, 0x72, 0x74, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x22, 0x70, 0x0a, 0x18, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64,
-	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a,
-	0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74,
-	0x66, 0x6f, 0x72, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x64, 0x65, 0x70,
-	0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65,
-	0x72, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66,
-	0x6f, 0x72, 0x6d, 0x22, 0x56, 0x0a, 0x19, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x69,
-	0x6c, 0x64, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x39, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x52, 0x09, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x73, 0x41, 0x74, 0x22, 0x6b, 0x0a, 0x14, 0x52,
-	0x65, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x38,
-	0x0a, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e,
-	0x76, 0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x53, 0x74, 0x65, 0x70, 0x52, 0x0a, 0x62, 0x75,
-	0x69, 0x6c, 0x64, 0x53, 0x74, 0x65, 0x70, 0x73, 0x22, 0x17, 0x0a, 0x15, 0x52, 0x65, 0x70, 0x6f,
-	0x72, 0x74, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0xc4, 0x02, 0x0a, 0x09, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x53, 0x74, 0x65, 0x70, 0x12,
-	0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x72, 0x74, 0x52, 0x06, 0x63, 0x61, 0x43, 0x65, 0x72, 0x74, 0x12, 0x63, 0x0a, 0x08, 0x69, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x45, 0x2e, 0x64,
+	0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x4b, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12,
+	0x57, 0x0a, 0x04, 0x67, 0x7a, 0x69, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x41, 0x2e,
+	0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x4b, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x47, 0x7a, 0x69, 0x70,
+	0x48, 0x00, 0x52, 0x04, 0x67, 0x7a, 0x69, 0x70, 0x12, 0x32, 0x0a, 0x12, 0x77, 0x65, 0x62, 0x73,
+	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x11, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x38, 0x0a, 0x07,
+	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x48, 0x02, 0x52, 0x07, 0x6d, 0x61, 0x63, 0x68,
+	0x69, 0x6e, 0x65, 0x88, 0x01, 0x01, 0x1a, 0x0a, 0x0a, 0x08, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x1a, 0x06, 0x0a, 0x04, 0x47, 0x7a, 0x69, 0x70, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x6f,
+	0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x77, 0x65, 0x62,
+	0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x42,
+	0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x42, 0x0c, 0x0a, 0x0a, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2c, 0x0a, 0x04, 0x43, 0x65, 0x72,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x65, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x63, 0x65, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0xbf, 0x01, 0x0a, 0x18, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12,
+	0x39, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1d, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d,
+	0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x40, 0x0a, 0x08, 0x70, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x64,
+	0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x53, 0x74, 0x65, 0x70, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52,
+	0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x88, 0x01, 0x01, 0x42, 0x0b, 0x0a, 0x09,
+	0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x22, 0x96, 0x01, 0x0a, 0x11, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x53, 0x74, 0x65, 0x70, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x65,
+	0x70, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x53, 0x74, 0x65, 0x70, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x5f, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x53, 0x74, 0x65, 0x70, 0x73, 0x12, 0x26, 0x0a, 0x0c, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x74, 0x65, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x00, 0x52, 0x0b, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x65, 0x70, 0x88, 0x01,
+	0x01, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x74,
+	0x65, 0x70, 0x22, 0x56, 0x0a, 0x19, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x39, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
-	0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x75,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x0a, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x19, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
-	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d, 0x73, 0x74,
-	0x61, 0x62, 0x6c, 0x65, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x48, 0x01, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73,
-	0x74, 0x88, 0x01, 0x01, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d,
-	0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x07, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74,
-	0x73, 0x12, 0x29, 0x0a, 0x10, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x5f, 0x64, 0x69,
-	0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x61, 0x6e, 0x63,
-	0x65, 0x73, 0x74, 0x6f, 0x72, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x42, 0x08, 0x0a, 0x06,
-	0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x73, 0x74, 0x61, 0x62, 0x6c,
-	0x65, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x8d, 0x02, 0x0a, 0x13, 0x52, 0x65, 0x70,
-	0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x3c, 0x0a, 0x08, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e,
-	0x6d, 0x6f, 0x62, 0x79, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x6b, 0x69, 0x74, 0x2e, 0x76, 0x31,
-	0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52,
-	0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x12, 0x5b, 0x0a, 0x0e, 0x73, 0x74, 0x61,
-	0x62, 0x6c, 0x65, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x34, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31,
-	0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73,
-	0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x44,
-	0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x1a, 0x40, 0x0a, 0x12, 0x53, 0x74, 0x61, 0x62, 0x6c, 0x65,
-	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x16, 0x0a, 0x14, 0x52, 0x65, 0x70, 0x6f,
-	0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x99, 0x02, 0x0a, 0x19, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19,
-	0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x3c, 0x0a, 0x08, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d, 0x6f,
-	0x62, 0x79, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x6b, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x08, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x12, 0x61, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x62, 0x6c,
-	0x65, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x3a, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52,
-	0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x44,
-	0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d, 0x73, 0x74, 0x61,
-	0x62, 0x6c, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x1a, 0x40, 0x0a, 0x12, 0x53, 0x74,
-	0x61, 0x62, 0x6c, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
-	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x1c, 0x0a, 0x1a,
-	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x74, 0x72, 0x65,
-	0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x6e, 0x0a, 0x11, 0x4c, 0x69,
-	0x73, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x1b,
-	0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
-	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x69, 0x0a, 0x12, 0x4c, 0x69,
-	0x73, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x2b, 0x0a, 0x06, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x13, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e,
-	0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x06, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x12, 0x26, 0x0a,
-	0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xc2, 0x01, 0x0a, 0x05, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
-	0x31, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x19, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x42,
-	0x75, 0x69, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3b, 0x0a,
-	0x0b, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x09, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x73, 0x41, 0x74, 0x22, 0x6b, 0x0a, 0x14, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x38, 0x0a,
+	0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x53, 0x74, 0x65, 0x70, 0x52, 0x0a, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x53, 0x74, 0x65, 0x70, 0x73, 0x22, 0x17, 0x0a, 0x15, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0xc4, 0x02, 0x0a, 0x09, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x53, 0x74, 0x65, 0x70, 0x12, 0x39,
+	0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a,
-	0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x41, 0x74, 0x22, 0x6e, 0x0a, 0x09, 0x50, 0x61,
-	0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65,
-	0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f,
-	0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x42, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x6c, 0x61, 0x73,
-	0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x72, 0x0a, 0x19, 0x52, 0x65,
-	0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64,
-	0x49, 0x64, 0x12, 0x3a, 0x0a, 0x0b, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x66, 0x69, 0x6c, 0x65,
-	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e,
-	0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x66, 0x69, 0x6c,
-	0x65, 0x52, 0x0b, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x22, 0x5c,
-	0x0a, 0x0a, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06,
-	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x1c, 0x0a, 0x1a,
-	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65,
-	0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2f, 0x0a, 0x12, 0x47, 0x65,
-	0x74, 0x50, 0x75, 0x6c, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x22, 0xa1, 0x01, 0x0a, 0x13,
-	0x47, 0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
-	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
-	0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x6f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x64, 0x65, 0x70,
-	0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4f,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22,
-	0x75, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
-	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x70, 0x72,
-	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x1e, 0x0a, 0x08, 0x62, 0x75,
-	0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x07,
-	0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x70,
-	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x62, 0x75,
-	0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x22, 0x2c, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x50, 0x75, 0x6c,
-	0x6c, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14,
-	0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
-	0x6f, 0x6b, 0x65, 0x6e, 0x2a, 0x95, 0x01, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
-	0x12, 0x17, 0x0a, 0x13, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50,
-	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x4f, 0x4d,
-	0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c,
-	0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x42, 0x41, 0x4b, 0x45, 0x10, 0x02, 0x12, 0x12,
-	0x0a, 0x0e, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x58,
-	0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x44, 0x41,
-	0x47, 0x47, 0x45, 0x52, 0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e,
-	0x44, 0x5f, 0x45, 0x58, 0x45, 0x43, 0x10, 0x05, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x4f, 0x4d, 0x4d,
-	0x41, 0x4e, 0x44, 0x5f, 0x46, 0x4c, 0x59, 0x43, 0x54, 0x4c, 0x10, 0x06, 0x2a, 0x6b, 0x0a, 0x0f,
-	0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12,
-	0x20, 0x0a, 0x1c, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x45, 0x52, 0x5f, 0x50, 0x4c, 0x41, 0x54, 0x46,
-	0x4f, 0x52, 0x4d, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
-	0x00, 0x12, 0x1a, 0x0a, 0x16, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x45, 0x52, 0x5f, 0x50, 0x4c, 0x41,
-	0x54, 0x46, 0x4f, 0x52, 0x4d, 0x5f, 0x41, 0x4d, 0x44, 0x36, 0x34, 0x10, 0x01, 0x12, 0x1a, 0x0a,
-	0x16, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x45, 0x52, 0x5f, 0x50, 0x4c, 0x41, 0x54, 0x46, 0x4f, 0x52,
-	0x4d, 0x5f, 0x41, 0x52, 0x4d, 0x36, 0x34, 0x10, 0x02, 0x2a, 0x94, 0x01, 0x0a, 0x0b, 0x42, 0x75,
-	0x69, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x18, 0x42, 0x55, 0x49,
-	0x4c, 0x44, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
-	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x42, 0x55, 0x49, 0x4c, 0x44,
-	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10,
-	0x01, 0x12, 0x19, 0x0a, 0x15, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
-	0x53, 0x5f, 0x46, 0x49, 0x4e, 0x49, 0x53, 0x48, 0x45, 0x44, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13,
-	0x42, 0x55, 0x49, 0x4c, 0x44, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x46, 0x41, 0x49,
-	0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x19, 0x0a, 0x15, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x5f, 0x53,
-	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x45, 0x44, 0x10, 0x04,
-	0x32, 0xdc, 0x08, 0x0a, 0x0c, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x52, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64,
-	0x12, 0x20, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x21, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76,
-	0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c,
-	0x64, 0x12, 0x1d, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31,
-	0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1e, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e,
-	0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x52, 0x0a, 0x0b, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12,
-	0x20, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46,
-	0x69, 0x6e, 0x69, 0x73, 0x68, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x21, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31,
-	0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x70, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64,
-	0x4b, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x2e,
-	0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
-	0x42, 0x75, 0x69, 0x6c, 0x64, 0x4b, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x64, 0x65, 0x70, 0x6f,
-	0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c,
-	0x64, 0x4b, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x64, 0x0a, 0x11, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x42, 0x75, 0x69, 0x6c, 0x64, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x26, 0x2e, 0x64, 0x65,
-	0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72,
-	0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e,
-	0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x48, 0x65,
-	0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x0d,
-	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x22, 0x2e,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x19,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d, 0x73, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x01, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x88, 0x01, 0x01, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73,
+	0x12, 0x29, 0x0a, 0x10, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x5f, 0x64, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x61, 0x6e, 0x63, 0x65,
+	0x73, 0x74, 0x6f, 0x72, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x42, 0x08, 0x0a, 0x06, 0x5f,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x8d, 0x02, 0x0a, 0x13, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x3c, 0x0a, 0x08, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d,
+	0x6f, 0x62, 0x79, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x6b, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x08,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x12, 0x5b, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x34, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x73, 0x1a, 0x40, 0x0a, 0x12, 0x53, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x44,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x16, 0x0a, 0x14, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x99, 0x02, 0x0a, 0x19, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x3c, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d, 0x6f, 0x62,
+	0x79, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x6b, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x08, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x12, 0x61, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3a,
+	0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x1a, 0x40, 0x0a, 0x12, 0x53, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x1c, 0x0a, 0x1a, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x6e, 0x0a, 0x11, 0x4c, 0x69, 0x73,
+	0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a,
+	0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x69, 0x0a, 0x12, 0x4c, 0x69, 0x73,
+	0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2b, 0x0a, 0x06, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x52, 0x06, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x12, 0x26, 0x0a, 0x0f,
+	0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x8f, 0x02, 0x0a, 0x05, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x31,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19,
+	0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3b, 0x0a, 0x0b,
+	0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x66,
+	0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x41, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x28, 0x0a, 0x10,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x50, 0x65, 0x72,
+	0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x22, 0x6e, 0x0a, 0x09, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
+	0x49, 0x64, 0x12, 0x42, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xc0, 0x01, 0x0a, 0x19, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12,
+	0x3a, 0x0a, 0x0b, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x0b,
+	0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x4c, 0x0a, 0x10, 0x64,
+	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x0f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x22, 0x74, 0x0a, 0x0a, 0x44, 0x6f, 0x63,
+	0x6b, 0x65, 0x72, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22,
+	0x65, 0x0a, 0x13, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x1c, 0x0a, 0x1a, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2f, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x49, 0x64, 0x22, 0xa1, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x50, 0x75, 0x6c,
+	0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x75, 0x0a, 0x13, 0x47, 0x65, 0x74,
+	0x50, 0x75, 0x6c, 0x6c, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x22, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49,
+	0x64, 0x88, 0x01, 0x01, 0x12, 0x1e, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49,
+	0x64, 0x88, 0x01, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
+	0x5f, 0x69, 0x64, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64,
+	0x22, 0xa9, 0x01, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x3e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48,
+	0x00, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x88, 0x01, 0x01, 0x12,
+	0x1f, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x01, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x88, 0x01, 0x01,
+	0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x42,
+	0x0b, 0x0a, 0x09, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x61, 0x0a, 0x1c,
+	0x47, 0x65, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70,
+	0x72, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x66,
+	0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0c, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x22,
+	0x5a, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x46, 0x69, 0x6e, 0x67,
+	0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x39, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69,
+	0x6e, 0x74, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x22, 0x80, 0x01, 0x0a, 0x11,
+	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x69, 0x6e,
+	0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x77,
+	0x0a, 0x1f, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x46, 0x69,
+	0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x07,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x52, 0x07,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x22, 0x22, 0x0a, 0x20, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69,
+	0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x36, 0x0a, 0x19, 0x4c,
+	0x69, 0x73, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x49, 0x64, 0x22, 0x57, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64,
+	0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x39, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63,
+	0x74, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x22, 0xb9, 0x01, 0x0a,
+	0x0d, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x69, 0x7a, 0x65,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a,
+	0x0c, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x55, 0x72, 0x6c,
+	0x12, 0x1b, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a,
+	0x07, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x51, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x43,
+	0x61, 0x63, 0x68, 0x65, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0xfd, 0x01, 0x0a, 0x16,
+	0x47, 0x65, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x45, 0x0a, 0x05, 0x61, 0x74,
+	0x74, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x64, 0x65, 0x70, 0x6f,
+	0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x63, 0x68,
+	0x65, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x41, 0x74, 0x74, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x61, 0x74, 0x74, 0x72,
+	0x73, 0x12, 0x4e, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63,
+	0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x61, 0x6c, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
+	0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x41, 0x74, 0x74, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a, 0x95, 0x01, 0x0a, 0x07,
+	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x4f, 0x4d, 0x4d, 0x41,
+	0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x11, 0x0a, 0x0d, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x42, 0x55, 0x49, 0x4c,
+	0x44, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x42,
+	0x41, 0x4b, 0x45, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44,
+	0x5f, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x58, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x4f, 0x4d,
+	0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x44, 0x41, 0x47, 0x47, 0x45, 0x52, 0x10, 0x04, 0x12, 0x10, 0x0a,
+	0x0c, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x45, 0x58, 0x45, 0x43, 0x10, 0x05, 0x12,
+	0x12, 0x0a, 0x0e, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x46, 0x4c, 0x59, 0x43, 0x54,
+	0x4c, 0x10, 0x06, 0x2a, 0x6b, 0x0a, 0x0f, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x50, 0x6c,
+	0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x20, 0x0a, 0x1c, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x45,
+	0x52, 0x5f, 0x50, 0x4c, 0x41, 0x54, 0x46, 0x4f, 0x52, 0x4d, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x42, 0x55, 0x49, 0x4c,
+	0x44, 0x45, 0x52, 0x5f, 0x50, 0x4c, 0x41, 0x54, 0x46, 0x4f, 0x52, 0x4d, 0x5f, 0x41, 0x4d, 0x44,
+	0x36, 0x34, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x45, 0x52, 0x5f,
+	0x50, 0x4c, 0x41, 0x54, 0x46, 0x4f, 0x52, 0x4d, 0x5f, 0x41, 0x52, 0x4d, 0x36, 0x34, 0x10, 0x02,
+	0x2a, 0x94, 0x01, 0x0a, 0x0b, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x1c, 0x0a, 0x18, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18,
+	0x0a, 0x14, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52,
+	0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x42, 0x55, 0x49, 0x4c,
+	0x44, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x46, 0x49, 0x4e, 0x49, 0x53, 0x48, 0x45,
+	0x44, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x55, 0x53, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x19, 0x0a, 0x15,
+	0x42, 0x55, 0x49, 0x4c, 0x44, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x41, 0x4e,
+	0x43, 0x45, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x32, 0x8f, 0x0c, 0x0a, 0x0c, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x52, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x20, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e,
+	0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x64, 0x65, 0x70, 0x6f,
+	0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x08,
+	0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x1d, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74,
+	0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e,
+	0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x0b, 0x46, 0x69, 0x6e, 0x69, 0x73,
+	0x68, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x20, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63,
+	0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74,
+	0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x70, 0x0a, 0x15, 0x47,
+	0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4b, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4b, 0x69, 0x74, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x2b, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4b, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x64, 0x0a,
+	0x11, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x48, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x12, 0x26, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x48, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x64, 0x65, 0x70,
+	0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x0d, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x69, 0x6d,
+	0x69, 0x6e, 0x67, 0x73, 0x12, 0x22, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74,
+	0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x69,
+	0x6d, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a,
+	0x0c, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x2e,
 	0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70,
-	0x6f, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x23, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31,
-	0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x0c, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63,
-	0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x64, 0x65, 0x70, 0x6f,
-	0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69, 0x0a,
-	0x12, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x12, 0x27, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e,
+	0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x22, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69, 0x0a, 0x12, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x27, 0x2e, 0x64, 0x65, 0x70,
+	0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e,
 	0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x64,
-	0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f,
-	0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x12, 0x67, 0x0a, 0x12, 0x52, 0x65, 0x70, 0x6f,
-	0x72, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x27,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x12,
+	0x67, 0x0a, 0x12, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x27, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28,
 	0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
 	0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e,
-	0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x69,
-	0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x51, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x12,
-	0x1f, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x20, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e,
-	0x4c, 0x69, 0x73, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x49,
-	0x6e, 0x66, 0x6f, 0x12, 0x20, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e,
-	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c,
-	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x49, 0x6e, 0x66, 0x6f,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x50,
-	0x75, 0x6c, 0x6c, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x21, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74,
-	0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x54,
-	0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x64, 0x65,
-	0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x75,
-	0x6c, 0x6c, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
-	0xa3, 0x01, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c,
-	0x69, 0x2e, 0x76, 0x31, 0x42, 0x0a, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x50, 0x72, 0x6f, 0x74, 0x6f,
-	0x50, 0x01, 0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64,
-	0x65, 0x70, 0x6f, 0x74, 0x2f, 0x63, 0x6c, 0x69, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2f, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2f, 0x63, 0x6c, 0x69, 0x2f, 0x76, 0x31, 0x3b,
-	0x63, 0x6c, 0x69, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x44, 0x43, 0x58, 0xaa, 0x02, 0x0c, 0x44, 0x65,
-	0x70, 0x6f, 0x74, 0x2e, 0x43, 0x6c, 0x69, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x0c, 0x44, 0x65, 0x70,
-	0x6f, 0x74, 0x5c, 0x43, 0x6c, 0x69, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x18, 0x44, 0x65, 0x70, 0x6f,
-	0x74, 0x5c, 0x43, 0x6c, 0x69, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0e, 0x44, 0x65, 0x70, 0x6f, 0x74, 0x3a, 0x3a, 0x43, 0x6c,
-	0x69, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x12, 0x1f, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63,
+	0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e,
+	0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x0b, 0x47,
+	0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x20, 0x2e, 0x64, 0x65, 0x70,
+	0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x75, 0x6c,
+	0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x64,
+	0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50,
+	0x75, 0x6c, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x55, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x21, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x22, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x67, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x12, 0x27, 0x2e, 0x64,
+	0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72,
+	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x70, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x46, 0x69, 0x6e, 0x67,
+	0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x2a, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74,
+	0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x46, 0x69, 0x6e,
+	0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x79, 0x0a, 0x18, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x2d, 0x2e,
+	0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70,
+	0x72, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x64,
+	0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72,
+	0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5b, 0x0a, 0x0e,
+	0x47, 0x65, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x23,
+	0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x49, 0x6d, 0x70, 0x6f, 0x72,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0xa3, 0x01, 0x0a, 0x10, 0x63, 0x6f,
+	0x6d, 0x2e, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x63, 0x6c, 0x69, 0x2e, 0x76, 0x31, 0x42, 0x0a,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x31, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x65, 0x70, 0x6f, 0x74, 0x2f, 0x63,
+	0x6c, 0x69, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x65, 0x70,
+	0x6f, 0x74, 0x2f, 0x63, 0x6c, 0x69, 0x2f, 0x76, 0x31, 0x3b, 0x63, 0x6c, 0x69, 0x76, 0x31, 0xa2,
+	0x02, 0x03, 0x44, 0x43, 0x58, 0xaa, 0x02, 0x0c, 0x44, 0x65, 0x70, 0x6f, 0x74, 0x2e, 0x43, 0x6c,
+	0x69, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x0c, 0x44, 0x65, 0x70, 0x6f, 0x74, 0x5c, 0x43, 0x6c, 0x69,
+	0x5c, 0x56, 0x31, 0xe2, 0x02, 0x18, 0x44, 0x65, 0x70, 0x6f, 0x74, 0x5c, 0x43, 0x6c, 0x69, 0x5c,
+	0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02,
+	0x0e, 0x44, 0x65, 0x70, 0x6f, 0x74, 0x3a, 0x3a, 0x43, 0x6c, 0x69, 0x3a, 0x3a, 0x56, 0x31, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -3299,128 +4547,163 @@ func file_depot_cli_v1_build_proto_rawDescGZIP() []byte {
 }
 
 var file_depot_cli_v1_build_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_depot_cli_v1_build_proto_msgTypes = make([]protoimpl.MessageInfo, 48)
+var file_depot_cli_v1_build_proto_msgTypes = make([]protoimpl.MessageInfo, 64)
 var file_depot_cli_v1_build_proto_goTypes = []interface{}{
 	(Command)(0),                                             // 0: depot.cli.v1.Command
 	(BuilderPlatform)(0),                                     // 1: depot.cli.v1.BuilderPlatform
 	(BuildStatus)(0),                                         // 2: depot.cli.v1.BuildStatus
 	(*CreateBuildRequest)(nil),                               // 3: depot.cli.v1.CreateBuildRequest
-	(*BuildOptions)(nil),                                     // 4: depot.cli.v1.BuildOptions
-	(*BuildOutput)(nil),                                      // 5: depot.cli.v1.BuildOutput
-	(*CreateBuildResponse)(nil),                              // 6: depot.cli.v1.CreateBuildResponse
-	(*GetBuildRequest)(nil),                                  // 7: depot.cli.v1.GetBuildRequest
-	(*GetBuildResponse)(nil),                                 // 8: depot.cli.v1.GetBuildResponse
-	(*Registry)(nil),                                         // 9: depot.cli.v1.Registry
-	(*FinishBuildRequest)(nil),                               // 10: depot.cli.v1.FinishBuildRequest
-	(*FinishBuildResponse)(nil),                              // 11: depot.cli.v1.FinishBuildResponse
-	(*GetBuildKitConnectionRequest)(nil),                     // 12: depot.cli.v1.GetBuildKitConnectionRequest
-	(*GetBuildKitConnectionResponse)(nil),                    // 13: depot.cli.v1.GetBuildKitConnectionResponse
-	(*Cert)(nil),                                             // 14: depot.cli.v1.Cert
-	(*ReportBuildHealthRequest)(nil),                         // 15: depot.cli.v1.ReportBuildHealthRequest
-	(*ReportBuildHealthResponse)(nil),                        // 16: depot.cli.v1.ReportBuildHealthResponse
-	(*ReportTimingsRequest)(nil),                             // 17: depot.cli.v1.ReportTimingsRequest
-	(*ReportTimingsResponse)(nil),                            // 18: depot.cli.v1.ReportTimingsResponse
-	(*BuildStep)(nil),                                        // 19: depot.cli.v1.BuildStep
-	(*ReportStatusRequest)(nil),                              // 20: depot.cli.v1.ReportStatusRequest
-	(*ReportStatusResponse)(nil),                             // 21: depot.cli.v1.ReportStatusResponse
-	(*ReportStatusStreamRequest)(nil),                        // 22: depot.cli.v1.ReportStatusStreamRequest
-	(*ReportStatusStreamResponse)(nil),                       // 23: depot.cli.v1.ReportStatusStreamResponse
-	(*ListBuildsRequest)(nil),                                // 24: depot.cli.v1.ListBuildsRequest
-	(*ListBuildsResponse)(nil),                               // 25: depot.cli.v1.ListBuildsResponse
-	(*Build)(nil),                                            // 26: depot.cli.v1.Build
-	(*PageToken)(nil),                                        // 27: depot.cli.v1.PageToken
-	(*ReportBuildContextRequest)(nil),                        // 28: depot.cli.v1.ReportBuildContextRequest
-	(*Dockerfile)(nil),                                       // 29: depot.cli.v1.Dockerfile
-	(*ReportBuildContextResponse)(nil),                       // 30: depot.cli.v1.ReportBuildContextResponse
-	(*GetPullInfoRequest)(nil),                               // 31: depot.cli.v1.GetPullInfoRequest
-	(*GetPullInfoResponse)(nil),                              // 32: depot.cli.v1.GetPullInfoResponse
-	(*GetPullTokenRequest)(nil),                              // 33: depot.cli.v1.GetPullTokenRequest
-	(*GetPullTokenResponse)(nil),                             // 34: depot.cli.v1.GetPullTokenResponse
-	(*CreateBuildRequest_RequiredEngine)(nil),                // 35: depot.cli.v1.CreateBuildRequest.RequiredEngine
-	(*CreateBuildRequest_RequiredEngine_BuildKitEngine)(nil), // 36: depot.cli.v1.CreateBuildRequest.RequiredEngine.BuildKitEngine
-	(*CreateBuildRequest_RequiredEngine_DaggerEngine)(nil),   // 37: depot.cli.v1.CreateBuildRequest.RequiredEngine.DaggerEngine
-	nil,                                                             // 38: depot.cli.v1.BuildOutput.AttributesEntry
-	(*CreateBuildResponse_Profiler)(nil),                            // 39: depot.cli.v1.CreateBuildResponse.Profiler
-	(*CreateBuildResponse_Credential)(nil),                          // 40: depot.cli.v1.CreateBuildResponse.Credential
-	(*CreateBuildResponse_Tag)(nil),                                 // 41: depot.cli.v1.CreateBuildResponse.Tag
-	(*FinishBuildRequest_BuildSuccess)(nil),                         // 42: depot.cli.v1.FinishBuildRequest.BuildSuccess
-	(*FinishBuildRequest_BuildError)(nil),                           // 43: depot.cli.v1.FinishBuildRequest.BuildError
-	(*FinishBuildRequest_BuildCanceled)(nil),                        // 44: depot.cli.v1.FinishBuildRequest.BuildCanceled
-	(*GetBuildKitConnectionResponse_PendingConnection)(nil),         // 45: depot.cli.v1.GetBuildKitConnectionResponse.PendingConnection
-	(*GetBuildKitConnectionResponse_ActiveConnection)(nil),          // 46: depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection
-	(*GetBuildKitConnectionResponse_ActiveConnection_Identity)(nil), // 47: depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection.Identity
-	(*GetBuildKitConnectionResponse_ActiveConnection_Gzip)(nil),     // 48: depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection.Gzip
-	nil,                            // 49: depot.cli.v1.ReportStatusRequest.StableDigestsEntry
-	nil,                            // 50: depot.cli.v1.ReportStatusStreamRequest.StableDigestsEntry
-	(*timestamppb.Timestamp)(nil),  // 51: google.protobuf.Timestamp
-	(*control.StatusResponse)(nil), // 52: moby.buildkit.v1.StatusResponse
+	(*CIContext)(nil),                                        // 4: depot.cli.v1.CIContext
+	(*BuildOptions)(nil),                                     // 5: depot.cli.v1.BuildOptions
+	(*BuildOutput)(nil),                                      // 6: depot.cli.v1.BuildOutput
+	(*CreateBuildResponse)(nil),                              // 7: depot.cli.v1.CreateBuildResponse
+	(*GetBuildRequest)(nil),                                  // 8: depot.cli.v1.GetBuildRequest
+	(*GetBuildResponse)(nil),                                 // 9: depot.cli.v1.GetBuildResponse
+	(*Registry)(nil),                                         // 10: depot.cli.v1.Registry
+	(*FinishBuildRequest)(nil),                               // 11: depot.cli.v1.FinishBuildRequest
+	(*FinishBuildResponse)(nil),                              // 12: depot.cli.v1.FinishBuildResponse
+	(*GetBuildKitConnectionRequest)(nil),                     // 13: depot.cli.v1.GetBuildKitConnectionRequest
+	(*Placement)(nil),                                        // 14: depot.cli.v1.Placement
+	(*MachineInfo)(nil),                                      // 15: depot.cli.v1.MachineInfo
+	(*GetBuildKitConnectionResponse)(nil),                    // 16: depot.cli.v1.GetBuildKitConnectionResponse
+	(*Cert)(nil),                                             // 17: depot.cli.v1.Cert
+	(*ReportBuildHealthRequest)(nil),                         // 18: depot.cli.v1.ReportBuildHealthRequest
+	(*BuildStepProgress)(nil),                                // 19: depot.cli.v1.BuildStepProgress
+	(*ReportBuildHealthResponse)(nil),                        // 20: depot.cli.v1.ReportBuildHealthResponse
+	(*ReportTimingsRequest)(nil),                             // 21: depot.cli.v1.ReportTimingsRequest
+	(*ReportTimingsResponse)(nil),                            // 22: depot.cli.v1.ReportTimingsResponse
+	(*BuildStep)(nil),                                        // 23: depot.cli.v1.BuildStep
+	(*ReportStatusRequest)(nil),                              // 24: depot.cli.v1.ReportStatusRequest
+	(*ReportStatusResponse)(nil),                             // 25: depot.cli.v1.ReportStatusResponse
+	(*ReportStatusStreamRequest)(nil),                        // 26: depot.cli.v1.ReportStatusStreamRequest
+	(*ReportStatusStreamResponse)(nil),                       // 27: depot.cli.v1.ReportStatusStreamResponse
+	(*ListBuildsRequest)(nil),                                // 28: depot.cli.v1.ListBuildsRequest
+	(*ListBuildsResponse)(nil),                               // 29: depot.cli.v1.ListBuildsResponse
+	(*Build)(nil),                                            // 30: depot.cli.v1.Build
+	(*PageToken)(nil),                                        // 31: depot.cli.v1.PageToken
+	(*ReportBuildContextRequest)(nil),                        // 32: depot.cli.v1.ReportBuildContextRequest
+	(*Dockerfile)(nil),                                       // 33: depot.cli.v1.Dockerfile
+	(*BuildDefinitionFile)(nil),                              // 34: depot.cli.v1.BuildDefinitionFile
+	(*ReportBuildContextResponse)(nil),                       // 35: depot.cli.v1.ReportBuildContextResponse
+	(*GetPullInfoRequest)(nil),                               // 36: depot.cli.v1.GetPullInfoRequest
+	(*GetPullInfoResponse)(nil),                              // 37: depot.cli.v1.GetPullInfoResponse
+	(*GetPullTokenRequest)(nil),                              // 38: depot.cli.v1.GetPullTokenRequest
+	(*GetPullTokenResponse)(nil),                             // 39: depot.cli.v1.GetPullTokenResponse
+	(*GetTargetFingerprintsRequest)(nil),                     // 40: depot.cli.v1.GetTargetFingerprintsRequest
+	(*GetTargetFingerprintsResponse)(nil),                    // 41: depot.cli.v1.GetTargetFingerprintsResponse
+	(*TargetFingerprint)(nil),                                // 42: depot.cli.v1.TargetFingerprint
+	(*ReportTargetFingerprintsRequest)(nil),                  // 43: depot.cli.v1.ReportTargetFingerprintsRequest
+	(*ReportTargetFingerprintsResponse)(nil),                 // 44: depot.cli.v1.ReportTargetFingerprintsResponse
+	(*ListBuildArtifactsRequest)(nil),                        // 45: depot.cli.v1.ListBuildArtifactsRequest
+	(*ListBuildArtifactsResponse)(nil),                       // 46: depot.cli.v1.ListBuildArtifactsResponse
+	(*BuildArtifact)(nil),                                    // 47: depot.cli.v1.BuildArtifact
+	(*GetCacheImportRequest)(nil),                            // 48: depot.cli.v1.GetCacheImportRequest
+	(*GetCacheImportResponse)(nil),                           // 49: depot.cli.v1.GetCacheImportResponse
+	(*CreateBuildRequest_RequiredEngine)(nil),                // 50: depot.cli.v1.CreateBuildRequest.RequiredEngine
+	(*CreateBuildRequest_RequiredEngine_BuildKitEngine)(nil), // 51: depot.cli.v1.CreateBuildRequest.RequiredEngine.BuildKitEngine
+	(*CreateBuildRequest_RequiredEngine_DaggerEngine)(nil),   // 52: depot.cli.v1.CreateBuildRequest.RequiredEngine.DaggerEngine
+	nil,                                                             // 53: depot.cli.v1.BuildOutput.AttributesEntry
+	(*CreateBuildResponse_Profiler)(nil),                            // 54: depot.cli.v1.CreateBuildResponse.Profiler
+	(*CreateBuildResponse_Credential)(nil),                          // 55: depot.cli.v1.CreateBuildResponse.Credential
+	(*CreateBuildResponse_Tag)(nil),                                 // 56: depot.cli.v1.CreateBuildResponse.Tag
+	(*FinishBuildRequest_BuildSuccess)(nil),                         // 57: depot.cli.v1.FinishBuildRequest.BuildSuccess
+	(*FinishBuildRequest_BuildError)(nil),                           // 58: depot.cli.v1.FinishBuildRequest.BuildError
+	(*FinishBuildRequest_BuildCanceled)(nil),                        // 59: depot.cli.v1.FinishBuildRequest.BuildCanceled
+	(*GetBuildKitConnectionResponse_PendingConnection)(nil),         // 60: depot.cli.v1.GetBuildKitConnectionResponse.PendingConnection
+	(*GetBuildKitConnectionResponse_ActiveConnection)(nil),          // 61: depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection
+	(*GetBuildKitConnectionResponse_ActiveConnection_Identity)(nil), // 62: depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection.Identity
+	(*GetBuildKitConnectionResponse_ActiveConnection_Gzip)(nil),     // 63: depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection.Gzip
+	nil,                            // 64: depot.cli.v1.ReportStatusRequest.StableDigestsEntry
+	nil,                            // 65: depot.cli.v1.ReportStatusStreamRequest.StableDigestsEntry
+	nil,                            // 66: depot.cli.v1.GetCacheImportResponse.AttrsEntry
+	(*timestamppb.Timestamp)(nil),  // 67: google.protobuf.Timestamp
+	(*control.StatusResponse)(nil), // 68: moby.buildkit.v1.StatusResponse
 }
 var file_depot_cli_v1_build_proto_depIdxs = []int32{
-	4,  // 0: depot.cli.v1.CreateBuildRequest.options:type_name -> depot.cli.v1.BuildOptions
-	35, // 1: depot.cli.v1.CreateBuildRequest.required_engine:type_name -> depot.cli.v1.CreateBuildRequest.RequiredEngine
-	0,  // 2: depot.cli.v1.BuildOptions.command:type_name -> depot.cli.v1.Command
-	5,  // 3: depot.cli.v1.BuildOptions.outputs:type_name -> depot.cli.v1.BuildOutput
-	38, // 4: depot.cli.v1.BuildOutput.attributes:type_name -> depot.cli.v1.BuildOutput.AttributesEntry
-	9,  // 5: depot.cli.v1.CreateBuildResponse.registry:type_name -> depot.cli.v1.Registry
-	39, // 6: depot.cli.v1.CreateBuildResponse.profiler:type_name -> depot.cli.v1.CreateBuildResponse.Profiler
-	40, // 7: depot.cli.v1.CreateBuildResponse.additional_credentials:type_name -> depot.cli.v1.CreateBuildResponse.Credential
-	41, // 8: depot.cli.v1.CreateBuildResponse.additional_tags:type_name -> depot.cli.v1.CreateBuildResponse.Tag
-	42, // 9: depot.cli.v1.FinishBuildRequest.success:type_name -> depot.cli.v1.FinishBuildRequest.BuildSuccess
-	43, // 10: depot.cli.v1.FinishBuildRequest.error:type_name -> depot.cli.v1.FinishBuildRequest.BuildError
-	44, // 11: depot.cli.v1.FinishBuildRequest.canceled:type_name -> depot.cli.v1.FinishBuildRequest.BuildCanceled
-	1,  // 12: depot.cli.v1.GetBuildKitConnectionRequest.platform:type_name -> depot.cli.v1.BuilderPlatform
-	45, // 13: depot.cli.v1.GetBuildKitConnectionResponse.pending:type_name -> depot.cli.v1.GetBuildKitConnectionResponse.PendingConnection
-	46, // 14: depot.cli.v1.GetBuildKitConnectionResponse.active:type_name -> depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection
-	1,  // 15: depot.cli.v1.ReportBuildHealthRequest.platform:type_name -> depot.cli.v1.BuilderPlatform
-	51, // 16: depot.cli.v1.ReportBuildHealthResponse.cancels_at:type_name -> google.protobuf.Timestamp
-	19, // 17: depot.cli.v1.ReportTimingsRequest.build_steps:type_name -> depot.cli.v1.BuildStep
-	51, // 18: depot.cli.v1.BuildStep.start_time:type_name -> google.protobuf.Timestamp
-	52, // 19: depot.cli.v1.ReportStatusRequest.statuses:type_name -> moby.buildkit.v1.StatusResponse
-	49, // 20: depot.cli.v1.ReportStatusRequest.stable_digests:type_name -> depot.cli.v1.ReportStatusRequest.StableDigestsEntry
-	52, // 21: depot.cli.v1.ReportStatusStreamRequest.statuses:type_name -> moby.buildkit.v1.StatusResponse
-	50, // 22: depot.cli.v1.ReportStatusStreamRequest.stable_digests:type_name -> depot.cli.v1.ReportStatusStreamRequest.StableDigestsEntry
-	26, // 23: depot.cli.v1.ListBuildsResponse.builds:type_name -> depot.cli.v1.Build
-	2,  // 24: depot.cli.v1.Build.status:type_name -> depot.cli.v1.BuildStatus
-	51, // 25: depot.cli.v1.Build.created_at:type_name -> google.protobuf.Timestamp
-	51, // 26: depot.cli.v1.Build.finished_at:type_name -> google.protobuf.Timestamp
-	51, // 27: depot.cli.v1.PageToken.last_created_at:type_name -> google.protobuf.Timestamp
-	29, // 28: depot.cli.v1.ReportBuildContextRequest.dockerfiles:type_name -> depot.cli.v1.Dockerfile
-	4,  // 29: depot.cli.v1.GetPullInfoResponse.options:type_name -> depot.cli.v1.BuildOptions
-	36, // 30: depot.cli.v1.CreateBuildRequest.RequiredEngine.buildkit:type_name -> depot.cli.v1.CreateBuildRequest.RequiredEngine.BuildKitEngine
-	37, // 31: depot.cli.v1.CreateBuildRequest.RequiredEngine.dagger:type_name -> depot.cli.v1.CreateBuildRequest.RequiredEngine.DaggerEngine
-	14, // 32: depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection.cert:type_name -> depot.cli.v1.Cert
-	14, // 33: depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection.ca_cert:type_name -> depot.cli.v1.Cert
-	47, // 34: depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection.identity:type_name -> depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection.Identity
-	48, // 35: depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection.gzip:type_name -> depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection.Gzip
-	3,  // 36: depot.cli.v1.BuildService.CreateBuild:input_type -> depot.cli.v1.CreateBuildRequest
-	7,  // 37: depot.cli.v1.BuildService.GetBuild:input_type -> depot.cli.v1.GetBuildRequest
-	10, // 38: depot.cli.v1.BuildService.FinishBuild:input_type -> depot.cli.v1.FinishBuildRequest
-	12, // 39: depot.cli.v1.BuildService.GetBuildKitConnection:input_type -> depot.cli.v1.GetBuildKitConnectionRequest
-	15, // 40: depot.cli.v1.BuildService.ReportBuildHealth:input_type -> depot.cli.v1.ReportBuildHealthRequest
-	17, // 41: depot.cli.v1.BuildService.ReportTimings:input_type -> depot.cli.v1.ReportTimingsRequest
-	20, // 42: depot.cli.v1.BuildService.ReportStatus:input_type -> depot.cli.v1.ReportStatusRequest
-	22, // 43: depot.cli.v1.BuildService.ReportStatusStream:input_type -> depot.cli.v1.ReportStatusStreamRequest
-	28, // 44: depot.cli.v1.BuildService.ReportBuildContext:input_type -> depot.cli.v1.ReportBuildContextRequest
-	24, // 45: depot.cli.v1.BuildService.ListBuilds:input_type -> depot.cli.v1.ListBuildsRequest
-	31, // 46: depot.cli.v1.BuildService.GetPullInfo:input_type -> depot.cli.v1.GetPullInfoRequest
-	33, // 47: depot.cli.v1.BuildService.GetPullToken:input_type -> depot.cli.v1.GetPullTokenRequest
-	6,  // 48: depot.cli.v1.BuildService.CreateBuild:output_type -> depot.cli.v1.CreateBuildResponse
-	8,  // 49: depot.cli.v1.BuildService.GetBuild:output_type -> depot.cli.v1.GetBuildResponse
-	11, // 50: depot.cli.v1.BuildService.FinishBuild:output_type -> depot.cli.v1.FinishBuildResponse
-	13, // 51: depot.cli.v1.BuildService.GetBuildKitConnection:output_type -> depot.cli.v1.GetBuildKitConnectionResponse
-	16, // 52: depot.cli.v1.BuildService.ReportBuildHealth:output_type -> depot.cli.v1.ReportBuildHealthResponse
-	18, // 53: depot.cli.v1.BuildService.ReportTimings:output_type -> depot.cli.v1.ReportTimingsResponse
-	21, // 54: depot.cli.v1.BuildService.ReportStatus:output_type -> depot.cli.v1.ReportStatusResponse
-	23, // 55: depot.cli.v1.BuildService.ReportStatusStream:output_type -> depot.cli.v1.ReportStatusStreamResponse
-	30, // 56: depot.cli.v1.BuildService.ReportBuildContext:output_type -> depot.cli.v1.ReportBuildContextResponse
-	25, // 57: depot.cli.v1.BuildService.ListBuilds:output_type -> depot.cli.v1.ListBuildsResponse
-	32, // 58: depot.cli.v1.BuildService.GetPullInfo:output_type -> depot.cli.v1.GetPullInfoResponse
-	34, // 59: depot.cli.v1.BuildService.GetPullToken:output_type -> depot.cli.v1.GetPullTokenResponse
-	48, // [48:60] is the sub-list for method output_type
-	36, // [36:48] is the sub-list for method input_type
-	36, // [36:36] is the sub-list for extension type_name
-	36, // [36:36] is the sub-list for extension extendee
-	0,  // [0:36] is the sub-list for field type_name
+	5,  // 0: depot.cli.v1.CreateBuildRequest.options:type_name -> depot.cli.v1.BuildOptions
+	50, // 1: depot.cli.v1.CreateBuildRequest.required_engine:type_name -> depot.cli.v1.CreateBuildRequest.RequiredEngine
+	4,  // 2: depot.cli.v1.CreateBuildRequest.ci:type_name -> depot.cli.v1.CIContext
+	0,  // 3: depot.cli.v1.BuildOptions.command:type_name -> depot.cli.v1.Command
+	6,  // 4: depot.cli.v1.BuildOptions.outputs:type_name -> depot.cli.v1.BuildOutput
+	53, // 5: depot.cli.v1.BuildOutput.attributes:type_name -> depot.cli.v1.BuildOutput.AttributesEntry
+	10, // 6: depot.cli.v1.CreateBuildResponse.registry:type_name -> depot.cli.v1.Registry
+	54, // 7: depot.cli.v1.CreateBuildResponse.profiler:type_name -> depot.cli.v1.CreateBuildResponse.Profiler
+	55, // 8: depot.cli.v1.CreateBuildResponse.additional_credentials:type_name -> depot.cli.v1.CreateBuildResponse.Credential
+	56, // 9: depot.cli.v1.CreateBuildResponse.additional_tags:type_name -> depot.cli.v1.CreateBuildResponse.Tag
+	57, // 10: depot.cli.v1.FinishBuildRequest.success:type_name -> depot.cli.v1.FinishBuildRequest.BuildSuccess
+	58, // 11: depot.cli.v1.FinishBuildRequest.error:type_name -> depot.cli.v1.FinishBuildRequest.BuildError
+	59, // 12: depot.cli.v1.FinishBuildRequest.canceled:type_name -> depot.cli.v1.FinishBuildRequest.BuildCanceled
+	1,  // 13: depot.cli.v1.GetBuildKitConnectionRequest.platform:type_name -> depot.cli.v1.BuilderPlatform
+	14, // 14: depot.cli.v1.GetBuildKitConnectionRequest.placement:type_name -> depot.cli.v1.Placement
+	60, // 15: depot.cli.v1.GetBuildKitConnectionResponse.pending:type_name -> depot.cli.v1.GetBuildKitConnectionResponse.PendingConnection
+	61, // 16: depot.cli.v1.GetBuildKitConnectionResponse.active:type_name -> depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection
+	1,  // 17: depot.cli.v1.ReportBuildHealthRequest.platform:type_name -> depot.cli.v1.BuilderPlatform
+	19, // 18: depot.cli.v1.ReportBuildHealthRequest.progress:type_name -> depot.cli.v1.BuildStepProgress
+	67, // 19: depot.cli.v1.ReportBuildHealthResponse.cancels_at:type_name -> google.protobuf.Timestamp
+	23, // 20: depot.cli.v1.ReportTimingsRequest.build_steps:type_name -> depot.cli.v1.BuildStep
+	67, // 21: depot.cli.v1.BuildStep.start_time:type_name -> google.protobuf.Timestamp
+	68, // 22: depot.cli.v1.ReportStatusRequest.statuses:type_name -> moby.buildkit.v1.StatusResponse
+	64, // 23: depot.cli.v1.ReportStatusRequest.stable_digests:type_name -> depot.cli.v1.ReportStatusRequest.StableDigestsEntry
+	68, // 24: depot.cli.v1.ReportStatusStreamRequest.statuses:type_name -> moby.buildkit.v1.StatusResponse
+	65, // 25: depot.cli.v1.ReportStatusStreamRequest.stable_digests:type_name -> depot.cli.v1.ReportStatusStreamRequest.StableDigestsEntry
+	30, // 26: depot.cli.v1.ListBuildsResponse.builds:type_name -> depot.cli.v1.Build
+	2,  // 27: depot.cli.v1.Build.status:type_name -> depot.cli.v1.BuildStatus
+	67, // 28: depot.cli.v1.Build.created_at:type_name -> google.protobuf.Timestamp
+	67, // 29: depot.cli.v1.Build.finished_at:type_name -> google.protobuf.Timestamp
+	67, // 30: depot.cli.v1.PageToken.last_created_at:type_name -> google.protobuf.Timestamp
+	33, // 31: depot.cli.v1.ReportBuildContextRequest.dockerfiles:type_name -> depot.cli.v1.Dockerfile
+	34, // 32: depot.cli.v1.ReportBuildContextRequest.definition_files:type_name -> depot.cli.v1.BuildDefinitionFile
+	5,  // 33: depot.cli.v1.GetPullInfoResponse.options:type_name -> depot.cli.v1.BuildOptions
+	67, // 34: depot.cli.v1.GetPullTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
+	42, // 35: depot.cli.v1.GetTargetFingerprintsResponse.targets:type_name -> depot.cli.v1.TargetFingerprint
+	42, // 36: depot.cli.v1.ReportTargetFingerprintsRequest.targets:type_name -> depot.cli.v1.TargetFingerprint
+	47, // 37: depot.cli.v1.ListBuildArtifactsResponse.artifacts:type_name -> depot.cli.v1.BuildArtifact
+	66, // 38: depot.cli.v1.GetCacheImportResponse.attrs:type_name -> depot.cli.v1.GetCacheImportResponse.AttrsEntry
+	55, // 39: depot.cli.v1.GetCacheImportResponse.credentials:type_name -> depot.cli.v1.CreateBuildResponse.Credential
+	51, // 40: depot.cli.v1.CreateBuildRequest.RequiredEngine.buildkit:type_name -> depot.cli.v1.CreateBuildRequest.RequiredEngine.BuildKitEngine
+	52, // 41: depot.cli.v1.CreateBuildRequest.RequiredEngine.dagger:type_name -> depot.cli.v1.CreateBuildRequest.RequiredEngine.DaggerEngine
+	17, // 42: depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection.cert:type_name -> depot.cli.v1.Cert
+	17, // 43: depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection.ca_cert:type_name -> depot.cli.v1.Cert
+	62, // 44: depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection.identity:type_name -> depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection.Identity
+	63, // 45: depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection.gzip:type_name -> depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection.Gzip
+	15, // 46: depot.cli.v1.GetBuildKitConnectionResponse.ActiveConnection.machine:type_name -> depot.cli.v1.MachineInfo
+	3,  // 47: depot.cli.v1.BuildService.CreateBuild:input_type -> depot.cli.v1.CreateBuildRequest
+	8,  // 48: depot.cli.v1.BuildService.GetBuild:input_type -> depot.cli.v1.GetBuildRequest
+	11, // 49: depot.cli.v1.BuildService.FinishBuild:input_type -> depot.cli.v1.FinishBuildRequest
+	13, // 50: depot.cli.v1.BuildService.GetBuildKitConnection:input_type -> depot.cli.v1.GetBuildKitConnectionRequest
+	18, // 51: depot.cli.v1.BuildService.ReportBuildHealth:input_type -> depot.cli.v1.ReportBuildHealthRequest
+	21, // 52: depot.cli.v1.BuildService.ReportTimings:input_type -> depot.cli.v1.ReportTimingsRequest
+	24, // 53: depot.cli.v1.BuildService.ReportStatus:input_type -> depot.cli.v1.ReportStatusRequest
+	26, // 54: depot.cli.v1.BuildService.ReportStatusStream:input_type -> depot.cli.v1.ReportStatusStreamRequest
+	32, // 55: depot.cli.v1.BuildService.ReportBuildContext:input_type -> depot.cli.v1.ReportBuildContextRequest
+	28, // 56: depot.cli.v1.BuildService.ListBuilds:input_type -> depot.cli.v1.ListBuildsRequest
+	36, // 57: depot.cli.v1.BuildService.GetPullInfo:input_type -> depot.cli.v1.GetPullInfoRequest
+	38, // 58: depot.cli.v1.BuildService.GetPullToken:input_type -> depot.cli.v1.GetPullTokenRequest
+	45, // 59: depot.cli.v1.BuildService.ListBuildArtifacts:input_type -> depot.cli.v1.ListBuildArtifactsRequest
+	40, // 60: depot.cli.v1.BuildService.GetTargetFingerprints:input_type -> depot.cli.v1.GetTargetFingerprintsRequest
+	43, // 61: depot.cli.v1.BuildService.ReportTargetFingerprints:input_type -> depot.cli.v1.ReportTargetFingerprintsRequest
+	48, // 62: depot.cli.v1.BuildService.GetCacheImport:input_type -> depot.cli.v1.GetCacheImportRequest
+	7,  // 63: depot.cli.v1.BuildService.CreateBuild:output_type -> depot.cli.v1.CreateBuildResponse
+	9,  // 64: depot.cli.v1.BuildService.GetBuild:output_type -> depot.cli.v1.GetBuildResponse
+	12, // 65: depot.cli.v1.BuildService.FinishBuild:output_type -> depot.cli.v1.FinishBuildResponse
+	16, // 66: depot.cli.v1.BuildService.GetBuildKitConnection:output_type -> depot.cli.v1.GetBuildKitConnectionResponse
+	20, // 67: depot.cli.v1.BuildService.ReportBuildHealth:output_type -> depot.cli.v1.ReportBuildHealthResponse
+	22, // 68: depot.cli.v1.BuildService.ReportTimings:output_type -> depot.cli.v1.ReportTimingsResponse
+	25, // 69: depot.cli.v1.BuildService.ReportStatus:output_type -> depot.cli.v1.ReportStatusResponse
+	27, // 70: depot.cli.v1.BuildService.ReportStatusStream:output_type -> depot.cli.v1.ReportStatusStreamResponse
+	35, // 71: depot.cli.v1.BuildService.ReportBuildContext:output_type -> depot.cli.v1.ReportBuildContextResponse
+	29, // 72: depot.cli.v1.BuildService.ListBuilds:output_type -> depot.cli.v1.ListBuildsResponse
+	37, // 73: depot.cli.v1.BuildService.GetPullInfo:output_type -> depot.cli.v1.GetPullInfoResponse
+	39, // 74: depot.cli.v1.BuildService.GetPullToken:output_type -> depot.cli.v1.GetPullTokenResponse
+	46, // 75: depot.cli.v1.BuildService.ListBuildArtifacts:output_type -> depot.cli.v1.ListBuildArtifactsResponse
+	41, // 76: depot.cli.v1.BuildService.GetTargetFingerprints:output_type -> depot.cli.v1.GetTargetFingerprintsResponse
+	44, // 77: depot.cli.v1.BuildService.ReportTargetFingerprints:output_type -> depot.cli.v1.ReportTargetFingerprintsResponse
+	49, // 78: depot.cli.v1.BuildService.GetCacheImport:output_type -> depot.cli.v1.GetCacheImportResponse
+	63, // [63:79] is the sub-list for method output_type
+	47, // [47:63] is the sub-list for method input_type
+	47, // [47:47] is the sub-list for extension type_name
+	47, // [47:47] is the sub-list for extension extendee
+	0,  // [0:47] is the sub-list for field type_name
 }
 
 func init() { file_depot_cli_v1_build_proto_init() }
@@ -3442,7 +4725,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BuildOptions); i {
+			switch v := v.(*CIContext); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3454,7 +4737,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BuildOutput); i {
+			switch v := v.(*BuildOptions); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3466,7 +4749,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateBuildResponse); i {
+			switch v := v.(*BuildOutput); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3478,7 +4761,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBuildRequest); i {
+			switch v := v.(*CreateBuildResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3490,7 +4773,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBuildResponse); i {
+			switch v := v.(*GetBuildRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3502,7 +4785,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Registry); i {
+			switch v := v.(*GetBuildResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3514,7 +4797,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FinishBuildRequest); i {
+			switch v := v.(*Registry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3526,7 +4809,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FinishBuildResponse); i {
+			switch v := v.(*FinishBuildRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3538,7 +4821,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBuildKitConnectionRequest); i {
+			switch v := v.(*FinishBuildResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3550,7 +4833,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBuildKitConnectionResponse); i {
+			switch v := v.(*GetBuildKitConnectionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3562,7 +4845,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Cert); i {
+			switch v := v.(*Placement); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3574,7 +4857,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReportBuildHealthRequest); i {
+			switch v := v.(*MachineInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3586,7 +4869,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReportBuildHealthResponse); i {
+			switch v := v.(*GetBuildKitConnectionResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3598,7 +4881,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReportTimingsRequest); i {
+			switch v := v.(*Cert); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3610,7 +4893,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReportTimingsResponse); i {
+			switch v := v.(*ReportBuildHealthRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3622,7 +4905,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BuildStep); i {
+			switch v := v.(*BuildStepProgress); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3634,7 +4917,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReportStatusRequest); i {
+			switch v := v.(*ReportBuildHealthResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3646,7 +4929,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReportStatusResponse); i {
+			switch v := v.(*ReportTimingsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3658,7 +4941,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReportStatusStreamRequest); i {
+			switch v := v.(*ReportTimingsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3670,7 +4953,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReportStatusStreamResponse); i {
+			switch v := v.(*BuildStep); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3682,7 +4965,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListBuildsRequest); i {
+			switch v := v.(*ReportStatusRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3694,7 +4977,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListBuildsResponse); i {
+			switch v := v.(*ReportStatusResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3706,7 +4989,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Build); i {
+			switch v := v.(*ReportStatusStreamRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3718,7 +5001,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PageToken); i {
+			switch v := v.(*ReportStatusStreamResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3730,7 +5013,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReportBuildContextRequest); i {
+			switch v := v.(*ListBuildsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3742,7 +5025,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Dockerfile); i {
+			switch v := v.(*ListBuildsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3754,7 +5037,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReportBuildContextResponse); i {
+			switch v := v.(*Build); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3766,7 +5049,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetPullInfoRequest); i {
+			switch v := v.(*PageToken); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3778,7 +5061,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetPullInfoResponse); i {
+			switch v := v.(*ReportBuildContextRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3790,7 +5073,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetPullTokenRequest); i {
+			switch v := v.(*Dockerfile); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3802,7 +5085,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetPullTokenResponse); i {
+			switch v := v.(*BuildDefinitionFile); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3814,7 +5097,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateBuildRequest_RequiredEngine); i {
+			switch v := v.(*ReportBuildContextResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3826,7 +5109,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateBuildRequest_RequiredEngine_BuildKitEngine); i {
+			switch v := v.(*GetPullInfoRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3838,7 +5121,19 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateBuildRequest_RequiredEngine_DaggerEngine); i {
+			switch v := v.(*GetPullInfoResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_depot_cli_v1_build_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPullTokenRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3850,7 +5145,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateBuildResponse_Profiler); i {
+			switch v := v.(*GetPullTokenResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3862,7 +5157,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateBuildResponse_Credential); i {
+			switch v := v.(*GetTargetFingerprintsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3874,7 +5169,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateBuildResponse_Tag); i {
+			switch v := v.(*GetTargetFingerprintsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3886,7 +5181,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FinishBuildRequest_BuildSuccess); i {
+			switch v := v.(*TargetFingerprint); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3898,7 +5193,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FinishBuildRequest_BuildError); i {
+			switch v := v.(*ReportTargetFingerprintsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3910,7 +5205,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FinishBuildRequest_BuildCanceled); i {
+			switch v := v.(*ReportTargetFingerprintsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3922,7 +5217,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBuildKitConnectionResponse_PendingConnection); i {
+			switch v := v.(*ListBuildArtifactsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3934,7 +5229,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBuildKitConnectionResponse_ActiveConnection); i {
+			switch v := v.(*ListBuildArtifactsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3946,7 +5241,7 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBuildKitConnectionResponse_ActiveConnection_Identity); i {
+			switch v := v.(*BuildArtifact); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3958,6 +5253,174 @@ func file_depot_cli_v1_build_proto_init() {
 			}
 		}
 		file_depot_cli_v1_build_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCacheImportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_depot_cli_v1_build_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCacheImportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_depot_cli_v1_build_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateBuildRequest_RequiredEngine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_depot_cli_v1_build_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateBuildRequest_RequiredEngine_BuildKitEngine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_depot_cli_v1_build_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateBuildRequest_RequiredEngine_DaggerEngine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_depot_cli_v1_build_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateBuildResponse_Profiler); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_depot_cli_v1_build_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateBuildResponse_Credential); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_depot_cli_v1_build_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateBuildResponse_Tag); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_depot_cli_v1_build_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FinishBuildRequest_BuildSuccess); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_depot_cli_v1_build_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FinishBuildRequest_BuildError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_depot_cli_v1_build_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FinishBuildRequest_BuildCanceled); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_depot_cli_v1_build_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBuildKitConnectionResponse_PendingConnection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_depot_cli_v1_build_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBuildKitConnectionResponse_ActiveConnection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_depot_cli_v1_build_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBuildKitConnectionResponse_ActiveConnection_Identity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_depot_cli_v1_build_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetBuildKitConnectionResponse_ActiveConnection_Gzip); i {
 			case 0:
 				return &v.state
@@ -3972,23 +5435,30 @@ func file_depot_cli_v1_build_proto_init() {
 	}
 	file_depot_cli_v1_build_proto_msgTypes[0].OneofWrappers = []interface{}{}
 	file_depot_cli_v1_build_proto_msgTypes[1].OneofWrappers = []interface{}{}
-	file_depot_cli_v1_build_proto_msgTypes[3].OneofWrappers = []interface{}{}
-	file_depot_cli_v1_build_proto_msgTypes[7].OneofWrappers = []interface{}{
+	file_depot_cli_v1_build_proto_msgTypes[2].OneofWrappers = []interface{}{}
+	file_depot_cli_v1_build_proto_msgTypes[4].OneofWrappers = []interface{}{}
+	file_depot_cli_v1_build_proto_msgTypes[8].OneofWrappers = []interface{}{
 		(*FinishBuildRequest_Success)(nil),
 		(*FinishBuildRequest_Error)(nil),
 		(*FinishBuildRequest_Canceled)(nil),
 	}
-	file_depot_cli_v1_build_proto_msgTypes[10].OneofWrappers = []interface{}{
+	file_depot_cli_v1_build_proto_msgTypes[10].OneofWrappers = []interface{}{}
+	file_depot_cli_v1_build_proto_msgTypes[11].OneofWrappers = []interface{}{}
+	file_depot_cli_v1_build_proto_msgTypes[13].OneofWrappers = []interface{}{
 		(*GetBuildKitConnectionResponse_Pending)(nil),
 		(*GetBuildKitConnectionResponse_Active)(nil),
 	}
+	file_depot_cli_v1_build_proto_msgTypes[15].OneofWrappers = []interface{}{}
 	file_depot_cli_v1_build_proto_msgTypes[16].OneofWrappers = []interface{}{}
-	file_depot_cli_v1_build_proto_msgTypes[30].OneofWrappers = []interface{}{}
-	file_depot_cli_v1_build_proto_msgTypes[32].OneofWrappers = []interface{}{
+	file_depot_cli_v1_build_proto_msgTypes[20].OneofWrappers = []interface{}{}
+	file_depot_cli_v1_build_proto_msgTypes[35].OneofWrappers = []interface{}{}
+	file_depot_cli_v1_build_proto_msgTypes[36].OneofWrappers = []interface{}{}
+	file_depot_cli_v1_build_proto_msgTypes[44].OneofWrappers = []interface{}{}
+	file_depot_cli_v1_build_proto_msgTypes[47].OneofWrappers = []interface{}{
 		(*CreateBuildRequest_RequiredEngine_Buildkit)(nil),
 		(*CreateBuildRequest_RequiredEngine_Dagger)(nil),
 	}
-	file_depot_cli_v1_build_proto_msgTypes[43].OneofWrappers = []interface{}{
+	file_depot_cli_v1_build_proto_msgTypes[58].OneofWrappers = []interface{}{
 		(*GetBuildKitConnectionResponse_ActiveConnection_Identity_)(nil),
 		(*GetBuildKitConnectionResponse_ActiveConnection_Gzip_)(nil),
 	}
@@ -3998,7 +5468,7 @@ func file_depot_cli_v1_build_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_depot_cli_v1_build_proto_rawDesc,
 			NumEnums:      3,
-			NumMessages:   48,
+			NumMessages:   64,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
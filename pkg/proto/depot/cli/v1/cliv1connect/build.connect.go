@@ -67,6 +67,18 @@ const (
 	// BuildServiceGetPullTokenProcedure is the fully-qualified name of the BuildService's GetPullToken
 	// RPC.
 	BuildServiceGetPullTokenProcedure = "/depot.cli.v1.BuildService/GetPullToken"
+	// BuildServiceListBuildArtifactsProcedure is the fully-qualified name of the BuildService's
+	// ListBuildArtifacts RPC.
+	BuildServiceListBuildArtifactsProcedure = "/depot.cli.v1.BuildService/ListBuildArtifacts"
+	// BuildServiceGetTargetFingerprintsProcedure is the fully-qualified name of the BuildService's
+	// GetTargetFingerprints RPC.
+	BuildServiceGetTargetFingerprintsProcedure = "/depot.cli.v1.BuildService/GetTargetFingerprints"
+	// BuildServiceReportTargetFingerprintsProcedure is the fully-qualified name of the BuildService's
+	// ReportTargetFingerprints RPC.
+	BuildServiceReportTargetFingerprintsProcedure = "/depot.cli.v1.BuildService/ReportTargetFingerprints"
+	// BuildServiceGetCacheImportProcedure is the fully-qualified name of the BuildService's
+	// GetCacheImport RPC.
+	BuildServiceGetCacheImportProcedure = "/depot.cli.v1.BuildService/GetCacheImport"
 )
 
 // BuildServiceClient is a client for the depot.cli.v1.BuildService service.
@@ -83,6 +95,10 @@ type BuildServiceClient interface {
 	ListBuilds(context.Context, *connect.Request[v1.ListBuildsRequest]) (*connect.Response[v1.ListBuildsResponse], error)
 	GetPullInfo(context.Context, *connect.Request[v1.GetPullInfoRequest]) (*connect.Response[v1.GetPullInfoResponse], error)
 	GetPullToken(context.Context, *connect.Request[v1.GetPullTokenRequest]) (*connect.Response[v1.GetPullTokenResponse], error)
+	ListBuildArtifacts(context.Context, *connect.Request[v1.ListBuildArtifactsRequest]) (*connect.Response[v1.ListBuildArtifactsResponse], error)
+	GetTargetFingerprints(context.Context, *connect.Request[v1.GetTargetFingerprintsRequest]) (*connect.Response[v1.GetTargetFingerprintsResponse], error)
+	ReportTargetFingerprints(context.Context, *connect.Request[v1.ReportTargetFingerprintsRequest]) (*connect.Response[v1.ReportTargetFingerprintsResponse], error)
+	GetCacheImport(context.Context, *connect.Request[v1.GetCacheImportRequest]) (*connect.Response[v1.GetCacheImportResponse], error)
 }
 
 // NewBuildServiceClient constructs a client for the depot.cli.v1.BuildService service. By default,
@@ -155,23 +171,47 @@ func NewBuildServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			baseURL+BuildServiceGetPullTokenProcedure,
 			opts...,
 		),
+		listBuildArtifacts: connect.NewClient[v1.ListBuildArtifactsRequest, v1.ListBuildArtifactsResponse](
+			httpClient,
+			baseURL+BuildServiceListBuildArtifactsProcedure,
+			opts...,
+		),
+		getTargetFingerprints: connect.NewClient[v1.GetTargetFingerprintsRequest, v1.GetTargetFingerprintsResponse](
+			httpClient,
+			baseURL+BuildServiceGetTargetFingerprintsProcedure,
+			opts...,
+		),
+		reportTargetFingerprints: connect.NewClient[v1.ReportTargetFingerprintsRequest, v1.ReportTargetFingerprintsResponse](
+			httpClient,
+			baseURL+BuildServiceReportTargetFingerprintsProcedure,
+			opts...,
+		),
+		getCacheImport: connect.NewClient[v1.GetCacheImportRequest, v1.GetCacheImportResponse](
+			httpClient,
+			baseURL+BuildServiceGetCacheImportProcedure,
+			opts...,
+		),
 	}
 }
 
 // buildServiceClient implements BuildServiceClient.
 type buildServiceClient struct {
-	createBuild           *connect.Client[v1.CreateBuildRequest, v1.CreateBuildResponse]
-	getBuild              *connect.Client[v1.GetBuildRequest, v1.GetBuildResponse]
-	finishBuild           *connect.Client[v1.FinishBuildRequest, v1.FinishBuildResponse]
-	getBuildKitConnection *connect.Client[v1.GetBuildKitConnectionRequest, v1.GetBuildKitConnectionResponse]
-	reportBuildHealth     *connect.Client[v1.ReportBuildHealthRequest, v1.ReportBuildHealthResponse]
-	reportTimings         *connect.Client[v1.ReportTimingsRequest, v1.ReportTimingsResponse]
-	reportStatus          *connect.Client[v1.ReportStatusRequest, v1.ReportStatusResponse]
-	reportStatusStream    *connect.Client[v1.ReportStatusStreamRequest, v1.ReportStatusStreamResponse]
-	reportBuildContext    *connect.Client[v1.ReportBuildContextRequest, v1.ReportBuildContextResponse]
-	listBuilds            *connect.Client[v1.ListBuildsRequest, v1.ListBuildsResponse]
-	getPullInfo           *connect.Client[v1.GetPullInfoRequest, v1.GetPullInfoResponse]
-	getPullToken          *connect.Client[v1.GetPullTokenRequest, v1.GetPullTokenResponse]
+	createBuild              *connect.Client[v1.CreateBuildRequest, v1.CreateBuildResponse]
+	getBuild                 *connect.Client[v1.GetBuildRequest, v1.GetBuildResponse]
+	finishBuild              *connect.Client[v1.FinishBuildRequest, v1.FinishBuildResponse]
+	getBuildKitConnection    *connect.Client[v1.GetBuildKitConnectionRequest, v1.GetBuildKitConnectionResponse]
+	reportBuildHealth        *connect.Client[v1.ReportBuildHealthRequest, v1.ReportBuildHealthResponse]
+	reportTimings            *connect.Client[v1.ReportTimingsRequest, v1.ReportTimingsResponse]
+	reportStatus             *connect.Client[v1.ReportStatusRequest, v1.ReportStatusResponse]
+	reportStatusStream       *connect.Client[v1.ReportStatusStreamRequest, v1.ReportStatusStreamResponse]
+	reportBuildContext       *connect.Client[v1.ReportBuildContextRequest, v1.ReportBuildContextResponse]
+	listBuilds               *connect.Client[v1.ListBuildsRequest, v1.ListBuildsResponse]
+	getPullInfo              *connect.Client[v1.GetPullInfoRequest, v1.GetPullInfoResponse]
+	getPullToken             *connect.Client[v1.GetPullTokenRequest, v1.GetPullTokenResponse]
+	listBuildArtifacts       *connect.Client[v1.ListBuildArtifactsRequest, v1.ListBuildArtifactsResponse]
+	getTargetFingerprints    *connect.Client[v1.GetTargetFingerprintsRequest, v1.GetTargetFingerprintsResponse]
+	reportTargetFingerprints *connect.Client[v1.ReportTargetFingerprintsRequest, v1.ReportTargetFingerprintsResponse]
+	getCacheImport           *connect.Client[v1.GetCacheImportRequest, v1.GetCacheImportResponse]
 }
 
 // CreateBuild calls depot.cli.v1.BuildService.CreateBuild.
@@ -234,6 +274,26 @@ func (c *buildServiceClient) GetPullToken(ctx context.Context, req *connect.Requ
 	return c.getPullToken.CallUnary(ctx, req)
 }
 
+// ListBuildArtifacts calls depot.cli.v1.BuildService.ListBuildArtifacts.
+func (c *buildServiceClient) ListBuildArtifacts(ctx context.Context, req *connect.Request[v1.ListBuildArtifactsRequest]) (*connect.Response[v1.ListBuildArtifactsResponse], error) {
+	return c.listBuildArtifacts.CallUnary(ctx, req)
+}
+
+// GetTargetFingerprints calls depot.cli.v1.BuildService.GetTargetFingerprints.
+func (c *buildServiceClient) GetTargetFingerprints(ctx context.Context, req *connect.Request[v1.GetTargetFingerprintsRequest]) (*connect.Response[v1.GetTargetFingerprintsResponse], error) {
+	return c.getTargetFingerprints.CallUnary(ctx, req)
+}
+
+// ReportTargetFingerprints calls depot.cli.v1.BuildService.ReportTargetFingerprints.
+func (c *buildServiceClient) ReportTargetFingerprints(ctx context.Context, req *connect.Request[v1.ReportTargetFingerprintsRequest]) (*connect.Response[v1.ReportTargetFingerprintsResponse], error) {
+	return c.reportTargetFingerprints.CallUnary(ctx, req)
+}
+
+// GetCacheImport calls depot.cli.v1.BuildService.GetCacheImport.
+func (c *buildServiceClient) GetCacheImport(ctx context.Context, req *connect.Request[v1.GetCacheImportRequest]) (*connect.Response[v1.GetCacheImportResponse], error) {
+	return c.getCacheImport.CallUnary(ctx, req)
+}
+
 // BuildServiceHandler is an implementation of the depot.cli.v1.BuildService service.
 type BuildServiceHandler interface {
 	CreateBuild(context.Context, *connect.Request[v1.CreateBuildRequest]) (*connect.Response[v1.CreateBuildResponse], error)
@@ -248,6 +308,10 @@ type BuildServiceHandler interface {
 	ListBuilds(context.Context, *connect.Request[v1.ListBuildsRequest]) (*connect.Response[v1.ListBuildsResponse], error)
 	GetPullInfo(context.Context, *connect.Request[v1.GetPullInfoRequest]) (*connect.Response[v1.GetPullInfoResponse], error)
 	GetPullToken(context.Context, *connect.Request[v1.GetPullTokenRequest]) (*connect.Response[v1.GetPullTokenResponse], error)
+	ListBuildArtifacts(context.Context, *connect.Request[v1.ListBuildArtifactsRequest]) (*connect.Response[v1.ListBuildArtifactsResponse], error)
+	GetTargetFingerprints(context.Context, *connect.Request[v1.GetTargetFingerprintsRequest]) (*connect.Response[v1.GetTargetFingerprintsResponse], error)
+	ReportTargetFingerprints(context.Context, *connect.Request[v1.ReportTargetFingerprintsRequest]) (*connect.Response[v1.ReportTargetFingerprintsResponse], error)
+	GetCacheImport(context.Context, *connect.Request[v1.GetCacheImportRequest]) (*connect.Response[v1.GetCacheImportResponse], error)
 }
 
 // NewBuildServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -316,6 +380,26 @@ func NewBuildServiceHandler(svc BuildServiceHandler, opts ...connect.HandlerOpti
 		svc.GetPullToken,
 		opts...,
 	)
+	buildServiceListBuildArtifactsHandler := connect.NewUnaryHandler(
+		BuildServiceListBuildArtifactsProcedure,
+		svc.ListBuildArtifacts,
+		opts...,
+	)
+	buildServiceGetTargetFingerprintsHandler := connect.NewUnaryHandler(
+		BuildServiceGetTargetFingerprintsProcedure,
+		svc.GetTargetFingerprints,
+		opts...,
+	)
+	buildServiceReportTargetFingerprintsHandler := connect.NewUnaryHandler(
+		BuildServiceReportTargetFingerprintsProcedure,
+		svc.ReportTargetFingerprints,
+		opts...,
+	)
+	buildServiceGetCacheImportHandler := connect.NewUnaryHandler(
+		BuildServiceGetCacheImportProcedure,
+		svc.GetCacheImport,
+		opts...,
+	)
 	return "/depot.cli.v1.BuildService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case BuildServiceCreateBuildProcedure:
@@ -342,6 +426,14 @@ func NewBuildServiceHandler(svc BuildServiceHandler, opts ...connect.HandlerOpti
 			buildServiceGetPullInfoHandler.ServeHTTP(w, r)
 		case BuildServiceGetPullTokenProcedure:
 			buildServiceGetPullTokenHandler.ServeHTTP(w, r)
+		case BuildServiceListBuildArtifactsProcedure:
+			buildServiceListBuildArtifactsHandler.ServeHTTP(w, r)
+		case BuildServiceGetTargetFingerprintsProcedure:
+			buildServiceGetTargetFingerprintsHandler.ServeHTTP(w, r)
+		case BuildServiceReportTargetFingerprintsProcedure:
+			buildServiceReportTargetFingerprintsHandler.ServeHTTP(w, r)
+		case BuildServiceGetCacheImportProcedure:
+			buildServiceGetCacheImportHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -398,3 +490,19 @@ func (UnimplementedBuildServiceHandler) GetPullInfo(context.Context, *connect.Re
 func (UnimplementedBuildServiceHandler) GetPullToken(context.Context, *connect.Request[v1.GetPullTokenRequest]) (*connect.Response[v1.GetPullTokenResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("depot.cli.v1.BuildService.GetPullToken is not implemented"))
 }
+
+func (UnimplementedBuildServiceHandler) ListBuildArtifacts(context.Context, *connect.Request[v1.ListBuildArtifactsRequest]) (*connect.Response[v1.ListBuildArtifactsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("depot.cli.v1.BuildService.ListBuildArtifacts is not implemented"))
+}
+
+func (UnimplementedBuildServiceHandler) GetTargetFingerprints(context.Context, *connect.Request[v1.GetTargetFingerprintsRequest]) (*connect.Response[v1.GetTargetFingerprintsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("depot.cli.v1.BuildService.GetTargetFingerprints is not implemented"))
+}
+
+func (UnimplementedBuildServiceHandler) ReportTargetFingerprints(context.Context, *connect.Request[v1.ReportTargetFingerprintsRequest]) (*connect.Response[v1.ReportTargetFingerprintsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("depot.cli.v1.BuildService.ReportTargetFingerprints is not implemented"))
+}
+
+func (UnimplementedBuildServiceHandler) GetCacheImport(context.Context, *connect.Request[v1.GetCacheImportRequest]) (*connect.Response[v1.GetCacheImportResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("depot.cli.v1.BuildService.GetCacheImport is not implemented"))
+}
@@ -0,0 +1,131 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: depot/cli/v1/usage.proto
+
+package cliv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion0_1_0
+
+const (
+	// UsageServiceName is the fully-qualified name of the UsageService service.
+	UsageServiceName = "depot.cli.v1.UsageService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// UsageServiceGetUsageProcedure is the fully-qualified name of the UsageService's GetUsage RPC.
+	UsageServiceGetUsageProcedure = "/depot.cli.v1.UsageService/GetUsage"
+	// UsageServiceGetCacheStatsProcedure is the fully-qualified name of the UsageService's
+	// GetCacheStats RPC.
+	UsageServiceGetCacheStatsProcedure = "/depot.cli.v1.UsageService/GetCacheStats"
+)
+
+// UsageServiceClient is a client for the depot.cli.v1.UsageService service.
+type UsageServiceClient interface {
+	GetUsage(context.Context, *connect.Request[v1.GetUsageRequest]) (*connect.Response[v1.GetUsageResponse], error)
+	GetCacheStats(context.Context, *connect.Request[v1.GetCacheStatsRequest]) (*connect.Response[v1.GetCacheStatsResponse], error)
+}
+
+// NewUsageServiceClient constructs a client for the depot.cli.v1.UsageService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewUsageServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) UsageServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &usageServiceClient{
+		getUsage: connect.NewClient[v1.GetUsageRequest, v1.GetUsageResponse](
+			httpClient,
+			baseURL+UsageServiceGetUsageProcedure,
+			opts...,
+		),
+		getCacheStats: connect.NewClient[v1.GetCacheStatsRequest, v1.GetCacheStatsResponse](
+			httpClient,
+			baseURL+UsageServiceGetCacheStatsProcedure,
+			opts...,
+		),
+	}
+}
+
+// usageServiceClient implements UsageServiceClient.
+type usageServiceClient struct {
+	getUsage      *connect.Client[v1.GetUsageRequest, v1.GetUsageResponse]
+	getCacheStats *connect.Client[v1.GetCacheStatsRequest, v1.GetCacheStatsResponse]
+}
+
+// GetUsage calls depot.cli.v1.UsageService.GetUsage.
+func (c *usageServiceClient) GetUsage(ctx context.Context, req *connect.Request[v1.GetUsageRequest]) (*connect.Response[v1.GetUsageResponse], error) {
+	return c.getUsage.CallUnary(ctx, req)
+}
+
+// GetCacheStats calls depot.cli.v1.UsageService.GetCacheStats.
+func (c *usageServiceClient) GetCacheStats(ctx context.Context, req *connect.Request[v1.GetCacheStatsRequest]) (*connect.Response[v1.GetCacheStatsResponse], error) {
+	return c.getCacheStats.CallUnary(ctx, req)
+}
+
+// UsageServiceHandler is an implementation of the depot.cli.v1.UsageService service.
+type UsageServiceHandler interface {
+	GetUsage(context.Context, *connect.Request[v1.GetUsageRequest]) (*connect.Response[v1.GetUsageResponse], error)
+	GetCacheStats(context.Context, *connect.Request[v1.GetCacheStatsRequest]) (*connect.Response[v1.GetCacheStatsResponse], error)
+}
+
+// NewUsageServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewUsageServiceHandler(svc UsageServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	usageServiceGetUsageHandler := connect.NewUnaryHandler(
+		UsageServiceGetUsageProcedure,
+		svc.GetUsage,
+		opts...,
+	)
+	usageServiceGetCacheStatsHandler := connect.NewUnaryHandler(
+		UsageServiceGetCacheStatsProcedure,
+		svc.GetCacheStats,
+		opts...,
+	)
+	return "/depot.cli.v1.UsageService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case UsageServiceGetUsageProcedure:
+			usageServiceGetUsageHandler.ServeHTTP(w, r)
+		case UsageServiceGetCacheStatsProcedure:
+			usageServiceGetCacheStatsHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedUsageServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedUsageServiceHandler struct{}
+
+func (UnimplementedUsageServiceHandler) GetUsage(context.Context, *connect.Request[v1.GetUsageRequest]) (*connect.Response[v1.GetUsageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("depot.cli.v1.UsageService.GetUsage is not implemented"))
+}
+
+func (UnimplementedUsageServiceHandler) GetCacheStats(context.Context, *connect.Request[v1.GetCacheStatsRequest]) (*connect.Response[v1.GetCacheStatsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("depot.cli.v1.UsageService.GetCacheStats is not implemented"))
+}
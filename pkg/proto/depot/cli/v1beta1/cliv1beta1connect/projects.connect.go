@@ -39,12 +39,20 @@ const (
 	// ProjectsServiceResetProjectCacheProcedure is the fully-qualified name of the ProjectsService's
 	// ResetProjectCache RPC.
 	ProjectsServiceResetProjectCacheProcedure = "/depot.cli.v1beta1.ProjectsService/ResetProjectCache"
+	// ProjectsServiceListCacheMountsProcedure is the fully-qualified name of the ProjectsService's
+	// ListCacheMounts RPC.
+	ProjectsServiceListCacheMountsProcedure = "/depot.cli.v1beta1.ProjectsService/ListCacheMounts"
+	// ProjectsServiceClearCacheMountsProcedure is the fully-qualified name of the ProjectsService's
+	// ClearCacheMounts RPC.
+	ProjectsServiceClearCacheMountsProcedure = "/depot.cli.v1beta1.ProjectsService/ClearCacheMounts"
 )
 
 // ProjectsServiceClient is a client for the depot.cli.v1beta1.ProjectsService service.
 type ProjectsServiceClient interface {
 	ListProjects(context.Context, *connect.Request[v1beta1.ListProjectsRequest]) (*connect.Response[v1beta1.ListProjectsResponse], error)
 	ResetProjectCache(context.Context, *connect.Request[v1beta1.ResetProjectCacheRequest]) (*connect.Response[v1beta1.ResetProjectCacheResponse], error)
+	ListCacheMounts(context.Context, *connect.Request[v1beta1.ListCacheMountsRequest]) (*connect.Response[v1beta1.ListCacheMountsResponse], error)
+	ClearCacheMounts(context.Context, *connect.Request[v1beta1.ClearCacheMountsRequest]) (*connect.Response[v1beta1.ClearCacheMountsResponse], error)
 }
 
 // NewProjectsServiceClient constructs a client for the depot.cli.v1beta1.ProjectsService service.
@@ -67,6 +75,16 @@ func NewProjectsServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 			baseURL+ProjectsServiceResetProjectCacheProcedure,
 			opts...,
 		),
+		listCacheMounts: connect.NewClient[v1beta1.ListCacheMountsRequest, v1beta1.ListCacheMountsResponse](
+			httpClient,
+			baseURL+ProjectsServiceListCacheMountsProcedure,
+			opts...,
+		),
+		clearCacheMounts: connect.NewClient[v1beta1.ClearCacheMountsRequest, v1beta1.ClearCacheMountsResponse](
+			httpClient,
+			baseURL+ProjectsServiceClearCacheMountsProcedure,
+			opts...,
+		),
 	}
 }
 
@@ -74,6 +92,8 @@ func NewProjectsServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 type projectsServiceClient struct {
 	listProjects      *connect.Client[v1beta1.ListProjectsRequest, v1beta1.ListProjectsResponse]
 	resetProjectCache *connect.Client[v1beta1.ResetProjectCacheRequest, v1beta1.ResetProjectCacheResponse]
+	listCacheMounts   *connect.Client[v1beta1.ListCacheMountsRequest, v1beta1.ListCacheMountsResponse]
+	clearCacheMounts  *connect.Client[v1beta1.ClearCacheMountsRequest, v1beta1.ClearCacheMountsResponse]
 }
 
 // ListProjects calls depot.cli.v1beta1.ProjectsService.ListProjects.
@@ -86,10 +106,22 @@ func (c *projectsServiceClient) ResetProjectCache(ctx context.Context, req *conn
 	return c.resetProjectCache.CallUnary(ctx, req)
 }
 
+// ListCacheMounts calls depot.cli.v1beta1.ProjectsService.ListCacheMounts.
+func (c *projectsServiceClient) ListCacheMounts(ctx context.Context, req *connect.Request[v1beta1.ListCacheMountsRequest]) (*connect.Response[v1beta1.ListCacheMountsResponse], error) {
+	return c.listCacheMounts.CallUnary(ctx, req)
+}
+
+// ClearCacheMounts calls depot.cli.v1beta1.ProjectsService.ClearCacheMounts.
+func (c *projectsServiceClient) ClearCacheMounts(ctx context.Context, req *connect.Request[v1beta1.ClearCacheMountsRequest]) (*connect.Response[v1beta1.ClearCacheMountsResponse], error) {
+	return c.clearCacheMounts.CallUnary(ctx, req)
+}
+
 // ProjectsServiceHandler is an implementation of the depot.cli.v1beta1.ProjectsService service.
 type ProjectsServiceHandler interface {
 	ListProjects(context.Context, *connect.Request[v1beta1.ListProjectsRequest]) (*connect.Response[v1beta1.ListProjectsResponse], error)
 	ResetProjectCache(context.Context, *connect.Request[v1beta1.ResetProjectCacheRequest]) (*connect.Response[v1beta1.ResetProjectCacheResponse], error)
+	ListCacheMounts(context.Context, *connect.Request[v1beta1.ListCacheMountsRequest]) (*connect.Response[v1beta1.ListCacheMountsResponse], error)
+	ClearCacheMounts(context.Context, *connect.Request[v1beta1.ClearCacheMountsRequest]) (*connect.Response[v1beta1.ClearCacheMountsResponse], error)
 }
 
 // NewProjectsServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -108,12 +140,26 @@ func NewProjectsServiceHandler(svc ProjectsServiceHandler, opts ...connect.Handl
 		svc.ResetProjectCache,
 		opts...,
 	)
+	projectsServiceListCacheMountsHandler := connect.NewUnaryHandler(
+		ProjectsServiceListCacheMountsProcedure,
+		svc.ListCacheMounts,
+		opts...,
+	)
+	projectsServiceClearCacheMountsHandler := connect.NewUnaryHandler(
+		ProjectsServiceClearCacheMountsProcedure,
+		svc.ClearCacheMounts,
+		opts...,
+	)
 	return "/depot.cli.v1beta1.ProjectsService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case ProjectsServiceListProjectsProcedure:
 			projectsServiceListProjectsHandler.ServeHTTP(w, r)
 		case ProjectsServiceResetProjectCacheProcedure:
 			projectsServiceResetProjectCacheHandler.ServeHTTP(w, r)
+		case ProjectsServiceListCacheMountsProcedure:
+			projectsServiceListCacheMountsHandler.ServeHTTP(w, r)
+		case ProjectsServiceClearCacheMountsProcedure:
+			projectsServiceClearCacheMountsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -130,3 +176,11 @@ func (UnimplementedProjectsServiceHandler) ListProjects(context.Context, *connec
 func (UnimplementedProjectsServiceHandler) ResetProjectCache(context.Context, *connect.Request[v1beta1.ResetProjectCacheRequest]) (*connect.Response[v1beta1.ResetProjectCacheResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("depot.cli.v1beta1.ProjectsService.ResetProjectCache is not implemented"))
 }
+
+func (UnimplementedProjectsServiceHandler) ListCacheMounts(context.Context, *connect.Request[v1beta1.ListCacheMountsRequest]) (*connect.Response[v1beta1.ListCacheMountsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("depot.cli.v1beta1.ProjectsService.ListCacheMounts is not implemented"))
+}
+
+func (UnimplementedProjectsServiceHandler) ClearCacheMounts(context.Context, *connect.Request[v1beta1.ClearCacheMountsRequest]) (*connect.Response[v1beta1.ClearCacheMountsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("depot.cli.v1beta1.ProjectsService.ClearCacheMounts is not implemented"))
+}
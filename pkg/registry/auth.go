@@ -33,15 +33,21 @@ func ReplaceDockerAuth(credentials []build.Credential, as []session.Attachable)
 	for _, c := range credentials {
 		dockerConfig.AuthConfigs[c.Host] = types.AuthConfig{
 			Auth:          c.Token,
+			IdentityToken: c.IdentityToken,
 			ServerAddress: c.Host,
 		}
 	}
 
 	for i, a := range as {
 		if _, ok := a.(auth.AuthServer); ok {
+			// Keep the credentials of an earlier replacement for other hosts.
+			merged := credentials
+			if prev, ok := a.(*AuthProvider); ok {
+				merged = mergeCredentials(credentials, prev.credentials)
+			}
 			p := authprovider.NewDockerAuthProvider(dockerConfig)
 			as[i] = &AuthProvider{
-				credentials: credentials,
+				credentials: merged,
 				inner:       p.(auth.AuthServer),
 			}
 		}
@@ -50,6 +56,25 @@ func ReplaceDockerAuth(credentials []build.Credential, as []session.Attachable)
 	return as
 }
 
+// mergeCredentials returns credentials followed by the credentials of prev
+// for the hosts credentials does not have.
+func mergeCredentials(credentials, prev []build.Credential) []build.Credential {
+	merged := append([]build.Credential{}, credentials...)
+	for _, p := range prev {
+		found := false
+		for _, c := range credentials {
+			if c.Host == p.Host {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, p)
+		}
+	}
+	return merged
+}
+
 func (a *AuthProvider) Register(server *grpc.Server) {
 	auth.RegisterAuthServer(server, a)
 }
@@ -57,6 +82,9 @@ func (a *AuthProvider) Register(server *grpc.Server) {
 func (a *AuthProvider) Credentials(ctx context.Context, req *auth.CredentialsRequest) (*auth.CredentialsResponse, error) {
 	for _, c := range a.credentials {
 		if c.Host == req.Host {
+			if c.IdentityToken != "" {
+				return &auth.CredentialsResponse{Secret: c.IdentityToken}, nil
+			}
 			decodedAuth, err := base64.StdEncoding.DecodeString(c.Token)
 			if err != nil {
 				return nil, err
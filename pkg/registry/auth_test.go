@@ -0,0 +1,98 @@
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"reflect"
+	"testing"
+
+	"github.com/depot/cli/pkg/build"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/auth"
+)
+
+func TestMergeCredentials(t *testing.T) {
+	depot := build.Credential{Host: "registry.depot.dev", Token: "new"}
+	oldDepot := build.Credential{Host: "registry.depot.dev", Token: "old"}
+	ecr := build.Credential{Host: "123.dkr.ecr.us-east-1.amazonaws.com", Token: "ecr"}
+	ghcr := build.Credential{Host: "ghcr.io", IdentityToken: "refresh"}
+
+	tests := []struct {
+		name        string
+		credentials []build.Credential
+		prev        []build.Credential
+		want        []build.Credential
+	}{
+		{
+			name:        "no previous credentials",
+			credentials: []build.Credential{depot},
+			want:        []build.Credential{depot},
+		},
+		{
+			name: "only previous credentials",
+			prev: []build.Credential{ecr},
+			want: []build.Credential{ecr},
+		},
+		{
+			name:        "new credentials take precedence for the same host",
+			credentials: []build.Credential{depot},
+			prev:        []build.Credential{oldDepot},
+			want:        []build.Credential{depot},
+		},
+		{
+			name:        "previous credentials are kept for other hosts",
+			credentials: []build.Credential{depot},
+			prev:        []build.Credential{oldDepot, ecr, ghcr},
+			want:        []build.Credential{depot, ecr, ghcr},
+		},
+		{
+			name: "neither",
+			want: []build.Credential{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeCredentials(tt.credentials, tt.prev); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeCredentials() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeCredentialsDoesNotModifyCredentials(t *testing.T) {
+	credentials := make([]build.Credential, 1, 2)
+	credentials[0] = build.Credential{Host: "registry.depot.dev", Token: "new"}
+	_ = mergeCredentials(credentials, []build.Credential{{Host: "ghcr.io", Token: "ghcr"}})
+	if got := credentials[:2][1]; got != (build.Credential{}) {
+		t.Errorf("mergeCredentials() wrote %+v past the credentials", got)
+	}
+}
+
+func TestReplaceDockerAuthKeepsEarlierCredentials(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+
+	basic := func(username, password string) string {
+		return base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	}
+	as := []session.Attachable{&AuthProvider{}}
+	as = ReplaceDockerAuth([]build.Credential{
+		{Host: "registry.depot.dev", Token: basic("x-token", "old")},
+		{Host: "ghcr.io", Token: basic("user", "ghcr")},
+	}, as)
+	as = ReplaceDockerAuth([]build.Credential{{Host: "registry.depot.dev", Token: basic("x-token", "new")}}, as)
+
+	provider, ok := as[0].(*AuthProvider)
+	if !ok {
+		t.Fatalf("ReplaceDockerAuth() = %T, want *AuthProvider", as[0])
+	}
+	for host, want := range map[string]string{"registry.depot.dev": "new", "ghcr.io": "ghcr"} {
+		res, err := provider.Credentials(context.Background(), &auth.CredentialsRequest{Host: host})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Secret != want {
+			t.Errorf("Credentials(%s) secret = %q, want %q", host, res.Secret, want)
+		}
+	}
+}
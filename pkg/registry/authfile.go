@@ -0,0 +1,195 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/depot/cli/pkg/build"
+	buildx "github.com/docker/buildx/build"
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/docker/cli/cli/config/types"
+	"github.com/pkg/errors"
+)
+
+// dockerHubHost is the host that builders request Docker Hub credentials for.
+const dockerHubHost = "registry-1.docker.io"
+
+// LoadCredentials reads the registry credentials of the --registry-auth
+// sources.  "env:VAR" reads them from an environment variable and "file:PATH",
+// or a plain path, from a file.  Either holds a Docker config.json or a
+// Kubernetes .dockerconfigjson secret, as JSON or base64-encoded JSON.
+func LoadCredentials(sources []string) ([]build.Credential, error) {
+	var credentials []build.Credential
+	for _, source := range sources {
+		var (
+			dt  []byte
+			err error
+		)
+		if name, ok := strings.CutPrefix(source, "env:"); ok {
+			value, found := os.LookupEnv(name)
+			if !found || value == "" {
+				return nil, errors.Errorf("registry auth %s: environment variable %s is not set", source, name)
+			}
+			dt = []byte(value)
+		} else {
+			dt, err = os.ReadFile(strings.TrimPrefix(source, "file:"))
+			if err != nil {
+				return nil, errors.Wrapf(err, "registry auth %s", source)
+			}
+		}
+
+		creds, err := parseDockerConfig(dt)
+		if err != nil {
+			return nil, errors.Wrapf(err, "registry auth %s", source)
+		}
+		credentials = mergeCredentials(creds, credentials)
+	}
+	return credentials, nil
+}
+
+type dockerAuthEntry struct {
+	Auth          string `json:"auth"`
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+	IdentityToken string `json:"identitytoken"`
+}
+
+// parseDockerConfig reads the credentials of the "auths" of a Docker config,
+// or of the hosts of a legacy .dockercfg that has no "auths".  Empty "auths"
+// entries, which "docker login" writes for the credentials it keeps in a
+// credential helper, are skipped, and the credentials of the "credsStore" and
+// "credHelpers" helpers are read from the helpers.
+func parseDockerConfig(dt []byte) ([]build.Credential, error) {
+	dt = bytes.TrimSpace(dt)
+	if len(dt) > 0 && dt[0] != '{' {
+		decoded, err := base64.StdEncoding.DecodeString(string(dt))
+		if err != nil {
+			return nil, errors.New("expected a Docker config as JSON or base64-encoded JSON")
+		}
+		dt = bytes.TrimSpace(decoded)
+	}
+
+	var config struct {
+		Auths       map[string]dockerAuthEntry `json:"auths"`
+		CredsStore  string                     `json:"credsStore"`
+		CredHelpers map[string]string          `json:"credHelpers"`
+	}
+	if err := json.Unmarshal(dt, &config); err != nil {
+		return nil, errors.Wrap(err, "invalid Docker config")
+	}
+	usesHelpers := config.CredsStore != "" || len(config.CredHelpers) > 0
+	auths := config.Auths
+	if auths == nil && !usesHelpers {
+		if err := json.Unmarshal(dt, &auths); err != nil {
+			return nil, errors.Wrap(err, "invalid Docker config")
+		}
+	}
+
+	hosts := make([]string, 0, len(auths))
+	for host := range auths {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	credentials := make([]build.Credential, 0, len(auths))
+	for _, host := range hosts {
+		entry := auths[host]
+		if entry == (dockerAuthEntry{}) {
+			continue
+		}
+		credential, err := authCredential(host, types.AuthConfig{
+			Auth:          entry.Auth,
+			Username:      entry.Username,
+			Password:      entry.Password,
+			IdentityToken: entry.IdentityToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		credentials = append(credentials, credential)
+	}
+
+	if usesHelpers {
+		helperCredentials, err := readCredentialHelpers(config.CredsStore, config.CredHelpers)
+		if err != nil {
+			return nil, err
+		}
+		credentials = mergeCredentials(credentials, helperCredentials)
+	}
+	return credentials, nil
+}
+
+// readCredentialHelpers reads the credentials of the credsStore and the
+// credHelpers of a Docker config from the docker-credential helpers.
+func readCredentialHelpers(credsStore string, credHelpers map[string]string) ([]build.Credential, error) {
+	dockerConfig := configfile.New("")
+	dockerConfig.CredentialsStore = credsStore
+	dockerConfig.CredentialHelpers = credHelpers
+	auths, err := dockerConfig.GetAllCredentials()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the credentials of the credential helpers")
+	}
+
+	hosts := make([]string, 0, len(auths))
+	for host := range auths {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	credentials := make([]build.Credential, 0, len(auths))
+	for _, host := range hosts {
+		auth := auths[host]
+		if auth.Username == "" && auth.Password == "" && auth.IdentityToken == "" {
+			continue
+		}
+		credential, err := authCredential(host, auth)
+		if err != nil {
+			return nil, err
+		}
+		credentials = append(credentials, credential)
+	}
+	return credentials, nil
+}
+
+// authCredential converts the auth of a host to a credential, preferring its
+// identity token to its username and password.
+func authCredential(host string, auth types.AuthConfig) (build.Credential, error) {
+	credential := build.Credential{Host: normalizeHost(host), Token: auth.Auth, IdentityToken: auth.IdentityToken}
+	if credential.Token == "" && credential.IdentityToken == "" {
+		if auth.Username == "" || auth.Password == "" {
+			return build.Credential{}, errors.Errorf("no username and password for %s", host)
+		}
+		credential.Token = base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", auth.Username, auth.Password)))
+	}
+	return credential, nil
+}
+
+// normalizeHost converts the keys of a Docker config, such as
+// "https://index.docker.io/v1/", to the hosts builders request credentials for.
+func normalizeHost(host string) string {
+	host = strings.TrimPrefix(strings.TrimPrefix(host, "https://"), "http://")
+	host, _, _ = strings.Cut(host, "/")
+	switch host {
+	case "index.docker.io", "docker.io":
+		return dockerHubHost
+	}
+	return host
+}
+
+// WithCredentials makes the builds use the credentials for their registries
+// instead of the credentials of the Docker config.
+func WithCredentials(buildOpts map[string]buildx.Options, credentials []build.Credential) map[string]buildx.Options {
+	if len(credentials) == 0 {
+		return buildOpts
+	}
+	for target, buildOpt := range buildOpts {
+		buildOpt.Session = ReplaceDockerAuth(credentials, buildOpt.Session)
+		buildOpts[target] = buildOpt
+	}
+	return buildOpts
+}
@@ -0,0 +1,87 @@
+package registry
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/depot/cli/pkg/build"
+)
+
+func TestLoadCredentials(t *testing.T) {
+	auth := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.json")
+	config := `{"auths": {"https://index.docker.io/v1/": {"auth": "` + auth("hub:secret") + `"}, "ghcr.io": {"username": "octocat", "password": "ghp_token"}}}`
+	if err := os.WriteFile(configFile, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// A .dockerconfigjson secret mounted as an environment variable.
+	t.Setenv("REGISTRY_AUTH", base64.StdEncoding.EncodeToString([]byte(`{"auths": {"ghcr.io": {"auth": "`+auth("bot:other")+`"}}}`)))
+
+	creds, err := LoadCredentials([]string{configFile, "env:REGISTRY_AUTH"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []build.Credential{
+		{Host: "ghcr.io", Token: auth("bot:other")},
+		{Host: "registry-1.docker.io", Token: auth("hub:secret")},
+	}
+	if !reflect.DeepEqual(creds, want) {
+		t.Errorf("LoadCredentials() = %+v, want %+v", creds, want)
+	}
+
+	legacy, err := parseDockerConfig([]byte(`{"quay.io": {"auth": "` + auth("robot:pw") + `"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []build.Credential{{Host: "quay.io", Token: auth("robot:pw")}}; !reflect.DeepEqual(legacy, want) {
+		t.Errorf("parseDockerConfig() = %+v, want %+v", legacy, want)
+	}
+
+	// Entries kept by a credential helper are empty, and identity tokens
+	// replace the username and password.
+	tokens, err := parseDockerConfig([]byte(`{"auths": {"ghcr.io": {}, "myregistry.azurecr.io": {"auth": "` + auth("00000000-0000-0000-0000-000000000000:") + `", "identitytoken": "refresh"}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []build.Credential{{Host: "myregistry.azurecr.io", Token: auth("00000000-0000-0000-0000-000000000000:"), IdentityToken: "refresh"}}; !reflect.DeepEqual(tokens, want) {
+		t.Errorf("parseDockerConfig() = %+v, want %+v", tokens, want)
+	}
+
+	// A config without "auths" whose credentials are in the credsStore.
+	helper := "#!/bin/sh\n" +
+		"case \"$1\" in\n" +
+		"list) echo '{\"https://index.docker.io/v1/\": \"hub\"}' ;;\n" +
+		"get) echo '{\"ServerURL\": \"https://index.docker.io/v1/\", \"Username\": \"hub\", \"Secret\": \"stored\"}' ;;\n" +
+		"esac\n"
+	if err := os.WriteFile(filepath.Join(dir, "docker-credential-depot-test"), []byte(helper), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	stored, err := parseDockerConfig([]byte(`{"credsStore": "depot-test"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []build.Credential{{Host: "registry-1.docker.io", Token: auth("hub:stored")}}; !reflect.DeepEqual(stored, want) {
+		t.Errorf("parseDockerConfig() = %+v, want %+v", stored, want)
+	}
+
+	errorCases := map[string][]string{
+		"unset variable": {"env:DEPOT_TEST_UNSET_REGISTRY_AUTH"},
+		"missing file":   {filepath.Join(dir, "missing.json")},
+	}
+	for name, sources := range errorCases {
+		if _, err := LoadCredentials(sources); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	for _, config := range []string{"not base64!", `{"auths": {"ghcr.io": {"username": "octocat"}}}`} {
+		if _, err := parseDockerConfig([]byte(config)); err == nil {
+			t.Errorf("parseDockerConfig(%q): expected an error", config)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package registry
 
 import (
+	"strconv"
+
 	"github.com/depot/cli/pkg/build"
 	buildx "github.com/docker/buildx/build"
 	"github.com/moby/buildkit/client"
@@ -17,6 +19,35 @@ type SaveOptions struct {
 	AddTargetSuffix bool
 }
 
+// TakePushTags removes the tags of the targets that push to a registry and
+// returns them by target.  The builder then only pushes a --save build to the
+// depot registry, and the tags are pushed from the saved build by the CLI,
+// which limits and retries the uploads to each registry with a PushPolicy.
+func TakePushTags(buildOpts map[string]buildx.Options) map[string][]string {
+	tags := map[string][]string{}
+	for target, buildOpt := range buildOpts {
+		if len(buildOpt.Tags) == 0 || !pushes(buildOpt) {
+			continue
+		}
+		tags[target] = buildOpt.Tags
+		buildOpt.Tags = nil
+		buildOpts[target] = buildOpt
+	}
+	return tags
+}
+
+func pushes(buildOpt buildx.Options) bool {
+	for _, export := range buildOpt.Exports {
+		if export.Type != "image" {
+			continue
+		}
+		if push, err := strconv.ParseBool(export.Attrs["push"]); err == nil && push {
+			return true
+		}
+	}
+	return false
+}
+
 // WithDepotSave adds an output type image with a push to the depot registry.
 // If any image exports already exist, they will be updated to push to the depot registry.
 func WithDepotSave(buildOpts map[string]buildx.Options, opts SaveOptions) map[string]buildx.Options {
@@ -0,0 +1,28 @@
+package registry
+
+import (
+	"net/url"
+	"strings"
+)
+
+// NextPage returns the URL of the next page of a paginated registry response
+// from its Link header, such as
+// `</v2/library/golang/tags/list?last=1.21&n=1000>; rel="next"`, or "" on
+// the last page.  A relative link is resolved against the current URL.
+func NextPage(current, link string) (string, error) {
+	if link == "" {
+		return "", nil
+	}
+	target, _, _ := strings.Cut(link, ";")
+	target = strings.Trim(strings.TrimSpace(target), "<>")
+
+	base, err := url.Parse(current)
+	if err != nil {
+		return "", err
+	}
+	next, err := base.Parse(target)
+	if err != nil {
+		return "", err
+	}
+	return next.String(), nil
+}
@@ -0,0 +1,37 @@
+package registry
+
+import "testing"
+
+func TestNextPage(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		link    string
+		want    string
+	}{
+		{name: "last page", current: "https://registry.example.com/v2/app/tags/list?n=1000"},
+		{
+			name:    "relative",
+			current: "https://registry.example.com/v2/app/tags/list?n=1000",
+			link:    `</v2/app/tags/list?last=abc&n=1000>; rel="next"`,
+			want:    "https://registry.example.com/v2/app/tags/list?last=abc&n=1000",
+		},
+		{
+			name:    "absolute",
+			current: "https://registry.example.com/v2/app/tags/list?n=1000",
+			link:    `<https://cdn.example.com/v2/app/tags/list?last=abc>; rel="next"`,
+			want:    "https://cdn.example.com/v2/app/tags/list?last=abc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NextPage(tt.current, tt.link)
+			if err != nil {
+				t.Fatalf("NextPage() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NextPage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
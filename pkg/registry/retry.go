@@ -0,0 +1,202 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// PushPolicy limits the concurrent requests that push an image to each
+// registry and retries the requests that time out, whose connection is reset,
+// or that fail with rate limits or server errors.  When a host responds with
+// 429 Too Many Requests, every request to that host waits for its Retry-After
+// before trying again.
+type PushPolicy struct {
+	// Concurrency is the number of concurrent requests per registry host.
+	Concurrency int
+	// Attempts is the number of times a request is sent before giving up.
+	Attempts int
+	// MinBackoff and MaxBackoff bound the exponential backoff between
+	// attempts when the registry does not send Retry-After.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	client *http.Client
+	mu     sync.Mutex
+	hosts  map[string]*hostLimit
+}
+
+// hostLimit holds the slots of the requests that push to a registry and the
+// pause of the requests sent to a host.  Requests relayed by another service,
+// such as the Depot blob service, take the slots of the registry they push to
+// but are paused by the host that answered.
+type hostLimit struct {
+	slots chan struct{}
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+// The defaults of the push policy of "depot push" and --save pushes.
+const (
+	DefaultConcurrency = 4
+	DefaultAttempts    = 5
+)
+
+func NewPushPolicy(concurrency, attempts int) *PushPolicy {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if attempts < 1 {
+		attempts = 1
+	}
+	return &PushPolicy{
+		Concurrency: concurrency,
+		Attempts:    attempts,
+		MinBackoff:  time.Second,
+		MaxBackoff:  time.Minute,
+		client:      http.DefaultClient,
+		hosts:       map[string]*hostLimit{},
+	}
+}
+
+func (p *PushPolicy) host(host string) *hostLimit {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	h, ok := p.hosts[host]
+	if !ok {
+		h = &hostLimit{slots: make(chan struct{}, p.Concurrency)}
+		p.hosts[host] = h
+	}
+	return h
+}
+
+// Do sends the request made by newRequest, which is called again for every
+// attempt, and returns the first response that is not retried.  The request
+// takes a slot of the registry host.  The caller must close the body of the
+// response.
+func (p *PushPolicy) Do(ctx context.Context, host string, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	return p.do(ctx, host, p.Attempts, newRequest)
+}
+
+func (p *PushPolicy) do(ctx context.Context, host string, attempts int, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	h := p.host(host)
+	select {
+	case h.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-h.slots }()
+
+	for attempt := 1; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.host(req.URL.Host).wait(ctx); err != nil {
+			return nil, err
+		}
+
+		res, err := p.client.Do(req)
+		retry := shouldRetry(res, err)
+		delay := p.backoff(attempt)
+		if retry && res != nil {
+			if retryAfter, ok := parseRetryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+				delay = retryAfter
+			}
+			if res.StatusCode == http.StatusTooManyRequests {
+				p.host(res.Request.URL.Host).pause(delay)
+			}
+		}
+		if attempt >= attempts || !retry {
+			return res, err
+		}
+		if res != nil {
+			_ = res.Body.Close()
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// backoff doubles from MinBackoff with up to 20% jitter.
+func (p *PushPolicy) backoff(attempt int) time.Duration {
+	delay := p.MinBackoff << (attempt - 1)
+	if delay <= 0 || delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	return delay + time.Duration(rand.Int63n(int64(delay)/5+1))
+}
+
+// pause delays the next requests to the host by d.
+func (h *hostLimit) pause(d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if until := time.Now().Add(d); until.After(h.pausedUntil) {
+		h.pausedUntil = until
+	}
+}
+
+func (h *hostLimit) wait(ctx context.Context) error {
+	h.mu.Lock()
+	d := time.Until(h.pausedUntil)
+	h.mu.Unlock()
+	if d <= 0 {
+		return nil
+	}
+	select {
+	case <-time.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func shouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		return RetryableError(err)
+	}
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode/100 == 5
+}
+
+// RetryableError reports whether a request failed on a timeout or a dropped
+// connection.  Other failures, such as TLS handshakes and untrusted
+// certificates, fail the same way every time.
+func RetryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// parseRetryAfter parses the seconds or HTTP date of a Retry-After header.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,164 @@
+package registry
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestPushPolicyRetries(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch requests.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+
+	policy := NewPushPolicy(1, 3)
+	policy.MinBackoff = time.Millisecond
+	res, err := policy.Do(context.Background(), "registry.example.com", func() (*http.Request, error) {
+		return http.NewRequest(http.MethodPut, server.URL, nil)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = res.Body.Close()
+	if res.StatusCode != http.StatusCreated || requests.Load() != 3 {
+		t.Errorf("expected the third attempt to succeed, got %d after %d requests", res.StatusCode, requests.Load())
+	}
+
+	requests.Store(0)
+	policy = NewPushPolicy(1, 1)
+	res, err = policy.Do(context.Background(), "registry.example.com", func() (*http.Request, error) {
+		return http.NewRequest(http.MethodPut, server.URL, nil)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = res.Body.Close()
+	if res.StatusCode != http.StatusTooManyRequests || requests.Load() != 1 {
+		t.Errorf("expected a single attempt, got %d after %d requests", res.StatusCode, requests.Load())
+	}
+}
+
+func TestPushPolicyConcurrency(t *testing.T) {
+	var active, maxActive atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			m := maxActive.Load()
+			if n <= m || maxActive.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	policy := NewPushPolicy(2, 1)
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := policy.Do(context.Background(), "registry.example.com", func() (*http.Request, error) {
+				return http.NewRequest(http.MethodPut, server.URL, nil)
+			})
+			if err == nil {
+				_ = res.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+	if maxActive.Load() > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", maxActive.Load())
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for value, expected := range map[string]time.Duration{
+		"30":                            30 * time.Second,
+		"Sat, 01 Jun 2024 12:01:00 GMT": time.Minute,
+	} {
+		d, ok := parseRetryAfter(value, now)
+		if !ok || d != expected {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; expected %v", value, d, ok, expected)
+		}
+	}
+	if _, ok := parseRetryAfter("soon", now); ok {
+		t.Errorf("expected an invalid Retry-After to be ignored")
+	}
+}
+
+func TestPushPolicyPausesAnsweringHost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	policy := NewPushPolicy(1, 1)
+	res, err := policy.Do(context.Background(), "registry.example.com", func() (*http.Request, error) {
+		return http.NewRequest(http.MethodPost, server.URL, nil)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = res.Body.Close()
+
+	if time.Until(policy.host(strings.TrimPrefix(server.URL, "http://")).pausedUntil) <= 0 {
+		t.Errorf("expected the host that answered to be paused")
+	}
+	if !policy.host("registry.example.com").pausedUntil.IsZero() {
+		t.Errorf("expected the registry not to be paused by the host relaying its requests")
+	}
+}
+
+func TestShouldRetryErrors(t *testing.T) {
+	for _, tt := range []struct {
+		err      error
+		expected bool
+	}{
+		{&url.Error{Op: "Post", URL: "https://r", Err: syscall.ECONNRESET}, true},
+		{&url.Error{Op: "Post", URL: "https://r", Err: io.ErrUnexpectedEOF}, true},
+		{&net.OpError{Op: "dial", Err: timeoutError{}}, true},
+		{&url.Error{Op: "Post", URL: "https://r", Err: x509.UnknownAuthorityError{}}, false},
+		{&url.Error{Op: "Post", URL: "https://r", Err: tls.RecordHeaderError{Msg: "first record does not look like a TLS handshake"}}, false},
+		{context.Canceled, false},
+	} {
+		if got := shouldRetry(nil, tt.err); got != tt.expected {
+			t.Errorf("shouldRetry(%v) = %v, expected %v", tt.err, got, tt.expected)
+		}
+	}
+	for status, expected := range map[int]bool{429: true, 500: true, 503: true, 400: false, 401: false, 404: false} {
+		if got := shouldRetry(&http.Response{StatusCode: status}, nil); got != expected {
+			t.Errorf("shouldRetry(%d) = %v, expected %v", status, got, expected)
+		}
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
@@ -0,0 +1,216 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// DefaultChunkSize is the size of the chunks of a blob upload.  Registries
+// such as ECR require chunks of at least 5 MiB.
+const DefaultChunkSize = 16 << 20
+
+// BlobUpload is a blob to upload to a repository of a registry.
+type BlobUpload struct {
+	// Host is the host of the registry API, e.g. "registry-1.docker.io".
+	Host       string
+	Repository string
+	Digest     digest.Digest
+	Size       int64
+	// Authorization is the value of the Authorization header.
+	Authorization string
+	// ChunkSize is the size of the chunks, DefaultChunkSize if zero.
+	ChunkSize int64
+	// UserAgent is the value of the User-Agent header.
+	UserAgent string
+}
+
+// UploadBlob uploads the blob in chunks, reading it from open, which returns
+// the blob from the offset.  When a chunk fails, the upload resumes from the
+// offset that the registry reports, so only the rest of the blob is sent
+// again; a chunk is never retried blindly, as the registry may have stored
+// part of it.  The upload gives up after Attempts failed chunks in a row.
+func (p *PushPolicy) UploadBlob(ctx context.Context, blob BlobUpload, open func(offset int64) (io.ReadCloser, error)) error {
+	chunkSize := blob.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	start := fmt.Sprintf("https://%s/v2/%s/blobs/uploads/", blob.Host, blob.Repository)
+	res, err := p.Do(ctx, blob.Host, func() (*http.Request, error) {
+		return blob.request(ctx, http.MethodPost, start, nil, 0)
+	})
+	if err != nil {
+		return err
+	}
+	location, err := uploadLocation(res, http.StatusAccepted)
+	if err != nil {
+		return fmt.Errorf("unable to start the upload of %s: %w", blob.Digest, err)
+	}
+
+	var (
+		offset int64
+		source io.ReadCloser
+		failed int
+	)
+	defer func() {
+		if source != nil {
+			_ = source.Close()
+		}
+	}()
+	chunk := make([]byte, chunkSize)
+	for offset < blob.Size {
+		if source == nil {
+			if source, err = open(offset); err != nil {
+				return err
+			}
+		}
+		n, err := io.ReadFull(source, chunk[:min(chunkSize, blob.Size-offset)])
+		if err != nil {
+			return fmt.Errorf("unable to read %s: %w", blob.Digest, err)
+		}
+
+		res, err := p.do(ctx, blob.Host, 1, func() (*http.Request, error) {
+			req, err := blob.request(ctx, http.MethodPatch, location, chunk[:n], int64(n))
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("Content-Range", fmt.Sprintf("%d-%d", offset, offset+int64(n)-1))
+			return req, nil
+		})
+		if err == nil {
+			var next string
+			if next, err = uploadLocation(res, http.StatusAccepted); err == nil {
+				location = next
+				offset += int64(n)
+				failed = 0
+				continue
+			}
+		}
+		if !resumable(res, err) {
+			return fmt.Errorf("unable to upload %s: %w", blob.Digest, err)
+		}
+
+		failed++
+		if failed >= p.Attempts {
+			return fmt.Errorf("unable to upload %s after %d attempts: %w", blob.Digest, failed, err)
+		}
+		select {
+		case <-time.After(p.backoff(failed)):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		// Resume from what the registry has, which may be less or more than
+		// was sent before the failure.
+		resumed, next, statusErr := p.uploadStatus(ctx, blob, location)
+		if statusErr != nil {
+			return fmt.Errorf("unable to resume the upload of %s: %w (after %v)", blob.Digest, statusErr, err)
+		}
+		location = next
+		if resumed != offset+int64(n) {
+			_ = source.Close()
+			source = nil
+		}
+		offset = resumed
+	}
+
+	commit, err := url.Parse(location)
+	if err != nil {
+		return err
+	}
+	query := commit.Query()
+	query.Set("digest", blob.Digest.String())
+	commit.RawQuery = query.Encode()
+	res, err = p.Do(ctx, blob.Host, func() (*http.Request, error) {
+		return blob.request(ctx, http.MethodPut, commit.String(), nil, 0)
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := uploadLocation(res, http.StatusCreated); err != nil {
+		return fmt.Errorf("unable to finish the upload of %s: %w", blob.Digest, err)
+	}
+	return nil
+}
+
+// uploadStatus returns the offset to resume the upload from and its location.
+func (p *PushPolicy) uploadStatus(ctx context.Context, blob BlobUpload, location string) (int64, string, error) {
+	res, err := p.Do(ctx, blob.Host, func() (*http.Request, error) {
+		return blob.request(ctx, http.MethodGet, location, nil, 0)
+	})
+	if err != nil {
+		return 0, "", err
+	}
+	next, err := uploadLocation(res, http.StatusNoContent)
+	if err != nil {
+		return 0, "", err
+	}
+	return parseUploadRange(res.Header.Get("Range")), next, nil
+}
+
+// parseUploadRange returns the size that the registry has of an upload from
+// its Range header, e.g. "0-1023".
+func parseUploadRange(value string) int64 {
+	_, end, ok := strings.Cut(value, "-")
+	if !ok {
+		return 0
+	}
+	n, err := strconv.ParseInt(end, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n + 1
+}
+
+// resumable reports whether a failed chunk may be resumed.
+func resumable(res *http.Response, err error) bool {
+	if res == nil {
+		return RetryableError(err)
+	}
+	return res.StatusCode == http.StatusRequestedRangeNotSatisfiable || shouldRetry(res, nil)
+}
+
+// uploadLocation checks the status of an upload response, closes it, and
+// returns its absolute Location.
+func uploadLocation(res *http.Response, status int) (string, error) {
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode != status {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return "", fmt.Errorf("unexpected status code: %s %s", res.Status, strings.TrimSpace(string(body)))
+	}
+	location, err := res.Location()
+	if err == http.ErrNoLocation {
+		return res.Request.URL.String(), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return location.String(), nil
+}
+
+func (b BlobUpload) request(ctx context.Context, method, url string, body []byte, size int64) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = size
+	if body != nil {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+	if b.Authorization != "" {
+		req.Header.Set("Authorization", b.Authorization)
+	}
+	if b.UserAgent != "" {
+		req.Header.Set("User-Agent", b.UserAgent)
+	}
+	return req, nil
+}
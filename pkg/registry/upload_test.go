@@ -0,0 +1,113 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// uploadServer is a registry that stores uploads and drops the connection
+// of the chunks in failChunks after storing half of them.
+type uploadServer struct {
+	mu         sync.Mutex
+	data       []byte
+	patches    int
+	failChunks map[int]bool
+	committed  digest.Digest
+}
+
+func (s *uploadServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case r.Method == http.MethodPost:
+		w.Header().Set("Location", "/v2/repo/blobs/uploads/1")
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodPatch:
+		var start, end int
+		if _, err := fmt.Sscanf(r.Header.Get("Content-Range"), "%d-%d", &start, &end); err != nil || start != len(s.data) {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		s.patches++
+		if s.failChunks[s.patches] {
+			s.data = append(s.data, body[:len(body)/2]...)
+			hj, _ := w.(http.Hijacker)
+			conn, _, _ := hj.Hijack()
+			_ = conn.Close()
+			return
+		}
+		s.data = append(s.data, body...)
+		w.Header().Set("Location", "/v2/repo/blobs/uploads/1")
+		w.Header().Set("Range", fmt.Sprintf("0-%d", len(s.data)-1))
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodGet:
+		w.Header().Set("Location", "/v2/repo/blobs/uploads/1")
+		w.Header().Set("Range", fmt.Sprintf("0-%d", len(s.data)-1))
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut:
+		s.committed = digest.Digest(r.URL.Query().Get("digest"))
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+func TestUploadBlobResumes(t *testing.T) {
+	blob := bytes.Repeat([]byte("0123456789"), 10)
+	registry := &uploadServer{failChunks: map[int]bool{2: true, 3: true}}
+	server := httptest.NewTLSServer(registry)
+	defer server.Close()
+
+	policy := NewPushPolicy(1, 3)
+	policy.MinBackoff = time.Millisecond
+	policy.client = server.Client()
+
+	var opened []int64
+	open := func(offset int64) (io.ReadCloser, error) {
+		opened = append(opened, offset)
+		return io.NopCloser(bytes.NewReader(blob[offset:])), nil
+	}
+	upload := BlobUpload{
+		Host:       strings.TrimPrefix(server.URL, "https://"),
+		Repository: "repo",
+		Digest:     digest.FromBytes(blob),
+		Size:       int64(len(blob)),
+		ChunkSize:  30,
+	}
+	if err := policy.UploadBlob(context.Background(), upload, open); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(registry.data, blob) {
+		t.Errorf("uploaded %q, expected %q", registry.data, blob)
+	}
+	if registry.committed != upload.Digest {
+		t.Errorf("committed %s, expected %s", registry.committed, upload.Digest)
+	}
+	// The failed chunks are resumed from the middle of the chunk.
+	if len(opened) != 3 || opened[0] != 0 || opened[1] != 45 || opened[2] != 60 {
+		t.Errorf("expected the blob to be read again from the offsets the registry has, got %v", opened)
+	}
+
+	registry = &uploadServer{failChunks: map[int]bool{1: true, 2: true, 3: true}}
+	server.Config.Handler = registry
+	if err := policy.UploadBlob(context.Background(), upload, open); err == nil {
+		t.Errorf("expected the upload to give up after 3 failed chunks")
+	}
+}
+
+func TestParseUploadRange(t *testing.T) {
+	for value, expected := range map[string]int64{"0-1023": 1024, "bytes=0-9": 10, "": 0, "0-x": 0} {
+		if got := parseUploadRange(value); got != expected {
+			t.Errorf("parseUploadRange(%q) = %d, expected %d", value, got, expected)
+		}
+	}
+}
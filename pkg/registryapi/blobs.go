@@ -0,0 +1,95 @@
+package registryapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// GetImageManifest returns the manifest or index of the saved image of a
+// build or of one of its bake targets.
+func (c *Client) GetImageManifest(ctx context.Context, buildID, target string) (ocispecs.Descriptor, []byte, error) {
+	repo, err := c.repository(ctx, buildID)
+	if err != nil {
+		return ocispecs.Descriptor{}, nil, err
+	}
+
+	header := http.Header{"Accept": []string{manifestAccept}}
+	resp, dt, err := c.do(ctx, repo, http.MethodGet, repo.url("/manifests/%s", repo.imageTag(target)), header, nil)
+	if err != nil {
+		return ocispecs.Descriptor{}, nil, err
+	}
+	desc := ocispecs.Descriptor{
+		MediaType: resp.Header.Get("Content-Type"),
+		Digest:    digest.FromBytes(dt),
+		Size:      int64(len(dt)),
+	}
+	return desc, dt, nil
+}
+
+// GetManifest returns a manifest of a build's repository by digest, such as
+// the platform manifests of an index.
+func (c *Client) GetManifest(ctx context.Context, buildID string, dgst digest.Digest) ([]byte, error) {
+	repo, err := c.repository(ctx, buildID)
+	if err != nil {
+		return nil, err
+	}
+
+	header := http.Header{"Accept": []string{manifestAccept}}
+	_, dt, err := c.do(ctx, repo, http.MethodGet, repo.url("/manifests/%s", dgst), header, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := verify(dgst, dt); err != nil {
+		return nil, err
+	}
+	return dt, nil
+}
+
+// GetBlob reads a small blob of a build's repository such as an image
+// config or an attestation.
+func (c *Client) GetBlob(ctx context.Context, buildID string, dgst digest.Digest) ([]byte, error) {
+	repo, err := c.repository(ctx, buildID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, dt, err := c.do(ctx, repo, http.MethodGet, repo.url("/blobs/%s", dgst), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := verify(dgst, dt); err != nil {
+		return nil, err
+	}
+	return dt, nil
+}
+
+// OpenBlob streams a blob of a build's repository such as a layer.  The
+// content is not verified against the digest.
+func (c *Client) OpenBlob(ctx context.Context, buildID string, dgst digest.Digest) (io.ReadCloser, error) {
+	repo, err := c.repository(ctx, buildID)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *http.Response
+	err = c.retry(ctx, func() error {
+		resp, err = c.send(ctx, repo, http.MethodGet, repo.url("/blobs/%s", dgst), nil, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func verify(dgst digest.Digest, dt []byte) error {
+	if got := dgst.Algorithm().FromBytes(dt); got != dgst {
+		return fmt.Errorf("digest mismatch: got %s, expected %s", got, dgst)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+// Package registryapi is a client for the Depot ephemeral registry, where
+// builds run with --save store their images.
+package registryapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	remoteserrors "github.com/containerd/containerd/remotes/errors"
+	depotapi "github.com/depot/cli/pkg/api"
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+	"github.com/depot/cli/pkg/proto/depot/cli/v1/cliv1connect"
+	"github.com/depot/cli/pkg/registry"
+)
+
+const (
+	defaultMaxRetries = 4
+	defaultBackoff    = 250 * time.Millisecond
+	maxBackoff        = 5 * time.Second
+)
+
+// Client reads and changes the saved images of builds.
+type Client struct {
+	token      string
+	builds     cliv1connect.BuildServiceClient
+	httpClient *http.Client
+	maxRetries int
+	backoff    time.Duration
+
+	mu        sync.Mutex
+	pullInfos map[string]*cliv1.GetPullInfoResponse
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBuildClient sets the client of the Depot build API.
+func WithBuildClient(builds cliv1connect.BuildServiceClient) Option {
+	return func(c *Client) { c.builds = builds }
+}
+
+// WithHTTPClient sets the HTTP client used to call the registry.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithRetries sets how many times failed requests are retried and the
+// initial backoff between them, which doubles after each attempt.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+// NewClient returns a client authenticating with the Depot token.
+func NewClient(token string, opts ...Option) *Client {
+	c := &Client{
+		token:      token,
+		httpClient: http.DefaultClient,
+		maxRetries: defaultMaxRetries,
+		backoff:    defaultBackoff,
+		pullInfos:  map[string]*cliv1.GetPullInfoResponse{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.builds == nil {
+		c.builds = depotapi.NewBuildClient()
+	}
+	return c
+}
+
+// GetPullInfo returns the image reference and registry credentials of a saved build.
+func (c *Client) GetPullInfo(ctx context.Context, buildID string) (*cliv1.GetPullInfoResponse, error) {
+	c.mu.Lock()
+	info, ok := c.pullInfos[buildID]
+	c.mu.Unlock()
+	if ok {
+		return info, nil
+	}
+
+	err := c.retry(ctx, func() error {
+		req := &cliv1.GetPullInfoRequest{BuildId: buildID}
+		res, err := c.builds.GetPullInfo(ctx, depotapi.WithAuthentication(connect.NewRequest(req), c.token))
+		if err != nil {
+			return err
+		}
+		info = res.Msg
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.pullInfos[buildID] = info
+	c.mu.Unlock()
+	return info, nil
+}
+
+// retry calls fn until it succeeds, fails with an error that is not
+// transient, or the retries are exhausted.
+func (c *Client) retry(ctx context.Context, fn func() error) error {
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= c.maxRetries || !retryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// retryable reports whether a request may succeed if it is sent again.
+// Authorization failures, such as a 401 or 403 from the registry or its
+// token server, fail the same way every time.
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+	var unexpectedStatus remoteserrors.ErrUnexpectedStatus
+	if errors.As(err, &unexpectedStatus) {
+		return unexpectedStatus.StatusCode == http.StatusTooManyRequests || unexpectedStatus.StatusCode >= 500
+	}
+
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		switch connectErr.Code() {
+		case connect.CodeUnavailable, connect.CodeResourceExhausted, connect.CodeAborted, connect.CodeInternal, connect.CodeUnknown:
+			return true
+		}
+		return false
+	}
+
+	return registry.RetryableError(err)
+}
@@ -0,0 +1,217 @@
+package registryapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/containerd/containerd/remotes/docker"
+	remoteserrors "github.com/containerd/containerd/remotes/errors"
+	cliv1 "github.com/depot/cli/pkg/proto/depot/cli/v1"
+	"github.com/depot/cli/pkg/proto/depot/cli/v1/cliv1connect"
+	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// mockServer serves the build API and an ephemeral registry with the
+// manifests of a build.
+type mockServer struct {
+	cliv1connect.UnimplementedBuildServiceHandler
+
+	host string
+
+	mu             sync.Mutex
+	pullInfoErrors int
+	tagsErrors     int
+	manifests      map[string][]byte
+	deleted        []string
+}
+
+func newMockServer(t *testing.T) (*mockServer, cliv1connect.BuildServiceClient) {
+	m := &mockServer{manifests: map[string][]byte{}}
+
+	mux := http.NewServeMux()
+	mux.Handle(cliv1connect.NewBuildServiceHandler(m))
+	mux.HandleFunc("/v2/", m.registry)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	m.host = strings.TrimPrefix(server.URL, "http://")
+	return m, cliv1connect.NewBuildServiceClient(server.Client(), server.URL)
+}
+
+func (m *mockServer) GetPullInfo(_ context.Context, req *connect.Request[cliv1.GetPullInfoRequest]) (*connect.Response[cliv1.GetPullInfoResponse], error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if req.Header().Get("Authorization") != "Bearer depot-token" {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid token"))
+	}
+	if m.pullInfoErrors > 0 {
+		m.pullInfoErrors--
+		return nil, connect.NewError(connect.CodeUnavailable, errors.New("try again"))
+	}
+	return connect.NewResponse(&cliv1.GetPullInfoResponse{
+		Reference: fmt.Sprintf("%s/project:%s", m.host, req.Msg.BuildId),
+		Username:  "x-token",
+		Password:  "registry-password",
+	}), nil
+}
+
+func (m *mockServer) registry(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if user, password, ok := r.BasicAuth(); !ok || user != "x-token" || password != "registry-password" {
+		w.Header().Set("WWW-Authenticate", `Basic realm="depot"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v2/project/")
+	if path == "tags/list" {
+		if m.tagsErrors > 0 {
+			m.tagsErrors--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		tags := []string{}
+		for tag := range m.manifests {
+			if !strings.HasPrefix(tag, "sha256:") {
+				tags = append(tags, fmt.Sprintf("%q", tag))
+			}
+		}
+		fmt.Fprintf(w, `{"name":"project","tags":[%s]}`, strings.Join(tags, ","))
+		return
+	}
+
+	ref, ok := strings.CutPrefix(path, "manifests/")
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodHead, http.MethodGet:
+		manifest, ok := m.manifests[ref]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", ocispecs.MediaTypeImageManifest)
+		w.Header().Set("Docker-Content-Digest", digest.FromBytes(manifest).String())
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(manifest)
+		}
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		m.manifests[ref] = body
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodDelete:
+		m.deleted = append(m.deleted, ref)
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func TestClient(t *testing.T) {
+	m, builds := newMockServer(t)
+	m.manifests["build1"] = []byte(`{"schemaVersion":2}`)
+	m.manifests["build1-api"] = []byte(`{"schemaVersion":2,"api":true}`)
+	m.manifests["build2"] = []byte(`{"schemaVersion":2,"other":true}`)
+	m.pullInfoErrors = 1
+	m.tagsErrors = 1
+
+	ctx := context.Background()
+	client := NewClient("depot-token", WithBuildClient(builds), WithRetries(2, time.Millisecond))
+
+	info, err := client.GetPullInfo(ctx, "build1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := m.host + "/project:build1"; info.Reference != want {
+		t.Errorf("Reference = %s, want %s", info.Reference, want)
+	}
+
+	imgs, err := client.ListImages(ctx, "build1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(imgs) != 2 || imgs[0].Tag != "build1" || imgs[1].Target != "api" {
+		t.Fatalf("ListImages() = %+v", imgs)
+	}
+	if imgs[1].Digest != digest.FromBytes(m.manifests["build1-api"]) {
+		t.Errorf("Digest = %s", imgs[1].Digest)
+	}
+
+	if err := client.TagImage(ctx, "build1", "api", "release"); err != nil {
+		t.Fatal(err)
+	}
+	if string(m.manifests["release"]) != string(m.manifests["build1-api"]) {
+		t.Errorf("tagged manifest = %s", m.manifests["release"])
+	}
+
+	if err := client.DeleteImage(ctx, "build1", ""); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.deleted) != 1 || m.deleted[0] != digest.FromBytes(m.manifests["build1"]).String() {
+		t.Errorf("deleted = %v", m.deleted)
+	}
+
+	var statusErr *StatusError
+	if err := client.DeleteImage(ctx, "build1", "missing"); !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("DeleteImage(missing) = %v, want not found", err)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	m, builds := newMockServer(t)
+	m.pullInfoErrors = 3
+
+	client := NewClient("depot-token", WithBuildClient(builds), WithRetries(1, time.Millisecond))
+	if _, err := client.GetPullInfo(context.Background(), "build1"); connect.CodeOf(err) != connect.CodeUnavailable {
+		t.Errorf("GetPullInfo() = %v, want unavailable", err)
+	}
+	if m.pullInfoErrors != 1 {
+		t.Errorf("expected two attempts, %d errors left", m.pullInfoErrors)
+	}
+
+	client = NewClient("wrong-token", WithBuildClient(builds), WithRetries(3, time.Millisecond))
+	if _, err := client.GetPullInfo(context.Background(), "build1"); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("GetPullInfo() = %v, want unauthenticated", err)
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "unavailable", err: &StatusError{StatusCode: http.StatusServiceUnavailable}, want: true},
+		{name: "rate limited", err: &StatusError{StatusCode: http.StatusTooManyRequests}, want: true},
+		{name: "unauthorized", err: &StatusError{StatusCode: http.StatusUnauthorized}, want: false},
+		{name: "forbidden", err: &StatusError{StatusCode: http.StatusForbidden}, want: false},
+		{name: "token server forbidden", err: fmt.Errorf("failed to fetch token: %w", remoteserrors.ErrUnexpectedStatus{StatusCode: http.StatusForbidden}), want: false},
+		{name: "token server unavailable", err: remoteserrors.ErrUnexpectedStatus{StatusCode: http.StatusBadGateway}, want: true},
+		{name: "invalid authorization", err: fmt.Errorf("no scope: %w", docker.ErrInvalidAuthorization), want: false},
+		{name: "unauthenticated", err: connect.NewError(connect.CodeUnauthenticated, errors.New("bad token")), want: false},
+		{name: "connection reset", err: fmt.Errorf("read: %w", io.ErrUnexpectedEOF), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryable(tt.err); got != tt.want {
+				t.Errorf("retryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package registryapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/remotes/docker"
+	depotapi "github.com/depot/cli/pkg/api"
+	"github.com/depot/cli/pkg/registry"
+	"github.com/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// manifestAccept are the manifest media types requested from the registry.
+var manifestAccept = strings.Join([]string{
+	ocispecs.MediaTypeImageIndex,
+	ocispecs.MediaTypeImageManifest,
+	images.MediaTypeDockerSchema2ManifestList,
+	images.MediaTypeDockerSchema2Manifest,
+}, ", ")
+
+// Image is a saved image of a build.
+type Image struct {
+	// Reference is the full reference of the image, e.g. "registry.depot.dev/project:build-target".
+	Reference string `json:"reference"`
+	Tag       string `json:"tag"`
+	// Target is the bake target of the image, if any.
+	Target    string        `json:"target,omitempty"`
+	Digest    digest.Digest `json:"digest"`
+	MediaType string        `json:"mediaType"`
+}
+
+// StatusError is an unexpected response from the registry.
+type StatusError struct {
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("unexpected status: %s", e.Status)
+	}
+	return fmt.Sprintf("unexpected status: %s %s", e.Status, e.Body)
+}
+
+// repository is the registry repository of a build's saved images.
+type repository struct {
+	host       docker.RegistryHost
+	authorizer docker.Authorizer
+	name       reference.Named
+	buildTag   string
+}
+
+func (c *Client) repository(ctx context.Context, buildID string) (*repository, error) {
+	info, err := c.GetPullInfo(ctx, buildID)
+	if err != nil {
+		return nil, err
+	}
+
+	named, err := reference.ParseNormalizedNamed(info.Reference)
+	if err != nil {
+		return nil, err
+	}
+	tag := buildID
+	if tagged, ok := named.(reference.Tagged); ok {
+		tag = tagged.Tag()
+	}
+
+	authorizer := docker.NewDockerAuthorizer(
+		docker.WithAuthClient(c.httpClient),
+		docker.WithAuthCreds(func(string) (string, string, error) {
+			return info.Username, info.Password, nil
+		}),
+	)
+	hosts, err := docker.ConfigureDefaultRegistries(
+		docker.WithAuthorizer(authorizer),
+		docker.WithClient(c.httpClient),
+		docker.WithPlainHTTP(docker.MatchLocalhost),
+	)(reference.Domain(named))
+	if err != nil {
+		return nil, err
+	}
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("no registry host for %s", info.Reference)
+	}
+
+	return &repository{
+		host:       hosts[len(hosts)-1],
+		authorizer: authorizer,
+		name:       reference.TrimNamed(named),
+		buildTag:   tag,
+	}, nil
+}
+
+func (r *repository) url(format string, args ...interface{}) string {
+	return fmt.Sprintf("%s://%s%s/%s", r.host.Scheme, r.host.Host, r.host.Path, reference.Path(r.name)) + fmt.Sprintf(format, args...)
+}
+
+// imageTag is the tag of a build's image or of one of its bake targets.
+func (r *repository) imageTag(target string) string {
+	if target == "" {
+		return r.buildTag
+	}
+	return r.buildTag + "-" + target
+}
+
+// do makes a registry request, authorizing after an unauthorized response and
+// retrying transient failures.  The body of successful responses is returned.
+func (c *Client) do(ctx context.Context, repo *repository, method, url string, header http.Header, body []byte) (*http.Response, []byte, error) {
+	var (
+		resp *http.Response
+		dt   []byte
+	)
+	err := c.retry(ctx, func() error {
+		var err error
+		resp, err = c.send(ctx, repo, method, url, header, body)
+		if err != nil {
+			return err
+		}
+		dt, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, dt, nil
+}
+
+// send makes a single authorized registry request.  The caller must close
+// the body of the successful response it returns.
+func (c *Client) send(ctx context.Context, repo *repository, method, url string, header http.Header, body []byte) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		req.Header.Set("User-Agent", depotapi.Agent())
+		if err := repo.authorizer.Authorize(ctx, req); err != nil {
+			return nil, err
+		}
+
+		resp, err := repo.host.Client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode/100 == 2 {
+			return resp, nil
+		}
+
+		dt, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusUnauthorized && attempt == 0 {
+			if err := repo.authorizer.AddResponses(ctx, []*http.Response{resp}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		return nil, &StatusError{StatusCode: resp.StatusCode, Status: resp.Status, Body: strings.TrimSpace(string(dt))}
+	}
+}
+
+// ListImages returns the saved images of a build, one per bake target.
+func (c *Client) ListImages(ctx context.Context, buildID string) ([]Image, error) {
+	repo, err := c.repository(ctx, buildID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	for url := repo.url("/tags/list?n=1000"); url != ""; {
+		resp, dt, err := c.do(ctx, repo, http.MethodGet, url, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		var list struct {
+			Tags []string `json:"tags"`
+		}
+		if err := json.Unmarshal(dt, &list); err != nil {
+			return nil, fmt.Errorf("invalid tags list: %w", err)
+		}
+		tags = append(tags, list.Tags...)
+		url, err = registry.NextPage(url, resp.Header.Get("Link"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(tags)
+
+	var imgs []Image
+	for _, tag := range tags {
+		target, ok := strings.CutPrefix(tag, repo.buildTag)
+		if !ok || (target != "" && !strings.HasPrefix(target, "-")) {
+			continue
+		}
+		target = strings.TrimPrefix(target, "-")
+
+		desc, err := c.resolve(ctx, repo, tag)
+		if err != nil {
+			return nil, err
+		}
+		imgs = append(imgs, Image{
+			Reference: repo.name.String() + ":" + tag,
+			Tag:       tag,
+			Target:    target,
+			Digest:    desc.Digest,
+			MediaType: desc.MediaType,
+		})
+	}
+	return imgs, nil
+}
+
+// resolve returns the manifest descriptor of a tag.
+func (c *Client) resolve(ctx context.Context, repo *repository, tag string) (ocispecs.Descriptor, error) {
+	header := http.Header{"Accept": []string{manifestAccept}}
+	resp, _, err := c.do(ctx, repo, http.MethodHead, repo.url("/manifests/%s", tag), header, nil)
+	if err != nil {
+		return ocispecs.Descriptor{}, err
+	}
+	dgst, err := digest.Parse(resp.Header.Get("Docker-Content-Digest"))
+	if err != nil {
+		return ocispecs.Descriptor{}, fmt.Errorf("invalid digest of %s: %w", tag, err)
+	}
+	return ocispecs.Descriptor{Digest: dgst, MediaType: resp.Header.Get("Content-Type")}, nil
+}
+
+// DeleteImage deletes the saved image of a build or of one of its bake targets.
+func (c *Client) DeleteImage(ctx context.Context, buildID, target string) error {
+	repo, err := c.repository(ctx, buildID)
+	if err != nil {
+		return err
+	}
+	desc, err := c.resolve(ctx, repo, repo.imageTag(target))
+	if err != nil {
+		return err
+	}
+	_, _, err = c.do(ctx, repo, http.MethodDelete, repo.url("/manifests/%s", desc.Digest), nil, nil)
+	return err
+}
+
+// TagImage adds a tag in the build's repository to the saved image of a
+// build or of one of its bake targets.
+func (c *Client) TagImage(ctx context.Context, buildID, target, tag string) error {
+	repo, err := c.repository(ctx, buildID)
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{"Accept": []string{manifestAccept}}
+	resp, manifest, err := c.do(ctx, repo, http.MethodGet, repo.url("/manifests/%s", repo.imageTag(target)), header, nil)
+	if err != nil {
+		return err
+	}
+
+	header = http.Header{"Content-Type": []string{resp.Header.Get("Content-Type")}}
+	_, _, err = c.do(ctx, repo, http.MethodPut, repo.url("/manifests/%s", tag), header, manifest)
+	return err
+}
@@ -0,0 +1,357 @@
+package release
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/distribution/reference"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is a release file describing which bake targets to build and which
+// manifests to update with the pushed image digests.
+type Config struct {
+	// Files are the bake files; the default bake files are used if empty.
+	Files []string `yaml:"files"`
+	// Targets are the bake targets or groups to build.
+	Targets []string `yaml:"targets"`
+	// Set are target overrides in the format of `depot bake --set`.
+	Set []string `yaml:"set"`
+	// Manifests are updated with the digests of the pushed images.
+	Manifests []Manifest `yaml:"manifests"`
+}
+
+// Manifest is a YAML file updated after the release images are pushed.
+//
+// Without images every reference to a release image in the file is pinned
+// to its digest, which suits plain Kubernetes manifests.  With images the
+// values at the given paths are set, which suits Helm values and kustomize.
+type Manifest struct {
+	Path   string          `yaml:"path"`
+	Images []ManifestImage `yaml:"images"`
+}
+
+// ManifestImage sets values of a manifest to the image of a target.  Paths
+// are dot separated keys with numeric list indexes, e.g. "images.0.digest".
+type ManifestImage struct {
+	Target     string `yaml:"target"`
+	Ref        string `yaml:"ref"`        // Set to repository:tag@digest.
+	Repository string `yaml:"repository"` // Set to the repository.
+	Tag        string `yaml:"tag"`        // Set to the tag.
+	Digest     string `yaml:"digest"`     // Set to the digest.
+}
+
+// Image is an image pushed for a release target.
+type Image struct {
+	Target     string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// Ref returns the image reference pinned to the digest.
+func (i Image) Ref() string {
+	if i.Tag == "" {
+		return i.Repository + "@" + i.Digest
+	}
+	return i.Repository + ":" + i.Tag + "@" + i.Digest
+}
+
+// ReadConfig reads and validates a release file.
+func ReadConfig(path string) (*Config, error) {
+	dt, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(dt, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid release file %s: %w", path, err)
+	}
+
+	for _, manifest := range cfg.Manifests {
+		if manifest.Path == "" {
+			return nil, fmt.Errorf("invalid release file %s: manifest path is required", path)
+		}
+		for _, image := range manifest.Images {
+			if image.Target == "" {
+				return nil, fmt.Errorf("invalid release file %s: image target is required for manifest %s", path, manifest.Path)
+			}
+		}
+	}
+
+	return &cfg, nil
+}
+
+// NewImages returns an image for every tag a target was pushed with.
+func NewImages(target, names, digest string) ([]Image, error) {
+	var images []Image
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		named, err := reference.ParseNormalizedNamed(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid image name %q for target %s: %w", name, target, err)
+		}
+
+		image := Image{Target: target, Repository: reference.FamiliarName(named), Digest: digest}
+		if tagged, ok := named.(reference.Tagged); ok {
+			image.Tag = tagged.Tag()
+		}
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+// Patch updates the manifest file with the pushed images.
+func Patch(manifest Manifest, images []Image) error {
+	dt, err := os.ReadFile(manifest.Path)
+	if err != nil {
+		return err
+	}
+
+	var patched []byte
+	if len(manifest.Images) == 0 {
+		patched, err = pinReferences(dt, images)
+	} else {
+		patched, err = setValues(dt, manifest.Images, images)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to update %s: %w", manifest.Path, err)
+	}
+
+	if bytes.Equal(dt, patched) {
+		return nil
+	}
+
+	info, err := os.Stat(manifest.Path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(manifest.Path, patched, info.Mode())
+}
+
+// pinReferences pins the image fields of the manifest, the values of
+// "image" keys such as those of Kubernetes containers and compose services,
+// that refer to a release image repository with any tag or digest to the
+// first pushed tag and the digest.  Only those values are replaced, so the
+// rest of the file is kept as it is.
+func pinReferences(dt []byte, images []Image) ([]byte, error) {
+	refs := map[string]string{}
+	for _, image := range images {
+		named, err := reference.ParseNormalizedNamed(image.Repository)
+		if err != nil {
+			continue
+		}
+		if _, ok := refs[named.Name()]; !ok {
+			refs[named.Name()] = image.Ref()
+		}
+	}
+
+	var edits []edit
+	dec := yaml.NewDecoder(bytes.NewReader(dt))
+	for {
+		var doc yaml.Node
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		edits = imageFields(&doc, refs, edits)
+	}
+	return applyEdits(dt, edits)
+}
+
+// edit replaces the scalar at a line and column of a YAML file.
+type edit struct {
+	line, column int
+	style        yaml.Style
+	old, new     string
+}
+
+// imageFields appends the edits of the image fields below the node that
+// refer to the repositories of refs.
+func imageFields(node *yaml.Node, refs map[string]string, edits []edit) []edit {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value != "image" || value.Kind != yaml.ScalarNode {
+				edits = imageFields(value, refs, edits)
+				continue
+			}
+			named, err := reference.ParseNormalizedNamed(value.Value)
+			if err != nil {
+				continue
+			}
+			if ref, ok := refs[named.Name()]; ok && value.Value != ref {
+				edits = append(edits, edit{line: value.Line, column: value.Column, style: value.Style, old: value.Value, new: ref})
+			}
+		}
+		return edits
+	}
+	for _, child := range node.Content {
+		edits = imageFields(child, refs, edits)
+	}
+	return edits
+}
+
+// applyEdits replaces the scalars of the edits in the file.
+func applyEdits(dt []byte, edits []edit) ([]byte, error) {
+	type replacement struct {
+		offset   int
+		old, new string
+	}
+	lines := bytes.SplitAfter(dt, []byte("\n"))
+	replacements := make([]replacement, 0, len(edits))
+	for _, e := range edits {
+		if e.line < 1 || e.line > len(lines) {
+			return nil, fmt.Errorf("unable to find image %s at line %d", e.old, e.line)
+		}
+		offset := 0
+		for _, line := range lines[:e.line-1] {
+			offset += len(line)
+		}
+		// Columns count characters rather than bytes.
+		line := lines[e.line-1]
+		column := 0
+		for n := 1; n < e.column && column < len(line); n++ {
+			_, size := utf8.DecodeRune(line[column:])
+			column += size
+		}
+		offset += column
+		if e.style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+			offset++
+		}
+		if !bytes.HasPrefix(dt[offset:], []byte(e.old)) {
+			return nil, fmt.Errorf("unable to find image %s at line %d", e.old, e.line)
+		}
+		replacements = append(replacements, replacement{offset: offset, old: e.old, new: e.new})
+	}
+	sort.Slice(replacements, func(i, j int) bool { return replacements[i].offset < replacements[j].offset })
+
+	var buf bytes.Buffer
+	last := 0
+	for _, r := range replacements {
+		buf.Write(dt[last:r.offset])
+		buf.WriteString(r.new)
+		last = r.offset + len(r.old)
+	}
+	buf.Write(dt[last:])
+	return buf.Bytes(), nil
+}
+
+func setValues(dt []byte, manifestImages []ManifestImage, images []Image) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(dt, &doc); err != nil {
+		return nil, err
+	}
+
+	// The target that set each path, as a path can only hold one value.
+	targets := map[string]string{}
+	for _, manifestImage := range manifestImages {
+		image, ok := findImage(images, manifestImage.Target)
+		if !ok {
+			return nil, fmt.Errorf("target %s did not push an image", manifestImage.Target)
+		}
+
+		values := []struct{ path, value string }{
+			{manifestImage.Ref, image.Ref()},
+			{manifestImage.Repository, image.Repository},
+			{manifestImage.Tag, image.Tag},
+			{manifestImage.Digest, image.Digest},
+		}
+		for _, v := range values {
+			if v.path == "" {
+				continue
+			}
+			if target, ok := targets[v.path]; ok {
+				return nil, fmt.Errorf("%s is set more than once, for targets %s and %s", v.path, target, manifestImage.Target)
+			}
+			targets[v.path] = manifestImage.Target
+			if err := setValue(&doc, v.path, v.value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func findImage(images []Image, target string) (Image, bool) {
+	for _, image := range images {
+		if image.Target == target {
+			return image, true
+		}
+	}
+	return Image{}, false
+}
+
+// setValue sets the scalar at the dot separated path, creating missing mapping keys.
+func setValue(doc *yaml.Node, path, value string) error {
+	node := doc
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			node.Content = []*yaml.Node{{Kind: yaml.MappingNode}}
+		}
+		node = node.Content[0]
+	}
+
+	keys := strings.Split(path, ".")
+	for i, key := range keys {
+		last := i == len(keys)-1
+
+		switch node.Kind {
+		case yaml.SequenceNode:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(node.Content) {
+				return fmt.Errorf("invalid list index %q in %s", key, path)
+			}
+			node = node.Content[idx]
+		case yaml.MappingNode:
+			var child *yaml.Node
+			for j := 0; j+1 < len(node.Content); j += 2 {
+				if node.Content[j].Value == key {
+					child = node.Content[j+1]
+					break
+				}
+			}
+			if child == nil {
+				child = &yaml.Node{Kind: yaml.MappingNode}
+				if last {
+					child = &yaml.Node{Kind: yaml.ScalarNode}
+				}
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, child)
+			}
+			node = child
+		default:
+			return fmt.Errorf("%s is not a map or list at %q", path, key)
+		}
+	}
+
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("%s is not a scalar value", path)
+	}
+	node.Value = value
+	node.Tag = "!!str"
+	node.Style = 0
+	return nil
+}
@@ -0,0 +1,127 @@
+package release
+
+import (
+	"strings"
+	"testing"
+)
+
+const testDigest = "sha256:6839c1808eab334a9b0f400f119773a0a7d494631c083aef6d3447e3798b544f"
+
+func TestPinReferences(t *testing.T) {
+	images := []Image{
+		{Target: "api", Repository: "ghcr.io/org/api", Tag: "v2", Digest: testDigest},
+		{Target: "api", Repository: "ghcr.io/org/api", Tag: "latest", Digest: testDigest},
+		{Target: "cache", Repository: "redis", Tag: "7", Digest: testDigest},
+	}
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "containers",
+			in: `containers:
+  - image: ghcr.io/org/api:v1
+  - image: "ghcr.io/org/api"
+  - image: ghcr.io/org/api-worker:v1
+`,
+			want: `containers:
+  - image: ghcr.io/org/api:v2@` + testDigest + `
+  - image: "ghcr.io/org/api:v2@` + testDigest + `"
+  - image: ghcr.io/org/api-worker:v1
+`,
+		},
+		{
+			name: "short names only match image fields",
+			in: `# redis: the cache
+metadata:
+  name: redis
+spec:
+  containers:
+    - name: redis
+      image: docker.io/library/redis:6
+      command: [redis, --port, "6379"]
+      env:
+        - name: HOST
+          value: redis
+    - name: sidecar
+      image: 'org/redis:1'
+`,
+			want: `# redis: the cache
+metadata:
+  name: redis
+spec:
+  containers:
+    - name: redis
+      image: redis:7@` + testDigest + `
+      command: [redis, --port, "6379"]
+      env:
+        - name: HOST
+          value: redis
+    - name: sidecar
+      image: 'org/redis:1'
+`,
+		},
+		{
+			name: "documents",
+			in: `kind: Deployment
+image: ghcr.io/org/api@` + testDigest + `
+---
+kind: Job # übersicht
+spec: {image: "ghcr.io/org/api:v1", name: api}
+`,
+			want: `kind: Deployment
+image: ghcr.io/org/api:v2@` + testDigest + `
+---
+kind: Job # übersicht
+spec: {image: "ghcr.io/org/api:v2@` + testDigest + `", name: api}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pinReferences([]byte(tt.in), images)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("pinReferences() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := pinReferences([]byte("image: [unclosed"), images); err == nil {
+		t.Error("expected invalid YAML to fail")
+	}
+}
+
+func TestSetValues(t *testing.T) {
+	images := []Image{{Target: "api", Repository: "ghcr.io/org/api", Tag: "1.0", Digest: testDigest}}
+	in := `# Helm values
+image:
+  repository: ghcr.io/org/api
+  tag: latest
+replicas: 2
+`
+	got, err := setValues([]byte(in), []ManifestImage{{Target: "api", Tag: "image.tag", Digest: "image.digest"}}, images)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"# Helm values", `tag: "1.0"`, "digest: " + testDigest, "replicas: 2"} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("setValues() = %s, missing %q", got, want)
+		}
+	}
+
+	if _, err := setValues([]byte(in), []ManifestImage{{Target: "web", Tag: "image.tag"}}, images); err == nil {
+		t.Error("expected an error for a target without an image")
+	}
+	if _, err := setValues([]byte(in), []ManifestImage{{Target: "api", Tag: "image.tag", Digest: "image.tag"}}, images); err == nil {
+		t.Error("expected an error for a path set twice by an image")
+	}
+	twoImages := append(images, Image{Target: "web", Repository: "ghcr.io/org/web", Tag: "1.0", Digest: testDigest})
+	if _, err := setValues([]byte(in), []ManifestImage{{Target: "api", Tag: "image.tag"}, {Target: "web", Tag: "image.tag"}}, twoImages); err == nil {
+		t.Error("expected an error for a path set by two images")
+	}
+}
@@ -145,40 +145,42 @@ func decodeSBOMReferences(encodedSBOMs string) ([]sbomReference, error) {
 	return sboms, err
 }
 
-// downloadSBOM downloads the SBOM and also writes it to the output file.
-func downloadSBOM(ctx context.Context, sbom sbomOutput) error {
-	client, err := sbom.driver.Client(ctx)
-	if err != nil {
-		return err
-	}
+// Attestation is the raw in-toto SBOM statement generated for a platform.
+type Attestation struct {
+	Platform  string
+	Statement []byte
+}
 
-	contentClient := client.ContentClient()
-	r, err := contentClient.Read(ctx, &contentv1.ReadContentRequest{Digest: digest.Digest(sbom.sbom.Digest)})
+// Attestations reads the SBOM in-toto statements of a node response from
+// the builder's content store.
+func Attestations(ctx context.Context, nodeRes depotbuild.DepotNodeResponse) ([]Attestation, error) {
+	sboms, err := decodeNodeResponses(nodeRes)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Preallocate 1MB for the buffer. This is a guess at the size of the SBOM.
-	inner := make([]byte, 0, 1024*1024)
-	buf := bytes.NewBuffer(inner)
-
-	for {
-		resp, err := r.Recv()
-		if err != nil {
-			if errors.Is(err, io.EOF) {
-				break
-			}
-			return err
-		}
-		_, err = buf.Write(resp.Data)
+	attestations := make([]Attestation, 0, len(sboms))
+	for _, sbom := range sboms {
+		statement, err := ReadContent(ctx, nodeRes.Node.Driver, digest.Digest(sbom.Digest))
 		if err != nil {
-			return err
+			return nil, err
 		}
+		attestations = append(attestations, Attestation{Platform: sbom.Platform, Statement: statement})
+	}
+
+	return attestations, nil
+}
+
+// downloadSBOM downloads the SBOM and also writes it to the output file.
+func downloadSBOM(ctx context.Context, sbom sbomOutput) error {
+	buf, err := ReadContent(ctx, sbom.driver, digest.Digest(sbom.sbom.Digest))
+	if err != nil {
+		return err
 	}
 
 	// Strip the in-toto statement header and save the SBOM predicate.
 	var statement Statement
-	err = json.Unmarshal(buf.Bytes(), &statement)
+	err = json.Unmarshal(buf, &statement)
 	if err != nil {
 		return err
 	}
@@ -201,6 +203,40 @@ func downloadSBOM(ctx context.Context, sbom sbomOutput) error {
 	return output.Close()
 }
 
+// ReadContent reads a blob from the content store of the builder.
+func ReadContent(ctx context.Context, d driver.Driver, dgst digest.Digest) ([]byte, error) {
+	client, err := d.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contentClient := client.ContentClient()
+	r, err := contentClient.Read(ctx, &contentv1.ReadContentRequest{Digest: dgst})
+	if err != nil {
+		return nil, err
+	}
+
+	// Preallocate 1MB for the buffer. This is a guess at the size of the SBOM.
+	inner := make([]byte, 0, 1024*1024)
+	buf := bytes.NewBuffer(inner)
+
+	for {
+		resp, err := r.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		_, err = buf.Write(resp.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
 // Statement copied from in-toto-golang/in_toto but using json.RawMessage
 // to avoid unmarshalling and allocating the subject and predicate.
 type Statement struct {